@@ -21,25 +21,38 @@ import (
 	"github.com/nats-io/jwt/v2"
 	"github.com/nats-io/nkeys"
 	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/aws-source/sources/apigateway"
+	"github.com/overmindtech/aws-source/sources/apigatewayv2"
 	"github.com/overmindtech/aws-source/sources/autoscaling"
+	"github.com/overmindtech/aws-source/sources/backup"
+	"github.com/overmindtech/aws-source/sources/cloudformation"
 	"github.com/overmindtech/aws-source/sources/cloudfront"
 	"github.com/overmindtech/aws-source/sources/cloudwatch"
 	"github.com/overmindtech/aws-source/sources/directconnect"
 	"github.com/overmindtech/aws-source/sources/dynamodb"
 	"github.com/overmindtech/aws-source/sources/ec2"
+	"github.com/overmindtech/aws-source/sources/ecr"
 	"github.com/overmindtech/aws-source/sources/ecs"
 	"github.com/overmindtech/aws-source/sources/efs"
 	"github.com/overmindtech/aws-source/sources/eks"
+	"github.com/overmindtech/aws-source/sources/elasticache"
 	"github.com/overmindtech/aws-source/sources/elb"
 	"github.com/overmindtech/aws-source/sources/elbv2"
+	"github.com/overmindtech/aws-source/sources/events"
+	"github.com/overmindtech/aws-source/sources/firehose"
 	"github.com/overmindtech/aws-source/sources/iam"
+	"github.com/overmindtech/aws-source/sources/kinesis"
 	"github.com/overmindtech/aws-source/sources/lambda"
+	"github.com/overmindtech/aws-source/sources/memorydb"
 	"github.com/overmindtech/aws-source/sources/networkfirewall"
 	"github.com/overmindtech/aws-source/sources/networkmanager"
 	"github.com/overmindtech/aws-source/sources/rds"
 	"github.com/overmindtech/aws-source/sources/route53"
 	"github.com/overmindtech/aws-source/sources/s3"
+	"github.com/overmindtech/aws-source/sources/servicediscovery"
+	"github.com/overmindtech/aws-source/sources/sfn"
 	"github.com/overmindtech/aws-source/sources/sqs"
+	"github.com/overmindtech/aws-source/sources/wafv2"
 	"github.com/overmindtech/aws-source/tracing"
 	"github.com/overmindtech/discovery"
 	"github.com/overmindtech/sdp-go/auth"
@@ -78,6 +91,8 @@ var rootCmd = &cobra.Command{
 		natsJWT := viper.GetString("nats-jwt")
 		natsNKeySeed := viper.GetString("nats-nkey-seed")
 		maxParallel := viper.GetInt("max-parallel")
+		s3MaxParallel := viper.GetInt("s3-max-parallel")
+		eksKubernetes := viper.GetBool("eks-kubernetes")
 		apiKey := viper.GetString("api-key")
 		apiPath := viper.GetString("api-path")
 		healthCheckPort := viper.GetInt("health-check-port")
@@ -111,6 +126,8 @@ var rootCmd = &cobra.Command{
 			"nats-jwt":            natsJWT,
 			"nats-nkey-seed":      natsNKeySeedLog,
 			"max-parallel":        maxParallel,
+			"s3-max-parallel":     s3MaxParallel,
+			"eks-kubernetes":      eksKubernetes,
 			"aws-regions":         regions,
 			"aws-access-strategy": awsAuthConfig.Strategy,
 			"aws-external-id":     awsAuthConfig.ExternalID,
@@ -151,7 +168,7 @@ var rootCmd = &cobra.Command{
 			TokenClient:       tokenClient,
 		}
 
-		e, err := InitializeAwsSourceEngine(natsOptions, awsAuthConfig, maxParallel)
+		e, err := InitializeAwsSourceEngine(natsOptions, awsAuthConfig, maxParallel, s3MaxParallel, eksKubernetes)
 		if err != nil {
 			log.WithError(err).Error("Could not initialize aws source")
 			return
@@ -267,6 +284,8 @@ func init() {
 	rootCmd.PersistentFlags().String("aws-regions", "", "Comma-separated list of AWS regions that this source should operate in")
 	rootCmd.PersistentFlags().BoolP("auto-config", "a", false, "Use the local AWS config, the same as the AWS CLI could use. This can be set up with \"aws configure\"")
 	rootCmd.PersistentFlags().IntP("health-check-port", "", 8080, "The port that the health check should run on")
+	rootCmd.PersistentFlags().Bool("eks-kubernetes", false, "Read Services, Ingresses, ServiceAccounts and PersistentVolumes from inside EKS clusters to link them to AWS resources. The source's IAM principal must be granted read access in each cluster")
+	rootCmd.PersistentFlags().Int("s3-max-parallel", s3.DefaultMaxParallel, "Max number of S3 requests to run in parallel across all buckets. Each bucket requires around 20 requests")
 
 	// tracing
 	rootCmd.PersistentFlags().String("honeycomb-api-key", "", "If specified, configures opentelemetry libraries to submit traces to honeycomb")
@@ -496,7 +515,7 @@ func (t TerminationLogHook) Fire(e *log.Entry) error {
 	return err
 }
 
-func InitializeAwsSourceEngine(natsOptions auth.NATSOptions, awsAuthConfig AwsAuthConfig, maxParallel int) (*discovery.Engine, error) {
+func InitializeAwsSourceEngine(natsOptions auth.NATSOptions, awsAuthConfig AwsAuthConfig, maxParallel int, s3MaxParallel int, eksKubernetes bool) (*discovery.Engine, error) {
 	e, err := discovery.NewEngine()
 	if err != nil {
 		return nil, fmt.Errorf("error initializing Engine: %w", err)
@@ -596,7 +615,9 @@ func InitializeAwsSourceEngine(natsOptions auth.NATSOptions, awsAuthConfig AwsAu
 			ec2.NewAddressSource(cfg, *callerID.Account, &ec2RateLimit),
 			ec2.NewCapacityReservationFleetSource(cfg, *callerID.Account, &ec2RateLimit),
 			ec2.NewCapacityReservationSource(cfg, *callerID.Account, &ec2RateLimit),
+			ec2.NewCustomerGatewaySource(cfg, *callerID.Account, &ec2RateLimit),
 			ec2.NewEgressOnlyInternetGatewaySource(cfg, *callerID.Account, &ec2RateLimit),
+			ec2.NewHostSource(cfg, *callerID.Account, &ec2RateLimit),
 			ec2.NewIamInstanceProfileAssociationSource(cfg, *callerID.Account, &ec2RateLimit),
 			ec2.NewImageSource(cfg, *callerID.Account, &ec2RateLimit),
 			ec2.NewInstanceEventWindowSource(cfg, *callerID.Account, &ec2RateLimit),
@@ -606,6 +627,7 @@ func InitializeAwsSourceEngine(natsOptions auth.NATSOptions, awsAuthConfig AwsAu
 			ec2.NewKeyPairSource(cfg, *callerID.Account, &ec2RateLimit),
 			ec2.NewLaunchTemplateSource(cfg, *callerID.Account, &ec2RateLimit),
 			ec2.NewLaunchTemplateVersionSource(cfg, *callerID.Account, &ec2RateLimit),
+			ec2.NewManagedPrefixListSource(cfg, *callerID.Account, &ec2RateLimit),
 			ec2.NewNatGatewaySource(cfg, *callerID.Account, &ec2RateLimit),
 			ec2.NewNetworkAclSource(cfg, *callerID.Account, &ec2RateLimit),
 			ec2.NewNetworkInterfacePermissionSource(cfg, *callerID.Account, &ec2RateLimit),
@@ -618,8 +640,12 @@ func InitializeAwsSourceEngine(natsOptions auth.NATSOptions, awsAuthConfig AwsAu
 			ec2.NewSubnetSource(cfg, *callerID.Account, &ec2RateLimit),
 			ec2.NewVolumeSource(cfg, *callerID.Account, &ec2RateLimit),
 			ec2.NewVolumeStatusSource(cfg, *callerID.Account, &ec2RateLimit),
+			ec2.NewVpcEndpointServiceSource(cfg, *callerID.Account, &ec2RateLimit),
+			ec2.NewVpcEndpointSource(cfg, *callerID.Account, &ec2RateLimit),
 			ec2.NewVpcPeeringConnectionSource(cfg, *callerID.Account, &ec2RateLimit),
 			ec2.NewVpcSource(cfg, *callerID.Account, &ec2RateLimit),
+			ec2.NewVpnConnectionSource(cfg, *callerID.Account, &ec2RateLimit),
+			ec2.NewVpnGatewaySource(cfg, *callerID.Account, &ec2RateLimit),
 
 			// EFS (I'm assuming it shares its rate limit with EC2))
 			efs.NewAccessPointSource(cfg, *callerID.Account, &ec2RateLimit),
@@ -629,10 +655,13 @@ func InitializeAwsSourceEngine(natsOptions auth.NATSOptions, awsAuthConfig AwsAu
 			efs.NewReplicationConfigurationSource(cfg, *callerID.Account, &ec2RateLimit),
 
 			// EKS
+			eks.NewAccessEntrySource(cfg, *callerID.Account, region),
 			eks.NewAddonSource(cfg, *callerID.Account, region),
-			eks.NewClusterSource(cfg, *callerID.Account, region),
+			eks.NewClusterSource(cfg, *callerID.Account, region, eksKubernetes),
 			eks.NewFargateProfileSource(cfg, *callerID.Account, region),
+			eks.NewIdentityProviderConfigSource(cfg, *callerID.Account, region),
 			eks.NewNodegroupSource(cfg, *callerID.Account, region),
+			eks.NewPodIdentityAssociationSource(cfg, *callerID.Account, region),
 
 			// Route 53
 			route53.NewHealthCheckSource(cfg, *callerID.Account, region),
@@ -645,22 +674,35 @@ func InitializeAwsSourceEngine(natsOptions auth.NATSOptions, awsAuthConfig AwsAu
 			// IAM
 			iam.NewGroupSource(cfg, *callerID.Account, region, &iamRateLimit),
 			iam.NewInstanceProfileSource(cfg, *callerID.Account, region, &iamRateLimit),
+			iam.NewOIDCProviderSource(cfg, *callerID.Account, region, &iamRateLimit),
 			iam.NewPolicySource(cfg, *callerID.Account, region, &iamRateLimit),
 			iam.NewRoleSource(cfg, *callerID.Account, region, &iamRateLimit),
+			iam.NewSAMLProviderSource(cfg, *callerID.Account, region, &iamRateLimit),
 			iam.NewUserSource(cfg, *callerID.Account, region, &iamRateLimit),
 
 			// Lambda
 			lambda.NewFunctionSource(cfg, *callerID.Account, region),
 			lambda.NewLayerSource(cfg, *callerID.Account, region),
 			lambda.NewLayerVersionSource(cfg, *callerID.Account, region),
+			lambda.NewEventSourceMappingSource(cfg, *callerID.Account, region),
+			lambda.NewAliasSource(cfg, *callerID.Account, region),
+			lambda.NewFunctionVersionSource(cfg, *callerID.Account, region),
+			lambda.NewProvisionedConcurrencyConfigSource(cfg, *callerID.Account, region),
 
 			// ECS
+			ecs.NewAccountSettingSource(cfg, *callerID.Account),
+			ecs.NewAttributeSource(cfg, *callerID.Account),
 			ecs.NewCapacityProviderSource(cfg, *callerID.Account),
 			ecs.NewClusterSource(cfg, *callerID.Account, region),
 			ecs.NewContainerInstanceSource(cfg, *callerID.Account, region),
 			ecs.NewServiceSource(cfg, *callerID.Account, region),
 			ecs.NewTaskDefinitionSource(cfg, *callerID.Account, region),
 			ecs.NewTaskSource(cfg, *callerID.Account, region),
+			ecs.NewTaskSetSource(cfg, *callerID.Account),
+
+			// ECR
+			ecr.NewRepositorySource(cfg, *callerID.Account, region),
+			ecr.NewImageSource(cfg, *callerID.Account, region),
 
 			// DynamoDB
 			dynamodb.NewBackupSource(cfg, *callerID.Account, region),
@@ -674,8 +716,40 @@ func InitializeAwsSourceEngine(natsOptions auth.NATSOptions, awsAuthConfig AwsAu
 			rds.NewDBSubnetGroupSource(cfg, *callerID.Account),
 			rds.NewOptionGroupSource(cfg, *callerID.Account),
 
+			// ElastiCache
+			elasticache.NewCacheClusterSource(cfg, *callerID.Account),
+			elasticache.NewParameterGroupSource(cfg, *callerID.Account, region),
+			elasticache.NewReplicationGroupSource(cfg, *callerID.Account),
+			elasticache.NewSubnetGroupSource(cfg, *callerID.Account),
+
+			// MemoryDB
+			memorydb.NewClusterSource(cfg, *callerID.Account),
+
+			// Step Functions
+			sfn.NewActivitySource(cfg, *callerID.Account, region),
+			sfn.NewStateMachineSource(cfg, *callerID.Account, region),
+
+			// Cloud Map
+			servicediscovery.NewNamespaceSource(cfg, *callerID.Account, region),
+			servicediscovery.NewServiceSource(cfg, *callerID.Account, region),
+			servicediscovery.NewInstanceSource(cfg, *callerID.Account, region),
+
+			// CloudFormation
+			cloudformation.NewStackSource(cfg, *callerID.Account, region),
+			cloudformation.NewStackResourceSource(cfg, *callerID.Account, region),
+			cloudformation.NewStackSetSource(cfg, *callerID.Account, region),
+
+			// S3
+			s3.NewAccessPointSource(cfg, *callerID.Account, region),
+			s3.NewObjectLambdaAccessPointSource(cfg, *callerID.Account, region),
+
 			// Autoscaling
 			autoscaling.NewAutoScalingGroupSource(cfg, *callerID.Account, &autoScalingRateLimit),
+			autoscaling.NewLaunchConfigurationSource(cfg, *callerID.Account, &autoScalingRateLimit),
+			autoscaling.NewLifecycleHookSource(cfg, *callerID.Account, &autoScalingRateLimit),
+			autoscaling.NewPolicySource(cfg, *callerID.Account, &autoScalingRateLimit),
+			autoscaling.NewScheduledActionSource(cfg, *callerID.Account, &autoScalingRateLimit),
+			autoscaling.NewWarmPoolSource(cfg, *callerID.Account, &autoScalingRateLimit),
 
 			// ELB
 			elb.NewInstanceHealthSource(cfg, *callerID.Account),
@@ -723,6 +797,48 @@ func InitializeAwsSourceEngine(natsOptions auth.NATSOptions, awsAuthConfig AwsAu
 			sns.NewPlatformApplicationSource(cfg, *callerID.Account, region),
 			sns.NewEndpointSource(cfg, *callerID.Account, region),
 			sns.NewDataProtectionPolicySource(cfg, *callerID.Account, region),
+
+			// EventBridge
+			events.NewEventBusSource(cfg, *callerID.Account, region),
+			events.NewRuleSource(cfg, *callerID.Account, region),
+			events.NewArchiveSource(cfg, *callerID.Account, region),
+
+			// Kinesis
+			kinesis.NewStreamSource(cfg, *callerID.Account, region),
+			firehose.NewDeliveryStreamSource(cfg, *callerID.Account, region),
+
+			// WAF
+			wafv2.NewWebACLSource(cfg, *callerID.Account, region),
+			wafv2.NewRuleGroupSource(cfg, *callerID.Account, region),
+			wafv2.NewIPSetSource(cfg, *callerID.Account, region),
+			wafv2.NewRegexPatternSetSource(cfg, *callerID.Account, region),
+
+			// Backup
+			backup.NewBackupVaultSource(cfg, *callerID.Account, region),
+			backup.NewBackupPlanSource(cfg, *callerID.Account, region),
+			backup.NewBackupSelectionSource(cfg, *callerID.Account, region),
+			backup.NewRecoveryPointSource(cfg, *callerID.Account, region),
+
+			// API Gateway
+			apigateway.NewRestAPISource(cfg, *callerID.Account, region),
+			apigateway.NewResourceSource(cfg, *callerID.Account, region),
+			apigateway.NewStageSource(cfg, *callerID.Account, region),
+			apigateway.NewDeploymentSource(cfg, *callerID.Account, region),
+			apigateway.NewAuthorizerSource(cfg, *callerID.Account, region),
+			apigateway.NewDomainNameSource(cfg, *callerID.Account, region),
+			apigateway.NewBasePathMappingSource(cfg, *callerID.Account, region),
+			apigateway.NewVpcLinkSource(cfg, *callerID.Account, region),
+
+			// API Gateway v2
+			apigatewayv2.NewAPISource(cfg, *callerID.Account, region),
+			apigatewayv2.NewRouteSource(cfg, *callerID.Account, region),
+			apigatewayv2.NewIntegrationSource(cfg, *callerID.Account, region),
+			apigatewayv2.NewStageSource(cfg, *callerID.Account, region),
+			apigatewayv2.NewDeploymentSource(cfg, *callerID.Account, region),
+			apigatewayv2.NewAuthorizerSource(cfg, *callerID.Account, region),
+			apigatewayv2.NewDomainNameSource(cfg, *callerID.Account, region),
+			apigatewayv2.NewAPIMappingSource(cfg, *callerID.Account, region),
+			apigatewayv2.NewVpcLinkSource(cfg, *callerID.Account, region),
 		}
 
 		e.AddSources(sources...)
@@ -745,8 +861,15 @@ func InitializeAwsSourceEngine(natsOptions auth.NATSOptions, awsAuthConfig AwsAu
 				cloudfront.NewRealtimeLogConfigsSource(cfg, *callerID.Account),
 				cloudfront.NewStreamingDistributionSource(cfg, *callerID.Account),
 
+				// WAF resources for CloudFront
+				wafv2.NewCloudFrontWebACLSource(cfg, *callerID.Account),
+				wafv2.NewCloudFrontRuleGroupSource(cfg, *callerID.Account),
+				wafv2.NewCloudFrontIPSetSource(cfg, *callerID.Account),
+				wafv2.NewCloudFrontRegexPatternSetSource(cfg, *callerID.Account),
+
 				// S3
-				s3.NewS3Source(cfg, *callerID.Account),
+				s3.NewS3Source(cfg, *callerID.Account, s3MaxParallel),
+				s3.NewMultiRegionAccessPointSource(cfg, *callerID.Account),
 			)
 			globalDone = true
 		}
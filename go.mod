@@ -5,31 +5,46 @@ go 1.22.0
 // Direct dependencies
 require (
 	github.com/MrAlias/otel-schema-utils v0.2.1-alpha
-	github.com/aws/aws-sdk-go-v2 v1.25.3
+	github.com/aws/aws-sdk-go-v2 v1.29.0
 	github.com/aws/aws-sdk-go-v2/config v1.27.7
 	github.com/aws/aws-sdk-go-v2/credentials v1.17.7
+	github.com/aws/aws-sdk-go-v2/service/apigateway v1.23.4
+	github.com/aws/aws-sdk-go-v2/service/apigatewayv2 v1.20.2
 	github.com/aws/aws-sdk-go-v2/service/autoscaling v1.40.3
+	github.com/aws/aws-sdk-go-v2/service/backup v1.34.0
+	github.com/aws/aws-sdk-go-v2/service/cloudformation v1.50.0
 	github.com/aws/aws-sdk-go-v2/service/cloudfront v1.35.2
 	github.com/aws/aws-sdk-go-v2/service/cloudwatch v1.36.2
 	github.com/aws/aws-sdk-go-v2/service/directconnect v1.24.2
 	github.com/aws/aws-sdk-go-v2/service/dynamodb v1.30.4
 	github.com/aws/aws-sdk-go-v2/service/ec2 v1.150.0
+	github.com/aws/aws-sdk-go-v2/service/ecr v1.27.2
 	github.com/aws/aws-sdk-go-v2/service/ecs v1.41.2
 	github.com/aws/aws-sdk-go-v2/service/efs v1.28.2
 	github.com/aws/aws-sdk-go-v2/service/eks v1.41.1
+	github.com/aws/aws-sdk-go-v2/service/elasticache v1.37.2
 	github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing v1.24.2
 	github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2 v1.30.2
+	github.com/aws/aws-sdk-go-v2/service/eventbridge v1.30.2
+	github.com/aws/aws-sdk-go-v2/service/firehose v1.28.2
 	github.com/aws/aws-sdk-go-v2/service/iam v1.31.2
+	github.com/aws/aws-sdk-go-v2/service/kinesis v1.27.2
 	github.com/aws/aws-sdk-go-v2/service/lambda v1.53.2
+	github.com/aws/aws-sdk-go-v2/service/memorydb v1.20.0
 	github.com/aws/aws-sdk-go-v2/service/networkfirewall v1.38.2
 	github.com/aws/aws-sdk-go-v2/service/networkmanager v1.25.2
 	github.com/aws/aws-sdk-go-v2/service/rds v1.75.1
+	github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi v1.21.2
 	github.com/aws/aws-sdk-go-v2/service/route53 v1.40.2
 	github.com/aws/aws-sdk-go-v2/service/s3 v1.51.4
+	github.com/aws/aws-sdk-go-v2/service/s3control v1.45.0
+	github.com/aws/aws-sdk-go-v2/service/servicediscovery v1.30.0
+	github.com/aws/aws-sdk-go-v2/service/sfn v1.28.0
 	github.com/aws/aws-sdk-go-v2/service/sns v1.29.2
 	github.com/aws/aws-sdk-go-v2/service/sqs v1.31.2
 	github.com/aws/aws-sdk-go-v2/service/sts v1.28.4
-	github.com/aws/smithy-go v1.20.1
+	github.com/aws/aws-sdk-go-v2/service/wafv2 v1.48.0
+	github.com/aws/smithy-go v1.20.2
 	github.com/getsentry/sentry-go v0.27.0
 	github.com/iancoleman/strcase v0.2.0
 	github.com/nats-io/jwt/v2 v2.5.5
@@ -50,6 +65,7 @@ require (
 	go.opentelemetry.io/otel/sdk v1.24.0
 	go.opentelemetry.io/otel/trace v1.24.0
 	go.uber.org/automaxprocs v1.5.3
+	golang.org/x/sync v0.6.0
 	google.golang.org/protobuf v1.33.0
 )
 
@@ -61,15 +77,15 @@ require (
 	github.com/aws/aws-sdk-go v1.50.15 // indirect
 	github.com/aws/aws-sdk-go-v2/aws/protocol/eventstream v1.6.1 // indirect
 	github.com/aws/aws-sdk-go-v2/feature/ec2/imds v1.15.3 // indirect
-	github.com/aws/aws-sdk-go-v2/internal/configsources v1.3.3 // indirect
-	github.com/aws/aws-sdk-go-v2/internal/endpoints/v2 v2.6.3 // indirect
+	github.com/aws/aws-sdk-go-v2/internal/configsources v1.3.11 // indirect
+	github.com/aws/aws-sdk-go-v2/internal/endpoints/v2 v2.6.11 // indirect
 	github.com/aws/aws-sdk-go-v2/internal/ini v1.8.0 // indirect
 	github.com/aws/aws-sdk-go-v2/internal/v4a v1.3.3 // indirect
 	github.com/aws/aws-sdk-go-v2/service/internal/accept-encoding v1.11.1 // indirect
 	github.com/aws/aws-sdk-go-v2/service/internal/checksum v1.3.5 // indirect
 	github.com/aws/aws-sdk-go-v2/service/internal/endpoint-discovery v1.9.4 // indirect
 	github.com/aws/aws-sdk-go-v2/service/internal/presigned-url v1.11.5 // indirect
-	github.com/aws/aws-sdk-go-v2/service/internal/s3shared v1.17.3 // indirect
+	github.com/aws/aws-sdk-go-v2/service/internal/s3shared v1.17.11 // indirect
 	github.com/aws/aws-sdk-go-v2/service/sso v1.20.2 // indirect
 	github.com/aws/aws-sdk-go-v2/service/ssooidc v1.23.2 // indirect
 	github.com/cenkalti/backoff/v4 v4.2.1 // indirect
@@ -105,7 +121,6 @@ require (
 	golang.org/x/exp v0.0.0-20231206192017-f3f8817b8deb // indirect
 	golang.org/x/net v0.22.0 // indirect
 	golang.org/x/oauth2 v0.18.0 // indirect
-	golang.org/x/sync v0.6.0 // indirect
 	golang.org/x/sys v0.18.0 // indirect
 	golang.org/x/text v0.14.0 // indirect
 	google.golang.org/appengine v1.6.8 // indirect
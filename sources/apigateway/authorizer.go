@@ -0,0 +1,209 @@
+package apigateway
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+type AuthorizerDetails struct {
+	Authorizer *types.Authorizer
+
+	// The ID of the REST API that the authorizer belongs to
+	RestApiId string
+}
+
+func authorizerGetFunc(ctx context.Context, client APIGatewayClient, scope, query string) (*AuthorizerDetails, error) {
+	restAPIID, authorizerID, err := parseChildQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := client.GetAuthorizer(ctx, &apigateway.GetAuthorizerInput{
+		RestApiId:    &restAPIID,
+		AuthorizerId: &authorizerID,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthorizerDetails{
+		Authorizer: &types.Authorizer{
+			AuthType:                     out.AuthType,
+			AuthorizerCredentials:        out.AuthorizerCredentials,
+			AuthorizerResultTtlInSeconds: out.AuthorizerResultTtlInSeconds,
+			AuthorizerUri:                out.AuthorizerUri,
+			Id:                           out.Id,
+			IdentitySource:               out.IdentitySource,
+			IdentityValidationExpression: out.IdentityValidationExpression,
+			Name:                         out.Name,
+			ProviderARNs:                 out.ProviderARNs,
+			Type:                         out.Type,
+		},
+		RestApiId: restAPIID,
+	}, nil
+}
+
+// listAuthorizers Lists all authorizers in a given REST API
+func listAuthorizers(ctx context.Context, client APIGatewayClient, restAPIID string) ([]*AuthorizerDetails, error) {
+	authorizers := make([]*AuthorizerDetails, 0)
+	input := apigateway.GetAuthorizersInput{
+		RestApiId: &restAPIID,
+	}
+
+	for {
+		out, err := client.GetAuthorizers(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range out.Items {
+			authorizers = append(authorizers, &AuthorizerDetails{
+				Authorizer: &out.Items[i],
+				RestApiId:  restAPIID,
+			})
+		}
+
+		if out.Position == nil || len(out.Items) == 0 {
+			break
+		}
+
+		input.Position = out.Position
+	}
+
+	return authorizers, nil
+}
+
+func authorizerListFunc(ctx context.Context, client APIGatewayClient, scope string) ([]*AuthorizerDetails, error) {
+	apis, err := listRestAPIs(ctx, client)
+
+	if err != nil {
+		return nil, err
+	}
+
+	authorizers := make([]*AuthorizerDetails, 0)
+
+	for _, api := range apis {
+		if api.Id == nil {
+			continue
+		}
+
+		apiAuthorizers, err := listAuthorizers(ctx, client, *api.Id)
+
+		if err != nil {
+			return nil, err
+		}
+
+		authorizers = append(authorizers, apiAuthorizers...)
+	}
+
+	return authorizers, nil
+}
+
+// authorizerSearchFunc Searches for authorizers by the ID or ARN of the REST
+// API that they belong to
+func authorizerSearchFunc(ctx context.Context, client APIGatewayClient, scope, query string) ([]*AuthorizerDetails, error) {
+	restAPIID, err := restAPIIDFromQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return listAuthorizers(ctx, client, restAPIID)
+}
+
+func authorizerItemMapper(scope string, awsItem *AuthorizerDetails) (*sdp.Item, error) {
+	enrichedAuthorizer := struct {
+		*types.Authorizer
+		RestApiId string
+	}{
+		Authorizer: awsItem.Authorizer,
+		RestApiId:  awsItem.RestApiId,
+	}
+
+	attributes, err := sources.ToAttributesCase(enrichedAuthorizer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if awsItem.Authorizer.Id != nil {
+		err = attributes.Set("uniqueName", awsItem.RestApiId+"/"+*awsItem.Authorizer.Id)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	item := sdp.Item{
+		Type:            "apigateway-authorizer",
+		UniqueAttribute: "uniqueName",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	// +overmind:link apigateway-rest-api
+	item.LinkedItemQueries = append(item.LinkedItemQueries, restAPILink(scope, awsItem.RestApiId))
+
+	if link := lambdaURILink(awsItem.Authorizer.AuthorizerUri); link != nil {
+		// +overmind:link lambda-function
+		item.LinkedItemQueries = append(item.LinkedItemQueries, link)
+	}
+
+	if link := roleLink(awsItem.Authorizer.AuthorizerCredentials); link != nil {
+		// +overmind:link iam-role
+		item.LinkedItemQueries = append(item.LinkedItemQueries, link)
+	}
+
+	for _, providerARN := range awsItem.Authorizer.ProviderARNs {
+		if a, err := sources.ParseARN(providerARN); err == nil {
+			// +overmind:link cognito-idp-user-pool
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "cognito-idp-user-pool",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  providerARN,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the user pool will affect who is authorized
+					In: true,
+					// Changing the authorizer won't affect the user pool
+					Out: false,
+				},
+			})
+		}
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type apigateway-authorizer
+// +overmind:descriptiveType API Gateway Authorizer
+// +overmind:get Get an authorizer by {restApiId}/{authorizerId}
+// +overmind:list List all authorizers in all REST APIs
+// +overmind:search Search for authorizers by REST API ID or ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_api_gateway_authorizer.rest_api_id
+// +overmind:terraform:method SEARCH
+
+func NewAuthorizerSource(config aws.Config, accountID string, region string) *sources.GetListSource[*AuthorizerDetails, APIGatewayClient, *apigateway.Options] {
+	return &sources.GetListSource[*AuthorizerDetails, APIGatewayClient, *apigateway.Options]{
+		ItemType:   "apigateway-authorizer",
+		Client:     apigateway.NewFromConfig(config),
+		AccountID:  accountID,
+		Region:     region,
+		GetFunc:    authorizerGetFunc,
+		ListFunc:   authorizerListFunc,
+		SearchFunc: authorizerSearchFunc,
+		ItemMapper: authorizerItemMapper,
+	}
+}
@@ -0,0 +1,97 @@
+package apigateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testAPIGatewayClient) GetAuthorizer(ctx context.Context, params *apigateway.GetAuthorizerInput, optFns ...func(*apigateway.Options)) (*apigateway.GetAuthorizerOutput, error) {
+	return &apigateway.GetAuthorizerOutput{
+		Id:                    params.AuthorizerId,
+		Name:                  sources.PtrString("token"),
+		Type:                  types.AuthorizerTypeToken,
+		AuthorizerUri:         sources.PtrString("arn:aws:apigateway:eu-west-2:lambda:path/2015-03-31/functions/arn:aws:lambda:eu-west-2:052392120703:function:authorize/invocations"), // link
+		AuthorizerCredentials: sources.PtrString("arn:aws:iam::052392120703:role/apigateway-authorizer"),                                                                               // link
+		IdentitySource:        sources.PtrString("method.request.header.Authorization"),
+		ProviderARNs: []string{
+			"arn:aws:cognito-idp:eu-west-2:052392120703:userpool/eu-west-2_abc123", // link
+		},
+	}, nil
+}
+
+func (c testAPIGatewayClient) GetAuthorizers(ctx context.Context, params *apigateway.GetAuthorizersInput, optFns ...func(*apigateway.Options)) (*apigateway.GetAuthorizersOutput, error) {
+	return &apigateway.GetAuthorizersOutput{
+		Items: []types.Authorizer{
+			{
+				Id:   sources.PtrString("auth01"),
+				Name: sources.PtrString("token"),
+			},
+		},
+	}, nil
+}
+
+func TestAuthorizerItemMapper(t *testing.T) {
+	authorizer, err := authorizerGetFunc(context.Background(), testAPIGatewayClient{}, "052392120703.eu-west-2", "abc123/auth01")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := authorizerItemMapper("052392120703.eu-west-2", authorizer)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "apigateway-rest-api",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "abc123",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "lambda-function",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:lambda:eu-west-2:052392120703:function:authorize",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "iam-role",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:iam::052392120703:role/apigateway-authorizer",
+			ExpectedScope:  "052392120703",
+		},
+		{
+			ExpectedType:   "cognito-idp-user-pool",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:cognito-idp:eu-west-2:052392120703:userpool/eu-west-2_abc123",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestNewAuthorizerSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewAuthorizerSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -0,0 +1,200 @@
+package apigateway
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+type BasePathMappingDetails struct {
+	BasePathMapping *types.BasePathMapping
+
+	// The custom domain name that the mapping belongs to
+	DomainName string
+}
+
+func basePathMappingGetFunc(ctx context.Context, client APIGatewayClient, scope, query string) (*BasePathMappingDetails, error) {
+	domainName, basePath, err := parseChildQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := client.GetBasePathMapping(ctx, &apigateway.GetBasePathMappingInput{
+		DomainName: &domainName,
+		BasePath:   &basePath,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &BasePathMappingDetails{
+		BasePathMapping: &types.BasePathMapping{
+			BasePath:  out.BasePath,
+			RestApiId: out.RestApiId,
+			Stage:     out.Stage,
+		},
+		DomainName: domainName,
+	}, nil
+}
+
+// listBasePathMappings Lists all base path mappings for a given domain name
+func listBasePathMappings(ctx context.Context, client APIGatewayClient, domainName string) ([]*BasePathMappingDetails, error) {
+	mappings := make([]*BasePathMappingDetails, 0)
+	input := apigateway.GetBasePathMappingsInput{
+		DomainName: &domainName,
+	}
+
+	for {
+		out, err := client.GetBasePathMappings(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range out.Items {
+			mappings = append(mappings, &BasePathMappingDetails{
+				BasePathMapping: &out.Items[i],
+				DomainName:      domainName,
+			})
+		}
+
+		if out.Position == nil || len(out.Items) == 0 {
+			break
+		}
+
+		input.Position = out.Position
+	}
+
+	return mappings, nil
+}
+
+func basePathMappingListFunc(ctx context.Context, client APIGatewayClient, scope string) ([]*BasePathMappingDetails, error) {
+	domainNames, err := listDomainNames(ctx, client)
+
+	if err != nil {
+		return nil, err
+	}
+
+	mappings := make([]*BasePathMappingDetails, 0)
+
+	for _, domainName := range domainNames {
+		if domainName.DomainName == nil {
+			continue
+		}
+
+		domainMappings, err := listBasePathMappings(ctx, client, *domainName.DomainName)
+
+		if err != nil {
+			return nil, err
+		}
+
+		mappings = append(mappings, domainMappings...)
+	}
+
+	return mappings, nil
+}
+
+// basePathMappingSearchFunc Searches for base path mappings by the custom
+// domain name that they belong to
+func basePathMappingSearchFunc(ctx context.Context, client APIGatewayClient, scope, query string) ([]*BasePathMappingDetails, error) {
+	return listBasePathMappings(ctx, client, query)
+}
+
+func basePathMappingItemMapper(scope string, awsItem *BasePathMappingDetails) (*sdp.Item, error) {
+	enrichedMapping := struct {
+		*types.BasePathMapping
+		DomainName string
+	}{
+		BasePathMapping: awsItem.BasePathMapping,
+		DomainName:      awsItem.DomainName,
+	}
+
+	attributes, err := sources.ToAttributesCase(enrichedMapping)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if awsItem.BasePathMapping.BasePath != nil {
+		err = attributes.Set("uniqueName", awsItem.DomainName+"/"+*awsItem.BasePathMapping.BasePath)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	item := sdp.Item{
+		Type:            "apigateway-base-path-mapping",
+		UniqueAttribute: "uniqueName",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	// +overmind:link apigateway-domain-name
+	item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+		Query: &sdp.Query{
+			Type:   "apigateway-domain-name",
+			Method: sdp.QueryMethod_GET,
+			Query:  awsItem.DomainName,
+			Scope:  scope,
+		},
+		BlastPropagation: &sdp.BlastPropagation{
+			// The mapping is part of the domain
+			In:  true,
+			Out: true,
+		},
+	})
+
+	if awsItem.BasePathMapping.RestApiId != nil {
+		// +overmind:link apigateway-rest-api
+		item.LinkedItemQueries = append(item.LinkedItemQueries, restAPILink(scope, *awsItem.BasePathMapping.RestApiId))
+
+		if awsItem.BasePathMapping.Stage != nil {
+			// +overmind:link apigateway-stage
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "apigateway-stage",
+					Method: sdp.QueryMethod_GET,
+					Query:  *awsItem.BasePathMapping.RestApiId + "/" + *awsItem.BasePathMapping.Stage,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Requests to the base path are served by the stage
+					In:  true,
+					Out: true,
+				},
+			})
+		}
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type apigateway-base-path-mapping
+// +overmind:descriptiveType API Gateway Base Path Mapping
+// +overmind:get Get a base path mapping by {domainName}/{basePath}
+// +overmind:list List all base path mappings for all custom domain names
+// +overmind:search Search for base path mappings by custom domain name
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_api_gateway_base_path_mapping.domain_name
+// +overmind:terraform:method SEARCH
+
+func NewBasePathMappingSource(config aws.Config, accountID string, region string) *sources.GetListSource[*BasePathMappingDetails, APIGatewayClient, *apigateway.Options] {
+	return &sources.GetListSource[*BasePathMappingDetails, APIGatewayClient, *apigateway.Options]{
+		ItemType:   "apigateway-base-path-mapping",
+		Client:     apigateway.NewFromConfig(config),
+		AccountID:  accountID,
+		Region:     region,
+		GetFunc:    basePathMappingGetFunc,
+		ListFunc:   basePathMappingListFunc,
+		SearchFunc: basePathMappingSearchFunc,
+		ItemMapper: basePathMappingItemMapper,
+	}
+}
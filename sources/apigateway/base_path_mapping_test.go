@@ -0,0 +1,90 @@
+package apigateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testAPIGatewayClient) GetBasePathMapping(ctx context.Context, params *apigateway.GetBasePathMappingInput, optFns ...func(*apigateway.Options)) (*apigateway.GetBasePathMappingOutput, error) {
+	return &apigateway.GetBasePathMappingOutput{
+		BasePath:  params.BasePath,
+		RestApiId: sources.PtrString("abc123"), // link
+		Stage:     sources.PtrString("prod"),   // link
+	}, nil
+}
+
+func (c testAPIGatewayClient) GetBasePathMappings(ctx context.Context, params *apigateway.GetBasePathMappingsInput, optFns ...func(*apigateway.Options)) (*apigateway.GetBasePathMappingsOutput, error) {
+	return &apigateway.GetBasePathMappingsOutput{
+		Items: []types.BasePathMapping{
+			{
+				BasePath:  sources.PtrString("orders"),
+				RestApiId: sources.PtrString("abc123"),
+				Stage:     sources.PtrString("prod"),
+			},
+		},
+	}, nil
+}
+
+func TestBasePathMappingItemMapper(t *testing.T) {
+	mapping, err := basePathMappingGetFunc(context.Background(), testAPIGatewayClient{}, "052392120703.eu-west-2", "api.example.com/orders")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := basePathMappingItemMapper("052392120703.eu-west-2", mapping)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.UniqueAttributeValue() != "api.example.com/orders" {
+		t.Errorf("expected unique attribute value api.example.com/orders, got %v", item.UniqueAttributeValue())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "apigateway-domain-name",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "api.example.com",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "apigateway-rest-api",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "abc123",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "apigateway-stage",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "abc123/prod",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestNewBasePathMappingSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewBasePathMappingSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
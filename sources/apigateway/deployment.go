@@ -0,0 +1,173 @@
+package apigateway
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+type DeploymentDetails struct {
+	Deployment *types.Deployment
+
+	// The ID of the REST API that the deployment belongs to
+	RestApiId string
+}
+
+func deploymentGetFunc(ctx context.Context, client APIGatewayClient, scope, query string) (*DeploymentDetails, error) {
+	restAPIID, deploymentID, err := parseChildQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := client.GetDeployment(ctx, &apigateway.GetDeploymentInput{
+		RestApiId:    &restAPIID,
+		DeploymentId: &deploymentID,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeploymentDetails{
+		Deployment: &types.Deployment{
+			ApiSummary:  out.ApiSummary,
+			CreatedDate: out.CreatedDate,
+			Description: out.Description,
+			Id:          out.Id,
+		},
+		RestApiId: restAPIID,
+	}, nil
+}
+
+// listDeployments Lists all deployments of a given REST API
+func listDeployments(ctx context.Context, client APIGatewayClient, restAPIID string) ([]*DeploymentDetails, error) {
+	deployments := make([]*DeploymentDetails, 0)
+	input := apigateway.GetDeploymentsInput{
+		RestApiId: &restAPIID,
+	}
+
+	for {
+		out, err := client.GetDeployments(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range out.Items {
+			deployments = append(deployments, &DeploymentDetails{
+				Deployment: &out.Items[i],
+				RestApiId:  restAPIID,
+			})
+		}
+
+		if out.Position == nil || len(out.Items) == 0 {
+			break
+		}
+
+		input.Position = out.Position
+	}
+
+	return deployments, nil
+}
+
+func deploymentListFunc(ctx context.Context, client APIGatewayClient, scope string) ([]*DeploymentDetails, error) {
+	apis, err := listRestAPIs(ctx, client)
+
+	if err != nil {
+		return nil, err
+	}
+
+	deployments := make([]*DeploymentDetails, 0)
+
+	for _, api := range apis {
+		if api.Id == nil {
+			continue
+		}
+
+		apiDeployments, err := listDeployments(ctx, client, *api.Id)
+
+		if err != nil {
+			return nil, err
+		}
+
+		deployments = append(deployments, apiDeployments...)
+	}
+
+	return deployments, nil
+}
+
+// deploymentSearchFunc Searches for deployments by the ID or ARN of the REST
+// API that they belong to
+func deploymentSearchFunc(ctx context.Context, client APIGatewayClient, scope, query string) ([]*DeploymentDetails, error) {
+	restAPIID, err := restAPIIDFromQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return listDeployments(ctx, client, restAPIID)
+}
+
+func deploymentItemMapper(scope string, awsItem *DeploymentDetails) (*sdp.Item, error) {
+	enrichedDeployment := struct {
+		*types.Deployment
+		RestApiId string
+	}{
+		Deployment: awsItem.Deployment,
+		RestApiId:  awsItem.RestApiId,
+	}
+
+	attributes, err := sources.ToAttributesCase(enrichedDeployment)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if awsItem.Deployment.Id != nil {
+		err = attributes.Set("uniqueName", awsItem.RestApiId+"/"+*awsItem.Deployment.Id)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	item := sdp.Item{
+		Type:            "apigateway-deployment",
+		UniqueAttribute: "uniqueName",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	// +overmind:link apigateway-rest-api
+	item.LinkedItemQueries = append(item.LinkedItemQueries, restAPILink(scope, awsItem.RestApiId))
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type apigateway-deployment
+// +overmind:descriptiveType API Gateway Deployment
+// +overmind:get Get a deployment by {restApiId}/{deploymentId}
+// +overmind:list List all deployments of all REST APIs
+// +overmind:search Search for deployments by REST API ID or ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_api_gateway_deployment.rest_api_id
+// +overmind:terraform:method SEARCH
+
+func NewDeploymentSource(config aws.Config, accountID string, region string) *sources.GetListSource[*DeploymentDetails, APIGatewayClient, *apigateway.Options] {
+	return &sources.GetListSource[*DeploymentDetails, APIGatewayClient, *apigateway.Options]{
+		ItemType:   "apigateway-deployment",
+		Client:     apigateway.NewFromConfig(config),
+		AccountID:  accountID,
+		Region:     region,
+		GetFunc:    deploymentGetFunc,
+		ListFunc:   deploymentListFunc,
+		SearchFunc: deploymentSearchFunc,
+		ItemMapper: deploymentItemMapper,
+	}
+}
@@ -0,0 +1,76 @@
+package apigateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testAPIGatewayClient) GetDeployment(ctx context.Context, params *apigateway.GetDeploymentInput, optFns ...func(*apigateway.Options)) (*apigateway.GetDeploymentOutput, error) {
+	return &apigateway.GetDeploymentOutput{
+		Id:          params.DeploymentId,
+		Description: sources.PtrString("release 42"),
+		CreatedDate: sources.PtrTime(time.Now()),
+	}, nil
+}
+
+func (c testAPIGatewayClient) GetDeployments(ctx context.Context, params *apigateway.GetDeploymentsInput, optFns ...func(*apigateway.Options)) (*apigateway.GetDeploymentsOutput, error) {
+	return &apigateway.GetDeploymentsOutput{
+		Items: []types.Deployment{
+			{
+				Id: sources.PtrString("dep01"),
+			},
+		},
+	}, nil
+}
+
+func TestDeploymentItemMapper(t *testing.T) {
+	deployment, err := deploymentGetFunc(context.Background(), testAPIGatewayClient{}, "052392120703.eu-west-2", "abc123/dep01")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := deploymentItemMapper("052392120703.eu-west-2", deployment)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.UniqueAttributeValue() != "abc123/dep01" {
+		t.Errorf("expected unique attribute value abc123/dep01, got %v", item.UniqueAttributeValue())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "apigateway-rest-api",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "abc123",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestNewDeploymentSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewDeploymentSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -0,0 +1,261 @@
+package apigateway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func domainNameGetFunc(ctx context.Context, client APIGatewayClient, scope, query string) (*types.DomainName, error) {
+	out, err := client.GetDomainName(ctx, &apigateway.GetDomainNameInput{
+		DomainName: &query,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.DomainName{
+		CertificateArn:                      out.CertificateArn,
+		CertificateName:                     out.CertificateName,
+		CertificateUploadDate:               out.CertificateUploadDate,
+		DistributionDomainName:              out.DistributionDomainName,
+		DistributionHostedZoneId:            out.DistributionHostedZoneId,
+		DomainName:                          out.DomainName,
+		DomainNameStatus:                    out.DomainNameStatus,
+		DomainNameStatusMessage:             out.DomainNameStatusMessage,
+		EndpointConfiguration:               out.EndpointConfiguration,
+		MutualTlsAuthentication:             out.MutualTlsAuthentication,
+		OwnershipVerificationCertificateArn: out.OwnershipVerificationCertificateArn,
+		RegionalCertificateArn:              out.RegionalCertificateArn,
+		RegionalCertificateName:             out.RegionalCertificateName,
+		RegionalDomainName:                  out.RegionalDomainName,
+		RegionalHostedZoneId:                out.RegionalHostedZoneId,
+		SecurityPolicy:                      out.SecurityPolicy,
+		Tags:                                out.Tags,
+	}, nil
+}
+
+// listDomainNames Lists all custom domain names in the region
+func listDomainNames(ctx context.Context, client APIGatewayClient) ([]*types.DomainName, error) {
+	domainNames := make([]*types.DomainName, 0)
+	input := apigateway.GetDomainNamesInput{}
+
+	for {
+		out, err := client.GetDomainNames(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range out.Items {
+			domainNames = append(domainNames, &out.Items[i])
+		}
+
+		if out.Position == nil || len(out.Items) == 0 {
+			break
+		}
+
+		input.Position = out.Position
+	}
+
+	return domainNames, nil
+}
+
+func domainNameListFunc(ctx context.Context, client APIGatewayClient, scope string) ([]*types.DomainName, error) {
+	return listDomainNames(ctx, client)
+}
+
+// domainNameSearchFunc Searches for a domain name by its ARN, which is in the
+// format arn:aws:apigateway:{region}::/domainnames/{domainName}
+func domainNameSearchFunc(ctx context.Context, client APIGatewayClient, scope, query string) ([]*types.DomainName, error) {
+	path, ok := arnPath(query)
+
+	if !ok || len(path) != 2 || path[0] != "domainnames" {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: fmt.Sprintf("ARN %v is not an API Gateway domain name", query),
+		}
+	}
+
+	domainName, err := domainNameGetFunc(ctx, client, scope, path[1])
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []*types.DomainName{domainName}, nil
+}
+
+func domainNameItemMapper(scope string, awsItem *types.DomainName) (*sdp.Item, error) {
+	attributes, err := sources.ToAttributesCase(awsItem, "tags")
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := sdp.Item{
+		Type:            "apigateway-domain-name",
+		UniqueAttribute: "domainName",
+		Attributes:      attributes,
+		Scope:           scope,
+		Tags:            awsItem.Tags,
+	}
+
+	switch awsItem.DomainNameStatus {
+	case types.DomainNameStatusAvailable:
+		item.Health = sdp.Health_HEALTH_OK.Enum()
+	case types.DomainNameStatusUpdating, types.DomainNameStatusPending, types.DomainNameStatusPendingCertificateReimport, types.DomainNameStatusPendingOwnershipVerification:
+		item.Health = sdp.Health_HEALTH_PENDING.Enum()
+	}
+
+	if awsItem.DomainName != nil {
+		// The records that point the custom domain at API Gateway
+		//
+		// +overmind:link dns
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "dns",
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  *awsItem.DomainName,
+				Scope:  "global",
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// DNS always links
+				In:  true,
+				Out: true,
+			},
+		})
+
+		// The Route 53 records that alias the custom domain to API Gateway
+		//
+		// +overmind:link route53-resource-record-set
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "route53-resource-record-set",
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  *awsItem.DomainName,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Changing the records won't affect the domain
+				In: false,
+				// Changing the domain will affect what the records resolve to
+				Out: true,
+			},
+		})
+
+		// +overmind:link apigateway-base-path-mapping
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "apigateway-base-path-mapping",
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  *awsItem.DomainName,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// The mappings route requests for the domain to APIs
+				In:  true,
+				Out: true,
+			},
+		})
+	}
+
+	certificateARNs := []*string{
+		awsItem.CertificateArn,
+		awsItem.RegionalCertificateArn,
+		awsItem.OwnershipVerificationCertificateArn,
+	}
+
+	for _, certificateARN := range certificateARNs {
+		if certificateARN == nil {
+			continue
+		}
+
+		if a, err := sources.ParseARN(*certificateARN); err == nil {
+			// +overmind:link acm-certificate
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "acm-certificate",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *certificateARN,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the certificate will affect the domain
+					In: true,
+					// Changing the domain won't affect the certificate
+					Out: false,
+				},
+			})
+		}
+	}
+
+	for _, domainName := range []*string{awsItem.DistributionDomainName, awsItem.RegionalDomainName} {
+		if domainName != nil {
+			// +overmind:link dns
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "dns",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *domainName,
+					Scope:  "global",
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// DNS always links
+					In:  true,
+					Out: true,
+				},
+			})
+		}
+	}
+
+	if awsItem.EndpointConfiguration != nil {
+		for _, id := range awsItem.EndpointConfiguration.VpcEndpointIds {
+			// +overmind:link ec2-vpc-endpoint
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "ec2-vpc-endpoint",
+					Method: sdp.QueryMethod_GET,
+					Query:  id,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Private domains can only be reached through the endpoint
+					In: true,
+					// Changing the domain won't affect the endpoint
+					Out: false,
+				},
+			})
+		}
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type apigateway-domain-name
+// +overmind:descriptiveType API Gateway Domain Name
+// +overmind:get Get a custom domain name by name
+// +overmind:list List all custom domain names
+// +overmind:search Search for a custom domain name by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_api_gateway_domain_name.domain_name
+
+func NewDomainNameSource(config aws.Config, accountID string, region string) *sources.GetListSource[*types.DomainName, APIGatewayClient, *apigateway.Options] {
+	return &sources.GetListSource[*types.DomainName, APIGatewayClient, *apigateway.Options]{
+		ItemType:   "apigateway-domain-name",
+		Client:     apigateway.NewFromConfig(config),
+		AccountID:  accountID,
+		Region:     region,
+		GetFunc:    domainNameGetFunc,
+		ListFunc:   domainNameListFunc,
+		SearchFunc: domainNameSearchFunc,
+		ItemMapper: domainNameItemMapper,
+	}
+}
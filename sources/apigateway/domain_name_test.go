@@ -0,0 +1,129 @@
+package apigateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testAPIGatewayClient) GetDomainName(ctx context.Context, params *apigateway.GetDomainNameInput, optFns ...func(*apigateway.Options)) (*apigateway.GetDomainNameOutput, error) {
+	return &apigateway.GetDomainNameOutput{
+		DomainName:             params.DomainName,
+		DomainNameStatus:       types.DomainNameStatusAvailable,
+		RegionalCertificateArn: sources.PtrString("arn:aws:acm:eu-west-2:052392120703:certificate/0123"), // link
+		RegionalDomainName:     sources.PtrString("d-abc123.execute-api.eu-west-2.amazonaws.com"),        // link
+		RegionalHostedZoneId:   sources.PtrString("ZJ5UAJN8Y3Z2Q"),
+		SecurityPolicy:         types.SecurityPolicyTls12,
+		EndpointConfiguration: &types.EndpointConfiguration{
+			Types: []types.EndpointType{
+				types.EndpointTypeRegional,
+			},
+		},
+		Tags: map[string]string{
+			"foo": "bar",
+		},
+	}, nil
+}
+
+func (c testAPIGatewayClient) GetDomainNames(ctx context.Context, params *apigateway.GetDomainNamesInput, optFns ...func(*apigateway.Options)) (*apigateway.GetDomainNamesOutput, error) {
+	return &apigateway.GetDomainNamesOutput{
+		Items: []types.DomainName{
+			{
+				DomainName: sources.PtrString("api.example.com"),
+			},
+		},
+	}, nil
+}
+
+func TestDomainNameItemMapper(t *testing.T) {
+	domainName, err := domainNameGetFunc(context.Background(), testAPIGatewayClient{}, "052392120703.eu-west-2", "api.example.com")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := domainNameItemMapper("052392120703.eu-west-2", domainName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.GetHealth() != sdp.Health_HEALTH_OK {
+		t.Errorf("expected health to be OK, got %v", item.GetHealth())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "dns",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "api.example.com",
+			ExpectedScope:  "global",
+		},
+		{
+			ExpectedType:   "route53-resource-record-set",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "api.example.com",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "apigateway-base-path-mapping",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "api.example.com",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "acm-certificate",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:acm:eu-west-2:052392120703:certificate/0123",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "dns",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "d-abc123.execute-api.eu-west-2.amazonaws.com",
+			ExpectedScope:  "global",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestDomainNameSearchFunc(t *testing.T) {
+	domainNames, err := domainNameSearchFunc(context.Background(), testAPIGatewayClient{}, "052392120703.eu-west-2", "arn:aws:apigateway:eu-west-2::/domainnames/api.example.com")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(domainNames) != 1 || *domainNames[0].DomainName != "api.example.com" {
+		t.Errorf("expected to find api.example.com, got %v", domainNames)
+	}
+
+	_, err = domainNameSearchFunc(context.Background(), testAPIGatewayClient{}, "052392120703.eu-west-2", "arn:aws:apigateway:eu-west-2::/restapis/abc123")
+
+	if err == nil {
+		t.Error("expected error for a REST API ARN, got nil")
+	}
+}
+
+func TestNewDomainNameSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewDomainNameSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -0,0 +1,284 @@
+package apigateway
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+type ResourceDetails struct {
+	Resource *types.Resource
+
+	// The ID of the REST API that the resource belongs to
+	RestApiId string
+}
+
+// Embedding methods means that we get the methods and their integrations in
+// the same call as the resource
+var embedMethods = []string{"methods"}
+
+func resourceGetFunc(ctx context.Context, client APIGatewayClient, scope, query string) (*ResourceDetails, error) {
+	restAPIID, resourceID, err := parseChildQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := client.GetResource(ctx, &apigateway.GetResourceInput{
+		RestApiId:  &restAPIID,
+		ResourceId: &resourceID,
+		Embed:      embedMethods,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResourceDetails{
+		Resource: &types.Resource{
+			Id:              out.Id,
+			ParentId:        out.ParentId,
+			Path:            out.Path,
+			PathPart:        out.PathPart,
+			ResourceMethods: out.ResourceMethods,
+		},
+		RestApiId: restAPIID,
+	}, nil
+}
+
+// listResources Lists all resources in a given REST API
+func listResources(ctx context.Context, client APIGatewayClient, restAPIID string) ([]*ResourceDetails, error) {
+	resources := make([]*ResourceDetails, 0)
+	input := apigateway.GetResourcesInput{
+		RestApiId: &restAPIID,
+		Embed:     embedMethods,
+	}
+
+	for {
+		out, err := client.GetResources(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range out.Items {
+			resources = append(resources, &ResourceDetails{
+				Resource:  &out.Items[i],
+				RestApiId: restAPIID,
+			})
+		}
+
+		if out.Position == nil || len(out.Items) == 0 {
+			break
+		}
+
+		input.Position = out.Position
+	}
+
+	return resources, nil
+}
+
+func resourceListFunc(ctx context.Context, client APIGatewayClient, scope string) ([]*ResourceDetails, error) {
+	apis, err := listRestAPIs(ctx, client)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]*ResourceDetails, 0)
+
+	for _, api := range apis {
+		if api.Id == nil {
+			continue
+		}
+
+		apiResources, err := listResources(ctx, client, *api.Id)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, apiResources...)
+	}
+
+	return resources, nil
+}
+
+// resourceSearchFunc Searches for resources by the ID or ARN of the REST API
+// that they belong to
+func resourceSearchFunc(ctx context.Context, client APIGatewayClient, scope, query string) ([]*ResourceDetails, error) {
+	restAPIID, err := restAPIIDFromQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return listResources(ctx, client, restAPIID)
+}
+
+// integrationLinks Returns the links for the integration behind a method
+func integrationLinks(scope string, integration *types.Integration) []*sdp.LinkedItemQuery {
+	links := make([]*sdp.LinkedItemQuery, 0)
+
+	if integration == nil {
+		return links
+	}
+
+	switch integration.Type {
+	case types.IntegrationTypeAws, types.IntegrationTypeAwsProxy:
+		if link := lambdaURILink(integration.Uri); link != nil {
+			links = append(links, link)
+		}
+	case types.IntegrationTypeHttp, types.IntegrationTypeHttpProxy:
+		// Private integrations still have a URI, but it's the host header
+		// that is sent to the load balancer behind the VPC link
+		if integration.Uri != nil && integration.ConnectionType != types.ConnectionTypeVpcLink {
+			links = append(links, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "http",
+					Method: sdp.QueryMethod_GET,
+					Query:  *integration.Uri,
+					Scope:  "global",
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// The endpoint handles the requests, so changes to either
+					// will affect the other
+					In:  true,
+					Out: true,
+				},
+			})
+		}
+	}
+
+	if integration.ConnectionType == types.ConnectionTypeVpcLink && integration.ConnectionId != nil {
+		links = append(links, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "apigateway-vpc-link",
+				Method: sdp.QueryMethod_GET,
+				Query:  *integration.ConnectionId,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Requests are sent through the VPC link
+				In:  true,
+				Out: true,
+			},
+		})
+	}
+
+	if link := roleLink(integration.Credentials); link != nil {
+		links = append(links, link)
+	}
+
+	return links
+}
+
+func resourceItemMapper(scope string, awsItem *ResourceDetails) (*sdp.Item, error) {
+	enrichedResource := struct {
+		*types.Resource
+		RestApiId string
+	}{
+		Resource:  awsItem.Resource,
+		RestApiId: awsItem.RestApiId,
+	}
+
+	attributes, err := sources.ToAttributesCase(enrichedResource)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if awsItem.Resource.Id != nil {
+		err = attributes.Set("uniqueName", awsItem.RestApiId+"/"+*awsItem.Resource.Id)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	item := sdp.Item{
+		Type:            "apigateway-resource",
+		UniqueAttribute: "uniqueName",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	// +overmind:link apigateway-rest-api
+	item.LinkedItemQueries = append(item.LinkedItemQueries, restAPILink(scope, awsItem.RestApiId))
+
+	if awsItem.Resource.ParentId != nil {
+		// +overmind:link apigateway-resource
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "apigateway-resource",
+				Method: sdp.QueryMethod_GET,
+				Query:  awsItem.RestApiId + "/" + *awsItem.Resource.ParentId,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Changing the parent changes the path of this resource
+				In: true,
+				// Changing this resource won't affect the parent
+				Out: false,
+			},
+		})
+	}
+
+	for _, method := range awsItem.Resource.ResourceMethods {
+		if method.AuthorizerId != nil {
+			// +overmind:link apigateway-authorizer
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "apigateway-authorizer",
+					Method: sdp.QueryMethod_GET,
+					Query:  awsItem.RestApiId + "/" + *method.AuthorizerId,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the authorizer will affect who can call the
+					// method
+					In: true,
+					// Changing the method won't affect the authorizer
+					Out: false,
+				},
+			})
+		}
+
+		// +overmind:link lambda-function
+		// +overmind:link http
+		// +overmind:link apigateway-vpc-link
+		// +overmind:link iam-role
+		item.LinkedItemQueries = append(item.LinkedItemQueries, integrationLinks(scope, method.MethodIntegration)...)
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type apigateway-resource
+// +overmind:descriptiveType API Gateway Resource
+// +overmind:get Get a resource, including its methods, by {restApiId}/{resourceId}
+// +overmind:list List all resources in all REST APIs
+// +overmind:search Search for resources by REST API ID or ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_api_gateway_resource.rest_api_id
+// +overmind:terraform:queryMap aws_api_gateway_method.rest_api_id
+// +overmind:terraform:queryMap aws_api_gateway_integration.rest_api_id
+// +overmind:terraform:method SEARCH
+
+func NewResourceSource(config aws.Config, accountID string, region string) *sources.GetListSource[*ResourceDetails, APIGatewayClient, *apigateway.Options] {
+	return &sources.GetListSource[*ResourceDetails, APIGatewayClient, *apigateway.Options]{
+		ItemType:   "apigateway-resource",
+		Client:     apigateway.NewFromConfig(config),
+		AccountID:  accountID,
+		Region:     region,
+		GetFunc:    resourceGetFunc,
+		ListFunc:   resourceListFunc,
+		SearchFunc: resourceSearchFunc,
+		ItemMapper: resourceItemMapper,
+	}
+}
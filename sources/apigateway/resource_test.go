@@ -0,0 +1,174 @@
+package apigateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+var testResourceMethods = map[string]types.Method{
+	"GET": {
+		HttpMethod:        sources.PtrString("GET"),
+		AuthorizationType: sources.PtrString("CUSTOM"),
+		AuthorizerId:      sources.PtrString("auth01"), // link
+		MethodIntegration: &types.Integration{
+			Type:        types.IntegrationTypeAwsProxy,
+			HttpMethod:  sources.PtrString("POST"),
+			Uri:         sources.PtrString("arn:aws:apigateway:eu-west-2:lambda:path/2015-03-31/functions/arn:aws:lambda:eu-west-2:052392120703:function:orders/invocations"), // link
+			Credentials: sources.PtrString("arn:aws:iam::052392120703:role/apigateway-invoke"),                                                                                // link
+		},
+	},
+	"POST": {
+		HttpMethod:        sources.PtrString("POST"),
+		AuthorizationType: sources.PtrString("NONE"),
+		MethodIntegration: &types.Integration{
+			Type:           types.IntegrationTypeHttpProxy,
+			HttpMethod:     sources.PtrString("POST"),
+			Uri:            sources.PtrString("https://orders.internal.example.com/orders"),
+			ConnectionType: types.ConnectionTypeVpcLink,
+			ConnectionId:   sources.PtrString("vl0123"), // link
+		},
+	},
+	"PUT": {
+		HttpMethod:        sources.PtrString("PUT"),
+		AuthorizationType: sources.PtrString("NONE"),
+		MethodIntegration: &types.Integration{
+			Type:       types.IntegrationTypeHttp,
+			HttpMethod: sources.PtrString("PUT"),
+			Uri:        sources.PtrString("https://example.com/orders"), // link
+		},
+	},
+}
+
+func (c testAPIGatewayClient) GetResource(ctx context.Context, params *apigateway.GetResourceInput, optFns ...func(*apigateway.Options)) (*apigateway.GetResourceOutput, error) {
+	return &apigateway.GetResourceOutput{
+		Id:              params.ResourceId,
+		ParentId:        sources.PtrString("root01"), // link
+		Path:            sources.PtrString("/orders"),
+		PathPart:        sources.PtrString("orders"),
+		ResourceMethods: testResourceMethods,
+	}, nil
+}
+
+func (c testAPIGatewayClient) GetResources(ctx context.Context, params *apigateway.GetResourcesInput, optFns ...func(*apigateway.Options)) (*apigateway.GetResourcesOutput, error) {
+	return &apigateway.GetResourcesOutput{
+		Items: []types.Resource{
+			{
+				Id:   sources.PtrString("root01"),
+				Path: sources.PtrString("/"),
+			},
+			{
+				Id:              sources.PtrString("res01"),
+				ParentId:        sources.PtrString("root01"),
+				Path:            sources.PtrString("/orders"),
+				PathPart:        sources.PtrString("orders"),
+				ResourceMethods: testResourceMethods,
+			},
+		},
+	}, nil
+}
+
+func TestResourceItemMapper(t *testing.T) {
+	resource, err := resourceGetFunc(context.Background(), testAPIGatewayClient{}, "052392120703.eu-west-2", "abc123/res01")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := resourceItemMapper("052392120703.eu-west-2", resource)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.UniqueAttributeValue() != "abc123/res01" {
+		t.Errorf("expected unique attribute value abc123/res01, got %v", item.UniqueAttributeValue())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "apigateway-rest-api",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "abc123",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "apigateway-resource",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "abc123/root01",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "apigateway-authorizer",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "abc123/auth01",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "lambda-function",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:lambda:eu-west-2:052392120703:function:orders",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "iam-role",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:iam::052392120703:role/apigateway-invoke",
+			ExpectedScope:  "052392120703",
+		},
+		{
+			ExpectedType:   "apigateway-vpc-link",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "vl0123",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "http",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "https://example.com/orders",
+			ExpectedScope:  "global",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestResourceSearchFunc(t *testing.T) {
+	resources, err := resourceSearchFunc(context.Background(), testAPIGatewayClient{}, "052392120703.eu-west-2", "abc123")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resources) != 2 {
+		t.Fatalf("expected 2 resources, got %v", len(resources))
+	}
+
+	for _, resource := range resources {
+		if resource.RestApiId != "abc123" {
+			t.Errorf("expected REST API ID abc123, got %v", resource.RestApiId)
+		}
+	}
+}
+
+func TestNewResourceSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewResourceSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
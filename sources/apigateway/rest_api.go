@@ -0,0 +1,154 @@
+package apigateway
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func restAPIGetFunc(ctx context.Context, client APIGatewayClient, scope, query string) (*types.RestApi, error) {
+	out, err := client.GetRestApi(ctx, &apigateway.GetRestApiInput{
+		RestApiId: &query,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.RestApi{
+		ApiKeySource:              out.ApiKeySource,
+		BinaryMediaTypes:          out.BinaryMediaTypes,
+		CreatedDate:               out.CreatedDate,
+		Description:               out.Description,
+		DisableExecuteApiEndpoint: out.DisableExecuteApiEndpoint,
+		EndpointConfiguration:     out.EndpointConfiguration,
+		Id:                        out.Id,
+		MinimumCompressionSize:    out.MinimumCompressionSize,
+		Name:                      out.Name,
+		Policy:                    out.Policy,
+		RootResourceId:            out.RootResourceId,
+		Tags:                      out.Tags,
+		Version:                   out.Version,
+		Warnings:                  out.Warnings,
+	}, nil
+}
+
+func restAPIListFunc(ctx context.Context, client APIGatewayClient, scope string) ([]*types.RestApi, error) {
+	apis, err := listRestAPIs(ctx, client)
+
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*types.RestApi, 0, len(apis))
+
+	for i := range apis {
+		items = append(items, &apis[i])
+	}
+
+	return items, nil
+}
+
+// restAPISearchFunc Searches for a REST API by its ARN, or by an execute-api
+// ARN such as the ones used in Lambda resource policies
+func restAPISearchFunc(ctx context.Context, client APIGatewayClient, scope, query string) ([]*types.RestApi, error) {
+	id, err := restAPIIDFromQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	api, err := restAPIGetFunc(ctx, client, scope, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []*types.RestApi{api}, nil
+}
+
+func restAPIItemMapper(scope string, awsItem *types.RestApi) (*sdp.Item, error) {
+	attributes, err := sources.ToAttributesCase(awsItem, "tags")
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := sdp.Item{
+		Type:            "apigateway-rest-api",
+		UniqueAttribute: "id",
+		Attributes:      attributes,
+		Scope:           scope,
+		Tags:            awsItem.Tags,
+	}
+
+	if awsItem.Id != nil {
+		// +overmind:link apigateway-resource
+		// +overmind:link apigateway-stage
+		// +overmind:link apigateway-deployment
+		// +overmind:link apigateway-authorizer
+		for _, childType := range []string{"apigateway-resource", "apigateway-stage", "apigateway-deployment", "apigateway-authorizer"} {
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   childType,
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *awsItem.Id,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// The API and its children are tightly coupled
+					In:  true,
+					Out: true,
+				},
+			})
+		}
+	}
+
+	if awsItem.EndpointConfiguration != nil {
+		for _, id := range awsItem.EndpointConfiguration.VpcEndpointIds {
+			// +overmind:link ec2-vpc-endpoint
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "ec2-vpc-endpoint",
+					Method: sdp.QueryMethod_GET,
+					Query:  id,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Private APIs can only be reached through the endpoint
+					In: true,
+					// Changing the API won't affect the endpoint
+					Out: false,
+				},
+			})
+		}
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type apigateway-rest-api
+// +overmind:descriptiveType API Gateway REST API
+// +overmind:get Get a REST API by ID
+// +overmind:list List all REST APIs
+// +overmind:search Search for a REST API by ARN, or by an execute-api ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_api_gateway_rest_api.id
+
+func NewRestAPISource(config aws.Config, accountID string, region string) *sources.GetListSource[*types.RestApi, APIGatewayClient, *apigateway.Options] {
+	return &sources.GetListSource[*types.RestApi, APIGatewayClient, *apigateway.Options]{
+		ItemType:   "apigateway-rest-api",
+		Client:     apigateway.NewFromConfig(config),
+		AccountID:  accountID,
+		Region:     region,
+		GetFunc:    restAPIGetFunc,
+		ListFunc:   restAPIListFunc,
+		SearchFunc: restAPISearchFunc,
+		ItemMapper: restAPIItemMapper,
+	}
+}
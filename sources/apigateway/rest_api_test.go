@@ -0,0 +1,129 @@
+package apigateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testAPIGatewayClient) GetRestApi(ctx context.Context, params *apigateway.GetRestApiInput, optFns ...func(*apigateway.Options)) (*apigateway.GetRestApiOutput, error) {
+	return &apigateway.GetRestApiOutput{
+		Id:             params.RestApiId,
+		Name:           sources.PtrString("orders"),
+		RootResourceId: sources.PtrString("root01"),
+		CreatedDate:    sources.PtrTime(time.Now()),
+		EndpointConfiguration: &types.EndpointConfiguration{
+			Types: []types.EndpointType{
+				types.EndpointTypePrivate,
+			},
+			VpcEndpointIds: []string{
+				"vpce-0123456789abcdef0", // link
+			},
+		},
+		Tags: map[string]string{
+			"foo": "bar",
+		},
+	}, nil
+}
+
+func (c testAPIGatewayClient) GetRestApis(ctx context.Context, params *apigateway.GetRestApisInput, optFns ...func(*apigateway.Options)) (*apigateway.GetRestApisOutput, error) {
+	return &apigateway.GetRestApisOutput{
+		Items: []types.RestApi{
+			{
+				Id:   sources.PtrString("abc123"),
+				Name: sources.PtrString("orders"),
+			},
+		},
+	}, nil
+}
+
+func TestRestAPIItemMapper(t *testing.T) {
+	api, err := restAPIGetFunc(context.Background(), testAPIGatewayClient{}, "052392120703.eu-west-2", "abc123")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := restAPIItemMapper("052392120703.eu-west-2", api)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.GetTags()["foo"] != "bar" {
+		t.Errorf("expected tag foo to be bar, got %v", item.GetTags()["foo"])
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "apigateway-resource",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "abc123",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "apigateway-stage",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "abc123",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "apigateway-deployment",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "abc123",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "apigateway-authorizer",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "abc123",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "ec2-vpc-endpoint",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "vpce-0123456789abcdef0",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestRestAPISearchFunc(t *testing.T) {
+	apis, err := restAPISearchFunc(context.Background(), testAPIGatewayClient{}, "052392120703.eu-west-2", "arn:aws:execute-api:eu-west-2:052392120703:abc123/*/GET/orders")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(apis) != 1 {
+		t.Fatalf("expected 1 API, got %v", len(apis))
+	}
+
+	if *apis[0].Id != "abc123" {
+		t.Errorf("expected API ID abc123, got %v", *apis[0].Id)
+	}
+}
+
+func TestNewRestAPISource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewRestAPISource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
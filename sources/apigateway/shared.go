@@ -0,0 +1,218 @@
+package apigateway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+// APIGatewayClient Represents the client we need to talk to API Gateway,
+// usually this is *apigateway.Client
+type APIGatewayClient interface {
+	GetAuthorizer(ctx context.Context, params *apigateway.GetAuthorizerInput, optFns ...func(*apigateway.Options)) (*apigateway.GetAuthorizerOutput, error)
+	GetAuthorizers(ctx context.Context, params *apigateway.GetAuthorizersInput, optFns ...func(*apigateway.Options)) (*apigateway.GetAuthorizersOutput, error)
+	GetBasePathMapping(ctx context.Context, params *apigateway.GetBasePathMappingInput, optFns ...func(*apigateway.Options)) (*apigateway.GetBasePathMappingOutput, error)
+	GetBasePathMappings(ctx context.Context, params *apigateway.GetBasePathMappingsInput, optFns ...func(*apigateway.Options)) (*apigateway.GetBasePathMappingsOutput, error)
+	GetDeployment(ctx context.Context, params *apigateway.GetDeploymentInput, optFns ...func(*apigateway.Options)) (*apigateway.GetDeploymentOutput, error)
+	GetDeployments(ctx context.Context, params *apigateway.GetDeploymentsInput, optFns ...func(*apigateway.Options)) (*apigateway.GetDeploymentsOutput, error)
+	GetDomainName(ctx context.Context, params *apigateway.GetDomainNameInput, optFns ...func(*apigateway.Options)) (*apigateway.GetDomainNameOutput, error)
+	GetDomainNames(ctx context.Context, params *apigateway.GetDomainNamesInput, optFns ...func(*apigateway.Options)) (*apigateway.GetDomainNamesOutput, error)
+	GetResource(ctx context.Context, params *apigateway.GetResourceInput, optFns ...func(*apigateway.Options)) (*apigateway.GetResourceOutput, error)
+	GetResources(ctx context.Context, params *apigateway.GetResourcesInput, optFns ...func(*apigateway.Options)) (*apigateway.GetResourcesOutput, error)
+	GetRestApi(ctx context.Context, params *apigateway.GetRestApiInput, optFns ...func(*apigateway.Options)) (*apigateway.GetRestApiOutput, error)
+	GetRestApis(ctx context.Context, params *apigateway.GetRestApisInput, optFns ...func(*apigateway.Options)) (*apigateway.GetRestApisOutput, error)
+	GetStage(ctx context.Context, params *apigateway.GetStageInput, optFns ...func(*apigateway.Options)) (*apigateway.GetStageOutput, error)
+	GetStages(ctx context.Context, params *apigateway.GetStagesInput, optFns ...func(*apigateway.Options)) (*apigateway.GetStagesOutput, error)
+	GetVpcLink(ctx context.Context, params *apigateway.GetVpcLinkInput, optFns ...func(*apigateway.Options)) (*apigateway.GetVpcLinkOutput, error)
+	GetVpcLinks(ctx context.Context, params *apigateway.GetVpcLinksInput, optFns ...func(*apigateway.Options)) (*apigateway.GetVpcLinksOutput, error)
+}
+
+// parseChildQuery Splits a query in the format {parent}/{child} into its
+// parts. This is used for resources that only exist within a REST API or
+// domain name, neither of which can contain a slash
+func parseChildQuery(query string) (parent string, child string, err error) {
+	parent, child, found := strings.Cut(query, "/")
+
+	if !found || parent == "" || child == "" {
+		return "", "", &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: fmt.Sprintf("query %v must be in the format {parent}/{child}", query),
+		}
+	}
+
+	return parent, child, nil
+}
+
+// arnPath Returns the sections of the path in an API Gateway ARN. These are in
+// the format arn:aws:apigateway:{region}::/restapis/{id}/stages/{name} so the
+// path for this example would be [restapis, {id}, stages, {name}]
+func arnPath(query string) ([]string, bool) {
+	a, err := sources.ParseARN(query)
+
+	if err != nil || a.Service != "apigateway" {
+		return nil, false
+	}
+
+	return strings.Split(strings.TrimPrefix(a.Resource, "/"), "/"), true
+}
+
+// restAPIIDFromQuery Returns the ID of a REST API from a query that is either
+// the ID itself, the ARN of the API or one of its children, or an execute-api
+// ARN. Execute-api ARNs are used in resource policies and are in the format
+// arn:aws:execute-api:{region}:{account}:{apiId}/{stage}/{method}/{path}
+func restAPIIDFromQuery(query string) (string, error) {
+	a, err := sources.ParseARN(query)
+
+	if err != nil {
+		// This isn't an ARN so assume that it's an ID
+		return query, nil
+	}
+
+	switch a.Service {
+	case "execute-api":
+		id, _, _ := strings.Cut(a.Resource, "/")
+
+		return id, nil
+	case "apigateway":
+		path, _ := arnPath(query)
+
+		if len(path) >= 2 && path[0] == "restapis" {
+			return path[1], nil
+		}
+	}
+
+	return "", &sdp.QueryError{
+		ErrorType:   sdp.QueryError_NOTFOUND,
+		ErrorString: fmt.Sprintf("ARN %v does not refer to a REST API", query),
+	}
+}
+
+// listRestAPIs Lists all REST APIs in the region
+func listRestAPIs(ctx context.Context, client APIGatewayClient) ([]types.RestApi, error) {
+	apis := make([]types.RestApi, 0)
+	input := apigateway.GetRestApisInput{}
+
+	for {
+		out, err := client.GetRestApis(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		apis = append(apis, out.Items...)
+
+		if out.Position == nil || len(out.Items) == 0 {
+			break
+		}
+
+		input.Position = out.Position
+	}
+
+	return apis, nil
+}
+
+// restAPILink Returns a link to the REST API that a child resource belongs to
+func restAPILink(scope string, restAPIID string) *sdp.LinkedItemQuery {
+	return &sdp.LinkedItemQuery{
+		Query: &sdp.Query{
+			Type:   "apigateway-rest-api",
+			Method: sdp.QueryMethod_GET,
+			Query:  restAPIID,
+			Scope:  scope,
+		},
+		BlastPropagation: &sdp.BlastPropagation{
+			// The API and its children are tightly coupled
+			In:  true,
+			Out: true,
+		},
+	}
+}
+
+// lambdaFunctionARNFromURI Extracts the ARN of a Lambda function from an
+// integration or authorizer URI. These are in the format:
+// arn:aws:apigateway:{region}:lambda:path/2015-03-31/functions/{functionArn}/invocations
+func lambdaFunctionARNFromURI(uri string) (string, bool) {
+	_, rest, found := strings.Cut(uri, ":lambda:path/")
+
+	if !found {
+		return "", false
+	}
+
+	_, rest, found = strings.Cut(rest, "/functions/")
+
+	if !found {
+		return "", false
+	}
+
+	functionARN, _, found := strings.Cut(rest, "/invocations")
+
+	return functionARN, found
+}
+
+// lambdaURILink Returns a link to the Lambda function referenced in an
+// integration or authorizer URI, if there is one
+func lambdaURILink(uri *string) *sdp.LinkedItemQuery {
+	if uri == nil {
+		return nil
+	}
+
+	functionARN, ok := lambdaFunctionARNFromURI(*uri)
+
+	if !ok {
+		return nil
+	}
+
+	a, err := sources.ParseARN(functionARN)
+
+	if err != nil {
+		return nil
+	}
+
+	return &sdp.LinkedItemQuery{
+		Query: &sdp.Query{
+			Type:   "lambda-function",
+			Method: sdp.QueryMethod_SEARCH,
+			Query:  functionARN,
+			Scope:  sources.FormatScope(a.AccountID, a.Region),
+		},
+		BlastPropagation: &sdp.BlastPropagation{
+			// The function handles the requests, so changes to either will
+			// affect the other
+			In:  true,
+			Out: true,
+		},
+	}
+}
+
+// roleLink Returns a link to an IAM role that API Gateway assumes
+func roleLink(roleARN *string) *sdp.LinkedItemQuery {
+	if roleARN == nil {
+		return nil
+	}
+
+	a, err := sources.ParseARN(*roleARN)
+
+	if err != nil {
+		return nil
+	}
+
+	return &sdp.LinkedItemQuery{
+		Query: &sdp.Query{
+			Type:   "iam-role",
+			Method: sdp.QueryMethod_SEARCH,
+			Query:  *roleARN,
+			Scope:  sources.FormatScope(a.AccountID, a.Region),
+		},
+		BlastPropagation: &sdp.BlastPropagation{
+			// Changing the role will affect what API Gateway can invoke
+			In: true,
+			// Changing API Gateway won't affect the role
+			Out: false,
+		},
+	}
+}
@@ -0,0 +1,148 @@
+package apigateway
+
+import (
+	"testing"
+)
+
+type testAPIGatewayClient struct{}
+
+func TestParseChildQuery(t *testing.T) {
+	tests := []struct {
+		Query          string
+		ExpectedParent string
+		ExpectedChild  string
+		ExpectError    bool
+	}{
+		{
+			Query:          "abc123/def456",
+			ExpectedParent: "abc123",
+			ExpectedChild:  "def456",
+		},
+		{
+			Query:          "api.example.com/(none)",
+			ExpectedParent: "api.example.com",
+			ExpectedChild:  "(none)",
+		},
+		{
+			Query:       "abc123",
+			ExpectError: true,
+		},
+		{
+			Query:       "abc123/",
+			ExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Query, func(t *testing.T) {
+			parent, child, err := parseChildQuery(test.Query)
+
+			if test.ExpectError {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if parent != test.ExpectedParent {
+				t.Errorf("expected parent %v, got %v", test.ExpectedParent, parent)
+			}
+
+			if child != test.ExpectedChild {
+				t.Errorf("expected child %v, got %v", test.ExpectedChild, child)
+			}
+		})
+	}
+}
+
+func TestRestAPIIDFromQuery(t *testing.T) {
+	tests := []struct {
+		Query       string
+		ExpectedID  string
+		ExpectError bool
+	}{
+		{
+			Query:      "abc123",
+			ExpectedID: "abc123",
+		},
+		{
+			Query:      "arn:aws:apigateway:eu-west-2::/restapis/abc123",
+			ExpectedID: "abc123",
+		},
+		{
+			Query:      "arn:aws:apigateway:eu-west-2::/restapis/abc123/stages/prod",
+			ExpectedID: "abc123",
+		},
+		{
+			Query:      "arn:aws:execute-api:eu-west-2:052392120703:abc123/*/GET/orders",
+			ExpectedID: "abc123",
+		},
+		{
+			Query:       "arn:aws:apigateway:eu-west-2::/domainnames/api.example.com",
+			ExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Query, func(t *testing.T) {
+			id, err := restAPIIDFromQuery(test.Query)
+
+			if test.ExpectError {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if id != test.ExpectedID {
+				t.Errorf("expected ID %v, got %v", test.ExpectedID, id)
+			}
+		})
+	}
+}
+
+func TestLambdaFunctionARNFromURI(t *testing.T) {
+	tests := []struct {
+		URI         string
+		ExpectedARN string
+		ExpectOK    bool
+	}{
+		{
+			URI:         "arn:aws:apigateway:eu-west-2:lambda:path/2015-03-31/functions/arn:aws:lambda:eu-west-2:052392120703:function:orders/invocations",
+			ExpectedARN: "arn:aws:lambda:eu-west-2:052392120703:function:orders",
+			ExpectOK:    true,
+		},
+		{
+			URI:      "arn:aws:apigateway:eu-west-2:sqs:path/052392120703/orders",
+			ExpectOK: false,
+		},
+		{
+			URI:      "https://example.com/orders",
+			ExpectOK: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.URI, func(t *testing.T) {
+			arn, ok := lambdaFunctionARNFromURI(test.URI)
+
+			if ok != test.ExpectOK {
+				t.Fatalf("expected ok to be %v, got %v", test.ExpectOK, ok)
+			}
+
+			if arn != test.ExpectedARN {
+				t.Errorf("expected ARN %v, got %v", test.ExpectedARN, arn)
+			}
+		})
+	}
+}
@@ -0,0 +1,271 @@
+package apigateway
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+type StageDetails struct {
+	Stage *types.Stage
+
+	// The ID of the REST API that the stage belongs to
+	RestApiId string
+}
+
+func stageGetFunc(ctx context.Context, client APIGatewayClient, scope, query string) (*StageDetails, error) {
+	restAPIID, stageName, err := parseChildQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := client.GetStage(ctx, &apigateway.GetStageInput{
+		RestApiId: &restAPIID,
+		StageName: &stageName,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &StageDetails{
+		Stage: &types.Stage{
+			AccessLogSettings:    out.AccessLogSettings,
+			CacheClusterEnabled:  out.CacheClusterEnabled,
+			CacheClusterSize:     out.CacheClusterSize,
+			CacheClusterStatus:   out.CacheClusterStatus,
+			CanarySettings:       out.CanarySettings,
+			ClientCertificateId:  out.ClientCertificateId,
+			CreatedDate:          out.CreatedDate,
+			DeploymentId:         out.DeploymentId,
+			Description:          out.Description,
+			DocumentationVersion: out.DocumentationVersion,
+			LastUpdatedDate:      out.LastUpdatedDate,
+			MethodSettings:       out.MethodSettings,
+			StageName:            out.StageName,
+			Tags:                 out.Tags,
+			TracingEnabled:       out.TracingEnabled,
+			Variables:            out.Variables,
+			WebAclArn:            out.WebAclArn,
+		},
+		RestApiId: restAPIID,
+	}, nil
+}
+
+// listStages Lists all stages in a given REST API
+func listStages(ctx context.Context, client APIGatewayClient, restAPIID string) ([]*StageDetails, error) {
+	out, err := client.GetStages(ctx, &apigateway.GetStagesInput{
+		RestApiId: &restAPIID,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	stages := make([]*StageDetails, 0, len(out.Item))
+
+	for i := range out.Item {
+		stages = append(stages, &StageDetails{
+			Stage:     &out.Item[i],
+			RestApiId: restAPIID,
+		})
+	}
+
+	return stages, nil
+}
+
+func stageListFunc(ctx context.Context, client APIGatewayClient, scope string) ([]*StageDetails, error) {
+	apis, err := listRestAPIs(ctx, client)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stages := make([]*StageDetails, 0)
+
+	for _, api := range apis {
+		if api.Id == nil {
+			continue
+		}
+
+		apiStages, err := listStages(ctx, client, *api.Id)
+
+		if err != nil {
+			return nil, err
+		}
+
+		stages = append(stages, apiStages...)
+	}
+
+	return stages, nil
+}
+
+// stageSearchFunc Searches for stages by the ARN of the stage itself, which
+// is what WAF uses when referring to them, or by the ID or ARN of the REST
+// API that they belong to
+func stageSearchFunc(ctx context.Context, client APIGatewayClient, scope, query string) ([]*StageDetails, error) {
+	if path, ok := arnPath(query); ok && len(path) == 4 && path[0] == "restapis" && path[2] == "stages" {
+		stage, err := stageGetFunc(ctx, client, scope, path[1]+"/"+path[3])
+
+		if err != nil {
+			return nil, err
+		}
+
+		return []*StageDetails{stage}, nil
+	}
+
+	restAPIID, err := restAPIIDFromQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return listStages(ctx, client, restAPIID)
+}
+
+func stageItemMapper(scope string, awsItem *StageDetails) (*sdp.Item, error) {
+	enrichedStage := struct {
+		*types.Stage
+		RestApiId string
+	}{
+		Stage:     awsItem.Stage,
+		RestApiId: awsItem.RestApiId,
+	}
+
+	attributes, err := sources.ToAttributesCase(enrichedStage, "tags")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if awsItem.Stage.StageName != nil {
+		err = attributes.Set("uniqueName", awsItem.RestApiId+"/"+*awsItem.Stage.StageName)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	item := sdp.Item{
+		Type:            "apigateway-stage",
+		UniqueAttribute: "uniqueName",
+		Attributes:      attributes,
+		Scope:           scope,
+		Tags:            awsItem.Stage.Tags,
+	}
+
+	// +overmind:link apigateway-rest-api
+	item.LinkedItemQueries = append(item.LinkedItemQueries, restAPILink(scope, awsItem.RestApiId))
+
+	deploymentIDs := make([]string, 0)
+
+	if awsItem.Stage.DeploymentId != nil {
+		deploymentIDs = append(deploymentIDs, *awsItem.Stage.DeploymentId)
+	}
+
+	if awsItem.Stage.CanarySettings != nil && awsItem.Stage.CanarySettings.DeploymentId != nil {
+		deploymentIDs = append(deploymentIDs, *awsItem.Stage.CanarySettings.DeploymentId)
+	}
+
+	for _, id := range deploymentIDs {
+		// +overmind:link apigateway-deployment
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "apigateway-deployment",
+				Method: sdp.QueryMethod_GET,
+				Query:  awsItem.RestApiId + "/" + id,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// The deployment is what the stage serves
+				In: true,
+				// Changing the stage won't affect the deployment
+				Out: false,
+			},
+		})
+	}
+
+	if awsItem.Stage.WebAclArn != nil {
+		if a, err := sources.ParseARN(*awsItem.Stage.WebAclArn); err == nil {
+			// +overmind:link wafv2-web-acl
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "wafv2-web-acl",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *awsItem.Stage.WebAclArn,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the ACL will affect the traffic that reaches the
+					// stage
+					In: true,
+					// Changing the stage won't affect the ACL
+					Out: false,
+				},
+			})
+		}
+	}
+
+	if awsItem.Stage.AccessLogSettings != nil && awsItem.Stage.AccessLogSettings.DestinationArn != nil {
+		if a, err := sources.ParseARN(*awsItem.Stage.AccessLogSettings.DestinationArn); err == nil {
+			var queryType string
+
+			switch a.Service {
+			case "logs":
+				queryType = "logs-log-group"
+			case "firehose":
+				queryType = "firehose-delivery-stream"
+			}
+
+			if queryType != "" {
+				// +overmind:link logs-log-group
+				// +overmind:link firehose-delivery-stream
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   queryType,
+						Method: sdp.QueryMethod_SEARCH,
+						Query:  *awsItem.Stage.AccessLogSettings.DestinationArn,
+						Scope:  sources.FormatScope(a.AccountID, a.Region),
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Changing the destination could mean that access logs
+						// are lost
+						In: true,
+						// Access logs are sent to the destination
+						Out: true,
+					},
+				})
+			}
+		}
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type apigateway-stage
+// +overmind:descriptiveType API Gateway Stage
+// +overmind:get Get a stage by {restApiId}/{stageName}
+// +overmind:list List all stages in all REST APIs
+// +overmind:search Search for stages by ARN, or by REST API ID or ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_api_gateway_stage.arn
+// +overmind:terraform:method SEARCH
+
+func NewStageSource(config aws.Config, accountID string, region string) *sources.GetListSource[*StageDetails, APIGatewayClient, *apigateway.Options] {
+	return &sources.GetListSource[*StageDetails, APIGatewayClient, *apigateway.Options]{
+		ItemType:   "apigateway-stage",
+		Client:     apigateway.NewFromConfig(config),
+		AccountID:  accountID,
+		Region:     region,
+		GetFunc:    stageGetFunc,
+		ListFunc:   stageListFunc,
+		SearchFunc: stageSearchFunc,
+		ItemMapper: stageItemMapper,
+	}
+}
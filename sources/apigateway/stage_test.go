@@ -0,0 +1,148 @@
+package apigateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testAPIGatewayClient) GetStage(ctx context.Context, params *apigateway.GetStageInput, optFns ...func(*apigateway.Options)) (*apigateway.GetStageOutput, error) {
+	return &apigateway.GetStageOutput{
+		StageName:    params.StageName,
+		DeploymentId: sources.PtrString("dep01"), // link
+		CanarySettings: &types.CanarySettings{
+			DeploymentId:   sources.PtrString("dep02"), // link
+			PercentTraffic: 10,
+		},
+		AccessLogSettings: &types.AccessLogSettings{
+			DestinationArn: sources.PtrString("arn:aws:logs:eu-west-2:052392120703:log-group:orders-access"), // link
+			Format:         sources.PtrString("$context.requestId"),
+		},
+		WebAclArn:      sources.PtrString("arn:aws:wafv2:eu-west-2:052392120703:regional/webacl/orders/a1b2c3"), // link
+		TracingEnabled: true,
+		CreatedDate:    sources.PtrTime(time.Now()),
+		Tags: map[string]string{
+			"foo": "bar",
+		},
+	}, nil
+}
+
+func (c testAPIGatewayClient) GetStages(ctx context.Context, params *apigateway.GetStagesInput, optFns ...func(*apigateway.Options)) (*apigateway.GetStagesOutput, error) {
+	return &apigateway.GetStagesOutput{
+		Item: []types.Stage{
+			{
+				StageName:    sources.PtrString("prod"),
+				DeploymentId: sources.PtrString("dep01"),
+			},
+			{
+				StageName:    sources.PtrString("dev"),
+				DeploymentId: sources.PtrString("dep02"),
+			},
+		},
+	}, nil
+}
+
+func TestStageItemMapper(t *testing.T) {
+	stage, err := stageGetFunc(context.Background(), testAPIGatewayClient{}, "052392120703.eu-west-2", "abc123/prod")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := stageItemMapper("052392120703.eu-west-2", stage)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.UniqueAttributeValue() != "abc123/prod" {
+		t.Errorf("expected unique attribute value abc123/prod, got %v", item.UniqueAttributeValue())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "apigateway-rest-api",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "abc123",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "apigateway-deployment",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "abc123/dep01",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "apigateway-deployment",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "abc123/dep02",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "wafv2-web-acl",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:wafv2:eu-west-2:052392120703:regional/webacl/orders/a1b2c3",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "logs-log-group",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:logs:eu-west-2:052392120703:log-group:orders-access",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestStageSearchFunc(t *testing.T) {
+	t.Run("with a stage ARN", func(t *testing.T) {
+		stages, err := stageSearchFunc(context.Background(), testAPIGatewayClient{}, "052392120703.eu-west-2", "arn:aws:apigateway:eu-west-2::/restapis/abc123/stages/prod")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(stages) != 1 {
+			t.Fatalf("expected 1 stage, got %v", len(stages))
+		}
+
+		if *stages[0].Stage.StageName != "prod" || stages[0].RestApiId != "abc123" {
+			t.Errorf("expected stage abc123/prod, got %v/%v", stages[0].RestApiId, *stages[0].Stage.StageName)
+		}
+	})
+
+	t.Run("with a REST API ID", func(t *testing.T) {
+		stages, err := stageSearchFunc(context.Background(), testAPIGatewayClient{}, "052392120703.eu-west-2", "abc123")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(stages) != 2 {
+			t.Fatalf("expected 2 stages, got %v", len(stages))
+		}
+	})
+}
+
+func TestNewStageSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewStageSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -0,0 +1,146 @@
+package apigateway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func vpcLinkGetFunc(ctx context.Context, client APIGatewayClient, scope, query string) (*types.VpcLink, error) {
+	out, err := client.GetVpcLink(ctx, &apigateway.GetVpcLinkInput{
+		VpcLinkId: &query,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.VpcLink{
+		Description:   out.Description,
+		Id:            out.Id,
+		Name:          out.Name,
+		Status:        out.Status,
+		StatusMessage: out.StatusMessage,
+		Tags:          out.Tags,
+		TargetArns:    out.TargetArns,
+	}, nil
+}
+
+func vpcLinkListFunc(ctx context.Context, client APIGatewayClient, scope string) ([]*types.VpcLink, error) {
+	links := make([]*types.VpcLink, 0)
+	input := apigateway.GetVpcLinksInput{}
+
+	for {
+		out, err := client.GetVpcLinks(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range out.Items {
+			links = append(links, &out.Items[i])
+		}
+
+		if out.Position == nil || len(out.Items) == 0 {
+			break
+		}
+
+		input.Position = out.Position
+	}
+
+	return links, nil
+}
+
+// vpcLinkSearchFunc Searches for a VPC link by its ARN, which is in the format
+// arn:aws:apigateway:{region}::/vpclinks/{id}
+func vpcLinkSearchFunc(ctx context.Context, client APIGatewayClient, scope, query string) ([]*types.VpcLink, error) {
+	path, ok := arnPath(query)
+
+	if !ok || len(path) != 2 || path[0] != "vpclinks" {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: fmt.Sprintf("ARN %v is not an API Gateway VPC link", query),
+		}
+	}
+
+	link, err := vpcLinkGetFunc(ctx, client, scope, path[1])
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []*types.VpcLink{link}, nil
+}
+
+func vpcLinkItemMapper(scope string, awsItem *types.VpcLink) (*sdp.Item, error) {
+	attributes, err := sources.ToAttributesCase(awsItem, "tags")
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := sdp.Item{
+		Type:            "apigateway-vpc-link",
+		UniqueAttribute: "id",
+		Attributes:      attributes,
+		Scope:           scope,
+		Tags:            awsItem.Tags,
+	}
+
+	switch awsItem.Status {
+	case types.VpcLinkStatusAvailable:
+		item.Health = sdp.Health_HEALTH_OK.Enum()
+	case types.VpcLinkStatusPending, types.VpcLinkStatusDeleting:
+		item.Health = sdp.Health_HEALTH_PENDING.Enum()
+	case types.VpcLinkStatusFailed:
+		item.Health = sdp.Health_HEALTH_ERROR.Enum()
+	}
+
+	for _, targetARN := range awsItem.TargetArns {
+		if a, err := sources.ParseARN(targetARN); err == nil {
+			// +overmind:link elbv2-load-balancer
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "elbv2-load-balancer",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  targetARN,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Requests are sent through the link to the load balancer
+					In:  true,
+					Out: true,
+				},
+			})
+		}
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type apigateway-vpc-link
+// +overmind:descriptiveType API Gateway VPC Link
+// +overmind:get Get a VPC link by ID
+// +overmind:list List all VPC links
+// +overmind:search Search for a VPC link by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_api_gateway_vpc_link.id
+
+func NewVpcLinkSource(config aws.Config, accountID string, region string) *sources.GetListSource[*types.VpcLink, APIGatewayClient, *apigateway.Options] {
+	return &sources.GetListSource[*types.VpcLink, APIGatewayClient, *apigateway.Options]{
+		ItemType:   "apigateway-vpc-link",
+		Client:     apigateway.NewFromConfig(config),
+		AccountID:  accountID,
+		Region:     region,
+		GetFunc:    vpcLinkGetFunc,
+		ListFunc:   vpcLinkListFunc,
+		SearchFunc: vpcLinkSearchFunc,
+		ItemMapper: vpcLinkItemMapper,
+	}
+}
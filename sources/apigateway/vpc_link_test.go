@@ -0,0 +1,80 @@
+package apigateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testAPIGatewayClient) GetVpcLink(ctx context.Context, params *apigateway.GetVpcLinkInput, optFns ...func(*apigateway.Options)) (*apigateway.GetVpcLinkOutput, error) {
+	return &apigateway.GetVpcLinkOutput{
+		Id:     params.VpcLinkId,
+		Name:   sources.PtrString("orders"),
+		Status: types.VpcLinkStatusAvailable,
+		TargetArns: []string{
+			"arn:aws:elasticloadbalancing:eu-west-2:052392120703:loadbalancer/net/orders/0123456789abcdef", // link
+		},
+	}, nil
+}
+
+func (c testAPIGatewayClient) GetVpcLinks(ctx context.Context, params *apigateway.GetVpcLinksInput, optFns ...func(*apigateway.Options)) (*apigateway.GetVpcLinksOutput, error) {
+	return &apigateway.GetVpcLinksOutput{
+		Items: []types.VpcLink{
+			{
+				Id:     sources.PtrString("vl0123"),
+				Status: types.VpcLinkStatusAvailable,
+			},
+		},
+	}, nil
+}
+
+func TestVpcLinkItemMapper(t *testing.T) {
+	link, err := vpcLinkGetFunc(context.Background(), testAPIGatewayClient{}, "052392120703.eu-west-2", "vl0123")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := vpcLinkItemMapper("052392120703.eu-west-2", link)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.GetHealth() != sdp.Health_HEALTH_OK {
+		t.Errorf("expected health to be OK, got %v", item.GetHealth())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "elbv2-load-balancer",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:elasticloadbalancing:eu-west-2:052392120703:loadbalancer/net/orders/0123456789abcdef",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestNewVpcLinkSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewVpcLinkSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -0,0 +1,154 @@
+package apigatewayv2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func apiGetFunc(ctx context.Context, client APIGatewayV2Client, scope, query string) (*types.Api, error) {
+	out, err := client.GetApi(ctx, &apigatewayv2.GetApiInput{
+		ApiId: &query,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Api{
+		ApiEndpoint:               out.ApiEndpoint,
+		ApiGatewayManaged:         out.ApiGatewayManaged,
+		ApiId:                     out.ApiId,
+		ApiKeySelectionExpression: out.ApiKeySelectionExpression,
+		CorsConfiguration:         out.CorsConfiguration,
+		CreatedDate:               out.CreatedDate,
+		Description:               out.Description,
+		DisableExecuteApiEndpoint: out.DisableExecuteApiEndpoint,
+		DisableSchemaValidation:   out.DisableSchemaValidation,
+		ImportInfo:                out.ImportInfo,
+		Name:                      out.Name,
+		ProtocolType:              out.ProtocolType,
+		RouteSelectionExpression:  out.RouteSelectionExpression,
+		Tags:                      out.Tags,
+		Version:                   out.Version,
+		Warnings:                  out.Warnings,
+	}, nil
+}
+
+func apiListFunc(ctx context.Context, client APIGatewayV2Client, scope string) ([]*types.Api, error) {
+	apis, err := listAPIs(ctx, client)
+
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*types.Api, 0, len(apis))
+
+	for i := range apis {
+		items = append(items, &apis[i])
+	}
+
+	return items, nil
+}
+
+// apiSearchFunc Searches for an API by its ARN, or by an execute-api ARN such
+// as the ones used in Lambda resource policies
+func apiSearchFunc(ctx context.Context, client APIGatewayV2Client, scope, query string) ([]*types.Api, error) {
+	id, err := apiIDFromQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	api, err := apiGetFunc(ctx, client, scope, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []*types.Api{api}, nil
+}
+
+func apiItemMapper(scope string, awsItem *types.Api) (*sdp.Item, error) {
+	attributes, err := sources.ToAttributesCase(awsItem, "tags")
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := sdp.Item{
+		Type:            "apigatewayv2-api",
+		UniqueAttribute: "apiId",
+		Attributes:      attributes,
+		Scope:           scope,
+		Tags:            awsItem.Tags,
+	}
+
+	if awsItem.ApiId != nil {
+		// +overmind:link apigatewayv2-route
+		// +overmind:link apigatewayv2-integration
+		// +overmind:link apigatewayv2-stage
+		// +overmind:link apigatewayv2-deployment
+		// +overmind:link apigatewayv2-authorizer
+		for _, childType := range []string{"apigatewayv2-route", "apigatewayv2-integration", "apigatewayv2-stage", "apigatewayv2-deployment", "apigatewayv2-authorizer"} {
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   childType,
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *awsItem.ApiId,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// The API and its children are tightly coupled
+					In:  true,
+					Out: true,
+				},
+			})
+		}
+	}
+
+	if awsItem.ApiEndpoint != nil && awsItem.ProtocolType == types.ProtocolTypeHttp {
+		// +overmind:link http
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "http",
+				Method: sdp.QueryMethod_GET,
+				Query:  *awsItem.ApiEndpoint,
+				Scope:  "global",
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// These are tightly linked
+				In:  true,
+				Out: true,
+			},
+		})
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type apigatewayv2-api
+// +overmind:descriptiveType API Gateway v2 API
+// +overmind:get Get an HTTP or WebSocket API by ID
+// +overmind:list List all HTTP and WebSocket APIs
+// +overmind:search Search for an API by ARN, or by an execute-api ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_apigatewayv2_api.id
+
+func NewAPISource(config aws.Config, accountID string, region string) *sources.GetListSource[*types.Api, APIGatewayV2Client, *apigatewayv2.Options] {
+	return &sources.GetListSource[*types.Api, APIGatewayV2Client, *apigatewayv2.Options]{
+		ItemType:   "apigatewayv2-api",
+		Client:     apigatewayv2.NewFromConfig(config),
+		AccountID:  accountID,
+		Region:     region,
+		GetFunc:    apiGetFunc,
+		ListFunc:   apiListFunc,
+		SearchFunc: apiSearchFunc,
+		ItemMapper: apiItemMapper,
+	}
+}
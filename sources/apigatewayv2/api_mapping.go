@@ -0,0 +1,201 @@
+package apigatewayv2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+type APIMappingDetails struct {
+	ApiMapping *types.ApiMapping
+
+	// The custom domain name that the mapping belongs to
+	DomainName string
+}
+
+func apiMappingGetFunc(ctx context.Context, client APIGatewayV2Client, scope, query string) (*APIMappingDetails, error) {
+	domainName, mappingID, err := parseChildQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := client.GetApiMapping(ctx, &apigatewayv2.GetApiMappingInput{
+		DomainName:   &domainName,
+		ApiMappingId: &mappingID,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &APIMappingDetails{
+		ApiMapping: &types.ApiMapping{
+			ApiId:         out.ApiId,
+			ApiMappingId:  out.ApiMappingId,
+			ApiMappingKey: out.ApiMappingKey,
+			Stage:         out.Stage,
+		},
+		DomainName: domainName,
+	}, nil
+}
+
+// listAPIMappings Lists all API mappings for a given domain name
+func listAPIMappings(ctx context.Context, client APIGatewayV2Client, domainName string) ([]*APIMappingDetails, error) {
+	mappings := make([]*APIMappingDetails, 0)
+	input := apigatewayv2.GetApiMappingsInput{
+		DomainName: &domainName,
+	}
+
+	for {
+		out, err := client.GetApiMappings(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range out.Items {
+			mappings = append(mappings, &APIMappingDetails{
+				ApiMapping: &out.Items[i],
+				DomainName: domainName,
+			})
+		}
+
+		if out.NextToken == nil {
+			break
+		}
+
+		input.NextToken = out.NextToken
+	}
+
+	return mappings, nil
+}
+
+func apiMappingListFunc(ctx context.Context, client APIGatewayV2Client, scope string) ([]*APIMappingDetails, error) {
+	domainNames, err := listDomainNames(ctx, client)
+
+	if err != nil {
+		return nil, err
+	}
+
+	mappings := make([]*APIMappingDetails, 0)
+
+	for _, domainName := range domainNames {
+		if domainName.DomainName == nil {
+			continue
+		}
+
+		domainMappings, err := listAPIMappings(ctx, client, *domainName.DomainName)
+
+		if err != nil {
+			return nil, err
+		}
+
+		mappings = append(mappings, domainMappings...)
+	}
+
+	return mappings, nil
+}
+
+// apiMappingSearchFunc Searches for API mappings by the custom domain name
+// that they belong to
+func apiMappingSearchFunc(ctx context.Context, client APIGatewayV2Client, scope, query string) ([]*APIMappingDetails, error) {
+	return listAPIMappings(ctx, client, query)
+}
+
+func apiMappingItemMapper(scope string, awsItem *APIMappingDetails) (*sdp.Item, error) {
+	enrichedMapping := struct {
+		*types.ApiMapping
+		DomainName string
+	}{
+		ApiMapping: awsItem.ApiMapping,
+		DomainName: awsItem.DomainName,
+	}
+
+	attributes, err := sources.ToAttributesCase(enrichedMapping)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if awsItem.ApiMapping.ApiMappingId != nil {
+		err = attributes.Set("uniqueName", awsItem.DomainName+"/"+*awsItem.ApiMapping.ApiMappingId)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	item := sdp.Item{
+		Type:            "apigatewayv2-api-mapping",
+		UniqueAttribute: "uniqueName",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	// +overmind:link apigatewayv2-domain-name
+	item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+		Query: &sdp.Query{
+			Type:   "apigatewayv2-domain-name",
+			Method: sdp.QueryMethod_GET,
+			Query:  awsItem.DomainName,
+			Scope:  scope,
+		},
+		BlastPropagation: &sdp.BlastPropagation{
+			// The mapping is part of the domain
+			In:  true,
+			Out: true,
+		},
+	})
+
+	if awsItem.ApiMapping.ApiId != nil {
+		// +overmind:link apigatewayv2-api
+		item.LinkedItemQueries = append(item.LinkedItemQueries, apiLink(scope, *awsItem.ApiMapping.ApiId))
+
+		if awsItem.ApiMapping.Stage != nil {
+			// +overmind:link apigatewayv2-stage
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "apigatewayv2-stage",
+					Method: sdp.QueryMethod_GET,
+					Query:  *awsItem.ApiMapping.ApiId + "/" + *awsItem.ApiMapping.Stage,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Requests to the mapping key are served by the stage
+					In:  true,
+					Out: true,
+				},
+			})
+		}
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type apigatewayv2-api-mapping
+// +overmind:descriptiveType API Gateway v2 API Mapping
+// +overmind:get Get an API mapping by {domainName}/{apiMappingId}
+// +overmind:list List all API mappings for all custom domain names
+// +overmind:search Search for API mappings by custom domain name
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_apigatewayv2_api_mapping.domain_name
+// +overmind:terraform:method SEARCH
+
+func NewAPIMappingSource(config aws.Config, accountID string, region string) *sources.GetListSource[*APIMappingDetails, APIGatewayV2Client, *apigatewayv2.Options] {
+	return &sources.GetListSource[*APIMappingDetails, APIGatewayV2Client, *apigatewayv2.Options]{
+		ItemType:   "apigatewayv2-api-mapping",
+		Client:     apigatewayv2.NewFromConfig(config),
+		AccountID:  accountID,
+		Region:     region,
+		GetFunc:    apiMappingGetFunc,
+		ListFunc:   apiMappingListFunc,
+		SearchFunc: apiMappingSearchFunc,
+		ItemMapper: apiMappingItemMapper,
+	}
+}
@@ -0,0 +1,103 @@
+package apigatewayv2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testAPIGatewayV2Client) GetApiMapping(ctx context.Context, params *apigatewayv2.GetApiMappingInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetApiMappingOutput, error) {
+	return &apigatewayv2.GetApiMappingOutput{
+		ApiMappingId:  params.ApiMappingId,
+		ApiMappingKey: sources.PtrString("orders"),
+		ApiId:         sources.PtrString("a1b2c3d4e5"), // link
+		Stage:         sources.PtrString("$default"),   // link
+	}, nil
+}
+
+func (c testAPIGatewayV2Client) GetApiMappings(ctx context.Context, params *apigatewayv2.GetApiMappingsInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetApiMappingsOutput, error) {
+	return &apigatewayv2.GetApiMappingsOutput{
+		Items: []types.ApiMapping{
+			{
+				ApiMappingId: sources.PtrString("map01"),
+				ApiId:        sources.PtrString("a1b2c3d4e5"),
+				Stage:        sources.PtrString("$default"),
+			},
+		},
+	}, nil
+}
+
+func TestAPIMappingItemMapper(t *testing.T) {
+	mapping, err := apiMappingGetFunc(context.Background(), testAPIGatewayV2Client{}, "052392120703.eu-west-2", "api.example.com/map01")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := apiMappingItemMapper("052392120703.eu-west-2", mapping)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.UniqueAttributeValue() != "api.example.com/map01" {
+		t.Errorf("expected unique attribute value api.example.com/map01, got %v", item.UniqueAttributeValue())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "apigatewayv2-domain-name",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "api.example.com",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "apigatewayv2-api",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "a1b2c3d4e5",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "apigatewayv2-stage",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "a1b2c3d4e5/$default",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestAPIMappingListFunc(t *testing.T) {
+	mappings, err := apiMappingListFunc(context.Background(), testAPIGatewayV2Client{}, "052392120703.eu-west-2")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(mappings) != 1 || mappings[0].DomainName != "api.example.com" {
+		t.Errorf("expected 1 mapping for api.example.com, got %v", mappings)
+	}
+}
+
+func TestNewAPIMappingSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewAPIMappingSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
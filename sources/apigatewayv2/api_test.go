@@ -0,0 +1,126 @@
+package apigatewayv2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testAPIGatewayV2Client) GetApi(ctx context.Context, params *apigatewayv2.GetApiInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetApiOutput, error) {
+	return &apigatewayv2.GetApiOutput{
+		ApiId:                    params.ApiId,
+		ApiEndpoint:              sources.PtrString("https://a1b2c3d4e5.execute-api.eu-west-2.amazonaws.com"), // link
+		Name:                     sources.PtrString("orders"),
+		ProtocolType:             types.ProtocolTypeHttp,
+		RouteSelectionExpression: sources.PtrString("$request.method $request.path"),
+		CreatedDate:              sources.PtrTime(time.Now()),
+		Tags: map[string]string{
+			"foo": "bar",
+		},
+	}, nil
+}
+
+func (c testAPIGatewayV2Client) GetApis(ctx context.Context, params *apigatewayv2.GetApisInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetApisOutput, error) {
+	return &apigatewayv2.GetApisOutput{
+		Items: []types.Api{
+			{
+				ApiId:        sources.PtrString("a1b2c3d4e5"),
+				Name:         sources.PtrString("orders"),
+				ProtocolType: types.ProtocolTypeHttp,
+			},
+		},
+	}, nil
+}
+
+func TestAPIItemMapper(t *testing.T) {
+	api, err := apiGetFunc(context.Background(), testAPIGatewayV2Client{}, "052392120703.eu-west-2", "a1b2c3d4e5")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := apiItemMapper("052392120703.eu-west-2", api)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.GetTags()["foo"] != "bar" {
+		t.Errorf("expected tag foo=bar, got %v", item.GetTags())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "apigatewayv2-route",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "a1b2c3d4e5",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "apigatewayv2-integration",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "a1b2c3d4e5",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "apigatewayv2-stage",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "a1b2c3d4e5",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "apigatewayv2-deployment",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "a1b2c3d4e5",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "apigatewayv2-authorizer",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "a1b2c3d4e5",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "http",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "https://a1b2c3d4e5.execute-api.eu-west-2.amazonaws.com",
+			ExpectedScope:  "global",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestAPISearchFunc(t *testing.T) {
+	apis, err := apiSearchFunc(context.Background(), testAPIGatewayV2Client{}, "052392120703.eu-west-2", "arn:aws:execute-api:eu-west-2:052392120703:a1b2c3d4e5/*/*/orders")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(apis) != 1 || *apis[0].ApiId != "a1b2c3d4e5" {
+		t.Errorf("expected to find API a1b2c3d4e5, got %v", apis)
+	}
+}
+
+func TestNewAPISource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewAPISource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -0,0 +1,208 @@
+package apigatewayv2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+type AuthorizerDetails struct {
+	Authorizer *types.Authorizer
+
+	// The ID of the API that the authorizer belongs to
+	ApiId string
+}
+
+func authorizerGetFunc(ctx context.Context, client APIGatewayV2Client, scope, query string) (*AuthorizerDetails, error) {
+	apiID, authorizerID, err := parseChildQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := client.GetAuthorizer(ctx, &apigatewayv2.GetAuthorizerInput{
+		ApiId:        &apiID,
+		AuthorizerId: &authorizerID,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthorizerDetails{
+		Authorizer: &types.Authorizer{
+			AuthorizerCredentialsArn:       out.AuthorizerCredentialsArn,
+			AuthorizerId:                   out.AuthorizerId,
+			AuthorizerPayloadFormatVersion: out.AuthorizerPayloadFormatVersion,
+			AuthorizerResultTtlInSeconds:   out.AuthorizerResultTtlInSeconds,
+			AuthorizerType:                 out.AuthorizerType,
+			AuthorizerUri:                  out.AuthorizerUri,
+			EnableSimpleResponses:          out.EnableSimpleResponses,
+			IdentitySource:                 out.IdentitySource,
+			IdentityValidationExpression:   out.IdentityValidationExpression,
+			JwtConfiguration:               out.JwtConfiguration,
+			Name:                           out.Name,
+		},
+		ApiId: apiID,
+	}, nil
+}
+
+// listAuthorizers Lists all authorizers in a given API
+func listAuthorizers(ctx context.Context, client APIGatewayV2Client, apiID string) ([]*AuthorizerDetails, error) {
+	authorizers := make([]*AuthorizerDetails, 0)
+	input := apigatewayv2.GetAuthorizersInput{
+		ApiId: &apiID,
+	}
+
+	for {
+		out, err := client.GetAuthorizers(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range out.Items {
+			authorizers = append(authorizers, &AuthorizerDetails{
+				Authorizer: &out.Items[i],
+				ApiId:      apiID,
+			})
+		}
+
+		if out.NextToken == nil {
+			break
+		}
+
+		input.NextToken = out.NextToken
+	}
+
+	return authorizers, nil
+}
+
+func authorizerListFunc(ctx context.Context, client APIGatewayV2Client, scope string) ([]*AuthorizerDetails, error) {
+	apis, err := listAPIs(ctx, client)
+
+	if err != nil {
+		return nil, err
+	}
+
+	authorizers := make([]*AuthorizerDetails, 0)
+
+	for _, api := range apis {
+		if api.ApiId == nil {
+			continue
+		}
+
+		apiAuthorizers, err := listAuthorizers(ctx, client, *api.ApiId)
+
+		if err != nil {
+			return nil, err
+		}
+
+		authorizers = append(authorizers, apiAuthorizers...)
+	}
+
+	return authorizers, nil
+}
+
+// authorizerSearchFunc Searches for authorizers by the ID or ARN of the API
+// that they belong to
+func authorizerSearchFunc(ctx context.Context, client APIGatewayV2Client, scope, query string) ([]*AuthorizerDetails, error) {
+	apiID, err := apiIDFromQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return listAuthorizers(ctx, client, apiID)
+}
+
+func authorizerItemMapper(scope string, awsItem *AuthorizerDetails) (*sdp.Item, error) {
+	enrichedAuthorizer := struct {
+		*types.Authorizer
+		ApiId string
+	}{
+		Authorizer: awsItem.Authorizer,
+		ApiId:      awsItem.ApiId,
+	}
+
+	attributes, err := sources.ToAttributesCase(enrichedAuthorizer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if awsItem.Authorizer.AuthorizerId != nil {
+		err = attributes.Set("uniqueName", awsItem.ApiId+"/"+*awsItem.Authorizer.AuthorizerId)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	item := sdp.Item{
+		Type:            "apigatewayv2-authorizer",
+		UniqueAttribute: "uniqueName",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	// +overmind:link apigatewayv2-api
+	item.LinkedItemQueries = append(item.LinkedItemQueries, apiLink(scope, awsItem.ApiId))
+
+	if link := lambdaURILink(awsItem.Authorizer.AuthorizerUri); link != nil {
+		// +overmind:link lambda-function
+		item.LinkedItemQueries = append(item.LinkedItemQueries, link)
+	}
+
+	if link := roleLink(awsItem.Authorizer.AuthorizerCredentialsArn); link != nil {
+		// +overmind:link iam-role
+		item.LinkedItemQueries = append(item.LinkedItemQueries, link)
+	}
+
+	if awsItem.Authorizer.JwtConfiguration != nil && awsItem.Authorizer.JwtConfiguration.Issuer != nil {
+		// +overmind:link http
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "http",
+				Method: sdp.QueryMethod_GET,
+				Query:  *awsItem.Authorizer.JwtConfiguration.Issuer,
+				Scope:  "global",
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Changing the issuer will affect which tokens are valid
+				In: true,
+				// Changing the authorizer won't affect the issuer
+				Out: false,
+			},
+		})
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type apigatewayv2-authorizer
+// +overmind:descriptiveType API Gateway v2 Authorizer
+// +overmind:get Get an authorizer by {apiId}/{authorizerId}
+// +overmind:list List all authorizers in all APIs
+// +overmind:search Search for authorizers by API ID or ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_apigatewayv2_authorizer.api_id
+// +overmind:terraform:method SEARCH
+
+func NewAuthorizerSource(config aws.Config, accountID string, region string) *sources.GetListSource[*AuthorizerDetails, APIGatewayV2Client, *apigatewayv2.Options] {
+	return &sources.GetListSource[*AuthorizerDetails, APIGatewayV2Client, *apigatewayv2.Options]{
+		ItemType:   "apigatewayv2-authorizer",
+		Client:     apigatewayv2.NewFromConfig(config),
+		AccountID:  accountID,
+		Region:     region,
+		GetFunc:    authorizerGetFunc,
+		ListFunc:   authorizerListFunc,
+		SearchFunc: authorizerSearchFunc,
+		ItemMapper: authorizerItemMapper,
+	}
+}
@@ -0,0 +1,89 @@
+package apigatewayv2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testAPIGatewayV2Client) GetAuthorizer(ctx context.Context, params *apigatewayv2.GetAuthorizerInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetAuthorizerOutput, error) {
+	return &apigatewayv2.GetAuthorizerOutput{
+		AuthorizerId:   params.AuthorizerId,
+		Name:           sources.PtrString("orders-jwt"),
+		AuthorizerType: types.AuthorizerTypeJwt,
+		IdentitySource: []string{
+			"$request.header.Authorization",
+		},
+		JwtConfiguration: &types.JWTConfiguration{
+			Audience: []string{
+				"orders",
+			},
+			Issuer: sources.PtrString("https://cognito-idp.eu-west-2.amazonaws.com/eu-west-2_abc123"), // link
+		},
+	}, nil
+}
+
+func (c testAPIGatewayV2Client) GetAuthorizers(ctx context.Context, params *apigatewayv2.GetAuthorizersInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetAuthorizersOutput, error) {
+	return &apigatewayv2.GetAuthorizersOutput{
+		Items: []types.Authorizer{
+			{
+				AuthorizerId:   sources.PtrString("auth01"),
+				Name:           sources.PtrString("orders-jwt"),
+				AuthorizerType: types.AuthorizerTypeJwt,
+			},
+		},
+	}, nil
+}
+
+func TestAuthorizerItemMapper(t *testing.T) {
+	authorizer, err := authorizerGetFunc(context.Background(), testAPIGatewayV2Client{}, "052392120703.eu-west-2", "a1b2c3d4e5/auth01")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := authorizerItemMapper("052392120703.eu-west-2", authorizer)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "apigatewayv2-api",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "a1b2c3d4e5",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "http",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "https://cognito-idp.eu-west-2.amazonaws.com/eu-west-2_abc123",
+			ExpectedScope:  "global",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestNewAuthorizerSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewAuthorizerSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -0,0 +1,184 @@
+package apigatewayv2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+type DeploymentDetails struct {
+	Deployment *types.Deployment
+
+	// The ID of the API that the deployment belongs to
+	ApiId string
+}
+
+func deploymentGetFunc(ctx context.Context, client APIGatewayV2Client, scope, query string) (*DeploymentDetails, error) {
+	apiID, deploymentID, err := parseChildQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := client.GetDeployment(ctx, &apigatewayv2.GetDeploymentInput{
+		ApiId:        &apiID,
+		DeploymentId: &deploymentID,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeploymentDetails{
+		Deployment: &types.Deployment{
+			AutoDeployed:            out.AutoDeployed,
+			CreatedDate:             out.CreatedDate,
+			DeploymentId:            out.DeploymentId,
+			DeploymentStatus:        out.DeploymentStatus,
+			DeploymentStatusMessage: out.DeploymentStatusMessage,
+			Description:             out.Description,
+		},
+		ApiId: apiID,
+	}, nil
+}
+
+// listDeployments Lists all deployments of a given API
+func listDeployments(ctx context.Context, client APIGatewayV2Client, apiID string) ([]*DeploymentDetails, error) {
+	deployments := make([]*DeploymentDetails, 0)
+	input := apigatewayv2.GetDeploymentsInput{
+		ApiId: &apiID,
+	}
+
+	for {
+		out, err := client.GetDeployments(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range out.Items {
+			deployments = append(deployments, &DeploymentDetails{
+				Deployment: &out.Items[i],
+				ApiId:      apiID,
+			})
+		}
+
+		if out.NextToken == nil {
+			break
+		}
+
+		input.NextToken = out.NextToken
+	}
+
+	return deployments, nil
+}
+
+func deploymentListFunc(ctx context.Context, client APIGatewayV2Client, scope string) ([]*DeploymentDetails, error) {
+	apis, err := listAPIs(ctx, client)
+
+	if err != nil {
+		return nil, err
+	}
+
+	deployments := make([]*DeploymentDetails, 0)
+
+	for _, api := range apis {
+		if api.ApiId == nil {
+			continue
+		}
+
+		apiDeployments, err := listDeployments(ctx, client, *api.ApiId)
+
+		if err != nil {
+			return nil, err
+		}
+
+		deployments = append(deployments, apiDeployments...)
+	}
+
+	return deployments, nil
+}
+
+// deploymentSearchFunc Searches for deployments by the ID or ARN of the API
+// that they belong to
+func deploymentSearchFunc(ctx context.Context, client APIGatewayV2Client, scope, query string) ([]*DeploymentDetails, error) {
+	apiID, err := apiIDFromQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return listDeployments(ctx, client, apiID)
+}
+
+func deploymentItemMapper(scope string, awsItem *DeploymentDetails) (*sdp.Item, error) {
+	enrichedDeployment := struct {
+		*types.Deployment
+		ApiId string
+	}{
+		Deployment: awsItem.Deployment,
+		ApiId:      awsItem.ApiId,
+	}
+
+	attributes, err := sources.ToAttributesCase(enrichedDeployment)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if awsItem.Deployment.DeploymentId != nil {
+		err = attributes.Set("uniqueName", awsItem.ApiId+"/"+*awsItem.Deployment.DeploymentId)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	item := sdp.Item{
+		Type:            "apigatewayv2-deployment",
+		UniqueAttribute: "uniqueName",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	switch awsItem.Deployment.DeploymentStatus {
+	case types.DeploymentStatusDeployed:
+		item.Health = sdp.Health_HEALTH_OK.Enum()
+	case types.DeploymentStatusPending:
+		item.Health = sdp.Health_HEALTH_PENDING.Enum()
+	case types.DeploymentStatusFailed:
+		item.Health = sdp.Health_HEALTH_ERROR.Enum()
+	}
+
+	// +overmind:link apigatewayv2-api
+	item.LinkedItemQueries = append(item.LinkedItemQueries, apiLink(scope, awsItem.ApiId))
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type apigatewayv2-deployment
+// +overmind:descriptiveType API Gateway v2 Deployment
+// +overmind:get Get a deployment by {apiId}/{deploymentId}
+// +overmind:list List all deployments of all APIs
+// +overmind:search Search for deployments by API ID or ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_apigatewayv2_deployment.api_id
+// +overmind:terraform:method SEARCH
+
+func NewDeploymentSource(config aws.Config, accountID string, region string) *sources.GetListSource[*DeploymentDetails, APIGatewayV2Client, *apigatewayv2.Options] {
+	return &sources.GetListSource[*DeploymentDetails, APIGatewayV2Client, *apigatewayv2.Options]{
+		ItemType:   "apigatewayv2-deployment",
+		Client:     apigatewayv2.NewFromConfig(config),
+		AccountID:  accountID,
+		Region:     region,
+		GetFunc:    deploymentGetFunc,
+		ListFunc:   deploymentListFunc,
+		SearchFunc: deploymentSearchFunc,
+		ItemMapper: deploymentItemMapper,
+	}
+}
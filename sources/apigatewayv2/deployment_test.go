@@ -0,0 +1,78 @@
+package apigatewayv2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testAPIGatewayV2Client) GetDeployment(ctx context.Context, params *apigatewayv2.GetDeploymentInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetDeploymentOutput, error) {
+	return &apigatewayv2.GetDeploymentOutput{
+		DeploymentId:     params.DeploymentId,
+		AutoDeployed:     sources.PtrBool(true),
+		DeploymentStatus: types.DeploymentStatusDeployed,
+		CreatedDate:      sources.PtrTime(time.Now()),
+	}, nil
+}
+
+func (c testAPIGatewayV2Client) GetDeployments(ctx context.Context, params *apigatewayv2.GetDeploymentsInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetDeploymentsOutput, error) {
+	return &apigatewayv2.GetDeploymentsOutput{
+		Items: []types.Deployment{
+			{
+				DeploymentId:     sources.PtrString("dep01"),
+				DeploymentStatus: types.DeploymentStatusDeployed,
+			},
+		},
+	}, nil
+}
+
+func TestDeploymentItemMapper(t *testing.T) {
+	deployment, err := deploymentGetFunc(context.Background(), testAPIGatewayV2Client{}, "052392120703.eu-west-2", "a1b2c3d4e5/dep01")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := deploymentItemMapper("052392120703.eu-west-2", deployment)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.GetHealth() != sdp.Health_HEALTH_OK {
+		t.Errorf("expected health to be OK, got %v", item.GetHealth())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "apigatewayv2-api",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "a1b2c3d4e5",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestNewDeploymentSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewDeploymentSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -0,0 +1,227 @@
+package apigatewayv2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func domainNameGetFunc(ctx context.Context, client APIGatewayV2Client, scope, query string) (*types.DomainName, error) {
+	out, err := client.GetDomainName(ctx, &apigatewayv2.GetDomainNameInput{
+		DomainName: &query,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.DomainName{
+		ApiMappingSelectionExpression: out.ApiMappingSelectionExpression,
+		DomainName:                    out.DomainName,
+		DomainNameConfigurations:      out.DomainNameConfigurations,
+		MutualTlsAuthentication:       out.MutualTlsAuthentication,
+		Tags:                          out.Tags,
+	}, nil
+}
+
+// listDomainNames Lists all custom domain names in the region
+func listDomainNames(ctx context.Context, client APIGatewayV2Client) ([]*types.DomainName, error) {
+	domainNames := make([]*types.DomainName, 0)
+	input := apigatewayv2.GetDomainNamesInput{}
+
+	for {
+		out, err := client.GetDomainNames(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range out.Items {
+			domainNames = append(domainNames, &out.Items[i])
+		}
+
+		if out.NextToken == nil {
+			break
+		}
+
+		input.NextToken = out.NextToken
+	}
+
+	return domainNames, nil
+}
+
+func domainNameListFunc(ctx context.Context, client APIGatewayV2Client, scope string) ([]*types.DomainName, error) {
+	return listDomainNames(ctx, client)
+}
+
+// domainNameSearchFunc Searches for a domain name by its ARN, which is in the
+// format arn:aws:apigateway:{region}::/domainnames/{domainName}
+func domainNameSearchFunc(ctx context.Context, client APIGatewayV2Client, scope, query string) ([]*types.DomainName, error) {
+	path, ok := arnPath(query)
+
+	if !ok || len(path) != 2 || path[0] != "domainnames" {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: fmt.Sprintf("ARN %v is not an API Gateway domain name", query),
+		}
+	}
+
+	domainName, err := domainNameGetFunc(ctx, client, scope, path[1])
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []*types.DomainName{domainName}, nil
+}
+
+func domainNameItemMapper(scope string, awsItem *types.DomainName) (*sdp.Item, error) {
+	attributes, err := sources.ToAttributesCase(awsItem, "tags")
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := sdp.Item{
+		Type:            "apigatewayv2-domain-name",
+		UniqueAttribute: "domainName",
+		Attributes:      attributes,
+		Scope:           scope,
+		Tags:            awsItem.Tags,
+	}
+
+	if awsItem.DomainName != nil {
+		// The records that point the custom domain at API Gateway
+		//
+		// +overmind:link dns
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "dns",
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  *awsItem.DomainName,
+				Scope:  "global",
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// DNS always links
+				In:  true,
+				Out: true,
+			},
+		})
+
+		// The Route 53 records that alias the custom domain to API Gateway
+		//
+		// +overmind:link route53-resource-record-set
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "route53-resource-record-set",
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  *awsItem.DomainName,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Changing the records won't affect the domain
+				In: false,
+				// Changing the domain will affect what the records resolve to
+				Out: true,
+			},
+		})
+
+		// +overmind:link apigatewayv2-api-mapping
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "apigatewayv2-api-mapping",
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  *awsItem.DomainName,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// The mappings route requests for the domain to APIs
+				In:  true,
+				Out: true,
+			},
+		})
+	}
+
+	// A domain name has one configuration per endpoint, each with its own
+	// certificate and target domain
+	for _, config := range awsItem.DomainNameConfigurations {
+		switch config.DomainNameStatus {
+		case types.DomainNameStatusAvailable:
+			if item.Health == nil {
+				item.Health = sdp.Health_HEALTH_OK.Enum()
+			}
+		case types.DomainNameStatusUpdating, types.DomainNameStatusPendingCertificateReimport, types.DomainNameStatusPendingOwnershipVerification:
+			item.Health = sdp.Health_HEALTH_PENDING.Enum()
+		}
+
+		for _, certificateARN := range []*string{config.CertificateArn, config.OwnershipVerificationCertificateArn} {
+			if certificateARN == nil {
+				continue
+			}
+
+			if a, err := sources.ParseARN(*certificateARN); err == nil {
+				// +overmind:link acm-certificate
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "acm-certificate",
+						Method: sdp.QueryMethod_SEARCH,
+						Query:  *certificateARN,
+						Scope:  sources.FormatScope(a.AccountID, a.Region),
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Changing the certificate will affect the domain
+						In: true,
+						// Changing the domain won't affect the certificate
+						Out: false,
+					},
+				})
+			}
+		}
+
+		if config.ApiGatewayDomainName != nil {
+			// +overmind:link dns
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "dns",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *config.ApiGatewayDomainName,
+					Scope:  "global",
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// DNS always links
+					In:  true,
+					Out: true,
+				},
+			})
+		}
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type apigatewayv2-domain-name
+// +overmind:descriptiveType API Gateway v2 Domain Name
+// +overmind:get Get a custom domain name by name
+// +overmind:list List all custom domain names
+// +overmind:search Search for a custom domain name by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_apigatewayv2_domain_name.domain_name
+
+func NewDomainNameSource(config aws.Config, accountID string, region string) *sources.GetListSource[*types.DomainName, APIGatewayV2Client, *apigatewayv2.Options] {
+	return &sources.GetListSource[*types.DomainName, APIGatewayV2Client, *apigatewayv2.Options]{
+		ItemType:   "apigatewayv2-domain-name",
+		Client:     apigatewayv2.NewFromConfig(config),
+		AccountID:  accountID,
+		Region:     region,
+		GetFunc:    domainNameGetFunc,
+		ListFunc:   domainNameListFunc,
+		SearchFunc: domainNameSearchFunc,
+		ItemMapper: domainNameItemMapper,
+	}
+}
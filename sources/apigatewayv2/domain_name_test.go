@@ -0,0 +1,119 @@
+package apigatewayv2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testAPIGatewayV2Client) GetDomainName(ctx context.Context, params *apigatewayv2.GetDomainNameInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetDomainNameOutput, error) {
+	return &apigatewayv2.GetDomainNameOutput{
+		DomainName: params.DomainName,
+		DomainNameConfigurations: []types.DomainNameConfiguration{
+			{
+				ApiGatewayDomainName: sources.PtrString("d-abc123.execute-api.eu-west-2.amazonaws.com"),        // link
+				CertificateArn:       sources.PtrString("arn:aws:acm:eu-west-2:052392120703:certificate/0123"), // link
+				HostedZoneId:         sources.PtrString("ZJ5UAJN8Y3Z2Q"),
+				DomainNameStatus:     types.DomainNameStatusAvailable,
+				EndpointType:         types.EndpointTypeRegional,
+				SecurityPolicy:       types.SecurityPolicyTls12,
+			},
+		},
+		Tags: map[string]string{
+			"foo": "bar",
+		},
+	}, nil
+}
+
+func (c testAPIGatewayV2Client) GetDomainNames(ctx context.Context, params *apigatewayv2.GetDomainNamesInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetDomainNamesOutput, error) {
+	return &apigatewayv2.GetDomainNamesOutput{
+		Items: []types.DomainName{
+			{
+				DomainName: sources.PtrString("api.example.com"),
+			},
+		},
+	}, nil
+}
+
+func TestDomainNameItemMapper(t *testing.T) {
+	domainName, err := domainNameGetFunc(context.Background(), testAPIGatewayV2Client{}, "052392120703.eu-west-2", "api.example.com")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := domainNameItemMapper("052392120703.eu-west-2", domainName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.GetHealth() != sdp.Health_HEALTH_OK {
+		t.Errorf("expected health to be OK, got %v", item.GetHealth())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "dns",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "api.example.com",
+			ExpectedScope:  "global",
+		},
+		{
+			ExpectedType:   "route53-resource-record-set",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "api.example.com",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "apigatewayv2-api-mapping",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "api.example.com",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "acm-certificate",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:acm:eu-west-2:052392120703:certificate/0123",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "dns",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "d-abc123.execute-api.eu-west-2.amazonaws.com",
+			ExpectedScope:  "global",
+		},
+	}
+
+	tests.Execute(t, item)
+
+	// The hosted zones that API Gateway returns are owned by AWS, so they
+	// shouldn't be linked
+	for _, link := range item.GetLinkedItemQueries() {
+		if link.GetQuery().GetType() == "route53-hosted-zone" {
+			t.Errorf("expected no hosted zone links, got %v", link.GetQuery())
+		}
+	}
+}
+
+func TestNewDomainNameSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewDomainNameSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
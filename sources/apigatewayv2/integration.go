@@ -0,0 +1,287 @@
+package apigatewayv2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+type IntegrationDetails struct {
+	Integration *types.Integration
+
+	// The ID of the API that the integration belongs to
+	ApiId string
+}
+
+func integrationGetFunc(ctx context.Context, client APIGatewayV2Client, scope, query string) (*IntegrationDetails, error) {
+	apiID, integrationID, err := parseChildQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := client.GetIntegration(ctx, &apigatewayv2.GetIntegrationInput{
+		ApiId:         &apiID,
+		IntegrationId: &integrationID,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &IntegrationDetails{
+		Integration: &types.Integration{
+			ApiGatewayManaged:                      out.ApiGatewayManaged,
+			ConnectionId:                           out.ConnectionId,
+			ConnectionType:                         out.ConnectionType,
+			ContentHandlingStrategy:                out.ContentHandlingStrategy,
+			CredentialsArn:                         out.CredentialsArn,
+			Description:                            out.Description,
+			IntegrationId:                          out.IntegrationId,
+			IntegrationMethod:                      out.IntegrationMethod,
+			IntegrationResponseSelectionExpression: out.IntegrationResponseSelectionExpression,
+			IntegrationSubtype:                     out.IntegrationSubtype,
+			IntegrationType:                        out.IntegrationType,
+			IntegrationUri:                         out.IntegrationUri,
+			PassthroughBehavior:                    out.PassthroughBehavior,
+			PayloadFormatVersion:                   out.PayloadFormatVersion,
+			RequestParameters:                      out.RequestParameters,
+			RequestTemplates:                       out.RequestTemplates,
+			ResponseParameters:                     out.ResponseParameters,
+			TemplateSelectionExpression:            out.TemplateSelectionExpression,
+			TimeoutInMillis:                        out.TimeoutInMillis,
+			TlsConfig:                              out.TlsConfig,
+		},
+		ApiId: apiID,
+	}, nil
+}
+
+// listIntegrations Lists all integrations in a given API
+func listIntegrations(ctx context.Context, client APIGatewayV2Client, apiID string) ([]*IntegrationDetails, error) {
+	integrations := make([]*IntegrationDetails, 0)
+	input := apigatewayv2.GetIntegrationsInput{
+		ApiId: &apiID,
+	}
+
+	for {
+		out, err := client.GetIntegrations(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range out.Items {
+			integrations = append(integrations, &IntegrationDetails{
+				Integration: &out.Items[i],
+				ApiId:       apiID,
+			})
+		}
+
+		if out.NextToken == nil {
+			break
+		}
+
+		input.NextToken = out.NextToken
+	}
+
+	return integrations, nil
+}
+
+func integrationListFunc(ctx context.Context, client APIGatewayV2Client, scope string) ([]*IntegrationDetails, error) {
+	apis, err := listAPIs(ctx, client)
+
+	if err != nil {
+		return nil, err
+	}
+
+	integrations := make([]*IntegrationDetails, 0)
+
+	for _, api := range apis {
+		if api.ApiId == nil {
+			continue
+		}
+
+		apiIntegrations, err := listIntegrations(ctx, client, *api.ApiId)
+
+		if err != nil {
+			return nil, err
+		}
+
+		integrations = append(integrations, apiIntegrations...)
+	}
+
+	return integrations, nil
+}
+
+// integrationSearchFunc Searches for integrations by the ID or ARN of the API
+// that they belong to
+func integrationSearchFunc(ctx context.Context, client APIGatewayV2Client, scope, query string) ([]*IntegrationDetails, error) {
+	apiID, err := apiIDFromQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return listIntegrations(ctx, client, apiID)
+}
+
+// integrationURILink Returns a link to the backend that an integration sends
+// requests to. Depending on the type of integration the URI can be a Lambda
+// function, a load balancer listener or Cloud Map service behind a VPC link,
+// or a plain HTTP endpoint
+func integrationURILink(integration *types.Integration) *sdp.LinkedItemQuery {
+	if integration.IntegrationUri == nil {
+		return nil
+	}
+
+	uri := *integration.IntegrationUri
+
+	switch integration.IntegrationType {
+	case types.IntegrationTypeAws, types.IntegrationTypeAwsProxy:
+		return lambdaURILink(integration.IntegrationUri)
+	case types.IntegrationTypeHttp, types.IntegrationTypeHttpProxy:
+		if integration.ConnectionType == types.ConnectionTypeVpcLink {
+			a, err := sources.ParseARN(uri)
+
+			if err != nil {
+				return nil
+			}
+
+			query := &sdp.Query{
+				Query: uri,
+				Scope: sources.FormatScope(a.AccountID, a.Region),
+			}
+
+			switch a.Service {
+			case "elasticloadbalancing":
+				query.Type = "elbv2-listener"
+				query.Method = sdp.QueryMethod_GET
+			case "servicediscovery":
+				query.Type = "servicediscovery-service"
+				query.Method = sdp.QueryMethod_SEARCH
+			default:
+				return nil
+			}
+
+			return &sdp.LinkedItemQuery{
+				Query: query,
+				BlastPropagation: &sdp.BlastPropagation{
+					// The backend handles the requests, so changes to either
+					// will affect the other
+					In:  true,
+					Out: true,
+				},
+			}
+		}
+
+		return &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "http",
+				Method: sdp.QueryMethod_GET,
+				Query:  uri,
+				Scope:  "global",
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// The endpoint handles the requests, so changes to either will
+				// affect the other
+				In:  true,
+				Out: true,
+			},
+		}
+	}
+
+	return nil
+}
+
+func integrationItemMapper(scope string, awsItem *IntegrationDetails) (*sdp.Item, error) {
+	enrichedIntegration := struct {
+		*types.Integration
+		ApiId string
+	}{
+		Integration: awsItem.Integration,
+		ApiId:       awsItem.ApiId,
+	}
+
+	attributes, err := sources.ToAttributesCase(enrichedIntegration)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if awsItem.Integration.IntegrationId != nil {
+		err = attributes.Set("uniqueName", awsItem.ApiId+"/"+*awsItem.Integration.IntegrationId)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	item := sdp.Item{
+		Type:            "apigatewayv2-integration",
+		UniqueAttribute: "uniqueName",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	// +overmind:link apigatewayv2-api
+	item.LinkedItemQueries = append(item.LinkedItemQueries, apiLink(scope, awsItem.ApiId))
+
+	// +overmind:link lambda-function
+	// +overmind:link elbv2-listener
+	// +overmind:link servicediscovery-service
+	// +overmind:link http
+	if link := integrationURILink(awsItem.Integration); link != nil {
+		item.LinkedItemQueries = append(item.LinkedItemQueries, link)
+	}
+
+	if awsItem.Integration.ConnectionType == types.ConnectionTypeVpcLink && awsItem.Integration.ConnectionId != nil {
+		// +overmind:link apigatewayv2-vpc-link
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "apigatewayv2-vpc-link",
+				Method: sdp.QueryMethod_GET,
+				Query:  *awsItem.Integration.ConnectionId,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Requests are sent through the VPC link
+				In:  true,
+				Out: true,
+			},
+		})
+	}
+
+	if link := roleLink(awsItem.Integration.CredentialsArn); link != nil {
+		// +overmind:link iam-role
+		item.LinkedItemQueries = append(item.LinkedItemQueries, link)
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type apigatewayv2-integration
+// +overmind:descriptiveType API Gateway v2 Integration
+// +overmind:get Get an integration by {apiId}/{integrationId}
+// +overmind:list List all integrations in all APIs
+// +overmind:search Search for integrations by API ID or ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_apigatewayv2_integration.api_id
+// +overmind:terraform:method SEARCH
+
+func NewIntegrationSource(config aws.Config, accountID string, region string) *sources.GetListSource[*IntegrationDetails, APIGatewayV2Client, *apigatewayv2.Options] {
+	return &sources.GetListSource[*IntegrationDetails, APIGatewayV2Client, *apigatewayv2.Options]{
+		ItemType:   "apigatewayv2-integration",
+		Client:     apigatewayv2.NewFromConfig(config),
+		AccountID:  accountID,
+		Region:     region,
+		GetFunc:    integrationGetFunc,
+		ListFunc:   integrationListFunc,
+		SearchFunc: integrationSearchFunc,
+		ItemMapper: integrationItemMapper,
+	}
+}
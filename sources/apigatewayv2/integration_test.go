@@ -0,0 +1,138 @@
+package apigatewayv2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testAPIGatewayV2Client) GetIntegration(ctx context.Context, params *apigatewayv2.GetIntegrationInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetIntegrationOutput, error) {
+	return &apigatewayv2.GetIntegrationOutput{
+		IntegrationId:        params.IntegrationId,
+		IntegrationType:      types.IntegrationTypeAwsProxy,
+		IntegrationUri:       sources.PtrString("arn:aws:lambda:eu-west-2:052392120703:function:orders"), // link
+		PayloadFormatVersion: sources.PtrString("2.0"),
+		CredentialsArn:       sources.PtrString("arn:aws:iam::052392120703:role/apigateway-invoke"), // link
+		TimeoutInMillis:      sources.PtrInt32(30000),
+	}, nil
+}
+
+func (c testAPIGatewayV2Client) GetIntegrations(ctx context.Context, params *apigatewayv2.GetIntegrationsInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetIntegrationsOutput, error) {
+	return &apigatewayv2.GetIntegrationsOutput{
+		Items: []types.Integration{
+			{
+				IntegrationId:   sources.PtrString("int0123"),
+				IntegrationType: types.IntegrationTypeAwsProxy,
+			},
+		},
+	}, nil
+}
+
+func TestIntegrationItemMapper(t *testing.T) {
+	integration, err := integrationGetFunc(context.Background(), testAPIGatewayV2Client{}, "052392120703.eu-west-2", "a1b2c3d4e5/int0123")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := integrationItemMapper("052392120703.eu-west-2", integration)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "apigatewayv2-api",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "a1b2c3d4e5",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "lambda-function",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:lambda:eu-west-2:052392120703:function:orders",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "iam-role",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:iam::052392120703:role/apigateway-invoke",
+			ExpectedScope:  "052392120703",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestIntegrationURILink(t *testing.T) {
+	t.Run("with a load balancer listener behind a VPC link", func(t *testing.T) {
+		link := integrationURILink(&types.Integration{
+			IntegrationType: types.IntegrationTypeHttpProxy,
+			ConnectionType:  types.ConnectionTypeVpcLink,
+			IntegrationUri:  sources.PtrString("arn:aws:elasticloadbalancing:eu-west-2:052392120703:listener/app/orders/50dc6c495c0c9188/f2f7dc8efc522ab2"),
+		})
+
+		if link == nil {
+			t.Fatal("expected a link, got nil")
+		}
+
+		if link.GetQuery().GetType() != "elbv2-listener" || link.GetQuery().GetMethod() != sdp.QueryMethod_GET {
+			t.Errorf("expected elbv2-listener GET, got %v %v", link.GetQuery().GetType(), link.GetQuery().GetMethod())
+		}
+	})
+
+	t.Run("with a Cloud Map service behind a VPC link", func(t *testing.T) {
+		link := integrationURILink(&types.Integration{
+			IntegrationType: types.IntegrationTypeHttpProxy,
+			ConnectionType:  types.ConnectionTypeVpcLink,
+			IntegrationUri:  sources.PtrString("arn:aws:servicediscovery:eu-west-2:052392120703:service/srv-abc123"),
+		})
+
+		if link == nil {
+			t.Fatal("expected a link, got nil")
+		}
+
+		if link.GetQuery().GetType() != "servicediscovery-service" || link.GetQuery().GetMethod() != sdp.QueryMethod_SEARCH {
+			t.Errorf("expected servicediscovery-service SEARCH, got %v %v", link.GetQuery().GetType(), link.GetQuery().GetMethod())
+		}
+	})
+
+	t.Run("with a public HTTP endpoint", func(t *testing.T) {
+		link := integrationURILink(&types.Integration{
+			IntegrationType: types.IntegrationTypeHttpProxy,
+			ConnectionType:  types.ConnectionTypeInternet,
+			IntegrationUri:  sources.PtrString("https://example.com/orders"),
+		})
+
+		if link == nil {
+			t.Fatal("expected a link, got nil")
+		}
+
+		if link.GetQuery().GetType() != "http" || link.GetQuery().GetScope() != "global" {
+			t.Errorf("expected global http link, got %v %v", link.GetQuery().GetType(), link.GetQuery().GetScope())
+		}
+	})
+}
+
+func TestNewIntegrationSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewIntegrationSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -0,0 +1,221 @@
+package apigatewayv2
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+type RouteDetails struct {
+	Route *types.Route
+
+	// The ID of the API that the route belongs to
+	ApiId string
+}
+
+func routeGetFunc(ctx context.Context, client APIGatewayV2Client, scope, query string) (*RouteDetails, error) {
+	apiID, routeID, err := parseChildQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := client.GetRoute(ctx, &apigatewayv2.GetRouteInput{
+		ApiId:   &apiID,
+		RouteId: &routeID,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &RouteDetails{
+		Route: &types.Route{
+			ApiGatewayManaged:                out.ApiGatewayManaged,
+			ApiKeyRequired:                   out.ApiKeyRequired,
+			AuthorizationScopes:              out.AuthorizationScopes,
+			AuthorizationType:                out.AuthorizationType,
+			AuthorizerId:                     out.AuthorizerId,
+			ModelSelectionExpression:         out.ModelSelectionExpression,
+			OperationName:                    out.OperationName,
+			RequestModels:                    out.RequestModels,
+			RequestParameters:                out.RequestParameters,
+			RouteId:                          out.RouteId,
+			RouteKey:                         out.RouteKey,
+			RouteResponseSelectionExpression: out.RouteResponseSelectionExpression,
+			Target:                           out.Target,
+		},
+		ApiId: apiID,
+	}, nil
+}
+
+// listRoutes Lists all routes in a given API
+func listRoutes(ctx context.Context, client APIGatewayV2Client, apiID string) ([]*RouteDetails, error) {
+	routes := make([]*RouteDetails, 0)
+	input := apigatewayv2.GetRoutesInput{
+		ApiId: &apiID,
+	}
+
+	for {
+		out, err := client.GetRoutes(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range out.Items {
+			routes = append(routes, &RouteDetails{
+				Route: &out.Items[i],
+				ApiId: apiID,
+			})
+		}
+
+		if out.NextToken == nil {
+			break
+		}
+
+		input.NextToken = out.NextToken
+	}
+
+	return routes, nil
+}
+
+func routeListFunc(ctx context.Context, client APIGatewayV2Client, scope string) ([]*RouteDetails, error) {
+	apis, err := listAPIs(ctx, client)
+
+	if err != nil {
+		return nil, err
+	}
+
+	routes := make([]*RouteDetails, 0)
+
+	for _, api := range apis {
+		if api.ApiId == nil {
+			continue
+		}
+
+		apiRoutes, err := listRoutes(ctx, client, *api.ApiId)
+
+		if err != nil {
+			return nil, err
+		}
+
+		routes = append(routes, apiRoutes...)
+	}
+
+	return routes, nil
+}
+
+// routeSearchFunc Searches for routes by the ID or ARN of the API that they
+// belong to
+func routeSearchFunc(ctx context.Context, client APIGatewayV2Client, scope, query string) ([]*RouteDetails, error) {
+	apiID, err := apiIDFromQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return listRoutes(ctx, client, apiID)
+}
+
+func routeItemMapper(scope string, awsItem *RouteDetails) (*sdp.Item, error) {
+	enrichedRoute := struct {
+		*types.Route
+		ApiId string
+	}{
+		Route: awsItem.Route,
+		ApiId: awsItem.ApiId,
+	}
+
+	attributes, err := sources.ToAttributesCase(enrichedRoute)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if awsItem.Route.RouteId != nil {
+		err = attributes.Set("uniqueName", awsItem.ApiId+"/"+*awsItem.Route.RouteId)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	item := sdp.Item{
+		Type:            "apigatewayv2-route",
+		UniqueAttribute: "uniqueName",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	// +overmind:link apigatewayv2-api
+	item.LinkedItemQueries = append(item.LinkedItemQueries, apiLink(scope, awsItem.ApiId))
+
+	// Targets are in the format integrations/{integrationId}
+	if awsItem.Route.Target != nil {
+		if integrationID, found := strings.CutPrefix(*awsItem.Route.Target, "integrations/"); found {
+			// +overmind:link apigatewayv2-integration
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "apigatewayv2-integration",
+					Method: sdp.QueryMethod_GET,
+					Query:  awsItem.ApiId + "/" + integrationID,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Requests to the route are handled by the integration
+					In:  true,
+					Out: true,
+				},
+			})
+		}
+	}
+
+	if awsItem.Route.AuthorizerId != nil {
+		// +overmind:link apigatewayv2-authorizer
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "apigatewayv2-authorizer",
+				Method: sdp.QueryMethod_GET,
+				Query:  awsItem.ApiId + "/" + *awsItem.Route.AuthorizerId,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Changing the authorizer will affect who can call the route
+				In: true,
+				// Changing the route won't affect the authorizer
+				Out: false,
+			},
+		})
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type apigatewayv2-route
+// +overmind:descriptiveType API Gateway v2 Route
+// +overmind:get Get a route by {apiId}/{routeId}
+// +overmind:list List all routes in all APIs
+// +overmind:search Search for routes by API ID or ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_apigatewayv2_route.api_id
+// +overmind:terraform:method SEARCH
+
+func NewRouteSource(config aws.Config, accountID string, region string) *sources.GetListSource[*RouteDetails, APIGatewayV2Client, *apigatewayv2.Options] {
+	return &sources.GetListSource[*RouteDetails, APIGatewayV2Client, *apigatewayv2.Options]{
+		ItemType:   "apigatewayv2-route",
+		Client:     apigatewayv2.NewFromConfig(config),
+		AccountID:  accountID,
+		Region:     region,
+		GetFunc:    routeGetFunc,
+		ListFunc:   routeListFunc,
+		SearchFunc: routeSearchFunc,
+		ItemMapper: routeItemMapper,
+	}
+}
@@ -0,0 +1,91 @@
+package apigatewayv2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testAPIGatewayV2Client) GetRoute(ctx context.Context, params *apigatewayv2.GetRouteInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetRouteOutput, error) {
+	return &apigatewayv2.GetRouteOutput{
+		RouteId:           params.RouteId,
+		RouteKey:          sources.PtrString("GET /orders"),
+		AuthorizationType: types.AuthorizationTypeJwt,
+		AuthorizerId:      sources.PtrString("auth01"),               // link
+		Target:            sources.PtrString("integrations/int0123"), // link
+	}, nil
+}
+
+func (c testAPIGatewayV2Client) GetRoutes(ctx context.Context, params *apigatewayv2.GetRoutesInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetRoutesOutput, error) {
+	return &apigatewayv2.GetRoutesOutput{
+		Items: []types.Route{
+			{
+				RouteId:  sources.PtrString("rt0123"),
+				RouteKey: sources.PtrString("GET /orders"),
+			},
+		},
+	}, nil
+}
+
+func TestRouteItemMapper(t *testing.T) {
+	route, err := routeGetFunc(context.Background(), testAPIGatewayV2Client{}, "052392120703.eu-west-2", "a1b2c3d4e5/rt0123")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := routeItemMapper("052392120703.eu-west-2", route)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.UniqueAttributeValue() != "a1b2c3d4e5/rt0123" {
+		t.Errorf("expected unique attribute value a1b2c3d4e5/rt0123, got %v", item.UniqueAttributeValue())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "apigatewayv2-api",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "a1b2c3d4e5",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "apigatewayv2-integration",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "a1b2c3d4e5/int0123",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "apigatewayv2-authorizer",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "a1b2c3d4e5/auth01",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestNewRouteSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewRouteSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
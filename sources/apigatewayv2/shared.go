@@ -0,0 +1,225 @@
+package apigatewayv2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+// APIGatewayV2Client Represents the client we need to talk to API Gateway v2,
+// usually this is *apigatewayv2.Client
+type APIGatewayV2Client interface {
+	GetApi(ctx context.Context, params *apigatewayv2.GetApiInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetApiOutput, error)
+	GetApiMapping(ctx context.Context, params *apigatewayv2.GetApiMappingInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetApiMappingOutput, error)
+	GetApiMappings(ctx context.Context, params *apigatewayv2.GetApiMappingsInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetApiMappingsOutput, error)
+	GetApis(ctx context.Context, params *apigatewayv2.GetApisInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetApisOutput, error)
+	GetAuthorizer(ctx context.Context, params *apigatewayv2.GetAuthorizerInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetAuthorizerOutput, error)
+	GetAuthorizers(ctx context.Context, params *apigatewayv2.GetAuthorizersInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetAuthorizersOutput, error)
+	GetDeployment(ctx context.Context, params *apigatewayv2.GetDeploymentInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetDeploymentOutput, error)
+	GetDeployments(ctx context.Context, params *apigatewayv2.GetDeploymentsInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetDeploymentsOutput, error)
+	GetDomainName(ctx context.Context, params *apigatewayv2.GetDomainNameInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetDomainNameOutput, error)
+	GetDomainNames(ctx context.Context, params *apigatewayv2.GetDomainNamesInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetDomainNamesOutput, error)
+	GetIntegration(ctx context.Context, params *apigatewayv2.GetIntegrationInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetIntegrationOutput, error)
+	GetIntegrations(ctx context.Context, params *apigatewayv2.GetIntegrationsInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetIntegrationsOutput, error)
+	GetRoute(ctx context.Context, params *apigatewayv2.GetRouteInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetRouteOutput, error)
+	GetRoutes(ctx context.Context, params *apigatewayv2.GetRoutesInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetRoutesOutput, error)
+	GetStage(ctx context.Context, params *apigatewayv2.GetStageInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetStageOutput, error)
+	GetStages(ctx context.Context, params *apigatewayv2.GetStagesInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetStagesOutput, error)
+	GetVpcLink(ctx context.Context, params *apigatewayv2.GetVpcLinkInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetVpcLinkOutput, error)
+	GetVpcLinks(ctx context.Context, params *apigatewayv2.GetVpcLinksInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetVpcLinksOutput, error)
+}
+
+// parseChildQuery Splits a query in the format {parent}/{child} into its
+// parts. This is used for resources that only exist within an API or domain
+// name, neither of which can contain a slash
+func parseChildQuery(query string) (parent string, child string, err error) {
+	parent, child, found := strings.Cut(query, "/")
+
+	if !found || parent == "" || child == "" {
+		return "", "", &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: fmt.Sprintf("query %v must be in the format {parent}/{child}", query),
+		}
+	}
+
+	return parent, child, nil
+}
+
+// arnPath Returns the sections of the path in an API Gateway ARN. These are in
+// the format arn:aws:apigateway:{region}::/apis/{id}/stages/{name} so the
+// path for this example would be [apis, {id}, stages, {name}]
+func arnPath(query string) ([]string, bool) {
+	a, err := sources.ParseARN(query)
+
+	if err != nil || a.Service != "apigateway" {
+		return nil, false
+	}
+
+	return strings.Split(strings.TrimPrefix(a.Resource, "/"), "/"), true
+}
+
+// apiIDFromQuery Returns the ID of an API from a query that is either the ID
+// itself, the ARN of the API or one of its children, or an execute-api ARN.
+// Execute-api ARNs are used in resource policies and are in the format
+// arn:aws:execute-api:{region}:{account}:{apiId}/{stage}/{route}
+func apiIDFromQuery(query string) (string, error) {
+	a, err := sources.ParseARN(query)
+
+	if err != nil {
+		// This isn't an ARN so assume that it's an ID
+		return query, nil
+	}
+
+	switch a.Service {
+	case "execute-api":
+		id, _, _ := strings.Cut(a.Resource, "/")
+
+		return id, nil
+	case "apigateway":
+		path, _ := arnPath(query)
+
+		if len(path) >= 2 && path[0] == "apis" {
+			return path[1], nil
+		}
+	}
+
+	return "", &sdp.QueryError{
+		ErrorType:   sdp.QueryError_NOTFOUND,
+		ErrorString: fmt.Sprintf("ARN %v does not refer to an API", query),
+	}
+}
+
+// listAPIs Lists all HTTP and WebSocket APIs in the region
+func listAPIs(ctx context.Context, client APIGatewayV2Client) ([]types.Api, error) {
+	apis := make([]types.Api, 0)
+	input := apigatewayv2.GetApisInput{}
+
+	for {
+		out, err := client.GetApis(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		apis = append(apis, out.Items...)
+
+		if out.NextToken == nil {
+			break
+		}
+
+		input.NextToken = out.NextToken
+	}
+
+	return apis, nil
+}
+
+// apiLink Returns a link to the API that a child resource belongs to
+func apiLink(scope string, apiID string) *sdp.LinkedItemQuery {
+	return &sdp.LinkedItemQuery{
+		Query: &sdp.Query{
+			Type:   "apigatewayv2-api",
+			Method: sdp.QueryMethod_GET,
+			Query:  apiID,
+			Scope:  scope,
+		},
+		BlastPropagation: &sdp.BlastPropagation{
+			// The API and its children are tightly coupled
+			In:  true,
+			Out: true,
+		},
+	}
+}
+
+// lambdaFunctionARNFromURI Extracts the ARN of a Lambda function from an
+// integration or authorizer URI. This can either be the ARN of the function
+// itself, or an invocation URI in the format:
+// arn:aws:apigateway:{region}:lambda:path/2015-03-31/functions/{functionArn}/invocations
+func lambdaFunctionARNFromURI(uri string) (string, bool) {
+	if a, err := sources.ParseARN(uri); err == nil && a.Service == "lambda" {
+		return uri, true
+	}
+
+	_, rest, found := strings.Cut(uri, ":lambda:path/")
+
+	if !found {
+		return "", false
+	}
+
+	_, rest, found = strings.Cut(rest, "/functions/")
+
+	if !found {
+		return "", false
+	}
+
+	functionARN, _, found := strings.Cut(rest, "/invocations")
+
+	return functionARN, found
+}
+
+// lambdaURILink Returns a link to the Lambda function referenced in an
+// integration or authorizer URI, if there is one
+func lambdaURILink(uri *string) *sdp.LinkedItemQuery {
+	if uri == nil {
+		return nil
+	}
+
+	functionARN, ok := lambdaFunctionARNFromURI(*uri)
+
+	if !ok {
+		return nil
+	}
+
+	a, err := sources.ParseARN(functionARN)
+
+	if err != nil {
+		return nil
+	}
+
+	return &sdp.LinkedItemQuery{
+		Query: &sdp.Query{
+			Type:   "lambda-function",
+			Method: sdp.QueryMethod_SEARCH,
+			Query:  functionARN,
+			Scope:  sources.FormatScope(a.AccountID, a.Region),
+		},
+		BlastPropagation: &sdp.BlastPropagation{
+			// The function handles the requests, so changes to either will
+			// affect the other
+			In:  true,
+			Out: true,
+		},
+	}
+}
+
+// roleLink Returns a link to an IAM role that API Gateway assumes
+func roleLink(roleARN *string) *sdp.LinkedItemQuery {
+	if roleARN == nil {
+		return nil
+	}
+
+	a, err := sources.ParseARN(*roleARN)
+
+	if err != nil {
+		return nil
+	}
+
+	return &sdp.LinkedItemQuery{
+		Query: &sdp.Query{
+			Type:   "iam-role",
+			Method: sdp.QueryMethod_SEARCH,
+			Query:  *roleARN,
+			Scope:  sources.FormatScope(a.AccountID, a.Region),
+		},
+		BlastPropagation: &sdp.BlastPropagation{
+			// Changing the role will affect what API Gateway can invoke
+			In: true,
+			// Changing API Gateway won't affect the role
+			Out: false,
+		},
+	}
+}
@@ -0,0 +1,95 @@
+package apigatewayv2
+
+import (
+	"testing"
+)
+
+type testAPIGatewayV2Client struct{}
+
+func TestAPIIDFromQuery(t *testing.T) {
+	tests := []struct {
+		Query       string
+		ExpectedID  string
+		ExpectError bool
+	}{
+		{
+			Query:      "a1b2c3d4e5",
+			ExpectedID: "a1b2c3d4e5",
+		},
+		{
+			Query:      "arn:aws:apigateway:eu-west-2::/apis/a1b2c3d4e5",
+			ExpectedID: "a1b2c3d4e5",
+		},
+		{
+			Query:      "arn:aws:apigateway:eu-west-2::/apis/a1b2c3d4e5/stages/$default",
+			ExpectedID: "a1b2c3d4e5",
+		},
+		{
+			Query:      "arn:aws:execute-api:eu-west-2:052392120703:a1b2c3d4e5/*/*/orders",
+			ExpectedID: "a1b2c3d4e5",
+		},
+		{
+			Query:       "arn:aws:apigateway:eu-west-2::/vpclinks/vl0123",
+			ExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Query, func(t *testing.T) {
+			id, err := apiIDFromQuery(test.Query)
+
+			if test.ExpectError {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if id != test.ExpectedID {
+				t.Errorf("expected ID %v, got %v", test.ExpectedID, id)
+			}
+		})
+	}
+}
+
+func TestLambdaFunctionARNFromURI(t *testing.T) {
+	tests := []struct {
+		URI         string
+		ExpectedARN string
+		ExpectOK    bool
+	}{
+		{
+			URI:         "arn:aws:lambda:eu-west-2:052392120703:function:orders",
+			ExpectedARN: "arn:aws:lambda:eu-west-2:052392120703:function:orders",
+			ExpectOK:    true,
+		},
+		{
+			URI:         "arn:aws:apigateway:eu-west-2:lambda:path/2015-03-31/functions/arn:aws:lambda:eu-west-2:052392120703:function:orders/invocations",
+			ExpectedARN: "arn:aws:lambda:eu-west-2:052392120703:function:orders",
+			ExpectOK:    true,
+		},
+		{
+			URI:      "https://example.com/orders",
+			ExpectOK: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.URI, func(t *testing.T) {
+			arn, ok := lambdaFunctionARNFromURI(test.URI)
+
+			if ok != test.ExpectOK {
+				t.Fatalf("expected ok to be %v, got %v", test.ExpectOK, ok)
+			}
+
+			if arn != test.ExpectedARN {
+				t.Errorf("expected ARN %v, got %v", test.ExpectedARN, arn)
+			}
+		})
+	}
+}
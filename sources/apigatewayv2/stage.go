@@ -0,0 +1,233 @@
+package apigatewayv2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+type StageDetails struct {
+	Stage *types.Stage
+
+	// The ID of the API that the stage belongs to
+	ApiId string
+}
+
+func stageGetFunc(ctx context.Context, client APIGatewayV2Client, scope, query string) (*StageDetails, error) {
+	apiID, stageName, err := parseChildQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := client.GetStage(ctx, &apigatewayv2.GetStageInput{
+		ApiId:     &apiID,
+		StageName: &stageName,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &StageDetails{
+		Stage: &types.Stage{
+			AccessLogSettings:           out.AccessLogSettings,
+			ApiGatewayManaged:           out.ApiGatewayManaged,
+			AutoDeploy:                  out.AutoDeploy,
+			ClientCertificateId:         out.ClientCertificateId,
+			CreatedDate:                 out.CreatedDate,
+			DefaultRouteSettings:        out.DefaultRouteSettings,
+			DeploymentId:                out.DeploymentId,
+			Description:                 out.Description,
+			LastDeploymentStatusMessage: out.LastDeploymentStatusMessage,
+			LastUpdatedDate:             out.LastUpdatedDate,
+			RouteSettings:               out.RouteSettings,
+			StageName:                   out.StageName,
+			StageVariables:              out.StageVariables,
+			Tags:                        out.Tags,
+		},
+		ApiId: apiID,
+	}, nil
+}
+
+// listStages Lists all stages in a given API
+func listStages(ctx context.Context, client APIGatewayV2Client, apiID string) ([]*StageDetails, error) {
+	stages := make([]*StageDetails, 0)
+	input := apigatewayv2.GetStagesInput{
+		ApiId: &apiID,
+	}
+
+	for {
+		out, err := client.GetStages(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range out.Items {
+			stages = append(stages, &StageDetails{
+				Stage: &out.Items[i],
+				ApiId: apiID,
+			})
+		}
+
+		if out.NextToken == nil {
+			break
+		}
+
+		input.NextToken = out.NextToken
+	}
+
+	return stages, nil
+}
+
+func stageListFunc(ctx context.Context, client APIGatewayV2Client, scope string) ([]*StageDetails, error) {
+	apis, err := listAPIs(ctx, client)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stages := make([]*StageDetails, 0)
+
+	for _, api := range apis {
+		if api.ApiId == nil {
+			continue
+		}
+
+		apiStages, err := listStages(ctx, client, *api.ApiId)
+
+		if err != nil {
+			return nil, err
+		}
+
+		stages = append(stages, apiStages...)
+	}
+
+	return stages, nil
+}
+
+// stageSearchFunc Searches for stages by the ARN of the stage itself, or by
+// the ID or ARN of the API that they belong to
+func stageSearchFunc(ctx context.Context, client APIGatewayV2Client, scope, query string) ([]*StageDetails, error) {
+	if path, ok := arnPath(query); ok && len(path) == 4 && path[0] == "apis" && path[2] == "stages" {
+		stage, err := stageGetFunc(ctx, client, scope, path[1]+"/"+path[3])
+
+		if err != nil {
+			return nil, err
+		}
+
+		return []*StageDetails{stage}, nil
+	}
+
+	apiID, err := apiIDFromQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return listStages(ctx, client, apiID)
+}
+
+func stageItemMapper(scope string, awsItem *StageDetails) (*sdp.Item, error) {
+	enrichedStage := struct {
+		*types.Stage
+		ApiId string
+	}{
+		Stage: awsItem.Stage,
+		ApiId: awsItem.ApiId,
+	}
+
+	attributes, err := sources.ToAttributesCase(enrichedStage, "tags")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if awsItem.Stage.StageName != nil {
+		err = attributes.Set("uniqueName", awsItem.ApiId+"/"+*awsItem.Stage.StageName)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	item := sdp.Item{
+		Type:            "apigatewayv2-stage",
+		UniqueAttribute: "uniqueName",
+		Attributes:      attributes,
+		Scope:           scope,
+		Tags:            awsItem.Stage.Tags,
+	}
+
+	// +overmind:link apigatewayv2-api
+	item.LinkedItemQueries = append(item.LinkedItemQueries, apiLink(scope, awsItem.ApiId))
+
+	if awsItem.Stage.DeploymentId != nil {
+		// +overmind:link apigatewayv2-deployment
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "apigatewayv2-deployment",
+				Method: sdp.QueryMethod_GET,
+				Query:  awsItem.ApiId + "/" + *awsItem.Stage.DeploymentId,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// The deployment is what the stage serves
+				In: true,
+				// Changing the stage won't affect the deployment
+				Out: false,
+			},
+		})
+	}
+
+	if awsItem.Stage.AccessLogSettings != nil && awsItem.Stage.AccessLogSettings.DestinationArn != nil {
+		if a, err := sources.ParseARN(*awsItem.Stage.AccessLogSettings.DestinationArn); err == nil {
+			// +overmind:link logs-log-group
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "logs-log-group",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *awsItem.Stage.AccessLogSettings.DestinationArn,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the log group could mean that access logs are
+					// lost
+					In: true,
+					// Access logs are sent to the log group
+					Out: true,
+				},
+			})
+		}
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type apigatewayv2-stage
+// +overmind:descriptiveType API Gateway v2 Stage
+// +overmind:get Get a stage by {apiId}/{stageName}
+// +overmind:list List all stages in all APIs
+// +overmind:search Search for stages by ARN, or by API ID or ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_apigatewayv2_stage.arn
+// +overmind:terraform:method SEARCH
+
+func NewStageSource(config aws.Config, accountID string, region string) *sources.GetListSource[*StageDetails, APIGatewayV2Client, *apigatewayv2.Options] {
+	return &sources.GetListSource[*StageDetails, APIGatewayV2Client, *apigatewayv2.Options]{
+		ItemType:   "apigatewayv2-stage",
+		Client:     apigatewayv2.NewFromConfig(config),
+		AccountID:  accountID,
+		Region:     region,
+		GetFunc:    stageGetFunc,
+		ListFunc:   stageListFunc,
+		SearchFunc: stageSearchFunc,
+		ItemMapper: stageItemMapper,
+	}
+}
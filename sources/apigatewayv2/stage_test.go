@@ -0,0 +1,131 @@
+package apigatewayv2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testAPIGatewayV2Client) GetStage(ctx context.Context, params *apigatewayv2.GetStageInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetStageOutput, error) {
+	return &apigatewayv2.GetStageOutput{
+		StageName:    params.StageName,
+		AutoDeploy:   sources.PtrBool(true),
+		DeploymentId: sources.PtrString("dep01"), // link
+		AccessLogSettings: &types.AccessLogSettings{
+			DestinationArn: sources.PtrString("arn:aws:logs:eu-west-2:052392120703:log-group:orders-access"), // link
+			Format:         sources.PtrString("$context.requestId"),
+		},
+		CreatedDate: sources.PtrTime(time.Now()),
+		Tags: map[string]string{
+			"foo": "bar",
+		},
+	}, nil
+}
+
+func (c testAPIGatewayV2Client) GetStages(ctx context.Context, params *apigatewayv2.GetStagesInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetStagesOutput, error) {
+	return &apigatewayv2.GetStagesOutput{
+		Items: []types.Stage{
+			{
+				StageName:    sources.PtrString("$default"),
+				DeploymentId: sources.PtrString("dep01"),
+			},
+			{
+				StageName:    sources.PtrString("dev"),
+				DeploymentId: sources.PtrString("dep02"),
+			},
+		},
+	}, nil
+}
+
+func TestStageItemMapper(t *testing.T) {
+	stage, err := stageGetFunc(context.Background(), testAPIGatewayV2Client{}, "052392120703.eu-west-2", "a1b2c3d4e5/$default")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := stageItemMapper("052392120703.eu-west-2", stage)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.UniqueAttributeValue() != "a1b2c3d4e5/$default" {
+		t.Errorf("expected unique attribute value a1b2c3d4e5/$default, got %v", item.UniqueAttributeValue())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "apigatewayv2-api",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "a1b2c3d4e5",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "apigatewayv2-deployment",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "a1b2c3d4e5/dep01",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "logs-log-group",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:logs:eu-west-2:052392120703:log-group:orders-access",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestStageSearchFunc(t *testing.T) {
+	t.Run("with a stage ARN", func(t *testing.T) {
+		stages, err := stageSearchFunc(context.Background(), testAPIGatewayV2Client{}, "052392120703.eu-west-2", "arn:aws:apigateway:eu-west-2::/apis/a1b2c3d4e5/stages/dev")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(stages) != 1 {
+			t.Fatalf("expected 1 stage, got %v", len(stages))
+		}
+
+		if *stages[0].Stage.StageName != "dev" || stages[0].ApiId != "a1b2c3d4e5" {
+			t.Errorf("expected stage a1b2c3d4e5/dev, got %v/%v", stages[0].ApiId, *stages[0].Stage.StageName)
+		}
+	})
+
+	t.Run("with an API ID", func(t *testing.T) {
+		stages, err := stageSearchFunc(context.Background(), testAPIGatewayV2Client{}, "052392120703.eu-west-2", "a1b2c3d4e5")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(stages) != 2 {
+			t.Fatalf("expected 2 stages, got %v", len(stages))
+		}
+	})
+}
+
+func TestNewStageSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewStageSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
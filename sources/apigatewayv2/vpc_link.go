@@ -0,0 +1,168 @@
+package apigatewayv2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func vpcLinkGetFunc(ctx context.Context, client APIGatewayV2Client, scope, query string) (*types.VpcLink, error) {
+	out, err := client.GetVpcLink(ctx, &apigatewayv2.GetVpcLinkInput{
+		VpcLinkId: &query,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.VpcLink{
+		CreatedDate:          out.CreatedDate,
+		Name:                 out.Name,
+		SecurityGroupIds:     out.SecurityGroupIds,
+		SubnetIds:            out.SubnetIds,
+		Tags:                 out.Tags,
+		VpcLinkId:            out.VpcLinkId,
+		VpcLinkStatus:        out.VpcLinkStatus,
+		VpcLinkStatusMessage: out.VpcLinkStatusMessage,
+		VpcLinkVersion:       out.VpcLinkVersion,
+	}, nil
+}
+
+func vpcLinkListFunc(ctx context.Context, client APIGatewayV2Client, scope string) ([]*types.VpcLink, error) {
+	links := make([]*types.VpcLink, 0)
+	input := apigatewayv2.GetVpcLinksInput{}
+
+	for {
+		out, err := client.GetVpcLinks(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range out.Items {
+			links = append(links, &out.Items[i])
+		}
+
+		if out.NextToken == nil {
+			break
+		}
+
+		input.NextToken = out.NextToken
+	}
+
+	return links, nil
+}
+
+// vpcLinkSearchFunc Searches for a VPC link by its ARN, which is in the format
+// arn:aws:apigateway:{region}::/vpclinks/{id}
+func vpcLinkSearchFunc(ctx context.Context, client APIGatewayV2Client, scope, query string) ([]*types.VpcLink, error) {
+	path, ok := arnPath(query)
+
+	if !ok || len(path) != 2 || path[0] != "vpclinks" {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: fmt.Sprintf("ARN %v is not an API Gateway VPC link", query),
+		}
+	}
+
+	link, err := vpcLinkGetFunc(ctx, client, scope, path[1])
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []*types.VpcLink{link}, nil
+}
+
+func vpcLinkItemMapper(scope string, awsItem *types.VpcLink) (*sdp.Item, error) {
+	attributes, err := sources.ToAttributesCase(awsItem, "tags")
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := sdp.Item{
+		Type:            "apigatewayv2-vpc-link",
+		UniqueAttribute: "vpcLinkId",
+		Attributes:      attributes,
+		Scope:           scope,
+		Tags:            awsItem.Tags,
+	}
+
+	switch awsItem.VpcLinkStatus {
+	case types.VpcLinkStatusAvailable:
+		item.Health = sdp.Health_HEALTH_OK.Enum()
+	case types.VpcLinkStatusPending, types.VpcLinkStatusDeleting:
+		item.Health = sdp.Health_HEALTH_PENDING.Enum()
+	case types.VpcLinkStatusFailed:
+		item.Health = sdp.Health_HEALTH_ERROR.Enum()
+	case types.VpcLinkStatusInactive:
+		item.Health = sdp.Health_HEALTH_WARNING.Enum()
+	}
+
+	for _, id := range awsItem.SecurityGroupIds {
+		// +overmind:link ec2-security-group
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "ec2-security-group",
+				Method: sdp.QueryMethod_GET,
+				Query:  id,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Changing the security group will affect the traffic that
+				// can flow through the link
+				In: true,
+				// Changing the link won't affect the security group
+				Out: false,
+			},
+		})
+	}
+
+	for _, id := range awsItem.SubnetIds {
+		// +overmind:link ec2-subnet
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "ec2-subnet",
+				Method: sdp.QueryMethod_GET,
+				Query:  id,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// The link's network interfaces are created in the subnet
+				In: true,
+				// Changing the link won't affect the subnet
+				Out: false,
+			},
+		})
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type apigatewayv2-vpc-link
+// +overmind:descriptiveType API Gateway v2 VPC Link
+// +overmind:get Get a VPC link by ID
+// +overmind:list List all VPC links
+// +overmind:search Search for a VPC link by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_apigatewayv2_vpc_link.id
+
+func NewVpcLinkSource(config aws.Config, accountID string, region string) *sources.GetListSource[*types.VpcLink, APIGatewayV2Client, *apigatewayv2.Options] {
+	return &sources.GetListSource[*types.VpcLink, APIGatewayV2Client, *apigatewayv2.Options]{
+		ItemType:   "apigatewayv2-vpc-link",
+		Client:     apigatewayv2.NewFromConfig(config),
+		AccountID:  accountID,
+		Region:     region,
+		GetFunc:    vpcLinkGetFunc,
+		ListFunc:   vpcLinkListFunc,
+		SearchFunc: vpcLinkSearchFunc,
+		ItemMapper: vpcLinkItemMapper,
+	}
+}
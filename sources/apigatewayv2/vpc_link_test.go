@@ -0,0 +1,106 @@
+package apigatewayv2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testAPIGatewayV2Client) GetVpcLink(ctx context.Context, params *apigatewayv2.GetVpcLinkInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetVpcLinkOutput, error) {
+	return &apigatewayv2.GetVpcLinkOutput{
+		VpcLinkId: params.VpcLinkId,
+		Name:      sources.PtrString("orders"),
+		SecurityGroupIds: []string{
+			"sg-0123456789", // link
+		},
+		SubnetIds: []string{
+			"subnet-0123456789", // link
+		},
+		VpcLinkStatus:  types.VpcLinkStatusAvailable,
+		VpcLinkVersion: types.VpcLinkVersionV2,
+		CreatedDate:    sources.PtrTime(time.Now()),
+		Tags: map[string]string{
+			"foo": "bar",
+		},
+	}, nil
+}
+
+func (c testAPIGatewayV2Client) GetVpcLinks(ctx context.Context, params *apigatewayv2.GetVpcLinksInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetVpcLinksOutput, error) {
+	return &apigatewayv2.GetVpcLinksOutput{
+		Items: []types.VpcLink{
+			{
+				VpcLinkId: sources.PtrString("vl0123"),
+				Name:      sources.PtrString("orders"),
+			},
+		},
+	}, nil
+}
+
+func TestVpcLinkItemMapper(t *testing.T) {
+	link, err := vpcLinkGetFunc(context.Background(), testAPIGatewayV2Client{}, "052392120703.eu-west-2", "vl0123")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := vpcLinkItemMapper("052392120703.eu-west-2", link)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.GetHealth() != sdp.Health_HEALTH_OK {
+		t.Errorf("expected health to be OK, got %v", item.GetHealth())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "ec2-security-group",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "sg-0123456789",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "ec2-subnet",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "subnet-0123456789",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestVpcLinkSearchFunc(t *testing.T) {
+	links, err := vpcLinkSearchFunc(context.Background(), testAPIGatewayV2Client{}, "052392120703.eu-west-2", "arn:aws:apigateway:eu-west-2::/vpclinks/vl0123")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(links) != 1 || *links[0].VpcLinkId != "vl0123" {
+		t.Errorf("expected to find vl0123, got %v", links)
+	}
+}
+
+func TestNewVpcLinkSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewVpcLinkSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -205,6 +205,75 @@ func autoScalingGroupOutputMapper(_ context.Context, _ *autoscaling.Client, scop
 			})
 		}
 
+		if asg.AutoScalingGroupName != nil {
+			// +overmind:link autoscaling-policy
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "autoscaling-policy",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *asg.AutoScalingGroupName,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Policies scale the ASG
+					In: true,
+					// Deleting the ASG deletes its policies
+					Out: true,
+				},
+			})
+
+			// +overmind:link autoscaling-lifecycle-hook
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "autoscaling-lifecycle-hook",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *asg.AutoScalingGroupName,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Hooks can hold instances in a wait state during
+					// scaling
+					In: true,
+					// Deleting the ASG deletes its hooks
+					Out: true,
+				},
+			})
+
+			// +overmind:link autoscaling-scheduled-action
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "autoscaling-scheduled-action",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *asg.AutoScalingGroupName,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Scheduled actions change the capacity of the ASG
+					In: true,
+					// Deleting the ASG deletes its scheduled actions
+					Out: true,
+				},
+			})
+
+			if asg.WarmPoolConfiguration != nil {
+				// +overmind:link autoscaling-warm-pool
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "autoscaling-warm-pool",
+						Method: sdp.QueryMethod_GET,
+						Query:  *asg.AutoScalingGroupName,
+						Scope:  scope,
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// The warm pool supplies instances to the ASG
+						In: true,
+						// Deleting the ASG deletes its warm pool
+						Out: true,
+					},
+				})
+			}
+		}
+
 		items = append(items, &item)
 	}
 
@@ -219,6 +219,30 @@ func TestAutoScalingGroupOutputMapper(t *testing.T) {
 			ExpectedQuery:  "lt-0174ff2b8909d0c75",
 			ExpectedScope:  "foo",
 		},
+		{
+			ExpectedType:   "autoscaling-policy",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "eks-default-20230117110031319900000013-96c2dfb1-a11b-b5e4-6efb-0fea7e22855c",
+			ExpectedScope:  "foo",
+		},
+		{
+			ExpectedType:   "autoscaling-lifecycle-hook",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "eks-default-20230117110031319900000013-96c2dfb1-a11b-b5e4-6efb-0fea7e22855c",
+			ExpectedScope:  "foo",
+		},
+		{
+			ExpectedType:   "autoscaling-scheduled-action",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "eks-default-20230117110031319900000013-96c2dfb1-a11b-b5e4-6efb-0fea7e22855c",
+			ExpectedScope:  "foo",
+		},
+		{
+			ExpectedType:   "autoscaling-warm-pool",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "eks-default-20230117110031319900000013-96c2dfb1-a11b-b5e4-6efb-0fea7e22855c",
+			ExpectedScope:  "foo",
+		},
 	}
 
 	tests.Execute(t, item)
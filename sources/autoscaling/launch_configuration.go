@@ -0,0 +1,229 @@
+package autoscaling
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func launchConfigurationOutputMapper(_ context.Context, _ *autoscaling.Client, scope string, _ *autoscaling.DescribeLaunchConfigurationsInput, output *autoscaling.DescribeLaunchConfigurationsOutput) ([]*sdp.Item, error) {
+	items := make([]*sdp.Item, 0)
+
+	for _, lc := range output.LaunchConfigurations {
+		attributes, err := sources.ToAttributesCase(lc)
+
+		if err != nil {
+			return nil, err
+		}
+
+		item := sdp.Item{
+			Type:            "autoscaling-launch-configuration",
+			UniqueAttribute: "launchConfigurationName",
+			Scope:           scope,
+			Attributes:      attributes,
+		}
+
+		for _, imageID := range []*string{lc.ImageId, lc.KernelId, lc.RamdiskId} {
+			if imageID != nil && *imageID != "" {
+				// +overmind:link ec2-image
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "ec2-image",
+						Method: sdp.QueryMethod_GET,
+						Query:  *imageID,
+						Scope:  scope,
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Instances are launched from the image
+						In: true,
+						// The launch configuration won't affect the image
+						Out: false,
+					},
+				})
+			}
+		}
+
+		if lc.KeyName != nil {
+			// +overmind:link ec2-key-pair
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "ec2-key-pair",
+					Method: sdp.QueryMethod_GET,
+					Query:  *lc.KeyName,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changes to the key pair affect access to instances
+					In: true,
+					// The launch configuration won't affect the key pair
+					Out: false,
+				},
+			})
+		}
+
+		for _, groupID := range append(lc.SecurityGroups, lc.ClassicLinkVPCSecurityGroups...) {
+			// EC2-Classic launch configurations can reference groups by name,
+			// which we can't look up
+			if !strings.HasPrefix(groupID, "sg-") {
+				continue
+			}
+
+			// +overmind:link ec2-security-group
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "ec2-security-group",
+					Method: sdp.QueryMethod_GET,
+					Query:  groupID,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changes to the security group affect launched instances
+					In: true,
+					// The launch configuration won't affect the group
+					Out: false,
+				},
+			})
+		}
+
+		if lc.ClassicLinkVPCId != nil {
+			// +overmind:link ec2-vpc
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "ec2-vpc",
+					Method: sdp.QueryMethod_GET,
+					Query:  *lc.ClassicLinkVPCId,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changes to the VPC affect launched instances
+					In: true,
+					// The launch configuration won't affect the VPC
+					Out: false,
+				},
+			})
+		}
+
+		if lc.IamInstanceProfile != nil {
+			// This can be either the name or the ARN of the instance profile
+			if a, err := sources.ParseARN(*lc.IamInstanceProfile); err == nil {
+				// +overmind:link iam-instance-profile
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "iam-instance-profile",
+						Method: sdp.QueryMethod_SEARCH,
+						Query:  *lc.IamInstanceProfile,
+						Scope:  sources.FormatScope(a.AccountID, a.Region),
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Changes to the profile affect the permissions of
+						// launched instances
+						In: true,
+						// The launch configuration won't affect the profile
+						Out: false,
+					},
+				})
+			} else if accountID, _, err := sources.ParseScope(scope); err == nil {
+				// +overmind:link iam-instance-profile
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "iam-instance-profile",
+						Method: sdp.QueryMethod_GET,
+						Query:  *lc.IamInstanceProfile,
+						Scope:  accountID,
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Changes to the profile affect the permissions of
+						// launched instances
+						In: true,
+						// The launch configuration won't affect the profile
+						Out: false,
+					},
+				})
+			}
+		}
+
+		for _, mapping := range lc.BlockDeviceMappings {
+			if mapping.Ebs != nil && mapping.Ebs.SnapshotId != nil {
+				// +overmind:link ec2-snapshot
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "ec2-snapshot",
+						Method: sdp.QueryMethod_GET,
+						Query:  *mapping.Ebs.SnapshotId,
+						Scope:  scope,
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Volumes are created from the snapshot
+						In: true,
+						// The launch configuration won't affect the snapshot
+						Out: false,
+					},
+				})
+			}
+		}
+
+		items = append(items, &item)
+	}
+
+	return items, nil
+}
+
+// +overmind:type autoscaling-launch-configuration
+// +overmind:descriptiveType Autoscaling Launch Configuration
+// +overmind:get Get a launch configuration by name
+// +overmind:list List launch configurations
+// +overmind:search Search for launch configurations by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_launch_configuration.name
+//
+//go:generate docgen ../../docs-data
+func NewLaunchConfigurationSource(config aws.Config, accountID string, limit *sources.LimitBucket) *sources.DescribeOnlySource[*autoscaling.DescribeLaunchConfigurationsInput, *autoscaling.DescribeLaunchConfigurationsOutput, *autoscaling.Client, *autoscaling.Options] {
+	return &sources.DescribeOnlySource[*autoscaling.DescribeLaunchConfigurationsInput, *autoscaling.DescribeLaunchConfigurationsOutput, *autoscaling.Client, *autoscaling.Options]{
+		ItemType:  "autoscaling-launch-configuration",
+		Config:    config,
+		AccountID: accountID,
+		Client:    autoscaling.NewFromConfig(config),
+		InputMapperGet: func(scope, query string) (*autoscaling.DescribeLaunchConfigurationsInput, error) {
+			return &autoscaling.DescribeLaunchConfigurationsInput{
+				LaunchConfigurationNames: []string{query},
+			}, nil
+		},
+		InputMapperList: func(scope string) (*autoscaling.DescribeLaunchConfigurationsInput, error) {
+			return &autoscaling.DescribeLaunchConfigurationsInput{}, nil
+		},
+		InputMapperSearch: func(ctx context.Context, client *autoscaling.Client, scope, query string) (*autoscaling.DescribeLaunchConfigurationsInput, error) {
+			// The ARN contains a UUID before the name, so we need to pull
+			// the name out ourselves
+			a, err := sources.ParseARN(query)
+
+			if err != nil {
+				return nil, err
+			}
+
+			name, ok := arnField(a, "launchConfigurationName")
+
+			if !ok {
+				return nil, &sdp.QueryError{
+					ErrorType:   sdp.QueryError_NOTFOUND,
+					ErrorString: "ARN does not contain a launch configuration name",
+				}
+			}
+
+			return &autoscaling.DescribeLaunchConfigurationsInput{
+				LaunchConfigurationNames: []string{name},
+			}, nil
+		},
+		PaginatorBuilder: func(client *autoscaling.Client, params *autoscaling.DescribeLaunchConfigurationsInput) sources.Paginator[*autoscaling.DescribeLaunchConfigurationsOutput, *autoscaling.Options] {
+			return autoscaling.NewDescribeLaunchConfigurationsPaginator(client, params)
+		},
+		DescribeFunc: func(ctx context.Context, client *autoscaling.Client, input *autoscaling.DescribeLaunchConfigurationsInput) (*autoscaling.DescribeLaunchConfigurationsOutput, error) {
+			limit.Wait(ctx) // Wait for rate limiting
+			return client.DescribeLaunchConfigurations(ctx, input)
+		},
+		OutputMapper: launchConfigurationOutputMapper,
+	}
+}
@@ -0,0 +1,131 @@
+package autoscaling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func TestLaunchConfigurationOutputMapper(t *testing.T) {
+	t.Parallel()
+
+	output := autoscaling.DescribeLaunchConfigurationsOutput{
+		LaunchConfigurations: []types.LaunchConfiguration{
+			{
+				LaunchConfigurationName: sources.PtrString("web-20240101"),
+				LaunchConfigurationARN:  sources.PtrString("arn:aws:autoscaling:eu-west-2:944651592624:launchConfiguration:7b5b3d3a-2f4e-4b4c-9a57-3e1e9c0a3f11:launchConfigurationName/web-20240101"),
+				CreatedTime:             sources.PtrTime(time.Now()),
+				ImageId:                 sources.PtrString("ami-0a1b2c3d4e5f67890"), // link
+				InstanceType:            sources.PtrString("t3.micro"),
+				KeyName:                 sources.PtrString("deploy"), // link
+				SecurityGroups: []string{
+					"sg-0123456789abcdef0", // link
+					"default",              // EC2-Classic name, ignored
+				},
+				IamInstanceProfile: sources.PtrString("arn:aws:iam::944651592624:instance-profile/web"), // link
+				BlockDeviceMappings: []types.BlockDeviceMapping{
+					{
+						DeviceName: sources.PtrString("/dev/xvda"),
+						Ebs: &types.Ebs{
+							SnapshotId: sources.PtrString("snap-0123456789abcdef0"), // link
+							VolumeSize: sources.PtrInt32(20),
+						},
+					},
+				},
+				InstanceMonitoring: &types.InstanceMonitoring{
+					Enabled: sources.PtrBool(true),
+				},
+			},
+		},
+	}
+
+	items, err := launchConfigurationOutputMapper(context.Background(), nil, "944651592624.eu-west-2", nil, &output)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 1 {
+		t.Fatalf("expected 1 item, got %v", len(items))
+	}
+
+	item := items[0]
+
+	if err := item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if len(item.GetLinkedItemQueries()) != 5 {
+		t.Errorf("expected 5 links, got %v", len(item.GetLinkedItemQueries()))
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "ec2-image",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "ami-0a1b2c3d4e5f67890",
+			ExpectedScope:  "944651592624.eu-west-2",
+		},
+		{
+			ExpectedType:   "ec2-key-pair",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "deploy",
+			ExpectedScope:  "944651592624.eu-west-2",
+		},
+		{
+			ExpectedType:   "ec2-security-group",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "sg-0123456789abcdef0",
+			ExpectedScope:  "944651592624.eu-west-2",
+		},
+		{
+			ExpectedType:   "iam-instance-profile",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:iam::944651592624:instance-profile/web",
+			ExpectedScope:  "944651592624",
+		},
+		{
+			ExpectedType:   "ec2-snapshot",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "snap-0123456789abcdef0",
+			ExpectedScope:  "944651592624.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestLaunchConfigurationInstanceProfileName(t *testing.T) {
+	t.Parallel()
+
+	output := autoscaling.DescribeLaunchConfigurationsOutput{
+		LaunchConfigurations: []types.LaunchConfiguration{
+			{
+				LaunchConfigurationName: sources.PtrString("web-20240101"),
+				IamInstanceProfile:      sources.PtrString("web"), // link
+			},
+		},
+	}
+
+	items, err := launchConfigurationOutputMapper(context.Background(), nil, "944651592624.eu-west-2", nil, &output)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "iam-instance-profile",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "web",
+			ExpectedScope:  "944651592624",
+		},
+	}
+
+	tests.Execute(t, items[0])
+}
@@ -0,0 +1,152 @@
+package autoscaling
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func lifecycleHookOutputMapper(_ context.Context, _ *autoscaling.Client, scope string, _ *autoscaling.DescribeLifecycleHooksInput, output *autoscaling.DescribeLifecycleHooksOutput) ([]*sdp.Item, error) {
+	items := make([]*sdp.Item, 0)
+
+	for _, hook := range output.LifecycleHooks {
+		attributes, err := sources.ToAttributesCase(hook)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if hook.AutoScalingGroupName == nil || hook.LifecycleHookName == nil {
+			continue
+		}
+
+		err = attributes.Set("uniqueName", *hook.AutoScalingGroupName+"/"+*hook.LifecycleHookName)
+
+		if err != nil {
+			return nil, err
+		}
+
+		item := sdp.Item{
+			Type:            "autoscaling-lifecycle-hook",
+			UniqueAttribute: "uniqueName",
+			Scope:           scope,
+			Attributes:      attributes,
+		}
+
+		// +overmind:link autoscaling-auto-scaling-group
+		item.LinkedItemQueries = append(item.LinkedItemQueries, groupLink(*hook.AutoScalingGroupName, scope))
+
+		if hook.NotificationTargetARN != nil {
+			if a, err := sources.ParseARN(*hook.NotificationTargetARN); err == nil {
+				switch a.Service {
+				case "sns":
+					// +overmind:link sns-topic
+					item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+						Query: &sdp.Query{
+							Type:   "sns-topic",
+							Method: sdp.QueryMethod_SEARCH,
+							Query:  *hook.NotificationTargetARN,
+							Scope:  sources.FormatScope(a.AccountID, a.Region),
+						},
+						BlastPropagation: &sdp.BlastPropagation{
+							// If the topic is broken, instances will wait
+							// for the hook to time out
+							In: true,
+							// The hook sends notifications to the topic
+							Out: true,
+						},
+					})
+				case "sqs":
+					// +overmind:link sqs-queue
+					item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+						Query: &sdp.Query{
+							Type:   "sqs-queue",
+							Method: sdp.QueryMethod_SEARCH,
+							Query:  *hook.NotificationTargetARN,
+							Scope:  sources.FormatScope(a.AccountID, a.Region),
+						},
+						BlastPropagation: &sdp.BlastPropagation{
+							// If the queue is broken, instances will wait
+							// for the hook to time out
+							In: true,
+							// The hook sends messages to the queue
+							Out: true,
+						},
+					})
+				}
+			}
+		}
+
+		if hook.RoleARN != nil {
+			if a, err := sources.ParseARN(*hook.RoleARN); err == nil {
+				// +overmind:link iam-role
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "iam-role",
+						Method: sdp.QueryMethod_SEARCH,
+						Query:  *hook.RoleARN,
+						Scope:  sources.FormatScope(a.AccountID, a.Region),
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// The role is used to publish to the notification
+						// target
+						In: true,
+						// The hook won't affect the role
+						Out: false,
+					},
+				})
+			}
+		}
+
+		items = append(items, &item)
+	}
+
+	return items, nil
+}
+
+// +overmind:type autoscaling-lifecycle-hook
+// +overmind:descriptiveType Autoscaling Lifecycle Hook
+// +overmind:get Get a lifecycle hook by {autoScalingGroupName}/{lifecycleHookName}
+// +overmind:search Search for lifecycle hooks by Auto Scaling group name
+// +overmind:group AWS
+//
+//go:generate docgen ../../docs-data
+func NewLifecycleHookSource(config aws.Config, accountID string, limit *sources.LimitBucket) *sources.DescribeOnlySource[*autoscaling.DescribeLifecycleHooksInput, *autoscaling.DescribeLifecycleHooksOutput, *autoscaling.Client, *autoscaling.Options] {
+	return &sources.DescribeOnlySource[*autoscaling.DescribeLifecycleHooksInput, *autoscaling.DescribeLifecycleHooksOutput, *autoscaling.Client, *autoscaling.Options]{
+		ItemType:  "autoscaling-lifecycle-hook",
+		Config:    config,
+		AccountID: accountID,
+		Client:    autoscaling.NewFromConfig(config),
+		InputMapperGet: func(scope, query string) (*autoscaling.DescribeLifecycleHooksInput, error) {
+			groupName, hookName, err := parseGroupQuery(query)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return &autoscaling.DescribeLifecycleHooksInput{
+				AutoScalingGroupName: &groupName,
+				LifecycleHookNames:   []string{hookName},
+			}, nil
+		},
+		InputMapperList: func(scope string) (*autoscaling.DescribeLifecycleHooksInput, error) {
+			return nil, &sdp.QueryError{
+				ErrorType:   sdp.QueryError_NOTFOUND,
+				ErrorString: "list not supported for autoscaling-lifecycle-hook, use search",
+			}
+		},
+		InputMapperSearch: func(ctx context.Context, client *autoscaling.Client, scope, query string) (*autoscaling.DescribeLifecycleHooksInput, error) {
+			return &autoscaling.DescribeLifecycleHooksInput{
+				AutoScalingGroupName: &query,
+			}, nil
+		},
+		DescribeFunc: func(ctx context.Context, client *autoscaling.Client, input *autoscaling.DescribeLifecycleHooksInput) (*autoscaling.DescribeLifecycleHooksOutput, error) {
+			limit.Wait(ctx) // Wait for rate limiting
+			return client.DescribeLifecycleHooks(ctx, input)
+		},
+		OutputMapper: lifecycleHookOutputMapper,
+	}
+}
@@ -0,0 +1,86 @@
+package autoscaling
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func TestLifecycleHookOutputMapper(t *testing.T) {
+	t.Parallel()
+
+	output := autoscaling.DescribeLifecycleHooksOutput{
+		LifecycleHooks: []types.LifecycleHook{
+			{
+				AutoScalingGroupName:  sources.PtrString("web"), // link
+				LifecycleHookName:     sources.PtrString("drain"),
+				LifecycleTransition:   sources.PtrString("autoscaling:EC2_INSTANCE_TERMINATING"),
+				DefaultResult:         sources.PtrString("CONTINUE"),
+				HeartbeatTimeout:      sources.PtrInt32(300),
+				GlobalTimeout:         sources.PtrInt32(30000),
+				NotificationTargetARN: sources.PtrString("arn:aws:sqs:eu-west-2:944651592624:web-lifecycle"), // link
+				RoleARN:               sources.PtrString("arn:aws:iam::944651592624:role/web-lifecycle"),     // link
+			},
+			{
+				AutoScalingGroupName:  sources.PtrString("web"),
+				LifecycleHookName:     sources.PtrString("warm-up"),
+				LifecycleTransition:   sources.PtrString("autoscaling:EC2_INSTANCE_LAUNCHING"),
+				NotificationTargetARN: sources.PtrString("arn:aws:sns:eu-west-2:944651592624:web-lifecycle"), // link
+			},
+		},
+	}
+
+	items, err := lifecycleHookOutputMapper(context.Background(), nil, "944651592624.eu-west-2", nil, &output)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items, got %v", len(items))
+	}
+
+	for _, item := range items {
+		if err := item.Validate(); err != nil {
+			t.Error(err)
+		}
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "autoscaling-auto-scaling-group",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "web",
+			ExpectedScope:  "944651592624.eu-west-2",
+		},
+		{
+			ExpectedType:   "sqs-queue",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:sqs:eu-west-2:944651592624:web-lifecycle",
+			ExpectedScope:  "944651592624.eu-west-2",
+		},
+		{
+			ExpectedType:   "iam-role",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:iam::944651592624:role/web-lifecycle",
+			ExpectedScope:  "944651592624",
+		},
+	}
+
+	tests.Execute(t, items[0])
+
+	tests = sources.QueryTests{
+		{
+			ExpectedType:   "sns-topic",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:sns:eu-west-2:944651592624:web-lifecycle",
+			ExpectedScope:  "944651592624.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, items[1])
+}
@@ -0,0 +1,147 @@
+package autoscaling
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func policyOutputMapper(_ context.Context, _ *autoscaling.Client, scope string, _ *autoscaling.DescribePoliciesInput, output *autoscaling.DescribePoliciesOutput) ([]*sdp.Item, error) {
+	items := make([]*sdp.Item, 0)
+
+	for _, policy := range output.ScalingPolicies {
+		attributes, err := sources.ToAttributesCase(policy)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if policy.AutoScalingGroupName == nil || policy.PolicyName == nil {
+			continue
+		}
+
+		err = attributes.Set("uniqueName", *policy.AutoScalingGroupName+"/"+*policy.PolicyName)
+
+		if err != nil {
+			return nil, err
+		}
+
+		item := sdp.Item{
+			Type:            "autoscaling-policy",
+			UniqueAttribute: "uniqueName",
+			Scope:           scope,
+			Attributes:      attributes,
+		}
+
+		if policy.Enabled != nil && !*policy.Enabled {
+			item.Health = sdp.Health_HEALTH_WARNING.Enum()
+		}
+
+		// +overmind:link autoscaling-auto-scaling-group
+		item.LinkedItemQueries = append(item.LinkedItemQueries, groupLink(*policy.AutoScalingGroupName, scope))
+
+		for _, alarm := range policy.Alarms {
+			if alarm.AlarmName == nil {
+				continue
+			}
+
+			alarmScope := scope
+
+			if alarm.AlarmARN != nil {
+				if a, err := sources.ParseARN(*alarm.AlarmARN); err == nil {
+					alarmScope = sources.FormatScope(a.AccountID, a.Region)
+				}
+			}
+
+			// +overmind:link cloudwatch-alarm
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "cloudwatch-alarm",
+					Method: sdp.QueryMethod_GET,
+					Query:  *alarm.AlarmName,
+					Scope:  alarmScope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// The alarm is what triggers the policy
+					In: true,
+					// Changes to the policy won't affect the alarm
+					Out: false,
+				},
+			})
+		}
+
+		items = append(items, &item)
+	}
+
+	return items, nil
+}
+
+// +overmind:type autoscaling-policy
+// +overmind:descriptiveType Autoscaling Policy
+// +overmind:get Get a scaling policy by {autoScalingGroupName}/{policyName}
+// +overmind:list List scaling policies
+// +overmind:search Search for scaling policies by ARN, or by Auto Scaling group name
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_autoscaling_policy.arn
+// +overmind:terraform:method SEARCH
+//
+//go:generate docgen ../../docs-data
+func NewPolicySource(config aws.Config, accountID string, limit *sources.LimitBucket) *sources.DescribeOnlySource[*autoscaling.DescribePoliciesInput, *autoscaling.DescribePoliciesOutput, *autoscaling.Client, *autoscaling.Options] {
+	return &sources.DescribeOnlySource[*autoscaling.DescribePoliciesInput, *autoscaling.DescribePoliciesOutput, *autoscaling.Client, *autoscaling.Options]{
+		ItemType:  "autoscaling-policy",
+		Config:    config,
+		AccountID: accountID,
+		Client:    autoscaling.NewFromConfig(config),
+		InputMapperGet: func(scope, query string) (*autoscaling.DescribePoliciesInput, error) {
+			groupName, policyName, err := parseGroupQuery(query)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return &autoscaling.DescribePoliciesInput{
+				AutoScalingGroupName: &groupName,
+				PolicyNames:          []string{policyName},
+			}, nil
+		},
+		InputMapperList: func(scope string) (*autoscaling.DescribePoliciesInput, error) {
+			return &autoscaling.DescribePoliciesInput{}, nil
+		},
+		InputMapperSearch: func(ctx context.Context, client *autoscaling.Client, scope, query string) (*autoscaling.DescribePoliciesInput, error) {
+			a, err := sources.ParseARN(query)
+
+			if err != nil {
+				// Search by Auto Scaling group name
+				return &autoscaling.DescribePoliciesInput{
+					AutoScalingGroupName: &query,
+				}, nil
+			}
+
+			groupName, groupOK := arnField(a, "autoScalingGroupName")
+			policyName, policyOK := arnField(a, "policyName")
+
+			if !groupOK || !policyOK {
+				return nil, &sdp.QueryError{
+					ErrorType:   sdp.QueryError_NOTFOUND,
+					ErrorString: "ARN does not contain an Auto Scaling group and policy name",
+				}
+			}
+
+			return &autoscaling.DescribePoliciesInput{
+				AutoScalingGroupName: &groupName,
+				PolicyNames:          []string{policyName},
+			}, nil
+		},
+		PaginatorBuilder: func(client *autoscaling.Client, params *autoscaling.DescribePoliciesInput) sources.Paginator[*autoscaling.DescribePoliciesOutput, *autoscaling.Options] {
+			return autoscaling.NewDescribePoliciesPaginator(client, params)
+		},
+		DescribeFunc: func(ctx context.Context, client *autoscaling.Client, input *autoscaling.DescribePoliciesInput) (*autoscaling.DescribePoliciesOutput, error) {
+			limit.Wait(ctx) // Wait for rate limiting
+			return client.DescribePolicies(ctx, input)
+		},
+		OutputMapper: policyOutputMapper,
+	}
+}
@@ -0,0 +1,117 @@
+package autoscaling
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func TestPolicyOutputMapper(t *testing.T) {
+	t.Parallel()
+
+	output := autoscaling.DescribePoliciesOutput{
+		ScalingPolicies: []types.ScalingPolicy{
+			{
+				AutoScalingGroupName: sources.PtrString("web"), // link
+				PolicyName:           sources.PtrString("scale-out"),
+				PolicyARN:            sources.PtrString("arn:aws:autoscaling:eu-west-2:944651592624:scalingPolicy:3b1d2a4e-8f6c-4e0a-9a1b-2c3d4e5f6a7b:autoScalingGroupName/web:policyName/scale-out"),
+				PolicyType:           sources.PtrString("StepScaling"),
+				AdjustmentType:       sources.PtrString("ChangeInCapacity"),
+				Enabled:              sources.PtrBool(true),
+				StepAdjustments: []types.StepAdjustment{
+					{
+						MetricIntervalLowerBound: sources.PtrFloat64(0),
+						ScalingAdjustment:        sources.PtrInt32(1),
+					},
+				},
+				Alarms: []types.Alarm{
+					{
+						AlarmName: sources.PtrString("web-high-cpu"), // link
+						AlarmARN:  sources.PtrString("arn:aws:cloudwatch:eu-west-2:944651592624:alarm:web-high-cpu"),
+					},
+				},
+			},
+		},
+	}
+
+	items, err := policyOutputMapper(context.Background(), nil, "944651592624.eu-west-2", nil, &output)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 1 {
+		t.Fatalf("expected 1 item, got %v", len(items))
+	}
+
+	item := items[0]
+
+	if err := item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.UniqueAttributeValue() != "web/scale-out" {
+		t.Errorf("expected unique attribute value web/scale-out, got %v", item.UniqueAttributeValue())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "autoscaling-auto-scaling-group",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "web",
+			ExpectedScope:  "944651592624.eu-west-2",
+		},
+		{
+			ExpectedType:   "cloudwatch-alarm",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "web-high-cpu",
+			ExpectedScope:  "944651592624.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestArnField(t *testing.T) {
+	t.Parallel()
+
+	a, err := sources.ParseARN("arn:aws:autoscaling:eu-west-2:944651592624:scalingPolicy:3b1d2a4e-8f6c-4e0a-9a1b-2c3d4e5f6a7b:autoScalingGroupName/web:policyName/scale-out")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if group, ok := arnField(a, "autoScalingGroupName"); !ok || group != "web" {
+		t.Errorf("expected group web, got %v", group)
+	}
+
+	if policy, ok := arnField(a, "policyName"); !ok || policy != "scale-out" {
+		t.Errorf("expected policy scale-out, got %v", policy)
+	}
+
+	if _, ok := arnField(a, "launchConfigurationName"); ok {
+		t.Error("expected launchConfigurationName to be missing")
+	}
+}
+
+func TestParseGroupQuery(t *testing.T) {
+	t.Parallel()
+
+	group, name, err := parseGroupQuery("web/scale-out")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if group != "web" || name != "scale-out" {
+		t.Errorf("expected web and scale-out, got %v and %v", group, name)
+	}
+
+	if _, _, err := parseGroupQuery("web"); err == nil {
+		t.Error("expected error for query without a name")
+	}
+}
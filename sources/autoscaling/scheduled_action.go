@@ -0,0 +1,91 @@
+package autoscaling
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func scheduledActionOutputMapper(_ context.Context, _ *autoscaling.Client, scope string, _ *autoscaling.DescribeScheduledActionsInput, output *autoscaling.DescribeScheduledActionsOutput) ([]*sdp.Item, error) {
+	items := make([]*sdp.Item, 0)
+
+	for _, action := range output.ScheduledUpdateGroupActions {
+		attributes, err := sources.ToAttributesCase(action)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if action.AutoScalingGroupName == nil || action.ScheduledActionName == nil {
+			continue
+		}
+
+		err = attributes.Set("uniqueName", *action.AutoScalingGroupName+"/"+*action.ScheduledActionName)
+
+		if err != nil {
+			return nil, err
+		}
+
+		item := sdp.Item{
+			Type:            "autoscaling-scheduled-action",
+			UniqueAttribute: "uniqueName",
+			Scope:           scope,
+			Attributes:      attributes,
+		}
+
+		// +overmind:link autoscaling-auto-scaling-group
+		item.LinkedItemQueries = append(item.LinkedItemQueries, groupLink(*action.AutoScalingGroupName, scope))
+
+		items = append(items, &item)
+	}
+
+	return items, nil
+}
+
+// +overmind:type autoscaling-scheduled-action
+// +overmind:descriptiveType Autoscaling Scheduled Action
+// +overmind:get Get a scheduled action by {autoScalingGroupName}/{scheduledActionName}
+// +overmind:list List scheduled actions
+// +overmind:search Search for scheduled actions by Auto Scaling group name
+// +overmind:group AWS
+//
+//go:generate docgen ../../docs-data
+func NewScheduledActionSource(config aws.Config, accountID string, limit *sources.LimitBucket) *sources.DescribeOnlySource[*autoscaling.DescribeScheduledActionsInput, *autoscaling.DescribeScheduledActionsOutput, *autoscaling.Client, *autoscaling.Options] {
+	return &sources.DescribeOnlySource[*autoscaling.DescribeScheduledActionsInput, *autoscaling.DescribeScheduledActionsOutput, *autoscaling.Client, *autoscaling.Options]{
+		ItemType:  "autoscaling-scheduled-action",
+		Config:    config,
+		AccountID: accountID,
+		Client:    autoscaling.NewFromConfig(config),
+		InputMapperGet: func(scope, query string) (*autoscaling.DescribeScheduledActionsInput, error) {
+			groupName, actionName, err := parseGroupQuery(query)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return &autoscaling.DescribeScheduledActionsInput{
+				AutoScalingGroupName: &groupName,
+				ScheduledActionNames: []string{actionName},
+			}, nil
+		},
+		InputMapperList: func(scope string) (*autoscaling.DescribeScheduledActionsInput, error) {
+			return &autoscaling.DescribeScheduledActionsInput{}, nil
+		},
+		InputMapperSearch: func(ctx context.Context, client *autoscaling.Client, scope, query string) (*autoscaling.DescribeScheduledActionsInput, error) {
+			return &autoscaling.DescribeScheduledActionsInput{
+				AutoScalingGroupName: &query,
+			}, nil
+		},
+		PaginatorBuilder: func(client *autoscaling.Client, params *autoscaling.DescribeScheduledActionsInput) sources.Paginator[*autoscaling.DescribeScheduledActionsOutput, *autoscaling.Options] {
+			return autoscaling.NewDescribeScheduledActionsPaginator(client, params)
+		},
+		DescribeFunc: func(ctx context.Context, client *autoscaling.Client, input *autoscaling.DescribeScheduledActionsInput) (*autoscaling.DescribeScheduledActionsOutput, error) {
+			limit.Wait(ctx) // Wait for rate limiting
+			return client.DescribeScheduledActions(ctx, input)
+		},
+		OutputMapper: scheduledActionOutputMapper,
+	}
+}
@@ -0,0 +1,63 @@
+package autoscaling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func TestScheduledActionOutputMapper(t *testing.T) {
+	t.Parallel()
+
+	output := autoscaling.DescribeScheduledActionsOutput{
+		ScheduledUpdateGroupActions: []types.ScheduledUpdateGroupAction{
+			{
+				AutoScalingGroupName: sources.PtrString("web"), // link
+				ScheduledActionName:  sources.PtrString("nightly-scale-in"),
+				ScheduledActionARN:   sources.PtrString("arn:aws:autoscaling:eu-west-2:944651592624:scheduledUpdateGroupAction:5f1c2d3e-4a5b-6c7d-8e9f-0a1b2c3d4e5f:autoScalingGroupName/web:scheduledActionName/nightly-scale-in"),
+				Recurrence:           sources.PtrString("0 22 * * *"),
+				StartTime:            sources.PtrTime(time.Now()),
+				MinSize:              sources.PtrInt32(1),
+				MaxSize:              sources.PtrInt32(2),
+				DesiredCapacity:      sources.PtrInt32(1),
+				TimeZone:             sources.PtrString("Europe/London"),
+			},
+		},
+	}
+
+	items, err := scheduledActionOutputMapper(context.Background(), nil, "944651592624.eu-west-2", nil, &output)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 1 {
+		t.Fatalf("expected 1 item, got %v", len(items))
+	}
+
+	item := items[0]
+
+	if err := item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.UniqueAttributeValue() != "web/nightly-scale-in" {
+		t.Errorf("expected unique attribute value web/nightly-scale-in, got %v", item.UniqueAttributeValue())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "autoscaling-auto-scaling-group",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "web",
+			ExpectedScope:  "944651592624.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+}
@@ -0,0 +1,57 @@
+package autoscaling
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+// parseGroupQuery Splits a query in the format {autoScalingGroupName}/{name}
+// into its parts. This is used for resources whose names are only unique
+// within an Auto Scaling group
+func parseGroupQuery(query string) (groupName string, name string, err error) {
+	groupName, name, found := strings.Cut(query, "/")
+
+	if !found || groupName == "" || name == "" {
+		return "", "", &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: fmt.Sprintf("query %v must be in the format {autoScalingGroupName}/{name}", query),
+		}
+	}
+
+	return groupName, name, nil
+}
+
+// arnField Returns the value of a named field from an Auto Scaling ARN. These
+// are in the format
+// arn:aws:autoscaling:{region}:{account}:scalingPolicy:{uuid}:autoScalingGroupName/{group}:policyName/{name}
+// so the "policyName" field for this example would be {name}
+func arnField(a *sources.ARN, field string) (string, bool) {
+	for _, section := range strings.Split(a.Resource, ":") {
+		if value, found := strings.CutPrefix(section, field+"/"); found && value != "" {
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+// groupLink Returns a link to the Auto Scaling group that a resource belongs to
+func groupLink(groupName string, scope string) *sdp.LinkedItemQuery {
+	return &sdp.LinkedItemQuery{
+		Query: &sdp.Query{
+			Type:   "autoscaling-auto-scaling-group",
+			Method: sdp.QueryMethod_GET,
+			Query:  groupName,
+			Scope:  scope,
+		},
+		BlastPropagation: &sdp.BlastPropagation{
+			// Deleting the group deletes everything attached to it
+			In: true,
+			// Changes to the resource affect how the group scales
+			Out: true,
+		},
+	}
+}
@@ -0,0 +1,118 @@
+package autoscaling
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func warmPoolOutputMapper(_ context.Context, _ *autoscaling.Client, scope string, input *autoscaling.DescribeWarmPoolInput, output *autoscaling.DescribeWarmPoolOutput) ([]*sdp.Item, error) {
+	// Groups without a warm pool return an empty configuration
+	if output.WarmPoolConfiguration == nil || input.AutoScalingGroupName == nil {
+		return []*sdp.Item{}, nil
+	}
+
+	attributes, err := sources.ToAttributesCase(struct {
+		AutoScalingGroupName  *string
+		WarmPoolConfiguration *types.WarmPoolConfiguration
+		Instances             []types.Instance
+	}{
+		AutoScalingGroupName:  input.AutoScalingGroupName,
+		WarmPoolConfiguration: output.WarmPoolConfiguration,
+		Instances:             output.Instances,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := sdp.Item{
+		Type:            "autoscaling-warm-pool",
+		UniqueAttribute: "autoScalingGroupName",
+		Scope:           scope,
+		Attributes:      attributes,
+	}
+
+	if output.WarmPoolConfiguration.Status == types.WarmPoolStatusPendingDelete {
+		item.Health = sdp.Health_HEALTH_WARNING.Enum()
+	}
+
+	// +overmind:link autoscaling-auto-scaling-group
+	item.LinkedItemQueries = append(item.LinkedItemQueries, groupLink(*input.AutoScalingGroupName, scope))
+
+	for _, instance := range output.Instances {
+		if instance.InstanceId != nil {
+			// +overmind:link ec2-instance
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "ec2-instance",
+					Method: sdp.QueryMethod_GET,
+					Query:  *instance.InstanceId,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changes to an instance won't affect the pool
+					In: false,
+					// Changes to the pool can cause instances to be
+					// terminated or moved into the group
+					Out: true,
+				},
+			})
+		}
+	}
+
+	return []*sdp.Item{&item}, nil
+}
+
+// +overmind:type autoscaling-warm-pool
+// +overmind:descriptiveType Autoscaling Warm Pool
+// +overmind:get Get the warm pool for an Auto Scaling group by the group's name
+// +overmind:group AWS
+//
+//go:generate docgen ../../docs-data
+func NewWarmPoolSource(config aws.Config, accountID string, limit *sources.LimitBucket) *sources.DescribeOnlySource[*autoscaling.DescribeWarmPoolInput, *autoscaling.DescribeWarmPoolOutput, *autoscaling.Client, *autoscaling.Options] {
+	return &sources.DescribeOnlySource[*autoscaling.DescribeWarmPoolInput, *autoscaling.DescribeWarmPoolOutput, *autoscaling.Client, *autoscaling.Options]{
+		ItemType:  "autoscaling-warm-pool",
+		Config:    config,
+		AccountID: accountID,
+		Client:    autoscaling.NewFromConfig(config),
+		InputMapperGet: func(scope, query string) (*autoscaling.DescribeWarmPoolInput, error) {
+			return &autoscaling.DescribeWarmPoolInput{
+				AutoScalingGroupName: &query,
+			}, nil
+		},
+		InputMapperList: func(scope string) (*autoscaling.DescribeWarmPoolInput, error) {
+			return nil, &sdp.QueryError{
+				ErrorType:   sdp.QueryError_NOTFOUND,
+				ErrorString: "list not supported for autoscaling-warm-pool, use get",
+			}
+		},
+		DescribeFunc: func(ctx context.Context, client *autoscaling.Client, input *autoscaling.DescribeWarmPoolInput) (*autoscaling.DescribeWarmPoolOutput, error) {
+			// The pages contain the instances in the pool, so combine them
+			// into a single output to return one item per pool
+			output := &autoscaling.DescribeWarmPoolOutput{}
+
+			paginator := autoscaling.NewDescribeWarmPoolPaginator(client, input)
+
+			for paginator.HasMorePages() {
+				limit.Wait(ctx) // Wait for rate limiting
+
+				page, err := paginator.NextPage(ctx)
+
+				if err != nil {
+					return nil, err
+				}
+
+				output.WarmPoolConfiguration = page.WarmPoolConfiguration
+				output.Instances = append(output.Instances, page.Instances...)
+			}
+
+			return output, nil
+		},
+		OutputMapper: warmPoolOutputMapper,
+	}
+}
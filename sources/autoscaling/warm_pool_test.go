@@ -0,0 +1,85 @@
+package autoscaling
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func TestWarmPoolOutputMapper(t *testing.T) {
+	t.Parallel()
+
+	input := autoscaling.DescribeWarmPoolInput{
+		AutoScalingGroupName: sources.PtrString("web"), // link
+	}
+
+	output := autoscaling.DescribeWarmPoolOutput{
+		WarmPoolConfiguration: &types.WarmPoolConfiguration{
+			MinSize:   sources.PtrInt32(1),
+			PoolState: types.WarmPoolStateStopped,
+		},
+		Instances: []types.Instance{
+			{
+				InstanceId:       sources.PtrString("i-0be6c4fe789cb1b78"), // link
+				AvailabilityZone: sources.PtrString("eu-west-2a"),
+				LifecycleState:   types.LifecycleStateWarmedStopped,
+				HealthStatus:     sources.PtrString("Healthy"),
+			},
+		},
+	}
+
+	items, err := warmPoolOutputMapper(context.Background(), nil, "944651592624.eu-west-2", &input, &output)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 1 {
+		t.Fatalf("expected 1 item, got %v", len(items))
+	}
+
+	item := items[0]
+
+	if err := item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "autoscaling-auto-scaling-group",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "web",
+			ExpectedScope:  "944651592624.eu-west-2",
+		},
+		{
+			ExpectedType:   "ec2-instance",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "i-0be6c4fe789cb1b78",
+			ExpectedScope:  "944651592624.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestWarmPoolOutputMapperNoPool(t *testing.T) {
+	t.Parallel()
+
+	input := autoscaling.DescribeWarmPoolInput{
+		AutoScalingGroupName: sources.PtrString("web"),
+	}
+
+	items, err := warmPoolOutputMapper(context.Background(), nil, "944651592624.eu-west-2", &input, &autoscaling.DescribeWarmPoolOutput{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 0 {
+		t.Errorf("expected no items, got %v", len(items))
+	}
+}
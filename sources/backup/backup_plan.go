@@ -0,0 +1,164 @@
+package backup
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/backup"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func backupPlanGetFunc(ctx context.Context, client BackupClient, scope, query string) (*backup.GetBackupPlanOutput, error) {
+	return client.GetBackupPlan(ctx, &backup.GetBackupPlanInput{
+		BackupPlanId: &query,
+	})
+}
+
+// backupPlanListFunc Lists all backup plans and then gets each of them, since
+// the list output doesn't include the rules
+func backupPlanListFunc(ctx context.Context, client BackupClient, scope string) ([]*backup.GetBackupPlanOutput, error) {
+	plans := make([]*backup.GetBackupPlanOutput, 0)
+	input := backup.ListBackupPlansInput{}
+
+	for {
+		out, err := client.ListBackupPlans(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, plan := range out.BackupPlansList {
+			if plan.BackupPlanId == nil {
+				continue
+			}
+
+			getOut, err := backupPlanGetFunc(ctx, client, scope, *plan.BackupPlanId)
+
+			if err != nil {
+				return nil, err
+			}
+
+			plans = append(plans, getOut)
+		}
+
+		if out.NextToken == nil {
+			break
+		}
+
+		input.NextToken = out.NextToken
+	}
+
+	return plans, nil
+}
+
+func backupPlanListTagsFunc(ctx context.Context, plan *backup.GetBackupPlanOutput, client BackupClient) (map[string]string, error) {
+	return tagsByResourceARN(ctx, client, plan.BackupPlanArn), nil
+}
+
+func backupPlanItemMapper(scope string, awsItem *backup.GetBackupPlanOutput) (*sdp.Item, error) {
+	attributes, err := sources.ToAttributesCase(awsItem, "resultMetadata")
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := sdp.Item{
+		Type:            "backup-backup-plan",
+		UniqueAttribute: "backupPlanId",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	if awsItem.BackupPlan != nil {
+		for _, rule := range awsItem.BackupPlan.Rules {
+			if rule.TargetBackupVaultName != nil {
+				// +overmind:link backup-backup-vault
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "backup-backup-vault",
+						Method: sdp.QueryMethod_GET,
+						Query:  *rule.TargetBackupVaultName,
+						Scope:  scope,
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Changing the vault will affect where backups are
+						// stored
+						In: true,
+						// Changing the plan will affect what is stored in the
+						// vault
+						Out: true,
+					},
+				})
+			}
+
+			for _, copyAction := range rule.CopyActions {
+				if copyAction.DestinationBackupVaultArn == nil {
+					continue
+				}
+
+				if a, err := sources.ParseARN(*copyAction.DestinationBackupVaultArn); err == nil {
+					// +overmind:link backup-backup-vault
+					item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+						Query: &sdp.Query{
+							Type:   "backup-backup-vault",
+							Method: sdp.QueryMethod_SEARCH,
+							Query:  *copyAction.DestinationBackupVaultArn,
+							Scope:  sources.FormatScope(a.AccountID, a.Region),
+						},
+						BlastPropagation: &sdp.BlastPropagation{
+							// Changing the destination vault will affect
+							// where copies are stored
+							In: true,
+							// Changing the plan will affect what is copied to
+							// the vault
+							Out: true,
+						},
+					})
+				}
+			}
+		}
+	}
+
+	if awsItem.BackupPlanId != nil {
+		// +overmind:link backup-backup-selection
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "backup-backup-selection",
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  *awsItem.BackupPlanId,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Selections are part of the plan, so they are tightly
+				// coupled
+				In:  true,
+				Out: true,
+			},
+		})
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type backup-backup-plan
+// +overmind:descriptiveType Backup Plan
+// +overmind:get Get a backup plan by ID
+// +overmind:list List all backup plans
+// +overmind:search Search for backup plans by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_backup_plan.id
+
+func NewBackupPlanSource(config aws.Config, accountID string, region string) *sources.GetListSource[*backup.GetBackupPlanOutput, BackupClient, *backup.Options] {
+	return &sources.GetListSource[*backup.GetBackupPlanOutput, BackupClient, *backup.Options]{
+		ItemType:     "backup-backup-plan",
+		Client:       backup.NewFromConfig(config),
+		AccountID:    accountID,
+		Region:       region,
+		GetFunc:      backupPlanGetFunc,
+		ListFunc:     backupPlanListFunc,
+		ListTagsFunc: backupPlanListTagsFunc,
+		ItemMapper:   backupPlanItemMapper,
+	}
+}
@@ -0,0 +1,117 @@
+package backup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/backup"
+	"github.com/aws/aws-sdk-go-v2/service/backup/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testBackupClient) GetBackupPlan(ctx context.Context, params *backup.GetBackupPlanInput, optFns ...func(*backup.Options)) (*backup.GetBackupPlanOutput, error) {
+	return &backup.GetBackupPlanOutput{
+		BackupPlanArn: sources.PtrString("arn:aws:backup:eu-west-1:123456789012:backup-plan:" + *params.BackupPlanId),
+		BackupPlanId:  params.BackupPlanId,
+		BackupPlan: &types.BackupPlan{
+			BackupPlanName: sources.PtrString("daily"),
+			Rules: []types.BackupRule{
+				{
+					RuleName:              sources.PtrString("daily-backups"),
+					RuleId:                sources.PtrString("rule-1"),
+					ScheduleExpression:    sources.PtrString("cron(0 5 ? * * *)"),
+					TargetBackupVaultName: sources.PtrString("Default"), // link
+					Lifecycle: &types.Lifecycle{
+						DeleteAfterDays: sources.PtrInt64(35),
+					},
+					CopyActions: []types.CopyAction{
+						{
+							DestinationBackupVaultArn: sources.PtrString("arn:aws:backup:us-east-1:123456789012:backup-vault:dr"), // link
+						},
+					},
+				},
+			},
+		},
+		CreationDate: sources.PtrTime(time.Now()),
+		VersionId:    sources.PtrString("version-1"),
+	}, nil
+}
+
+func (c testBackupClient) ListBackupPlans(ctx context.Context, params *backup.ListBackupPlansInput, optFns ...func(*backup.Options)) (*backup.ListBackupPlansOutput, error) {
+	return &backup.ListBackupPlansOutput{
+		BackupPlansList: []types.BackupPlansListMember{
+			{
+				BackupPlanId:   sources.PtrString("plan-1"),
+				BackupPlanName: sources.PtrString("daily"),
+			},
+		},
+	}, nil
+}
+
+func TestBackupPlanGetFunc(t *testing.T) {
+	plan, err := backupPlanGetFunc(context.Background(), testBackupClient{}, "123456789012.eu-west-1", "plan-1")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := backupPlanItemMapper("123456789012.eu-west-1", plan)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := item.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "backup-backup-vault",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "Default",
+			ExpectedScope:  "123456789012.eu-west-1",
+		},
+		{
+			ExpectedType:   "backup-backup-vault",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:backup:us-east-1:123456789012:backup-vault:dr",
+			ExpectedScope:  "123456789012.us-east-1",
+		},
+		{
+			ExpectedType:   "backup-backup-selection",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "plan-1",
+			ExpectedScope:  "123456789012.eu-west-1",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestBackupPlanListFunc(t *testing.T) {
+	plans, err := backupPlanListFunc(context.Background(), testBackupClient{}, "123456789012.eu-west-1")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(plans) != 1 {
+		t.Errorf("expected 1 plan, got %v", len(plans))
+	}
+}
+
+func TestNewBackupPlanSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewBackupPlanSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
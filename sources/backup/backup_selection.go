@@ -0,0 +1,524 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/backup"
+	"github.com/aws/aws-sdk-go-v2/service/backup/types"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	taggingTypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// The prefix that is used for tag condition keys in backup selections
+const resourceTagPrefix = "aws:ResourceTag/"
+
+// The resource types that we resolve tag-based selections for. These match
+// the types that we are able to link to in protectedResourceLink
+var protectedResourceTypes = []string{
+	"dynamodb:table",
+	"ec2:instance",
+	"ec2:volume",
+	"elasticfilesystem:file-system",
+	"rds:cluster",
+	"rds:db",
+}
+
+type BackupSelectionDetails struct {
+	Selection *backup.GetBackupSelectionOutput
+
+	// The ARNs of the resources that the selection currently matches
+	ProtectedResources []string
+}
+
+// parseSelectionQuery Parses a query in the format {backupPlanId}/{selectionId}
+func parseSelectionQuery(query string) (backupPlanID string, selectionID string, err error) {
+	sections := strings.Split(query, "/")
+
+	if len(sections) != 2 || sections[0] == "" || sections[1] == "" {
+		return "", "", &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: fmt.Sprintf("query %v must be in the format {backupPlanId}/{selectionId}", query),
+		}
+	}
+
+	return sections[0], sections[1], nil
+}
+
+// tagKey Returns the tag key from a selection condition key, which may or may
+// not include the aws:ResourceTag/ prefix
+func tagKey(conditionKey *string) string {
+	if conditionKey == nil {
+		return ""
+	}
+
+	return strings.TrimPrefix(*conditionKey, resourceTagPrefix)
+}
+
+// matchesConditions Checks whether a set of tags satisfies all of the
+// conditions of a selection
+func matchesConditions(tags map[string]string, conditions *types.Conditions) bool {
+	if conditions == nil {
+		return true
+	}
+
+	for _, c := range conditions.StringEquals {
+		if value, ok := tags[tagKey(c.ConditionKey)]; !ok || c.ConditionValue == nil || value != *c.ConditionValue {
+			return false
+		}
+	}
+
+	for _, c := range conditions.StringNotEquals {
+		if value, ok := tags[tagKey(c.ConditionKey)]; ok && c.ConditionValue != nil && value == *c.ConditionValue {
+			return false
+		}
+	}
+
+	for _, c := range conditions.StringLike {
+		if value, ok := tags[tagKey(c.ConditionKey)]; !ok || c.ConditionValue == nil || !wildcardMatch(*c.ConditionValue, value) {
+			return false
+		}
+	}
+
+	for _, c := range conditions.StringNotLike {
+		if value, ok := tags[tagKey(c.ConditionKey)]; ok && c.ConditionValue != nil && wildcardMatch(*c.ConditionValue, value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// getTaggedResources Returns the ARNs and tags of all resources that match the
+// given input
+func getTaggedResources(ctx context.Context, client TaggingClient, input *resourcegroupstaggingapi.GetResourcesInput) (map[string]map[string]string, error) {
+	resources := make(map[string]map[string]string)
+
+	for {
+		out, err := client.GetResources(ctx, input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, mapping := range out.ResourceTagMappingList {
+			if mapping.ResourceARN == nil {
+				continue
+			}
+
+			tags := make(map[string]string)
+
+			for _, tag := range mapping.Tags {
+				if tag.Key != nil && tag.Value != nil {
+					tags[*tag.Key] = *tag.Value
+				}
+			}
+
+			resources[*mapping.ResourceARN] = tags
+		}
+
+		if out.PaginationToken == nil || *out.PaginationToken == "" {
+			break
+		}
+
+		input.PaginationToken = out.PaginationToken
+	}
+
+	return resources, nil
+}
+
+// resolveSelection Works out which resources a selection currently applies
+// to. Resources that are listed explicitly are included directly, and
+// tag-based selections are evaluated using the tags that the Resource Groups
+// Tagging API reports for each resource. Since that API can only see resources
+// that have tags, wildcard selections without tags can only be partially
+// resolved
+func resolveSelection(ctx context.Context, client TaggingClient, selection *types.BackupSelection) ([]string, error) {
+	// Candidate resources and their tags
+	candidates := make(map[string]map[string]string)
+
+	explicit := make([]string, 0)
+	patterns := make([]string, 0)
+
+	for _, resource := range selection.Resources {
+		if strings.ContainsAny(resource, "*?") {
+			patterns = append(patterns, resource)
+		} else {
+			explicit = append(explicit, resource)
+		}
+	}
+
+	if len(explicit) > 0 {
+		for _, resource := range explicit {
+			candidates[resource] = map[string]string{}
+		}
+
+		if selection.Conditions != nil {
+			// We need the tags of explicit resources in order to evaluate
+			// the conditions. The API accepts up to 100 ARNs at a time
+			for i := 0; i < len(explicit); i += 100 {
+				end := min(i+100, len(explicit))
+
+				found, err := getTaggedResources(ctx, client, &resourcegroupstaggingapi.GetResourcesInput{
+					ResourceARNList: explicit[i:end],
+				})
+
+				if err != nil {
+					return nil, err
+				}
+
+				for arn, tags := range found {
+					candidates[arn] = tags
+				}
+			}
+		}
+	}
+
+	// Each tag in ListOfTags selects resources on its own
+	for _, condition := range selection.ListOfTags {
+		if condition.ConditionType != types.ConditionTypeStringequals || condition.ConditionValue == nil {
+			continue
+		}
+
+		found, err := getTaggedResources(ctx, client, &resourcegroupstaggingapi.GetResourcesInput{
+			ResourceTypeFilters: protectedResourceTypes,
+			TagFilters: []taggingTypes.TagFilter{
+				{
+					Key:    aws.String(tagKey(condition.ConditionKey)),
+					Values: []string{*condition.ConditionValue},
+				},
+			},
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		for arn, tags := range found {
+			candidates[arn] = tags
+		}
+	}
+
+	if len(patterns) > 0 {
+		// Narrow down the search as much as we can using the conditions, the
+		// rest will be evaluated by matchesConditions
+		tagFilters := make([]taggingTypes.TagFilter, 0)
+
+		if selection.Conditions != nil {
+			for _, c := range selection.Conditions.StringEquals {
+				if c.ConditionValue != nil {
+					tagFilters = append(tagFilters, taggingTypes.TagFilter{
+						Key:    aws.String(tagKey(c.ConditionKey)),
+						Values: []string{*c.ConditionValue},
+					})
+				}
+			}
+
+			for _, c := range selection.Conditions.StringLike {
+				tagFilters = append(tagFilters, taggingTypes.TagFilter{
+					Key: aws.String(tagKey(c.ConditionKey)),
+				})
+			}
+		}
+
+		found, err := getTaggedResources(ctx, client, &resourcegroupstaggingapi.GetResourcesInput{
+			ResourceTypeFilters: protectedResourceTypes,
+			TagFilters:          tagFilters,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		for arn, tags := range found {
+			for _, pattern := range patterns {
+				if wildcardMatch(pattern, arn) {
+					candidates[arn] = tags
+					break
+				}
+			}
+		}
+	}
+
+	resources := make([]string, 0)
+
+	for arn, tags := range candidates {
+		if !matchesConditions(tags, selection.Conditions) {
+			continue
+		}
+
+		excluded := false
+
+		for _, notResource := range selection.NotResources {
+			if wildcardMatch(notResource, arn) {
+				excluded = true
+				break
+			}
+		}
+
+		if !excluded {
+			resources = append(resources, arn)
+		}
+	}
+
+	sort.Strings(resources)
+
+	return resources, nil
+}
+
+func backupSelectionGetFunc(ctx context.Context, client BackupClient, taggingClient TaggingClient, query string) (*BackupSelectionDetails, error) {
+	backupPlanID, selectionID, err := parseSelectionQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := client.GetBackupSelection(ctx, &backup.GetBackupSelectionInput{
+		BackupPlanId: &backupPlanID,
+		SelectionId:  &selectionID,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	details := BackupSelectionDetails{
+		Selection:          out,
+		ProtectedResources: make([]string, 0),
+	}
+
+	if out.BackupSelection != nil && taggingClient != nil {
+		resources, err := resolveSelection(ctx, taggingClient, out.BackupSelection)
+
+		if err != nil {
+			// Not being able to resolve the resources shouldn't stop us from
+			// returning the selection itself
+			span := trace.SpanFromContext(ctx)
+			span.AddEvent("Error resolving backup selection resources", trace.WithAttributes(
+				attribute.String("error", err.Error()),
+			))
+		} else {
+			details.ProtectedResources = resources
+		}
+	}
+
+	return &details, nil
+}
+
+// listBackupSelections Lists all selections for a given backup plan
+func listBackupSelections(ctx context.Context, client BackupClient, taggingClient TaggingClient, backupPlanID string) ([]*BackupSelectionDetails, error) {
+	selections := make([]*BackupSelectionDetails, 0)
+	input := backup.ListBackupSelectionsInput{
+		BackupPlanId: &backupPlanID,
+	}
+
+	for {
+		out, err := client.ListBackupSelections(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, selection := range out.BackupSelectionsList {
+			if selection.SelectionId == nil {
+				continue
+			}
+
+			details, err := backupSelectionGetFunc(ctx, client, taggingClient, backupPlanID+"/"+*selection.SelectionId)
+
+			if err != nil {
+				return nil, err
+			}
+
+			selections = append(selections, details)
+		}
+
+		if out.NextToken == nil {
+			break
+		}
+
+		input.NextToken = out.NextToken
+	}
+
+	return selections, nil
+}
+
+func backupSelectionListFunc(ctx context.Context, client BackupClient, taggingClient TaggingClient) ([]*BackupSelectionDetails, error) {
+	selections := make([]*BackupSelectionDetails, 0)
+	input := backup.ListBackupPlansInput{}
+
+	for {
+		out, err := client.ListBackupPlans(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, plan := range out.BackupPlansList {
+			if plan.BackupPlanId == nil {
+				continue
+			}
+
+			planSelections, err := listBackupSelections(ctx, client, taggingClient, *plan.BackupPlanId)
+
+			if err != nil {
+				return nil, err
+			}
+
+			selections = append(selections, planSelections...)
+		}
+
+		if out.NextToken == nil {
+			break
+		}
+
+		input.NextToken = out.NextToken
+	}
+
+	return selections, nil
+}
+
+// backupSelectionSearchFunc Searches for the selections of a backup plan by
+// the plan's ID or ARN
+func backupSelectionSearchFunc(ctx context.Context, client BackupClient, taggingClient TaggingClient, query string) ([]*BackupSelectionDetails, error) {
+	backupPlanID := query
+
+	if a, err := sources.ParseARN(query); err == nil {
+		if a.Type() != "backup-plan" {
+			return nil, &sdp.QueryError{
+				ErrorType:   sdp.QueryError_NOTFOUND,
+				ErrorString: fmt.Sprintf("ARN %v is not a backup plan", query),
+			}
+		}
+
+		backupPlanID = a.ResourceID()
+	}
+
+	return listBackupSelections(ctx, client, taggingClient, backupPlanID)
+}
+
+func backupSelectionItemMapper(scope string, awsItem *BackupSelectionDetails) (*sdp.Item, error) {
+	enrichedSelection := struct {
+		*backup.GetBackupSelectionOutput
+		ProtectedResources []string
+	}{
+		GetBackupSelectionOutput: awsItem.Selection,
+		ProtectedResources:       awsItem.ProtectedResources,
+	}
+
+	attributes, err := sources.ToAttributesCase(enrichedSelection, "resultMetadata")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if awsItem.Selection.BackupPlanId != nil && awsItem.Selection.SelectionId != nil {
+		err = attributes.Set("uniqueName", *awsItem.Selection.BackupPlanId+"/"+*awsItem.Selection.SelectionId)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	item := sdp.Item{
+		Type:            "backup-backup-selection",
+		UniqueAttribute: "uniqueName",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	if awsItem.Selection.BackupPlanId != nil {
+		// +overmind:link backup-backup-plan
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "backup-backup-plan",
+				Method: sdp.QueryMethod_GET,
+				Query:  *awsItem.Selection.BackupPlanId,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Selections are part of the plan, so they are tightly
+				// coupled
+				In:  true,
+				Out: true,
+			},
+		})
+	}
+
+	if awsItem.Selection.BackupSelection != nil && awsItem.Selection.BackupSelection.IamRoleArn != nil {
+		if a, err := sources.ParseARN(*awsItem.Selection.BackupSelection.IamRoleArn); err == nil {
+			// +overmind:link iam-role
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "iam-role",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *awsItem.Selection.BackupSelection.IamRoleArn,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the role will affect whether the resources can
+					// be backed up
+					In: true,
+					// Changing the selection won't affect the role
+					Out: false,
+				},
+			})
+		}
+	}
+
+	for _, resourceARN := range awsItem.ProtectedResources {
+		// +overmind:link dynamodb-table
+		// +overmind:link ec2-instance
+		// +overmind:link ec2-volume
+		// +overmind:link efs-file-system
+		// +overmind:link rds-db-cluster
+		// +overmind:link rds-db-instance
+		if link := protectedResourceLink(resourceARN); link != nil {
+			item.LinkedItemQueries = append(item.LinkedItemQueries, link)
+		}
+	}
+
+	return &item, nil
+}
+
+// newBackupSelectionSource Creates a backup selection source with the given
+// tagging client, which is used to resolve tag-based selections
+func newBackupSelectionSource(client BackupClient, taggingClient TaggingClient, accountID string, region string) *sources.GetListSource[*BackupSelectionDetails, BackupClient, *backup.Options] {
+	return &sources.GetListSource[*BackupSelectionDetails, BackupClient, *backup.Options]{
+		ItemType:  "backup-backup-selection",
+		Client:    client,
+		AccountID: accountID,
+		Region:    region,
+		GetFunc: func(ctx context.Context, client BackupClient, scope, query string) (*BackupSelectionDetails, error) {
+			return backupSelectionGetFunc(ctx, client, taggingClient, query)
+		},
+		ListFunc: func(ctx context.Context, client BackupClient, scope string) ([]*BackupSelectionDetails, error) {
+			return backupSelectionListFunc(ctx, client, taggingClient)
+		},
+		SearchFunc: func(ctx context.Context, client BackupClient, scope, query string) ([]*BackupSelectionDetails, error) {
+			return backupSelectionSearchFunc(ctx, client, taggingClient, query)
+		},
+		ItemMapper: backupSelectionItemMapper,
+	}
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type backup-backup-selection
+// +overmind:descriptiveType Backup Selection
+// +overmind:get Get a backup selection by {backupPlanId}/{selectionId}
+// +overmind:list List all backup selections for all plans
+// +overmind:search Search for backup selections by backup plan ID or ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_backup_selection.plan_id
+// +overmind:terraform:method SEARCH
+
+func NewBackupSelectionSource(config aws.Config, accountID string, region string) *sources.GetListSource[*BackupSelectionDetails, BackupClient, *backup.Options] {
+	return newBackupSelectionSource(backup.NewFromConfig(config), resourcegroupstaggingapi.NewFromConfig(config), accountID, region)
+}
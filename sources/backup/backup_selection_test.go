@@ -0,0 +1,316 @@
+package backup
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/backup"
+	"github.com/aws/aws-sdk-go-v2/service/backup/types"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	taggingTypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+// testTaggingClient Returns the resources from a fixed set that match the
+// filters in the request
+type testTaggingClient struct {
+	Resources map[string]map[string]string
+}
+
+func (c testTaggingClient) GetResources(ctx context.Context, params *resourcegroupstaggingapi.GetResourcesInput, optFns ...func(*resourcegroupstaggingapi.Options)) (*resourcegroupstaggingapi.GetResourcesOutput, error) {
+	out := resourcegroupstaggingapi.GetResourcesOutput{}
+
+	for arn, tags := range c.Resources {
+		if len(params.ResourceARNList) > 0 && !slices.Contains(params.ResourceARNList, arn) {
+			continue
+		}
+
+		matches := true
+
+		for _, filter := range params.TagFilters {
+			value, ok := tags[*filter.Key]
+
+			if !ok || (len(filter.Values) > 0 && !slices.Contains(filter.Values, value)) {
+				matches = false
+			}
+		}
+
+		if !matches {
+			continue
+		}
+
+		mapping := taggingTypes.ResourceTagMapping{
+			ResourceARN: sources.PtrString(arn),
+		}
+
+		for k, v := range tags {
+			mapping.Tags = append(mapping.Tags, taggingTypes.Tag{
+				Key:   sources.PtrString(k),
+				Value: sources.PtrString(v),
+			})
+		}
+
+		out.ResourceTagMappingList = append(out.ResourceTagMappingList, mapping)
+	}
+
+	return &out, nil
+}
+
+var testTaggedResources = testTaggingClient{
+	Resources: map[string]map[string]string{
+		"arn:aws:rds:eu-west-1:123456789012:db:orders": {
+			"backup": "daily",
+			"env":    "prod",
+		},
+		"arn:aws:rds:eu-west-1:123456789012:db:orders-staging": {
+			"backup": "daily",
+			"env":    "staging",
+		},
+		"arn:aws:dynamodb:eu-west-1:123456789012:table/sessions": {
+			"backup": "hourly",
+			"env":    "prod",
+		},
+		"arn:aws:ec2:eu-west-1:123456789012:volume/vol-0123456789abcdef0": {
+			"env": "prod",
+		},
+	},
+}
+
+func (c testBackupClient) GetBackupSelection(ctx context.Context, params *backup.GetBackupSelectionInput, optFns ...func(*backup.Options)) (*backup.GetBackupSelectionOutput, error) {
+	return &backup.GetBackupSelectionOutput{
+		BackupPlanId: params.BackupPlanId,
+		SelectionId:  params.SelectionId,
+		CreationDate: sources.PtrTime(time.Now()),
+		BackupSelection: &types.BackupSelection{
+			SelectionName: sources.PtrString("daily-prod"),
+			IamRoleArn:    sources.PtrString("arn:aws:iam::123456789012:role/service-role/AWSBackupDefaultServiceRole"), // link
+			Resources: []string{
+				"arn:aws:elasticfilesystem:eu-west-1:123456789012:file-system/fs-01234567", // link
+			},
+			ListOfTags: []types.Condition{
+				{
+					ConditionKey:   sources.PtrString("backup"),
+					ConditionType:  types.ConditionTypeStringequals,
+					ConditionValue: sources.PtrString("daily"), // link
+				},
+			},
+		},
+	}, nil
+}
+
+func (c testBackupClient) ListBackupSelections(ctx context.Context, params *backup.ListBackupSelectionsInput, optFns ...func(*backup.Options)) (*backup.ListBackupSelectionsOutput, error) {
+	return &backup.ListBackupSelectionsOutput{
+		BackupSelectionsList: []types.BackupSelectionsListMember{
+			{
+				BackupPlanId:  params.BackupPlanId,
+				SelectionId:   sources.PtrString("selection-1"),
+				SelectionName: sources.PtrString("daily-prod"),
+			},
+		},
+	}, nil
+}
+
+func TestResolveSelection(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Selection types.BackupSelection
+		Expected  []string
+	}{
+		{
+			Name: "explicit resources",
+			Selection: types.BackupSelection{
+				Resources: []string{
+					"arn:aws:dynamodb:eu-west-1:123456789012:table/untagged",
+				},
+			},
+			Expected: []string{
+				"arn:aws:dynamodb:eu-west-1:123456789012:table/untagged",
+			},
+		},
+		{
+			Name: "list of tags",
+			Selection: types.BackupSelection{
+				ListOfTags: []types.Condition{
+					{
+						ConditionKey:   sources.PtrString("aws:ResourceTag/backup"),
+						ConditionType:  types.ConditionTypeStringequals,
+						ConditionValue: sources.PtrString("hourly"),
+					},
+					{
+						ConditionKey:   sources.PtrString("backup"),
+						ConditionType:  types.ConditionTypeStringequals,
+						ConditionValue: sources.PtrString("daily"),
+					},
+				},
+				NotResources: []string{
+					"arn:aws:rds:*:*:db:*-staging",
+				},
+			},
+			Expected: []string{
+				"arn:aws:dynamodb:eu-west-1:123456789012:table/sessions",
+				"arn:aws:rds:eu-west-1:123456789012:db:orders",
+			},
+		},
+		{
+			Name: "wildcard with conditions",
+			Selection: types.BackupSelection{
+				Resources: []string{
+					"*",
+				},
+				Conditions: &types.Conditions{
+					StringEquals: []types.ConditionParameter{
+						{
+							ConditionKey:   sources.PtrString("aws:ResourceTag/env"),
+							ConditionValue: sources.PtrString("prod"),
+						},
+					},
+					StringNotLike: []types.ConditionParameter{
+						{
+							ConditionKey:   sources.PtrString("aws:ResourceTag/backup"),
+							ConditionValue: sources.PtrString("hour*"),
+						},
+					},
+				},
+			},
+			Expected: []string{
+				"arn:aws:ec2:eu-west-1:123456789012:volume/vol-0123456789abcdef0",
+				"arn:aws:rds:eu-west-1:123456789012:db:orders",
+			},
+		},
+		{
+			Name: "explicit resources with conditions",
+			Selection: types.BackupSelection{
+				Resources: []string{
+					"arn:aws:rds:eu-west-1:123456789012:db:orders",
+					"arn:aws:rds:eu-west-1:123456789012:db:orders-staging",
+				},
+				Conditions: &types.Conditions{
+					StringLike: []types.ConditionParameter{
+						{
+							ConditionKey:   sources.PtrString("aws:ResourceTag/env"),
+							ConditionValue: sources.PtrString("stag*"),
+						},
+					},
+				},
+			},
+			Expected: []string{
+				"arn:aws:rds:eu-west-1:123456789012:db:orders-staging",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			resources, err := resolveSelection(context.Background(), testTaggedResources, &test.Selection)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !slices.Equal(resources, test.Expected) {
+				t.Errorf("expected %v, got %v", test.Expected, resources)
+			}
+		})
+	}
+}
+
+func TestBackupSelectionGetFunc(t *testing.T) {
+	selection, err := backupSelectionGetFunc(context.Background(), testBackupClient{}, testTaggedResources, "plan-1/selection-1")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := backupSelectionItemMapper("123456789012.eu-west-1", selection)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := item.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if item.UniqueAttributeValue() != "plan-1/selection-1" {
+		t.Errorf("expected unique attribute value plan-1/selection-1, got %v", item.UniqueAttributeValue())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "backup-backup-plan",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "plan-1",
+			ExpectedScope:  "123456789012.eu-west-1",
+		},
+		{
+			ExpectedType:   "iam-role",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:iam::123456789012:role/service-role/AWSBackupDefaultServiceRole",
+			ExpectedScope:  "123456789012",
+		},
+		{
+			ExpectedType:   "efs-file-system",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:elasticfilesystem:eu-west-1:123456789012:file-system/fs-01234567",
+			ExpectedScope:  "123456789012.eu-west-1",
+		},
+		{
+			ExpectedType:   "rds-db-instance",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:rds:eu-west-1:123456789012:db:orders",
+			ExpectedScope:  "123456789012.eu-west-1",
+		},
+		{
+			ExpectedType:   "rds-db-instance",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:rds:eu-west-1:123456789012:db:orders-staging",
+			ExpectedScope:  "123456789012.eu-west-1",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestBackupSelectionSearchFunc(t *testing.T) {
+	selections, err := backupSelectionSearchFunc(context.Background(), testBackupClient{}, testTaggedResources, "arn:aws:backup:eu-west-1:123456789012:backup-plan:plan-1")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(selections) != 1 {
+		t.Fatalf("expected 1 selection, got %v", len(selections))
+	}
+
+	if *selections[0].Selection.BackupPlanId != "plan-1" {
+		t.Errorf("expected plan ID plan-1, got %v", *selections[0].Selection.BackupPlanId)
+	}
+}
+
+func TestBackupSelectionListFunc(t *testing.T) {
+	selections, err := backupSelectionListFunc(context.Background(), testBackupClient{}, testTaggedResources)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(selections) != 1 {
+		t.Errorf("expected 1 selection, got %v", len(selections))
+	}
+}
+
+func TestNewBackupSelectionSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewBackupSelectionSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
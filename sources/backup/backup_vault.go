@@ -0,0 +1,157 @@
+package backup
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/backup"
+	"github.com/aws/aws-sdk-go-v2/service/backup/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func backupVaultGetFunc(ctx context.Context, client BackupClient, scope, query string) (*types.BackupVaultListMember, error) {
+	out, err := client.DescribeBackupVault(ctx, &backup.DescribeBackupVaultInput{
+		BackupVaultName: &query,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.BackupVaultListMember{
+		BackupVaultArn:         out.BackupVaultArn,
+		BackupVaultName:        out.BackupVaultName,
+		CreationDate:           out.CreationDate,
+		CreatorRequestId:       out.CreatorRequestId,
+		EncryptionKeyArn:       out.EncryptionKeyArn,
+		LockDate:               out.LockDate,
+		Locked:                 out.Locked,
+		MaxRetentionDays:       out.MaxRetentionDays,
+		MinRetentionDays:       out.MinRetentionDays,
+		NumberOfRecoveryPoints: out.NumberOfRecoveryPoints,
+	}, nil
+}
+
+// listBackupVaults Lists all backup vaults in the region
+func listBackupVaults(ctx context.Context, client BackupClient) ([]types.BackupVaultListMember, error) {
+	vaults := make([]types.BackupVaultListMember, 0)
+	input := backup.ListBackupVaultsInput{}
+
+	for {
+		out, err := client.ListBackupVaults(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		vaults = append(vaults, out.BackupVaultList...)
+
+		if out.NextToken == nil {
+			break
+		}
+
+		input.NextToken = out.NextToken
+	}
+
+	return vaults, nil
+}
+
+func backupVaultListFunc(ctx context.Context, client BackupClient, scope string) ([]*types.BackupVaultListMember, error) {
+	vaults, err := listBackupVaults(ctx, client)
+
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*types.BackupVaultListMember, 0, len(vaults))
+
+	for i := range vaults {
+		items = append(items, &vaults[i])
+	}
+
+	return items, nil
+}
+
+func backupVaultListTagsFunc(ctx context.Context, vault *types.BackupVaultListMember, client BackupClient) (map[string]string, error) {
+	return tagsByResourceARN(ctx, client, vault.BackupVaultArn), nil
+}
+
+func backupVaultItemMapper(scope string, awsItem *types.BackupVaultListMember) (*sdp.Item, error) {
+	attributes, err := sources.ToAttributesCase(awsItem)
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := sdp.Item{
+		Type:            "backup-backup-vault",
+		UniqueAttribute: "backupVaultName",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	if awsItem.EncryptionKeyArn != nil {
+		if a, err := sources.ParseARN(*awsItem.EncryptionKeyArn); err == nil {
+			// +overmind:link kms-key
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "kms-key",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *awsItem.EncryptionKeyArn,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the key will affect the ability to read the
+					// recovery points in the vault
+					In: true,
+					// Changing the vault won't affect the key
+					Out: false,
+				},
+			})
+		}
+	}
+
+	if awsItem.BackupVaultName != nil {
+		// +overmind:link backup-recovery-point
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "backup-recovery-point",
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  *awsItem.BackupVaultName,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Recovery points don't affect the vault
+				In: false,
+				// Changing the vault, for example its lock or access policy,
+				// will affect the recovery points stored in it
+				Out: true,
+			},
+		})
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type backup-backup-vault
+// +overmind:descriptiveType Backup Vault
+// +overmind:get Get a backup vault by name
+// +overmind:list List all backup vaults
+// +overmind:search Search for backup vaults by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_backup_vault.name
+
+func NewBackupVaultSource(config aws.Config, accountID string, region string) *sources.GetListSource[*types.BackupVaultListMember, BackupClient, *backup.Options] {
+	return &sources.GetListSource[*types.BackupVaultListMember, BackupClient, *backup.Options]{
+		ItemType:     "backup-backup-vault",
+		Client:       backup.NewFromConfig(config),
+		AccountID:    accountID,
+		Region:       region,
+		GetFunc:      backupVaultGetFunc,
+		ListFunc:     backupVaultListFunc,
+		ListTagsFunc: backupVaultListTagsFunc,
+		ItemMapper:   backupVaultItemMapper,
+	}
+}
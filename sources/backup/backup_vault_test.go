@@ -0,0 +1,96 @@
+package backup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/backup"
+	"github.com/aws/aws-sdk-go-v2/service/backup/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testBackupClient) DescribeBackupVault(ctx context.Context, params *backup.DescribeBackupVaultInput, optFns ...func(*backup.Options)) (*backup.DescribeBackupVaultOutput, error) {
+	return &backup.DescribeBackupVaultOutput{
+		BackupVaultArn:         sources.PtrString("arn:aws:backup:eu-west-1:123456789012:backup-vault:" + *params.BackupVaultName),
+		BackupVaultName:        params.BackupVaultName,
+		CreationDate:           sources.PtrTime(time.Now()),
+		EncryptionKeyArn:       sources.PtrString("arn:aws:kms:eu-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"), // link
+		Locked:                 sources.PtrBool(false),
+		NumberOfRecoveryPoints: 3,
+		VaultType:              types.VaultTypeBackupVault,
+	}, nil
+}
+
+func (c testBackupClient) ListBackupVaults(ctx context.Context, params *backup.ListBackupVaultsInput, optFns ...func(*backup.Options)) (*backup.ListBackupVaultsOutput, error) {
+	return &backup.ListBackupVaultsOutput{
+		BackupVaultList: []types.BackupVaultListMember{
+			{
+				BackupVaultArn:         sources.PtrString("arn:aws:backup:eu-west-1:123456789012:backup-vault:Default"),
+				BackupVaultName:        sources.PtrString("Default"),
+				NumberOfRecoveryPoints: 3,
+			},
+		},
+	}, nil
+}
+
+func TestBackupVaultGetFunc(t *testing.T) {
+	vault, err := backupVaultGetFunc(context.Background(), testBackupClient{}, "123456789012.eu-west-1", "Default")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := backupVaultItemMapper("123456789012.eu-west-1", vault)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := item.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "kms-key",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:kms:eu-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+			ExpectedScope:  "123456789012.eu-west-1",
+		},
+		{
+			ExpectedType:   "backup-recovery-point",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "Default",
+			ExpectedScope:  "123456789012.eu-west-1",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestBackupVaultListFunc(t *testing.T) {
+	vaults, err := backupVaultListFunc(context.Background(), testBackupClient{}, "123456789012.eu-west-1")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(vaults) != 1 {
+		t.Errorf("expected 1 vault, got %v", len(vaults))
+	}
+}
+
+func TestNewBackupVaultSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewBackupVaultSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -0,0 +1,414 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/backup"
+	"github.com/aws/aws-sdk-go-v2/service/backup/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+// parseRecoveryPointQuery Parses a query in the format
+// {backupVaultName}/{recoveryPointArn}. Vault names can't contain slashes so
+// we split on the first one
+func parseRecoveryPointQuery(query string) (backupVaultName string, recoveryPointARN string, err error) {
+	i := strings.Index(query, "/")
+
+	if i <= 0 || i == len(query)-1 {
+		return "", "", &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: fmt.Sprintf("query %v must be in the format {backupVaultName}/{recoveryPointArn}", query),
+		}
+	}
+
+	return query[:i], query[i+1:], nil
+}
+
+func recoveryPointGetFunc(ctx context.Context, client BackupClient, scope, query string) (*types.RecoveryPointByBackupVault, error) {
+	backupVaultName, recoveryPointARN, err := parseRecoveryPointQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := client.DescribeRecoveryPoint(ctx, &backup.DescribeRecoveryPointInput{
+		BackupVaultName:  &backupVaultName,
+		RecoveryPointArn: &recoveryPointARN,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.RecoveryPointByBackupVault{
+		BackupSizeInBytes:         out.BackupSizeInBytes,
+		BackupVaultArn:            out.BackupVaultArn,
+		BackupVaultName:           out.BackupVaultName,
+		CalculatedLifecycle:       out.CalculatedLifecycle,
+		CompletionDate:            out.CompletionDate,
+		CompositeMemberIdentifier: out.CompositeMemberIdentifier,
+		CreatedBy:                 out.CreatedBy,
+		CreationDate:              out.CreationDate,
+		EncryptionKeyArn:          out.EncryptionKeyArn,
+		IamRoleArn:                out.IamRoleArn,
+		IsEncrypted:               out.IsEncrypted,
+		IsParent:                  out.IsParent,
+		LastRestoreTime:           out.LastRestoreTime,
+		Lifecycle:                 out.Lifecycle,
+		ParentRecoveryPointArn:    out.ParentRecoveryPointArn,
+		RecoveryPointArn:          out.RecoveryPointArn,
+		ResourceArn:               out.ResourceArn,
+		ResourceName:              out.ResourceName,
+		ResourceType:              out.ResourceType,
+		SourceBackupVaultArn:      out.SourceBackupVaultArn,
+		Status:                    out.Status,
+		StatusMessage:             out.StatusMessage,
+		VaultType:                 out.VaultType,
+	}, nil
+}
+
+// listRecoveryPointsByVault Lists all recovery points in a given vault
+func listRecoveryPointsByVault(ctx context.Context, client BackupClient, backupVaultName string) ([]*types.RecoveryPointByBackupVault, error) {
+	recoveryPoints := make([]*types.RecoveryPointByBackupVault, 0)
+	input := backup.ListRecoveryPointsByBackupVaultInput{
+		BackupVaultName: &backupVaultName,
+	}
+
+	for {
+		out, err := client.ListRecoveryPointsByBackupVault(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range out.RecoveryPoints {
+			recoveryPoints = append(recoveryPoints, &out.RecoveryPoints[i])
+		}
+
+		if out.NextToken == nil {
+			break
+		}
+
+		input.NextToken = out.NextToken
+	}
+
+	return recoveryPoints, nil
+}
+
+func recoveryPointListFunc(ctx context.Context, client BackupClient, scope string) ([]*types.RecoveryPointByBackupVault, error) {
+	vaults, err := listBackupVaults(ctx, client)
+
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryPoints := make([]*types.RecoveryPointByBackupVault, 0)
+
+	for _, vault := range vaults {
+		if vault.BackupVaultName == nil {
+			continue
+		}
+
+		vaultRecoveryPoints, err := listRecoveryPointsByVault(ctx, client, *vault.BackupVaultName)
+
+		if err != nil {
+			return nil, err
+		}
+
+		recoveryPoints = append(recoveryPoints, vaultRecoveryPoints...)
+	}
+
+	return recoveryPoints, nil
+}
+
+// findRecoveryPoint Finds a recovery point by ARN. The API needs to know which
+// vault the recovery point is in, so we need to check each of them
+func findRecoveryPoint(ctx context.Context, client BackupClient, scope, recoveryPointARN string) (*types.RecoveryPointByBackupVault, error) {
+	vaults, err := listBackupVaults(ctx, client)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, vault := range vaults {
+		if vault.BackupVaultName == nil {
+			continue
+		}
+
+		recoveryPoint, err := recoveryPointGetFunc(ctx, client, scope, *vault.BackupVaultName+"/"+recoveryPointARN)
+
+		if err != nil {
+			var notFound *types.ResourceNotFoundException
+
+			if errors.As(err, &notFound) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		return recoveryPoint, nil
+	}
+
+	return nil, &sdp.QueryError{
+		ErrorType:   sdp.QueryError_NOTFOUND,
+		ErrorString: fmt.Sprintf("recovery point %v not found in any vault", recoveryPointARN),
+	}
+}
+
+// recoveryPointSearchFunc Searches for recovery points. The query can be the
+// ARN of a recovery point, the ARN of a protected resource, or the name of a
+// backup vault. Recovery point ARNs are often the ARN of a snapshot in the
+// underlying service so we can't always tell them apart from resource ARNs by
+// looking at them
+func recoveryPointSearchFunc(ctx context.Context, client BackupClient, scope, query string) ([]*types.RecoveryPointByBackupVault, error) {
+	a, err := sources.ParseARN(query)
+
+	if err != nil {
+		// Assume that this is a vault name
+		return listRecoveryPointsByVault(ctx, client, query)
+	}
+
+	if a.Service != "backup" || a.Type() != "recovery-point" {
+		recoveryPoints := make([]*types.RecoveryPointByBackupVault, 0)
+		input := backup.ListRecoveryPointsByResourceInput{
+			ResourceArn: &query,
+		}
+
+		for {
+			out, err := client.ListRecoveryPointsByResource(ctx, &input)
+
+			if err != nil {
+				return nil, err
+			}
+
+			for _, summary := range out.RecoveryPoints {
+				if summary.BackupVaultName == nil || summary.RecoveryPointArn == nil {
+					continue
+				}
+
+				recoveryPoint, err := recoveryPointGetFunc(ctx, client, scope, *summary.BackupVaultName+"/"+*summary.RecoveryPointArn)
+
+				if err != nil {
+					return nil, err
+				}
+
+				recoveryPoints = append(recoveryPoints, recoveryPoint)
+			}
+
+			if out.NextToken == nil {
+				break
+			}
+
+			input.NextToken = out.NextToken
+		}
+
+		if len(recoveryPoints) > 0 {
+			return recoveryPoints, nil
+		}
+	}
+
+	recoveryPoint, err := findRecoveryPoint(ctx, client, scope, query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []*types.RecoveryPointByBackupVault{recoveryPoint}, nil
+}
+
+func recoveryPointListTagsFunc(ctx context.Context, recoveryPoint *types.RecoveryPointByBackupVault, client BackupClient) (map[string]string, error) {
+	return tagsByResourceARN(ctx, client, recoveryPoint.RecoveryPointArn), nil
+}
+
+func recoveryPointItemMapper(scope string, awsItem *types.RecoveryPointByBackupVault) (*sdp.Item, error) {
+	attributes, err := sources.ToAttributesCase(awsItem)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if awsItem.BackupVaultName != nil && awsItem.RecoveryPointArn != nil {
+		// Recovery points can only be fetched along with their vault
+		err = attributes.Set("uniqueName", *awsItem.BackupVaultName+"/"+*awsItem.RecoveryPointArn)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	item := sdp.Item{
+		Type:            "backup-recovery-point",
+		UniqueAttribute: "uniqueName",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	switch awsItem.Status {
+	case types.RecoveryPointStatusCompleted:
+		item.Health = sdp.Health_HEALTH_OK.Enum()
+	case types.RecoveryPointStatusPartial, types.RecoveryPointStatusExpired:
+		item.Health = sdp.Health_HEALTH_WARNING.Enum()
+	case types.RecoveryPointStatusDeleting:
+		item.Health = sdp.Health_HEALTH_PENDING.Enum()
+	}
+
+	if awsItem.BackupVaultName != nil {
+		// +overmind:link backup-backup-vault
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "backup-backup-vault",
+				Method: sdp.QueryMethod_GET,
+				Query:  *awsItem.BackupVaultName,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Changing the vault will affect the recovery point
+				In: true,
+				// Recovery points don't affect the vault
+				Out: false,
+			},
+		})
+	}
+
+	if awsItem.SourceBackupVaultArn != nil {
+		if a, err := sources.ParseARN(*awsItem.SourceBackupVaultArn); err == nil {
+			// +overmind:link backup-backup-vault
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "backup-backup-vault",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *awsItem.SourceBackupVaultArn,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// This recovery point is a copy of one in the source
+					// vault, so they aren't connected once it has been copied
+					In:  false,
+					Out: false,
+				},
+			})
+		}
+	}
+
+	if awsItem.CreatedBy != nil && awsItem.CreatedBy.BackupPlanId != nil {
+		// +overmind:link backup-backup-plan
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "backup-backup-plan",
+				Method: sdp.QueryMethod_GET,
+				Query:  *awsItem.CreatedBy.BackupPlanId,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// The plan controls the lifecycle of the recovery point
+				In: true,
+				// Recovery points don't affect the plan
+				Out: false,
+			},
+		})
+	}
+
+	if awsItem.IamRoleArn != nil {
+		if a, err := sources.ParseARN(*awsItem.IamRoleArn); err == nil {
+			// +overmind:link iam-role
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "iam-role",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *awsItem.IamRoleArn,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the role could affect access to the recovery
+					// point
+					In: true,
+					// Changing the recovery point won't affect the role
+					Out: false,
+				},
+			})
+		}
+	}
+
+	if awsItem.EncryptionKeyArn != nil {
+		if a, err := sources.ParseARN(*awsItem.EncryptionKeyArn); err == nil {
+			// +overmind:link kms-key
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "kms-key",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *awsItem.EncryptionKeyArn,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the key will affect the ability to restore the
+					// recovery point
+					In: true,
+					// Changing the recovery point won't affect the key
+					Out: false,
+				},
+			})
+		}
+	}
+
+	if awsItem.ParentRecoveryPointArn != nil {
+		if a, err := sources.ParseARN(*awsItem.ParentRecoveryPointArn); err == nil {
+			// +overmind:link backup-recovery-point
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "backup-recovery-point",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *awsItem.ParentRecoveryPointArn,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Composite recovery points are tightly coupled to their
+					// parent
+					In:  true,
+					Out: true,
+				},
+			})
+		}
+	}
+
+	if awsItem.ResourceArn != nil {
+		// +overmind:link dynamodb-table
+		// +overmind:link ec2-instance
+		// +overmind:link ec2-volume
+		// +overmind:link efs-file-system
+		// +overmind:link rds-db-cluster
+		// +overmind:link rds-db-instance
+		if link := protectedResourceLink(*awsItem.ResourceArn); link != nil {
+			item.LinkedItemQueries = append(item.LinkedItemQueries, link)
+		}
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type backup-recovery-point
+// +overmind:descriptiveType Backup Recovery Point
+// +overmind:get Get a recovery point by {backupVaultName}/{recoveryPointArn}
+// +overmind:list List all recovery points in all vaults
+// +overmind:search Search for recovery points by ARN, by the ARN of the protected resource, or by backup vault name
+// +overmind:group AWS
+
+func NewRecoveryPointSource(config aws.Config, accountID string, region string) *sources.GetListSource[*types.RecoveryPointByBackupVault, BackupClient, *backup.Options] {
+	return &sources.GetListSource[*types.RecoveryPointByBackupVault, BackupClient, *backup.Options]{
+		ItemType:     "backup-recovery-point",
+		Client:       backup.NewFromConfig(config),
+		AccountID:    accountID,
+		Region:       region,
+		GetFunc:      recoveryPointGetFunc,
+		ListFunc:     recoveryPointListFunc,
+		SearchFunc:   recoveryPointSearchFunc,
+		ListTagsFunc: recoveryPointListTagsFunc,
+		ItemMapper:   recoveryPointItemMapper,
+	}
+}
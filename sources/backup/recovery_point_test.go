@@ -0,0 +1,187 @@
+package backup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/backup"
+	"github.com/aws/aws-sdk-go-v2/service/backup/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+const testRecoveryPointARN = "arn:aws:backup:eu-west-1:123456789012:recovery-point:1EB3B5E7-9EB0-435A-A80B-108B488B0D45"
+
+func (c testBackupClient) DescribeRecoveryPoint(ctx context.Context, params *backup.DescribeRecoveryPointInput, optFns ...func(*backup.Options)) (*backup.DescribeRecoveryPointOutput, error) {
+	if *params.RecoveryPointArn != testRecoveryPointARN {
+		return nil, &types.ResourceNotFoundException{
+			Message: sources.PtrString("recovery point not found"),
+		}
+	}
+
+	return &backup.DescribeRecoveryPointOutput{
+		BackupSizeInBytes: sources.PtrInt64(1024),
+		BackupVaultArn:    sources.PtrString("arn:aws:backup:eu-west-1:123456789012:backup-vault:" + *params.BackupVaultName),
+		BackupVaultName:   params.BackupVaultName, // link
+		CreatedBy: &types.RecoveryPointCreator{
+			BackupPlanArn: sources.PtrString("arn:aws:backup:eu-west-1:123456789012:backup-plan:plan-1"),
+			BackupPlanId:  sources.PtrString("plan-1"), // link
+			BackupRuleId:  sources.PtrString("rule-1"),
+		},
+		CreationDate:     sources.PtrTime(time.Now()),
+		EncryptionKeyArn: sources.PtrString("arn:aws:kms:eu-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"), // link
+		IamRoleArn:       sources.PtrString("arn:aws:iam::123456789012:role/service-role/AWSBackupDefaultServiceRole"),     // link
+		IsEncrypted:      true,
+		RecoveryPointArn: params.RecoveryPointArn,
+		ResourceArn:      sources.PtrString("arn:aws:dynamodb:eu-west-1:123456789012:table/sessions"), // link
+		ResourceType:     sources.PtrString("DynamoDB"),
+		Status:           types.RecoveryPointStatusCompleted, // health
+	}, nil
+}
+
+func (c testBackupClient) ListRecoveryPointsByBackupVault(ctx context.Context, params *backup.ListRecoveryPointsByBackupVaultInput, optFns ...func(*backup.Options)) (*backup.ListRecoveryPointsByBackupVaultOutput, error) {
+	return &backup.ListRecoveryPointsByBackupVaultOutput{
+		RecoveryPoints: []types.RecoveryPointByBackupVault{
+			{
+				BackupVaultName:  params.BackupVaultName,
+				RecoveryPointArn: sources.PtrString(testRecoveryPointARN),
+				ResourceArn:      sources.PtrString("arn:aws:dynamodb:eu-west-1:123456789012:table/sessions"),
+				Status:           types.RecoveryPointStatusCompleted,
+			},
+		},
+	}, nil
+}
+
+func (c testBackupClient) ListRecoveryPointsByResource(ctx context.Context, params *backup.ListRecoveryPointsByResourceInput, optFns ...func(*backup.Options)) (*backup.ListRecoveryPointsByResourceOutput, error) {
+	if *params.ResourceArn != "arn:aws:dynamodb:eu-west-1:123456789012:table/sessions" {
+		return &backup.ListRecoveryPointsByResourceOutput{}, nil
+	}
+
+	return &backup.ListRecoveryPointsByResourceOutput{
+		RecoveryPoints: []types.RecoveryPointByResource{
+			{
+				BackupVaultName:  sources.PtrString("Default"),
+				RecoveryPointArn: sources.PtrString(testRecoveryPointARN),
+				Status:           types.RecoveryPointStatusCompleted,
+			},
+		},
+	}, nil
+}
+
+func TestRecoveryPointGetFunc(t *testing.T) {
+	recoveryPoint, err := recoveryPointGetFunc(context.Background(), testBackupClient{}, "123456789012.eu-west-1", "Default/"+testRecoveryPointARN)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := recoveryPointItemMapper("123456789012.eu-west-1", recoveryPoint)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := item.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if item.GetHealth() != sdp.Health_HEALTH_OK {
+		t.Errorf("expected health OK, got %v", item.GetHealth())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "backup-backup-vault",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "Default",
+			ExpectedScope:  "123456789012.eu-west-1",
+		},
+		{
+			ExpectedType:   "backup-backup-plan",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "plan-1",
+			ExpectedScope:  "123456789012.eu-west-1",
+		},
+		{
+			ExpectedType:   "iam-role",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:iam::123456789012:role/service-role/AWSBackupDefaultServiceRole",
+			ExpectedScope:  "123456789012",
+		},
+		{
+			ExpectedType:   "kms-key",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:kms:eu-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+			ExpectedScope:  "123456789012.eu-west-1",
+		},
+		{
+			ExpectedType:   "dynamodb-table",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:dynamodb:eu-west-1:123456789012:table/sessions",
+			ExpectedScope:  "123456789012.eu-west-1",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestRecoveryPointSearchFunc(t *testing.T) {
+	queries := []string{
+		// Recovery point ARN, which requires checking each vault
+		testRecoveryPointARN,
+		// Protected resource ARN
+		"arn:aws:dynamodb:eu-west-1:123456789012:table/sessions",
+		// Vault name
+		"Default",
+	}
+
+	for _, query := range queries {
+		t.Run(query, func(t *testing.T) {
+			recoveryPoints, err := recoveryPointSearchFunc(context.Background(), testBackupClient{}, "123456789012.eu-west-1", query)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(recoveryPoints) != 1 {
+				t.Fatalf("expected 1 recovery point, got %v", len(recoveryPoints))
+			}
+
+			if *recoveryPoints[0].RecoveryPointArn != testRecoveryPointARN {
+				t.Errorf("expected recovery point %v, got %v", testRecoveryPointARN, *recoveryPoints[0].RecoveryPointArn)
+			}
+		})
+	}
+
+	_, err := recoveryPointSearchFunc(context.Background(), testBackupClient{}, "123456789012.eu-west-1", "arn:aws:backup:eu-west-1:123456789012:recovery-point:missing")
+
+	if err == nil {
+		t.Error("expected error for missing recovery point")
+	}
+}
+
+func TestRecoveryPointListFunc(t *testing.T) {
+	recoveryPoints, err := recoveryPointListFunc(context.Background(), testBackupClient{}, "123456789012.eu-west-1")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(recoveryPoints) != 1 {
+		t.Errorf("expected 1 recovery point, got %v", len(recoveryPoints))
+	}
+}
+
+func TestNewRecoveryPointSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewRecoveryPointSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -0,0 +1,135 @@
+package backup
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/backup"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+// BackupClient Represents the client we need to talk to AWS Backup, usually
+// this is *backup.Client
+type BackupClient interface {
+	DescribeBackupVault(ctx context.Context, params *backup.DescribeBackupVaultInput, optFns ...func(*backup.Options)) (*backup.DescribeBackupVaultOutput, error)
+	DescribeRecoveryPoint(ctx context.Context, params *backup.DescribeRecoveryPointInput, optFns ...func(*backup.Options)) (*backup.DescribeRecoveryPointOutput, error)
+	GetBackupPlan(ctx context.Context, params *backup.GetBackupPlanInput, optFns ...func(*backup.Options)) (*backup.GetBackupPlanOutput, error)
+	GetBackupSelection(ctx context.Context, params *backup.GetBackupSelectionInput, optFns ...func(*backup.Options)) (*backup.GetBackupSelectionOutput, error)
+	ListBackupPlans(ctx context.Context, params *backup.ListBackupPlansInput, optFns ...func(*backup.Options)) (*backup.ListBackupPlansOutput, error)
+	ListBackupSelections(ctx context.Context, params *backup.ListBackupSelectionsInput, optFns ...func(*backup.Options)) (*backup.ListBackupSelectionsOutput, error)
+	ListBackupVaults(ctx context.Context, params *backup.ListBackupVaultsInput, optFns ...func(*backup.Options)) (*backup.ListBackupVaultsOutput, error)
+	ListRecoveryPointsByBackupVault(ctx context.Context, params *backup.ListRecoveryPointsByBackupVaultInput, optFns ...func(*backup.Options)) (*backup.ListRecoveryPointsByBackupVaultOutput, error)
+	ListRecoveryPointsByResource(ctx context.Context, params *backup.ListRecoveryPointsByResourceInput, optFns ...func(*backup.Options)) (*backup.ListRecoveryPointsByResourceOutput, error)
+	ListTags(ctx context.Context, params *backup.ListTagsInput, optFns ...func(*backup.Options)) (*backup.ListTagsOutput, error)
+}
+
+// TaggingClient The parts of the Resource Groups Tagging API that we need in
+// order to work out which resources are matched by a tag-based backup
+// selection, usually this is *resourcegroupstaggingapi.Client
+type TaggingClient interface {
+	GetResources(ctx context.Context, params *resourcegroupstaggingapi.GetResourcesInput, optFns ...func(*resourcegroupstaggingapi.Options)) (*resourcegroupstaggingapi.GetResourcesOutput, error)
+}
+
+func tagsByResourceARN(ctx context.Context, client BackupClient, resourceARN *string) map[string]string {
+	if resourceARN == nil {
+		return nil
+	}
+
+	tags := make(map[string]string)
+	input := backup.ListTagsInput{
+		ResourceArn: resourceARN,
+	}
+
+	for {
+		out, err := client.ListTags(ctx, &input)
+
+		if err != nil {
+			return sources.HandleTagsError(ctx, err)
+		}
+
+		for k, v := range out.Tags {
+			tags[k] = v
+		}
+
+		if out.NextToken == nil {
+			break
+		}
+
+		input.NextToken = out.NextToken
+	}
+
+	return tags
+}
+
+// wildcardMatch Checks whether a value matches a pattern that uses the `*` and
+// `?` wildcards, as used in selection resource ARNs and StringLike conditions
+func wildcardMatch(pattern string, value string) bool {
+	expression := regexp.QuoteMeta(pattern)
+	expression = strings.ReplaceAll(expression, `\*`, ".*")
+	expression = strings.ReplaceAll(expression, `\?`, ".")
+
+	matched, err := regexp.MatchString("^"+expression+"$", value)
+
+	return err == nil && matched
+}
+
+// protectedResourceLink Returns a link to a resource that is protected by
+// AWS Backup, based on its ARN. Returns nil if the resource is of a type that
+// we don't support
+func protectedResourceLink(resourceARN string) *sdp.LinkedItemQuery {
+	a, err := sources.ParseARN(resourceARN)
+
+	if err != nil {
+		return nil
+	}
+
+	var queryType string
+
+	switch a.Service {
+	case "ec2":
+		switch a.Type() {
+		case "volume":
+			queryType = "ec2-volume"
+		case "instance":
+			queryType = "ec2-instance"
+		}
+	case "rds":
+		switch a.Type() {
+		case "db":
+			queryType = "rds-db-instance"
+		case "cluster":
+			queryType = "rds-db-cluster"
+		}
+	case "elasticfilesystem":
+		if a.Type() == "file-system" {
+			queryType = "efs-file-system"
+		}
+	case "dynamodb":
+		if a.Type() == "table" {
+			queryType = "dynamodb-table"
+		}
+	}
+
+	if queryType == "" {
+		return nil
+	}
+
+	return &sdp.LinkedItemQuery{
+		Query: &sdp.Query{
+			Type:   queryType,
+			Method: sdp.QueryMethod_SEARCH,
+			Query:  resourceARN,
+			Scope:  sources.FormatScope(a.AccountID, a.Region),
+		},
+		BlastPropagation: &sdp.BlastPropagation{
+			// Changing the resource won't affect the backup configuration
+			In: false,
+			// Changing the backup configuration will affect whether the
+			// resource can be restored
+			Out: true,
+		},
+	}
+}
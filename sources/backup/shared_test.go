@@ -0,0 +1,95 @@
+package backup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/backup"
+)
+
+type testBackupClient struct{}
+
+func (c testBackupClient) ListTags(ctx context.Context, params *backup.ListTagsInput, optFns ...func(*backup.Options)) (*backup.ListTagsOutput, error) {
+	return &backup.ListTagsOutput{
+		Tags: map[string]string{
+			"foo": "bar",
+		},
+	}, nil
+}
+
+func TestWildcardMatch(t *testing.T) {
+	tests := []struct {
+		Pattern string
+		Value   string
+		Match   bool
+	}{
+		{
+			Pattern: "*",
+			Value:   "arn:aws:ec2:eu-west-1:123456789012:volume/vol-0123456789abcdef0",
+			Match:   true,
+		},
+		{
+			Pattern: "arn:aws:ec2:*:*:volume/*",
+			Value:   "arn:aws:ec2:eu-west-1:123456789012:volume/vol-0123456789abcdef0",
+			Match:   true,
+		},
+		{
+			Pattern: "arn:aws:ec2:*:*:volume/*",
+			Value:   "arn:aws:rds:eu-west-1:123456789012:db:orders",
+			Match:   false,
+		},
+		{
+			Pattern: "prod-?",
+			Value:   "prod-1",
+			Match:   true,
+		},
+		{
+			Pattern: "prod.1",
+			Value:   "prodx1",
+			Match:   false,
+		},
+	}
+
+	for _, test := range tests {
+		if match := wildcardMatch(test.Pattern, test.Value); match != test.Match {
+			t.Errorf("expected %v matching %v to be %v, got %v", test.Pattern, test.Value, test.Match, match)
+		}
+	}
+}
+
+func TestProtectedResourceLink(t *testing.T) {
+	tests := map[string]string{
+		"arn:aws:ec2:eu-west-1:123456789012:volume/vol-0123456789abcdef0":           "ec2-volume",
+		"arn:aws:ec2:eu-west-1:123456789012:instance/i-0123456789abcdef0":           "ec2-instance",
+		"arn:aws:rds:eu-west-1:123456789012:db:orders":                              "rds-db-instance",
+		"arn:aws:rds:eu-west-1:123456789012:cluster:orders":                         "rds-db-cluster",
+		"arn:aws:elasticfilesystem:eu-west-1:123456789012:file-system/fs-01234567":  "efs-file-system",
+		"arn:aws:dynamodb:eu-west-1:123456789012:table/orders":                      "dynamodb-table",
+		"arn:aws:storagegateway:eu-west-1:123456789012:gateway/sgw-12A3456B/volume": "",
+	}
+
+	for arn, expectedType := range tests {
+		link := protectedResourceLink(arn)
+
+		if expectedType == "" {
+			if link != nil {
+				t.Errorf("expected no link for %v, got %v", arn, link.GetQuery().GetType())
+			}
+
+			continue
+		}
+
+		if link == nil {
+			t.Errorf("expected %v link for %v, got nil", expectedType, arn)
+			continue
+		}
+
+		if link.GetQuery().GetType() != expectedType {
+			t.Errorf("expected %v link for %v, got %v", expectedType, arn, link.GetQuery().GetType())
+		}
+
+		if link.GetQuery().GetScope() != "123456789012.eu-west-1" {
+			t.Errorf("expected scope 123456789012.eu-west-1, got %v", link.GetQuery().GetScope())
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package cloudformation
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+var ErrNoQuery = errors.New("no query found")
+
+// SuggestedResourceQuery Suggests a query for the item that a stack resource
+// manages based on its CloudFormation resource type and physical ID. The
+// physical ID is whatever CloudFormation returns from `Ref`, which is usually
+// a name or ID but is sometimes an ARN or URL, so this is handled per type
+//
+// The full list of resource types can be found here:
+// https://docs.aws.amazon.com/AWSCloudFormation/latest/UserGuide/aws-template-resource-type-ref.html
+//
+// The below list is not exhaustive and improvements are welcome
+func SuggestedResourceQuery(resourceType string, physicalID string, scope string) (*sdp.Query, error) {
+	if physicalID == "" {
+		return nil, ErrNoQuery
+	}
+
+	accountID, _, err := sources.ParseScope(scope)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Types where the physical ID can be used directly as a GET query in the
+	// same scope as the stack
+	getTypes := map[string]string{
+		"AWS::AutoScaling::AutoScalingGroup":         "autoscaling-auto-scaling-group",
+		"AWS::AutoScaling::LaunchConfiguration":      "autoscaling-launch-configuration",
+		"AWS::CloudWatch::Alarm":                     "cloudwatch-alarm",
+		"AWS::DynamoDB::Table":                       "dynamodb-table",
+		"AWS::EC2::CapacityReservation":              "ec2-capacity-reservation",
+		"AWS::EC2::CustomerGateway":                  "ec2-customer-gateway",
+		"AWS::EC2::EIP":                              "ec2-address",
+		"AWS::EC2::EgressOnlyInternetGateway":        "ec2-egress-only-internet-gateway",
+		"AWS::EC2::Host":                             "ec2-host",
+		"AWS::EC2::Instance":                         "ec2-instance",
+		"AWS::EC2::InternetGateway":                  "ec2-internet-gateway",
+		"AWS::EC2::KeyPair":                          "ec2-key-pair",
+		"AWS::EC2::LaunchTemplate":                   "ec2-launch-template",
+		"AWS::EC2::NatGateway":                       "ec2-nat-gateway",
+		"AWS::EC2::NetworkAcl":                       "ec2-network-acl",
+		"AWS::EC2::NetworkInterface":                 "ec2-network-interface",
+		"AWS::EC2::PrefixList":                       "ec2-managed-prefix-list",
+		"AWS::EC2::RouteTable":                       "ec2-route-table",
+		"AWS::EC2::SecurityGroup":                    "ec2-security-group",
+		"AWS::EC2::Subnet":                           "ec2-subnet",
+		"AWS::EC2::VPC":                              "ec2-vpc",
+		"AWS::EC2::VPCEndpoint":                      "ec2-vpc-endpoint",
+		"AWS::EC2::VPCPeeringConnection":             "ec2-vpc-peering-connection",
+		"AWS::EC2::VPNConnection":                    "ec2-vpn-connection",
+		"AWS::EC2::VPNGateway":                       "ec2-vpn-gateway",
+		"AWS::EC2::Volume":                           "ec2-volume",
+		"AWS::ECR::Repository":                       "ecr-repository",
+		"AWS::ECS::CapacityProvider":                 "ecs-capacity-provider",
+		"AWS::ECS::Cluster":                          "ecs-cluster",
+		"AWS::EFS::AccessPoint":                      "efs-access-point",
+		"AWS::EFS::FileSystem":                       "efs-file-system",
+		"AWS::EFS::MountTarget":                      "efs-mount-target",
+		"AWS::EKS::Cluster":                          "eks-cluster",
+		"AWS::ElastiCache::CacheCluster":             "elasticache-cache-cluster",
+		"AWS::ElastiCache::ParameterGroup":           "elasticache-parameter-group",
+		"AWS::ElastiCache::ReplicationGroup":         "elasticache-replication-group",
+		"AWS::ElastiCache::SubnetGroup":              "elasticache-subnet-group",
+		"AWS::ElasticLoadBalancing::LoadBalancer":    "elb-load-balancer",
+		"AWS::ElasticLoadBalancingV2::Listener":      "elbv2-listener",
+		"AWS::ElasticLoadBalancingV2::ListenerRule":  "elbv2-rule",
+		"AWS::Events::EventBus":                      "events-event-bus",
+		"AWS::KinesisFirehose::DeliveryStream":       "firehose-delivery-stream",
+		"AWS::Kinesis::Stream":                       "kinesis-stream",
+		"AWS::Lambda::EventSourceMapping":            "lambda-event-source-mapping",
+		"AWS::Lambda::Function":                      "lambda-function",
+		"AWS::MemoryDB::Cluster":                     "memorydb-cluster",
+		"AWS::RDS::DBCluster":                        "rds-db-cluster",
+		"AWS::RDS::DBClusterParameterGroup":          "rds-db-cluster-parameter-group",
+		"AWS::RDS::DBInstance":                       "rds-db-instance",
+		"AWS::RDS::DBParameterGroup":                 "rds-db-parameter-group",
+		"AWS::RDS::DBSubnetGroup":                    "rds-db-subnet-group",
+		"AWS::RDS::OptionGroup":                      "rds-option-group",
+		"AWS::Route53::HealthCheck":                  "route53-health-check",
+		"AWS::Route53::HostedZone":                   "route53-hosted-zone",
+		"AWS::SQS::Queue":                            "sqs-queue",
+		"AWS::ServiceDiscovery::HttpNamespace":       "servicediscovery-namespace",
+		"AWS::ServiceDiscovery::PrivateDnsNamespace": "servicediscovery-namespace",
+		"AWS::ServiceDiscovery::PublicDnsNamespace":  "servicediscovery-namespace",
+		"AWS::ServiceDiscovery::Service":             "servicediscovery-service",
+		"AWS::StepFunctions::Activity":               "sfn-activity",
+		"AWS::StepFunctions::StateMachine":           "sfn-state-machine",
+	}
+
+	// Types where the physical ID is an ARN that the target source supports
+	// searching for
+	arnTypes := map[string]string{
+		"AWS::CloudFormation::Stack":                "cloudformation-stack",
+		"AWS::ECS::TaskDefinition":                  "ecs-task-definition",
+		"AWS::ElasticLoadBalancingV2::LoadBalancer": "elbv2-load-balancer",
+		"AWS::ElasticLoadBalancingV2::TargetGroup":  "elbv2-target-group",
+		"AWS::IAM::ManagedPolicy":                   "iam-policy",
+		"AWS::Lambda::LayerVersion":                 "lambda-layer-version",
+		"AWS::SNS::Subscription":                    "sns-subscription",
+		"AWS::SNS::Topic":                           "sns-topic",
+	}
+
+	// IAM resources are global, so their scope is just the account
+	iamTypes := map[string]string{
+		"AWS::IAM::Group":           "iam-group",
+		"AWS::IAM::InstanceProfile": "iam-instance-profile",
+		"AWS::IAM::Role":            "iam-role",
+		"AWS::IAM::User":            "iam-user",
+	}
+
+	if itemType, ok := getTypes[resourceType]; ok {
+		return &sdp.Query{
+			Type:   itemType,
+			Method: sdp.QueryMethod_GET,
+			Query:  physicalID,
+			Scope:  scope,
+		}, nil
+	}
+
+	if itemType, ok := arnTypes[resourceType]; ok {
+		if a, err := sources.ParseARN(physicalID); err == nil {
+			return &sdp.Query{
+				Type:   itemType,
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  physicalID,
+				Scope:  sources.FormatScope(a.AccountID, a.Region),
+			}, nil
+		}
+
+		return nil, ErrNoQuery
+	}
+
+	if itemType, ok := iamTypes[resourceType]; ok {
+		return &sdp.Query{
+			Type:   itemType,
+			Method: sdp.QueryMethod_GET,
+			Query:  physicalID,
+			Scope:  sources.FormatScope(accountID, ""),
+		}, nil
+	}
+
+	switch resourceType {
+	case "AWS::S3::Bucket":
+		// S3 buckets are global
+		return &sdp.Query{
+			Type:   "s3-bucket",
+			Method: sdp.QueryMethod_GET,
+			Query:  physicalID,
+			Scope:  sources.FormatScope(accountID, ""),
+		}, nil
+	case "AWS::ECS::Service":
+		// The physical ID is the service ARN, which in the current format
+		// ends in {clusterName}/{serviceName}, which is what the ECS service
+		// source uses as its ID
+		if a, err := sources.ParseARN(physicalID); err == nil {
+			if clusterService := a.ResourceID(); strings.Count(clusterService, "/") == 1 {
+				return &sdp.Query{
+					Type:   "ecs-service",
+					Method: sdp.QueryMethod_GET,
+					Query:  clusterService,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				}, nil
+			}
+		}
+	}
+
+	return nil, ErrNoQuery
+}
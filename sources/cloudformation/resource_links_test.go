@@ -0,0 +1,135 @@
+package cloudformation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/overmindtech/sdp-go"
+)
+
+func TestSuggestedResourceQuery(t *testing.T) {
+	t.Parallel()
+
+	scope := "052392120703.eu-west-2"
+
+	cases := []struct {
+		Name           string
+		ResourceType   string
+		PhysicalID     string
+		ExpectedType   string
+		ExpectedMethod sdp.QueryMethod
+		ExpectedQuery  string
+		ExpectedScope  string
+	}{
+		{
+			Name:           "EC2 instance",
+			ResourceType:   "AWS::EC2::Instance",
+			PhysicalID:     "i-0e1f2a3b4c5d6e7f8",
+			ExpectedType:   "ec2-instance",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "i-0e1f2a3b4c5d6e7f8",
+			ExpectedScope:  scope,
+		},
+		{
+			Name:           "Lambda function",
+			ResourceType:   "AWS::Lambda::Function",
+			PhysicalID:     "app-handler",
+			ExpectedType:   "lambda-function",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "app-handler",
+			ExpectedScope:  scope,
+		},
+		{
+			Name:           "SQS queue",
+			ResourceType:   "AWS::SQS::Queue",
+			PhysicalID:     "https://sqs.eu-west-2.amazonaws.com/052392120703/app-jobs",
+			ExpectedType:   "sqs-queue",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "https://sqs.eu-west-2.amazonaws.com/052392120703/app-jobs",
+			ExpectedScope:  scope,
+		},
+		{
+			Name:           "SNS topic",
+			ResourceType:   "AWS::SNS::Topic",
+			PhysicalID:     "arn:aws:sns:eu-west-2:052392120703:app-events",
+			ExpectedType:   "sns-topic",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:sns:eu-west-2:052392120703:app-events",
+			ExpectedScope:  scope,
+		},
+		{
+			Name:           "Nested stack",
+			ResourceType:   "AWS::CloudFormation::Stack",
+			PhysicalID:     "arn:aws:cloudformation:eu-west-2:052392120703:stack/app-Nested-1ABC/4e9f1a60-e0f2-11ee-8a5b-0a1b2c3d4e5f",
+			ExpectedType:   "cloudformation-stack",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:cloudformation:eu-west-2:052392120703:stack/app-Nested-1ABC/4e9f1a60-e0f2-11ee-8a5b-0a1b2c3d4e5f",
+			ExpectedScope:  scope,
+		},
+		{
+			Name:           "IAM role",
+			ResourceType:   "AWS::IAM::Role",
+			PhysicalID:     "app-HandlerRole-1ABC",
+			ExpectedType:   "iam-role",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "app-HandlerRole-1ABC",
+			ExpectedScope:  "052392120703",
+		},
+		{
+			Name:           "S3 bucket",
+			ResourceType:   "AWS::S3::Bucket",
+			PhysicalID:     "app-assets",
+			ExpectedType:   "s3-bucket",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "app-assets",
+			ExpectedScope:  "052392120703",
+		},
+		{
+			Name:           "ECS service",
+			ResourceType:   "AWS::ECS::Service",
+			PhysicalID:     "arn:aws:ecs:eu-west-2:052392120703:service/app-cluster/app-web",
+			ExpectedType:   "ecs-service",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "app-cluster/app-web",
+			ExpectedScope:  scope,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+
+			query, err := SuggestedResourceQuery(c.ResourceType, c.PhysicalID, scope)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if query.Type != c.ExpectedType {
+				t.Errorf("expected type %q, got %q", c.ExpectedType, query.Type)
+			}
+
+			if query.Method != c.ExpectedMethod {
+				t.Errorf("expected method %v, got %v", c.ExpectedMethod, query.Method)
+			}
+
+			if query.Query != c.ExpectedQuery {
+				t.Errorf("expected query %q, got %q", c.ExpectedQuery, query.Query)
+			}
+
+			if query.Scope != c.ExpectedScope {
+				t.Errorf("expected scope %q, got %q", c.ExpectedScope, query.Scope)
+			}
+		})
+	}
+}
+
+func TestSuggestedResourceQueryUnknownType(t *testing.T) {
+	_, err := SuggestedResourceQuery("Custom::Thing", "foo", "052392120703.eu-west-2")
+
+	if !errors.Is(err, ErrNoQuery) {
+		t.Errorf("expected ErrNoQuery, got %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package cloudformation
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/smithy-go"
+)
+
+// CloudFormationClient Represents the client we need to talk to
+// CloudFormation, usually this is *cloudformation.Client
+type CloudFormationClient interface {
+	DescribeStackResource(ctx context.Context, params *cloudformation.DescribeStackResourceInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeStackResourceOutput, error)
+	DescribeStackSet(ctx context.Context, params *cloudformation.DescribeStackSetInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeStackSetOutput, error)
+	DescribeStacks(ctx context.Context, params *cloudformation.DescribeStacksInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeStacksOutput, error)
+	ListExports(ctx context.Context, params *cloudformation.ListExportsInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListExportsOutput, error)
+	ListImports(ctx context.Context, params *cloudformation.ListImportsInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListImportsOutput, error)
+	ListStackInstances(ctx context.Context, params *cloudformation.ListStackInstancesInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListStackInstancesOutput, error)
+	ListStackResources(ctx context.Context, params *cloudformation.ListStackResourcesInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListStackResourcesOutput, error)
+	ListStackSets(ctx context.Context, params *cloudformation.ListStackSetsInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListStackSetsOutput, error)
+}
+
+// Converts a slice of tags to a map
+func tagsToMap(tags []types.Tag) map[string]string {
+	tagsMap := make(map[string]string)
+
+	for _, tag := range tags {
+		if tag.Key != nil && tag.Value != nil {
+			tagsMap[*tag.Key] = *tag.Value
+		}
+	}
+
+	return tagsMap
+}
+
+// isNotImportedError Returns whether an error from ListImports means that
+// nothing imports the export. CloudFormation returns a validation error rather
+// than an empty list in this case. Other errors, including throttling, have the
+// same status code so the code and message need to be checked
+func isNotImportedError(err error) bool {
+	var apiErr smithy.APIError
+
+	if errors.As(err, &apiErr) {
+		return apiErr.ErrorCode() == "ValidationError" && strings.Contains(apiErr.ErrorMessage(), "is not imported by any stack")
+	}
+
+	return false
+}
+
+// listImportingStacks Returns the names of the stacks that import a given
+// export, or none if nothing imports it
+func listImportingStacks(ctx context.Context, client CloudFormationClient, exportName string) ([]string, error) {
+	stackNames := make([]string, 0)
+
+	paginator := cloudformation.NewListImportsPaginator(client, &cloudformation.ListImportsInput{
+		ExportName: &exportName,
+	})
+
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			if isNotImportedError(err) {
+				return stackNames, nil
+			}
+
+			return nil, err
+		}
+
+		stackNames = append(stackNames, out.Imports...)
+	}
+
+	return stackNames, nil
+}
+
+// importsByStack Returns the exports in the region keyed by the names of the
+// stacks that import them. There is no API that returns the imports of a
+// single stack so this requires checking the importers of every export
+func importsByStack(ctx context.Context, client CloudFormationClient) (map[string][]types.Export, error) {
+	imports := make(map[string][]types.Export)
+
+	paginator := cloudformation.NewListExportsPaginator(client, &cloudformation.ListExportsInput{})
+
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, export := range out.Exports {
+			if export.Name == nil {
+				continue
+			}
+
+			stackNames, err := listImportingStacks(ctx, client, *export.Name)
+
+			if err != nil {
+				return nil, err
+			}
+
+			for _, stackName := range stackNames {
+				imports[stackName] = append(imports[stackName], export)
+			}
+		}
+	}
+
+	return imports, nil
+}
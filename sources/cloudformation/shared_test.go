@@ -0,0 +1,97 @@
+package cloudformation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/smithy-go"
+	"github.com/overmindtech/aws-source/sources"
+)
+
+type testCloudFormationClient struct{}
+
+func (c testCloudFormationClient) ListExports(ctx context.Context, params *cloudformation.ListExportsInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListExportsOutput, error) {
+	return &cloudformation.ListExportsOutput{
+		Exports: []types.Export{
+			{
+				ExportingStackId: sources.PtrString("arn:aws:cloudformation:eu-west-2:052392120703:stack/network/4e9f1a60-e0f2-11ee-8a5b-0a1b2c3d4e5f"),
+				Name:             sources.PtrString("network-VpcId"),
+				Value:            sources.PtrString("vpc-0d7892e00e573e701"),
+			},
+			{
+				ExportingStackId: sources.PtrString("arn:aws:cloudformation:eu-west-2:052392120703:stack/network/4e9f1a60-e0f2-11ee-8a5b-0a1b2c3d4e5f"),
+				Name:             sources.PtrString("network-Unused"),
+				Value:            sources.PtrString("foo"),
+			},
+		},
+	}, nil
+}
+
+func (c testCloudFormationClient) ListImports(ctx context.Context, params *cloudformation.ListImportsInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListImportsOutput, error) {
+	if *params.ExportName != "network-VpcId" {
+		// This is what CloudFormation returns when nothing imports the
+		// export
+		return nil, &smithy.GenericAPIError{
+			Code:    "ValidationError",
+			Message: "Export '" + *params.ExportName + "' is not imported by any stack.",
+		}
+	}
+
+	return &cloudformation.ListImportsOutput{
+		Imports: []string{
+			"app",
+		},
+	}, nil
+}
+
+// throttledCloudFormationClient Returns a throttling error from ListImports,
+// which uses the same status code as the error for unimported exports
+type throttledCloudFormationClient struct {
+	testCloudFormationClient
+}
+
+func (c throttledCloudFormationClient) ListImports(ctx context.Context, params *cloudformation.ListImportsInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListImportsOutput, error) {
+	return nil, &smithy.GenericAPIError{
+		Code:    "Throttling",
+		Message: "Rate exceeded",
+	}
+}
+
+func TestListImportingStacks(t *testing.T) {
+	t.Run("imported export", func(t *testing.T) {
+		stackNames, err := listImportingStacks(context.Background(), testCloudFormationClient{}, "network-VpcId")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(stackNames) != 1 || stackNames[0] != "app" {
+			t.Errorf("expected [app], got %v", stackNames)
+		}
+	})
+
+	t.Run("unimported export", func(t *testing.T) {
+		stackNames, err := listImportingStacks(context.Background(), testCloudFormationClient{}, "network-Unused")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(stackNames) != 0 {
+			t.Errorf("expected no stacks, got %v", stackNames)
+		}
+	})
+
+	t.Run("throttled", func(t *testing.T) {
+		_, err := listImportingStacks(context.Background(), throttledCloudFormationClient{}, "network-VpcId")
+
+		var apiErr smithy.APIError
+
+		if !errors.As(err, &apiErr) || apiErr.ErrorCode() != "Throttling" {
+			t.Errorf("expected throttling error, got %v", err)
+		}
+	})
+}
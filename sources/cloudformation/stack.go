@@ -0,0 +1,421 @@
+package cloudformation
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+// StackDetails A stack along with the exports from other stacks that it
+// imports, since these aren't returned by DescribeStacks
+type StackDetails struct {
+	Stack   *types.Stack
+	Imports []types.Export
+}
+
+// describeStacks Describes stacks, without their imports
+func describeStacks(ctx context.Context, client CloudFormationClient, input *cloudformation.DescribeStacksInput) ([]*StackDetails, error) {
+	stacks := make([]*StackDetails, 0)
+
+	paginator := cloudformation.NewDescribeStacksPaginator(client, input)
+
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range out.Stacks {
+			stacks = append(stacks, &StackDetails{
+				Stack: &out.Stacks[i],
+			})
+		}
+	}
+
+	return stacks, nil
+}
+
+// stackImports Caches the exports that each stack in a scope imports. Working
+// these out needs a ListImports call for every export in the region, which is
+// too expensive to repeat for every Get or Search, so List refreshes the cache
+// and Get and Search reuse it until it expires
+type stackImports struct {
+	mu      sync.Mutex
+	imports map[string][]types.Export
+	expiry  time.Time
+}
+
+// get Returns the imports keyed by stack name, only looking them up if the
+// cache has expired or refresh is set
+func (c *stackImports) get(ctx context.Context, client CloudFormationClient, refresh bool) (map[string][]types.Export, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !refresh && c.imports != nil && time.Now().Before(c.expiry) {
+		return c.imports, nil
+	}
+
+	imports, err := importsByStack(ctx, client)
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.imports = imports
+	c.expiry = time.Now().Add(sources.DefaultCacheDuration)
+
+	return imports, nil
+}
+
+// addImports Adds the exports that each stack imports, refreshing the cached
+// imports if requested
+func addImports(ctx context.Context, client CloudFormationClient, cache *stackImports, refresh bool, stacks []*StackDetails) ([]*StackDetails, error) {
+	if len(stacks) == 0 {
+		return stacks, nil
+	}
+
+	imports, err := cache.get(ctx, client, refresh)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, stack := range stacks {
+		if stack.Stack.StackName != nil {
+			stack.Imports = imports[*stack.Stack.StackName]
+		}
+	}
+
+	return stacks, nil
+}
+
+func stackGetFunc(ctx context.Context, client CloudFormationClient, cache *stackImports, scope, query string) (*StackDetails, error) {
+	stacks, err := describeStacks(ctx, client, &cloudformation.DescribeStacksInput{
+		StackName: &query,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(stacks) != 1 {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: "stack not found",
+		}
+	}
+
+	stacks, err = addImports(ctx, client, cache, false, stacks)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return stacks[0], nil
+}
+
+func stackListFunc(ctx context.Context, client CloudFormationClient, cache *stackImports, scope string) ([]*StackDetails, error) {
+	stacks, err := describeStacks(ctx, client, &cloudformation.DescribeStacksInput{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return addImports(ctx, client, cache, true, stacks)
+}
+
+// stackSearchFunc Searches for stacks by ARN, or by the name of an export, in
+// which case the stacks that import the export are returned
+func stackSearchFunc(ctx context.Context, client CloudFormationClient, cache *stackImports, scope, query string) ([]*StackDetails, error) {
+	if _, err := sources.ParseARN(query); err == nil {
+		// DescribeStacks accepts the stack ID, which is the ARN
+		stack, err := stackGetFunc(ctx, client, cache, scope, query)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return []*StackDetails{stack}, nil
+	}
+
+	stackNames, err := listImportingStacks(ctx, client, query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stacks := make([]*StackDetails, 0)
+
+	for _, stackName := range stackNames {
+		importers, err := describeStacks(ctx, client, &cloudformation.DescribeStacksInput{
+			StackName: &stackName,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		stacks = append(stacks, importers...)
+	}
+
+	return addImports(ctx, client, cache, false, stacks)
+}
+
+func stackItemMapper(scope string, awsItem *StackDetails) (*sdp.Item, error) {
+	enrichedStack := struct {
+		*types.Stack
+		Imports []types.Export
+	}{
+		Stack:   awsItem.Stack,
+		Imports: awsItem.Imports,
+	}
+
+	attributes, err := sources.ToAttributesCase(enrichedStack, "tags")
+
+	if err != nil {
+		return nil, err
+	}
+
+	stack := awsItem.Stack
+
+	item := sdp.Item{
+		Type:            "cloudformation-stack",
+		UniqueAttribute: "stackName",
+		Attributes:      attributes,
+		Scope:           scope,
+		Tags:            tagsToMap(stack.Tags),
+	}
+
+	switch stack.StackStatus {
+	case types.StackStatusCreateComplete,
+		types.StackStatusUpdateComplete,
+		types.StackStatusImportComplete:
+		item.Health = sdp.Health_HEALTH_OK.Enum()
+	case types.StackStatusCreateInProgress,
+		types.StackStatusDeleteInProgress,
+		types.StackStatusReviewInProgress,
+		types.StackStatusUpdateInProgress,
+		types.StackStatusUpdateCompleteCleanupInProgress,
+		types.StackStatusImportInProgress:
+		item.Health = sdp.Health_HEALTH_PENDING.Enum()
+	case types.StackStatusRollbackInProgress,
+		types.StackStatusUpdateRollbackInProgress,
+		types.StackStatusUpdateRollbackCompleteCleanupInProgress,
+		types.StackStatusImportRollbackInProgress,
+		types.StackStatusRollbackComplete,
+		types.StackStatusUpdateRollbackComplete,
+		types.StackStatusImportRollbackComplete:
+		// The stack is usable but the last change to it didn't work
+		item.Health = sdp.Health_HEALTH_WARNING.Enum()
+	case types.StackStatusCreateFailed,
+		types.StackStatusDeleteFailed,
+		types.StackStatusRollbackFailed,
+		types.StackStatusUpdateFailed,
+		types.StackStatusUpdateRollbackFailed,
+		types.StackStatusImportRollbackFailed:
+		item.Health = sdp.Health_HEALTH_ERROR.Enum()
+	case types.StackStatusDeleteComplete:
+		// This means the stack has been deleted
+		item.Health = nil
+	}
+
+	if stack.StackName != nil {
+		// +overmind:link cloudformation-stack-resource
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "cloudformation-stack-resource",
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  *stack.StackName,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// The stack manages its resources, so they are tightly coupled
+				In:  true,
+				Out: true,
+			},
+		})
+	}
+
+	for _, output := range stack.Outputs {
+		if output.ExportName != nil {
+			// +overmind:link cloudformation-stack
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "cloudformation-stack",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *output.ExportName,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Stacks that import our exports can't affect us
+					In: false,
+					// Changing an export will affect the stacks that import it
+					Out: true,
+				},
+			})
+		}
+	}
+
+	for _, export := range awsItem.Imports {
+		if export.ExportingStackId == nil {
+			continue
+		}
+
+		if a, err := sources.ParseARN(*export.ExportingStackId); err == nil {
+			// +overmind:link cloudformation-stack
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "cloudformation-stack",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *export.ExportingStackId,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the exports of the other stack will affect us
+					In: true,
+					// We can't affect the stack that we import from
+					Out: false,
+				},
+			})
+		}
+	}
+
+	// Nested stacks are managed by their parent, and by the root stack if it
+	// isn't also the parent
+	nestedParents := make([]string, 0)
+
+	if stack.ParentId != nil {
+		nestedParents = append(nestedParents, *stack.ParentId)
+	}
+
+	if stack.RootId != nil && (stack.ParentId == nil || *stack.RootId != *stack.ParentId) {
+		nestedParents = append(nestedParents, *stack.RootId)
+	}
+
+	for _, id := range nestedParents {
+		if a, err := sources.ParseARN(id); err == nil {
+			// +overmind:link cloudformation-stack
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "cloudformation-stack",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  id,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the parent stack will change the nested stack
+					In: true,
+					// A failure in the nested stack will fail the parent
+					Out: true,
+				},
+			})
+		}
+	}
+
+	if stack.RoleARN != nil {
+		if a, err := sources.ParseARN(*stack.RoleARN); err == nil {
+			// +overmind:link iam-role
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "iam-role",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *stack.RoleARN,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// The role's permissions determine what the stack can
+					// deploy
+					In: true,
+					// The stack won't affect the role
+					Out: false,
+				},
+			})
+		}
+	}
+
+	for _, topicARN := range stack.NotificationARNs {
+		if a, err := sources.ParseARN(topicARN); err == nil {
+			// +overmind:link sns-topic
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "sns-topic",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  topicARN,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// The topic won't affect the stack
+					In: false,
+					// The stack sends events to the topic
+					Out: true,
+				},
+			})
+		}
+	}
+
+	if stack.RollbackConfiguration != nil {
+		for _, trigger := range stack.RollbackConfiguration.RollbackTriggers {
+			if trigger.Arn == nil {
+				continue
+			}
+
+			if a, err := sources.ParseARN(*trigger.Arn); err == nil {
+				// +overmind:link cloudwatch-alarm
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "cloudwatch-alarm",
+						Method: sdp.QueryMethod_GET,
+						Query:  a.ResourceID(),
+						Scope:  sources.FormatScope(a.AccountID, a.Region),
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// The alarm going off will roll back updates to the
+						// stack
+						In: true,
+						// The stack won't affect the alarm
+						Out: false,
+					},
+				})
+			}
+		}
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type cloudformation-stack
+// +overmind:descriptiveType CloudFormation Stack
+// +overmind:get Get a stack by name
+// +overmind:list List all stacks
+// +overmind:search Search for stacks by ARN, or by the name of an export to find the stacks that import it
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_cloudformation_stack.name
+
+func NewStackSource(config aws.Config, accountID string, region string) *sources.GetListSource[*StackDetails, CloudFormationClient, *cloudformation.Options] {
+	imports := &stackImports{}
+
+	return &sources.GetListSource[*StackDetails, CloudFormationClient, *cloudformation.Options]{
+		ItemType:  "cloudformation-stack",
+		Client:    cloudformation.NewFromConfig(config),
+		AccountID: accountID,
+		Region:    region,
+		GetFunc: func(ctx context.Context, client CloudFormationClient, scope, query string) (*StackDetails, error) {
+			return stackGetFunc(ctx, client, imports, scope, query)
+		},
+		ListFunc: func(ctx context.Context, client CloudFormationClient, scope string) ([]*StackDetails, error) {
+			return stackListFunc(ctx, client, imports, scope)
+		},
+		SearchFunc: func(ctx context.Context, client CloudFormationClient, scope, query string) ([]*StackDetails, error) {
+			return stackSearchFunc(ctx, client, imports, scope, query)
+		},
+		ItemMapper: stackItemMapper,
+	}
+}
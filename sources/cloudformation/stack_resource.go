@@ -0,0 +1,314 @@
+package cloudformation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func stackResourceGetFunc(ctx context.Context, client CloudFormationClient, scope, query string) (*types.StackResourceDetail, error) {
+	// We are using a custom id of {stackName}/{logicalResourceId} e.g.
+	// my-stack/MyBucket
+	stackName, logicalID, found := strings.Cut(query, "/")
+
+	if !found {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: fmt.Sprintf("query must be in the format {stackName}/{logicalResourceId}, got %v", query),
+		}
+	}
+
+	out, err := client.DescribeStackResource(ctx, &cloudformation.DescribeStackResourceInput{
+		StackName:         &stackName,
+		LogicalResourceId: &logicalID,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if out.StackResourceDetail == nil {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: "stack resource was nil",
+		}
+	}
+
+	return out.StackResourceDetail, nil
+}
+
+// listStackResources Lists all resources in a given stack. The summaries
+// returned by ListStackResources are converted to details so that they match
+// the output of a Get
+func listStackResources(ctx context.Context, client CloudFormationClient, stackName string) ([]*types.StackResourceDetail, error) {
+	resources := make([]*types.StackResourceDetail, 0)
+
+	paginator := cloudformation.NewListStackResourcesPaginator(client, &cloudformation.ListStackResourcesInput{
+		StackName: &stackName,
+	})
+
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, summary := range out.StackResourceSummaries {
+			resource := types.StackResourceDetail{
+				StackName:            &stackName,
+				LastUpdatedTimestamp: summary.LastUpdatedTimestamp,
+				LogicalResourceId:    summary.LogicalResourceId,
+				ModuleInfo:           summary.ModuleInfo,
+				PhysicalResourceId:   summary.PhysicalResourceId,
+				ResourceStatus:       summary.ResourceStatus,
+				ResourceStatusReason: summary.ResourceStatusReason,
+				ResourceType:         summary.ResourceType,
+			}
+
+			if summary.DriftInformation != nil {
+				resource.DriftInformation = &types.StackResourceDriftInformation{
+					StackResourceDriftStatus: summary.DriftInformation.StackResourceDriftStatus,
+					LastCheckTimestamp:       summary.DriftInformation.LastCheckTimestamp,
+				}
+			}
+
+			resources = append(resources, &resource)
+		}
+	}
+
+	return resources, nil
+}
+
+func stackResourceListFunc(ctx context.Context, client CloudFormationClient, scope string) ([]*types.StackResourceDetail, error) {
+	resources := make([]*types.StackResourceDetail, 0)
+
+	stacks, err := describeStacks(ctx, client, &cloudformation.DescribeStacksInput{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, stack := range stacks {
+		if stack.Stack.StackName == nil {
+			continue
+		}
+
+		stackResources, err := listStackResources(ctx, client, *stack.Stack.StackName)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, stackResources...)
+	}
+
+	return resources, nil
+}
+
+// stackResourceSearchFunc Searches for resources by the name or ARN of the
+// stack that they belong to
+func stackResourceSearchFunc(ctx context.Context, client CloudFormationClient, scope, query string) ([]*types.StackResourceDetail, error) {
+	stackName := query
+
+	if a, err := sources.ParseARN(query); err == nil {
+		// Stack ARNs are in the format stack/{stackName}/{uuid}
+		stackName, _, _ = strings.Cut(a.ResourceID(), "/")
+	}
+
+	return listStackResources(ctx, client, stackName)
+}
+
+func stackResourceItemMapper(scope string, awsItem *types.StackResourceDetail) (*sdp.Item, error) {
+	// The metadata is part of the template so it can be large and isn't
+	// useful for discovery
+	attributes, err := sources.ToAttributesCase(awsItem, "metadata")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if awsItem.StackName == nil || awsItem.LogicalResourceId == nil {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_OTHER,
+			ErrorString: "stack resource is missing its stack name or logical ID",
+		}
+	}
+
+	err = attributes.Set("uniqueName", fmt.Sprintf("%v/%v", *awsItem.StackName, *awsItem.LogicalResourceId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := sdp.Item{
+		Type:            "cloudformation-stack-resource",
+		UniqueAttribute: "uniqueName",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	switch awsItem.ResourceStatus {
+	case types.ResourceStatusCreateComplete,
+		types.ResourceStatusUpdateComplete,
+		types.ResourceStatusImportComplete:
+		item.Health = sdp.Health_HEALTH_OK.Enum()
+	case types.ResourceStatusCreateInProgress,
+		types.ResourceStatusDeleteInProgress,
+		types.ResourceStatusUpdateInProgress,
+		types.ResourceStatusImportInProgress,
+		types.ResourceStatusImportRollbackInProgress,
+		types.ResourceStatusRollbackInProgress,
+		types.ResourceStatusUpdateRollbackInProgress:
+		item.Health = sdp.Health_HEALTH_PENDING.Enum()
+	case types.ResourceStatusCreateFailed,
+		types.ResourceStatusDeleteFailed,
+		types.ResourceStatusUpdateFailed,
+		types.ResourceStatusImportFailed,
+		types.ResourceStatusImportRollbackFailed,
+		types.ResourceStatusRollbackFailed,
+		types.ResourceStatusUpdateRollbackFailed:
+		item.Health = sdp.Health_HEALTH_ERROR.Enum()
+	}
+
+	if awsItem.DriftInformation != nil && awsItem.DriftInformation.StackResourceDriftStatus == types.StackResourceDriftStatusModified {
+		// The resource has been changed outside of CloudFormation
+		item.Health = sdp.Health_HEALTH_WARNING.Enum()
+	}
+
+	// +overmind:link cloudformation-stack
+	item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+		Query: &sdp.Query{
+			Type:   "cloudformation-stack",
+			Method: sdp.QueryMethod_GET,
+			Query:  *awsItem.StackName,
+			Scope:  scope,
+		},
+		BlastPropagation: &sdp.BlastPropagation{
+			// The stack manages the resource, so they are tightly coupled
+			In:  true,
+			Out: true,
+		},
+	})
+
+	if awsItem.ResourceType != nil && awsItem.PhysicalResourceId != nil {
+		query, err := SuggestedResourceQuery(*awsItem.ResourceType, *awsItem.PhysicalResourceId, scope)
+
+		if err == nil {
+			// Possible links for a stack resource
+			//
+			// +overmind:link autoscaling-auto-scaling-group
+			// +overmind:link autoscaling-launch-configuration
+			// +overmind:link cloudformation-stack
+			// +overmind:link cloudwatch-alarm
+			// +overmind:link dynamodb-table
+			// +overmind:link ec2-address
+			// +overmind:link ec2-capacity-reservation
+			// +overmind:link ec2-customer-gateway
+			// +overmind:link ec2-egress-only-internet-gateway
+			// +overmind:link ec2-host
+			// +overmind:link ec2-instance
+			// +overmind:link ec2-internet-gateway
+			// +overmind:link ec2-key-pair
+			// +overmind:link ec2-launch-template
+			// +overmind:link ec2-managed-prefix-list
+			// +overmind:link ec2-nat-gateway
+			// +overmind:link ec2-network-acl
+			// +overmind:link ec2-network-interface
+			// +overmind:link ec2-route-table
+			// +overmind:link ec2-security-group
+			// +overmind:link ec2-subnet
+			// +overmind:link ec2-volume
+			// +overmind:link ec2-vpc
+			// +overmind:link ec2-vpc-endpoint
+			// +overmind:link ec2-vpc-peering-connection
+			// +overmind:link ec2-vpn-connection
+			// +overmind:link ec2-vpn-gateway
+			// +overmind:link ecr-repository
+			// +overmind:link ecs-capacity-provider
+			// +overmind:link ecs-cluster
+			// +overmind:link ecs-service
+			// +overmind:link ecs-task-definition
+			// +overmind:link efs-access-point
+			// +overmind:link efs-file-system
+			// +overmind:link efs-mount-target
+			// +overmind:link eks-cluster
+			// +overmind:link elasticache-cache-cluster
+			// +overmind:link elasticache-parameter-group
+			// +overmind:link elasticache-replication-group
+			// +overmind:link elasticache-subnet-group
+			// +overmind:link elb-load-balancer
+			// +overmind:link elbv2-listener
+			// +overmind:link elbv2-load-balancer
+			// +overmind:link elbv2-rule
+			// +overmind:link elbv2-target-group
+			// +overmind:link events-event-bus
+			// +overmind:link firehose-delivery-stream
+			// +overmind:link iam-group
+			// +overmind:link iam-instance-profile
+			// +overmind:link iam-policy
+			// +overmind:link iam-role
+			// +overmind:link iam-user
+			// +overmind:link kinesis-stream
+			// +overmind:link lambda-event-source-mapping
+			// +overmind:link lambda-function
+			// +overmind:link lambda-layer-version
+			// +overmind:link memorydb-cluster
+			// +overmind:link rds-db-cluster
+			// +overmind:link rds-db-cluster-parameter-group
+			// +overmind:link rds-db-instance
+			// +overmind:link rds-db-parameter-group
+			// +overmind:link rds-db-subnet-group
+			// +overmind:link rds-option-group
+			// +overmind:link route53-health-check
+			// +overmind:link route53-hosted-zone
+			// +overmind:link s3-bucket
+			// +overmind:link servicediscovery-namespace
+			// +overmind:link servicediscovery-service
+			// +overmind:link sfn-activity
+			// +overmind:link sfn-state-machine
+			// +overmind:link sns-subscription
+			// +overmind:link sns-topic
+			// +overmind:link sqs-queue
+
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: query,
+				BlastPropagation: &sdp.BlastPropagation{
+					// The stack resource represents the actual resource, so
+					// they are tightly coupled
+					In:  true,
+					Out: true,
+				},
+			})
+		}
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type cloudformation-stack-resource
+// +overmind:descriptiveType CloudFormation Stack Resource
+// +overmind:get Get a stack resource by {stackName}/{logicalResourceId}
+// +overmind:list List all stack resources
+// +overmind:search Search for stack resources by stack name or ARN
+// +overmind:group AWS
+
+func NewStackResourceSource(config aws.Config, accountID string, region string) *sources.GetListSource[*types.StackResourceDetail, CloudFormationClient, *cloudformation.Options] {
+	return &sources.GetListSource[*types.StackResourceDetail, CloudFormationClient, *cloudformation.Options]{
+		ItemType:   "cloudformation-stack-resource",
+		Client:     cloudformation.NewFromConfig(config),
+		AccountID:  accountID,
+		Region:     region,
+		GetFunc:    stackResourceGetFunc,
+		ListFunc:   stackResourceListFunc,
+		SearchFunc: stackResourceSearchFunc,
+		ItemMapper: stackResourceItemMapper,
+	}
+}
@@ -0,0 +1,170 @@
+package cloudformation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testCloudFormationClient) DescribeStackResource(ctx context.Context, params *cloudformation.DescribeStackResourceInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeStackResourceOutput, error) {
+	return &cloudformation.DescribeStackResourceOutput{
+		StackResourceDetail: &types.StackResourceDetail{
+			LastUpdatedTimestamp: sources.PtrTime(time.Now()),
+			LogicalResourceId:    params.LogicalResourceId,
+			Metadata:             sources.PtrString("{}"),
+			PhysicalResourceId:   sources.PtrString("app-handler"),
+			ResourceStatus:       types.ResourceStatusUpdateComplete,
+			ResourceType:         sources.PtrString("AWS::Lambda::Function"),
+			StackId:              sources.PtrString("arn:aws:cloudformation:eu-west-2:052392120703:stack/" + *params.StackName + "/4e9f1a60-e0f2-11ee-8a5b-0a1b2c3d4e5f"),
+			StackName:            params.StackName,
+			DriftInformation: &types.StackResourceDriftInformation{
+				StackResourceDriftStatus: types.StackResourceDriftStatusInSync,
+			},
+		},
+	}, nil
+}
+
+func (c testCloudFormationClient) ListStackResources(ctx context.Context, params *cloudformation.ListStackResourcesInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListStackResourcesOutput, error) {
+	return &cloudformation.ListStackResourcesOutput{
+		StackResourceSummaries: []types.StackResourceSummary{
+			{
+				LastUpdatedTimestamp: sources.PtrTime(time.Now()),
+				LogicalResourceId:    sources.PtrString("Handler"),
+				PhysicalResourceId:   sources.PtrString("app-handler"),
+				ResourceStatus:       types.ResourceStatusUpdateComplete,
+				ResourceType:         sources.PtrString("AWS::Lambda::Function"),
+			},
+			{
+				LastUpdatedTimestamp: sources.PtrTime(time.Now()),
+				LogicalResourceId:    sources.PtrString("Bucket"),
+				PhysicalResourceId:   sources.PtrString("app-assets"),
+				ResourceStatus:       types.ResourceStatusCreateComplete,
+				ResourceType:         sources.PtrString("AWS::S3::Bucket"),
+				DriftInformation: &types.StackResourceDriftInformationSummary{
+					StackResourceDriftStatus: types.StackResourceDriftStatusModified,
+				},
+			},
+		},
+	}, nil
+}
+
+func TestStackResourceGetFunc(t *testing.T) {
+	scope := "052392120703.eu-west-2"
+
+	resource, err := stackResourceGetFunc(context.Background(), testCloudFormationClient{}, scope, "app/Handler")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := stackResourceItemMapper(scope, resource)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := item.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if item.UniqueAttributeValue() != "app/Handler" {
+		t.Errorf("expected unique attribute value to be app/Handler, got %v", item.UniqueAttributeValue())
+	}
+
+	if _, err := item.GetAttributes().Get("metadata"); err == nil {
+		t.Error("expected metadata to be excluded")
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "cloudformation-stack",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "app",
+			ExpectedScope:  scope,
+		},
+		{
+			ExpectedType:   "lambda-function",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "app-handler",
+			ExpectedScope:  scope,
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestStackResourceGetFuncBadQuery(t *testing.T) {
+	_, err := stackResourceGetFunc(context.Background(), testCloudFormationClient{}, "052392120703.eu-west-2", "app")
+
+	if err == nil {
+		t.Error("expected error for query without a logical ID")
+	}
+}
+
+func TestStackResourceSearchFunc(t *testing.T) {
+	scope := "052392120703.eu-west-2"
+
+	resources, err := stackResourceSearchFunc(context.Background(), testCloudFormationClient{}, scope, "arn:aws:cloudformation:eu-west-2:052392120703:stack/app/4e9f1a60-e0f2-11ee-8a5b-0a1b2c3d4e5f")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resources) != 2 {
+		t.Fatalf("expected 2 resources, got %v", len(resources))
+	}
+
+	item, err := stackResourceItemMapper(scope, resources[1])
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := item.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if item.UniqueAttributeValue() != "app/Bucket" {
+		t.Errorf("expected unique attribute value to be app/Bucket, got %v", item.UniqueAttributeValue())
+	}
+
+	// The bucket has drifted from the template
+	if item.GetHealth() != sdp.Health_HEALTH_WARNING {
+		t.Errorf("expected health to be WARNING, got %v", item.GetHealth())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "cloudformation-stack",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "app",
+			ExpectedScope:  scope,
+		},
+		{
+			ExpectedType:   "s3-bucket",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "app-assets",
+			ExpectedScope:  "052392120703",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestNewStackResourceSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewStackResourceSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -0,0 +1,196 @@
+package cloudformation
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+// StackSetDetails A stack set along with its stack instances, since these
+// aren't returned by DescribeStackSet
+type StackSetDetails struct {
+	StackSet  *types.StackSet
+	Instances []types.StackInstanceSummary
+}
+
+func stackSetGetFunc(ctx context.Context, client CloudFormationClient, scope, query string) (*StackSetDetails, error) {
+	out, err := client.DescribeStackSet(ctx, &cloudformation.DescribeStackSetInput{
+		StackSetName: &query,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if out.StackSet == nil {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: "stack set was nil",
+		}
+	}
+
+	details := StackSetDetails{
+		StackSet:  out.StackSet,
+		Instances: make([]types.StackInstanceSummary, 0),
+	}
+
+	paginator := cloudformation.NewListStackInstancesPaginator(client, &cloudformation.ListStackInstancesInput{
+		StackSetName: &query,
+	})
+
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		details.Instances = append(details.Instances, out.Summaries...)
+	}
+
+	return &details, nil
+}
+
+func stackSetListFunc(ctx context.Context, client CloudFormationClient, scope string) ([]*StackSetDetails, error) {
+	stackSets := make([]*StackSetDetails, 0)
+
+	paginator := cloudformation.NewListStackSetsPaginator(client, &cloudformation.ListStackSetsInput{
+		// Deleted stack sets are also returned otherwise
+		Status: types.StackSetStatusActive,
+	})
+
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, summary := range out.Summaries {
+			if summary.StackSetName == nil {
+				continue
+			}
+
+			stackSet, err := stackSetGetFunc(ctx, client, scope, *summary.StackSetName)
+
+			if err != nil {
+				return nil, err
+			}
+
+			stackSets = append(stackSets, stackSet)
+		}
+	}
+
+	return stackSets, nil
+}
+
+func stackSetItemMapper(scope string, awsItem *StackSetDetails) (*sdp.Item, error) {
+	enrichedStackSet := struct {
+		*types.StackSet
+		Instances []types.StackInstanceSummary
+	}{
+		StackSet:  awsItem.StackSet,
+		Instances: awsItem.Instances,
+	}
+
+	// The template body is large and is better viewed in the console
+	attributes, err := sources.ToAttributesCase(enrichedStackSet, "tags", "templateBody")
+
+	if err != nil {
+		return nil, err
+	}
+
+	stackSet := awsItem.StackSet
+
+	item := sdp.Item{
+		Type:            "cloudformation-stack-set",
+		UniqueAttribute: "stackSetName",
+		Attributes:      attributes,
+		Scope:           scope,
+		Tags:            tagsToMap(stackSet.Tags),
+	}
+
+	for _, instance := range awsItem.Instances {
+		switch instance.Status {
+		case types.StackInstanceStatusInoperable:
+			item.Health = sdp.Health_HEALTH_ERROR.Enum()
+		case types.StackInstanceStatusOutdated:
+			if item.GetHealth() != sdp.Health_HEALTH_ERROR {
+				// The instance hasn't been updated to match the stack set
+				item.Health = sdp.Health_HEALTH_WARNING.Enum()
+			}
+		}
+
+		if instance.StackId == nil {
+			continue
+		}
+
+		if a, err := sources.ParseARN(*instance.StackId); err == nil {
+			// Stack instances can be in other accounts and regions
+			// +overmind:link cloudformation-stack
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "cloudformation-stack",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *instance.StackId,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// A failed stack will cause stack set operations to fail
+					In: true,
+					// The stack set deploys the stacks, so changes to it will
+					// change them
+					Out: true,
+				},
+			})
+		}
+	}
+
+	if stackSet.AdministrationRoleARN != nil {
+		if a, err := sources.ParseARN(*stackSet.AdministrationRoleARN); err == nil {
+			// +overmind:link iam-role
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "iam-role",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *stackSet.AdministrationRoleARN,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// The role's permissions determine whether the stack set
+					// can be deployed
+					In: true,
+					// The stack set won't affect the role
+					Out: false,
+				},
+			})
+		}
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type cloudformation-stack-set
+// +overmind:descriptiveType CloudFormation Stack Set
+// +overmind:get Get a stack set by name or ID
+// +overmind:list List all active stack sets
+// +overmind:search Search for stack sets by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_cloudformation_stack_set.name
+
+func NewStackSetSource(config aws.Config, accountID string, region string) *sources.GetListSource[*StackSetDetails, CloudFormationClient, *cloudformation.Options] {
+	return &sources.GetListSource[*StackSetDetails, CloudFormationClient, *cloudformation.Options]{
+		ItemType:   "cloudformation-stack-set",
+		Client:     cloudformation.NewFromConfig(config),
+		AccountID:  accountID,
+		Region:     region,
+		GetFunc:    stackSetGetFunc,
+		ListFunc:   stackSetListFunc,
+		ItemMapper: stackSetItemMapper,
+	}
+}
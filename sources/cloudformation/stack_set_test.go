@@ -0,0 +1,149 @@
+package cloudformation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testCloudFormationClient) DescribeStackSet(ctx context.Context, params *cloudformation.DescribeStackSetInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeStackSetOutput, error) {
+	return &cloudformation.DescribeStackSetOutput{
+		StackSet: &types.StackSet{
+			AdministrationRoleARN: sources.PtrString("arn:aws:iam::052392120703:role/AWSCloudFormationStackSetAdministrationRole"), // link
+			ExecutionRoleName:     sources.PtrString("AWSCloudFormationStackSetExecutionRole"),
+			PermissionModel:       types.PermissionModelsSelfManaged,
+			StackSetARN:           sources.PtrString("arn:aws:cloudformation:eu-west-2:052392120703:stackset/" + *params.StackSetName + ":6b1c3d82-e0f2-11ee-8a5b-0a1b2c3d4e5f"),
+			StackSetId:            sources.PtrString(*params.StackSetName + ":6b1c3d82-e0f2-11ee-8a5b-0a1b2c3d4e5f"),
+			StackSetName:          params.StackSetName,
+			Status:                types.StackSetStatusActive,
+			TemplateBody:          sources.PtrString("Resources: {}"),
+			Tags: []types.Tag{
+				{
+					Key:   sources.PtrString("foo"),
+					Value: sources.PtrString("bar"),
+				},
+			},
+		},
+	}, nil
+}
+
+func (c testCloudFormationClient) ListStackInstances(ctx context.Context, params *cloudformation.ListStackInstancesInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListStackInstancesOutput, error) {
+	return &cloudformation.ListStackInstancesOutput{
+		Summaries: []types.StackInstanceSummary{
+			{
+				Account:      sources.PtrString("052392120703"),
+				Region:       sources.PtrString("eu-west-2"),
+				StackId:      sources.PtrString("arn:aws:cloudformation:eu-west-2:052392120703:stack/StackSet-baseline-4e9f1a60/4e9f1a60-e0f2-11ee-8a5b-0a1b2c3d4e5f"), // link
+				StackSetId:   sources.PtrString(*params.StackSetName + ":6b1c3d82-e0f2-11ee-8a5b-0a1b2c3d4e5f"),
+				Status:       types.StackInstanceStatusCurrent,
+				DriftStatus:  types.StackDriftStatusInSync,
+				StatusReason: sources.PtrString(""),
+			},
+			{
+				Account:     sources.PtrString("210987654321"),
+				Region:      sources.PtrString("us-east-1"),
+				StackId:     sources.PtrString("arn:aws:cloudformation:us-east-1:210987654321:stack/StackSet-baseline-7a2b3c4d/7a2b3c4d-e0f2-11ee-8a5b-0a1b2c3d4e5f"), // link
+				StackSetId:  sources.PtrString(*params.StackSetName + ":6b1c3d82-e0f2-11ee-8a5b-0a1b2c3d4e5f"),
+				Status:      types.StackInstanceStatusOutdated,
+				DriftStatus: types.StackDriftStatusNotChecked,
+			},
+		},
+	}, nil
+}
+
+func (c testCloudFormationClient) ListStackSets(ctx context.Context, params *cloudformation.ListStackSetsInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListStackSetsOutput, error) {
+	return &cloudformation.ListStackSetsOutput{
+		Summaries: []types.StackSetSummary{
+			{
+				StackSetName: sources.PtrString("baseline"),
+				Status:       types.StackSetStatusActive,
+			},
+		},
+	}, nil
+}
+
+func TestStackSetGetFunc(t *testing.T) {
+	scope := "052392120703.eu-west-2"
+
+	stackSet, err := stackSetGetFunc(context.Background(), testCloudFormationClient{}, scope, "baseline")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := stackSetItemMapper(scope, stackSet)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := item.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	// One of the instances is outdated
+	if item.GetHealth() != sdp.Health_HEALTH_WARNING {
+		t.Errorf("expected health to be WARNING, got %v", item.GetHealth())
+	}
+
+	if _, err := item.GetAttributes().Get("templateBody"); err == nil {
+		t.Error("expected templateBody to be excluded")
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "cloudformation-stack",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:cloudformation:eu-west-2:052392120703:stack/StackSet-baseline-4e9f1a60/4e9f1a60-e0f2-11ee-8a5b-0a1b2c3d4e5f",
+			ExpectedScope:  scope,
+		},
+		{
+			ExpectedType:   "cloudformation-stack",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:cloudformation:us-east-1:210987654321:stack/StackSet-baseline-7a2b3c4d/7a2b3c4d-e0f2-11ee-8a5b-0a1b2c3d4e5f",
+			ExpectedScope:  "210987654321.us-east-1",
+		},
+		{
+			ExpectedType:   "iam-role",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:iam::052392120703:role/AWSCloudFormationStackSetAdministrationRole",
+			ExpectedScope:  "052392120703",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestStackSetListFunc(t *testing.T) {
+	stackSets, err := stackSetListFunc(context.Background(), testCloudFormationClient{}, "052392120703.eu-west-2")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stackSets) != 1 {
+		t.Fatalf("expected 1 stack set, got %v", len(stackSets))
+	}
+
+	if len(stackSets[0].Instances) != 2 {
+		t.Errorf("expected 2 stack instances, got %v", len(stackSets[0].Instances))
+	}
+}
+
+func TestNewStackSetSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewStackSetSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -0,0 +1,396 @@
+package cloudformation
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func testStack(name string) types.Stack {
+	stack := types.Stack{
+		CreationTime: sources.PtrTime(time.Now()),
+		StackId:      sources.PtrString("arn:aws:cloudformation:eu-west-2:052392120703:stack/" + name + "/4e9f1a60-e0f2-11ee-8a5b-0a1b2c3d4e5f"),
+		StackName:    sources.PtrString(name),
+		StackStatus:  types.StackStatusUpdateComplete,
+		Tags: []types.Tag{
+			{
+				Key:   sources.PtrString("foo"),
+				Value: sources.PtrString("bar"),
+			},
+		},
+	}
+
+	switch name {
+	case "network":
+		stack.Outputs = []types.Output{
+			{
+				ExportName:  sources.PtrString("network-VpcId"),
+				OutputKey:   sources.PtrString("VpcId"),
+				OutputValue: sources.PtrString("vpc-0d7892e00e573e701"),
+			},
+		}
+	case "app":
+		stack.RoleARN = sources.PtrString("arn:aws:iam::052392120703:role/cfn-deploy")
+		stack.NotificationARNs = []string{"arn:aws:sns:eu-west-2:052392120703:cfn-events"}
+		stack.ParentId = sources.PtrString("arn:aws:cloudformation:eu-west-2:052392120703:stack/root/5f0a2b71-e0f2-11ee-8a5b-0a1b2c3d4e5f")
+		stack.RootId = stack.ParentId
+		stack.RollbackConfiguration = &types.RollbackConfiguration{
+			RollbackTriggers: []types.RollbackTrigger{
+				{
+					Arn:  sources.PtrString("arn:aws:cloudwatch:eu-west-2:052392120703:alarm:app-errors"), // link
+					Type: sources.PtrString("AWS::CloudWatch::Alarm"),
+				},
+			},
+		}
+	}
+
+	return stack
+}
+
+func (c testCloudFormationClient) DescribeStacks(ctx context.Context, params *cloudformation.DescribeStacksInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeStacksOutput, error) {
+	if params.StackName == nil {
+		return &cloudformation.DescribeStacksOutput{
+			Stacks: []types.Stack{
+				testStack("network"),
+				testStack("app"),
+			},
+		}, nil
+	}
+
+	name := *params.StackName
+
+	if a, err := sources.ParseARN(name); err == nil {
+		name, _, _ = strings.Cut(a.ResourceID(), "/")
+	}
+
+	return &cloudformation.DescribeStacksOutput{
+		Stacks: []types.Stack{
+			testStack(name),
+		},
+	}, nil
+}
+
+func TestStackGetFunc(t *testing.T) {
+	scope := "052392120703.eu-west-2"
+
+	t.Run("exporting stack", func(t *testing.T) {
+		stack, err := stackGetFunc(context.Background(), testCloudFormationClient{}, &stackImports{}, scope, "network")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		item, err := stackItemMapper(scope, stack)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := item.Validate(); err != nil {
+			t.Fatal(err)
+		}
+
+		if item.GetHealth() != sdp.Health_HEALTH_OK {
+			t.Errorf("expected health to be OK, got %v", item.GetHealth())
+		}
+
+		tests := sources.QueryTests{
+			{
+				ExpectedType:   "cloudformation-stack-resource",
+				ExpectedMethod: sdp.QueryMethod_SEARCH,
+				ExpectedQuery:  "network",
+				ExpectedScope:  scope,
+			},
+			{
+				ExpectedType:   "cloudformation-stack",
+				ExpectedMethod: sdp.QueryMethod_SEARCH,
+				ExpectedQuery:  "network-VpcId",
+				ExpectedScope:  scope,
+			},
+		}
+
+		tests.Execute(t, item)
+	})
+
+	t.Run("importing stack", func(t *testing.T) {
+		stack, err := stackGetFunc(context.Background(), testCloudFormationClient{}, &stackImports{}, scope, "app")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Imports should be the same as when listing
+		if len(stack.Imports) != 1 {
+			t.Fatalf("expected 1 import, got %v", len(stack.Imports))
+		}
+
+		item, err := stackItemMapper(scope, stack)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := item.Validate(); err != nil {
+			t.Fatal(err)
+		}
+
+		tests := sources.QueryTests{
+			{
+				ExpectedType:   "cloudformation-stack-resource",
+				ExpectedMethod: sdp.QueryMethod_SEARCH,
+				ExpectedQuery:  "app",
+				ExpectedScope:  scope,
+			},
+			{
+				ExpectedType:   "cloudformation-stack",
+				ExpectedMethod: sdp.QueryMethod_SEARCH,
+				ExpectedQuery:  "arn:aws:cloudformation:eu-west-2:052392120703:stack/network/4e9f1a60-e0f2-11ee-8a5b-0a1b2c3d4e5f",
+				ExpectedScope:  scope,
+			},
+			{
+				ExpectedType:   "cloudformation-stack",
+				ExpectedMethod: sdp.QueryMethod_SEARCH,
+				ExpectedQuery:  "arn:aws:cloudformation:eu-west-2:052392120703:stack/root/5f0a2b71-e0f2-11ee-8a5b-0a1b2c3d4e5f",
+				ExpectedScope:  scope,
+			},
+			{
+				ExpectedType:   "iam-role",
+				ExpectedMethod: sdp.QueryMethod_SEARCH,
+				ExpectedQuery:  "arn:aws:iam::052392120703:role/cfn-deploy",
+				ExpectedScope:  "052392120703",
+			},
+			{
+				ExpectedType:   "sns-topic",
+				ExpectedMethod: sdp.QueryMethod_SEARCH,
+				ExpectedQuery:  "arn:aws:sns:eu-west-2:052392120703:cfn-events",
+				ExpectedScope:  scope,
+			},
+			{
+				ExpectedType:   "cloudwatch-alarm",
+				ExpectedMethod: sdp.QueryMethod_GET,
+				ExpectedQuery:  "app-errors",
+				ExpectedScope:  scope,
+			},
+		}
+
+		tests.Execute(t, item)
+
+		// The root stack is also the parent so should only be linked once
+		if len(item.GetLinkedItemQueries()) != len(tests) {
+			t.Errorf("expected %v linked item queries, got %v", len(tests), len(item.GetLinkedItemQueries()))
+		}
+	})
+}
+
+func TestStackListFunc(t *testing.T) {
+	scope := "052392120703.eu-west-2"
+
+	stacks, err := stackListFunc(context.Background(), testCloudFormationClient{}, &stackImports{}, scope)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stacks) != 2 {
+		t.Fatalf("expected 2 stacks, got %v", len(stacks))
+	}
+
+	t.Run("importing stack", func(t *testing.T) {
+		stack := stacks[1]
+
+		if *stack.Stack.StackName != "app" {
+			t.Fatalf("expected stack app, got %v", *stack.Stack.StackName)
+		}
+
+		if len(stack.Imports) != 1 {
+			t.Fatalf("expected 1 import, got %v", len(stack.Imports))
+		}
+
+		item, err := stackItemMapper(scope, stack)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := item.Validate(); err != nil {
+			t.Fatal(err)
+		}
+
+		tests := sources.QueryTests{
+			{
+				ExpectedType:   "cloudformation-stack-resource",
+				ExpectedMethod: sdp.QueryMethod_SEARCH,
+				ExpectedQuery:  "app",
+				ExpectedScope:  scope,
+			},
+			{
+				ExpectedType:   "cloudformation-stack",
+				ExpectedMethod: sdp.QueryMethod_SEARCH,
+				ExpectedQuery:  "arn:aws:cloudformation:eu-west-2:052392120703:stack/network/4e9f1a60-e0f2-11ee-8a5b-0a1b2c3d4e5f",
+				ExpectedScope:  scope,
+			},
+			{
+				ExpectedType:   "cloudformation-stack",
+				ExpectedMethod: sdp.QueryMethod_SEARCH,
+				ExpectedQuery:  "arn:aws:cloudformation:eu-west-2:052392120703:stack/root/5f0a2b71-e0f2-11ee-8a5b-0a1b2c3d4e5f",
+				ExpectedScope:  scope,
+			},
+			{
+				ExpectedType:   "iam-role",
+				ExpectedMethod: sdp.QueryMethod_SEARCH,
+				ExpectedQuery:  "arn:aws:iam::052392120703:role/cfn-deploy",
+				ExpectedScope:  "052392120703",
+			},
+			{
+				ExpectedType:   "sns-topic",
+				ExpectedMethod: sdp.QueryMethod_SEARCH,
+				ExpectedQuery:  "arn:aws:sns:eu-west-2:052392120703:cfn-events",
+				ExpectedScope:  scope,
+			},
+			{
+				ExpectedType:   "cloudwatch-alarm",
+				ExpectedMethod: sdp.QueryMethod_GET,
+				ExpectedQuery:  "app-errors",
+				ExpectedScope:  scope,
+			},
+		}
+
+		tests.Execute(t, item)
+
+		// The root stack is also the parent so should only be linked once
+		if len(item.GetLinkedItemQueries()) != len(tests) {
+			t.Errorf("expected %v linked item queries, got %v", len(tests), len(item.GetLinkedItemQueries()))
+		}
+	})
+}
+
+func TestStackSearchFunc(t *testing.T) {
+	scope := "052392120703.eu-west-2"
+
+	t.Run("by ARN", func(t *testing.T) {
+		stacks, err := stackSearchFunc(context.Background(), testCloudFormationClient{}, &stackImports{}, scope, "arn:aws:cloudformation:eu-west-2:052392120703:stack/network/4e9f1a60-e0f2-11ee-8a5b-0a1b2c3d4e5f")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(stacks) != 1 {
+			t.Fatalf("expected 1 stack, got %v", len(stacks))
+		}
+
+		if *stacks[0].Stack.StackName != "network" {
+			t.Errorf("expected stack network, got %v", *stacks[0].Stack.StackName)
+		}
+	})
+
+	t.Run("by export name", func(t *testing.T) {
+		stacks, err := stackSearchFunc(context.Background(), testCloudFormationClient{}, &stackImports{}, scope, "network-VpcId")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(stacks) != 1 {
+			t.Fatalf("expected 1 stack, got %v", len(stacks))
+		}
+
+		if *stacks[0].Stack.StackName != "app" {
+			t.Errorf("expected stack app, got %v", *stacks[0].Stack.StackName)
+		}
+
+		if len(stacks[0].Imports) != 1 {
+			t.Errorf("expected 1 import, got %v", len(stacks[0].Imports))
+		}
+	})
+
+	t.Run("by export name that isn't imported", func(t *testing.T) {
+		stacks, err := stackSearchFunc(context.Background(), testCloudFormationClient{}, &stackImports{}, scope, "network-Unused")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(stacks) != 0 {
+			t.Errorf("expected 0 stacks, got %v", len(stacks))
+		}
+	})
+}
+
+// countingCloudFormationClient Counts the calls to ListExports, which are made
+// every time the imports are looked up
+type countingCloudFormationClient struct {
+	testCloudFormationClient
+
+	listExportsCalls *int
+}
+
+func (c countingCloudFormationClient) ListExports(ctx context.Context, params *cloudformation.ListExportsInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListExportsOutput, error) {
+	*c.listExportsCalls++
+
+	return c.testCloudFormationClient.ListExports(ctx, params, optFns...)
+}
+
+func TestStackImportsCache(t *testing.T) {
+	scope := "052392120703.eu-west-2"
+	calls := 0
+	client := countingCloudFormationClient{listExportsCalls: &calls}
+	cache := &stackImports{}
+
+	if _, err := stackGetFunc(context.Background(), client, cache, scope, "app"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := stackSearchFunc(context.Background(), client, cache, scope, "network-VpcId"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The imports from the Get should be reused by the Search
+	if calls != 1 {
+		t.Errorf("expected imports to be looked up once, got %v", calls)
+	}
+
+	if _, err := stackListFunc(context.Background(), client, cache, scope); err != nil {
+		t.Fatal(err)
+	}
+
+	// List should always look up the imports
+	if calls != 2 {
+		t.Errorf("expected imports to be looked up twice, got %v", calls)
+	}
+
+	// Once the cache expires Get should look them up again
+	cache.expiry = time.Now().Add(-time.Second)
+
+	stack, err := stackGetFunc(context.Background(), client, cache, scope, "app")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 3 {
+		t.Errorf("expected imports to be looked up three times, got %v", calls)
+	}
+
+	if len(stack.Imports) != 1 {
+		t.Errorf("expected 1 import, got %v", len(stack.Imports))
+	}
+}
+
+func TestNewStackSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewStackSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -3,6 +3,7 @@ package cloudfront
 import (
 	"context"
 	"regexp"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
@@ -12,7 +13,53 @@ import (
 
 var s3DnsRegex = regexp.MustCompile(`([^\.]+)\.s3\.([^\.]+)\.amazonaws\.com`)
 
+// distributionIDByDomainName Finds the ID of the distribution with a given
+// domain name e.g. d111111abcdef8.cloudfront.net. Route 53 aliases point at
+// this domain name rather than the ID
+func distributionIDByDomainName(ctx context.Context, client CloudFrontClient, domainName string) (*string, error) {
+	domainName = strings.TrimSuffix(domainName, ".")
+
+	paginator := cloudfront.NewListDistributionsPaginator(client, &cloudfront.ListDistributionsInput{})
+
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if out.DistributionList == nil {
+			continue
+		}
+
+		for _, distribution := range out.DistributionList.Items {
+			if distribution.DomainName != nil && strings.EqualFold(*distribution.DomainName, domainName) {
+				return distribution.Id, nil
+			}
+		}
+	}
+
+	return nil, &sdp.QueryError{
+		ErrorType:   sdp.QueryError_NOTFOUND,
+		ErrorString: "no distribution found with domain name " + domainName,
+	}
+}
+
 func distributionGetFunc(ctx context.Context, client CloudFrontClient, scope string, input *cloudfront.GetDistributionInput) (*sdp.Item, error) {
+	// Searches by domain name pass the domain name in place of the ID, so
+	// resolve it to the real ID first
+	if input.Id != nil && strings.HasSuffix(strings.TrimSuffix(*input.Id, "."), ".cloudfront.net") {
+		id, err := distributionIDByDomainName(ctx, client, *input.Id)
+
+		if err != nil {
+			return nil, err
+		}
+
+		input = &cloudfront.GetDistributionInput{
+			Id: id,
+		}
+	}
+
 	out, err := client.GetDistribution(ctx, input)
 
 	if err != nil {
@@ -626,13 +673,21 @@ func distributionGetFunc(ctx context.Context, client CloudFrontClient, scope str
 
 		if dc.WebACLId != nil {
 			if arn, err := sources.ParseARN(*dc.WebACLId); err == nil {
+				// Web ACLs for CloudFront are global, so they are discovered
+				// in the account scope even though their ARNs are in us-east-1
+				aclScope := sources.FormatScope(arn.AccountID, arn.Region)
+
+				if arn.Type() == "global" {
+					aclScope = sources.FormatScope(arn.AccountID, "")
+				}
+
 				// +overmind:link wafv2-web-acl
 				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
 					Query: &sdp.Query{
 						Type:   "wafv2-web-acl",
 						Method: sdp.QueryMethod_SEARCH,
 						Query:  *dc.WebACLId,
-						Scope:  sources.FormatScope(arn.AccountID, arn.Region),
+						Scope:  aclScope,
 					},
 					BlastPropagation: &sdp.BlastPropagation{
 						// Changing the ACL could affect the distribution
@@ -670,7 +725,7 @@ func distributionGetFunc(ctx context.Context, client CloudFrontClient, scope str
 // +overmind:descriptiveType CloudFront Distribution
 // +overmind:get
 // +overmind:list
-// +overmind:search
+// +overmind:search Search for a distribution by ARN, or by its domain name e.g. d111111abcdef8.cloudfront.net
 // +overmind:group AWS
 // +overmind:terraform:queryMap aws_cloudfront_distribution.arn
 // +overmind:terraform:method SEARCH
@@ -702,5 +757,19 @@ func NewDistributionSource(config aws.Config, accountID string) *sources.AlwaysG
 			return inputs, nil
 		},
 		GetFunc: distributionGetFunc,
+		// ARNs are handled automatically, anything else is a domain name
+		AlwaysSearchARNs: true,
+		SearchGetInputMapper: func(scope, query string) (*cloudfront.GetDistributionInput, error) {
+			if !strings.HasSuffix(strings.TrimSuffix(query, "."), ".cloudfront.net") {
+				return nil, &sdp.QueryError{
+					ErrorType:   sdp.QueryError_NOTFOUND,
+					ErrorString: "search query must be an ARN or a cloudfront.net domain name, got " + query,
+				}
+			}
+
+			return &cloudfront.GetDistributionInput{
+				Id: &query,
+			}, nil
+		},
 	}
 }
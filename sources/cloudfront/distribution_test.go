@@ -346,7 +346,8 @@ func (t TestCloudFrontClient) ListDistributions(ctx context.Context, params *clo
 			IsTruncated: sources.PtrBool(false),
 			Items: []types.DistributionSummary{
 				{
-					Id: sources.PtrString("test-id"),
+					DomainName: sources.PtrString("d111111abcdef8.cloudfront.net"),
+					Id:         sources.PtrString("test-id"),
 				},
 			},
 		},
@@ -482,7 +483,7 @@ func TestDistributionGetFunc(t *testing.T) {
 			ExpectedType:   "wafv2-web-acl",
 			ExpectedMethod: sdp.QueryMethod_SEARCH,
 			ExpectedQuery:  "arn:aws:wafv2:us-east-1:123456789012:global/webacl/ExampleWebACL/473e64fd-f30b-4765-81a0-62ad96dd167a",
-			ExpectedScope:  "123456789012.us-east-1",
+			ExpectedScope:  "123456789012",
 		},
 		{
 			ExpectedType:   "s3-bucket",
@@ -495,6 +496,24 @@ func TestDistributionGetFunc(t *testing.T) {
 	tests.Execute(t, item)
 }
 
+func TestDistributionIDByDomainName(t *testing.T) {
+	id, err := distributionIDByDomainName(context.Background(), TestCloudFrontClient{}, "d111111abcdef8.cloudfront.net.")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *id != "test-id" {
+		t.Errorf("expected ID to be test-id, got %v", *id)
+	}
+
+	_, err = distributionIDByDomainName(context.Background(), TestCloudFrontClient{}, "d222222abcdef8.cloudfront.net")
+
+	if err == nil {
+		t.Error("expected error for unknown domain name")
+	}
+}
+
 func TestNewDistributionSource(t *testing.T) {
 	config, account, _ := sources.GetAutoConfig(t)
 
@@ -98,6 +98,24 @@ func tableGetFunc(ctx context.Context, client Client, scope string, input *dynam
 		}
 	}
 
+	if table.LatestStreamArn != nil {
+		// +overmind:link lambda-event-source-mapping
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "lambda-event-source-mapping",
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  *table.LatestStreamArn,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Changes to the table are delivered to the function via the
+				// stream, so these are tightly coupled
+				In:  true,
+				Out: true,
+			},
+		})
+	}
+
 	if table.RestoreSummary != nil {
 		if table.RestoreSummary.SourceBackupArn != nil {
 			if a, err = sources.ParseARN(*table.RestoreSummary.SourceBackupArn); err == nil {
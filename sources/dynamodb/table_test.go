@@ -82,7 +82,7 @@ func (t *TestClient) DescribeTable(context.Context, *dynamodb.DescribeTableInput
 				BillingMode: types.BillingModePayPerRequest,
 			},
 			GlobalTableVersion: sources.PtrString("1"),
-			LatestStreamArn:    sources.PtrString("arn:aws:dynamodb:eu-west-1:052392120703:table/test-DDBTable-1X52D7BWAAB2H/stream/2023-01-11T16:53:02.371"), // This doesn't get linked because there is no more data to get
+			LatestStreamArn:    sources.PtrString("arn:aws:dynamodb:eu-west-1:052392120703:table/test-DDBTable-1X52D7BWAAB2H/stream/2023-01-11T16:53:02.371"), // link
 			LatestStreamLabel:  sources.PtrString("2023-01-11T16:53:02.371"),
 			LocalSecondaryIndexes: []types.LocalSecondaryIndexDescription{
 				{
@@ -191,6 +191,12 @@ func TestTableGetFunc(t *testing.T) {
 	}
 
 	tests := sources.QueryTests{
+		{
+			ExpectedType:   "lambda-event-source-mapping",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:dynamodb:eu-west-1:052392120703:table/test-DDBTable-1X52D7BWAAB2H/stream/2023-01-11T16:53:02.371",
+			ExpectedScope:  "foo",
+		},
 		{
 			ExpectedType:   "kinesis-stream",
 			ExpectedMethod: sdp.QueryMethod_SEARCH,
@@ -0,0 +1,118 @@
+package ec2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func customerGatewayInputMapperGet(scope string, query string) (*ec2.DescribeCustomerGatewaysInput, error) {
+	return &ec2.DescribeCustomerGatewaysInput{
+		CustomerGatewayIds: []string{
+			query,
+		},
+	}, nil
+}
+
+func customerGatewayInputMapperList(scope string) (*ec2.DescribeCustomerGatewaysInput, error) {
+	return &ec2.DescribeCustomerGatewaysInput{}, nil
+}
+
+func customerGatewayOutputMapper(_ context.Context, _ *ec2.Client, scope string, _ *ec2.DescribeCustomerGatewaysInput, output *ec2.DescribeCustomerGatewaysOutput) ([]*sdp.Item, error) {
+	items := make([]*sdp.Item, 0)
+
+	for _, gw := range output.CustomerGateways {
+		attrs, err := sources.ToAttributesCase(gw, "tags")
+
+		if err != nil {
+			return nil, &sdp.QueryError{
+				ErrorType:   sdp.QueryError_OTHER,
+				ErrorString: err.Error(),
+				Scope:       scope,
+			}
+		}
+
+		item := sdp.Item{
+			Type:            "ec2-customer-gateway",
+			UniqueAttribute: "customerGatewayId",
+			Scope:           scope,
+			Attributes:      attrs,
+			Tags:            tagsToMap(gw.Tags),
+		}
+
+		if gw.State != nil {
+			item.Health = vpnStateToHealth(*gw.State)
+		}
+
+		if gw.IpAddress != nil {
+			// +overmind:link ip
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "ip",
+					Method: sdp.QueryMethod_GET,
+					Query:  *gw.IpAddress,
+					Scope:  "global",
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// The IP is the on-premises device, so they are tightly
+					// linked
+					In:  true,
+					Out: true,
+				},
+			})
+		}
+
+		if gw.CertificateArn != nil {
+			if arn, err := sources.ParseARN(*gw.CertificateArn); err == nil {
+				// +overmind:link acm-certificate
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "acm-certificate",
+						Method: sdp.QueryMethod_SEARCH,
+						Query:  *gw.CertificateArn,
+						Scope:  sources.FormatScope(arn.AccountID, arn.Region),
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// The certificate is used to authenticate the
+						// gateway
+						In: true,
+						// The gateway won't affect the certificate
+						Out: false,
+					},
+				})
+			}
+		}
+
+		items = append(items, &item)
+	}
+
+	return items, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type ec2-customer-gateway
+// +overmind:descriptiveType Customer Gateway
+// +overmind:get Get a customer gateway by ID
+// +overmind:list List all customer gateways
+// +overmind:search Search customer gateways by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_customer_gateway.id
+
+func NewCustomerGatewaySource(config aws.Config, accountID string, limit *sources.LimitBucket) *sources.DescribeOnlySource[*ec2.DescribeCustomerGatewaysInput, *ec2.DescribeCustomerGatewaysOutput, *ec2.Client, *ec2.Options] {
+	return &sources.DescribeOnlySource[*ec2.DescribeCustomerGatewaysInput, *ec2.DescribeCustomerGatewaysOutput, *ec2.Client, *ec2.Options]{
+		Config:    config,
+		Client:    ec2.NewFromConfig(config),
+		AccountID: accountID,
+		ItemType:  "ec2-customer-gateway",
+		DescribeFunc: func(ctx context.Context, client *ec2.Client, input *ec2.DescribeCustomerGatewaysInput) (*ec2.DescribeCustomerGatewaysOutput, error) {
+			limit.Wait(ctx) // Wait for rate limiting
+			return client.DescribeCustomerGateways(ctx, input)
+		},
+		InputMapperGet:  customerGatewayInputMapperGet,
+		InputMapperList: customerGatewayInputMapperList,
+		OutputMapper:    customerGatewayOutputMapper,
+	}
+}
@@ -0,0 +1,115 @@
+package ec2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func TestCustomerGatewayInputMapperGet(t *testing.T) {
+	input, err := customerGatewayInputMapperGet("foo", "bar")
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(input.CustomerGatewayIds) != 1 {
+		t.Fatalf("expected 1 CustomerGateway ID, got %v", len(input.CustomerGatewayIds))
+	}
+
+	if input.CustomerGatewayIds[0] != "bar" {
+		t.Errorf("expected CustomerGateway ID to be bar, got %v", input.CustomerGatewayIds[0])
+	}
+}
+
+func TestCustomerGatewayInputMapperList(t *testing.T) {
+	input, err := customerGatewayInputMapperList("foo")
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(input.Filters) != 0 || len(input.CustomerGatewayIds) != 0 {
+		t.Errorf("non-empty input: %v", input)
+	}
+}
+
+func TestCustomerGatewayOutputMapper(t *testing.T) {
+	output := &ec2.DescribeCustomerGatewaysOutput{
+		CustomerGateways: []types.CustomerGateway{
+			{
+				BgpAsn:            sources.PtrString("65000"),
+				CertificateArn:    sources.PtrString("arn:aws:acm:eu-west-2:052392120703:certificate/1b3c5d7e-9f1a-4b3c-8d5e-7f9a1b3c5d7e"),
+				CustomerGatewayId: sources.PtrString("cgw-0e11f167"),
+				IpAddress:         sources.PtrString("12.1.2.3"),
+				State:             sources.PtrString("available"),
+				Type:              sources.PtrString("ipsec.1"),
+				Tags: []types.Tag{
+					{
+						Key:   sources.PtrString("Name"),
+						Value: sources.PtrString("test"),
+					},
+				},
+			},
+		},
+	}
+
+	items, err := customerGatewayOutputMapper(context.Background(), nil, "foo", nil, output)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, item := range items {
+		if err := item.Validate(); err != nil {
+			t.Error(err)
+		}
+	}
+
+	if len(items) != 1 {
+		t.Fatalf("expected 1 item, got %v", len(items))
+	}
+
+	item := items[0]
+
+	if item.GetHealth() != sdp.Health_HEALTH_OK {
+		t.Errorf("expected health to be OK, got %v", item.GetHealth())
+	}
+
+	// It doesn't really make sense to test anything other than the linked items
+	// since the attributes are converted automatically
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "ip",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "12.1.2.3",
+			ExpectedScope:  "global",
+		},
+		{
+			ExpectedType:   "acm-certificate",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:acm:eu-west-2:052392120703:certificate/1b3c5d7e-9f1a-4b3c-8d5e-7f9a1b3c5d7e",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestNewCustomerGatewaySource(t *testing.T) {
+	config, account, _ := sources.GetAutoConfig(t)
+
+	source := NewCustomerGatewaySource(config, account, &TestRateLimit)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
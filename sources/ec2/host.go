@@ -0,0 +1,132 @@
+package ec2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func hostInputMapperGet(scope string, query string) (*ec2.DescribeHostsInput, error) {
+	return &ec2.DescribeHostsInput{
+		HostIds: []string{
+			query,
+		},
+	}, nil
+}
+
+func hostInputMapperList(scope string) (*ec2.DescribeHostsInput, error) {
+	return &ec2.DescribeHostsInput{}, nil
+}
+
+func hostOutputMapper(_ context.Context, _ *ec2.Client, scope string, _ *ec2.DescribeHostsInput, output *ec2.DescribeHostsOutput) ([]*sdp.Item, error) {
+	items := make([]*sdp.Item, 0)
+
+	for _, host := range output.Hosts {
+		attrs, err := sources.ToAttributesCase(host, "tags")
+
+		if err != nil {
+			return nil, &sdp.QueryError{
+				ErrorType:   sdp.QueryError_OTHER,
+				ErrorString: err.Error(),
+				Scope:       scope,
+			}
+		}
+
+		item := sdp.Item{
+			Type:            "ec2-host",
+			UniqueAttribute: "hostId",
+			Scope:           scope,
+			Attributes:      attrs,
+			Tags:            tagsToMap(host.Tags),
+		}
+
+		switch host.State {
+		case types.AllocationStateAvailable:
+			item.Health = sdp.Health_HEALTH_OK.Enum()
+		case types.AllocationStatePending, types.AllocationStateUnderAssessment:
+			item.Health = sdp.Health_HEALTH_PENDING.Enum()
+		case types.AllocationStatePermanentFailure:
+			item.Health = sdp.Health_HEALTH_ERROR.Enum()
+		case types.AllocationStateReleased, types.AllocationStateReleasedPermanentFailure:
+			// This means the host has been released
+			item.Health = nil
+		}
+
+		for _, instance := range host.Instances {
+			if instance.InstanceId != nil {
+				// +overmind:link ec2-instance
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "ec2-instance",
+						Method: sdp.QueryMethod_GET,
+						Query:  *instance.InstanceId,
+						Scope:  scope,
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Instances won't affect the host
+						In: false,
+						// Problems with the host will affect all instances
+						// running on it
+						Out: true,
+					},
+				})
+			}
+		}
+
+		if host.OutpostArn != nil {
+			if arn, err := sources.ParseARN(*host.OutpostArn); err == nil {
+				// +overmind:link outposts-outpost
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "outposts-outpost",
+						Method: sdp.QueryMethod_SEARCH,
+						Query:  *host.OutpostArn,
+						Scope:  sources.FormatScope(arn.AccountID, arn.Region),
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Changes to the outpost will affect the host
+						In: true,
+						// We can't affect the outpost
+						Out: false,
+					},
+				})
+			}
+		}
+
+		items = append(items, &item)
+	}
+
+	return items, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type ec2-host
+// +overmind:descriptiveType Dedicated Host
+// +overmind:get Get a dedicated host by ID
+// +overmind:list List all dedicated hosts
+// +overmind:search Search dedicated hosts by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_ec2_host.id
+
+func NewHostSource(config aws.Config, accountID string, limit *sources.LimitBucket) *sources.DescribeOnlySource[*ec2.DescribeHostsInput, *ec2.DescribeHostsOutput, *ec2.Client, *ec2.Options] {
+	return &sources.DescribeOnlySource[*ec2.DescribeHostsInput, *ec2.DescribeHostsOutput, *ec2.Client, *ec2.Options]{
+		Config:    config,
+		Client:    ec2.NewFromConfig(config),
+		AccountID: accountID,
+		ItemType:  "ec2-host",
+		DescribeFunc: func(ctx context.Context, client *ec2.Client, input *ec2.DescribeHostsInput) (*ec2.DescribeHostsOutput, error) {
+			limit.Wait(ctx) // Wait for rate limiting
+			return client.DescribeHosts(ctx, input)
+		},
+		InputMapperGet:  hostInputMapperGet,
+		InputMapperList: hostInputMapperList,
+		PaginatorBuilder: func(client *ec2.Client, params *ec2.DescribeHostsInput) sources.Paginator[*ec2.DescribeHostsOutput, *ec2.Options] {
+			return ec2.NewDescribeHostsPaginator(client, params)
+		},
+		OutputMapper: hostOutputMapper,
+	}
+}
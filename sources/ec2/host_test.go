@@ -0,0 +1,131 @@
+package ec2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func TestHostInputMapperGet(t *testing.T) {
+	input, err := hostInputMapperGet("foo", "bar")
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(input.HostIds) != 1 {
+		t.Fatalf("expected 1 Host ID, got %v", len(input.HostIds))
+	}
+
+	if input.HostIds[0] != "bar" {
+		t.Errorf("expected Host ID to be bar, got %v", input.HostIds[0])
+	}
+}
+
+func TestHostInputMapperList(t *testing.T) {
+	input, err := hostInputMapperList("foo")
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(input.Filter) != 0 || len(input.HostIds) != 0 {
+		t.Errorf("non-empty input: %v", input)
+	}
+}
+
+func TestHostOutputMapper(t *testing.T) {
+	output := &ec2.DescribeHostsOutput{
+		Hosts: []types.Host{
+			{
+				AutoPlacement:    types.AutoPlacementOn,
+				AvailabilityZone: sources.PtrString("eu-west-2a"),
+				AvailableCapacity: &types.AvailableCapacity{
+					AvailableVCpus: sources.PtrInt32(32),
+				},
+				HostId: sources.PtrString("h-0a1b2c3d4e5f67890"),
+				HostProperties: &types.HostProperties{
+					Cores:        sources.PtrInt32(24),
+					InstanceType: sources.PtrString("m5.large"),
+					Sockets:      sources.PtrInt32(2),
+					TotalVCpus:   sources.PtrInt32(48),
+				},
+				Instances: []types.HostInstance{
+					{
+						InstanceId:   sources.PtrString("i-0e1f2a3b4c5d6e7f8"),
+						InstanceType: sources.PtrString("m5.large"),
+						OwnerId:      sources.PtrString("052392120703"),
+					},
+				},
+				OutpostArn: sources.PtrString("arn:aws:outposts:eu-west-2:052392120703:outpost/op-0ac8aa5d5e0d4a8e1"),
+				OwnerId:    sources.PtrString("052392120703"),
+				State:      types.AllocationStateAvailable,
+				Tags: []types.Tag{
+					{
+						Key:   sources.PtrString("Name"),
+						Value: sources.PtrString("test"),
+					},
+				},
+			},
+		},
+	}
+
+	items, err := hostOutputMapper(context.Background(), nil, "foo", nil, output)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, item := range items {
+		if err := item.Validate(); err != nil {
+			t.Error(err)
+		}
+	}
+
+	if len(items) != 1 {
+		t.Fatalf("expected 1 item, got %v", len(items))
+	}
+
+	item := items[0]
+
+	if item.GetHealth() != sdp.Health_HEALTH_OK {
+		t.Errorf("expected health to be OK, got %v", item.GetHealth())
+	}
+
+	// It doesn't really make sense to test anything other than the linked items
+	// since the attributes are converted automatically
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "ec2-instance",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "i-0e1f2a3b4c5d6e7f8",
+			ExpectedScope:  item.Scope,
+		},
+		{
+			ExpectedType:   "outposts-outpost",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:outposts:eu-west-2:052392120703:outpost/op-0ac8aa5d5e0d4a8e1",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestNewHostSource(t *testing.T) {
+	config, account, _ := sources.GetAutoConfig(t)
+
+	source := NewHostSource(config, account, &TestRateLimit)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
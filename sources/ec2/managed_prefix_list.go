@@ -0,0 +1,108 @@
+package ec2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func managedPrefixListInputMapperGet(scope string, query string) (*ec2.DescribeManagedPrefixListsInput, error) {
+	return &ec2.DescribeManagedPrefixListsInput{
+		PrefixListIds: []string{
+			query,
+		},
+	}, nil
+}
+
+func managedPrefixListInputMapperList(scope string) (*ec2.DescribeManagedPrefixListsInput, error) {
+	return &ec2.DescribeManagedPrefixListsInput{}, nil
+}
+
+func managedPrefixListOutputMapper(_ context.Context, _ *ec2.Client, scope string, _ *ec2.DescribeManagedPrefixListsInput, output *ec2.DescribeManagedPrefixListsOutput) ([]*sdp.Item, error) {
+	items := make([]*sdp.Item, 0)
+
+	for _, prefixList := range output.PrefixLists {
+		var err error
+		var attrs *sdp.ItemAttributes
+		attrs, err = sources.ToAttributesCase(prefixList, "tags")
+
+		if err != nil {
+			return nil, &sdp.QueryError{
+				ErrorType:   sdp.QueryError_OTHER,
+				ErrorString: err.Error(),
+				Scope:       scope,
+			}
+		}
+
+		item := sdp.Item{
+			Type:            "ec2-managed-prefix-list",
+			UniqueAttribute: "prefixListId",
+			Scope:           scope,
+			Attributes:      attrs,
+			Tags:            tagsToMap(prefixList.Tags),
+		}
+
+		switch prefixList.State {
+		case types.PrefixListStateCreateInProgress:
+			item.Health = sdp.Health_HEALTH_PENDING.Enum()
+		case types.PrefixListStateCreateComplete:
+			item.Health = sdp.Health_HEALTH_OK.Enum()
+		case types.PrefixListStateCreateFailed:
+			item.Health = sdp.Health_HEALTH_ERROR.Enum()
+		case types.PrefixListStateModifyInProgress:
+			item.Health = sdp.Health_HEALTH_PENDING.Enum()
+		case types.PrefixListStateModifyComplete:
+			item.Health = sdp.Health_HEALTH_OK.Enum()
+		case types.PrefixListStateModifyFailed:
+			item.Health = sdp.Health_HEALTH_ERROR.Enum()
+		case types.PrefixListStateRestoreInProgress:
+			item.Health = sdp.Health_HEALTH_PENDING.Enum()
+		case types.PrefixListStateRestoreComplete:
+			item.Health = sdp.Health_HEALTH_OK.Enum()
+		case types.PrefixListStateRestoreFailed:
+			item.Health = sdp.Health_HEALTH_ERROR.Enum()
+		case types.PrefixListStateDeleteInProgress:
+			item.Health = sdp.Health_HEALTH_WARNING.Enum()
+		case types.PrefixListStateDeleteComplete:
+			item.Health = sdp.Health_HEALTH_UNKNOWN.Enum()
+		case types.PrefixListStateDeleteFailed:
+			item.Health = sdp.Health_HEALTH_ERROR.Enum()
+		}
+
+		items = append(items, &item)
+	}
+
+	return items, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type ec2-managed-prefix-list
+// +overmind:descriptiveType Managed Prefix List
+// +overmind:get Get a managed prefix list by ID
+// +overmind:list List all managed prefix lists
+// +overmind:search Search managed prefix lists by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_ec2_managed_prefix_list.id
+
+func NewManagedPrefixListSource(config aws.Config, accountID string, limit *sources.LimitBucket) *sources.DescribeOnlySource[*ec2.DescribeManagedPrefixListsInput, *ec2.DescribeManagedPrefixListsOutput, *ec2.Client, *ec2.Options] {
+	return &sources.DescribeOnlySource[*ec2.DescribeManagedPrefixListsInput, *ec2.DescribeManagedPrefixListsOutput, *ec2.Client, *ec2.Options]{
+		Config:    config,
+		Client:    ec2.NewFromConfig(config),
+		AccountID: accountID,
+		ItemType:  "ec2-managed-prefix-list",
+		DescribeFunc: func(ctx context.Context, client *ec2.Client, input *ec2.DescribeManagedPrefixListsInput) (*ec2.DescribeManagedPrefixListsOutput, error) {
+			limit.Wait(ctx) // Wait for rate limiting // Wait for late limiting
+			return client.DescribeManagedPrefixLists(ctx, input)
+		},
+		InputMapperGet:  managedPrefixListInputMapperGet,
+		InputMapperList: managedPrefixListInputMapperList,
+		PaginatorBuilder: func(client *ec2.Client, params *ec2.DescribeManagedPrefixListsInput) sources.Paginator[*ec2.DescribeManagedPrefixListsOutput, *ec2.Options] {
+			return ec2.NewDescribeManagedPrefixListsPaginator(client, params)
+		},
+		OutputMapper: managedPrefixListOutputMapper,
+	}
+}
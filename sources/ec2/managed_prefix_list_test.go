@@ -0,0 +1,100 @@
+package ec2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func TestManagedPrefixListInputMapperGet(t *testing.T) {
+	input, err := managedPrefixListInputMapperGet("foo", "bar")
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(input.PrefixListIds) != 1 {
+		t.Fatalf("expected 1 PrefixList ID, got %v", len(input.PrefixListIds))
+	}
+
+	if input.PrefixListIds[0] != "bar" {
+		t.Errorf("expected PrefixList ID to be bar, got %v", input.PrefixListIds[0])
+	}
+}
+
+func TestManagedPrefixListInputMapperList(t *testing.T) {
+	input, err := managedPrefixListInputMapperList("foo")
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(input.Filters) != 0 || len(input.PrefixListIds) != 0 {
+		t.Errorf("non-empty input: %v", input)
+	}
+}
+
+func TestManagedPrefixListOutputMapper(t *testing.T) {
+	output := &ec2.DescribeManagedPrefixListsOutput{
+		PrefixLists: []types.ManagedPrefixList{
+			{
+				AddressFamily:  sources.PtrString("IPv4"),
+				MaxEntries:     sources.PtrInt32(10),
+				OwnerId:        sources.PtrString("052392120703"),
+				PrefixListArn:  sources.PtrString("arn:aws:ec2:eu-west-2:052392120703:prefix-list/pl-7ca54015"),
+				PrefixListId:   sources.PtrString("pl-7ca54015"),
+				PrefixListName: sources.PtrString("office"),
+				State:          types.PrefixListStateCreateComplete,
+				Version:        sources.PtrInt64(1),
+				Tags: []types.Tag{
+					{
+						Key:   sources.PtrString("Name"),
+						Value: sources.PtrString("office"),
+					},
+				},
+			},
+		},
+	}
+
+	items, err := managedPrefixListOutputMapper(context.Background(), nil, "foo", nil, output)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 1 {
+		t.Fatalf("expected 1 item, got %v", len(items))
+	}
+
+	item := items[0]
+
+	if err := item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.UniqueAttributeValue() != "pl-7ca54015" {
+		t.Errorf("expected unique attribute value to be pl-7ca54015, got %v", item.UniqueAttributeValue())
+	}
+
+	if item.GetHealth() != sdp.Health_HEALTH_OK {
+		t.Errorf("expected health to be OK, got %v", item.GetHealth())
+	}
+}
+
+func TestNewManagedPrefixListSource(t *testing.T) {
+	config, account, _ := sources.GetAutoConfig(t)
+
+	source := NewManagedPrefixListSource(config, account, &TestRateLimit)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -85,6 +85,24 @@ func routeTableOutputMapper(_ context.Context, _ *ec2.Client, scope string, _ *e
 		}
 
 		for _, route := range rt.Routes {
+			if route.DestinationPrefixListId != nil {
+				// +overmind:link ec2-managed-prefix-list
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "ec2-managed-prefix-list",
+						Method: sdp.QueryMethod_GET,
+						Query:  *route.DestinationPrefixListId,
+						Scope:  scope,
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Changing the prefix list will change which traffic
+						// the route applies to
+						In: true,
+						// The route table won't affect the prefix list
+						Out: false,
+					},
+				})
+			}
 			if route.GatewayId != nil {
 				if strings.HasPrefix(*route.GatewayId, "igw") {
 					// +overmind:link ec2-internet-gateway
@@ -101,6 +119,21 @@ func routeTableOutputMapper(_ context.Context, _ *ec2.Client, scope string, _ *e
 						},
 					})
 				}
+				if strings.HasPrefix(*route.GatewayId, "vgw") {
+					// +overmind:link ec2-vpn-gateway
+					item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+						Query: &sdp.Query{
+							Type:   "ec2-vpn-gateway",
+							Method: sdp.QueryMethod_GET,
+							Query:  *route.GatewayId,
+							Scope:  scope,
+						},
+						BlastPropagation: &sdp.BlastPropagation{
+							In:  true,
+							Out: true,
+						},
+					})
+				}
 				if strings.HasPrefix(*route.GatewayId, "vpce") {
 					// +overmind:link ec2-vpc-endpoint
 					item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
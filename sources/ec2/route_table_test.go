@@ -189,6 +189,12 @@ func TestRouteTableOutputMapper(t *testing.T) {
 			ExpectedQuery:  "igw-12345",
 			ExpectedScope:  "foo",
 		},
+		{
+			ExpectedType:   "ec2-managed-prefix-list",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "pl-7ca54015",
+			ExpectedScope:  "foo",
+		},
 	}
 
 	tests.Execute(t, item)
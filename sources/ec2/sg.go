@@ -68,6 +68,10 @@ func securityGroupOutputMapper(_ context.Context, _ *ec2.Client, scope string, _
 		item.LinkedItemQueries = append(item.LinkedItemQueries, extractLinkedSecurityGroups(securityGroup.IpPermissions, scope)...)
 		item.LinkedItemQueries = append(item.LinkedItemQueries, extractLinkedSecurityGroups(securityGroup.IpPermissionsEgress, scope)...)
 
+		// +overmind:link ec2-managed-prefix-list
+		item.LinkedItemQueries = append(item.LinkedItemQueries, extractLinkedPrefixLists(securityGroup.IpPermissions, scope)...)
+		item.LinkedItemQueries = append(item.LinkedItemQueries, extractLinkedPrefixLists(securityGroup.IpPermissionsEgress, scope)...)
+
 		items = append(items, &item)
 	}
 
@@ -147,3 +151,33 @@ func extractLinkedSecurityGroups(permissions []types.IpPermission, scope string)
 
 	return requests
 }
+
+// extractLinkedPrefixLists Extracts related managed prefix lists from IP
+// permissions
+func extractLinkedPrefixLists(permissions []types.IpPermission, scope string) []*sdp.LinkedItemQuery {
+	requests := make([]*sdp.LinkedItemQuery, 0)
+
+	for _, permission := range permissions {
+		for _, prefixList := range permission.PrefixListIds {
+			if prefixList.PrefixListId != nil {
+				requests = append(requests, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "ec2-managed-prefix-list",
+						Method: sdp.QueryMethod_GET,
+						Query:  *prefixList.PrefixListId,
+						Scope:  scope,
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Changing the prefix list will change what the
+						// security group allows
+						In: true,
+						// The security group won't affect the prefix list
+						Out: false,
+					},
+				})
+			}
+		}
+	}
+
+	return requests
+}
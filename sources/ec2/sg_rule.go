@@ -81,6 +81,25 @@ func securityGroupRuleOutputMapper(_ context.Context, _ *ec2.Client, scope strin
 			}
 		}
 
+		if securityGroupRule.PrefixListId != nil {
+			// +overmind:link ec2-managed-prefix-list
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "ec2-managed-prefix-list",
+					Method: sdp.QueryMethod_GET,
+					Query:  *securityGroupRule.PrefixListId,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the prefix list will change what the rule
+					// allows
+					In: true,
+					// The rule won't affect the prefix list
+					Out: false,
+				},
+			})
+		}
+
 		items = append(items, &item)
 	}
 
@@ -69,8 +69,12 @@ func TestSecurityGroupOutputMapper(t *testing.T) {
 								CidrIp: sources.PtrString("0.0.0.0/0"),
 							},
 						},
-						Ipv6Ranges:       []types.Ipv6Range{},
-						PrefixListIds:    []types.PrefixListId{},
+						Ipv6Ranges: []types.Ipv6Range{},
+						PrefixListIds: []types.PrefixListId{
+							{
+								PrefixListId: sources.PtrString("pl-7ca54015"),
+							},
+						},
 						UserIdGroupPairs: []types.UserIdGroupPair{},
 					},
 				},
@@ -106,6 +110,12 @@ func TestSecurityGroupOutputMapper(t *testing.T) {
 			ExpectedQuery:  "sg-094e151c9fc5da181",
 			ExpectedScope:  "052392120704.eu-west-2",
 		},
+		{
+			ExpectedType:   "ec2-managed-prefix-list",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "pl-7ca54015",
+			ExpectedScope:  item.Scope,
+		},
 	}
 
 	tests.Execute(t, item)
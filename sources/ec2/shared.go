@@ -1,6 +1,9 @@
 package ec2
 
-import "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+import (
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/overmindtech/sdp-go"
+)
 
 // Converts a slice of tags to a map
 func tagsToMap(tags []types.Tag) map[string]string {
@@ -14,3 +17,19 @@ func tagsToMap(tags []types.Tag) map[string]string {
 
 	return tagsMap
 }
+
+// vpnStateToHealth Converts the state of a site-to-site VPN resource to a
+// health. Customer gateways use the same states but as a plain string
+func vpnStateToHealth(state string) *sdp.Health {
+	switch types.VpnState(state) {
+	case types.VpnStateAvailable:
+		return sdp.Health_HEALTH_OK.Enum()
+	case types.VpnStatePending:
+		return sdp.Health_HEALTH_PENDING.Enum()
+	case types.VpnStateDeleting:
+		return sdp.Health_HEALTH_WARNING.Enum()
+	}
+
+	// Deleted resources have no health
+	return nil
+}
@@ -0,0 +1,281 @@
+package ec2
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/aws-source/sources/iampolicy"
+	"github.com/overmindtech/sdp-go"
+)
+
+// extractEndpointPolicyLinks Parses a VPC endpoint policy and returns links to
+// the resources that it references, such as S3 buckets and DynamoDB tables.
+// Wildcard resources are skipped since they can't be resolved to a specific
+// item
+func extractEndpointPolicyLinks(policyDocument string, scope string) []*sdp.LinkedItemQuery {
+	policy, err := iampolicy.Parse(policyDocument)
+	if err != nil {
+		return []*sdp.LinkedItemQuery{}
+	}
+
+	accountID, _, err := sources.ParseScope(scope)
+	if err != nil {
+		return []*sdp.LinkedItemQuery{}
+	}
+
+	return iampolicy.LinkedItemQueries(policy.ResourceQueries(accountID), &sdp.BlastPropagation{
+		// Changing the resources won't affect the endpoint
+		In: false,
+		// Changing the endpoint policy will affect whether the resource can
+		// be accessed through the endpoint
+		Out: true,
+	})
+}
+
+// isAWSManagedEndpointService Returns whether a VPC endpoint service name
+// refers to a service that AWS runs, such as S3 or SageMaker, rather than an
+// endpoint service owned by a customer. Customer endpoint services are also
+// under com.amazonaws but always have the vpce prefix
+func isAWSManagedEndpointService(serviceName string) bool {
+	if strings.HasPrefix(serviceName, "com.amazonaws.vpce.") {
+		return false
+	}
+
+	return strings.HasPrefix(serviceName, "com.amazonaws.") || strings.HasPrefix(serviceName, "aws.sagemaker.")
+}
+
+func vpcEndpointInputMapperGet(scope string, query string) (*ec2.DescribeVpcEndpointsInput, error) {
+	return &ec2.DescribeVpcEndpointsInput{
+		VpcEndpointIds: []string{
+			query,
+		},
+	}, nil
+}
+
+func vpcEndpointInputMapperList(scope string) (*ec2.DescribeVpcEndpointsInput, error) {
+	return &ec2.DescribeVpcEndpointsInput{}, nil
+}
+
+func vpcEndpointOutputMapper(_ context.Context, _ *ec2.Client, scope string, _ *ec2.DescribeVpcEndpointsInput, output *ec2.DescribeVpcEndpointsOutput) ([]*sdp.Item, error) {
+	items := make([]*sdp.Item, 0)
+
+	for _, endpoint := range output.VpcEndpoints {
+		var err error
+		var attrs *sdp.ItemAttributes
+		attrs, err = sources.ToAttributesCase(endpoint, "tags")
+
+		if err != nil {
+			return nil, &sdp.QueryError{
+				ErrorType:   sdp.QueryError_OTHER,
+				ErrorString: err.Error(),
+				Scope:       scope,
+			}
+		}
+
+		item := sdp.Item{
+			Type:            "ec2-vpc-endpoint",
+			UniqueAttribute: "vpcEndpointId",
+			Scope:           scope,
+			Attributes:      attrs,
+			Tags:            tagsToMap(endpoint.Tags),
+		}
+
+		switch endpoint.State {
+		case types.StatePendingAcceptance:
+			item.Health = sdp.Health_HEALTH_PENDING.Enum()
+		case types.StatePending:
+			item.Health = sdp.Health_HEALTH_PENDING.Enum()
+		case types.StateAvailable:
+			item.Health = sdp.Health_HEALTH_OK.Enum()
+		case types.StateDeleting:
+			item.Health = sdp.Health_HEALTH_WARNING.Enum()
+		case types.StateDeleted:
+			item.Health = sdp.Health_HEALTH_UNKNOWN.Enum()
+		case types.StateRejected:
+			item.Health = sdp.Health_HEALTH_ERROR.Enum()
+		case types.StateFailed:
+			item.Health = sdp.Health_HEALTH_ERROR.Enum()
+		case types.StateExpired:
+			item.Health = sdp.Health_HEALTH_ERROR.Enum()
+		}
+
+		if endpoint.VpcId != nil {
+			// +overmind:link ec2-vpc
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "ec2-vpc",
+					Method: sdp.QueryMethod_GET,
+					Query:  *endpoint.VpcId,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the VPC could affect the endpoint
+					In: true,
+					// Changing the endpoint won't affect the VPC
+					Out: false,
+				},
+			})
+		}
+
+		// AWS-managed services can't be returned by the endpoint service
+		// source, so only customer services are linked
+		if endpoint.ServiceName != nil && !isAWSManagedEndpointService(*endpoint.ServiceName) {
+			// +overmind:link ec2-vpc-endpoint-service
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "ec2-vpc-endpoint-service",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *endpoint.ServiceName,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the service will affect the endpoint
+					In: true,
+					// Changing the endpoint won't affect the service
+					Out: false,
+				},
+			})
+		}
+
+		for _, routeTableID := range endpoint.RouteTableIds {
+			// +overmind:link ec2-route-table
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "ec2-route-table",
+					Method: sdp.QueryMethod_GET,
+					Query:  routeTableID,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Gateway endpoints add routes to the route table, and
+					// changing the route table could remove those routes
+					In:  true,
+					Out: true,
+				},
+			})
+		}
+
+		for _, subnetID := range endpoint.SubnetIds {
+			// +overmind:link ec2-subnet
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "ec2-subnet",
+					Method: sdp.QueryMethod_GET,
+					Query:  subnetID,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the subnet could affect the endpoint
+					In: true,
+					// Changing the endpoint won't affect the subnet
+					Out: false,
+				},
+			})
+		}
+
+		for _, group := range endpoint.Groups {
+			if group.GroupId != nil {
+				// +overmind:link ec2-security-group
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "ec2-security-group",
+						Method: sdp.QueryMethod_GET,
+						Query:  *group.GroupId,
+						Scope:  scope,
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Changing the security group will affect what can
+						// reach the endpoint
+						In: true,
+						// Changing the endpoint won't affect the security
+						// group
+						Out: false,
+					},
+				})
+			}
+		}
+
+		for _, eniID := range endpoint.NetworkInterfaceIds {
+			// +overmind:link ec2-network-interface
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "ec2-network-interface",
+					Method: sdp.QueryMethod_GET,
+					Query:  eniID,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// The endpoint and its interfaces are tightly coupled
+					In:  true,
+					Out: true,
+				},
+			})
+		}
+
+		for _, dnsEntry := range endpoint.DnsEntries {
+			if dnsEntry.DnsName != nil {
+				// Interface endpoints have a wildcard entry, strip that
+				// so that we can actually resolve the name
+				dnsName := strings.TrimPrefix(*dnsEntry.DnsName, "*.")
+
+				// +overmind:link dns
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "dns",
+						Method: sdp.QueryMethod_SEARCH,
+						Query:  dnsName,
+						Scope:  "global",
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// DNS is always linked
+						In:  true,
+						Out: true,
+					},
+				})
+			}
+		}
+
+		if endpoint.PolicyDocument != nil {
+			// +overmind:link s3-bucket
+			// +overmind:link s3-access-point
+			// +overmind:link dynamodb-table
+			item.LinkedItemQueries = append(item.LinkedItemQueries, extractEndpointPolicyLinks(*endpoint.PolicyDocument, scope)...)
+		}
+
+		items = append(items, &item)
+	}
+
+	return items, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type ec2-vpc-endpoint
+// +overmind:descriptiveType VPC Endpoint
+// +overmind:get Get a VPC Endpoint by ID
+// +overmind:list List all VPC Endpoints
+// +overmind:search Search VPC Endpoints by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_vpc_endpoint.id
+
+func NewVpcEndpointSource(config aws.Config, accountID string, limit *sources.LimitBucket) *sources.DescribeOnlySource[*ec2.DescribeVpcEndpointsInput, *ec2.DescribeVpcEndpointsOutput, *ec2.Client, *ec2.Options] {
+	return &sources.DescribeOnlySource[*ec2.DescribeVpcEndpointsInput, *ec2.DescribeVpcEndpointsOutput, *ec2.Client, *ec2.Options]{
+		Config:    config,
+		Client:    ec2.NewFromConfig(config),
+		AccountID: accountID,
+		ItemType:  "ec2-vpc-endpoint",
+		DescribeFunc: func(ctx context.Context, client *ec2.Client, input *ec2.DescribeVpcEndpointsInput) (*ec2.DescribeVpcEndpointsOutput, error) {
+			limit.Wait(ctx) // Wait for rate limiting // Wait for late limiting
+			return client.DescribeVpcEndpoints(ctx, input)
+		},
+		InputMapperGet:  vpcEndpointInputMapperGet,
+		InputMapperList: vpcEndpointInputMapperList,
+		PaginatorBuilder: func(client *ec2.Client, params *ec2.DescribeVpcEndpointsInput) sources.Paginator[*ec2.DescribeVpcEndpointsOutput, *ec2.Options] {
+			return ec2.NewDescribeVpcEndpointsPaginator(client, params)
+		},
+		OutputMapper: vpcEndpointOutputMapper,
+	}
+}
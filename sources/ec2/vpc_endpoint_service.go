@@ -0,0 +1,163 @@
+package ec2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func vpcEndpointServiceInputMapperGet(scope string, query string) (*ec2.DescribeVpcEndpointServiceConfigurationsInput, error) {
+	return &ec2.DescribeVpcEndpointServiceConfigurationsInput{
+		ServiceIds: []string{
+			query,
+		},
+	}, nil
+}
+
+func vpcEndpointServiceInputMapperList(scope string) (*ec2.DescribeVpcEndpointServiceConfigurationsInput, error) {
+	return &ec2.DescribeVpcEndpointServiceConfigurationsInput{}, nil
+}
+
+func vpcEndpointServiceOutputMapper(_ context.Context, _ *ec2.Client, scope string, _ *ec2.DescribeVpcEndpointServiceConfigurationsInput, output *ec2.DescribeVpcEndpointServiceConfigurationsOutput) ([]*sdp.Item, error) {
+	items := make([]*sdp.Item, 0)
+
+	for _, service := range output.ServiceConfigurations {
+		var err error
+		var attrs *sdp.ItemAttributes
+		attrs, err = sources.ToAttributesCase(service, "tags")
+
+		if err != nil {
+			return nil, &sdp.QueryError{
+				ErrorType:   sdp.QueryError_OTHER,
+				ErrorString: err.Error(),
+				Scope:       scope,
+			}
+		}
+
+		item := sdp.Item{
+			Type:            "ec2-vpc-endpoint-service",
+			UniqueAttribute: "serviceId",
+			Scope:           scope,
+			Attributes:      attrs,
+			Tags:            tagsToMap(service.Tags),
+		}
+
+		switch service.ServiceState {
+		case types.ServiceStatePending:
+			item.Health = sdp.Health_HEALTH_PENDING.Enum()
+		case types.ServiceStateAvailable:
+			item.Health = sdp.Health_HEALTH_OK.Enum()
+		case types.ServiceStateDeleting:
+			item.Health = sdp.Health_HEALTH_WARNING.Enum()
+		case types.ServiceStateDeleted:
+			item.Health = sdp.Health_HEALTH_UNKNOWN.Enum()
+		case types.ServiceStateFailed:
+			item.Health = sdp.Health_HEALTH_ERROR.Enum()
+		}
+
+		lbArns := make([]string, 0)
+		lbArns = append(lbArns, service.NetworkLoadBalancerArns...)
+		lbArns = append(lbArns, service.GatewayLoadBalancerArns...)
+
+		for _, lbArn := range lbArns {
+			if a, err := sources.ParseARN(lbArn); err == nil {
+				// +overmind:link elbv2-load-balancer
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "elbv2-load-balancer",
+						Method: sdp.QueryMethod_SEARCH,
+						Query:  lbArn,
+						Scope:  sources.FormatScope(a.AccountID, a.Region),
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// The load balancer is what actually serves the
+						// traffic, so changing it will affect the service
+						In: true,
+						// Changing the service won't affect the load balancer
+						Out: false,
+					},
+				})
+			}
+		}
+
+		for _, dnsName := range service.BaseEndpointDnsNames {
+			// +overmind:link dns
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "dns",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  dnsName,
+					Scope:  "global",
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// DNS is always linked
+					In:  true,
+					Out: true,
+				},
+			})
+		}
+
+		if service.PrivateDnsName != nil {
+			// +overmind:link dns
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "dns",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *service.PrivateDnsName,
+					Scope:  "global",
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// DNS is always linked
+					In:  true,
+					Out: true,
+				},
+			})
+		}
+
+		items = append(items, &item)
+	}
+
+	return items, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type ec2-vpc-endpoint-service
+// +overmind:descriptiveType VPC Endpoint Service
+// +overmind:get Get a VPC Endpoint Service by ID
+// +overmind:list List all VPC Endpoint Services owned by this account
+// +overmind:search Search VPC Endpoint Services by service name
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_vpc_endpoint_service.id
+
+func NewVpcEndpointServiceSource(config aws.Config, accountID string, limit *sources.LimitBucket) *sources.DescribeOnlySource[*ec2.DescribeVpcEndpointServiceConfigurationsInput, *ec2.DescribeVpcEndpointServiceConfigurationsOutput, *ec2.Client, *ec2.Options] {
+	return &sources.DescribeOnlySource[*ec2.DescribeVpcEndpointServiceConfigurationsInput, *ec2.DescribeVpcEndpointServiceConfigurationsOutput, *ec2.Client, *ec2.Options]{
+		Config:    config,
+		Client:    ec2.NewFromConfig(config),
+		AccountID: accountID,
+		ItemType:  "ec2-vpc-endpoint-service",
+		DescribeFunc: func(ctx context.Context, client *ec2.Client, input *ec2.DescribeVpcEndpointServiceConfigurationsInput) (*ec2.DescribeVpcEndpointServiceConfigurationsOutput, error) {
+			limit.Wait(ctx) // Wait for rate limiting // Wait for late limiting
+			return client.DescribeVpcEndpointServiceConfigurations(ctx, input)
+		},
+		InputMapperGet:  vpcEndpointServiceInputMapperGet,
+		InputMapperList: vpcEndpointServiceInputMapperList,
+		InputMapperSearch: func(ctx context.Context, client *ec2.Client, scope, query string) (*ec2.DescribeVpcEndpointServiceConfigurationsInput, error) {
+			return &ec2.DescribeVpcEndpointServiceConfigurationsInput{
+				Filters: []types.Filter{
+					{
+						Name:   sources.PtrString("service-name"),
+						Values: []string{query},
+					},
+				},
+			}, nil
+		},
+		PaginatorBuilder: func(client *ec2.Client, params *ec2.DescribeVpcEndpointServiceConfigurationsInput) sources.Paginator[*ec2.DescribeVpcEndpointServiceConfigurationsOutput, *ec2.Options] {
+			return ec2.NewDescribeVpcEndpointServiceConfigurationsPaginator(client, params)
+		},
+		OutputMapper: vpcEndpointServiceOutputMapper,
+	}
+}
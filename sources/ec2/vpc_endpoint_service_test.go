@@ -0,0 +1,125 @@
+package ec2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func TestVpcEndpointServiceInputMapperGet(t *testing.T) {
+	input, err := vpcEndpointServiceInputMapperGet("foo", "bar")
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(input.ServiceIds) != 1 {
+		t.Fatalf("expected 1 Service ID, got %v", len(input.ServiceIds))
+	}
+
+	if input.ServiceIds[0] != "bar" {
+		t.Errorf("expected Service ID to be bar, got %v", input.ServiceIds[0])
+	}
+}
+
+func TestVpcEndpointServiceInputMapperList(t *testing.T) {
+	input, err := vpcEndpointServiceInputMapperList("foo")
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(input.Filters) != 0 || len(input.ServiceIds) != 0 {
+		t.Errorf("non-empty input: %v", input)
+	}
+}
+
+func TestVpcEndpointServiceOutputMapper(t *testing.T) {
+	output := &ec2.DescribeVpcEndpointServiceConfigurationsOutput{
+		ServiceConfigurations: []types.ServiceConfiguration{
+			{
+				AcceptanceRequired: sources.PtrBool(true),
+				AvailabilityZones: []string{
+					"eu-west-2a",
+				},
+				BaseEndpointDnsNames: []string{
+					"vpce-svc-0a2c3c1b5d2a0b6c1.eu-west-2.vpce.amazonaws.com",
+				},
+				ManagesVpcEndpoints: sources.PtrBool(false),
+				NetworkLoadBalancerArns: []string{
+					"arn:aws:elasticloadbalancing:eu-west-2:052392120703:loadbalancer/net/example/0123456789abcdef",
+				},
+				PrivateDnsName: sources.PtrString("service.example.com"),
+				ServiceId:      sources.PtrString("vpce-svc-0a2c3c1b5d2a0b6c1"),
+				ServiceName:    sources.PtrString("com.amazonaws.vpce.eu-west-2.vpce-svc-0a2c3c1b5d2a0b6c1"),
+				ServiceState:   types.ServiceStateAvailable,
+				ServiceType: []types.ServiceTypeDetail{
+					{
+						ServiceType: types.ServiceTypeInterface,
+					},
+				},
+			},
+		},
+	}
+
+	items, err := vpcEndpointServiceOutputMapper(context.Background(), nil, "052392120703.eu-west-2", nil, output)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, item := range items {
+		if err := item.Validate(); err != nil {
+			t.Error(err)
+		}
+	}
+
+	if len(items) != 1 {
+		t.Fatalf("expected 1 item, got %v", len(items))
+	}
+
+	item := items[0]
+
+	// It doesn't really make sense to test anything other than the linked items
+	// since the attributes are converted automatically
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "elbv2-load-balancer",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:elasticloadbalancing:eu-west-2:052392120703:loadbalancer/net/example/0123456789abcdef",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "dns",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "vpce-svc-0a2c3c1b5d2a0b6c1.eu-west-2.vpce.amazonaws.com",
+			ExpectedScope:  "global",
+		},
+		{
+			ExpectedType:   "dns",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "service.example.com",
+			ExpectedScope:  "global",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestNewVpcEndpointServiceSource(t *testing.T) {
+	config, account, _ := sources.GetAutoConfig(t)
+
+	source := NewVpcEndpointServiceSource(config, account, &TestRateLimit)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
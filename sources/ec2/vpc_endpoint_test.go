@@ -0,0 +1,237 @@
+package ec2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func TestVpcEndpointInputMapperGet(t *testing.T) {
+	input, err := vpcEndpointInputMapperGet("foo", "bar")
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(input.VpcEndpointIds) != 1 {
+		t.Fatalf("expected 1 VpcEndpoint ID, got %v", len(input.VpcEndpointIds))
+	}
+
+	if input.VpcEndpointIds[0] != "bar" {
+		t.Errorf("expected VpcEndpoint ID to be bar, got %v", input.VpcEndpointIds[0])
+	}
+}
+
+func TestVpcEndpointInputMapperList(t *testing.T) {
+	input, err := vpcEndpointInputMapperList("foo")
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(input.Filters) != 0 || len(input.VpcEndpointIds) != 0 {
+		t.Errorf("non-empty input: %v", input)
+	}
+}
+
+func TestVpcEndpointOutputMapper(t *testing.T) {
+	output := &ec2.DescribeVpcEndpointsOutput{
+		VpcEndpoints: []types.VpcEndpoint{
+			{
+				VpcEndpointId:   sources.PtrString("vpce-0d7892e00e573e701"),
+				VpcEndpointType: types.VpcEndpointTypeGateway,
+				VpcId:           sources.PtrString("vpc-0d7892e00e573e701"),
+				ServiceName:     sources.PtrString("com.amazonaws.eu-west-2.s3"),
+				State:           types.StateAvailable,
+				PolicyDocument: sources.PtrString(`{
+					"Version": "2008-10-17",
+					"Statement": [
+						{
+							"Effect": "Allow",
+							"Principal": "*",
+							"Action": "s3:GetObject",
+							"Resource": ["arn:aws:s3:::example-bucket", "arn:aws:s3:::example-bucket/*", "arn:aws:s3:::*"]
+						},
+						{
+							"Effect": "Allow",
+							"Principal": "*",
+							"Action": "dynamodb:GetItem",
+							"Resource": "arn:aws:dynamodb:eu-west-2:052392120703:table/example-table"
+						}
+					]
+				}`),
+				RouteTableIds: []string{
+					"rtb-0b0e42d1431e832bd",
+				},
+				CreationTimestamp: sources.PtrTime(time.Now()),
+				OwnerId:           sources.PtrString("052392120703"),
+			},
+			{
+				VpcEndpointId:   sources.PtrString("vpce-09fcbac4dcf142db3"),
+				VpcEndpointType: types.VpcEndpointTypeInterface,
+				VpcId:           sources.PtrString("vpc-0d7892e00e573e701"),
+				ServiceName:     sources.PtrString("com.amazonaws.vpce.eu-west-2.vpce-svc-0a2c3c1b5d2a0b6c1"),
+				State:           types.StateAvailable,
+				SubnetIds: []string{
+					"subnet-0450a637af9984235",
+				},
+				Groups: []types.SecurityGroupIdentifier{
+					{
+						GroupId:   sources.PtrString("sg-094e151c9fc5da181"),
+						GroupName: sources.PtrString("default"),
+					},
+				},
+				NetworkInterfaceIds: []string{
+					"eni-0c59532b8e10343ae",
+				},
+				DnsEntries: []types.DnsEntry{
+					{
+						DnsName:      sources.PtrString("*.vpce-09fcbac4dcf142db3-abcdefgh.vpce-svc-0a2c3c1b5d2a0b6c1.eu-west-2.vpce.amazonaws.com"),
+						HostedZoneId: sources.PtrString("Z3U2PTZH4Z3U2P"),
+					},
+				},
+				PrivateDnsEnabled: sources.PtrBool(false),
+				OwnerId:           sources.PtrString("052392120703"),
+			},
+		},
+	}
+
+	items, err := vpcEndpointOutputMapper(context.Background(), nil, "052392120703.eu-west-2", nil, output)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, item := range items {
+		if err := item.Validate(); err != nil {
+			t.Error(err)
+		}
+	}
+
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items, got %v", len(items))
+	}
+
+	item := items[0]
+
+	// It doesn't really make sense to test anything other than the linked items
+	// since the attributes are converted automatically
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "ec2-vpc",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "vpc-0d7892e00e573e701",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "ec2-route-table",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "rtb-0b0e42d1431e832bd",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "s3-bucket",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "example-bucket",
+			ExpectedScope:  "052392120703",
+		},
+		{
+			ExpectedType:   "dynamodb-table",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:dynamodb:eu-west-2:052392120703:table/example-table",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+
+	// The bucket is referenced twice and once with a wildcard, so it should
+	// only be linked once
+	var bucketLinks int
+	for _, lir := range item.LinkedItemQueries {
+		if lir.Query.Type == "s3-bucket" {
+			bucketLinks++
+		}
+	}
+
+	if bucketLinks != 1 {
+		t.Errorf("expected 1 s3-bucket link, got %v", bucketLinks)
+	}
+
+	// S3 is run by AWS so there is no endpoint service to link to
+	for _, lir := range item.LinkedItemQueries {
+		if lir.Query.Type == "ec2-vpc-endpoint-service" {
+			t.Errorf("expected no endpoint service link for an AWS service, got %v", lir.Query.Query)
+		}
+	}
+
+	item = items[1]
+
+	tests = sources.QueryTests{
+		{
+			ExpectedType:   "ec2-subnet",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "subnet-0450a637af9984235",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "ec2-security-group",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "sg-094e151c9fc5da181",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "ec2-network-interface",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "eni-0c59532b8e10343ae",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "dns",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "vpce-09fcbac4dcf142db3-abcdefgh.vpce-svc-0a2c3c1b5d2a0b6c1.eu-west-2.vpce.amazonaws.com",
+			ExpectedScope:  "global",
+		},
+		{
+			ExpectedType:   "ec2-vpc-endpoint-service",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "com.amazonaws.vpce.eu-west-2.vpce-svc-0a2c3c1b5d2a0b6c1",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestIsAWSManagedEndpointService(t *testing.T) {
+	tests := map[string]bool{
+		"com.amazonaws.eu-west-2.s3":                              true,
+		"com.amazonaws.eu-west-2.execute-api":                     true,
+		"aws.sagemaker.eu-west-2.notebook":                        true,
+		"com.amazonaws.vpce.eu-west-2.vpce-svc-0a2c3c1b5d2a0b6c1": false,
+	}
+
+	for serviceName, expected := range tests {
+		if actual := isAWSManagedEndpointService(serviceName); actual != expected {
+			t.Errorf("expected %v for %v, got %v", expected, serviceName, actual)
+		}
+	}
+}
+
+func TestNewVpcEndpointSource(t *testing.T) {
+	config, account, _ := sources.GetAutoConfig(t)
+
+	source := NewVpcEndpointSource(config, account, &TestRateLimit)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
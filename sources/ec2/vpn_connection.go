@@ -0,0 +1,200 @@
+package ec2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func vpnConnectionInputMapperGet(scope string, query string) (*ec2.DescribeVpnConnectionsInput, error) {
+	return &ec2.DescribeVpnConnectionsInput{
+		VpnConnectionIds: []string{
+			query,
+		},
+	}, nil
+}
+
+func vpnConnectionInputMapperList(scope string) (*ec2.DescribeVpnConnectionsInput, error) {
+	return &ec2.DescribeVpnConnectionsInput{}, nil
+}
+
+func vpnConnectionOutputMapper(_ context.Context, _ *ec2.Client, scope string, _ *ec2.DescribeVpnConnectionsInput, output *ec2.DescribeVpnConnectionsOutput) ([]*sdp.Item, error) {
+	items := make([]*sdp.Item, 0)
+
+	for _, connection := range output.VpnConnections {
+		// The tunnel options contain the pre-shared keys, which we don't
+		// want to store. The customer gateway configuration also contains
+		// them so is excluded below
+		if connection.Options != nil {
+			options := *connection.Options
+			options.TunnelOptions = make([]types.TunnelOption, len(connection.Options.TunnelOptions))
+
+			for i, tunnel := range connection.Options.TunnelOptions {
+				tunnel.PreSharedKey = nil
+				options.TunnelOptions[i] = tunnel
+			}
+
+			connection.Options = &options
+		}
+
+		attrs, err := sources.ToAttributesCase(connection, "tags", "customerGatewayConfiguration")
+
+		if err != nil {
+			return nil, &sdp.QueryError{
+				ErrorType:   sdp.QueryError_OTHER,
+				ErrorString: err.Error(),
+				Scope:       scope,
+			}
+		}
+
+		item := sdp.Item{
+			Type:            "ec2-vpn-connection",
+			UniqueAttribute: "vpnConnectionId",
+			Scope:           scope,
+			Attributes:      attrs,
+			Tags:            tagsToMap(connection.Tags),
+			Health:          vpnStateToHealth(string(connection.State)),
+		}
+
+		if connection.State == types.VpnStateAvailable && len(connection.VgwTelemetry) > 0 {
+			// A connection with some of its tunnels down has lost
+			// redundancy, one with all of them down isn't passing traffic
+			var up int
+
+			for _, telemetry := range connection.VgwTelemetry {
+				if telemetry.Status == types.TelemetryStatusUp {
+					up++
+				}
+			}
+
+			switch {
+			case up == 0:
+				item.Health = sdp.Health_HEALTH_ERROR.Enum()
+			case up < len(connection.VgwTelemetry):
+				item.Health = sdp.Health_HEALTH_WARNING.Enum()
+			}
+		}
+
+		if connection.CustomerGatewayId != nil {
+			// +overmind:link ec2-customer-gateway
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "ec2-customer-gateway",
+					Method: sdp.QueryMethod_GET,
+					Query:  *connection.CustomerGatewayId,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// The customer gateway is one end of the connection
+					In:  true,
+					Out: true,
+				},
+			})
+		}
+
+		if connection.VpnGatewayId != nil {
+			// +overmind:link ec2-vpn-gateway
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "ec2-vpn-gateway",
+					Method: sdp.QueryMethod_GET,
+					Query:  *connection.VpnGatewayId,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// The gateway is the AWS end of the connection
+					In:  true,
+					Out: true,
+				},
+			})
+		}
+
+		if connection.TransitGatewayId != nil {
+			// +overmind:link ec2-transit-gateway
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "ec2-transit-gateway",
+					Method: sdp.QueryMethod_GET,
+					Query:  *connection.TransitGatewayId,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// The transit gateway is the AWS end of the connection
+					In:  true,
+					Out: true,
+				},
+			})
+		}
+
+		// The outside IPs of the tunnels are reported in both the telemetry
+		// and the tunnel options, so only link to each once
+		outsideIPs := make([]string, 0)
+		seen := make(map[string]bool)
+
+		for _, telemetry := range connection.VgwTelemetry {
+			if telemetry.OutsideIpAddress != nil && !seen[*telemetry.OutsideIpAddress] {
+				seen[*telemetry.OutsideIpAddress] = true
+				outsideIPs = append(outsideIPs, *telemetry.OutsideIpAddress)
+			}
+		}
+
+		if connection.Options != nil {
+			for _, tunnel := range connection.Options.TunnelOptions {
+				if tunnel.OutsideIpAddress != nil && !seen[*tunnel.OutsideIpAddress] {
+					seen[*tunnel.OutsideIpAddress] = true
+					outsideIPs = append(outsideIPs, *tunnel.OutsideIpAddress)
+				}
+			}
+		}
+
+		for _, ip := range outsideIPs {
+			// +overmind:link ip
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "ip",
+					Method: sdp.QueryMethod_GET,
+					Query:  ip,
+					Scope:  "global",
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// IPs are always linked
+					In:  true,
+					Out: true,
+				},
+			})
+		}
+
+		items = append(items, &item)
+	}
+
+	return items, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type ec2-vpn-connection
+// +overmind:descriptiveType Site-to-Site VPN Connection
+// +overmind:get Get a VPN connection by ID
+// +overmind:list List all VPN connections
+// +overmind:search Search VPN connections by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_vpn_connection.id
+
+func NewVpnConnectionSource(config aws.Config, accountID string, limit *sources.LimitBucket) *sources.DescribeOnlySource[*ec2.DescribeVpnConnectionsInput, *ec2.DescribeVpnConnectionsOutput, *ec2.Client, *ec2.Options] {
+	return &sources.DescribeOnlySource[*ec2.DescribeVpnConnectionsInput, *ec2.DescribeVpnConnectionsOutput, *ec2.Client, *ec2.Options]{
+		Config:    config,
+		Client:    ec2.NewFromConfig(config),
+		AccountID: accountID,
+		ItemType:  "ec2-vpn-connection",
+		DescribeFunc: func(ctx context.Context, client *ec2.Client, input *ec2.DescribeVpnConnectionsInput) (*ec2.DescribeVpnConnectionsOutput, error) {
+			limit.Wait(ctx) // Wait for rate limiting
+			return client.DescribeVpnConnections(ctx, input)
+		},
+		InputMapperGet:  vpnConnectionInputMapperGet,
+		InputMapperList: vpnConnectionInputMapperList,
+		OutputMapper:    vpnConnectionOutputMapper,
+	}
+}
@@ -0,0 +1,178 @@
+package ec2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func TestVpnConnectionInputMapperGet(t *testing.T) {
+	input, err := vpnConnectionInputMapperGet("foo", "bar")
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(input.VpnConnectionIds) != 1 {
+		t.Fatalf("expected 1 VpnConnection ID, got %v", len(input.VpnConnectionIds))
+	}
+
+	if input.VpnConnectionIds[0] != "bar" {
+		t.Errorf("expected VpnConnection ID to be bar, got %v", input.VpnConnectionIds[0])
+	}
+}
+
+func TestVpnConnectionInputMapperList(t *testing.T) {
+	input, err := vpnConnectionInputMapperList("foo")
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(input.Filters) != 0 || len(input.VpnConnectionIds) != 0 {
+		t.Errorf("non-empty input: %v", input)
+	}
+}
+
+func TestVpnConnectionOutputMapper(t *testing.T) {
+	output := &ec2.DescribeVpnConnectionsOutput{
+		VpnConnections: []types.VpnConnection{
+			{
+				Category:                     sources.PtrString("VPN"),
+				CustomerGatewayConfiguration: sources.PtrString("<vpn_connection>secret</vpn_connection>"),
+				CustomerGatewayId:            sources.PtrString("cgw-0e11f167"),
+				Options: &types.VpnConnectionOptions{
+					StaticRoutesOnly: sources.PtrBool(false),
+					TunnelOptions: []types.TunnelOption{
+						{
+							OutsideIpAddress: sources.PtrString("3.8.1.1"),
+							PreSharedKey:     sources.PtrString("supersecret1"),
+						},
+						{
+							OutsideIpAddress: sources.PtrString("3.8.1.2"),
+							PreSharedKey:     sources.PtrString("supersecret2"),
+						},
+					},
+				},
+				State:        types.VpnStateAvailable,
+				Type:         types.GatewayTypeIpsec1,
+				VpnGatewayId: sources.PtrString("vgw-8db04f81"),
+				VgwTelemetry: []types.VgwTelemetry{
+					{
+						OutsideIpAddress: sources.PtrString("3.8.1.1"),
+						Status:           types.TelemetryStatusUp,
+					},
+					{
+						OutsideIpAddress: sources.PtrString("3.8.1.2"),
+						Status:           types.TelemetryStatusDown,
+					},
+				},
+				VpnConnectionId: sources.PtrString("vpn-40f41529"),
+				Tags: []types.Tag{
+					{
+						Key:   sources.PtrString("Name"),
+						Value: sources.PtrString("test"),
+					},
+				},
+			},
+		},
+	}
+
+	items, err := vpnConnectionOutputMapper(context.Background(), nil, "foo", nil, output)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, item := range items {
+		if err := item.Validate(); err != nil {
+			t.Error(err)
+		}
+	}
+
+	if len(items) != 1 {
+		t.Fatalf("expected 1 item, got %v", len(items))
+	}
+
+	item := items[0]
+
+	// One of the two tunnels is down
+	if item.GetHealth() != sdp.Health_HEALTH_WARNING {
+		t.Errorf("expected health to be WARNING, got %v", item.GetHealth())
+	}
+
+	if _, err := item.GetAttributes().Get("customerGatewayConfiguration"); err == nil {
+		t.Error("expected customerGatewayConfiguration to be excluded")
+	}
+
+	tunnels, err := item.GetAttributes().Get("options.tunnelOptions")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tunnel := range tunnels.([]interface{}) {
+		if _, ok := tunnel.(map[string]interface{})["preSharedKey"]; ok {
+			t.Error("expected pre-shared key to be removed")
+		}
+	}
+
+	// The original output shouldn't have been modified
+	if output.VpnConnections[0].Options.TunnelOptions[0].PreSharedKey == nil {
+		t.Error("expected original tunnel options to be unchanged")
+	}
+
+	// It doesn't really make sense to test anything other than the linked items
+	// since the attributes are converted automatically
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "ec2-customer-gateway",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "cgw-0e11f167",
+			ExpectedScope:  item.Scope,
+		},
+		{
+			ExpectedType:   "ec2-vpn-gateway",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "vgw-8db04f81",
+			ExpectedScope:  item.Scope,
+		},
+		{
+			ExpectedType:   "ip",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "3.8.1.1",
+			ExpectedScope:  "global",
+		},
+		{
+			ExpectedType:   "ip",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "3.8.1.2",
+			ExpectedScope:  "global",
+		},
+	}
+
+	tests.Execute(t, item)
+
+	// Each IP should only be linked once
+	if len(item.GetLinkedItemQueries()) != 4 {
+		t.Errorf("expected 4 linked item queries, got %v", len(item.GetLinkedItemQueries()))
+	}
+}
+
+func TestNewVpnConnectionSource(t *testing.T) {
+	config, account, _ := sources.GetAutoConfig(t)
+
+	source := NewVpnConnectionSource(config, account, &TestRateLimit)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
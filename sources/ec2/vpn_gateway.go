@@ -0,0 +1,117 @@
+package ec2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func vpnGatewayInputMapperGet(scope string, query string) (*ec2.DescribeVpnGatewaysInput, error) {
+	return &ec2.DescribeVpnGatewaysInput{
+		VpnGatewayIds: []string{
+			query,
+		},
+	}, nil
+}
+
+func vpnGatewayInputMapperList(scope string) (*ec2.DescribeVpnGatewaysInput, error) {
+	return &ec2.DescribeVpnGatewaysInput{}, nil
+}
+
+func vpnGatewayOutputMapper(_ context.Context, _ *ec2.Client, scope string, _ *ec2.DescribeVpnGatewaysInput, output *ec2.DescribeVpnGatewaysOutput) ([]*sdp.Item, error) {
+	items := make([]*sdp.Item, 0)
+
+	for _, gw := range output.VpnGateways {
+		attrs, err := sources.ToAttributesCase(gw, "tags")
+
+		if err != nil {
+			return nil, &sdp.QueryError{
+				ErrorType:   sdp.QueryError_OTHER,
+				ErrorString: err.Error(),
+				Scope:       scope,
+			}
+		}
+
+		item := sdp.Item{
+			Type:            "ec2-vpn-gateway",
+			UniqueAttribute: "vpnGatewayId",
+			Scope:           scope,
+			Attributes:      attrs,
+			Tags:            tagsToMap(gw.Tags),
+			Health:          vpnStateToHealth(string(gw.State)),
+		}
+
+		for _, attachment := range gw.VpcAttachments {
+			if attachment.VpcId != nil && attachment.State != types.AttachmentStatusDetached {
+				// +overmind:link ec2-vpc
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "ec2-vpc",
+						Method: sdp.QueryMethod_GET,
+						Query:  *attachment.VpcId,
+						Scope:  scope,
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Changing the VPC won't affect the gateway
+						In: false,
+						// Changing the gateway will affect traffic in the
+						// VPC
+						Out: true,
+					},
+				})
+			}
+		}
+
+		if gw.VpnGatewayId != nil {
+			// Virtual private gateways can also be used by Direct Connect,
+			// where they are described as virtual gateways with the same ID
+			// +overmind:link directconnect-virtual-gateway
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "directconnect-virtual-gateway",
+					Method: sdp.QueryMethod_GET,
+					Query:  *gw.VpnGatewayId,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// These are the same gateway
+					In:  true,
+					Out: true,
+				},
+			})
+		}
+
+		items = append(items, &item)
+	}
+
+	return items, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type ec2-vpn-gateway
+// +overmind:descriptiveType Virtual Private Gateway
+// +overmind:get Get a virtual private gateway by ID
+// +overmind:list List all virtual private gateways
+// +overmind:search Search virtual private gateways by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_vpn_gateway.id
+
+func NewVpnGatewaySource(config aws.Config, accountID string, limit *sources.LimitBucket) *sources.DescribeOnlySource[*ec2.DescribeVpnGatewaysInput, *ec2.DescribeVpnGatewaysOutput, *ec2.Client, *ec2.Options] {
+	return &sources.DescribeOnlySource[*ec2.DescribeVpnGatewaysInput, *ec2.DescribeVpnGatewaysOutput, *ec2.Client, *ec2.Options]{
+		Config:    config,
+		Client:    ec2.NewFromConfig(config),
+		AccountID: accountID,
+		ItemType:  "ec2-vpn-gateway",
+		DescribeFunc: func(ctx context.Context, client *ec2.Client, input *ec2.DescribeVpnGatewaysInput) (*ec2.DescribeVpnGatewaysOutput, error) {
+			limit.Wait(ctx) // Wait for rate limiting
+			return client.DescribeVpnGateways(ctx, input)
+		},
+		InputMapperGet:  vpnGatewayInputMapperGet,
+		InputMapperList: vpnGatewayInputMapperList,
+		OutputMapper:    vpnGatewayOutputMapper,
+	}
+}
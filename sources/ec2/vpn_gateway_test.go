@@ -0,0 +1,124 @@
+package ec2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func TestVpnGatewayInputMapperGet(t *testing.T) {
+	input, err := vpnGatewayInputMapperGet("foo", "bar")
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(input.VpnGatewayIds) != 1 {
+		t.Fatalf("expected 1 VpnGateway ID, got %v", len(input.VpnGatewayIds))
+	}
+
+	if input.VpnGatewayIds[0] != "bar" {
+		t.Errorf("expected VpnGateway ID to be bar, got %v", input.VpnGatewayIds[0])
+	}
+}
+
+func TestVpnGatewayInputMapperList(t *testing.T) {
+	input, err := vpnGatewayInputMapperList("foo")
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(input.Filters) != 0 || len(input.VpnGatewayIds) != 0 {
+		t.Errorf("non-empty input: %v", input)
+	}
+}
+
+func TestVpnGatewayOutputMapper(t *testing.T) {
+	output := &ec2.DescribeVpnGatewaysOutput{
+		VpnGateways: []types.VpnGateway{
+			{
+				AmazonSideAsn:    sources.PtrInt64(64512),
+				AvailabilityZone: sources.PtrString("eu-west-2a"),
+				State:            types.VpnStateAvailable,
+				Type:             types.GatewayTypeIpsec1,
+				VpcAttachments: []types.VpcAttachment{
+					{
+						State: types.AttachmentStatusAttached,
+						VpcId: sources.PtrString("vpc-0d7892e00e573e701"),
+					},
+					{
+						State: types.AttachmentStatusDetached,
+						VpcId: sources.PtrString("vpc-0a1b2c3d4e5f67890"),
+					},
+				},
+				VpnGatewayId: sources.PtrString("vgw-8db04f81"),
+				Tags: []types.Tag{
+					{
+						Key:   sources.PtrString("Name"),
+						Value: sources.PtrString("test"),
+					},
+				},
+			},
+		},
+	}
+
+	items, err := vpnGatewayOutputMapper(context.Background(), nil, "foo", nil, output)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, item := range items {
+		if err := item.Validate(); err != nil {
+			t.Error(err)
+		}
+	}
+
+	if len(items) != 1 {
+		t.Fatalf("expected 1 item, got %v", len(items))
+	}
+
+	item := items[0]
+
+	if len(item.GetLinkedItemQueries()) != 2 {
+		t.Errorf("expected 2 linked item queries, got %v", len(item.GetLinkedItemQueries()))
+	}
+
+	// It doesn't really make sense to test anything other than the linked items
+	// since the attributes are converted automatically
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "ec2-vpc",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "vpc-0d7892e00e573e701",
+			ExpectedScope:  item.Scope,
+		},
+		{
+			ExpectedType:   "directconnect-virtual-gateway",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "vgw-8db04f81",
+			ExpectedScope:  item.Scope,
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestNewVpnGatewaySource(t *testing.T) {
+	config, account, _ := sources.GetAutoConfig(t)
+
+	source := NewVpnGatewaySource(config, account, &TestRateLimit)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
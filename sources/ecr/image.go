@@ -0,0 +1,216 @@
+package ecr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+// describeImage Describes a single image in a repository, identified by
+// either its digest or a tag
+func describeImage(ctx context.Context, client ECRClient, repositoryName string, imageID types.ImageIdentifier) (*types.ImageDetail, error) {
+	out, err := client.DescribeImages(ctx, &ecr.DescribeImagesInput{
+		RepositoryName: &repositoryName,
+		ImageIds: []types.ImageIdentifier{
+			imageID,
+		},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(out.ImageDetails) != 1 {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: fmt.Sprintf("expected 1 image, got %v", len(out.ImageDetails)),
+		}
+	}
+
+	return &out.ImageDetails[0], nil
+}
+
+// listImages Lists all images in a given repository
+func listImages(ctx context.Context, client ECRClient, repositoryName string) ([]*types.ImageDetail, error) {
+	images := make([]*types.ImageDetail, 0)
+	paginator := ecr.NewDescribeImagesPaginator(client, &ecr.DescribeImagesInput{
+		RepositoryName: &repositoryName,
+	})
+
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range out.ImageDetails {
+			images = append(images, &out.ImageDetails[i])
+		}
+	}
+
+	return images, nil
+}
+
+// imageGetFunc Gets an image by {repositoryName}@{imageDigest}. Tags aren't
+// accepted here since they can be moved between images
+func imageGetFunc(ctx context.Context, client ECRClient, scope, query string) (*types.ImageDetail, error) {
+	repositoryName, digest, found := strings.Cut(query, "@")
+
+	if !found || repositoryName == "" || digest == "" {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: fmt.Sprintf("query %v must be in the format {repositoryName}@{imageDigest}", query),
+		}
+	}
+
+	return describeImage(ctx, client, repositoryName, types.ImageIdentifier{
+		ImageDigest: &digest,
+	})
+}
+
+func imageListFunc(ctx context.Context, client ECRClient, scope string) ([]*types.ImageDetail, error) {
+	images := make([]*types.ImageDetail, 0)
+	paginator := ecr.NewDescribeRepositoriesPaginator(client, &ecr.DescribeRepositoriesInput{})
+
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, repository := range out.Repositories {
+			if repository.RepositoryName == nil {
+				continue
+			}
+
+			repositoryImages, err := listImages(ctx, client, *repository.RepositoryName)
+
+			if err != nil {
+				return nil, err
+			}
+
+			images = append(images, repositoryImages...)
+		}
+	}
+
+	return images, nil
+}
+
+// imageSearchFunc Searches for images by image URI, or by the name or ARN of
+// the repository that they are in. Image URIs can use either a tag or a
+// digest, tags are resolved to the image that they currently point at
+func imageSearchFunc(ctx context.Context, client ECRClient, scope, query string) ([]*types.ImageDetail, error) {
+	if uri, err := parseImageURI(query); err == nil {
+		imageID := types.ImageIdentifier{}
+
+		switch {
+		case uri.Digest != "":
+			imageID.ImageDigest = &uri.Digest
+		case uri.Tag != "":
+			imageID.ImageTag = &uri.Tag
+		default:
+			// Images pulled without a tag use latest
+			imageID.ImageTag = sources.PtrString("latest")
+		}
+
+		image, err := describeImage(ctx, client, uri.RepositoryName, imageID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return []*types.ImageDetail{image}, nil
+	}
+
+	repositoryName := query
+
+	if a, err := sources.ParseARN(query); err == nil {
+		if a.Type() != "repository" {
+			return nil, &sdp.QueryError{
+				ErrorType:   sdp.QueryError_NOTFOUND,
+				ErrorString: fmt.Sprintf("ARN %v is not an ECR repository", query),
+			}
+		}
+
+		repositoryName = a.ResourceID()
+	}
+
+	return listImages(ctx, client, repositoryName)
+}
+
+func imageItemMapper(scope string, awsItem *types.ImageDetail) (*sdp.Item, error) {
+	attributes, err := sources.ToAttributesCase(awsItem)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if awsItem.RepositoryName == nil || awsItem.ImageDigest == nil {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_OTHER,
+			ErrorString: "image is missing repository name or digest",
+		}
+	}
+
+	// Digests are only unique within a repository since the same image can
+	// be pushed to many repositories
+	err = attributes.Set("uniqueName", *awsItem.RepositoryName+"@"+*awsItem.ImageDigest)
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := sdp.Item{
+		Type:            "ecr-image",
+		UniqueAttribute: "uniqueName",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	// +overmind:link ecr-repository
+	item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+		Query: &sdp.Query{
+			Type:   "ecr-repository",
+			Method: sdp.QueryMethod_GET,
+			Query:  *awsItem.RepositoryName,
+			Scope:  scope,
+		},
+		BlastPropagation: &sdp.BlastPropagation{
+			// The repository's lifecycle policy can delete the image
+			In: true,
+			// Changing an image won't affect the repository
+			Out: false,
+		},
+	})
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type ecr-image
+// +overmind:descriptiveType ECR Image
+// +overmind:get Get an image by {repositoryName}@{imageDigest}
+// +overmind:list List all images in all repositories
+// +overmind:search Search for images by image URI, or by repository name or ARN
+// +overmind:group AWS
+
+func NewImageSource(config aws.Config, accountID string, region string) *sources.GetListSource[*types.ImageDetail, ECRClient, *ecr.Options] {
+	return &sources.GetListSource[*types.ImageDetail, ECRClient, *ecr.Options]{
+		ItemType:   "ecr-image",
+		Client:     ecr.NewFromConfig(config),
+		AccountID:  accountID,
+		Region:     region,
+		GetFunc:    imageGetFunc,
+		ListFunc:   imageListFunc,
+		SearchFunc: imageSearchFunc,
+		ItemMapper: imageItemMapper,
+	}
+}
@@ -0,0 +1,122 @@
+package ecr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testECRClient) DescribeImages(ctx context.Context, params *ecr.DescribeImagesInput, optFns ...func(*ecr.Options)) (*ecr.DescribeImagesOutput, error) {
+	image := types.ImageDetail{
+		RepositoryName:         params.RepositoryName,
+		RegistryId:             sources.PtrString("052392120703"),
+		ImageDigest:            sources.PtrString("sha256:0123abcd"),
+		ImageTags:              []string{"v1.2.3", "latest"},
+		ImageSizeInBytes:       sources.PtrInt64(52428800),
+		ImagePushedAt:          sources.PtrTime(time.Now()),
+		ImageManifestMediaType: sources.PtrString("application/vnd.docker.distribution.manifest.v2+json"),
+		ImageScanStatus: &types.ImageScanStatus{
+			Status: types.ScanStatusComplete,
+		},
+		ImageScanFindingsSummary: &types.ImageScanFindingsSummary{
+			FindingSeverityCounts: map[string]int32{
+				"HIGH": 1,
+			},
+		},
+	}
+
+	if len(params.ImageIds) == 0 {
+		return &ecr.DescribeImagesOutput{
+			ImageDetails: []types.ImageDetail{image, image},
+		}, nil
+	}
+
+	return &ecr.DescribeImagesOutput{
+		ImageDetails: []types.ImageDetail{image},
+	}, nil
+}
+
+func TestImageItemMapper(t *testing.T) {
+	image, err := imageGetFunc(context.Background(), testECRClient{}, "052392120703.eu-west-2", "team/orders@sha256:0123abcd")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := imageItemMapper("052392120703.eu-west-2", image)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.UniqueAttributeValue() != "team/orders@sha256:0123abcd" {
+		t.Errorf("expected unique attribute value team/orders@sha256:0123abcd, got %v", item.UniqueAttributeValue())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "ecr-repository",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "team/orders",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestImageGetFuncRequiresDigest(t *testing.T) {
+	_, err := imageGetFunc(context.Background(), testECRClient{}, "052392120703.eu-west-2", "team/orders:v1.2.3")
+
+	if err == nil {
+		t.Error("expected error for a tag query, got nil")
+	}
+}
+
+func TestImageSearchFunc(t *testing.T) {
+	t.Run("with an image URI", func(t *testing.T) {
+		images, err := imageSearchFunc(context.Background(), testECRClient{}, "052392120703.eu-west-2", "052392120703.dkr.ecr.eu-west-2.amazonaws.com/team/orders:v1.2.3")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(images) != 1 || *images[0].RepositoryName != "team/orders" {
+			t.Errorf("expected 1 image in team/orders, got %v", images)
+		}
+	})
+
+	t.Run("with a repository ARN", func(t *testing.T) {
+		images, err := imageSearchFunc(context.Background(), testECRClient{}, "052392120703.eu-west-2", "arn:aws:ecr:eu-west-2:052392120703:repository/team/orders")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(images) != 2 || *images[0].RepositoryName != "team/orders" {
+			t.Errorf("expected 2 images in team/orders, got %v", images)
+		}
+	})
+}
+
+func TestNewImageSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewImageSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
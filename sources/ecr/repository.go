@@ -0,0 +1,293 @@
+package ecr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+type RepositoryDetails struct {
+	Repository *types.Repository
+
+	// The text of the lifecycle policy, if there is one
+	LifecyclePolicy *string
+
+	// The text of the repository policy, if there is one
+	RepositoryPolicy *string
+
+	// The registry's replication rules that apply to this repository
+	ReplicationRules []types.ReplicationRule
+}
+
+// replicationRulesForRepository Returns the replication rules that apply to a
+// given repository. Rules without filters apply to all repositories
+func replicationRulesForRepository(config *types.ReplicationConfiguration, repositoryName string) []types.ReplicationRule {
+	rules := make([]types.ReplicationRule, 0)
+
+	if config == nil {
+		return rules
+	}
+
+	for _, rule := range config.Rules {
+		matches := len(rule.RepositoryFilters) == 0
+
+		for _, filter := range rule.RepositoryFilters {
+			if filter.FilterType == types.RepositoryFilterTypePrefixMatch && filter.Filter != nil && strings.HasPrefix(repositoryName, *filter.Filter) {
+				matches = true
+			}
+		}
+
+		if matches {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules
+}
+
+// getRepositoryDetails Adds the lifecycle policy, repository policy and
+// replication rules to a repository. Repositories without a policy return a
+// not found error, which we ignore
+func getRepositoryDetails(ctx context.Context, client ECRClient, repository types.Repository, replication *types.ReplicationConfiguration) (*RepositoryDetails, error) {
+	details := RepositoryDetails{
+		Repository: &repository,
+	}
+
+	if repository.RepositoryName == nil {
+		return nil, errors.New("repository name was nil")
+	}
+
+	details.ReplicationRules = replicationRulesForRepository(replication, *repository.RepositoryName)
+
+	lifecycle, err := client.GetLifecyclePolicy(ctx, &ecr.GetLifecyclePolicyInput{
+		RepositoryName: repository.RepositoryName,
+		RegistryId:     repository.RegistryId,
+	})
+
+	if err != nil {
+		var notFound *types.LifecyclePolicyNotFoundException
+
+		if !errors.As(err, &notFound) {
+			return nil, err
+		}
+	} else {
+		details.LifecyclePolicy = lifecycle.LifecyclePolicyText
+	}
+
+	policy, err := client.GetRepositoryPolicy(ctx, &ecr.GetRepositoryPolicyInput{
+		RepositoryName: repository.RepositoryName,
+		RegistryId:     repository.RegistryId,
+	})
+
+	if err != nil {
+		var notFound *types.RepositoryPolicyNotFoundException
+
+		if !errors.As(err, &notFound) {
+			return nil, err
+		}
+	} else {
+		details.RepositoryPolicy = policy.PolicyText
+	}
+
+	return &details, nil
+}
+
+// getReplicationConfiguration Gets the replication configuration for the
+// registry. This is set once per registry and applies to all repositories
+func getReplicationConfiguration(ctx context.Context, client ECRClient) (*types.ReplicationConfiguration, error) {
+	out, err := client.DescribeRegistry(ctx, &ecr.DescribeRegistryInput{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return out.ReplicationConfiguration, nil
+}
+
+func repositoryGetFunc(ctx context.Context, client ECRClient, scope, query string) (*RepositoryDetails, error) {
+	out, err := client.DescribeRepositories(ctx, &ecr.DescribeRepositoriesInput{
+		RepositoryNames: []string{
+			query,
+		},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(out.Repositories) != 1 {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: fmt.Sprintf("expected 1 repository, got %v", len(out.Repositories)),
+		}
+	}
+
+	replication, err := getReplicationConfiguration(ctx, client)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return getRepositoryDetails(ctx, client, out.Repositories[0], replication)
+}
+
+func repositoryListFunc(ctx context.Context, client ECRClient, scope string) ([]*RepositoryDetails, error) {
+	replication, err := getReplicationConfiguration(ctx, client)
+
+	if err != nil {
+		return nil, err
+	}
+
+	repositories := make([]*RepositoryDetails, 0)
+	paginator := ecr.NewDescribeRepositoriesPaginator(client, &ecr.DescribeRepositoriesInput{})
+
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, repository := range out.Repositories {
+			details, err := getRepositoryDetails(ctx, client, repository, replication)
+
+			if err != nil {
+				return nil, err
+			}
+
+			repositories = append(repositories, details)
+		}
+	}
+
+	return repositories, nil
+}
+
+func repositoryListTagsFunc(ctx context.Context, repository *RepositoryDetails, client ECRClient) (map[string]string, error) {
+	return tagsByResourceARN(ctx, client, repository.Repository.RepositoryArn), nil
+}
+
+func repositoryItemMapper(scope string, awsItem *RepositoryDetails) (*sdp.Item, error) {
+	enrichedRepository := struct {
+		*types.Repository
+		LifecyclePolicy  *string
+		RepositoryPolicy *string
+		ReplicationRules []types.ReplicationRule
+	}{
+		Repository:       awsItem.Repository,
+		LifecyclePolicy:  awsItem.LifecyclePolicy,
+		RepositoryPolicy: awsItem.RepositoryPolicy,
+		ReplicationRules: awsItem.ReplicationRules,
+	}
+
+	attributes, err := sources.ToAttributesCase(enrichedRepository)
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := sdp.Item{
+		Type:            "ecr-repository",
+		UniqueAttribute: "repositoryName",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	if awsItem.Repository.RepositoryName != nil {
+		// +overmind:link ecr-image
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "ecr-image",
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  *awsItem.Repository.RepositoryName,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Changing the lifecycle policy can delete images
+				Out: true,
+				// Pushing images won't affect the repository
+				In: false,
+			},
+		})
+
+		for _, rule := range awsItem.ReplicationRules {
+			for _, destination := range rule.Destinations {
+				if destination.RegistryId == nil || destination.Region == nil {
+					continue
+				}
+
+				// Replicated repositories have the same name in the
+				// destination registry
+				//
+				// +overmind:link ecr-repository
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "ecr-repository",
+						Method: sdp.QueryMethod_GET,
+						Query:  *awsItem.Repository.RepositoryName,
+						Scope:  sources.FormatScope(*destination.RegistryId, *destination.Region),
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Images pushed here are replicated to the destination
+						Out: true,
+						// The destination doesn't affect the source
+						In: false,
+					},
+				})
+			}
+		}
+	}
+
+	if awsItem.Repository.EncryptionConfiguration != nil && awsItem.Repository.EncryptionConfiguration.KmsKey != nil {
+		if a, err := sources.ParseARN(*awsItem.Repository.EncryptionConfiguration.KmsKey); err == nil {
+			// +overmind:link kms-key
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "kms-key",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *awsItem.Repository.EncryptionConfiguration.KmsKey,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the key will affect whether images can be
+					// pulled
+					In: true,
+					// Changing the repository won't affect the key
+					Out: false,
+				},
+			})
+		}
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type ecr-repository
+// +overmind:descriptiveType ECR Repository
+// +overmind:get Get a repository by name
+// +overmind:list List all repositories
+// +overmind:search Search for a repository by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_ecr_repository.name
+// +overmind:terraform:queryMap aws_ecr_lifecycle_policy.repository
+// +overmind:terraform:queryMap aws_ecr_repository_policy.repository
+
+func NewRepositorySource(config aws.Config, accountID string, region string) *sources.GetListSource[*RepositoryDetails, ECRClient, *ecr.Options] {
+	return &sources.GetListSource[*RepositoryDetails, ECRClient, *ecr.Options]{
+		ItemType:     "ecr-repository",
+		Client:       ecr.NewFromConfig(config),
+		AccountID:    accountID,
+		Region:       region,
+		GetFunc:      repositoryGetFunc,
+		ListFunc:     repositoryListFunc,
+		ListTagsFunc: repositoryListTagsFunc,
+		ItemMapper:   repositoryItemMapper,
+	}
+}
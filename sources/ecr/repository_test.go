@@ -0,0 +1,180 @@
+package ecr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testECRClient) DescribeRepositories(ctx context.Context, params *ecr.DescribeRepositoriesInput, optFns ...func(*ecr.Options)) (*ecr.DescribeRepositoriesOutput, error) {
+	return &ecr.DescribeRepositoriesOutput{
+		Repositories: []types.Repository{
+			{
+				RepositoryName: sources.PtrString("team/orders"),
+				RepositoryArn:  sources.PtrString("arn:aws:ecr:eu-west-2:052392120703:repository/team/orders"),
+				RepositoryUri:  sources.PtrString("052392120703.dkr.ecr.eu-west-2.amazonaws.com/team/orders"),
+				RegistryId:     sources.PtrString("052392120703"),
+				CreatedAt:      sources.PtrTime(time.Now()),
+				EncryptionConfiguration: &types.EncryptionConfiguration{
+					EncryptionType: types.EncryptionTypeKms,
+					KmsKey:         sources.PtrString("arn:aws:kms:eu-west-2:052392120703:key/1234abcd-12ab-34cd-56ef-1234567890ab"), // link
+				},
+				ImageScanningConfiguration: &types.ImageScanningConfiguration{
+					ScanOnPush: true,
+				},
+				ImageTagMutability: types.ImageTagMutabilityImmutable,
+			},
+		},
+	}, nil
+}
+
+func (c testECRClient) DescribeRegistry(ctx context.Context, params *ecr.DescribeRegistryInput, optFns ...func(*ecr.Options)) (*ecr.DescribeRegistryOutput, error) {
+	return &ecr.DescribeRegistryOutput{
+		RegistryId: sources.PtrString("052392120703"),
+		ReplicationConfiguration: &types.ReplicationConfiguration{
+			Rules: []types.ReplicationRule{
+				{
+					Destinations: []types.ReplicationDestination{
+						{
+							Region:     sources.PtrString("eu-west-1"), // link
+							RegistryId: sources.PtrString("052392120703"),
+						},
+					},
+					RepositoryFilters: []types.RepositoryFilter{
+						{
+							Filter:     sources.PtrString("team/"),
+							FilterType: types.RepositoryFilterTypePrefixMatch,
+						},
+					},
+				},
+				{
+					Destinations: []types.ReplicationDestination{
+						{
+							Region:     sources.PtrString("us-east-1"),
+							RegistryId: sources.PtrString("052392120703"),
+						},
+					},
+					RepositoryFilters: []types.RepositoryFilter{
+						{
+							Filter:     sources.PtrString("other/"),
+							FilterType: types.RepositoryFilterTypePrefixMatch,
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func (c testECRClient) GetLifecyclePolicy(ctx context.Context, params *ecr.GetLifecyclePolicyInput, optFns ...func(*ecr.Options)) (*ecr.GetLifecyclePolicyOutput, error) {
+	return &ecr.GetLifecyclePolicyOutput{
+		RepositoryName:      params.RepositoryName,
+		LifecyclePolicyText: sources.PtrString(`{"rules":[{"rulePriority":1,"selection":{"tagStatus":"untagged","countType":"sinceImagePushed","countUnit":"days","countNumber":14},"action":{"type":"expire"}}]}`),
+	}, nil
+}
+
+func (c testECRClient) GetRepositoryPolicy(ctx context.Context, params *ecr.GetRepositoryPolicyInput, optFns ...func(*ecr.Options)) (*ecr.GetRepositoryPolicyOutput, error) {
+	return nil, &types.RepositoryPolicyNotFoundException{
+		Message: sources.PtrString("Repository policy does not exist"),
+	}
+}
+
+func (c testECRClient) ListTagsForResource(ctx context.Context, params *ecr.ListTagsForResourceInput, optFns ...func(*ecr.Options)) (*ecr.ListTagsForResourceOutput, error) {
+	return &ecr.ListTagsForResourceOutput{
+		Tags: []types.Tag{
+			{
+				Key:   sources.PtrString("foo"),
+				Value: sources.PtrString("bar"),
+			},
+		},
+	}, nil
+}
+
+func TestRepositoryItemMapper(t *testing.T) {
+	repository, err := repositoryGetFunc(context.Background(), testECRClient{}, "052392120703.eu-west-2", "team/orders")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if repository.LifecyclePolicy == nil {
+		t.Error("expected lifecycle policy to be set")
+	}
+
+	if repository.RepositoryPolicy != nil {
+		t.Errorf("expected repository policy to be nil, got %v", *repository.RepositoryPolicy)
+	}
+
+	if len(repository.ReplicationRules) != 1 {
+		t.Errorf("expected 1 replication rule to apply, got %v", len(repository.ReplicationRules))
+	}
+
+	item, err := repositoryItemMapper("052392120703.eu-west-2", repository)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "ecr-image",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "team/orders",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "ecr-repository",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "team/orders",
+			ExpectedScope:  "052392120703.eu-west-1",
+		},
+		{
+			ExpectedType:   "kms-key",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:kms:eu-west-2:052392120703:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestRepositoryListTagsFunc(t *testing.T) {
+	repository, err := repositoryGetFunc(context.Background(), testECRClient{}, "052392120703.eu-west-2", "team/orders")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tags, err := repositoryListTagsFunc(context.Background(), repository, testECRClient{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tags["foo"] != "bar" {
+		t.Errorf("expected tag foo=bar, got %v", tags)
+	}
+}
+
+func TestNewRepositorySource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewRepositorySource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -0,0 +1,139 @@
+package ecr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+// ECRClient Represents the client we need to talk to ECR, usually this is
+// *ecr.Client
+type ECRClient interface {
+	DescribeImages(ctx context.Context, params *ecr.DescribeImagesInput, optFns ...func(*ecr.Options)) (*ecr.DescribeImagesOutput, error)
+	DescribeRegistry(ctx context.Context, params *ecr.DescribeRegistryInput, optFns ...func(*ecr.Options)) (*ecr.DescribeRegistryOutput, error)
+	DescribeRepositories(ctx context.Context, params *ecr.DescribeRepositoriesInput, optFns ...func(*ecr.Options)) (*ecr.DescribeRepositoriesOutput, error)
+	GetLifecyclePolicy(ctx context.Context, params *ecr.GetLifecyclePolicyInput, optFns ...func(*ecr.Options)) (*ecr.GetLifecyclePolicyOutput, error)
+	GetRepositoryPolicy(ctx context.Context, params *ecr.GetRepositoryPolicyInput, optFns ...func(*ecr.Options)) (*ecr.GetRepositoryPolicyOutput, error)
+	ListTagsForResource(ctx context.Context, params *ecr.ListTagsForResourceInput, optFns ...func(*ecr.Options)) (*ecr.ListTagsForResourceOutput, error)
+}
+
+// imageURI The parts of an ECR image URI. These are in the format
+// {account}.dkr.ecr.{region}.amazonaws.com/{repositoryName}[:{tag}][@{digest}]
+type imageURI struct {
+	AccountID      string
+	Region         string
+	RepositoryName string
+	Tag            string
+	Digest         string
+}
+
+// parseImageURI Parses an ECR image URI. Returns an error if the URI doesn't
+// point at an ECR registry, since container images can come from anywhere
+func parseImageURI(uri string) (*imageURI, error) {
+	host, path, found := strings.Cut(uri, "/")
+	hostParts := strings.Split(host, ".")
+
+	if !found || len(hostParts) < 6 || hostParts[1] != "dkr" || !strings.HasPrefix(hostParts[2], "ecr") {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: fmt.Sprintf("%v is not an ECR image URI", uri),
+		}
+	}
+
+	parsed := imageURI{
+		AccountID: hostParts[0],
+		Region:    hostParts[3],
+	}
+
+	path, parsed.Digest, _ = strings.Cut(path, "@")
+
+	// Repository names can contain slashes but not colons, so anything after
+	// a colon must be the tag
+	parsed.RepositoryName, parsed.Tag, _ = strings.Cut(path, ":")
+
+	if parsed.RepositoryName == "" {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: fmt.Sprintf("%v does not include a repository name", uri),
+		}
+	}
+
+	return &parsed, nil
+}
+
+// ImageLink Returns a link to the ECR image that a container image URI refers
+// to, or nil if the image isn't stored in ECR. If the digest that the image
+// resolved to is known it should be passed in, since tags can be moved to
+// different images. If the digest isn't known the image will be resolved by
+// searching for the URI
+func ImageLink(uri string, digest *string) *sdp.LinkedItemQuery {
+	parsed, err := parseImageURI(uri)
+
+	if err != nil {
+		return nil
+	}
+
+	if digest != nil && *digest != "" {
+		parsed.Digest = *digest
+	}
+
+	query := &sdp.Query{
+		Type:  "ecr-image",
+		Scope: sources.FormatScope(parsed.AccountID, parsed.Region),
+	}
+
+	if parsed.Digest != "" {
+		query.Method = sdp.QueryMethod_GET
+		query.Query = parsed.RepositoryName + "@" + parsed.Digest
+	} else {
+		query.Method = sdp.QueryMethod_SEARCH
+		query.Query = uri
+	}
+
+	return &sdp.LinkedItemQuery{
+		Query: query,
+		BlastPropagation: &sdp.BlastPropagation{
+			// Deleting or replacing the image will affect whatever is
+			// running it
+			In: true,
+			// Running the image doesn't affect it
+			Out: false,
+		},
+	}
+}
+
+// tagsByResourceARN Returns the tags for a given resource ARN. Errors are
+// converted into the standard error tags rather than being returned
+func tagsByResourceARN(ctx context.Context, client ECRClient, resourceARN *string) map[string]string {
+	if resourceARN == nil {
+		return nil
+	}
+
+	out, err := client.ListTagsForResource(ctx, &ecr.ListTagsForResourceInput{
+		ResourceArn: resourceARN,
+	})
+
+	if err != nil {
+		return sources.HandleTagsError(ctx, err)
+	}
+
+	return tagsToMap(out.Tags)
+}
+
+// tagsToMap Converts a slice of tags to a map
+func tagsToMap(tags []types.Tag) map[string]string {
+	tagsMap := make(map[string]string)
+
+	for _, tag := range tags {
+		if tag.Key != nil && tag.Value != nil {
+			tagsMap[*tag.Key] = *tag.Value
+		}
+	}
+
+	return tagsMap
+}
@@ -0,0 +1,117 @@
+package ecr
+
+import (
+	"testing"
+
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+type testECRClient struct{}
+
+func TestParseImageURI(t *testing.T) {
+	tests := []struct {
+		URI         string
+		Expected    imageURI
+		ExpectError bool
+	}{
+		{
+			URI: "052392120703.dkr.ecr.eu-west-2.amazonaws.com/orders:v1.2.3",
+			Expected: imageURI{
+				AccountID:      "052392120703",
+				Region:         "eu-west-2",
+				RepositoryName: "orders",
+				Tag:            "v1.2.3",
+			},
+		},
+		{
+			URI: "052392120703.dkr.ecr.eu-west-2.amazonaws.com/team/orders@sha256:0123abcd",
+			Expected: imageURI{
+				AccountID:      "052392120703",
+				Region:         "eu-west-2",
+				RepositoryName: "team/orders",
+				Digest:         "sha256:0123abcd",
+			},
+		},
+		{
+			URI: "052392120703.dkr.ecr.eu-west-2.amazonaws.com/orders:latest@sha256:0123abcd",
+			Expected: imageURI{
+				AccountID:      "052392120703",
+				Region:         "eu-west-2",
+				RepositoryName: "orders",
+				Tag:            "latest",
+				Digest:         "sha256:0123abcd",
+			},
+		},
+		{
+			URI: "052392120703.dkr.ecr.eu-west-2.amazonaws.com/orders",
+			Expected: imageURI{
+				AccountID:      "052392120703",
+				Region:         "eu-west-2",
+				RepositoryName: "orders",
+			},
+		},
+		{
+			URI:         "httpd:2.4",
+			ExpectError: true,
+		},
+		{
+			URI:         "public.ecr.aws/nginx/nginx:latest",
+			ExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.URI, func(t *testing.T) {
+			parsed, err := parseImageURI(test.URI)
+
+			if test.ExpectError {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if *parsed != test.Expected {
+				t.Errorf("expected %+v, got %+v", test.Expected, *parsed)
+			}
+		})
+	}
+}
+
+func TestImageLink(t *testing.T) {
+	t.Run("with a tag", func(t *testing.T) {
+		link := ImageLink("052392120703.dkr.ecr.eu-west-2.amazonaws.com/orders:v1.2.3", nil)
+
+		if link == nil {
+			t.Fatal("expected a link, got nil")
+		}
+
+		if link.GetQuery().GetMethod() != sdp.QueryMethod_SEARCH || link.GetQuery().GetScope() != "052392120703.eu-west-2" {
+			t.Errorf("expected SEARCH in 052392120703.eu-west-2, got %v in %v", link.GetQuery().GetMethod(), link.GetQuery().GetScope())
+		}
+	})
+
+	t.Run("with a known digest", func(t *testing.T) {
+		link := ImageLink("052392120703.dkr.ecr.eu-west-2.amazonaws.com/orders:v1.2.3", sources.PtrString("sha256:0123abcd"))
+
+		if link == nil {
+			t.Fatal("expected a link, got nil")
+		}
+
+		if link.GetQuery().GetMethod() != sdp.QueryMethod_GET || link.GetQuery().GetQuery() != "orders@sha256:0123abcd" {
+			t.Errorf("expected GET orders@sha256:0123abcd, got %v %v", link.GetQuery().GetMethod(), link.GetQuery().GetQuery())
+		}
+	})
+
+	t.Run("with an image from Docker Hub", func(t *testing.T) {
+		if link := ImageLink("httpd:2.4", nil); link != nil {
+			t.Errorf("expected no link, got %v", link)
+		}
+	})
+}
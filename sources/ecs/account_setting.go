@@ -0,0 +1,71 @@
+package ecs
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func accountSettingOutputMapper(_ context.Context, _ ECSClient, scope string, _ *ecs.ListAccountSettingsInput, output *ecs.ListAccountSettingsOutput) ([]*sdp.Item, error) {
+	items := make([]*sdp.Item, 0)
+
+	for _, setting := range output.Settings {
+		attributes, err := sources.ToAttributesCase(setting)
+
+		if err != nil {
+			return nil, err
+		}
+
+		item := sdp.Item{
+			Type:            "ecs-account-setting",
+			UniqueAttribute: "name",
+			Scope:           scope,
+			Attributes:      attributes,
+		}
+
+		items = append(items, &item)
+	}
+
+	return items, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type ecs-account-setting
+// +overmind:descriptiveType ECS Account Setting
+// +overmind:get Get the effective value of an account setting by name
+// +overmind:list List the effective values of all account settings
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_ecs_account_setting_default.name
+
+func NewAccountSettingSource(config aws.Config, accountID string) *sources.DescribeOnlySource[*ecs.ListAccountSettingsInput, *ecs.ListAccountSettingsOutput, ECSClient, *ecs.Options] {
+	return &sources.DescribeOnlySource[*ecs.ListAccountSettingsInput, *ecs.ListAccountSettingsOutput, ECSClient, *ecs.Options]{
+		ItemType:  "ecs-account-setting",
+		Config:    config,
+		AccountID: accountID,
+		Client:    ecs.NewFromConfig(config),
+		DescribeFunc: func(ctx context.Context, client ECSClient, input *ecs.ListAccountSettingsInput) (*ecs.ListAccountSettingsOutput, error) {
+			return client.ListAccountSettings(ctx, input)
+		},
+		InputMapperGet: func(scope, query string) (*ecs.ListAccountSettingsInput, error) {
+			// Effective settings take the account default into account when
+			// nothing has been set explicitly
+			return &ecs.ListAccountSettingsInput{
+				Name:              types.SettingName(query),
+				EffectiveSettings: true,
+			}, nil
+		},
+		InputMapperList: func(scope string) (*ecs.ListAccountSettingsInput, error) {
+			return &ecs.ListAccountSettingsInput{
+				EffectiveSettings: true,
+			}, nil
+		},
+		PaginatorBuilder: func(client ECSClient, params *ecs.ListAccountSettingsInput) sources.Paginator[*ecs.ListAccountSettingsOutput, *ecs.Options] {
+			return ecs.NewListAccountSettingsPaginator(client, params)
+		},
+		OutputMapper: accountSettingOutputMapper,
+	}
+}
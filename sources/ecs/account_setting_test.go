@@ -0,0 +1,93 @@
+package ecs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/overmindtech/aws-source/sources"
+)
+
+func (t *TestClient) ListAccountSettings(ctx context.Context, params *ecs.ListAccountSettingsInput, optFns ...func(*ecs.Options)) (*ecs.ListAccountSettingsOutput, error) {
+	settings := []types.Setting{
+		{
+			Name:         types.SettingNameAwsvpcTrunking,
+			Value:        sources.PtrString("enabled"),
+			PrincipalArn: sources.PtrString("arn:aws:iam::052392120703:root"),
+			Type:         types.SettingTypeUser,
+		},
+		{
+			Name:         types.SettingNameContainerInsights,
+			Value:        sources.PtrString("disabled"),
+			PrincipalArn: sources.PtrString("arn:aws:iam::052392120703:root"),
+			Type:         types.SettingTypeAwsManaged,
+		},
+	}
+
+	if params.Name != "" {
+		for _, setting := range settings {
+			if setting.Name == params.Name {
+				return &ecs.ListAccountSettingsOutput{
+					Settings: []types.Setting{setting},
+				}, nil
+			}
+		}
+
+		return &ecs.ListAccountSettingsOutput{}, nil
+	}
+
+	return &ecs.ListAccountSettingsOutput{
+		Settings: settings,
+	}, nil
+}
+
+func TestAccountSettingSource(t *testing.T) {
+	src := NewAccountSettingSource(aws.Config{Region: "eu-west-1"}, "052392120703")
+
+	// Override the client
+	src.Client = &TestClient{}
+
+	scope := "052392120703.eu-west-1"
+
+	items, err := src.List(context.Background(), scope, false)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 2 {
+		t.Errorf("expected 2 items, got %v", len(items))
+	}
+
+	for _, item := range items {
+		if err := item.Validate(); err != nil {
+			t.Error(err)
+		}
+	}
+
+	item, err := src.Get(context.Background(), scope, "awsvpcTrunking", false)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value, _ := item.GetAttributes().Get("value"); value != "enabled" {
+		t.Errorf("expected value enabled, got %v", value)
+	}
+}
+
+func TestNewAccountSettingSource(t *testing.T) {
+	config, account, _ := sources.GetAutoConfig(t)
+
+	source := NewAccountSettingSource(config, account)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
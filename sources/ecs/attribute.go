@@ -0,0 +1,160 @@
+package ecs
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+// clusterName Returns the name of a cluster from either its name or ARN
+func clusterName(cluster string) string {
+	if a, err := sources.ParseARN(cluster); err == nil {
+		return a.ResourceID()
+	}
+
+	return cluster
+}
+
+func attributeOutputMapper(_ context.Context, _ ECSClient, scope string, input *ecs.ListAttributesInput, output *ecs.ListAttributesOutput) ([]*sdp.Item, error) {
+	items := make([]*sdp.Item, 0)
+
+	if input == nil || input.Cluster == nil {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_OTHER,
+			ErrorString: "attributes can only be listed for a given cluster",
+		}
+	}
+
+	cluster := clusterName(*input.Cluster)
+
+	for _, attribute := range output.Attributes {
+		if attribute.TargetId == nil || attribute.Name == nil {
+			continue
+		}
+
+		attributes, err := sources.ToAttributesCase(attribute)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// The target is returned as an ARN, we only want the ID from the end
+		// of it
+		targetID := *attribute.TargetId
+
+		if a, err := sources.ParseARN(targetID); err == nil {
+			sections := strings.Split(a.Resource, "/")
+			targetID = sections[len(sections)-1]
+		}
+
+		// Create unique attribute in the format
+		// {clusterName}/{containerInstanceId}/{attributeName} e.g.
+		// production/50e9bf71ed57450ca56293cc5a042886/ecs.instance-type
+		attributes.Set("id", cluster+"/"+targetID+"/"+*attribute.Name)
+
+		item := sdp.Item{
+			Type:            "ecs-attribute",
+			UniqueAttribute: "id",
+			Scope:           scope,
+			Attributes:      attributes,
+		}
+
+		// +overmind:link ecs-cluster
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "ecs-cluster",
+				Method: sdp.QueryMethod_GET,
+				Query:  cluster,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// The attribute won't be affected by the cluster
+				In: false,
+				// Attributes are used in placement constraints, so
+				// changing them affects where tasks in the cluster run
+				Out: true,
+			},
+		})
+
+		if attribute.TargetType == types.TargetTypeContainerInstance {
+			// +overmind:link ecs-container-instance
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "ecs-container-instance",
+					Method: sdp.QueryMethod_GET,
+					Query:  cluster + "/" + targetID,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// These are tightly linked
+					In:  true,
+					Out: true,
+				},
+			})
+		}
+
+		items = append(items, &item)
+	}
+
+	return items, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type ecs-attribute
+// +overmind:descriptiveType ECS Attribute
+// +overmind:get Get a container instance attribute by {clusterName}/{containerInstanceId}/{attributeName}
+// +overmind:search Search for container instance attributes by cluster name or ARN
+// +overmind:group AWS
+
+func NewAttributeSource(config aws.Config, accountID string) *sources.DescribeOnlySource[*ecs.ListAttributesInput, *ecs.ListAttributesOutput, ECSClient, *ecs.Options] {
+	return &sources.DescribeOnlySource[*ecs.ListAttributesInput, *ecs.ListAttributesOutput, ECSClient, *ecs.Options]{
+		ItemType:  "ecs-attribute",
+		Config:    config,
+		AccountID: accountID,
+		Client:    ecs.NewFromConfig(config),
+		// The API can only filter by attribute name, so we need to filter
+		// down to the container instance ourselves
+		UseListForGet: true,
+		DescribeFunc: func(ctx context.Context, client ECSClient, input *ecs.ListAttributesInput) (*ecs.ListAttributesOutput, error) {
+			return client.ListAttributes(ctx, input)
+		},
+		InputMapperGet: func(scope, query string) (*ecs.ListAttributesInput, error) {
+			sections := strings.SplitN(query, "/", 3)
+
+			if len(sections) != 3 {
+				return nil, &sdp.QueryError{
+					ErrorType:   sdp.QueryError_NOTFOUND,
+					ErrorString: "query must be in the format {clusterName}/{containerInstanceId}/{attributeName}",
+				}
+			}
+
+			return &ecs.ListAttributesInput{
+				Cluster:       &sections[0],
+				TargetType:    types.TargetTypeContainerInstance,
+				AttributeName: &sections[2],
+			}, nil
+		},
+		InputMapperList: func(scope string) (*ecs.ListAttributesInput, error) {
+			return nil, &sdp.QueryError{
+				ErrorType:   sdp.QueryError_NOTFOUND,
+				ErrorString: "list not supported for ecs-attribute, use search",
+			}
+		},
+		InputMapperSearch: func(ctx context.Context, client ECSClient, scope, query string) (*ecs.ListAttributesInput, error) {
+			// Search by cluster
+			return &ecs.ListAttributesInput{
+				Cluster:    &query,
+				TargetType: types.TargetTypeContainerInstance,
+			}, nil
+		},
+		PaginatorBuilder: func(client ECSClient, params *ecs.ListAttributesInput) sources.Paginator[*ecs.ListAttributesOutput, *ecs.Options] {
+			return ecs.NewListAttributesPaginator(client, params)
+		},
+		OutputMapper: attributeOutputMapper,
+	}
+}
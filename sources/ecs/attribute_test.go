@@ -0,0 +1,113 @@
+package ecs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (t *TestClient) ListAttributes(ctx context.Context, params *ecs.ListAttributesInput, optFns ...func(*ecs.Options)) (*ecs.ListAttributesOutput, error) {
+	return &ecs.ListAttributesOutput{
+		Attributes: []types.Attribute{
+			{
+				Name:       sources.PtrString("ecs.instance-type"),
+				Value:      sources.PtrString("t3.large"),
+				TargetId:   sources.PtrString("arn:aws:ecs:eu-west-1:052392120703:container-instance/production/50e9bf71ed57450ca56293cc5a042886"), // link
+				TargetType: types.TargetTypeContainerInstance,
+			},
+			{
+				Name:       sources.PtrString("ecs.instance-type"),
+				Value:      sources.PtrString("t3.large"),
+				TargetId:   sources.PtrString("arn:aws:ecs:eu-west-1:052392120703:container-instance/production/e8b2f7c1a2d34c6f9b0e1d2c3b4a5f6e"),
+				TargetType: types.TargetTypeContainerInstance,
+			},
+		},
+	}, nil
+}
+
+func TestAttributeOutputMapper(t *testing.T) {
+	output, err := (&TestClient{}).ListAttributes(context.Background(), &ecs.ListAttributesInput{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scope := "052392120703.eu-west-1"
+
+	items, err := attributeOutputMapper(context.Background(), &TestClient{}, scope, &ecs.ListAttributesInput{
+		Cluster: sources.PtrString("arn:aws:ecs:eu-west-1:052392120703:cluster/production"),
+	}, output)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items, got %v", len(items))
+	}
+
+	item := items[0]
+
+	if err := item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.UniqueAttributeValue() != "production/50e9bf71ed57450ca56293cc5a042886/ecs.instance-type" {
+		t.Errorf("unexpected unique attribute value %v", item.UniqueAttributeValue())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "ecs-cluster",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "production",
+			ExpectedScope:  scope,
+		},
+		{
+			ExpectedType:   "ecs-container-instance",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "production/50e9bf71ed57450ca56293cc5a042886",
+			ExpectedScope:  scope,
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestAttributeSourceGet(t *testing.T) {
+	src := NewAttributeSource(aws.Config{Region: "eu-west-1"}, "052392120703")
+
+	// Override the client
+	src.Client = &TestClient{}
+
+	// Both container instances have the same attribute, so this relies on
+	// the source filtering down to the right one
+	item, err := src.Get(context.Background(), "052392120703.eu-west-1", "production/e8b2f7c1a2d34c6f9b0e1d2c3b4a5f6e/ecs.instance-type", false)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if item.UniqueAttributeValue() != "production/e8b2f7c1a2d34c6f9b0e1d2c3b4a5f6e/ecs.instance-type" {
+		t.Errorf("unexpected unique attribute value %v", item.UniqueAttributeValue())
+	}
+}
+
+func TestNewAttributeSource(t *testing.T) {
+	config, account, _ := sources.GetAutoConfig(t)
+
+	source := NewAttributeSource(config, account)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
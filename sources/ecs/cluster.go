@@ -114,6 +114,23 @@ func clusterGetFunc(ctx context.Context, client ECSClient, scope string, input *
 					Out: true,
 				},
 			},
+			{
+				Query: &sdp.Query{
+					// +overmind:link ecs-attribute
+					// Search for all container instance attributes in this
+					// cluster
+					Type:   "ecs-attribute",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *cluster.ClusterName,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Attributes affect task placement within the cluster
+					In: true,
+					// The cluster won't affect the attributes
+					Out: false,
+				},
+			},
 		},
 	}
 
@@ -140,6 +140,12 @@ func TestClusterGetFunc(t *testing.T) {
 			ExpectedQuery:  "default",
 			ExpectedScope:  scope,
 		},
+		{
+			ExpectedType:   "ecs-attribute",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "default",
+			ExpectedScope:  scope,
+		},
 	}
 
 	tests.Execute(t, item)
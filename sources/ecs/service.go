@@ -42,8 +42,12 @@ func serviceGetFunc(ctx context.Context, client ECSClient, scope string, input *
 	taskSetIds := make([]string, 0)
 
 	for _, ts := range service.TaskSets {
-		if ts.Id != nil {
-			taskSetIds = append(taskSetIds, *ts.Id)
+		if ts.TaskSetArn != nil {
+			// The task set source uses the ARN's resource ID which is in the
+			// format {clusterName}/{serviceName}/{taskSetId}
+			if a, err := sources.ParseARN(*ts.TaskSetArn); err == nil {
+				taskSetIds = append(taskSetIds, a.ResourceID())
+			}
 		}
 	}
 
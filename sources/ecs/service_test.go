@@ -198,7 +198,8 @@ func (t *TestClient) DescribeServices(ctx context.Context, params *ecs.DescribeS
 					// which is redundant info. We should remove everything
 					// other than the IDs
 					{
-						Id: sources.PtrString("id"), // link, then remove
+						Id:         sources.PtrString("ecs-svc/1234567890123456789"),
+						TaskSetArn: sources.PtrString("arn:aws:ecs:eu-west-1:052392120703:task-set/ecs-template-ECSCluster-8nS0WOLbs3nZ/ecs-template-service-i0mQKzkhDI2C/ecs-svc/1234567890123456789"), // link, then remove
 					},
 				},
 			},
@@ -301,7 +302,7 @@ func TestServiceGetFunc(t *testing.T) {
 		{
 			ExpectedType:   "ecs-task-set",
 			ExpectedMethod: sdp.QueryMethod_GET,
-			ExpectedQuery:  "id",
+			ExpectedQuery:  "ecs-template-ECSCluster-8nS0WOLbs3nZ/ecs-template-service-i0mQKzkhDI2C/ecs-svc/1234567890123456789",
 			ExpectedScope:  "foo",
 		},
 	}
@@ -10,6 +10,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ecs"
 	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
 	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/aws-source/sources/ecr"
 	"github.com/overmindtech/sdp-go"
 )
 
@@ -132,6 +133,15 @@ func taskGetFunc(ctx context.Context, client ECSClient, scope string, input *ecs
 	}
 
 	for _, container := range task.Containers {
+		if container.Image != nil {
+			// The digest is the image that is actually running, even if the
+			// tag has since been moved
+			if link := ecr.ImageLink(*container.Image, container.ImageDigest); link != nil {
+				// +overmind:link ecr-image
+				item.LinkedItemQueries = append(item.LinkedItemQueries, link)
+			}
+		}
+
 		for _, ni := range container.NetworkInterfaces {
 			if ni.Ipv6Address != nil {
 				// +overmind:link ip
@@ -8,6 +8,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ecs"
 	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
 	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/aws-source/sources/ecr"
 	"github.com/overmindtech/sdp-go"
 )
 
@@ -62,6 +63,13 @@ func taskDefinitionGetFunc(ctx context.Context, client ECSClient, scope string,
 	var link *sdp.LinkedItemQuery
 
 	for _, cd := range td.ContainerDefinitions {
+		if cd.Image != nil {
+			if link = ecr.ImageLink(*cd.Image, nil); link != nil {
+				// +overmind:link ecr-image
+				item.LinkedItemQueries = append(item.LinkedItemQueries, link)
+			}
+		}
+
 		for _, secret := range cd.Secrets {
 			link = getSecretLinkedItem(secret)
 
@@ -18,7 +18,7 @@ func (t *TestClient) DescribeTaskDefinition(ctx context.Context, params *ecs.Des
 			ContainerDefinitions: []types.ContainerDefinition{
 				{
 					Name:   sources.PtrString("simple-app"),
-					Image:  sources.PtrString("httpd:2.4"),
+					Image:  sources.PtrString("052392120703.dkr.ecr.eu-west-1.amazonaws.com/simple-app:2.4"), // link
 					Cpu:    10,
 					Memory: sources.PtrInt32(300),
 					Links:  []string{},
@@ -237,6 +237,12 @@ func TestTaskDefinitionGetFunc(t *testing.T) {
 			ExpectedQuery:  "arn:aws:iam:us-east-2:123456789012:role/bar",
 			ExpectedScope:  "123456789012.us-east-2",
 		},
+		{
+			ExpectedType:   "ecr-image",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "052392120703.dkr.ecr.eu-west-1.amazonaws.com/simple-app:2.4",
+			ExpectedScope:  "052392120703.eu-west-1",
+		},
 	}
 
 	tests.Execute(t, item)
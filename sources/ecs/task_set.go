@@ -0,0 +1,295 @@
+package ecs
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+// TaskSetIncludeFields Fields that we want included by default
+var TaskSetIncludeFields = []types.TaskSetField{
+	types.TaskSetFieldTags,
+}
+
+func taskSetOutputMapper(_ context.Context, _ ECSClient, scope string, _ *ecs.DescribeTaskSetsInput, output *ecs.DescribeTaskSetsOutput) ([]*sdp.Item, error) {
+	items := make([]*sdp.Item, 0)
+
+	for _, taskSet := range output.TaskSets {
+		attributes, err := sources.ToAttributesCase(taskSet, "tags")
+
+		if err != nil {
+			return nil, err
+		}
+
+		if taskSet.TaskSetArn == nil {
+			continue
+		}
+
+		a, err := sources.ParseARN(*taskSet.TaskSetArn)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// Create unique attribute in the format
+		// {clusterName}/{serviceName}/{taskSetId} e.g.
+		// production/api/ecs-svc/1234567890123456789
+		attributes.Set("id", a.ResourceID())
+
+		item := sdp.Item{
+			Type:            "ecs-task-set",
+			UniqueAttribute: "id",
+			Scope:           scope,
+			Attributes:      attributes,
+			Tags:            tagsToMap(taskSet.Tags),
+		}
+
+		switch taskSet.StabilityStatus {
+		case types.StabilityStatusSteadyState:
+			item.Health = sdp.Health_HEALTH_OK.Enum()
+		case types.StabilityStatusStabilizing:
+			item.Health = sdp.Health_HEALTH_PENDING.Enum()
+		}
+
+		if taskSet.ServiceArn != nil {
+			if a, err = sources.ParseARN(*taskSet.ServiceArn); err == nil {
+				// +overmind:link ecs-service
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "ecs-service",
+						Method: sdp.QueryMethod_GET,
+						Query:  a.ResourceID(),
+						Scope:  sources.FormatScope(a.AccountID, a.Region),
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// These are tightly linked
+						In:  true,
+						Out: true,
+					},
+				})
+			}
+		}
+
+		if taskSet.ClusterArn != nil {
+			if a, err = sources.ParseARN(*taskSet.ClusterArn); err == nil {
+				// +overmind:link ecs-cluster
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "ecs-cluster",
+						Method: sdp.QueryMethod_SEARCH,
+						Query:  *taskSet.ClusterArn,
+						Scope:  sources.FormatScope(a.AccountID, a.Region),
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Changes to the cluster will affect the task set
+						In: true,
+						// The task set won't affect the cluster
+						Out: false,
+					},
+				})
+			}
+		}
+
+		if taskSet.TaskDefinition != nil {
+			if a, err = sources.ParseARN(*taskSet.TaskDefinition); err == nil {
+				// +overmind:link ecs-task-definition
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "ecs-task-definition",
+						Method: sdp.QueryMethod_SEARCH,
+						Query:  *taskSet.TaskDefinition,
+						Scope:  sources.FormatScope(a.AccountID, a.Region),
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Changing the task definition will affect the task
+						// set
+						In: true,
+						// The task set won't affect the task definition
+						Out: false,
+					},
+				})
+			}
+		}
+
+		for _, lb := range taskSet.LoadBalancers {
+			if lb.TargetGroupArn != nil {
+				if a, err = sources.ParseARN(*lb.TargetGroupArn); err == nil {
+					// +overmind:link elbv2-target-group
+					item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+						Query: &sdp.Query{
+							Type:   "elbv2-target-group",
+							Method: sdp.QueryMethod_SEARCH,
+							Query:  *lb.TargetGroupArn,
+							Scope:  sources.FormatScope(a.AccountID, a.Region),
+						},
+						BlastPropagation: &sdp.BlastPropagation{
+							// These are tightly linked
+							In:  true,
+							Out: true,
+						},
+					})
+				}
+			}
+		}
+
+		for _, sr := range taskSet.ServiceRegistries {
+			if sr.RegistryArn != nil {
+				if a, err = sources.ParseARN(*sr.RegistryArn); err == nil {
+					// +overmind:link servicediscovery-service
+					item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+						Query: &sdp.Query{
+							Type:   "servicediscovery-service",
+							Method: sdp.QueryMethod_SEARCH,
+							Query:  *sr.RegistryArn,
+							Scope:  sources.FormatScope(a.AccountID, a.Region),
+						},
+						BlastPropagation: &sdp.BlastPropagation{
+							// These are tightly linked
+							In:  true,
+							Out: true,
+						},
+					})
+				}
+			}
+		}
+
+		for _, strategy := range taskSet.CapacityProviderStrategy {
+			if strategy.CapacityProvider != nil {
+				// +overmind:link ecs-capacity-provider
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "ecs-capacity-provider",
+						Method: sdp.QueryMethod_GET,
+						Query:  *strategy.CapacityProvider,
+						Scope:  scope,
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Changing the capacity provider will affect the task
+						// set
+						In: true,
+						// The task set won't affect the capacity provider
+						Out: false,
+					},
+				})
+			}
+		}
+
+		if taskSet.NetworkConfiguration != nil && taskSet.NetworkConfiguration.AwsvpcConfiguration != nil {
+			for _, subnet := range taskSet.NetworkConfiguration.AwsvpcConfiguration.Subnets {
+				// +overmind:link ec2-subnet
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "ec2-subnet",
+						Method: sdp.QueryMethod_GET,
+						Query:  subnet,
+						Scope:  scope,
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Changing the subnet will affect the task set
+						In: true,
+						// The task set won't affect the subnet
+						Out: false,
+					},
+				})
+			}
+
+			for _, sg := range taskSet.NetworkConfiguration.AwsvpcConfiguration.SecurityGroups {
+				// +overmind:link ec2-security-group
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "ec2-security-group",
+						Method: sdp.QueryMethod_GET,
+						Query:  sg,
+						Scope:  scope,
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Changing the security group will affect the task
+						// set
+						In: true,
+						// The task set won't affect the security group
+						Out: false,
+					},
+				})
+			}
+		}
+
+		items = append(items, &item)
+	}
+
+	return items, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type ecs-task-set
+// +overmind:descriptiveType ECS Task Set
+// +overmind:get Get a task set by {clusterName}/{serviceName}/{taskSetId}
+// +overmind:search Search for task sets by service ARN
+// +overmind:group AWS
+
+func NewTaskSetSource(config aws.Config, accountID string) *sources.DescribeOnlySource[*ecs.DescribeTaskSetsInput, *ecs.DescribeTaskSetsOutput, ECSClient, *ecs.Options] {
+	return &sources.DescribeOnlySource[*ecs.DescribeTaskSetsInput, *ecs.DescribeTaskSetsOutput, ECSClient, *ecs.Options]{
+		ItemType:  "ecs-task-set",
+		Config:    config,
+		AccountID: accountID,
+		Client:    ecs.NewFromConfig(config),
+		DescribeFunc: func(ctx context.Context, client ECSClient, input *ecs.DescribeTaskSetsInput) (*ecs.DescribeTaskSetsOutput, error) {
+			return client.DescribeTaskSets(ctx, input)
+		},
+		InputMapperGet: func(scope, query string) (*ecs.DescribeTaskSetsInput, error) {
+			// We are using a custom id of
+			// {clusterName}/{serviceName}/{taskSetId}. The task set ID itself
+			// contains a slash e.g. production/api/ecs-svc/1234567890123456789
+			sections := strings.SplitN(query, "/", 3)
+
+			if len(sections) != 3 {
+				return nil, &sdp.QueryError{
+					ErrorType:   sdp.QueryError_NOTFOUND,
+					ErrorString: "query must be in the format {clusterName}/{serviceName}/{taskSetId}",
+				}
+			}
+
+			return &ecs.DescribeTaskSetsInput{
+				Cluster:  &sections[0],
+				Service:  &sections[1],
+				TaskSets: []string{sections[2]},
+				Include:  TaskSetIncludeFields,
+			}, nil
+		},
+		InputMapperList: func(scope string) (*ecs.DescribeTaskSetsInput, error) {
+			return nil, &sdp.QueryError{
+				ErrorType:   sdp.QueryError_NOTFOUND,
+				ErrorString: "list not supported for ecs-task-set, use search",
+			}
+		},
+		InputMapperSearch: func(ctx context.Context, client ECSClient, scope, query string) (*ecs.DescribeTaskSetsInput, error) {
+			// Search by service ARN, which is in the format
+			// arn:aws:ecs:{region}:{account}:service/{clusterName}/{serviceName}
+			a, err := sources.ParseARN(query)
+
+			if err != nil {
+				return nil, err
+			}
+
+			sections := strings.Split(a.ResourceID(), "/")
+
+			if len(sections) != 2 {
+				return nil, &sdp.QueryError{
+					ErrorType:   sdp.QueryError_NOTFOUND,
+					ErrorString: "service ARN must contain the cluster and service name",
+				}
+			}
+
+			return &ecs.DescribeTaskSetsInput{
+				Cluster: &sections[0],
+				Service: &sections[1],
+				Include: TaskSetIncludeFields,
+			}, nil
+		},
+		OutputMapper: taskSetOutputMapper,
+	}
+}
@@ -0,0 +1,172 @@
+package ecs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (t *TestClient) DescribeTaskSets(ctx context.Context, params *ecs.DescribeTaskSetsInput, optFns ...func(*ecs.Options)) (*ecs.DescribeTaskSetsOutput, error) {
+	return &ecs.DescribeTaskSetsOutput{
+		TaskSets: []types.TaskSet{
+			{
+				Id:                   sources.PtrString("ecs-svc/1234567890123456789"),
+				TaskSetArn:           sources.PtrString("arn:aws:ecs:eu-west-1:052392120703:task-set/production/api/ecs-svc/1234567890123456789"),
+				ServiceArn:           sources.PtrString("arn:aws:ecs:eu-west-1:052392120703:service/production/api"), // link
+				ClusterArn:           sources.PtrString("arn:aws:ecs:eu-west-1:052392120703:cluster/production"),     // link
+				ExternalId:           sources.PtrString("blue"),
+				Status:               sources.PtrString("PRIMARY"),
+				TaskDefinition:       sources.PtrString("arn:aws:ecs:eu-west-1:052392120703:task-definition/api:12"), // link
+				ComputedDesiredCount: 2,
+				PendingCount:         0,
+				RunningCount:         2,
+				CreatedAt:            sources.PtrTime(time.Now()),
+				UpdatedAt:            sources.PtrTime(time.Now()),
+				LaunchType:           types.LaunchTypeFargate,
+				PlatformVersion:      sources.PtrString("1.4.0"),
+				NetworkConfiguration: &types.NetworkConfiguration{
+					AwsvpcConfiguration: &types.AwsVpcConfiguration{
+						Subnets:        []string{"subnet-0d7892e00e573e701"}, // link
+						SecurityGroups: []string{"sg-09371b4a54fe7ab38"},     // link
+						AssignPublicIp: types.AssignPublicIpDisabled,
+					},
+				},
+				LoadBalancers: []types.LoadBalancer{
+					{
+						TargetGroupArn: sources.PtrString("arn:aws:elasticloadbalancing:eu-west-1:052392120703:targetgroup/api-blue/0cb7e0a7c7d8f5b5"), // link
+						ContainerName:  sources.PtrString("api"),
+						ContainerPort:  sources.PtrInt32(8080),
+					},
+				},
+				ServiceRegistries: []types.ServiceRegistry{
+					{
+						RegistryArn: sources.PtrString("arn:aws:servicediscovery:eu-west-1:052392120703:service/srv-e4anhexw6fjyc5ok"), // link
+					},
+				},
+				Scale: &types.Scale{
+					Unit:  types.ScaleUnitPercent,
+					Value: 100,
+				},
+				StabilityStatus:   types.StabilityStatusSteadyState,
+				StabilityStatusAt: sources.PtrTime(time.Now()),
+			},
+		},
+	}, nil
+}
+
+func TestTaskSetOutputMapper(t *testing.T) {
+	output, err := (&TestClient{}).DescribeTaskSets(context.Background(), &ecs.DescribeTaskSetsInput{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scope := "052392120703.eu-west-1"
+
+	items, err := taskSetOutputMapper(context.Background(), &TestClient{}, scope, nil, output)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 1 {
+		t.Fatalf("expected 1 item, got %v", len(items))
+	}
+
+	item := items[0]
+
+	if err := item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.UniqueAttributeValue() != "production/api/ecs-svc/1234567890123456789" {
+		t.Errorf("unexpected unique attribute value %v", item.UniqueAttributeValue())
+	}
+
+	if item.GetHealth() != sdp.Health_HEALTH_OK {
+		t.Errorf("expected health OK, got %v", item.GetHealth())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "ecs-service",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "production/api",
+			ExpectedScope:  scope,
+		},
+		{
+			ExpectedType:   "ecs-cluster",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:ecs:eu-west-1:052392120703:cluster/production",
+			ExpectedScope:  scope,
+		},
+		{
+			ExpectedType:   "ecs-task-definition",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:ecs:eu-west-1:052392120703:task-definition/api:12",
+			ExpectedScope:  scope,
+		},
+		{
+			ExpectedType:   "elbv2-target-group",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:elasticloadbalancing:eu-west-1:052392120703:targetgroup/api-blue/0cb7e0a7c7d8f5b5",
+			ExpectedScope:  scope,
+		},
+		{
+			ExpectedType:   "servicediscovery-service",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:servicediscovery:eu-west-1:052392120703:service/srv-e4anhexw6fjyc5ok",
+			ExpectedScope:  scope,
+		},
+		{
+			ExpectedType:   "ec2-subnet",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "subnet-0d7892e00e573e701",
+			ExpectedScope:  scope,
+		},
+		{
+			ExpectedType:   "ec2-security-group",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "sg-09371b4a54fe7ab38",
+			ExpectedScope:  scope,
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestTaskSetSourceSearch(t *testing.T) {
+	src := NewTaskSetSource(aws.Config{Region: "eu-west-1"}, "052392120703")
+
+	// Override the client
+	src.Client = &TestClient{}
+
+	items, err := src.Search(context.Background(), "052392120703.eu-west-1", "arn:aws:ecs:eu-west-1:052392120703:service/production/api", false)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 1 {
+		t.Errorf("expected 1 item, got %v", len(items))
+	}
+}
+
+func TestNewTaskSetSource(t *testing.T) {
+	config, account, _ := sources.GetAutoConfig(t)
+
+	source := NewTaskSetSource(config, account)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -51,7 +51,8 @@ func (t *TestClient) DescribeTasks(ctx context.Context, params *ecs.DescribeTask
 						ContainerArn: sources.PtrString("arn:aws:ecs:eu-west-1:052392120703:container/test-ECSCluster-Bt4SqcM3CURk/2ffd7ed376c841bcb0e6795ddb6e72e2/8f3db814-6b39-4cc0-9d0a-a7d5702175eb"),
 						TaskArn:      sources.PtrString("arn:aws:ecs:eu-west-1:052392120703:task/test-ECSCluster-Bt4SqcM3CURk/2ffd7ed376c841bcb0e6795ddb6e72e2"),
 						Name:         sources.PtrString("simple-app"),
-						Image:        sources.PtrString("httpd:2.4"),
+						Image:        sources.PtrString("052392120703.dkr.ecr.eu-west-1.amazonaws.com/simple-app:2.4"),
+						ImageDigest:  sources.PtrString("sha256:2ebfc3a4bc0da8e3a1ddd8b5c5ef6e8e2b7b1f4e6f4b6c53a1c2f5b9d0e8e7a1"), // link
 						RuntimeId:    sources.PtrString("7316b64efb397cececce7cc5f39c6d48ab454f904cc80009aef5ed01ebdb1333"),
 						LastStatus:   sources.PtrString("RUNNING"),
 						NetworkBindings: []types.NetworkBinding{
@@ -225,6 +226,12 @@ func TestTaskGetFunc(t *testing.T) {
 			ExpectedQuery:  "arn:aws:ecs:eu-west-1:052392120703:task-definition/test-ecs-demo-app:1",
 			ExpectedScope:  "052392120703.eu-west-1",
 		},
+		{
+			ExpectedType:   "ecr-image",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "simple-app@sha256:2ebfc3a4bc0da8e3a1ddd8b5c5ef6e8e2b7b1f4e6f4b6c53a1c2f5b9d0e8e7a1",
+			ExpectedScope:  "052392120703.eu-west-1",
+		},
 	}
 
 	tests.Execute(t, item)
@@ -0,0 +1,142 @@
+package eks
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/aws-source/sources/iampolicy"
+	"github.com/overmindtech/sdp-go"
+)
+
+func accessEntryGetFunc(ctx context.Context, client EKSClient, scope string, input *eks.DescribeAccessEntryInput) (*sdp.Item, error) {
+	out, err := client.DescribeAccessEntry(ctx, input)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if out.AccessEntry == nil {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: "access entry was nil",
+		}
+	}
+
+	entry := out.AccessEntry
+
+	// The access policies are what actually grant permissions within the
+	// cluster, so include them in the item
+	policies := make([]types.AssociatedAccessPolicy, 0)
+
+	paginator := eks.NewListAssociatedAccessPoliciesPaginator(client, &eks.ListAssociatedAccessPoliciesInput{
+		ClusterName:  entry.ClusterName,
+		PrincipalArn: entry.PrincipalArn,
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		policies = append(policies, page.AssociatedAccessPolicies...)
+	}
+
+	attributes, err := sources.ToAttributesCase(struct {
+		*types.AccessEntry
+		AssociatedAccessPolicies []types.AssociatedAccessPolicy
+	}{
+		AccessEntry:              entry,
+		AssociatedAccessPolicies: policies,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	// The uniqueAttributeValue for this is a custom field:
+	// {clusterName}/{principalArn}
+	attributes.Set("uniqueName", (*entry.ClusterName + "/" + *entry.PrincipalArn))
+
+	item := sdp.Item{
+		Type:            "eks-access-entry",
+		UniqueAttribute: "uniqueName",
+		Attributes:      attributes,
+		Scope:           scope,
+		Tags:            entry.Tags,
+	}
+
+	if accountID, _, err := sources.ParseScope(scope); err == nil {
+		if query := iampolicy.ARNQuery(*entry.PrincipalArn, accountID); query != nil {
+			// +overmind:link iam-role
+			// +overmind:link iam-user
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: query,
+				BlastPropagation: &sdp.BlastPropagation{
+					// Deleting the principal will make the entry useless
+					In: true,
+					// The entry controls what the principal can do in the
+					// cluster
+					Out: true,
+				},
+			})
+		}
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type eks-access-entry
+// +overmind:descriptiveType EKS Access Entry
+// +overmind:get Get an access entry by unique name ({clusterName}/{principalArn})
+// +overmind:list List all access entries
+// +overmind:search Search for access entries by cluster name
+// +overmind:group AWS
+
+func NewAccessEntrySource(config aws.Config, accountID string, region string) *sources.AlwaysGetSource[*eks.ListAccessEntriesInput, *eks.ListAccessEntriesOutput, *eks.DescribeAccessEntryInput, *eks.DescribeAccessEntryOutput, EKSClient, *eks.Options] {
+	return &sources.AlwaysGetSource[*eks.ListAccessEntriesInput, *eks.ListAccessEntriesOutput, *eks.DescribeAccessEntryInput, *eks.DescribeAccessEntryOutput, EKSClient, *eks.Options]{
+		ItemType:    "eks-access-entry",
+		Client:      eks.NewFromConfig(config),
+		AccountID:   accountID,
+		Region:      region,
+		DisableList: true,
+		SearchInputMapper: func(scope, query string) (*eks.ListAccessEntriesInput, error) {
+			return &eks.ListAccessEntriesInput{
+				ClusterName: &query,
+			}, nil
+		},
+		GetInputMapper: func(scope, query string) *eks.DescribeAccessEntryInput {
+			// The uniqueAttributeValue for this is a custom field:
+			// {clusterName}/{principalArn}. Principal ARNs can contain
+			// slashes but cluster names can't, so split on the first one
+			clusterName, principalARN, _ := strings.Cut(query, "/")
+
+			return &eks.DescribeAccessEntryInput{
+				ClusterName:  &clusterName,
+				PrincipalArn: &principalARN,
+			}
+		},
+		ListFuncPaginatorBuilder: func(client EKSClient, input *eks.ListAccessEntriesInput) sources.Paginator[*eks.ListAccessEntriesOutput, *eks.Options] {
+			return eks.NewListAccessEntriesPaginator(client, input)
+		},
+		ListFuncOutputMapper: func(output *eks.ListAccessEntriesOutput, input *eks.ListAccessEntriesInput) ([]*eks.DescribeAccessEntryInput, error) {
+			inputs := make([]*eks.DescribeAccessEntryInput, len(output.AccessEntries))
+
+			for i := range output.AccessEntries {
+				inputs[i] = &eks.DescribeAccessEntryInput{
+					ClusterName:  input.ClusterName,
+					PrincipalArn: &output.AccessEntries[i],
+				}
+			}
+
+			return inputs, nil
+		},
+		GetFunc: accessEntryGetFunc,
+	}
+}
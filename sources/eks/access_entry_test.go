@@ -0,0 +1,82 @@
+package eks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+var AccessEntryTestClient = TestClient{
+	DescribeAccessEntryOutput: &eks.DescribeAccessEntryOutput{
+		AccessEntry: &types.AccessEntry{
+			AccessEntryArn: sources.PtrString("arn:aws:eks:eu-west-2:052392120703:access-entry/cluster/role/052392120703/admin/0a1b2c3d-4e5f-6789-abcd-ef0123456789"),
+			ClusterName:    sources.PtrString("cluster"),
+			CreatedAt:      sources.PtrTime(time.Now()),
+			PrincipalArn:   sources.PtrString("arn:aws:iam::052392120703:role/admin"),
+			Tags:           map[string]string{},
+			Type:           sources.PtrString("STANDARD"),
+			Username:       sources.PtrString("arn:aws:sts::052392120703:assumed-role/admin/{{SessionName}}"),
+		},
+	},
+	ListAssociatedAccessPoliciesOutput: &eks.ListAssociatedAccessPoliciesOutput{
+		AssociatedAccessPolicies: []types.AssociatedAccessPolicy{
+			{
+				AccessScope: &types.AccessScope{
+					Type: types.AccessScopeTypeCluster,
+				},
+				AssociatedAt: sources.PtrTime(time.Now()),
+				PolicyArn:    sources.PtrString("arn:aws:eks::aws:cluster-access-policy/AmazonEKSClusterAdminPolicy"),
+			},
+		},
+	},
+}
+
+func TestAccessEntryGetFunc(t *testing.T) {
+	item, err := accessEntryGetFunc(context.Background(), AccessEntryTestClient, "052392120703.eu-west-2", &eks.DescribeAccessEntryInput{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.UniqueAttributeValue() != "cluster/arn:aws:iam::052392120703:role/admin" {
+		t.Errorf("unexpected unique attribute value %v", item.UniqueAttributeValue())
+	}
+
+	if _, err := item.GetAttributes().Get("associatedAccessPolicies"); err != nil {
+		t.Errorf("expected associated access policies: %v", err)
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "iam-role",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:iam::052392120703:role/admin",
+			ExpectedScope:  "052392120703",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestNewAccessEntrySource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewAccessEntrySource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:            source,
+		Timeout:           10 * time.Second,
+		SkipNotFoundCheck: true,
+	}
+
+	test.Run(t)
+}
@@ -17,6 +17,11 @@ type TestClient struct {
 	DescribeIdentityProviderConfigOutput *eks.DescribeIdentityProviderConfigOutput
 	ListNodegroupsOutput                 *eks.ListNodegroupsOutput
 	DescribeNodegroupOutput              *eks.DescribeNodegroupOutput
+	ListAccessEntriesOutput              *eks.ListAccessEntriesOutput
+	DescribeAccessEntryOutput            *eks.DescribeAccessEntryOutput
+	ListAssociatedAccessPoliciesOutput   *eks.ListAssociatedAccessPoliciesOutput
+	ListPodIdentityAssociationsOutput    *eks.ListPodIdentityAssociationsOutput
+	DescribePodIdentityAssociationOutput *eks.DescribePodIdentityAssociationOutput
 }
 
 func (t TestClient) ListClusters(context.Context, *eks.ListClustersInput, ...func(*eks.Options)) (*eks.ListClustersOutput, error) {
@@ -58,3 +63,23 @@ func (t TestClient) ListNodegroups(ctx context.Context, params *eks.ListNodegrou
 func (t TestClient) DescribeNodegroup(ctx context.Context, params *eks.DescribeNodegroupInput, optFns ...func(*eks.Options)) (*eks.DescribeNodegroupOutput, error) {
 	return t.DescribeNodegroupOutput, nil
 }
+
+func (t TestClient) ListAccessEntries(ctx context.Context, params *eks.ListAccessEntriesInput, optFns ...func(*eks.Options)) (*eks.ListAccessEntriesOutput, error) {
+	return t.ListAccessEntriesOutput, nil
+}
+
+func (t TestClient) DescribeAccessEntry(ctx context.Context, params *eks.DescribeAccessEntryInput, optFns ...func(*eks.Options)) (*eks.DescribeAccessEntryOutput, error) {
+	return t.DescribeAccessEntryOutput, nil
+}
+
+func (t TestClient) ListAssociatedAccessPolicies(ctx context.Context, params *eks.ListAssociatedAccessPoliciesInput, optFns ...func(*eks.Options)) (*eks.ListAssociatedAccessPoliciesOutput, error) {
+	return t.ListAssociatedAccessPoliciesOutput, nil
+}
+
+func (t TestClient) ListPodIdentityAssociations(ctx context.Context, params *eks.ListPodIdentityAssociationsInput, optFns ...func(*eks.Options)) (*eks.ListPodIdentityAssociationsOutput, error) {
+	return t.ListPodIdentityAssociationsOutput, nil
+}
+
+func (t TestClient) DescribePodIdentityAssociation(ctx context.Context, params *eks.DescribePodIdentityAssociationInput, optFns ...func(*eks.Options)) (*eks.DescribePodIdentityAssociationOutput, error) {
+	return t.DescribePodIdentityAssociationOutput, nil
+}
@@ -2,15 +2,69 @@ package eks
 
 import (
 	"context"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/eks"
 	"github.com/aws/aws-sdk-go-v2/service/eks/types"
 	"github.com/overmindtech/aws-source/sources"
 	"github.com/overmindtech/sdp-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
-func clusterGetFunc(ctx context.Context, client EKSClient, scope string, input *eks.DescribeClusterInput) (*sdp.Item, error) {
+// clusterNameByOIDCIssuer Finds the name of the cluster with a given OIDC
+// issuer URL. There is no way to look this up directly, so all clusters are
+// described until one matches
+func clusterNameByOIDCIssuer(ctx context.Context, client EKSClient, issuer string) (*string, error) {
+	paginator := eks.NewListClustersPaginator(client, &eks.ListClustersInput{})
+
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range out.Clusters {
+			cluster, err := client.DescribeCluster(ctx, &eks.DescribeClusterInput{
+				Name: &out.Clusters[i],
+			})
+
+			if err != nil {
+				return nil, err
+			}
+
+			if cluster.Cluster == nil || cluster.Cluster.Identity == nil || cluster.Cluster.Identity.Oidc == nil {
+				continue
+			}
+
+			if aws.ToString(cluster.Cluster.Identity.Oidc.Issuer) == issuer {
+				return cluster.Cluster.Name, nil
+			}
+		}
+	}
+
+	return nil, &sdp.QueryError{
+		ErrorType:   sdp.QueryError_NOTFOUND,
+		ErrorString: "no cluster found with OIDC issuer " + issuer,
+	}
+}
+
+func clusterGetFunc(ctx context.Context, client EKSClient, bridge *kubernetesBridge, scope string, input *eks.DescribeClusterInput) (*sdp.Item, error) {
+	if input.Name != nil && strings.HasPrefix(*input.Name, "https://") {
+		// This is an OIDC issuer from a search, resolve it to the cluster
+		name, err := clusterNameByOIDCIssuer(ctx, client, *input.Name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		input = &eks.DescribeClusterInput{
+			Name: name,
+		}
+	}
+
 	output, err := client.DescribeCluster(ctx, input)
 
 	if err != nil {
@@ -81,6 +135,49 @@ func clusterGetFunc(ctx context.Context, client EKSClient, scope string, input *
 					Out: true,
 				},
 			},
+			{
+				Query: &sdp.Query{
+					// +overmind:link eks-access-entry
+					Type:   "eks-access-entry",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *cluster.Name,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Access entries control who can administer the cluster
+					In:  true,
+					Out: true,
+				},
+			},
+			{
+				Query: &sdp.Query{
+					// +overmind:link eks-pod-identity-association
+					Type:   "eks-pod-identity-association",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *cluster.Name,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// These are tightly linked
+					In:  true,
+					Out: true,
+				},
+			},
+			{
+				Query: &sdp.Query{
+					// +overmind:link eks-identity-provider-config
+					Type:   "eks-identity-provider-config",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *cluster.Name,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Identity providers control who can authenticate to the
+					// cluster
+					In:  true,
+					Out: true,
+				},
+			},
 		},
 	}
 
@@ -164,6 +261,29 @@ func clusterGetFunc(ctx context.Context, client EKSClient, scope string, input *
 		})
 	}
 
+	if cluster.Identity != nil && cluster.Identity.Oidc != nil && cluster.Identity.Oidc.Issuer != nil {
+		if accountID, _, err := sources.ParseScope(scope); err == nil {
+			// +overmind:link iam-oidc-provider
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "iam-oidc-provider",
+					Method: sdp.QueryMethod_GET,
+					// The provider's name is the issuer without the scheme
+					Query: strings.TrimPrefix(*cluster.Identity.Oidc.Issuer, "https://"),
+					// IAM is global
+					Scope: sources.FormatScope(accountID, ""),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the provider will affect which pods can assume
+					// IAM roles
+					In: true,
+					// Deleting the cluster will invalidate the provider
+					Out: true,
+				},
+			})
+		}
+	}
+
 	if cluster.ResourcesVpcConfig != nil {
 		if cluster.ResourcesVpcConfig.ClusterSecurityGroupId != nil {
 			// +overmind:link ec2-security-group
@@ -258,6 +378,27 @@ func clusterGetFunc(ctx context.Context, client EKSClient, scope string, input *
 		}
 	}
 
+	// Only active clusters have a Kubernetes API that we can read from
+	if bridge != nil && cluster.Status == types.ClusterStatusActive {
+		// +overmind:link elbv2-load-balancer
+		// +overmind:link elb-load-balancer
+		// +overmind:link iam-role
+		// +overmind:link ec2-volume
+		// +overmind:link efs-file-system
+		links, err := bridge.linkedItemQueries(ctx, cluster, scope)
+
+		if err == nil {
+			item.LinkedItemQueries = append(item.LinkedItemQueries, links...)
+		} else {
+			// The source's principal often won't have been granted access to
+			// the cluster, this shouldn't stop the cluster being returned
+			span := trace.SpanFromContext(ctx)
+			span.AddEvent("Error reading Kubernetes resources", trace.WithAttributes(
+				attribute.String("error", err.Error()),
+			))
+		}
+	}
+
 	return &item, nil
 
 }
@@ -267,12 +408,23 @@ func clusterGetFunc(ctx context.Context, client EKSClient, scope string, input *
 // +overmind:descriptiveType EKS Cluster
 // +overmind:get Get a cluster by name
 // +overmind:list List all clusters
-// +overmind:search Search for clusters by ARN
+// +overmind:search Search for clusters by ARN, or by OIDC issuer URL e.g. https://oidc.eks.eu-west-2.amazonaws.com/id/EXAMPLED539D4633E53DE1B71EXAMPLE
 // +overmind:group AWS
 // +overmind:terraform:queryMap aws_eks_cluster.arn
 // +overmind:terraform:method SEARCH
 
-func NewClusterSource(config aws.Config, accountID string, region string) *sources.AlwaysGetSource[*eks.ListClustersInput, *eks.ListClustersOutput, *eks.DescribeClusterInput, *eks.DescribeClusterOutput, EKSClient, *eks.Options] {
+// NewClusterSource Creates a new EKS cluster source. If kubernetes is true the
+// source will also read Services, Ingresses, ServiceAccounts and
+// PersistentVolumes from inside each cluster, using the configured AWS
+// credentials, so that clusters can be linked to the AWS resources that their
+// workloads use
+func NewClusterSource(config aws.Config, accountID string, region string, kubernetes bool) *sources.AlwaysGetSource[*eks.ListClustersInput, *eks.ListClustersOutput, *eks.DescribeClusterInput, *eks.DescribeClusterOutput, EKSClient, *eks.Options] {
+	var bridge *kubernetesBridge
+
+	if kubernetes {
+		bridge = newKubernetesBridge(config)
+	}
+
 	return &sources.AlwaysGetSource[*eks.ListClustersInput, *eks.ListClustersOutput, *eks.DescribeClusterInput, *eks.DescribeClusterOutput, EKSClient, *eks.Options]{
 		ItemType:  "eks-cluster",
 		Client:    eks.NewFromConfig(config),
@@ -298,6 +450,21 @@ func NewClusterSource(config aws.Config, accountID string, region string) *sourc
 
 			return inputs, nil
 		},
-		GetFunc: clusterGetFunc,
+		AlwaysSearchARNs: true,
+		SearchGetInputMapper: func(scope, query string) (*eks.DescribeClusterInput, error) {
+			if !strings.HasPrefix(query, "https://oidc.eks.") {
+				return nil, &sdp.QueryError{
+					ErrorType:   sdp.QueryError_NOTFOUND,
+					ErrorString: "search query must be an ARN or an EKS OIDC issuer URL",
+				}
+			}
+
+			return &eks.DescribeClusterInput{
+				Name: &query,
+			}, nil
+		},
+		GetFunc: func(ctx context.Context, client EKSClient, scope string, input *eks.DescribeClusterInput) (*sdp.Item, error) {
+			return clusterGetFunc(ctx, client, bridge, scope, input)
+		},
 	}
 }
@@ -108,7 +108,7 @@ var ClusterClient = TestClient{
 }
 
 func TestClusterGetFunc(t *testing.T) {
-	item, err := clusterGetFunc(context.Background(), ClusterClient, "foo", &eks.DescribeClusterInput{})
+	item, err := clusterGetFunc(context.Background(), ClusterClient, nil, "foo", &eks.DescribeClusterInput{})
 
 	if err != nil {
 		t.Error(err)
@@ -204,10 +204,50 @@ func TestClusterGetFunc(t *testing.T) {
 	tests.Execute(t, item)
 }
 
+func TestClusterGetFuncByOIDCIssuer(t *testing.T) {
+	client := ClusterClient
+	client.ListClustersOutput = &eks.ListClustersOutput{
+		Clusters: []string{"dylan"},
+	}
+
+	scope := "801795385023.eu-west-2"
+
+	item, err := clusterGetFunc(context.Background(), client, nil, scope, &eks.DescribeClusterInput{
+		Name: sources.PtrString("https://oidc.eks.eu-west-2.amazonaws.com/id/00D3FF4CC48CBAA9BBC070DAA80BD251"),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if item.UniqueAttributeValue() != "dylan" {
+		t.Errorf("expected cluster dylan, got %v", item.UniqueAttributeValue())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "iam-oidc-provider",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "oidc.eks.eu-west-2.amazonaws.com/id/00D3FF4CC48CBAA9BBC070DAA80BD251",
+			ExpectedScope:  "801795385023",
+		},
+	}
+
+	tests.Execute(t, item)
+
+	_, err = clusterGetFunc(context.Background(), client, nil, scope, &eks.DescribeClusterInput{
+		Name: sources.PtrString("https://oidc.eks.eu-west-2.amazonaws.com/id/NOTFOUND"),
+	})
+
+	if err == nil {
+		t.Error("expected error for unknown issuer")
+	}
+}
+
 func TestNewClusterSource(t *testing.T) {
 	config, account, region := sources.GetAutoConfig(t)
 
-	source := NewClusterSource(config, account, region)
+	source := NewClusterSource(config, account, region, false)
 
 	test := sources.E2ETest{
 		Source:  source,
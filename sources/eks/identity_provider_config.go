@@ -0,0 +1,141 @@
+package eks
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+// identityProviderConfigTypeOIDC The only type of identity provider config
+// that EKS supports
+const identityProviderConfigTypeOIDC = "oidc"
+
+func identityProviderConfigGetFunc(ctx context.Context, client EKSClient, scope string, input *eks.DescribeIdentityProviderConfigInput) (*sdp.Item, error) {
+	out, err := client.DescribeIdentityProviderConfig(ctx, input)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if out.IdentityProviderConfig == nil || out.IdentityProviderConfig.Oidc == nil {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: "identity provider config was nil",
+		}
+	}
+
+	config := out.IdentityProviderConfig.Oidc
+
+	attributes, err := sources.ToAttributesCase(config)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// The uniqueAttributeValue for this is a custom field:
+	// {clusterName}/{identityProviderConfigName}
+	attributes.Set("uniqueName", (*config.ClusterName + "/" + *config.IdentityProviderConfigName))
+
+	item := sdp.Item{
+		Type:            "eks-identity-provider-config",
+		UniqueAttribute: "uniqueName",
+		Attributes:      attributes,
+		Scope:           scope,
+		Tags:            config.Tags,
+	}
+
+	switch config.Status {
+	case types.ConfigStatusCreating:
+		item.Health = sdp.Health_HEALTH_PENDING.Enum()
+	case types.ConfigStatusActive:
+		item.Health = sdp.Health_HEALTH_OK.Enum()
+	case types.ConfigStatusDeleting:
+		item.Health = sdp.Health_HEALTH_WARNING.Enum()
+	}
+
+	if config.IssuerUrl != nil {
+		// +overmind:link http
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "http",
+				Method: sdp.QueryMethod_GET,
+				Query:  *config.IssuerUrl,
+				Scope:  "global",
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// If the issuer is unavailable users can't authenticate to
+				// the cluster
+				In: true,
+				// The config can't affect the issuer
+				Out: false,
+			},
+		})
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type eks-identity-provider-config
+// +overmind:descriptiveType EKS Identity Provider Config
+// +overmind:get Get an identity provider config by unique name ({clusterName}/{identityProviderConfigName})
+// +overmind:list List all identity provider configs
+// +overmind:search Search for identity provider configs by cluster name
+// +overmind:group AWS
+
+func NewIdentityProviderConfigSource(config aws.Config, accountID string, region string) *sources.AlwaysGetSource[*eks.ListIdentityProviderConfigsInput, *eks.ListIdentityProviderConfigsOutput, *eks.DescribeIdentityProviderConfigInput, *eks.DescribeIdentityProviderConfigOutput, EKSClient, *eks.Options] {
+	return &sources.AlwaysGetSource[*eks.ListIdentityProviderConfigsInput, *eks.ListIdentityProviderConfigsOutput, *eks.DescribeIdentityProviderConfigInput, *eks.DescribeIdentityProviderConfigOutput, EKSClient, *eks.Options]{
+		ItemType:    "eks-identity-provider-config",
+		Client:      eks.NewFromConfig(config),
+		AccountID:   accountID,
+		Region:      region,
+		DisableList: true,
+		SearchInputMapper: func(scope, query string) (*eks.ListIdentityProviderConfigsInput, error) {
+			return &eks.ListIdentityProviderConfigsInput{
+				ClusterName: &query,
+			}, nil
+		},
+		GetInputMapper: func(scope, query string) *eks.DescribeIdentityProviderConfigInput {
+			// The uniqueAttributeValue for this is a custom field:
+			// {clusterName}/{identityProviderConfigName}
+			fields := strings.Split(query, "/")
+
+			var clusterName string
+			var configName string
+
+			if len(fields) == 2 {
+				clusterName = fields[0]
+				configName = fields[1]
+			}
+
+			return &eks.DescribeIdentityProviderConfigInput{
+				ClusterName: &clusterName,
+				IdentityProviderConfig: &types.IdentityProviderConfig{
+					Name: &configName,
+					Type: sources.PtrString(identityProviderConfigTypeOIDC),
+				},
+			}
+		},
+		ListFuncPaginatorBuilder: func(client EKSClient, input *eks.ListIdentityProviderConfigsInput) sources.Paginator[*eks.ListIdentityProviderConfigsOutput, *eks.Options] {
+			return eks.NewListIdentityProviderConfigsPaginator(client, input)
+		},
+		ListFuncOutputMapper: func(output *eks.ListIdentityProviderConfigsOutput, input *eks.ListIdentityProviderConfigsInput) ([]*eks.DescribeIdentityProviderConfigInput, error) {
+			inputs := make([]*eks.DescribeIdentityProviderConfigInput, len(output.IdentityProviderConfigs))
+
+			for i := range output.IdentityProviderConfigs {
+				inputs[i] = &eks.DescribeIdentityProviderConfigInput{
+					ClusterName:            input.ClusterName,
+					IdentityProviderConfig: &output.IdentityProviderConfigs[i],
+				}
+			}
+
+			return inputs, nil
+		},
+		GetFunc: identityProviderConfigGetFunc,
+	}
+}
@@ -0,0 +1,75 @@
+package eks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+var IdentityProviderConfigTestClient = TestClient{
+	DescribeIdentityProviderConfigOutput: &eks.DescribeIdentityProviderConfigOutput{
+		IdentityProviderConfig: &types.IdentityProviderConfigResponse{
+			Oidc: &types.OidcIdentityProviderConfig{
+				ClientId:                   sources.PtrString("kubernetes"),
+				ClusterName:                sources.PtrString("cluster"),
+				GroupsClaim:                sources.PtrString("groups"),
+				IdentityProviderConfigArn:  sources.PtrString("arn:aws:eks:eu-west-2:052392120703:identityproviderconfig/cluster/oidc/okta/0a1b2c3d-4e5f-6789-abcd-ef0123456789"),
+				IdentityProviderConfigName: sources.PtrString("okta"),
+				IssuerUrl:                  sources.PtrString("https://example.okta.com"),
+				Status:                     types.ConfigStatusActive,
+				Tags:                       map[string]string{},
+				UsernameClaim:              sources.PtrString("email"),
+			},
+		},
+	},
+}
+
+func TestIdentityProviderConfigGetFunc(t *testing.T) {
+	item, err := identityProviderConfigGetFunc(context.Background(), IdentityProviderConfigTestClient, "foo", &eks.DescribeIdentityProviderConfigInput{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.UniqueAttributeValue() != "cluster/okta" {
+		t.Errorf("expected unique attribute value cluster/okta, got %v", item.UniqueAttributeValue())
+	}
+
+	if item.GetHealth() != sdp.Health_HEALTH_OK {
+		t.Errorf("expected health to be OK, got %v", item.GetHealth())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "http",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "https://example.okta.com",
+			ExpectedScope:  "global",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestNewIdentityProviderConfigSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewIdentityProviderConfigSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:            source,
+		Timeout:           10 * time.Second,
+		SkipNotFoundCheck: true,
+	}
+
+	test.Run(t)
+}
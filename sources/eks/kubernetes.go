@@ -0,0 +1,463 @@
+package eks
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+const (
+	// kubernetesTokenPrefix The prefix that the EKS authenticator expects on
+	// bearer tokens
+	kubernetesTokenPrefix = "k8s-aws-v1."
+	// kubernetesClusterIDHeader The header that binds a token to a cluster
+	kubernetesClusterIDHeader = "x-k8s-aws-id"
+	// kubernetesPageSize The number of objects to request from the Kubernetes
+	// API at once
+	kubernetesPageSize = 500
+	// serviceAccountRoleAnnotation The annotation that IRSA uses to bind a
+	// service account to an IAM role
+	serviceAccountRoleAnnotation = "eks.amazonaws.com/role-arn"
+)
+
+// kubernetesTokenFunc Generates a bearer token for the Kubernetes API of the
+// named cluster
+type kubernetesTokenFunc func(ctx context.Context, clusterName string) (string, error)
+
+// kubernetesBridge Reads objects from inside EKS clusters so that they can be
+// linked to the AWS resources that they use
+type kubernetesBridge struct {
+	token kubernetesTokenFunc
+
+	// HTTP clients for each cluster, keyed by endpoint. These are reused so
+	// that connections are pooled rather than leaked on every request
+	httpClients   map[string]*kubernetesHTTPClient
+	httpClientsMu sync.Mutex
+}
+
+// kubernetesHTTPClient An HTTP client that trusts a cluster's certificate
+// authority
+type kubernetesHTTPClient struct {
+	ca     string
+	client *http.Client
+}
+
+// newKubernetesBridge Creates a bridge that authenticates to clusters using
+// the configured AWS credentials, the same as aws-iam-authenticator
+func newKubernetesBridge(config aws.Config) *kubernetesBridge {
+	return &kubernetesBridge{
+		token: stsTokenFunc(sts.NewPresignClient(sts.NewFromConfig(config))),
+	}
+}
+
+// stsTokenFunc Returns a function that generates tokens by presigning an STS
+// GetCallerIdentity request. EKS verifies the token by sending the request,
+// which tells it who the caller is
+func stsTokenFunc(client *sts.PresignClient) kubernetesTokenFunc {
+	return func(ctx context.Context, clusterName string) (string, error) {
+		request, err := client.PresignGetCallerIdentity(ctx, &sts.GetCallerIdentityInput{}, func(po *sts.PresignOptions) {
+			po.ClientOptions = append(po.ClientOptions, func(o *sts.Options) {
+				o.APIOptions = append(o.APIOptions,
+					smithyhttp.AddHeaderValue(kubernetesClusterIDHeader, clusterName),
+					addPresignExpiry,
+				)
+			})
+		})
+
+		if err != nil {
+			return "", err
+		}
+
+		return kubernetesTokenPrefix + base64.RawURLEncoding.EncodeToString([]byte(request.URL)), nil
+	}
+}
+
+// addPresignExpiry Adds the expiry that EKS requires to the presigned request.
+// This has to be added before the request is signed
+func addPresignExpiry(stack *middleware.Stack) error {
+	return stack.Build.Add(middleware.BuildMiddlewareFunc("AddPresignExpiry", func(ctx context.Context, in middleware.BuildInput, next middleware.BuildHandler) (middleware.BuildOutput, middleware.Metadata, error) {
+		if request, ok := in.Request.(*smithyhttp.Request); ok {
+			query := request.URL.Query()
+			query.Set("X-Amz-Expires", "60")
+			request.URL.RawQuery = query.Encode()
+		}
+
+		return next.HandleBuild(ctx, in)
+	}), middleware.After)
+}
+
+// kubernetesClient Makes requests to the Kubernetes API of a single cluster
+type kubernetesClient struct {
+	endpoint   string
+	token      string
+	httpClient *http.Client
+}
+
+// newKubernetesClient Creates a client for a cluster's Kubernetes API, trusting
+// only the cluster's own certificate authority
+func (b *kubernetesBridge) newKubernetesClient(ctx context.Context, cluster *types.Cluster) (*kubernetesClient, error) {
+	if cluster.Name == nil || cluster.Endpoint == nil || cluster.CertificateAuthority == nil || cluster.CertificateAuthority.Data == nil {
+		return nil, errors.New("cluster does not have a Kubernetes endpoint and certificate authority")
+	}
+
+	httpClient, err := b.httpClient(*cluster.Endpoint, *cluster.CertificateAuthority.Data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := b.token(ctx, *cluster.Name)
+
+	if err != nil {
+		return nil, fmt.Errorf("could not generate Kubernetes token: %w", err)
+	}
+
+	return &kubernetesClient{
+		endpoint:   strings.TrimSuffix(*cluster.Endpoint, "/"),
+		token:      token,
+		httpClient: httpClient,
+	}, nil
+}
+
+// httpClient Returns the HTTP client for a cluster, creating it if needed. If
+// the cluster's certificate authority has changed the old client is replaced
+func (b *kubernetesBridge) httpClient(endpoint string, caData string) (*http.Client, error) {
+	b.httpClientsMu.Lock()
+	defer b.httpClientsMu.Unlock()
+
+	if existing, ok := b.httpClients[endpoint]; ok {
+		if existing.ca == caData {
+			return existing.client, nil
+		}
+
+		existing.client.CloseIdleConnections()
+	}
+
+	ca, err := base64.StdEncoding.DecodeString(caData)
+
+	if err != nil {
+		return nil, fmt.Errorf("could not decode cluster certificate authority: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, errors.New("cluster certificate authority contained no certificates")
+	}
+
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:    pool,
+				MinVersion: tls.VersionTLS12,
+			},
+		},
+	}
+
+	if b.httpClients == nil {
+		b.httpClients = make(map[string]*kubernetesHTTPClient)
+	}
+
+	b.httpClients[endpoint] = &kubernetesHTTPClient{
+		ca:     caData,
+		client: client,
+	}
+
+	return client, nil
+}
+
+// kubernetesList The parts of a Kubernetes list response that we need
+type kubernetesList[T any] struct {
+	Metadata struct {
+		Continue string `json:"continue"`
+	} `json:"metadata"`
+	Items []T `json:"items"`
+}
+
+// listKubernetes Lists all objects at a given API path, following
+// pagination
+func listKubernetes[T any](ctx context.Context, client *kubernetesClient, path string) ([]T, error) {
+	items := make([]T, 0)
+	continueToken := ""
+
+	for {
+		query := url.Values{}
+		query.Set("limit", fmt.Sprint(kubernetesPageSize))
+
+		if continueToken != "" {
+			query.Set("continue", continueToken)
+		}
+
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, client.endpoint+path+"?"+query.Encode(), nil)
+
+		if err != nil {
+			return nil, err
+		}
+
+		request.Header.Set("Authorization", "Bearer "+client.token)
+		request.Header.Set("Accept", "application/json")
+
+		response, err := client.httpClient.Do(request)
+
+		if err != nil {
+			return nil, err
+		}
+
+		var page kubernetesList[T]
+
+		if response.StatusCode != http.StatusOK {
+			response.Body.Close()
+			return nil, fmt.Errorf("listing %v returned %v", path, response.Status)
+		}
+
+		err = json.NewDecoder(response.Body).Decode(&page)
+		response.Body.Close()
+
+		if err != nil {
+			return nil, fmt.Errorf("could not decode %v: %w", path, err)
+		}
+
+		items = append(items, page.Items...)
+
+		if page.Metadata.Continue == "" {
+			return items, nil
+		}
+
+		continueToken = page.Metadata.Continue
+	}
+}
+
+type kubernetesObjectMeta struct {
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+type kubernetesLoadBalancerStatus struct {
+	Ingress []struct {
+		Hostname string `json:"hostname"`
+	} `json:"ingress"`
+}
+
+type kubernetesService struct {
+	Metadata kubernetesObjectMeta `json:"metadata"`
+	Spec     struct {
+		Type string `json:"type"`
+	} `json:"spec"`
+	Status struct {
+		LoadBalancer kubernetesLoadBalancerStatus `json:"loadBalancer"`
+	} `json:"status"`
+}
+
+type kubernetesIngress struct {
+	Metadata kubernetesObjectMeta `json:"metadata"`
+	Status   struct {
+		LoadBalancer kubernetesLoadBalancerStatus `json:"loadBalancer"`
+	} `json:"status"`
+}
+
+type kubernetesServiceAccount struct {
+	Metadata kubernetesObjectMeta `json:"metadata"`
+}
+
+type kubernetesPersistentVolume struct {
+	Metadata kubernetesObjectMeta `json:"metadata"`
+	Spec     struct {
+		AWSElasticBlockStore *struct {
+			VolumeID string `json:"volumeID"`
+		} `json:"awsElasticBlockStore"`
+		CSI *struct {
+			Driver       string `json:"driver"`
+			VolumeHandle string `json:"volumeHandle"`
+		} `json:"csi"`
+	} `json:"spec"`
+}
+
+// linkedItemQueries Reads Services, Ingresses, ServiceAccounts and
+// PersistentVolumes from the cluster and returns links to the AWS resources
+// that they use
+func (b *kubernetesBridge) linkedItemQueries(ctx context.Context, cluster *types.Cluster, scope string) ([]*sdp.LinkedItemQuery, error) {
+	client, err := b.newKubernetesClient(ctx, cluster)
+
+	if err != nil {
+		return nil, err
+	}
+
+	accountID, _, err := sources.ParseScope(scope)
+
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([]*sdp.LinkedItemQuery, 0)
+	seen := make(map[string]bool)
+
+	add := func(query *sdp.Query, blast *sdp.BlastPropagation) {
+		key := query.GetType() + "|" + query.GetScope() + "|" + query.GetQuery()
+
+		if seen[key] {
+			return
+		}
+
+		seen[key] = true
+
+		links = append(links, &sdp.LinkedItemQuery{
+			Query:            query,
+			BlastPropagation: blast,
+		})
+	}
+
+	loadBalancerBlast := func() *sdp.BlastPropagation {
+		return &sdp.BlastPropagation{
+			// The load balancer serves the cluster's workloads
+			In: true,
+			// The load balancer is managed from inside the cluster
+			Out: true,
+		}
+	}
+
+	services, err := listKubernetes[kubernetesService](ctx, client, "/api/v1/services")
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, service := range services {
+		if service.Spec.Type != "LoadBalancer" {
+			continue
+		}
+
+		for _, ingress := range service.Status.LoadBalancer.Ingress {
+			for _, query := range sources.LoadBalancerQueries(ingress.Hostname, accountID) {
+				add(query, loadBalancerBlast())
+			}
+		}
+	}
+
+	ingresses, err := listKubernetes[kubernetesIngress](ctx, client, "/apis/networking.k8s.io/v1/ingresses")
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ingress := range ingresses {
+		for _, lb := range ingress.Status.LoadBalancer.Ingress {
+			for _, query := range sources.LoadBalancerQueries(lb.Hostname, accountID) {
+				add(query, loadBalancerBlast())
+			}
+		}
+	}
+
+	serviceAccounts, err := listKubernetes[kubernetesServiceAccount](ctx, client, "/api/v1/serviceaccounts")
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, serviceAccount := range serviceAccounts {
+		roleARN, ok := serviceAccount.Metadata.Annotations[serviceAccountRoleAnnotation]
+
+		if !ok {
+			continue
+		}
+
+		if a, err := sources.ParseARN(roleARN); err == nil {
+			add(&sdp.Query{
+				Type:   "iam-role",
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  roleARN,
+				Scope:  sources.FormatScope(a.AccountID, a.Region),
+			}, &sdp.BlastPropagation{
+				// The role controls what the cluster's pods can do
+				In: true,
+				// The cluster can't affect the role
+				Out: false,
+			})
+		}
+	}
+
+	volumes, err := listKubernetes[kubernetesPersistentVolume](ctx, client, "/api/v1/persistentvolumes")
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, volume := range volumes {
+		if id := ebsVolumeID(volume); id != "" {
+			add(&sdp.Query{
+				Type:   "ec2-volume",
+				Method: sdp.QueryMethod_GET,
+				Query:  id,
+				Scope:  scope,
+			}, &sdp.BlastPropagation{
+				// Pods can't start without their volumes
+				In: true,
+				// Dynamically provisioned volumes are deleted with their
+				// persistent volume
+				Out: true,
+			})
+		}
+
+		if id := efsFileSystemID(volume); id != "" {
+			add(&sdp.Query{
+				Type:   "efs-file-system",
+				Method: sdp.QueryMethod_GET,
+				Query:  id,
+				Scope:  scope,
+			}, &sdp.BlastPropagation{
+				// Pods can't start without their file systems
+				In: true,
+				// The file system isn't managed by the cluster
+				Out: false,
+			})
+		}
+	}
+
+	return links, nil
+}
+
+// ebsVolumeID Returns the ID of the EBS volume behind a persistent volume,
+// from either the in-tree plugin or the EBS CSI driver
+func ebsVolumeID(volume kubernetesPersistentVolume) string {
+	if volume.Spec.AWSElasticBlockStore != nil {
+		// The in-tree plugin allows aws://{zone}/{volumeID}
+		parts := strings.Split(volume.Spec.AWSElasticBlockStore.VolumeID, "/")
+		return parts[len(parts)-1]
+	}
+
+	if volume.Spec.CSI != nil && volume.Spec.CSI.Driver == "ebs.csi.aws.com" {
+		return volume.Spec.CSI.VolumeHandle
+	}
+
+	return ""
+}
+
+// efsFileSystemID Returns the ID of the EFS file system behind a persistent
+// volume. The EFS CSI driver's volume handle can be {fileSystemID},
+// {fileSystemID}:{path} or {fileSystemID}::{accessPointID}
+func efsFileSystemID(volume kubernetesPersistentVolume) string {
+	if volume.Spec.CSI != nil && volume.Spec.CSI.Driver == "efs.csi.aws.com" {
+		id, _, _ := strings.Cut(volume.Spec.CSI.VolumeHandle, ":")
+		return id
+	}
+
+	return ""
+}
@@ -0,0 +1,361 @@
+package eks
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+const testKubernetesToken = "k8s-aws-v1.test"
+
+// testKubernetesResponses Responses from the fake Kubernetes API server, keyed
+// by path. Services are split across two pages to test pagination
+var testKubernetesResponses = map[string]string{
+	"/api/v1/services": `{
+		"metadata": {"continue": "page-2"},
+		"items": [
+			{
+				"metadata": {"name": "web", "namespace": "default"},
+				"spec": {"type": "LoadBalancer"},
+				"status": {"loadBalancer": {"ingress": [{"hostname": "k8s-default-web-0123456789.elb.eu-west-2.amazonaws.com"}]}}
+			},
+			{
+				"metadata": {"name": "internal", "namespace": "default"},
+				"spec": {"type": "ClusterIP"},
+				"status": {"loadBalancer": {}}
+			}
+		]
+	}`,
+	"/api/v1/services?page-2": `{
+		"metadata": {},
+		"items": [
+			{
+				"metadata": {"name": "legacy", "namespace": "default"},
+				"spec": {"type": "LoadBalancer"},
+				"status": {"loadBalancer": {"ingress": [{"hostname": "a1b2c3d4e5f6-1234567890.eu-west-2.elb.amazonaws.com"}]}}
+			}
+		]
+	}`,
+	"/apis/networking.k8s.io/v1/ingresses": `{
+		"metadata": {},
+		"items": [
+			{
+				"metadata": {"name": "app", "namespace": "default"},
+				"status": {"loadBalancer": {"ingress": [{"hostname": "k8s-default-app-abcdef1234-1234567890.eu-west-2.elb.amazonaws.com"}]}}
+			}
+		]
+	}`,
+	"/api/v1/serviceaccounts": `{
+		"metadata": {},
+		"items": [
+			{
+				"metadata": {
+					"name": "uploader",
+					"namespace": "default",
+					"annotations": {"eks.amazonaws.com/role-arn": "arn:aws:iam::052392120703:role/uploader"}
+				}
+			},
+			{
+				"metadata": {"name": "default", "namespace": "default"}
+			}
+		]
+	}`,
+	"/api/v1/persistentvolumes": `{
+		"metadata": {},
+		"items": [
+			{
+				"metadata": {"name": "in-tree"},
+				"spec": {"awsElasticBlockStore": {"volumeID": "aws://eu-west-2a/vol-0a1b2c3d4e5f67890"}}
+			},
+			{
+				"metadata": {"name": "csi"},
+				"spec": {"csi": {"driver": "ebs.csi.aws.com", "volumeHandle": "vol-0123456789abcdef0"}}
+			},
+			{
+				"metadata": {"name": "shared"},
+				"spec": {"csi": {"driver": "efs.csi.aws.com", "volumeHandle": "fs-0123456789abcdef0::fsap-0123456789abcdef0"}}
+			}
+		]
+	}`,
+}
+
+// newTestKubernetesServer Starts a fake Kubernetes API server and returns a
+// cluster that points at it
+func newTestKubernetesServer(t *testing.T) *types.Cluster {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+testKubernetesToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		key := r.URL.Path
+
+		if c := r.URL.Query().Get("continue"); c != "" {
+			key += "?" + c
+		}
+
+		response, ok := testKubernetesResponses[key]
+
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(response))
+	}))
+
+	t.Cleanup(server.Close)
+
+	ca := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: server.Certificate().Raw,
+	})
+
+	cluster := &types.Cluster{
+		Name:     sources.PtrString("dylan"),
+		Endpoint: sources.PtrString(server.URL),
+		Status:   types.ClusterStatusActive,
+		CertificateAuthority: &types.Certificate{
+			Data: sources.PtrString(base64.StdEncoding.EncodeToString(ca)),
+		},
+	}
+
+	return cluster
+}
+
+func testKubernetesBridge(token string) *kubernetesBridge {
+	return &kubernetesBridge{
+		token: func(ctx context.Context, clusterName string) (string, error) {
+			return token, nil
+		},
+	}
+}
+
+func TestKubernetesBridgeLinkedItemQueries(t *testing.T) {
+	cluster := newTestKubernetesServer(t)
+
+	links, err := testKubernetesBridge(testKubernetesToken).linkedItemQueries(context.Background(), cluster, "052392120703.eu-west-2")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item := &sdp.Item{
+		LinkedItemQueries: links,
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "elbv2-load-balancer",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "k8s-default-web",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "elbv2-load-balancer",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "a1b2c3d4e5f6",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "elb-load-balancer",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "a1b2c3d4e5f6",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "elbv2-load-balancer",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "k8s-default-app-abcdef1234",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "iam-role",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:iam::052392120703:role/uploader",
+			ExpectedScope:  "052392120703",
+		},
+		{
+			ExpectedType:   "ec2-volume",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "vol-0a1b2c3d4e5f67890",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "ec2-volume",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "vol-0123456789abcdef0",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "efs-file-system",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "fs-0123456789abcdef0",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestKubernetesBridgeUnauthorized(t *testing.T) {
+	cluster := newTestKubernetesServer(t)
+
+	_, err := testKubernetesBridge("k8s-aws-v1.wrong").linkedItemQueries(context.Background(), cluster, "052392120703.eu-west-2")
+
+	if err == nil {
+		t.Error("expected error, got nil")
+	}
+}
+
+func TestKubernetesBridgeReusesHTTPClient(t *testing.T) {
+	cluster := newTestKubernetesServer(t)
+	bridge := testKubernetesBridge(testKubernetesToken)
+
+	first, err := bridge.newKubernetesClient(context.Background(), cluster)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := bridge.newKubernetesClient(context.Background(), cluster)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first.httpClient != second.httpClient {
+		t.Error("expected the HTTP client to be reused for the same cluster")
+	}
+
+	// If the cluster's certificate authority changes, a new client is needed.
+	// Test servers all share a certificate, so change the encoding instead
+	ca, err := base64.StdEncoding.DecodeString(*cluster.CertificateAuthority.Data)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cluster.CertificateAuthority = &types.Certificate{
+		Data: sources.PtrString(base64.StdEncoding.EncodeToString(append(ca, '\n'))),
+	}
+
+	third, err := bridge.newKubernetesClient(context.Background(), cluster)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if third.httpClient == first.httpClient {
+		t.Error("expected a new HTTP client when the certificate authority changes")
+	}
+
+	if len(bridge.httpClients) != 1 {
+		t.Errorf("expected 1 cached HTTP client, got %v", len(bridge.httpClients))
+	}
+}
+
+func TestClusterGetFuncKubernetes(t *testing.T) {
+	cluster := newTestKubernetesServer(t)
+
+	client := TestClient{
+		DescribeClusterOutput: &eks.DescribeClusterOutput{
+			Cluster: cluster,
+		},
+	}
+
+	t.Run("with access to the cluster", func(t *testing.T) {
+		item, err := clusterGetFunc(context.Background(), client, testKubernetesBridge(testKubernetesToken), "052392120703.eu-west-2", &eks.DescribeClusterInput{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tests := sources.QueryTests{
+			{
+				ExpectedType:   "iam-role",
+				ExpectedMethod: sdp.QueryMethod_SEARCH,
+				ExpectedQuery:  "arn:aws:iam::052392120703:role/uploader",
+				ExpectedScope:  "052392120703",
+			},
+		}
+
+		tests.Execute(t, item)
+	})
+
+	t.Run("without access to the cluster", func(t *testing.T) {
+		// The cluster should still be returned
+		item, err := clusterGetFunc(context.Background(), client, testKubernetesBridge("k8s-aws-v1.wrong"), "052392120703.eu-west-2", &eks.DescribeClusterInput{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, link := range item.GetLinkedItemQueries() {
+			if link.GetQuery().GetType() == "iam-role" && link.GetQuery().GetQuery() == "arn:aws:iam::052392120703:role/uploader" {
+				t.Error("expected no links from inside the cluster")
+			}
+		}
+	})
+}
+
+func TestSTSTokenFunc(t *testing.T) {
+	config := aws.Config{
+		Region: "eu-west-2",
+		Credentials: aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+			return aws.Credentials{
+				AccessKeyID:     "AKIDEXAMPLE",
+				SecretAccessKey: "secret",
+			}, nil
+		}),
+	}
+
+	token, err := stsTokenFunc(sts.NewPresignClient(sts.NewFromConfig(config)))(context.Background(), "dylan")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(token, kubernetesTokenPrefix) {
+		t.Fatalf("expected token to start with %v, got %v", kubernetesTokenPrefix, token)
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(token, kubernetesTokenPrefix))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	presigned, err := url.Parse(string(decoded))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := presigned.Query()
+
+	if query.Get("Action") != "GetCallerIdentity" {
+		t.Errorf("expected GetCallerIdentity action, got %v", query.Get("Action"))
+	}
+
+	if query.Get("X-Amz-Expires") != "60" {
+		t.Errorf("expected X-Amz-Expires to be 60, got %v", query.Get("X-Amz-Expires"))
+	}
+
+	if !strings.Contains(query.Get("X-Amz-SignedHeaders"), kubernetesClusterIDHeader) {
+		t.Errorf("expected %v to be signed, got %v", kubernetesClusterIDHeader, query.Get("X-Amz-SignedHeaders"))
+	}
+}
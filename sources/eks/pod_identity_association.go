@@ -0,0 +1,146 @@
+package eks
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func podIdentityAssociationGetFunc(ctx context.Context, client EKSClient, scope string, input *eks.DescribePodIdentityAssociationInput) (*sdp.Item, error) {
+	out, err := client.DescribePodIdentityAssociation(ctx, input)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if out.Association == nil {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: "pod identity association was nil",
+		}
+	}
+
+	association := out.Association
+
+	attributes, err := sources.ToAttributesCase(association)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// The uniqueAttributeValue for this is a custom field:
+	// {clusterName}/{associationId}
+	attributes.Set("uniqueName", (*association.ClusterName + "/" + *association.AssociationId))
+
+	item := sdp.Item{
+		Type:            "eks-pod-identity-association",
+		UniqueAttribute: "uniqueName",
+		Attributes:      attributes,
+		Scope:           scope,
+		Tags:            association.Tags,
+	}
+
+	if association.RoleArn != nil {
+		if a, err := sources.ParseARN(*association.RoleArn); err == nil {
+			// +overmind:link iam-role
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "iam-role",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *association.RoleArn,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// The role controls what the pods can do
+					In: true,
+					// The association can't affect the role
+					Out: false,
+				},
+			})
+		}
+	}
+
+	if association.Namespace != nil && association.ServiceAccount != nil {
+		// Kubernetes items are scoped to {clusterName}.{namespace}
+		// +overmind:link ServiceAccount
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "ServiceAccount",
+				Method: sdp.QueryMethod_GET,
+				Query:  *association.ServiceAccount,
+				Scope:  *association.ClusterName + "." + *association.Namespace,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Deleting the service account will make the association
+				// useless
+				In: true,
+				// The association controls which role the service account's
+				// pods get
+				Out: true,
+			},
+		})
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type eks-pod-identity-association
+// +overmind:descriptiveType EKS Pod Identity Association
+// +overmind:get Get a pod identity association by unique name ({clusterName}/{associationId})
+// +overmind:list List all pod identity associations
+// +overmind:search Search for pod identity associations by cluster name
+// +overmind:group AWS
+
+func NewPodIdentityAssociationSource(config aws.Config, accountID string, region string) *sources.AlwaysGetSource[*eks.ListPodIdentityAssociationsInput, *eks.ListPodIdentityAssociationsOutput, *eks.DescribePodIdentityAssociationInput, *eks.DescribePodIdentityAssociationOutput, EKSClient, *eks.Options] {
+	return &sources.AlwaysGetSource[*eks.ListPodIdentityAssociationsInput, *eks.ListPodIdentityAssociationsOutput, *eks.DescribePodIdentityAssociationInput, *eks.DescribePodIdentityAssociationOutput, EKSClient, *eks.Options]{
+		ItemType:    "eks-pod-identity-association",
+		Client:      eks.NewFromConfig(config),
+		AccountID:   accountID,
+		Region:      region,
+		DisableList: true,
+		SearchInputMapper: func(scope, query string) (*eks.ListPodIdentityAssociationsInput, error) {
+			return &eks.ListPodIdentityAssociationsInput{
+				ClusterName: &query,
+			}, nil
+		},
+		GetInputMapper: func(scope, query string) *eks.DescribePodIdentityAssociationInput {
+			// The uniqueAttributeValue for this is a custom field:
+			// {clusterName}/{associationId}
+			fields := strings.Split(query, "/")
+
+			var clusterName string
+			var associationID string
+
+			if len(fields) == 2 {
+				clusterName = fields[0]
+				associationID = fields[1]
+			}
+
+			return &eks.DescribePodIdentityAssociationInput{
+				ClusterName:   &clusterName,
+				AssociationId: &associationID,
+			}
+		},
+		ListFuncPaginatorBuilder: func(client EKSClient, input *eks.ListPodIdentityAssociationsInput) sources.Paginator[*eks.ListPodIdentityAssociationsOutput, *eks.Options] {
+			return eks.NewListPodIdentityAssociationsPaginator(client, input)
+		},
+		ListFuncOutputMapper: func(output *eks.ListPodIdentityAssociationsOutput, input *eks.ListPodIdentityAssociationsInput) ([]*eks.DescribePodIdentityAssociationInput, error) {
+			inputs := make([]*eks.DescribePodIdentityAssociationInput, len(output.Associations))
+
+			for i := range output.Associations {
+				inputs[i] = &eks.DescribePodIdentityAssociationInput{
+					ClusterName:   input.ClusterName,
+					AssociationId: output.Associations[i].AssociationId,
+				}
+			}
+
+			return inputs, nil
+		},
+		GetFunc: podIdentityAssociationGetFunc,
+	}
+}
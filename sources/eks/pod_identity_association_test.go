@@ -0,0 +1,70 @@
+package eks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+var PodIdentityAssociationTestClient = TestClient{
+	DescribePodIdentityAssociationOutput: &eks.DescribePodIdentityAssociationOutput{
+		Association: &types.PodIdentityAssociation{
+			AssociationArn: sources.PtrString("arn:aws:eks:eu-west-2:052392120703:podidentityassociation/cluster/a-0a1b2c3d4e5f67890"),
+			AssociationId:  sources.PtrString("a-0a1b2c3d4e5f67890"),
+			ClusterName:    sources.PtrString("cluster"),
+			CreatedAt:      sources.PtrTime(time.Now()),
+			Namespace:      sources.PtrString("default"),
+			RoleArn:        sources.PtrString("arn:aws:iam::052392120703:role/uploader"),
+			ServiceAccount: sources.PtrString("uploader"),
+			Tags:           map[string]string{},
+		},
+	},
+}
+
+func TestPodIdentityAssociationGetFunc(t *testing.T) {
+	item, err := podIdentityAssociationGetFunc(context.Background(), PodIdentityAssociationTestClient, "foo", &eks.DescribePodIdentityAssociationInput{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "iam-role",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:iam::052392120703:role/uploader",
+			ExpectedScope:  "052392120703",
+		},
+		{
+			ExpectedType:   "ServiceAccount",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "uploader",
+			ExpectedScope:  "cluster.default",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestNewPodIdentityAssociationSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewPodIdentityAssociationSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:            source,
+		Timeout:           10 * time.Second,
+		SkipNotFoundCheck: true,
+	}
+
+	test.Run(t)
+}
@@ -0,0 +1,184 @@
+package elasticache
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func cacheClusterOutputMapper(ctx context.Context, client elastiCacheClient, scope string, _ *elasticache.DescribeCacheClustersInput, output *elasticache.DescribeCacheClustersOutput) ([]*sdp.Item, error) {
+	items := make([]*sdp.Item, 0)
+
+	for _, cluster := range output.CacheClusters {
+		attributes, err := sources.ToAttributesCase(cluster)
+
+		if err != nil {
+			return nil, err
+		}
+
+		item := sdp.Item{
+			Type:            "elasticache-cache-cluster",
+			UniqueAttribute: "cacheClusterId",
+			Attributes:      attributes,
+			Scope:           scope,
+			Tags:            getTags(ctx, client, cluster.ARN),
+		}
+
+		if cluster.CacheClusterStatus != nil {
+			item.Health = statusToHealth(*cluster.CacheClusterStatus)
+		}
+
+		// +overmind:link dns
+		if link := endpointLink(cluster.ConfigurationEndpoint); link != nil {
+			item.LinkedItemQueries = append(item.LinkedItemQueries, link)
+		}
+
+		for _, node := range cluster.CacheNodes {
+			// +overmind:link dns
+			if link := endpointLink(node.Endpoint); link != nil {
+				item.LinkedItemQueries = append(item.LinkedItemQueries, link)
+			}
+		}
+
+		for _, sg := range cluster.SecurityGroups {
+			if sg.SecurityGroupId != nil {
+				// +overmind:link ec2-security-group
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "ec2-security-group",
+						Method: sdp.QueryMethod_GET,
+						Query:  *sg.SecurityGroupId,
+						Scope:  scope,
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Changing the security group can affect the cluster
+						In: true,
+						// The cluster won't affect the security group
+						Out: false,
+					},
+				})
+			}
+		}
+
+		if cluster.CacheSubnetGroupName != nil {
+			// +overmind:link elasticache-subnet-group
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "elasticache-subnet-group",
+					Method: sdp.QueryMethod_GET,
+					Query:  *cluster.CacheSubnetGroupName,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the subnet group can affect the cluster
+					In: true,
+					// The cluster won't affect the subnet group
+					Out: false,
+				},
+			})
+		}
+
+		if cluster.CacheParameterGroup != nil && cluster.CacheParameterGroup.CacheParameterGroupName != nil {
+			// +overmind:link elasticache-parameter-group
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "elasticache-parameter-group",
+					Method: sdp.QueryMethod_GET,
+					Query:  *cluster.CacheParameterGroup.CacheParameterGroupName,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the parameter group can affect the cluster
+					In: true,
+					// The cluster won't affect the parameter group
+					Out: false,
+				},
+			})
+		}
+
+		if cluster.ReplicationGroupId != nil {
+			// +overmind:link elasticache-replication-group
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "elasticache-replication-group",
+					Method: sdp.QueryMethod_GET,
+					Query:  *cluster.ReplicationGroupId,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Tightly coupled
+					In:  true,
+					Out: true,
+				},
+			})
+		}
+
+		if cluster.NotificationConfiguration != nil && cluster.NotificationConfiguration.TopicArn != nil {
+			if a, err := sources.ParseARN(*cluster.NotificationConfiguration.TopicArn); err == nil {
+				// +overmind:link sns-topic
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "sns-topic",
+						Method: sdp.QueryMethod_SEARCH,
+						Query:  *cluster.NotificationConfiguration.TopicArn,
+						Scope:  sources.FormatScope(a.AccountID, a.Region),
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Changing the topic won't affect the cluster
+						In: false,
+						// The cluster sends notifications to the topic
+						Out: true,
+					},
+				})
+			}
+		}
+
+		// +overmind:link logs-log-group
+		// +overmind:link firehose-delivery-stream
+		item.LinkedItemQueries = append(item.LinkedItemQueries, logDeliveryLinks(scope, cluster.LogDeliveryConfigurations)...)
+
+		items = append(items, &item)
+	}
+
+	return items, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type elasticache-cache-cluster
+// +overmind:descriptiveType ElastiCache Cache Cluster
+// +overmind:get Get a cache cluster by ID
+// +overmind:list List all cache clusters
+// +overmind:search Search for cache clusters by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_elasticache_cluster.cluster_id
+
+func NewCacheClusterSource(config aws.Config, accountID string) *sources.DescribeOnlySource[*elasticache.DescribeCacheClustersInput, *elasticache.DescribeCacheClustersOutput, elastiCacheClient, *elasticache.Options] {
+	return &sources.DescribeOnlySource[*elasticache.DescribeCacheClustersInput, *elasticache.DescribeCacheClustersOutput, elastiCacheClient, *elasticache.Options]{
+		ItemType:  "elasticache-cache-cluster",
+		Config:    config,
+		AccountID: accountID,
+		Client:    elasticache.NewFromConfig(config),
+		PaginatorBuilder: func(client elastiCacheClient, params *elasticache.DescribeCacheClustersInput) sources.Paginator[*elasticache.DescribeCacheClustersOutput, *elasticache.Options] {
+			return elasticache.NewDescribeCacheClustersPaginator(client, params)
+		},
+		DescribeFunc: func(ctx context.Context, client elastiCacheClient, input *elasticache.DescribeCacheClustersInput) (*elasticache.DescribeCacheClustersOutput, error) {
+			return client.DescribeCacheClusters(ctx, input)
+		},
+		InputMapperGet: func(scope, query string) (*elasticache.DescribeCacheClustersInput, error) {
+			return &elasticache.DescribeCacheClustersInput{
+				CacheClusterId: &query,
+				// Without this the node endpoints aren't returned
+				ShowCacheNodeInfo: sources.PtrBool(true),
+			}, nil
+		},
+		InputMapperList: func(scope string) (*elasticache.DescribeCacheClustersInput, error) {
+			return &elasticache.DescribeCacheClustersInput{
+				ShowCacheNodeInfo: sources.PtrBool(true),
+			}, nil
+		},
+		OutputMapper: cacheClusterOutputMapper,
+	}
+}
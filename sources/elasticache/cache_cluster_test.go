@@ -0,0 +1,154 @@
+package elasticache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func TestCacheClusterOutputMapper(t *testing.T) {
+	output := elasticache.DescribeCacheClustersOutput{
+		CacheClusters: []types.CacheCluster{
+			{
+				ARN:                      sources.PtrString("arn:aws:elasticache:eu-west-2:052392120703:cluster:app-cache-001"),
+				CacheClusterId:           sources.PtrString("app-cache-001"),
+				CacheClusterStatus:       sources.PtrString("available"),
+				CacheClusterCreateTime:   sources.PtrTime(time.Now()),
+				CacheNodeType:            sources.PtrString("cache.t4g.micro"),
+				Engine:                   sources.PtrString("redis"),
+				EngineVersion:            sources.PtrString("7.1.0"),
+				NumCacheNodes:            sources.PtrInt32(1),
+				AtRestEncryptionEnabled:  sources.PtrBool(true),
+				TransitEncryptionEnabled: sources.PtrBool(true),
+				ReplicationGroupId:       sources.PtrString("app-cache"),     // link
+				CacheSubnetGroupName:     sources.PtrString("redis-subnets"), // link
+				CacheParameterGroup: &types.CacheParameterGroupStatus{
+					CacheParameterGroupName: sources.PtrString("redis7-custom"), // link
+					ParameterApplyStatus:    sources.PtrString("in-sync"),
+				},
+				CacheNodes: []types.CacheNode{
+					{
+						CacheNodeId:     sources.PtrString("0001"),
+						CacheNodeStatus: sources.PtrString("available"),
+						Endpoint: &types.Endpoint{
+							Address: sources.PtrString("app-cache-001.app-cache.abc123.euw2.cache.amazonaws.com"), // link
+							Port:    sources.PtrInt32(6379),
+						},
+					},
+				},
+				SecurityGroups: []types.SecurityGroupMembership{
+					{
+						SecurityGroupId: sources.PtrString("sg-0b6a1c5e0e8d6c8f2"), // link
+						Status:          sources.PtrString("active"),
+					},
+				},
+				NotificationConfiguration: &types.NotificationConfiguration{
+					TopicArn:    sources.PtrString("arn:aws:sns:eu-west-2:052392120703:cache-events"), // link
+					TopicStatus: sources.PtrString("active"),
+				},
+				LogDeliveryConfigurations: []types.LogDeliveryConfiguration{
+					{
+						DestinationType: types.DestinationTypeCloudWatchLogs,
+						DestinationDetails: &types.DestinationDetails{
+							CloudWatchLogsDetails: &types.CloudWatchLogsDestinationDetails{
+								LogGroup: sources.PtrString("/elasticache/app-cache/slow-log"), // link
+							},
+						},
+						LogFormat: types.LogFormatJson,
+						LogType:   types.LogTypeSlowLog,
+						Status:    types.LogDeliveryConfigurationStatusActive,
+					},
+				},
+			},
+		},
+	}
+
+	items, err := cacheClusterOutputMapper(context.Background(), mockElastiCacheClient{}, "foo", nil, &output)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 1 {
+		t.Fatalf("got %v items, expected 1", len(items))
+	}
+
+	item := items[0]
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.Tags["key"] != "value" {
+		t.Errorf("expected key to be value, got %v", item.Tags["key"])
+	}
+
+	if item.GetHealth() != sdp.Health_HEALTH_OK {
+		t.Errorf("expected health to be OK, got %v", item.GetHealth())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "dns",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "app-cache-001.app-cache.abc123.euw2.cache.amazonaws.com",
+			ExpectedScope:  "global",
+		},
+		{
+			ExpectedType:   "ec2-security-group",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "sg-0b6a1c5e0e8d6c8f2",
+			ExpectedScope:  "foo",
+		},
+		{
+			ExpectedType:   "elasticache-subnet-group",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "redis-subnets",
+			ExpectedScope:  "foo",
+		},
+		{
+			ExpectedType:   "elasticache-parameter-group",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "redis7-custom",
+			ExpectedScope:  "foo",
+		},
+		{
+			ExpectedType:   "elasticache-replication-group",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "app-cache",
+			ExpectedScope:  "foo",
+		},
+		{
+			ExpectedType:   "sns-topic",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:sns:eu-west-2:052392120703:cache-events",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "logs-log-group",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "/elasticache/app-cache/slow-log",
+			ExpectedScope:  "foo",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestNewCacheClusterSource(t *testing.T) {
+	config, account, _ := sources.GetAutoConfig(t)
+
+	source := NewCacheClusterSource(config, account)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
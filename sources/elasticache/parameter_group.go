@@ -0,0 +1,138 @@
+package elasticache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+type ParameterGroup struct {
+	types.CacheParameterGroup
+
+	Parameters []types.Parameter
+}
+
+// getParameters Gets all of the parameters for a given parameter group
+func getParameters(ctx context.Context, client elastiCacheClient, groupName *string) ([]types.Parameter, error) {
+	params := make([]types.Parameter, 0)
+
+	paginator := elasticache.NewDescribeCacheParametersPaginator(client, &elasticache.DescribeCacheParametersInput{
+		CacheParameterGroupName: groupName,
+	})
+
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		params = append(params, out.Parameters...)
+	}
+
+	return params, nil
+}
+
+func parameterGroupItemMapper(scope string, awsItem *ParameterGroup) (*sdp.Item, error) {
+	attributes, err := sources.ToAttributesCase(awsItem)
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := sdp.Item{
+		Type:            "elasticache-parameter-group",
+		UniqueAttribute: "cacheParameterGroupName",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type elasticache-parameter-group
+// +overmind:descriptiveType ElastiCache Parameter Group
+// +overmind:get Get a parameter group by name
+// +overmind:list List all parameter groups
+// +overmind:search Search for a parameter group by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_elasticache_parameter_group.name
+
+func NewParameterGroupSource(config aws.Config, accountID string, region string) *sources.GetListSource[*ParameterGroup, elastiCacheClient, *elasticache.Options] {
+	return &sources.GetListSource[*ParameterGroup, elastiCacheClient, *elasticache.Options]{
+		ItemType:  "elasticache-parameter-group",
+		Client:    elasticache.NewFromConfig(config),
+		AccountID: accountID,
+		Region:    region,
+		GetFunc: func(ctx context.Context, client elastiCacheClient, scope, query string) (*ParameterGroup, error) {
+			out, err := client.DescribeCacheParameterGroups(ctx, &elasticache.DescribeCacheParameterGroupsInput{
+				CacheParameterGroupName: &query,
+			})
+
+			if err != nil {
+				return nil, err
+			}
+
+			if len(out.CacheParameterGroups) != 1 {
+				return nil, fmt.Errorf("expected 1 group, got %v", len(out.CacheParameterGroups))
+			}
+
+			params, err := getParameters(ctx, client, out.CacheParameterGroups[0].CacheParameterGroupName)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return &ParameterGroup{
+				Parameters:          params,
+				CacheParameterGroup: out.CacheParameterGroups[0],
+			}, nil
+		},
+		ListFunc: func(ctx context.Context, client elastiCacheClient, scope string) ([]*ParameterGroup, error) {
+			groups := make([]*ParameterGroup, 0)
+
+			paginator := elasticache.NewDescribeCacheParameterGroupsPaginator(client, &elasticache.DescribeCacheParameterGroupsInput{})
+
+			for paginator.HasMorePages() {
+				out, err := paginator.NextPage(ctx)
+
+				if err != nil {
+					return nil, err
+				}
+
+				for _, group := range out.CacheParameterGroups {
+					params, err := getParameters(ctx, client, group.CacheParameterGroupName)
+
+					if err != nil {
+						return nil, err
+					}
+
+					groups = append(groups, &ParameterGroup{
+						Parameters:          params,
+						CacheParameterGroup: group,
+					})
+				}
+			}
+
+			return groups, nil
+		},
+		ListTagsFunc: func(ctx context.Context, pg *ParameterGroup, c elastiCacheClient) (map[string]string, error) {
+			out, err := c.ListTagsForResource(ctx, &elasticache.ListTagsForResourceInput{
+				ResourceName: pg.ARN,
+			})
+
+			if err != nil {
+				return nil, err
+			}
+
+			return tagsToMap(out.TagList), nil
+		},
+		ItemMapper: parameterGroupItemMapper,
+	}
+}
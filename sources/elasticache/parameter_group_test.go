@@ -0,0 +1,54 @@
+package elasticache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/overmindtech/aws-source/sources"
+)
+
+func TestParameterGroupSource(t *testing.T) {
+	src := NewParameterGroupSource(aws.Config{}, "052392120703", "eu-west-2")
+
+	// Override the client
+	src.Client = mockElastiCacheClient{}
+
+	item, err := src.Get(context.Background(), "052392120703.eu-west-2", "redis7-custom", false)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.Tags["key"] != "value" {
+		t.Errorf("expected key to be value, got %v", item.Tags["key"])
+	}
+
+	params, err := item.GetAttributes().Get("parameters")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p, ok := params.([]interface{}); !ok || len(p) != 1 {
+		t.Errorf("expected 1 parameter, got %v", params)
+	}
+}
+
+func TestNewParameterGroupSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewParameterGroupSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -0,0 +1,132 @@
+package elasticache
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func replicationGroupOutputMapper(ctx context.Context, client elastiCacheClient, scope string, _ *elasticache.DescribeReplicationGroupsInput, output *elasticache.DescribeReplicationGroupsOutput) ([]*sdp.Item, error) {
+	items := make([]*sdp.Item, 0)
+
+	for _, group := range output.ReplicationGroups {
+		attributes, err := sources.ToAttributesCase(group)
+
+		if err != nil {
+			return nil, err
+		}
+
+		item := sdp.Item{
+			Type:            "elasticache-replication-group",
+			UniqueAttribute: "replicationGroupId",
+			Attributes:      attributes,
+			Scope:           scope,
+			Tags:            getTags(ctx, client, group.ARN),
+		}
+
+		if group.Status != nil {
+			item.Health = statusToHealth(*group.Status)
+		}
+
+		// The configuration endpoint is only set when cluster mode is enabled,
+		// otherwise each node group has its own primary and reader endpoints
+		// +overmind:link dns
+		if link := endpointLink(group.ConfigurationEndpoint); link != nil {
+			item.LinkedItemQueries = append(item.LinkedItemQueries, link)
+		}
+
+		for _, nodeGroup := range group.NodeGroups {
+			// +overmind:link dns
+			if link := endpointLink(nodeGroup.PrimaryEndpoint); link != nil {
+				item.LinkedItemQueries = append(item.LinkedItemQueries, link)
+			}
+
+			// +overmind:link dns
+			if link := endpointLink(nodeGroup.ReaderEndpoint); link != nil {
+				item.LinkedItemQueries = append(item.LinkedItemQueries, link)
+			}
+		}
+
+		for _, clusterID := range group.MemberClusters {
+			// +overmind:link elasticache-cache-cluster
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "elasticache-cache-cluster",
+					Method: sdp.QueryMethod_GET,
+					Query:  clusterID,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Tightly coupled
+					In:  true,
+					Out: true,
+				},
+			})
+		}
+
+		if group.KmsKeyId != nil {
+			// This actually uses the ARN not the id
+			if a, err := sources.ParseARN(*group.KmsKeyId); err == nil {
+				// +overmind:link kms-key
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "kms-key",
+						Method: sdp.QueryMethod_SEARCH,
+						Query:  *group.KmsKeyId,
+						Scope:  sources.FormatScope(a.AccountID, a.Region),
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Changing the KMS key can affect the replication group
+						In: true,
+						// The replication group won't affect the KMS key
+						Out: false,
+					},
+				})
+			}
+		}
+
+		// +overmind:link logs-log-group
+		// +overmind:link firehose-delivery-stream
+		item.LinkedItemQueries = append(item.LinkedItemQueries, logDeliveryLinks(scope, group.LogDeliveryConfigurations)...)
+
+		items = append(items, &item)
+	}
+
+	return items, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type elasticache-replication-group
+// +overmind:descriptiveType ElastiCache Replication Group
+// +overmind:get Get a replication group by ID
+// +overmind:list List all replication groups
+// +overmind:search Search for replication groups by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_elasticache_replication_group.replication_group_id
+
+func NewReplicationGroupSource(config aws.Config, accountID string) *sources.DescribeOnlySource[*elasticache.DescribeReplicationGroupsInput, *elasticache.DescribeReplicationGroupsOutput, elastiCacheClient, *elasticache.Options] {
+	return &sources.DescribeOnlySource[*elasticache.DescribeReplicationGroupsInput, *elasticache.DescribeReplicationGroupsOutput, elastiCacheClient, *elasticache.Options]{
+		ItemType:  "elasticache-replication-group",
+		Config:    config,
+		AccountID: accountID,
+		Client:    elasticache.NewFromConfig(config),
+		PaginatorBuilder: func(client elastiCacheClient, params *elasticache.DescribeReplicationGroupsInput) sources.Paginator[*elasticache.DescribeReplicationGroupsOutput, *elasticache.Options] {
+			return elasticache.NewDescribeReplicationGroupsPaginator(client, params)
+		},
+		DescribeFunc: func(ctx context.Context, client elastiCacheClient, input *elasticache.DescribeReplicationGroupsInput) (*elasticache.DescribeReplicationGroupsOutput, error) {
+			return client.DescribeReplicationGroups(ctx, input)
+		},
+		InputMapperGet: func(scope, query string) (*elasticache.DescribeReplicationGroupsInput, error) {
+			return &elasticache.DescribeReplicationGroupsInput{
+				ReplicationGroupId: &query,
+			}, nil
+		},
+		InputMapperList: func(scope string) (*elasticache.DescribeReplicationGroupsInput, error) {
+			return &elasticache.DescribeReplicationGroupsInput{}, nil
+		},
+		OutputMapper: replicationGroupOutputMapper,
+	}
+}
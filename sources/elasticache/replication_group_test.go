@@ -0,0 +1,141 @@
+package elasticache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func TestReplicationGroupOutputMapper(t *testing.T) {
+	output := elasticache.DescribeReplicationGroupsOutput{
+		ReplicationGroups: []types.ReplicationGroup{
+			{
+				ARN:                      sources.PtrString("arn:aws:elasticache:eu-west-2:052392120703:replicationgroup:app-cache"),
+				ReplicationGroupId:       sources.PtrString("app-cache"),
+				Description:              sources.PtrString("Application cache"),
+				Status:                   sources.PtrString("modifying"),
+				AutomaticFailover:        types.AutomaticFailoverStatusEnabled,
+				MultiAZ:                  types.MultiAZStatusEnabled,
+				ClusterEnabled:           sources.PtrBool(false),
+				CacheNodeType:            sources.PtrString("cache.t4g.micro"),
+				AtRestEncryptionEnabled:  sources.PtrBool(true),
+				TransitEncryptionEnabled: sources.PtrBool(true),
+				KmsKeyId:                 sources.PtrString("arn:aws:kms:eu-west-2:052392120703:key/3f6b1c2d-8a0e-4b7c-9d21-5e6f7a8b9c0d"), // link
+				MemberClusters: []string{
+					"app-cache-001", // link
+					"app-cache-002", // link
+				},
+				NodeGroups: []types.NodeGroup{
+					{
+						NodeGroupId: sources.PtrString("0001"),
+						Status:      sources.PtrString("available"),
+						PrimaryEndpoint: &types.Endpoint{
+							Address: sources.PtrString("master.app-cache.abc123.euw2.cache.amazonaws.com"), // link
+							Port:    sources.PtrInt32(6379),
+						},
+						ReaderEndpoint: &types.Endpoint{
+							Address: sources.PtrString("replica.app-cache.abc123.euw2.cache.amazonaws.com"), // link
+							Port:    sources.PtrInt32(6379),
+						},
+					},
+				},
+				LogDeliveryConfigurations: []types.LogDeliveryConfiguration{
+					{
+						DestinationType: types.DestinationTypeKinesisFirehose,
+						DestinationDetails: &types.DestinationDetails{
+							KinesisFirehoseDetails: &types.KinesisFirehoseDestinationDetails{
+								DeliveryStream: sources.PtrString("cache-logs"), // link
+							},
+						},
+						LogFormat: types.LogFormatText,
+						LogType:   types.LogTypeEngineLog,
+						Status:    types.LogDeliveryConfigurationStatusActive,
+					},
+				},
+			},
+		},
+	}
+
+	items, err := replicationGroupOutputMapper(context.Background(), mockElastiCacheClient{}, "foo", nil, &output)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 1 {
+		t.Fatalf("got %v items, expected 1", len(items))
+	}
+
+	item := items[0]
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.Tags["key"] != "value" {
+		t.Errorf("expected key to be value, got %v", item.Tags["key"])
+	}
+
+	if item.GetHealth() != sdp.Health_HEALTH_PENDING {
+		t.Errorf("expected health to be PENDING, got %v", item.GetHealth())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "dns",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "master.app-cache.abc123.euw2.cache.amazonaws.com",
+			ExpectedScope:  "global",
+		},
+		{
+			ExpectedType:   "dns",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "replica.app-cache.abc123.euw2.cache.amazonaws.com",
+			ExpectedScope:  "global",
+		},
+		{
+			ExpectedType:   "elasticache-cache-cluster",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "app-cache-001",
+			ExpectedScope:  "foo",
+		},
+		{
+			ExpectedType:   "elasticache-cache-cluster",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "app-cache-002",
+			ExpectedScope:  "foo",
+		},
+		{
+			ExpectedType:   "kms-key",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:kms:eu-west-2:052392120703:key/3f6b1c2d-8a0e-4b7c-9d21-5e6f7a8b9c0d",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "firehose-delivery-stream",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "cache-logs",
+			ExpectedScope:  "foo",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestNewReplicationGroupSource(t *testing.T) {
+	config, account, _ := sources.GetAutoConfig(t)
+
+	source := NewReplicationGroupSource(config, account)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -0,0 +1,136 @@
+package elasticache
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+type elastiCacheClient interface {
+	DescribeCacheClusters(ctx context.Context, params *elasticache.DescribeCacheClustersInput, optFns ...func(*elasticache.Options)) (*elasticache.DescribeCacheClustersOutput, error)
+	DescribeCacheParameterGroups(ctx context.Context, params *elasticache.DescribeCacheParameterGroupsInput, optFns ...func(*elasticache.Options)) (*elasticache.DescribeCacheParameterGroupsOutput, error)
+	DescribeCacheParameters(ctx context.Context, params *elasticache.DescribeCacheParametersInput, optFns ...func(*elasticache.Options)) (*elasticache.DescribeCacheParametersOutput, error)
+	DescribeCacheSubnetGroups(ctx context.Context, params *elasticache.DescribeCacheSubnetGroupsInput, optFns ...func(*elasticache.Options)) (*elasticache.DescribeCacheSubnetGroupsOutput, error)
+	DescribeReplicationGroups(ctx context.Context, params *elasticache.DescribeReplicationGroupsInput, optFns ...func(*elasticache.Options)) (*elasticache.DescribeReplicationGroupsOutput, error)
+	ListTagsForResource(ctx context.Context, params *elasticache.ListTagsForResourceInput, optFns ...func(*elasticache.Options)) (*elasticache.ListTagsForResourceOutput, error)
+}
+
+func tagsToMap(tags []types.Tag) map[string]string {
+	tagsMap := make(map[string]string)
+
+	for _, tag := range tags {
+		if tag.Key != nil && tag.Value != nil {
+			tagsMap[*tag.Key] = *tag.Value
+		}
+	}
+
+	return tagsMap
+}
+
+// getTags Gets the tags for a resource by ARN. If the tags can't be retrieved
+// the error is handled and returned as tags so that the item is still usable
+func getTags(ctx context.Context, client elastiCacheClient, arn *string) map[string]string {
+	if arn == nil {
+		return nil
+	}
+
+	out, err := client.ListTagsForResource(ctx, &elasticache.ListTagsForResourceInput{
+		ResourceName: arn,
+	})
+
+	if err != nil {
+		return sources.HandleTagsError(ctx, err)
+	}
+
+	return tagsToMap(out.TagList)
+}
+
+// statusToHealth Converts the status of a cache cluster or replication group
+// to a health. Both use the same set of lowercase statuses
+func statusToHealth(status string) *sdp.Health {
+	switch status {
+	case "available", "snapshotting":
+		return sdp.Health_HEALTH_OK.Enum()
+	case "creating", "modifying", "rebooting cluster nodes":
+		return sdp.Health_HEALTH_PENDING.Enum()
+	case "deleting", "deleted":
+		return sdp.Health_HEALTH_WARNING.Enum()
+	case "create-failed", "incompatible-network", "restore-failed":
+		return sdp.Health_HEALTH_ERROR.Enum()
+	}
+
+	return nil
+}
+
+// endpointLink Returns a link to the DNS name of an endpoint, or nil if the
+// endpoint doesn't have an address
+func endpointLink(endpoint *types.Endpoint) *sdp.LinkedItemQuery {
+	if endpoint == nil || endpoint.Address == nil {
+		return nil
+	}
+
+	return &sdp.LinkedItemQuery{
+		Query: &sdp.Query{
+			Type:   "dns",
+			Method: sdp.QueryMethod_SEARCH,
+			Query:  *endpoint.Address,
+			Scope:  "global",
+		},
+		BlastPropagation: &sdp.BlastPropagation{
+			// DNS always links
+			In:  true,
+			Out: true,
+		},
+	}
+}
+
+// logDeliveryLinks Returns links to the CloudWatch log groups and Firehose
+// delivery streams that engine logs are delivered to
+func logDeliveryLinks(scope string, configs []types.LogDeliveryConfiguration) []*sdp.LinkedItemQuery {
+	links := make([]*sdp.LinkedItemQuery, 0)
+
+	for _, config := range configs {
+		if config.DestinationDetails == nil {
+			continue
+		}
+
+		if details := config.DestinationDetails.CloudWatchLogsDetails; details != nil && details.LogGroup != nil {
+			links = append(links, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "logs-log-group",
+					Method: sdp.QueryMethod_GET,
+					Query:  *details.LogGroup,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the log group won't affect the cache
+					In: false,
+					// The cache sends logs to the log group
+					Out: true,
+				},
+			})
+		}
+
+		if details := config.DestinationDetails.KinesisFirehoseDetails; details != nil && details.DeliveryStream != nil {
+			links = append(links, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "firehose-delivery-stream",
+					Method: sdp.QueryMethod_GET,
+					Query:  *details.DeliveryStream,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the delivery stream won't affect the cache
+					In: false,
+					// The cache sends logs to the delivery stream
+					Out: true,
+				},
+			})
+		}
+	}
+
+	return links
+}
@@ -0,0 +1,64 @@
+package elasticache
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+	"github.com/overmindtech/aws-source/sources"
+)
+
+type mockElastiCacheClient struct{}
+
+func (m mockElastiCacheClient) DescribeCacheClusters(ctx context.Context, params *elasticache.DescribeCacheClustersInput, optFns ...func(*elasticache.Options)) (*elasticache.DescribeCacheClustersOutput, error) {
+	return nil, nil
+}
+
+func (m mockElastiCacheClient) DescribeCacheParameterGroups(ctx context.Context, params *elasticache.DescribeCacheParameterGroupsInput, optFns ...func(*elasticache.Options)) (*elasticache.DescribeCacheParameterGroupsOutput, error) {
+	return &elasticache.DescribeCacheParameterGroupsOutput{
+		CacheParameterGroups: []types.CacheParameterGroup{
+			{
+				ARN:                       sources.PtrString("arn:aws:elasticache:eu-west-2:052392120703:parametergroup:redis7-custom"),
+				CacheParameterGroupFamily: sources.PtrString("redis7"),
+				CacheParameterGroupName:   sources.PtrString("redis7-custom"),
+				Description:               sources.PtrString("Custom Redis 7 parameters"),
+				IsGlobal:                  sources.PtrBool(false),
+			},
+		},
+	}, nil
+}
+
+func (m mockElastiCacheClient) DescribeCacheParameters(ctx context.Context, params *elasticache.DescribeCacheParametersInput, optFns ...func(*elasticache.Options)) (*elasticache.DescribeCacheParametersOutput, error) {
+	return &elasticache.DescribeCacheParametersOutput{
+		Parameters: []types.Parameter{
+			{
+				ParameterName:  sources.PtrString("maxmemory-policy"),
+				ParameterValue: sources.PtrString("allkeys-lru"),
+				AllowedValues:  sources.PtrString("volatile-lru,allkeys-lru,volatile-lfu,allkeys-lfu,volatile-random,allkeys-random,volatile-ttl,noeviction"),
+				ChangeType:     types.ChangeTypeImmediate,
+				DataType:       sources.PtrString("string"),
+				IsModifiable:   sources.PtrBool(true),
+				Source:         sources.PtrString("user"),
+			},
+		},
+	}, nil
+}
+
+func (m mockElastiCacheClient) DescribeCacheSubnetGroups(ctx context.Context, params *elasticache.DescribeCacheSubnetGroupsInput, optFns ...func(*elasticache.Options)) (*elasticache.DescribeCacheSubnetGroupsOutput, error) {
+	return nil, nil
+}
+
+func (m mockElastiCacheClient) DescribeReplicationGroups(ctx context.Context, params *elasticache.DescribeReplicationGroupsInput, optFns ...func(*elasticache.Options)) (*elasticache.DescribeReplicationGroupsOutput, error) {
+	return nil, nil
+}
+
+func (m mockElastiCacheClient) ListTagsForResource(ctx context.Context, params *elasticache.ListTagsForResourceInput, optFns ...func(*elasticache.Options)) (*elasticache.ListTagsForResourceOutput, error) {
+	return &elasticache.ListTagsForResourceOutput{
+		TagList: []types.Tag{
+			{
+				Key:   sources.PtrString("key"),
+				Value: sources.PtrString("value"),
+			},
+		},
+	}, nil
+}
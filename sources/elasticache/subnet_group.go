@@ -0,0 +1,125 @@
+package elasticache
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func subnetGroupOutputMapper(ctx context.Context, client elastiCacheClient, scope string, _ *elasticache.DescribeCacheSubnetGroupsInput, output *elasticache.DescribeCacheSubnetGroupsOutput) ([]*sdp.Item, error) {
+	items := make([]*sdp.Item, 0)
+
+	for _, sg := range output.CacheSubnetGroups {
+		attributes, err := sources.ToAttributesCase(sg)
+
+		if err != nil {
+			return nil, err
+		}
+
+		item := sdp.Item{
+			Type:            "elasticache-subnet-group",
+			UniqueAttribute: "cacheSubnetGroupName",
+			Attributes:      attributes,
+			Scope:           scope,
+			Tags:            getTags(ctx, client, sg.ARN),
+		}
+
+		if sg.VpcId != nil {
+			// +overmind:link ec2-vpc
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "ec2-vpc",
+					Method: sdp.QueryMethod_GET,
+					Query:  *sg.VpcId,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the VPC can affect the subnet group
+					In: true,
+					// The subnet group won't affect the VPC
+					Out: false,
+				},
+			})
+		}
+
+		for _, subnet := range sg.Subnets {
+			if subnet.SubnetIdentifier != nil {
+				// +overmind:link ec2-subnet
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "ec2-subnet",
+						Method: sdp.QueryMethod_GET,
+						Query:  *subnet.SubnetIdentifier,
+						Scope:  scope,
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Changing the subnet can affect the subnet group
+						In: true,
+						// The subnet group won't affect the subnet
+						Out: false,
+					},
+				})
+			}
+
+			if subnet.SubnetOutpost != nil && subnet.SubnetOutpost.SubnetOutpostArn != nil {
+				if a, err := sources.ParseARN(*subnet.SubnetOutpost.SubnetOutpostArn); err == nil {
+					// +overmind:link outposts-outpost
+					item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+						Query: &sdp.Query{
+							Type:   "outposts-outpost",
+							Method: sdp.QueryMethod_SEARCH,
+							Query:  *subnet.SubnetOutpost.SubnetOutpostArn,
+							Scope:  sources.FormatScope(a.AccountID, a.Region),
+						},
+						BlastPropagation: &sdp.BlastPropagation{
+							// Changing the outpost can affect the subnet group
+							In: true,
+							// The subnet group won't affect the outpost
+							Out: false,
+						},
+					})
+				}
+			}
+		}
+
+		items = append(items, &item)
+	}
+
+	return items, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type elasticache-subnet-group
+// +overmind:descriptiveType ElastiCache Subnet Group
+// +overmind:get Get a subnet group by name
+// +overmind:list List all subnet groups
+// +overmind:search Search for subnet groups by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_elasticache_subnet_group.name
+
+func NewSubnetGroupSource(config aws.Config, accountID string) *sources.DescribeOnlySource[*elasticache.DescribeCacheSubnetGroupsInput, *elasticache.DescribeCacheSubnetGroupsOutput, elastiCacheClient, *elasticache.Options] {
+	return &sources.DescribeOnlySource[*elasticache.DescribeCacheSubnetGroupsInput, *elasticache.DescribeCacheSubnetGroupsOutput, elastiCacheClient, *elasticache.Options]{
+		ItemType:  "elasticache-subnet-group",
+		Config:    config,
+		AccountID: accountID,
+		Client:    elasticache.NewFromConfig(config),
+		PaginatorBuilder: func(client elastiCacheClient, params *elasticache.DescribeCacheSubnetGroupsInput) sources.Paginator[*elasticache.DescribeCacheSubnetGroupsOutput, *elasticache.Options] {
+			return elasticache.NewDescribeCacheSubnetGroupsPaginator(client, params)
+		},
+		DescribeFunc: func(ctx context.Context, client elastiCacheClient, input *elasticache.DescribeCacheSubnetGroupsInput) (*elasticache.DescribeCacheSubnetGroupsOutput, error) {
+			return client.DescribeCacheSubnetGroups(ctx, input)
+		},
+		InputMapperGet: func(scope, query string) (*elasticache.DescribeCacheSubnetGroupsInput, error) {
+			return &elasticache.DescribeCacheSubnetGroupsInput{
+				CacheSubnetGroupName: &query,
+			}, nil
+		},
+		InputMapperList: func(scope string) (*elasticache.DescribeCacheSubnetGroupsInput, error) {
+			return &elasticache.DescribeCacheSubnetGroupsInput{}, nil
+		},
+		OutputMapper: subnetGroupOutputMapper,
+	}
+}
@@ -0,0 +1,98 @@
+package elasticache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func TestSubnetGroupOutputMapper(t *testing.T) {
+	output := elasticache.DescribeCacheSubnetGroupsOutput{
+		CacheSubnetGroups: []types.CacheSubnetGroup{
+			{
+				ARN:                         sources.PtrString("arn:aws:elasticache:eu-west-2:052392120703:subnetgroup:redis-subnets"),
+				CacheSubnetGroupDescription: sources.PtrString("Subnets for Redis"),
+				CacheSubnetGroupName:        sources.PtrString("redis-subnets"),
+				VpcId:                       sources.PtrString("vpc-0d7892e00e573e701"), // link
+				Subnets: []types.Subnet{
+					{
+						SubnetIdentifier: sources.PtrString("subnet-0450a637af9984235"), // link
+						SubnetAvailabilityZone: &types.AvailabilityZone{
+							Name: sources.PtrString("eu-west-2c"),
+						},
+						SubnetOutpost: &types.SubnetOutpost{
+							SubnetOutpostArn: sources.PtrString("arn:aws:service:region:account:type/id"), // link
+						},
+						SupportedNetworkTypes: []types.NetworkType{
+							types.NetworkTypeIpv4,
+						},
+					},
+				},
+				SupportedNetworkTypes: []types.NetworkType{
+					types.NetworkTypeIpv4,
+				},
+			},
+		},
+	}
+
+	items, err := subnetGroupOutputMapper(context.Background(), mockElastiCacheClient{}, "foo", nil, &output)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 1 {
+		t.Fatalf("got %v items, expected 1", len(items))
+	}
+
+	item := items[0]
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.Tags["key"] != "value" {
+		t.Errorf("expected key to be value, got %v", item.Tags["key"])
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "ec2-vpc",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "vpc-0d7892e00e573e701",
+			ExpectedScope:  "foo",
+		},
+		{
+			ExpectedType:   "ec2-subnet",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "subnet-0450a637af9984235",
+			ExpectedScope:  "foo",
+		},
+		{
+			ExpectedType:   "outposts-outpost",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:service:region:account:type/id",
+			ExpectedScope:  "account.region",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestNewSubnetGroupSource(t *testing.T) {
+	config, account, _ := sources.GetAutoConfig(t)
+
+	source := NewSubnetGroupSource(config, account)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -0,0 +1,150 @@
+package events
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func archiveGetFunc(ctx context.Context, client EventsClient, scope, query string) (*eventbridge.DescribeArchiveOutput, error) {
+	return client.DescribeArchive(ctx, &eventbridge.DescribeArchiveInput{
+		ArchiveName: &query,
+	})
+}
+
+// listArchives Lists archives and then describes each of them, since the list
+// output doesn't include the ARN or event pattern
+func listArchives(ctx context.Context, client EventsClient, input *eventbridge.ListArchivesInput) ([]*eventbridge.DescribeArchiveOutput, error) {
+	archives := make([]*eventbridge.DescribeArchiveOutput, 0)
+
+	for {
+		out, err := client.ListArchives(ctx, input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, archive := range out.Archives {
+			if archive.ArchiveName == nil {
+				continue
+			}
+
+			describeOut, err := archiveGetFunc(ctx, client, "", *archive.ArchiveName)
+
+			if err != nil {
+				return nil, err
+			}
+
+			archives = append(archives, describeOut)
+		}
+
+		if out.NextToken == nil {
+			break
+		}
+
+		input.NextToken = out.NextToken
+	}
+
+	return archives, nil
+}
+
+func archiveListFunc(ctx context.Context, client EventsClient, scope string) ([]*eventbridge.DescribeArchiveOutput, error) {
+	return listArchives(ctx, client, &eventbridge.ListArchivesInput{})
+}
+
+// archiveSearchFunc Searches for archives by their own ARN, or by the ARN of
+// the event bus that they archive events from
+func archiveSearchFunc(ctx context.Context, client EventsClient, scope, query string) ([]*eventbridge.DescribeArchiveOutput, error) {
+	if a, err := sources.ParseARN(query); err == nil && a.Type() == "archive" {
+		archive, err := archiveGetFunc(ctx, client, scope, a.ResourceID())
+
+		if err != nil {
+			return nil, err
+		}
+
+		return []*eventbridge.DescribeArchiveOutput{archive}, nil
+	}
+
+	return listArchives(ctx, client, &eventbridge.ListArchivesInput{
+		EventSourceArn: &query,
+	})
+}
+
+func archiveListTagsFunc(ctx context.Context, archive *eventbridge.DescribeArchiveOutput, client EventsClient) (map[string]string, error) {
+	return tagsByResourceARN(ctx, client, archive.ArchiveArn), nil
+}
+
+func archiveItemMapper(scope string, awsItem *eventbridge.DescribeArchiveOutput) (*sdp.Item, error) {
+	attributes, err := sources.ToAttributesCase(awsItem, "resultMetadata")
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := sdp.Item{
+		Type:            "events-archive",
+		UniqueAttribute: "archiveName",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	switch awsItem.State {
+	case types.ArchiveStateEnabled:
+		item.Health = sdp.Health_HEALTH_OK.Enum()
+	case types.ArchiveStateDisabled:
+		item.Health = sdp.Health_HEALTH_WARNING.Enum()
+	case types.ArchiveStateCreating, types.ArchiveStateUpdating:
+		item.Health = sdp.Health_HEALTH_PENDING.Enum()
+	case types.ArchiveStateCreateFailed, types.ArchiveStateUpdateFailed:
+		item.Health = sdp.Health_HEALTH_ERROR.Enum()
+	}
+
+	if awsItem.EventSourceArn != nil {
+		if a, err := sources.ParseARN(*awsItem.EventSourceArn); err == nil {
+			// +overmind:link events-event-bus
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "events-event-bus",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *awsItem.EventSourceArn,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the bus will affect what is archived
+					In: true,
+					// Changing the archive won't affect the bus
+					Out: false,
+				},
+			})
+		}
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type events-archive
+// +overmind:descriptiveType EventBridge Archive
+// +overmind:get Get an archive by name
+// +overmind:list List all archives
+// +overmind:search Search for archives by ARN, or by the ARN of their event bus
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_cloudwatch_event_archive.name
+
+func NewArchiveSource(config aws.Config, accountID string, region string) *sources.GetListSource[*eventbridge.DescribeArchiveOutput, EventsClient, *eventbridge.Options] {
+	return &sources.GetListSource[*eventbridge.DescribeArchiveOutput, EventsClient, *eventbridge.Options]{
+		ItemType:     "events-archive",
+		Client:       eventbridge.NewFromConfig(config),
+		AccountID:    accountID,
+		Region:       region,
+		GetFunc:      archiveGetFunc,
+		ListFunc:     archiveListFunc,
+		SearchFunc:   archiveSearchFunc,
+		ListTagsFunc: archiveListTagsFunc,
+		ItemMapper:   archiveItemMapper,
+	}
+}
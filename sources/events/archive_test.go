@@ -0,0 +1,111 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testEventsClient) DescribeArchive(ctx context.Context, params *eventbridge.DescribeArchiveInput, optFns ...func(*eventbridge.Options)) (*eventbridge.DescribeArchiveOutput, error) {
+	return &eventbridge.DescribeArchiveOutput{
+		ArchiveArn:     sources.PtrString("arn:aws:events:us-east-1:123456789012:archive/" + *params.ArchiveName),
+		ArchiveName:    params.ArchiveName,
+		CreationTime:   sources.PtrTime(time.Now()),
+		Description:    sources.PtrString("All order events"),
+		EventCount:     1024,
+		EventPattern:   sources.PtrString(`{"source":["com.example.orders"]}`),
+		EventSourceArn: sources.PtrString("arn:aws:events:us-east-1:123456789012:event-bus/orders"), // link
+		RetentionDays:  sources.PtrInt32(30),
+		SizeBytes:      2048,
+		State:          types.ArchiveStateEnabled, // health
+	}, nil
+}
+
+func (c testEventsClient) ListArchives(ctx context.Context, params *eventbridge.ListArchivesInput, optFns ...func(*eventbridge.Options)) (*eventbridge.ListArchivesOutput, error) {
+	return &eventbridge.ListArchivesOutput{
+		Archives: []types.Archive{
+			{
+				ArchiveName:    sources.PtrString("orders-archive"),
+				EventSourceArn: sources.PtrString("arn:aws:events:us-east-1:123456789012:event-bus/orders"),
+				State:          types.ArchiveStateEnabled,
+			},
+		},
+	}, nil
+}
+
+func TestArchiveGetFunc(t *testing.T) {
+	archive, err := archiveGetFunc(context.Background(), testEventsClient{}, "123456789012.us-east-1", "orders-archive")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := archiveItemMapper("123456789012.us-east-1", archive)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := item.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if item.GetHealth() != sdp.Health_HEALTH_OK {
+		t.Errorf("expected health OK, got %v", item.GetHealth())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "events-event-bus",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:events:us-east-1:123456789012:event-bus/orders",
+			ExpectedScope:  "123456789012.us-east-1",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestArchiveSearchFunc(t *testing.T) {
+	archives, err := archiveSearchFunc(context.Background(), testEventsClient{}, "123456789012.us-east-1", "arn:aws:events:us-east-1:123456789012:archive/orders-archive")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(archives) != 1 {
+		t.Fatalf("expected 1 archive, got %v", len(archives))
+	}
+
+	if *archives[0].ArchiveName != "orders-archive" {
+		t.Errorf("expected archive orders-archive, got %v", *archives[0].ArchiveName)
+	}
+
+	archives, err = archiveSearchFunc(context.Background(), testEventsClient{}, "123456789012.us-east-1", "arn:aws:events:us-east-1:123456789012:event-bus/orders")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(archives) != 1 {
+		t.Fatalf("expected 1 archive, got %v", len(archives))
+	}
+}
+
+func TestNewArchiveSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewArchiveSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
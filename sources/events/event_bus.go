@@ -0,0 +1,147 @@
+package events
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func eventBusGetFunc(ctx context.Context, client EventsClient, scope, query string) (*types.EventBus, error) {
+	out, err := client.DescribeEventBus(ctx, &eventbridge.DescribeEventBusInput{
+		Name: &query,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.EventBus{
+		Arn:    out.Arn,
+		Name:   out.Name,
+		Policy: out.Policy,
+	}, nil
+}
+
+// listEventBuses Lists all event buses in the region. EventBridge doesn't
+// provide paginators so we need to handle the tokens ourselves
+func listEventBuses(ctx context.Context, client EventsClient) ([]types.EventBus, error) {
+	buses := make([]types.EventBus, 0)
+	input := eventbridge.ListEventBusesInput{}
+
+	for {
+		out, err := client.ListEventBuses(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		buses = append(buses, out.EventBuses...)
+
+		if out.NextToken == nil {
+			break
+		}
+
+		input.NextToken = out.NextToken
+	}
+
+	return buses, nil
+}
+
+func eventBusListFunc(ctx context.Context, client EventsClient, scope string) ([]*types.EventBus, error) {
+	buses, err := listEventBuses(ctx, client)
+
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*types.EventBus, len(buses))
+
+	for i := range buses {
+		items[i] = &buses[i]
+	}
+
+	return items, nil
+}
+
+func eventBusListTagsFunc(ctx context.Context, bus *types.EventBus, client EventsClient) (map[string]string, error) {
+	return tagsByResourceARN(ctx, client, bus.Arn), nil
+}
+
+func eventBusItemMapper(scope string, awsItem *types.EventBus) (*sdp.Item, error) {
+	attributes, err := sources.ToAttributesCase(awsItem)
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := sdp.Item{
+		Type:            "events-event-bus",
+		UniqueAttribute: "name",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	if awsItem.Name != nil {
+		// +overmind:link events-rule
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "events-rule",
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  *awsItem.Name,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Changing the bus will affect the rules on it
+				Out: true,
+				// Changing a rule won't affect the bus
+				In: false,
+			},
+		})
+	}
+
+	if awsItem.Arn != nil {
+		// +overmind:link events-archive
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "events-archive",
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  *awsItem.Arn,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Changing the bus will affect what is archived
+				Out: true,
+				// Changing the archive won't affect the bus
+				In: false,
+			},
+		})
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type events-event-bus
+// +overmind:descriptiveType EventBridge Event Bus
+// +overmind:get Get an event bus by name
+// +overmind:list List all event buses
+// +overmind:search Search for event buses by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_cloudwatch_event_bus.name
+
+func NewEventBusSource(config aws.Config, accountID string, region string) *sources.GetListSource[*types.EventBus, EventsClient, *eventbridge.Options] {
+	return &sources.GetListSource[*types.EventBus, EventsClient, *eventbridge.Options]{
+		ItemType:     "events-event-bus",
+		Client:       eventbridge.NewFromConfig(config),
+		AccountID:    accountID,
+		Region:       region,
+		GetFunc:      eventBusGetFunc,
+		ListFunc:     eventBusListFunc,
+		ListTagsFunc: eventBusListTagsFunc,
+		ItemMapper:   eventBusItemMapper,
+	}
+}
@@ -0,0 +1,107 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testEventsClient) DescribeEventBus(ctx context.Context, params *eventbridge.DescribeEventBusInput, optFns ...func(*eventbridge.Options)) (*eventbridge.DescribeEventBusOutput, error) {
+	return &eventbridge.DescribeEventBusOutput{
+		Arn:    sources.PtrString("arn:aws:events:us-east-1:123456789012:event-bus/orders"),
+		Name:   sources.PtrString("orders"),
+		Policy: sources.PtrString(`{"Version":"2012-10-17","Statement":[]}`),
+	}, nil
+}
+
+func (c testEventsClient) ListEventBuses(ctx context.Context, params *eventbridge.ListEventBusesInput, optFns ...func(*eventbridge.Options)) (*eventbridge.ListEventBusesOutput, error) {
+	if params.NextToken == nil {
+		return &eventbridge.ListEventBusesOutput{
+			EventBuses: []types.EventBus{
+				{
+					Arn:  sources.PtrString("arn:aws:events:us-east-1:123456789012:event-bus/default"),
+					Name: sources.PtrString("default"),
+				},
+			},
+			NextToken: sources.PtrString("page2"),
+		}, nil
+	}
+
+	return &eventbridge.ListEventBusesOutput{
+		EventBuses: []types.EventBus{
+			{
+				Arn:  sources.PtrString("arn:aws:events:us-east-1:123456789012:event-bus/orders"),
+				Name: sources.PtrString("orders"),
+			},
+		},
+	}, nil
+}
+
+func TestEventBusGetFunc(t *testing.T) {
+	bus, err := eventBusGetFunc(context.Background(), testEventsClient{}, "123456789012.us-east-1", "orders")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := eventBusItemMapper("123456789012.us-east-1", bus)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := item.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "events-rule",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "orders",
+			ExpectedScope:  "123456789012.us-east-1",
+		},
+		{
+			ExpectedType:   "events-archive",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:events:us-east-1:123456789012:event-bus/orders",
+			ExpectedScope:  "123456789012.us-east-1",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestEventBusListFunc(t *testing.T) {
+	buses, err := eventBusListFunc(context.Background(), testEventsClient{}, "123456789012.us-east-1")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(buses) != 2 {
+		t.Fatalf("expected 2 event buses, got %v", len(buses))
+	}
+
+	if *buses[1].Name != "orders" {
+		t.Errorf("expected second bus to be orders, got %v", *buses[1].Name)
+	}
+}
+
+func TestNewEventBusSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewEventBusSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -0,0 +1,420 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+// The name of the event bus that is used when a rule doesn't specify one
+const defaultEventBusName = "default"
+
+type RuleDetails struct {
+	Rule    *types.Rule
+	Targets []types.Target
+}
+
+// parseRuleQuery Splits a rule query in the format {eventBusName}/{ruleName}
+// into its parts. If there is no event bus name, the default bus is assumed.
+// Rule names can't contain slashes but partner event bus names can, so we
+// split on the last one
+func parseRuleQuery(query string) (eventBusName string, ruleName string) {
+	i := strings.LastIndex(query, "/")
+
+	if i == -1 {
+		return defaultEventBusName, query
+	}
+
+	return query[:i], query[i+1:]
+}
+
+// getTargets Gets all targets for a given rule
+func getTargets(ctx context.Context, client EventsClient, eventBusName *string, ruleName *string) ([]types.Target, error) {
+	targets := make([]types.Target, 0)
+	input := eventbridge.ListTargetsByRuleInput{
+		Rule:         ruleName,
+		EventBusName: eventBusName,
+	}
+
+	for {
+		out, err := client.ListTargetsByRule(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		targets = append(targets, out.Targets...)
+
+		if out.NextToken == nil {
+			break
+		}
+
+		input.NextToken = out.NextToken
+	}
+
+	return targets, nil
+}
+
+// listRules Lists all rules on a given event bus, including their targets
+func listRules(ctx context.Context, client EventsClient, eventBusName *string) ([]*RuleDetails, error) {
+	rules := make([]*RuleDetails, 0)
+	input := eventbridge.ListRulesInput{
+		EventBusName: eventBusName,
+	}
+
+	for {
+		out, err := client.ListRules(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range out.Rules {
+			rule := out.Rules[i]
+
+			targets, err := getTargets(ctx, client, rule.EventBusName, rule.Name)
+
+			if err != nil {
+				return nil, err
+			}
+
+			rules = append(rules, &RuleDetails{
+				Rule:    &rule,
+				Targets: targets,
+			})
+		}
+
+		if out.NextToken == nil {
+			break
+		}
+
+		input.NextToken = out.NextToken
+	}
+
+	return rules, nil
+}
+
+func ruleGetFunc(ctx context.Context, client EventsClient, scope, query string) (*RuleDetails, error) {
+	eventBusName, ruleName := parseRuleQuery(query)
+
+	out, err := client.DescribeRule(ctx, &eventbridge.DescribeRuleInput{
+		Name:         &ruleName,
+		EventBusName: &eventBusName,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := getTargets(ctx, client, out.EventBusName, out.Name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &RuleDetails{
+		Rule: &types.Rule{
+			Arn:                out.Arn,
+			Description:        out.Description,
+			EventBusName:       out.EventBusName,
+			EventPattern:       out.EventPattern,
+			ManagedBy:          out.ManagedBy,
+			Name:               out.Name,
+			RoleArn:            out.RoleArn,
+			ScheduleExpression: out.ScheduleExpression,
+			State:              out.State,
+		},
+		Targets: targets,
+	}, nil
+}
+
+func ruleListFunc(ctx context.Context, client EventsClient, scope string) ([]*RuleDetails, error) {
+	buses, err := listEventBuses(ctx, client)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]*RuleDetails, 0)
+
+	for _, bus := range buses {
+		busRules, err := listRules(ctx, client, bus.Name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, busRules...)
+	}
+
+	return rules, nil
+}
+
+// ruleSearchFunc Searches for rules by ARN, or by the name or ARN of the event
+// bus that they are on
+func ruleSearchFunc(ctx context.Context, client EventsClient, scope, query string) ([]*RuleDetails, error) {
+	eventBusName := query
+
+	if a, err := sources.ParseARN(query); err == nil {
+		switch a.Type() {
+		case "rule":
+			rule, err := ruleGetFunc(ctx, client, scope, a.ResourceID())
+
+			if err != nil {
+				return nil, err
+			}
+
+			return []*RuleDetails{rule}, nil
+		case "event-bus":
+			eventBusName = a.ResourceID()
+		default:
+			return nil, &sdp.QueryError{
+				ErrorType:   sdp.QueryError_NOTFOUND,
+				ErrorString: fmt.Sprintf("ARN %v is not a rule or event bus", query),
+			}
+		}
+	}
+
+	return listRules(ctx, client, &eventBusName)
+}
+
+func ruleListTagsFunc(ctx context.Context, rule *RuleDetails, client EventsClient) (map[string]string, error) {
+	return tagsByResourceARN(ctx, client, rule.Rule.Arn), nil
+}
+
+// targetLinks Returns the links for a given rule target based on the type of
+// resource that the target ARN refers to
+func targetLinks(target types.Target) []*sdp.LinkedItemQuery {
+	links := make([]*sdp.LinkedItemQuery, 0)
+
+	if target.Arn == nil {
+		return links
+	}
+
+	a, err := sources.ParseARN(*target.Arn)
+
+	if err != nil {
+		return links
+	}
+
+	var queryType string
+
+	switch a.Service {
+	case "lambda":
+		queryType = "lambda-function"
+	case "sqs":
+		queryType = "sqs-queue"
+	case "sns":
+		queryType = "sns-topic"
+	case "kinesis":
+		queryType = "kinesis-stream"
+	case "firehose":
+		queryType = "firehose-delivery-stream"
+	case "events":
+		// This could also be an API destination, which we don't support yet
+		if a.Type() == "event-bus" {
+			queryType = "events-event-bus"
+		}
+	case "ecs":
+		// For ECS targets the ARN is the cluster, and the task definition
+		// is in the parameters
+		queryType = "ecs-cluster"
+	}
+
+	if queryType != "" {
+		links = append(links, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   queryType,
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  *target.Arn,
+				Scope:  sources.FormatScope(a.AccountID, a.Region),
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// The rule and its targets make up a pipeline, so changes to
+				// either will affect the other
+				In:  true,
+				Out: true,
+			},
+		})
+	}
+
+	if target.EcsParameters != nil && target.EcsParameters.TaskDefinitionArn != nil {
+		if a, err := sources.ParseARN(*target.EcsParameters.TaskDefinitionArn); err == nil {
+			links = append(links, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "ecs-task-definition",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *target.EcsParameters.TaskDefinitionArn,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// The task definition is what will be run by the rule
+					In:  true,
+					Out: true,
+				},
+			})
+		}
+	}
+
+	if target.RoleArn != nil {
+		if a, err := sources.ParseARN(*target.RoleArn); err == nil {
+			links = append(links, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "iam-role",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *target.RoleArn,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the role will affect whether the target can be
+					// invoked
+					In: true,
+					// Changing the rule won't affect the role
+					Out: false,
+				},
+			})
+		}
+	}
+
+	if target.DeadLetterConfig != nil && target.DeadLetterConfig.Arn != nil {
+		if a, err := sources.ParseARN(*target.DeadLetterConfig.Arn); err == nil {
+			links = append(links, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "sqs-queue",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *target.DeadLetterConfig.Arn,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the queue could mean that failed events are
+					// lost
+					In: true,
+					// Failed events will be sent to the queue
+					Out: true,
+				},
+			})
+		}
+	}
+
+	return links
+}
+
+func ruleItemMapper(scope string, awsItem *RuleDetails) (*sdp.Item, error) {
+	enrichedRule := struct {
+		*types.Rule
+		Targets []types.Target
+	}{
+		Rule:    awsItem.Rule,
+		Targets: awsItem.Targets,
+	}
+
+	attributes, err := sources.ToAttributesCase(enrichedRule)
+
+	if err != nil {
+		return nil, err
+	}
+
+	eventBusName := defaultEventBusName
+
+	if awsItem.Rule.EventBusName != nil {
+		eventBusName = *awsItem.Rule.EventBusName
+	}
+
+	if awsItem.Rule.Name != nil {
+		// Rule names are only unique within an event bus, so we need to
+		// include the bus in the unique attribute
+		err = attributes.Set("fullName", eventBusName+"/"+*awsItem.Rule.Name)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	item := sdp.Item{
+		Type:            "events-rule",
+		UniqueAttribute: "fullName",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	// +overmind:link events-event-bus
+	item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+		Query: &sdp.Query{
+			Type:   "events-event-bus",
+			Method: sdp.QueryMethod_GET,
+			Query:  eventBusName,
+			Scope:  scope,
+		},
+		BlastPropagation: &sdp.BlastPropagation{
+			// Changing the bus will affect the rule
+			In: true,
+			// Changing the rule won't affect the bus
+			Out: false,
+		},
+	})
+
+	if awsItem.Rule.RoleArn != nil {
+		if a, err := sources.ParseARN(*awsItem.Rule.RoleArn); err == nil {
+			// +overmind:link iam-role
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "iam-role",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *awsItem.Rule.RoleArn,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the role will affect the rule
+					In: true,
+					// Changing the rule won't affect the role
+					Out: false,
+				},
+			})
+		}
+	}
+
+	for _, target := range awsItem.Targets {
+		// +overmind:link lambda-function
+		// +overmind:link sqs-queue
+		// +overmind:link sns-topic
+		// +overmind:link kinesis-stream
+		// +overmind:link firehose-delivery-stream
+		// +overmind:link events-event-bus
+		// +overmind:link ecs-cluster
+		// +overmind:link ecs-task-definition
+		// +overmind:link iam-role
+		item.LinkedItemQueries = append(item.LinkedItemQueries, targetLinks(target)...)
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type events-rule
+// +overmind:descriptiveType EventBridge Rule
+// +overmind:get Get a rule by full name ({eventBusName}/{ruleName})
+// +overmind:list List all rules on all event buses
+// +overmind:search Search for rules by ARN, or by event bus name or ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_cloudwatch_event_rule.arn
+// +overmind:terraform:method SEARCH
+
+func NewRuleSource(config aws.Config, accountID string, region string) *sources.GetListSource[*RuleDetails, EventsClient, *eventbridge.Options] {
+	return &sources.GetListSource[*RuleDetails, EventsClient, *eventbridge.Options]{
+		ItemType:     "events-rule",
+		Client:       eventbridge.NewFromConfig(config),
+		AccountID:    accountID,
+		Region:       region,
+		GetFunc:      ruleGetFunc,
+		ListFunc:     ruleListFunc,
+		SearchFunc:   ruleSearchFunc,
+		ListTagsFunc: ruleListTagsFunc,
+		ItemMapper:   ruleItemMapper,
+	}
+}
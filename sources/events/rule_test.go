@@ -0,0 +1,295 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testEventsClient) DescribeRule(ctx context.Context, params *eventbridge.DescribeRuleInput, optFns ...func(*eventbridge.Options)) (*eventbridge.DescribeRuleOutput, error) {
+	return &eventbridge.DescribeRuleOutput{
+		Arn:          sources.PtrString("arn:aws:events:us-east-1:123456789012:rule/orders/order-created"),
+		Description:  sources.PtrString("Routes new orders"),
+		EventBusName: params.EventBusName,
+		EventPattern: sources.PtrString(`{"source":["com.example.orders"]}`),
+		Name:         params.Name,
+		RoleArn:      sources.PtrString("arn:aws:iam::123456789012:role/events-invoke"), // link
+		State:        types.RuleStateEnabled,
+	}, nil
+}
+
+func (c testEventsClient) ListRules(ctx context.Context, params *eventbridge.ListRulesInput, optFns ...func(*eventbridge.Options)) (*eventbridge.ListRulesOutput, error) {
+	return &eventbridge.ListRulesOutput{
+		Rules: []types.Rule{
+			{
+				Arn:          sources.PtrString("arn:aws:events:us-east-1:123456789012:rule/" + *params.EventBusName + "/order-created"),
+				EventBusName: params.EventBusName,
+				Name:         sources.PtrString("order-created"),
+				State:        types.RuleStateEnabled,
+			},
+		},
+	}, nil
+}
+
+func (c testEventsClient) ListTargetsByRule(ctx context.Context, params *eventbridge.ListTargetsByRuleInput, optFns ...func(*eventbridge.Options)) (*eventbridge.ListTargetsByRuleOutput, error) {
+	return &eventbridge.ListTargetsByRuleOutput{
+		Targets: []types.Target{
+			{
+				Id:  sources.PtrString("lambda"),
+				Arn: sources.PtrString("arn:aws:lambda:us-east-1:123456789012:function:process-order"), // link
+				DeadLetterConfig: &types.DeadLetterConfig{
+					Arn: sources.PtrString("arn:aws:sqs:us-east-1:123456789012:order-dlq"), // link
+				},
+				RetryPolicy: &types.RetryPolicy{
+					MaximumEventAgeInSeconds: sources.PtrInt32(3600),
+					MaximumRetryAttempts:     sources.PtrInt32(3),
+				},
+			},
+			{
+				Id:  sources.PtrString("sqs"),
+				Arn: sources.PtrString("arn:aws:sqs:us-east-1:123456789012:orders"), // link
+				InputTransformer: &types.InputTransformer{
+					InputPathsMap: map[string]string{
+						"id": "$.detail.id",
+					},
+					InputTemplate: sources.PtrString(`{"orderId": <id>}`),
+				},
+			},
+			{
+				Id:  sources.PtrString("sns"),
+				Arn: sources.PtrString("arn:aws:sns:us-east-1:123456789012:orders"), // link
+			},
+			{
+				Id:      sources.PtrString("kinesis"),
+				Arn:     sources.PtrString("arn:aws:kinesis:us-east-1:123456789012:stream/orders"), // link
+				RoleArn: sources.PtrString("arn:aws:iam::123456789012:role/events-kinesis"),        // link
+			},
+			{
+				Id:  sources.PtrString("bus"),
+				Arn: sources.PtrString("arn:aws:events:eu-west-1:123456789012:event-bus/central"), // link
+			},
+			{
+				Id:      sources.PtrString("ecs"),
+				Arn:     sources.PtrString("arn:aws:ecs:us-east-1:123456789012:cluster/workers"), // link
+				RoleArn: sources.PtrString("arn:aws:iam::123456789012:role/events-ecs"),
+				EcsParameters: &types.EcsParameters{
+					TaskDefinitionArn: sources.PtrString("arn:aws:ecs:us-east-1:123456789012:task-definition/fulfil:3"), // link
+				},
+			},
+		},
+	}, nil
+}
+
+func TestParseRuleQuery(t *testing.T) {
+	tests := []struct {
+		Query        string
+		EventBusName string
+		RuleName     string
+	}{
+		{
+			Query:        "my-rule",
+			EventBusName: "default",
+			RuleName:     "my-rule",
+		},
+		{
+			Query:        "orders/my-rule",
+			EventBusName: "orders",
+			RuleName:     "my-rule",
+		},
+		{
+			Query:        "aws.partner/example.com/123/my-rule",
+			EventBusName: "aws.partner/example.com/123",
+			RuleName:     "my-rule",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Query, func(t *testing.T) {
+			eventBusName, ruleName := parseRuleQuery(test.Query)
+
+			if eventBusName != test.EventBusName {
+				t.Errorf("expected event bus name %v, got %v", test.EventBusName, eventBusName)
+			}
+
+			if ruleName != test.RuleName {
+				t.Errorf("expected rule name %v, got %v", test.RuleName, ruleName)
+			}
+		})
+	}
+}
+
+func TestRuleGetFunc(t *testing.T) {
+	rule, err := ruleGetFunc(context.Background(), testEventsClient{}, "123456789012.us-east-1", "orders/order-created")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := ruleItemMapper("123456789012.us-east-1", rule)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := item.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if item.UniqueAttributeValue() != "orders/order-created" {
+		t.Errorf("expected unique attribute value orders/order-created, got %v", item.UniqueAttributeValue())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "events-event-bus",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "orders",
+			ExpectedScope:  "123456789012.us-east-1",
+		},
+		{
+			ExpectedType:   "iam-role",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:iam::123456789012:role/events-invoke",
+			ExpectedScope:  "123456789012",
+		},
+		{
+			ExpectedType:   "lambda-function",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:lambda:us-east-1:123456789012:function:process-order",
+			ExpectedScope:  "123456789012.us-east-1",
+		},
+		{
+			ExpectedType:   "sqs-queue",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:sqs:us-east-1:123456789012:order-dlq",
+			ExpectedScope:  "123456789012.us-east-1",
+		},
+		{
+			ExpectedType:   "sqs-queue",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:sqs:us-east-1:123456789012:orders",
+			ExpectedScope:  "123456789012.us-east-1",
+		},
+		{
+			ExpectedType:   "sns-topic",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:sns:us-east-1:123456789012:orders",
+			ExpectedScope:  "123456789012.us-east-1",
+		},
+		{
+			ExpectedType:   "kinesis-stream",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:kinesis:us-east-1:123456789012:stream/orders",
+			ExpectedScope:  "123456789012.us-east-1",
+		},
+		{
+			ExpectedType:   "iam-role",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:iam::123456789012:role/events-kinesis",
+			ExpectedScope:  "123456789012",
+		},
+		{
+			ExpectedType:   "events-event-bus",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:events:eu-west-1:123456789012:event-bus/central",
+			ExpectedScope:  "123456789012.eu-west-1",
+		},
+		{
+			ExpectedType:   "ecs-cluster",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:ecs:us-east-1:123456789012:cluster/workers",
+			ExpectedScope:  "123456789012.us-east-1",
+		},
+		{
+			ExpectedType:   "ecs-task-definition",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:ecs:us-east-1:123456789012:task-definition/fulfil:3",
+			ExpectedScope:  "123456789012.us-east-1",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestRuleListFunc(t *testing.T) {
+	rules, err := ruleListFunc(context.Background(), testEventsClient{}, "123456789012.us-east-1")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// One rule on each of the two buses
+	if len(rules) != 2 {
+		t.Fatalf("expected 2 rules, got %v", len(rules))
+	}
+
+	for _, rule := range rules {
+		if len(rule.Targets) != 6 {
+			t.Errorf("expected 6 targets, got %v", len(rule.Targets))
+		}
+	}
+}
+
+func TestRuleSearchFunc(t *testing.T) {
+	t.Run("by rule ARN", func(t *testing.T) {
+		rules, err := ruleSearchFunc(context.Background(), testEventsClient{}, "123456789012.us-east-1", "arn:aws:events:us-east-1:123456789012:rule/orders/order-created")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(rules) != 1 {
+			t.Fatalf("expected 1 rule, got %v", len(rules))
+		}
+
+		if *rules[0].Rule.EventBusName != "orders" {
+			t.Errorf("expected event bus orders, got %v", *rules[0].Rule.EventBusName)
+		}
+	})
+
+	t.Run("by event bus ARN", func(t *testing.T) {
+		rules, err := ruleSearchFunc(context.Background(), testEventsClient{}, "123456789012.us-east-1", "arn:aws:events:us-east-1:123456789012:event-bus/orders")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(rules) != 1 {
+			t.Fatalf("expected 1 rule, got %v", len(rules))
+		}
+
+		if *rules[0].Rule.EventBusName != "orders" {
+			t.Errorf("expected event bus orders, got %v", *rules[0].Rule.EventBusName)
+		}
+	})
+
+	t.Run("by event bus name", func(t *testing.T) {
+		rules, err := ruleSearchFunc(context.Background(), testEventsClient{}, "123456789012.us-east-1", "orders")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(rules) != 1 {
+			t.Fatalf("expected 1 rule, got %v", len(rules))
+		}
+	})
+}
+
+func TestNewRuleSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewRuleSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
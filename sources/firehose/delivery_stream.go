@@ -0,0 +1,620 @@
+package firehose
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/firehose"
+	"github.com/aws/aws-sdk-go-v2/service/firehose/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func deliveryStreamGetFunc(ctx context.Context, client FirehoseClient, scope, query string) (*types.DeliveryStreamDescription, error) {
+	out, err := client.DescribeDeliveryStream(ctx, &firehose.DescribeDeliveryStreamInput{
+		DeliveryStreamName: &query,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if out.DeliveryStreamDescription == nil {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: "delivery stream description was nil",
+		}
+	}
+
+	return out.DeliveryStreamDescription, nil
+}
+
+// deliveryStreamListFunc Lists all delivery streams and then describes each of
+// them. Firehose doesn't provide paginators so we need to handle the
+// continuation ourselves
+func deliveryStreamListFunc(ctx context.Context, client FirehoseClient, scope string) ([]*types.DeliveryStreamDescription, error) {
+	streams := make([]*types.DeliveryStreamDescription, 0)
+	input := firehose.ListDeliveryStreamsInput{}
+
+	for {
+		out, err := client.ListDeliveryStreams(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range out.DeliveryStreamNames {
+			stream, err := deliveryStreamGetFunc(ctx, client, scope, name)
+
+			if err != nil {
+				return nil, err
+			}
+
+			streams = append(streams, stream)
+		}
+
+		if out.HasMoreDeliveryStreams == nil || !*out.HasMoreDeliveryStreams || len(out.DeliveryStreamNames) == 0 {
+			break
+		}
+
+		input.ExclusiveStartDeliveryStreamName = &out.DeliveryStreamNames[len(out.DeliveryStreamNames)-1]
+	}
+
+	return streams, nil
+}
+
+func deliveryStreamListTagsFunc(ctx context.Context, stream *types.DeliveryStreamDescription, client FirehoseClient) (map[string]string, error) {
+	tags := make([]types.Tag, 0)
+	input := firehose.ListTagsForDeliveryStreamInput{
+		DeliveryStreamName: stream.DeliveryStreamName,
+	}
+
+	for {
+		out, err := client.ListTagsForDeliveryStream(ctx, &input)
+
+		if err != nil {
+			return sources.HandleTagsError(ctx, err), nil
+		}
+
+		tags = append(tags, out.Tags...)
+
+		if out.HasMoreTags == nil || !*out.HasMoreTags || len(out.Tags) == 0 {
+			break
+		}
+
+		input.ExclusiveStartTagKey = out.Tags[len(out.Tags)-1].Key
+	}
+
+	return tagsToMap(tags), nil
+}
+
+// roleLink Returns a link to an IAM role that the delivery stream assumes
+func roleLink(roleARN *string) []*sdp.LinkedItemQuery {
+	if roleARN == nil {
+		return nil
+	}
+
+	a, err := sources.ParseARN(*roleARN)
+
+	if err != nil {
+		return nil
+	}
+
+	return []*sdp.LinkedItemQuery{
+		{
+			Query: &sdp.Query{
+				Type:   "iam-role",
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  *roleARN,
+				Scope:  sources.FormatScope(a.AccountID, a.Region),
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Changing the role will affect whether the stream can
+				// read or deliver data
+				In: true,
+				// Changing the stream won't affect the role
+				Out: false,
+			},
+		},
+	}
+}
+
+// httpLink Returns a link to an HTTP endpoint that data is delivered to
+func httpLink(endpoint *string) []*sdp.LinkedItemQuery {
+	if endpoint == nil || *endpoint == "" {
+		return nil
+	}
+
+	return []*sdp.LinkedItemQuery{
+		{
+			Query: &sdp.Query{
+				Type:   "http",
+				Method: sdp.QueryMethod_GET,
+				Query:  *endpoint,
+				Scope:  "global",
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// If the endpoint is down then delivery will fail
+				In: true,
+				// Data will be delivered to the endpoint
+				Out: true,
+			},
+		},
+	}
+}
+
+// loggingLinks Returns a link to the log group that delivery errors are
+// written to
+func loggingLinks(options *types.CloudWatchLoggingOptions, scope string) []*sdp.LinkedItemQuery {
+	if options == nil || options.LogGroupName == nil {
+		return nil
+	}
+
+	return []*sdp.LinkedItemQuery{
+		{
+			Query: &sdp.Query{
+				Type:   "logs-log-group",
+				Method: sdp.QueryMethod_GET,
+				Query:  *options.LogGroupName,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Changing the log group won't affect delivery
+				In: false,
+				// Errors will be logged to the log group
+				Out: true,
+			},
+		},
+	}
+}
+
+// processingLinks Returns links to the Lambda functions used to transform
+// records before delivery
+func processingLinks(config *types.ProcessingConfiguration) []*sdp.LinkedItemQuery {
+	links := make([]*sdp.LinkedItemQuery, 0)
+
+	if config == nil {
+		return links
+	}
+
+	for _, processor := range config.Processors {
+		if processor.Type != types.ProcessorTypeLambda {
+			continue
+		}
+
+		for _, param := range processor.Parameters {
+			if param.ParameterName != types.ProcessorParameterNameLambdaArn || param.ParameterValue == nil {
+				continue
+			}
+
+			if a, err := sources.ParseARN(*param.ParameterValue); err == nil {
+				links = append(links, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "lambda-function",
+						Method: sdp.QueryMethod_SEARCH,
+						Query:  *param.ParameterValue,
+						Scope:  sources.FormatScope(a.AccountID, a.Region),
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Changing the function will affect the data that is
+						// delivered
+						In: true,
+						// Records are sent to the function for transformation
+						Out: true,
+					},
+				})
+			}
+		}
+	}
+
+	return links
+}
+
+// vpcLinks Returns links to the networking that a destination is delivered
+// through
+func vpcLinks(config *types.VpcConfigurationDescription, scope string) []*sdp.LinkedItemQuery {
+	links := make([]*sdp.LinkedItemQuery, 0)
+
+	if config == nil {
+		return links
+	}
+
+	if config.VpcId != nil {
+		links = append(links, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "ec2-vpc",
+				Method: sdp.QueryMethod_GET,
+				Query:  *config.VpcId,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Changing the VPC will affect delivery
+				In: true,
+				// Changing the stream won't affect the VPC
+				Out: false,
+			},
+		})
+	}
+
+	for _, subnetID := range config.SubnetIds {
+		links = append(links, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "ec2-subnet",
+				Method: sdp.QueryMethod_GET,
+				Query:  subnetID,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Changing the subnet will affect delivery
+				In: true,
+				// Changing the stream won't affect the subnet
+				Out: false,
+			},
+		})
+	}
+
+	for _, sgID := range config.SecurityGroupIds {
+		links = append(links, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "ec2-security-group",
+				Method: sdp.QueryMethod_GET,
+				Query:  sgID,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Changing the security group will affect delivery
+				In: true,
+				// Changing the stream won't affect the security group
+				Out: false,
+			},
+		})
+	}
+
+	links = append(links, roleLink(config.RoleARN)...)
+
+	return links
+}
+
+// s3DestinationLinks Returns links for an S3 destination. This is used both
+// for S3 as the primary destination, and for the S3 backup of other
+// destinations
+func s3DestinationLinks(destination *types.S3DestinationDescription, scope string) []*sdp.LinkedItemQuery {
+	links := make([]*sdp.LinkedItemQuery, 0)
+
+	if destination == nil {
+		return links
+	}
+
+	if destination.BucketARN != nil {
+		if a, err := sources.ParseARN(*destination.BucketARN); err == nil {
+			// Bucket ARNs don't include the account, so assume it's the same
+			// as the stream's
+			accountID, _, _ := sources.ParseScope(scope)
+
+			links = append(links, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "s3-bucket",
+					Method: sdp.QueryMethod_GET,
+					Query:  a.Resource,
+					Scope:  sources.FormatScope(accountID, ""),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the bucket will affect delivery
+					In: true,
+					// Data will be delivered to the bucket
+					Out: true,
+				},
+			})
+		}
+	}
+
+	if destination.EncryptionConfiguration != nil && destination.EncryptionConfiguration.KMSEncryptionConfig != nil && destination.EncryptionConfiguration.KMSEncryptionConfig.AWSKMSKeyARN != nil {
+		keyARN := *destination.EncryptionConfiguration.KMSEncryptionConfig.AWSKMSKeyARN
+
+		if a, err := sources.ParseARN(keyARN); err == nil {
+			links = append(links, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "kms-key",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  keyARN,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the key will affect delivery
+					In: true,
+					// Changing the stream won't affect the key
+					Out: false,
+				},
+			})
+		}
+	}
+
+	links = append(links, roleLink(destination.RoleARN)...)
+	links = append(links, loggingLinks(destination.CloudWatchLoggingOptions, scope)...)
+
+	return links
+}
+
+// redshiftLinks Returns links to the Redshift cluster that data is delivered
+// to, based on the JDBC URL. This will be in the format:
+// jdbc:redshift://{cluster}.{id}.{region}.redshift.amazonaws.com:5439/{db}
+func redshiftLinks(jdbcURL *string, scope string) []*sdp.LinkedItemQuery {
+	links := make([]*sdp.LinkedItemQuery, 0)
+
+	if jdbcURL == nil {
+		return links
+	}
+
+	u, err := url.Parse(strings.TrimPrefix(*jdbcURL, "jdbc:"))
+
+	if err != nil || u.Hostname() == "" {
+		return links
+	}
+
+	hostname := u.Hostname()
+
+	links = append(links, &sdp.LinkedItemQuery{
+		Query: &sdp.Query{
+			Type:   "dns",
+			Method: sdp.QueryMethod_SEARCH,
+			Query:  hostname,
+			Scope:  "global",
+		},
+		BlastPropagation: &sdp.BlastPropagation{
+			// DNS is always linked
+			In:  true,
+			Out: true,
+		},
+	})
+
+	if strings.HasSuffix(hostname, ".redshift.amazonaws.com") {
+		sections := strings.Split(hostname, ".")
+
+		// The region is the third section of the hostname. The account isn't
+		// included so assume it's the same as the stream's
+		if len(sections) > 3 {
+			accountID, _, _ := sources.ParseScope(scope)
+
+			links = append(links, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "redshift-cluster",
+					Method: sdp.QueryMethod_GET,
+					Query:  sections[0],
+					Scope:  sources.FormatScope(accountID, sections[2]),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the cluster will affect delivery
+					In: true,
+					// Data will be delivered to the cluster
+					Out: true,
+				},
+			})
+		}
+	}
+
+	return links
+}
+
+// openSearchDomainLink Returns a link to the OpenSearch (or Elasticsearch)
+// domain that data is delivered to
+func openSearchDomainLink(domainARN *string) []*sdp.LinkedItemQuery {
+	if domainARN == nil {
+		return nil
+	}
+
+	a, err := sources.ParseARN(*domainARN)
+
+	if err != nil {
+		return nil
+	}
+
+	return []*sdp.LinkedItemQuery{
+		{
+			Query: &sdp.Query{
+				Type:   "opensearch-domain",
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  *domainARN,
+				Scope:  sources.FormatScope(a.AccountID, a.Region),
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Changing the domain will affect delivery
+				In: true,
+				// Data will be delivered to the domain
+				Out: true,
+			},
+		},
+	}
+}
+
+func deliveryStreamItemMapper(scope string, awsItem *types.DeliveryStreamDescription) (*sdp.Item, error) {
+	attributes, err := sources.ToAttributesCase(awsItem)
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := sdp.Item{
+		Type:            "firehose-delivery-stream",
+		UniqueAttribute: "deliveryStreamName",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	switch awsItem.DeliveryStreamStatus {
+	case types.DeliveryStreamStatusActive:
+		item.Health = sdp.Health_HEALTH_OK.Enum()
+	case types.DeliveryStreamStatusCreating:
+		item.Health = sdp.Health_HEALTH_PENDING.Enum()
+	case types.DeliveryStreamStatusDeleting:
+		item.Health = sdp.Health_HEALTH_WARNING.Enum()
+	case types.DeliveryStreamStatusCreatingFailed, types.DeliveryStreamStatusDeletingFailed:
+		item.Health = sdp.Health_HEALTH_ERROR.Enum()
+	}
+
+	if awsItem.Source != nil && awsItem.Source.KinesisStreamSourceDescription != nil {
+		source := awsItem.Source.KinesisStreamSourceDescription
+
+		if source.KinesisStreamARN != nil {
+			if a, err := sources.ParseARN(*source.KinesisStreamARN); err == nil {
+				// +overmind:link kinesis-stream
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "kinesis-stream",
+						Method: sdp.QueryMethod_SEARCH,
+						Query:  *source.KinesisStreamARN,
+						Scope:  sources.FormatScope(a.AccountID, a.Region),
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Changing the source stream will affect what is
+						// delivered
+						In: true,
+						// Changing the delivery stream won't affect the source
+						Out: false,
+					},
+				})
+			}
+		}
+
+		// +overmind:link iam-role
+		item.LinkedItemQueries = append(item.LinkedItemQueries, roleLink(source.RoleARN)...)
+	}
+
+	if awsItem.DeliveryStreamEncryptionConfiguration != nil && awsItem.DeliveryStreamEncryptionConfiguration.KeyARN != nil {
+		if a, err := sources.ParseARN(*awsItem.DeliveryStreamEncryptionConfiguration.KeyARN); err == nil {
+			// +overmind:link kms-key
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "kms-key",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *awsItem.DeliveryStreamEncryptionConfiguration.KeyARN,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the key will affect the stream
+					In: true,
+					// Changing the stream won't affect the key
+					Out: false,
+				},
+			})
+		}
+	}
+
+	for _, destination := range awsItem.Destinations {
+		// +overmind:link s3-bucket
+		// +overmind:link kms-key
+		// +overmind:link iam-role
+		// +overmind:link logs-log-group
+		// +overmind:link lambda-function
+		if d := destination.ExtendedS3DestinationDescription; d != nil {
+			item.LinkedItemQueries = append(item.LinkedItemQueries, s3DestinationLinks(&types.S3DestinationDescription{
+				BucketARN:                d.BucketARN,
+				EncryptionConfiguration:  d.EncryptionConfiguration,
+				RoleARN:                  d.RoleARN,
+				CloudWatchLoggingOptions: d.CloudWatchLoggingOptions,
+			}, scope)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, s3DestinationLinks(d.S3BackupDescription, scope)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, processingLinks(d.ProcessingConfiguration)...)
+		} else if d := destination.S3DestinationDescription; d != nil {
+			// The plain S3 destination is also returned alongside the
+			// extended one, so we only use it if there is no extended one
+			item.LinkedItemQueries = append(item.LinkedItemQueries, s3DestinationLinks(d, scope)...)
+		}
+
+		// +overmind:link redshift-cluster
+		// +overmind:link dns
+		if d := destination.RedshiftDestinationDescription; d != nil {
+			item.LinkedItemQueries = append(item.LinkedItemQueries, redshiftLinks(d.ClusterJDBCURL, scope)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, roleLink(d.RoleARN)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, loggingLinks(d.CloudWatchLoggingOptions, scope)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, s3DestinationLinks(d.S3DestinationDescription, scope)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, s3DestinationLinks(d.S3BackupDescription, scope)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, processingLinks(d.ProcessingConfiguration)...)
+		}
+
+		// +overmind:link opensearch-domain
+		// +overmind:link http
+		// +overmind:link ec2-vpc
+		// +overmind:link ec2-subnet
+		// +overmind:link ec2-security-group
+		if d := destination.AmazonopensearchserviceDestinationDescription; d != nil {
+			item.LinkedItemQueries = append(item.LinkedItemQueries, openSearchDomainLink(d.DomainARN)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, httpLink(d.ClusterEndpoint)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, vpcLinks(d.VpcConfigurationDescription, scope)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, roleLink(d.RoleARN)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, loggingLinks(d.CloudWatchLoggingOptions, scope)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, s3DestinationLinks(d.S3DestinationDescription, scope)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, processingLinks(d.ProcessingConfiguration)...)
+		}
+
+		if d := destination.ElasticsearchDestinationDescription; d != nil {
+			item.LinkedItemQueries = append(item.LinkedItemQueries, openSearchDomainLink(d.DomainARN)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, httpLink(d.ClusterEndpoint)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, vpcLinks(d.VpcConfigurationDescription, scope)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, roleLink(d.RoleARN)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, loggingLinks(d.CloudWatchLoggingOptions, scope)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, s3DestinationLinks(d.S3DestinationDescription, scope)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, processingLinks(d.ProcessingConfiguration)...)
+		}
+
+		if d := destination.AmazonOpenSearchServerlessDestinationDescription; d != nil {
+			item.LinkedItemQueries = append(item.LinkedItemQueries, httpLink(d.CollectionEndpoint)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, vpcLinks(d.VpcConfigurationDescription, scope)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, roleLink(d.RoleARN)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, loggingLinks(d.CloudWatchLoggingOptions, scope)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, s3DestinationLinks(d.S3DestinationDescription, scope)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, processingLinks(d.ProcessingConfiguration)...)
+		}
+
+		if d := destination.HttpEndpointDestinationDescription; d != nil {
+			if d.EndpointConfiguration != nil {
+				item.LinkedItemQueries = append(item.LinkedItemQueries, httpLink(d.EndpointConfiguration.Url)...)
+			}
+
+			item.LinkedItemQueries = append(item.LinkedItemQueries, roleLink(d.RoleARN)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, loggingLinks(d.CloudWatchLoggingOptions, scope)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, s3DestinationLinks(d.S3DestinationDescription, scope)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, processingLinks(d.ProcessingConfiguration)...)
+		}
+
+		if d := destination.SplunkDestinationDescription; d != nil {
+			item.LinkedItemQueries = append(item.LinkedItemQueries, httpLink(d.HECEndpoint)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, loggingLinks(d.CloudWatchLoggingOptions, scope)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, s3DestinationLinks(d.S3DestinationDescription, scope)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, processingLinks(d.ProcessingConfiguration)...)
+		}
+
+		if d := destination.SnowflakeDestinationDescription; d != nil {
+			item.LinkedItemQueries = append(item.LinkedItemQueries, httpLink(d.AccountUrl)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, roleLink(d.RoleARN)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, loggingLinks(d.CloudWatchLoggingOptions, scope)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, s3DestinationLinks(d.S3DestinationDescription, scope)...)
+			item.LinkedItemQueries = append(item.LinkedItemQueries, processingLinks(d.ProcessingConfiguration)...)
+		}
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type firehose-delivery-stream
+// +overmind:descriptiveType Kinesis Data Firehose Delivery Stream
+// +overmind:get Get a delivery stream by name
+// +overmind:list List all delivery streams
+// +overmind:search Search for delivery streams by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_kinesis_firehose_delivery_stream.name
+
+func NewDeliveryStreamSource(config aws.Config, accountID string, region string) *sources.GetListSource[*types.DeliveryStreamDescription, FirehoseClient, *firehose.Options] {
+	return &sources.GetListSource[*types.DeliveryStreamDescription, FirehoseClient, *firehose.Options]{
+		ItemType:     "firehose-delivery-stream",
+		Client:       firehose.NewFromConfig(config),
+		AccountID:    accountID,
+		Region:       region,
+		GetFunc:      deliveryStreamGetFunc,
+		ListFunc:     deliveryStreamListFunc,
+		ListTagsFunc: deliveryStreamListTagsFunc,
+		ItemMapper:   deliveryStreamItemMapper,
+	}
+}
@@ -0,0 +1,295 @@
+package firehose
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/firehose"
+	"github.com/aws/aws-sdk-go-v2/service/firehose/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testFirehoseClient) DescribeDeliveryStream(ctx context.Context, params *firehose.DescribeDeliveryStreamInput, optFns ...func(*firehose.Options)) (*firehose.DescribeDeliveryStreamOutput, error) {
+	s3Destination := &types.S3DestinationDescription{
+		BucketARN: sources.PtrString("arn:aws:s3:::order-archive"), // link
+		BufferingHints: &types.BufferingHints{
+			IntervalInSeconds: sources.PtrInt32(300),
+			SizeInMBs:         sources.PtrInt32(5),
+		},
+		CompressionFormat: types.CompressionFormatGzip,
+		EncryptionConfiguration: &types.EncryptionConfiguration{
+			KMSEncryptionConfig: &types.KMSEncryptionConfig{
+				AWSKMSKeyARN: sources.PtrString("arn:aws:kms:us-east-1:123456789012:key/12345678-1234-1234-1234-123456789012"), // link
+			},
+		},
+		RoleARN: sources.PtrString("arn:aws:iam::123456789012:role/firehose-delivery"), // link
+		CloudWatchLoggingOptions: &types.CloudWatchLoggingOptions{
+			Enabled:       sources.PtrBool(true),
+			LogGroupName:  sources.PtrString("/aws/kinesisfirehose/orders"), // link
+			LogStreamName: sources.PtrString("DestinationDelivery"),
+		},
+	}
+
+	processing := &types.ProcessingConfiguration{
+		Enabled: sources.PtrBool(true),
+		Processors: []types.Processor{
+			{
+				Type: types.ProcessorTypeLambda,
+				Parameters: []types.ProcessorParameter{
+					{
+						ParameterName:  types.ProcessorParameterNameLambdaArn,
+						ParameterValue: sources.PtrString("arn:aws:lambda:us-east-1:123456789012:function:transform-orders:$LATEST"), // link
+					},
+				},
+			},
+		},
+	}
+
+	return &firehose.DescribeDeliveryStreamOutput{
+		DeliveryStreamDescription: &types.DeliveryStreamDescription{
+			DeliveryStreamARN:    sources.PtrString("arn:aws:firehose:us-east-1:123456789012:deliverystream/" + *params.DeliveryStreamName),
+			DeliveryStreamName:   params.DeliveryStreamName,
+			DeliveryStreamStatus: types.DeliveryStreamStatusActive, // health
+			DeliveryStreamType:   types.DeliveryStreamTypeKinesisStreamAsSource,
+			VersionId:            sources.PtrString("1"),
+			HasMoreDestinations:  sources.PtrBool(false),
+			CreateTimestamp:      sources.PtrTime(time.Now()),
+			DeliveryStreamEncryptionConfiguration: &types.DeliveryStreamEncryptionConfiguration{
+				KeyARN:  sources.PtrString("arn:aws:kms:us-east-1:123456789012:key/abcdefgh-1234-1234-1234-123456789012"), // link
+				KeyType: types.KeyTypeCustomerManagedCmk,
+				Status:  types.DeliveryStreamEncryptionStatusEnabled,
+			},
+			Source: &types.SourceDescription{
+				KinesisStreamSourceDescription: &types.KinesisStreamSourceDescription{
+					KinesisStreamARN: sources.PtrString("arn:aws:kinesis:us-east-1:123456789012:stream/orders"), // link
+					RoleARN:          sources.PtrString("arn:aws:iam::123456789012:role/firehose-source"),       // link
+				},
+			},
+			Destinations: []types.DestinationDescription{
+				{
+					DestinationId: sources.PtrString("destinationId-000000000001"),
+					ExtendedS3DestinationDescription: &types.ExtendedS3DestinationDescription{
+						BucketARN:                s3Destination.BucketARN,
+						BufferingHints:           s3Destination.BufferingHints,
+						CompressionFormat:        s3Destination.CompressionFormat,
+						EncryptionConfiguration:  s3Destination.EncryptionConfiguration,
+						RoleARN:                  s3Destination.RoleARN,
+						CloudWatchLoggingOptions: s3Destination.CloudWatchLoggingOptions,
+						ProcessingConfiguration:  processing,
+					},
+					S3DestinationDescription: s3Destination,
+				},
+				{
+					DestinationId: sources.PtrString("destinationId-000000000002"),
+					RedshiftDestinationDescription: &types.RedshiftDestinationDescription{
+						ClusterJDBCURL:           sources.PtrString("jdbc:redshift://analytics.c1a2b3c4d5e6.us-east-1.redshift.amazonaws.com:5439/orders"), // link
+						RoleARN:                  sources.PtrString("arn:aws:iam::123456789012:role/firehose-redshift"),                                    // link
+						Username:                 sources.PtrString("firehose"),
+						S3DestinationDescription: s3Destination,
+					},
+				},
+				{
+					DestinationId: sources.PtrString("destinationId-000000000003"),
+					AmazonopensearchserviceDestinationDescription: &types.AmazonopensearchserviceDestinationDescription{
+						DomainARN: sources.PtrString("arn:aws:es:us-east-1:123456789012:domain/orders"), // link
+						IndexName: sources.PtrString("orders"),
+						RoleARN:   sources.PtrString("arn:aws:iam::123456789012:role/firehose-opensearch"),
+						VpcConfigurationDescription: &types.VpcConfigurationDescription{
+							VpcId:            sources.PtrString("vpc-0123456789abcdef0"), // link
+							SubnetIds:        []string{"subnet-0123456789abcdef0"},       // link
+							SecurityGroupIds: []string{"sg-0123456789abcdef0"},           // link
+							RoleARN:          sources.PtrString("arn:aws:iam::123456789012:role/firehose-vpc"),
+						},
+						S3DestinationDescription: s3Destination,
+					},
+				},
+				{
+					DestinationId: sources.PtrString("destinationId-000000000004"),
+					HttpEndpointDestinationDescription: &types.HttpEndpointDestinationDescription{
+						EndpointConfiguration: &types.HttpEndpointDescription{
+							Name: sources.PtrString("ingest"),
+							Url:  sources.PtrString("https://ingest.example.com/firehose"), // link
+						},
+						S3DestinationDescription: s3Destination,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func (c testFirehoseClient) ListDeliveryStreams(ctx context.Context, params *firehose.ListDeliveryStreamsInput, optFns ...func(*firehose.Options)) (*firehose.ListDeliveryStreamsOutput, error) {
+	if params.ExclusiveStartDeliveryStreamName == nil {
+		return &firehose.ListDeliveryStreamsOutput{
+			DeliveryStreamNames:    []string{"orders"},
+			HasMoreDeliveryStreams: sources.PtrBool(true),
+		}, nil
+	}
+
+	return &firehose.ListDeliveryStreamsOutput{
+		DeliveryStreamNames:    []string{"payments"},
+		HasMoreDeliveryStreams: sources.PtrBool(false),
+	}, nil
+}
+
+func (c testFirehoseClient) ListTagsForDeliveryStream(ctx context.Context, params *firehose.ListTagsForDeliveryStreamInput, optFns ...func(*firehose.Options)) (*firehose.ListTagsForDeliveryStreamOutput, error) {
+	return &firehose.ListTagsForDeliveryStreamOutput{
+		Tags: []types.Tag{
+			{
+				Key:   sources.PtrString("team"),
+				Value: sources.PtrString("orders"),
+			},
+		},
+		HasMoreTags: sources.PtrBool(false),
+	}, nil
+}
+
+func TestDeliveryStreamItemMapper(t *testing.T) {
+	stream, err := deliveryStreamGetFunc(context.Background(), testFirehoseClient{}, "123456789012.us-east-1", "orders")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := deliveryStreamItemMapper("123456789012.us-east-1", stream)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := item.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if item.GetHealth() != sdp.Health_HEALTH_OK {
+		t.Errorf("expected health OK, got %v", item.GetHealth())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "kinesis-stream",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:kinesis:us-east-1:123456789012:stream/orders",
+			ExpectedScope:  "123456789012.us-east-1",
+		},
+		{
+			ExpectedType:   "iam-role",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:iam::123456789012:role/firehose-source",
+			ExpectedScope:  "123456789012",
+		},
+		{
+			ExpectedType:   "kms-key",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:kms:us-east-1:123456789012:key/abcdefgh-1234-1234-1234-123456789012",
+			ExpectedScope:  "123456789012.us-east-1",
+		},
+		{
+			ExpectedType:   "s3-bucket",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "order-archive",
+			ExpectedScope:  "123456789012",
+		},
+		{
+			ExpectedType:   "kms-key",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:kms:us-east-1:123456789012:key/12345678-1234-1234-1234-123456789012",
+			ExpectedScope:  "123456789012.us-east-1",
+		},
+		{
+			ExpectedType:   "iam-role",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:iam::123456789012:role/firehose-delivery",
+			ExpectedScope:  "123456789012",
+		},
+		{
+			ExpectedType:   "logs-log-group",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "/aws/kinesisfirehose/orders",
+			ExpectedScope:  "123456789012.us-east-1",
+		},
+		{
+			ExpectedType:   "lambda-function",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:lambda:us-east-1:123456789012:function:transform-orders:$LATEST",
+			ExpectedScope:  "123456789012.us-east-1",
+		},
+		{
+			ExpectedType:   "dns",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "analytics.c1a2b3c4d5e6.us-east-1.redshift.amazonaws.com",
+			ExpectedScope:  "global",
+		},
+		{
+			ExpectedType:   "redshift-cluster",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "analytics",
+			ExpectedScope:  "123456789012.us-east-1",
+		},
+		{
+			ExpectedType:   "iam-role",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:iam::123456789012:role/firehose-redshift",
+			ExpectedScope:  "123456789012",
+		},
+		{
+			ExpectedType:   "opensearch-domain",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:es:us-east-1:123456789012:domain/orders",
+			ExpectedScope:  "123456789012.us-east-1",
+		},
+		{
+			ExpectedType:   "ec2-vpc",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "vpc-0123456789abcdef0",
+			ExpectedScope:  "123456789012.us-east-1",
+		},
+		{
+			ExpectedType:   "ec2-subnet",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "subnet-0123456789abcdef0",
+			ExpectedScope:  "123456789012.us-east-1",
+		},
+		{
+			ExpectedType:   "ec2-security-group",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "sg-0123456789abcdef0",
+			ExpectedScope:  "123456789012.us-east-1",
+		},
+		{
+			ExpectedType:   "http",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "https://ingest.example.com/firehose",
+			ExpectedScope:  "global",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestDeliveryStreamListFunc(t *testing.T) {
+	streams, err := deliveryStreamListFunc(context.Background(), testFirehoseClient{}, "123456789012.us-east-1")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(streams) != 2 {
+		t.Fatalf("expected 2 delivery streams, got %v", len(streams))
+	}
+}
+
+func TestNewDeliveryStreamSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewDeliveryStreamSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -0,0 +1,3 @@
+package firehose
+
+type testFirehoseClient struct{}
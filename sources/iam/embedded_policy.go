@@ -0,0 +1,120 @@
+package iam
+
+import (
+	"context"
+	"errors"
+
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/aws-source/sources/iampolicy"
+	"github.com/overmindtech/sdp-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// embeddedPolicy An inline policy that is embedded in a role, user or group
+type embeddedPolicy struct {
+	Name     string
+	Document *iampolicy.Document
+	// Why the document couldn't be read, if it couldn't
+	DocumentError string `json:",omitempty"`
+}
+
+// parseEmbeddedPolicy Parses the URL-encoded document of an inline policy. If
+// the document can't be parsed the policy is still returned, with the reason
+// recorded in DocumentError and on the span
+func parseEmbeddedPolicy(ctx context.Context, name string, document *string) *embeddedPolicy {
+	policy := embeddedPolicy{
+		Name: name,
+	}
+
+	var err error
+
+	if document == nil {
+		err = errors.New("policy document not found")
+	} else {
+		policy.Document, err = iampolicy.Parse(*document)
+	}
+
+	if err != nil {
+		policy.DocumentError = err.Error()
+		recordPolicyDocumentError(ctx, name, err)
+	}
+
+	return &policy
+}
+
+// recordPolicyDocumentError Adds an event to the span to note that a policy
+// document couldn't be read
+func recordPolicyDocumentError(ctx context.Context, name string, err error) {
+	span := trace.SpanFromContext(ctx)
+
+	span.AddEvent("Error reading policy document", trace.WithAttributes(
+		attribute.String("ovm.aws.iam.policyName", name),
+		attribute.String("error", err.Error()),
+	))
+}
+
+// policyResourceLinks Returns links to the concrete resources that a set of
+// policy documents grant permissions on, along with any resources that
+// contain wildcards and therefore can't be linked. The scope is that of the
+// IAM item, which is used for S3 buckets since their ARNs don't contain the
+// account
+func policyResourceLinks(scope string, documents ...*iampolicy.Document) ([]*sdp.LinkedItemQuery, []string, error) {
+	accountID, _, err := sources.ParseScope(scope)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if accountID == "aws" {
+		// AWS managed policies don't belong to an account
+		accountID = ""
+	}
+
+	queries := make([]*sdp.Query, 0)
+	wildcards := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, document := range documents {
+		if document == nil {
+			continue
+		}
+
+		for _, query := range document.ResourceQueries(accountID) {
+			key := query.GetType() + query.GetScope() + query.GetQuery()
+
+			if !seen[key] {
+				seen[key] = true
+				queries = append(queries, query)
+			}
+		}
+
+		for _, resource := range document.WildcardResources() {
+			if !seen[resource] {
+				seen[resource] = true
+				wildcards = append(wildcards, resource)
+			}
+		}
+	}
+
+	links := iampolicy.LinkedItemQueries(queries, &sdp.BlastPropagation{
+		// Changing the resource won't affect the permissions that are granted
+		// on it
+		In: false,
+		// Changing the policy will affect what can be done to the resource
+		Out: true,
+	})
+
+	return links, wildcards, nil
+}
+
+// embeddedPolicyDocuments Returns the documents of a list of inline policies
+func embeddedPolicyDocuments(policies []embeddedPolicy) []*iampolicy.Document {
+	documents := make([]*iampolicy.Document, 0, len(policies))
+
+	for _, policy := range policies {
+		documents = append(documents, policy.Document)
+	}
+
+	return documents
+}
@@ -12,7 +12,13 @@ import (
 	"github.com/overmindtech/sdp-go"
 )
 
-func groupGetFunc(ctx context.Context, client *iam.Client, scope, query string) (*types.Group, error) {
+type GroupDetails struct {
+	Group            *types.Group
+	EmbeddedPolicies []embeddedPolicy
+}
+
+func groupGetFunc(ctx context.Context, client IAMClient, scope, query string, limit *sources.LimitBucket) (*GroupDetails, error) {
+	limit.Wait(ctx) // Wait for rate limiting
 	out, err := client.GetGroup(ctx, &iam.GetGroupInput{
 		GroupName: &query,
 	})
@@ -21,27 +27,106 @@ func groupGetFunc(ctx context.Context, client *iam.Client, scope, query string)
 		return nil, err
 	}
 
-	return out.Group, nil
+	details := GroupDetails{
+		Group: out.Group,
+	}
+
+	if out.Group != nil {
+		details.EmbeddedPolicies, err = getGroupEmbeddedPolicies(ctx, client, out.Group.GroupName, limit)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &details, nil
 }
 
-func groupListFunc(ctx context.Context, client *iam.Client, scope string) ([]*types.Group, error) {
-	out, err := client.ListGroups(ctx, &iam.ListGroupsInput{})
+// getGroupEmbeddedPolicies Returns the inline policies embedded in a group
+func getGroupEmbeddedPolicies(ctx context.Context, client IAMClient, groupName *string, limit *sources.LimitBucket) ([]embeddedPolicy, error) {
+	policies := make([]embeddedPolicy, 0)
 
-	if err != nil {
-		return nil, err
+	paginator := iam.NewListGroupPoliciesPaginator(client, &iam.ListGroupPoliciesInput{
+		GroupName: groupName,
+	})
+
+	for paginator.HasMorePages() {
+		limit.Wait(ctx) // Wait for rate limiting
+		out, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, policyName := range out.PolicyNames {
+			limit.Wait(ctx) // Wait for rate limiting
+			policy, err := client.GetGroupPolicy(ctx, &iam.GetGroupPolicyInput{
+				GroupName:  groupName,
+				PolicyName: &policyName,
+			})
+
+			if err != nil {
+				// Ignore these errors
+				continue
+			}
+
+			policies = append(policies, *parseEmbeddedPolicy(ctx, policyName, policy.PolicyDocument))
+		}
 	}
 
-	zones := make([]*types.Group, len(out.Groups))
+	return policies, nil
+}
+
+func groupListFunc(ctx context.Context, client IAMClient, scope string, limit *sources.LimitBucket) ([]*GroupDetails, error) {
+	groups := make([]*GroupDetails, 0)
 
-	for i := range out.Groups {
-		zones[i] = &out.Groups[i]
+	paginator := iam.NewListGroupsPaginator(client, &iam.ListGroupsInput{})
+
+	for paginator.HasMorePages() {
+		limit.Wait(ctx) // Wait for rate limiting
+		out, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range out.Groups {
+			details := GroupDetails{
+				Group: &out.Groups[i],
+			}
+
+			details.EmbeddedPolicies, err = getGroupEmbeddedPolicies(ctx, client, out.Groups[i].GroupName, limit)
+
+			if err != nil {
+				return nil, err
+			}
+
+			groups = append(groups, &details)
+		}
 	}
 
-	return zones, nil
+	return groups, nil
 }
 
-func groupItemMapper(scope string, awsItem *types.Group) (*sdp.Item, error) {
-	attributes, err := sources.ToAttributesCase(awsItem)
+func groupItemMapper(scope string, awsItem *GroupDetails) (*sdp.Item, error) {
+	resourceLinks, wildcards, err := policyResourceLinks(scope, embeddedPolicyDocuments(awsItem.EmbeddedPolicies)...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	enrichedGroup := struct {
+		*types.Group
+		EmbeddedPolicies []embeddedPolicy
+		// Resources in the inline policies that contain wildcards
+		WildcardResources []string
+	}{
+		Group:             awsItem.Group,
+		EmbeddedPolicies:  awsItem.EmbeddedPolicies,
+		WildcardResources: wildcards,
+	}
+
+	attributes, err := sources.ToAttributesCase(enrichedGroup)
 
 	if err != nil {
 		return nil, err
@@ -54,6 +139,32 @@ func groupItemMapper(scope string, awsItem *types.Group) (*sdp.Item, error) {
 		Scope:           scope,
 	}
 
+	// +overmind:link dynamodb-table
+	// +overmind:link ecr-repository
+	// +overmind:link efs-access-point
+	// +overmind:link efs-file-system
+	// +overmind:link elbv2-load-balancer
+	// +overmind:link elbv2-target-group
+	// +overmind:link events-event-bus
+	// +overmind:link events-rule
+	// +overmind:link firehose-delivery-stream
+	// +overmind:link iam-instance-profile
+	// +overmind:link iam-policy
+	// +overmind:link iam-role
+	// +overmind:link iam-user
+	// +overmind:link kinesis-stream
+	// +overmind:link kms-key
+	// +overmind:link lambda-function
+	// +overmind:link logs-log-group
+	// +overmind:link s3-access-point
+	// +overmind:link s3-bucket
+	// +overmind:link s3-object-lambda-access-point
+	// +overmind:link sfn-activity
+	// +overmind:link sfn-state-machine
+	// +overmind:link sns-topic
+	// +overmind:link sqs-queue
+	item.LinkedItemQueries = append(item.LinkedItemQueries, resourceLinks...)
+
 	return &item, nil
 }
 
@@ -67,19 +178,17 @@ func groupItemMapper(scope string, awsItem *types.Group) (*sdp.Item, error) {
 // +overmind:terraform:queryMap aws_iam_group.arn
 // +overmind:terraform:method SEARCH
 
-func NewGroupSource(config aws.Config, accountID string, region string, limit *sources.LimitBucket) *sources.GetListSource[*types.Group, *iam.Client, *iam.Options] {
-	return &sources.GetListSource[*types.Group, *iam.Client, *iam.Options]{
+func NewGroupSource(config aws.Config, accountID string, region string, limit *sources.LimitBucket) *sources.GetListSource[*GroupDetails, IAMClient, *iam.Options] {
+	return &sources.GetListSource[*GroupDetails, IAMClient, *iam.Options]{
 		ItemType:      "iam-group",
 		Client:        iam.NewFromConfig(config),
 		CacheDuration: 3 * time.Hour, // IAM has very low rate limits, we need to cache for a long time
 		AccountID:     accountID,
-		GetFunc: func(ctx context.Context, client *iam.Client, scope, query string) (*types.Group, error) {
-			limit.Wait(ctx) // Wait for rate limiting
-			return groupGetFunc(ctx, client, scope, query)
+		GetFunc: func(ctx context.Context, client IAMClient, scope, query string) (*GroupDetails, error) {
+			return groupGetFunc(ctx, client, scope, query, limit)
 		},
-		ListFunc: func(ctx context.Context, client *iam.Client, scope string) ([]*types.Group, error) {
-			limit.Wait(ctx) // Wait for rate limiting
-			return groupListFunc(ctx, client, scope)
+		ListFunc: func(ctx context.Context, client IAMClient, scope string) ([]*GroupDetails, error) {
+			return groupListFunc(ctx, client, scope, limit)
 		},
 		ItemMapper: groupItemMapper,
 	}
@@ -1,23 +1,105 @@
 package iam
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/service/iam"
 	"github.com/aws/aws-sdk-go-v2/service/iam/types"
 	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
 )
 
+func (t *TestIAMClient) GetGroup(ctx context.Context, params *iam.GetGroupInput, optFns ...func(*iam.Options)) (*iam.GetGroupOutput, error) {
+	return &iam.GetGroupOutput{
+		Group: &types.Group{
+			Path:       sources.PtrString("/"),
+			GroupName:  params.GroupName,
+			GroupId:    sources.PtrString("AGPA3VLV2U27T6SSLJMDS"),
+			Arn:        sources.PtrString("arn:aws:iam::801795385023:group/power-users"),
+			CreateDate: sources.PtrTime(time.Now()),
+		},
+	}, nil
+}
+
+func (t *TestIAMClient) ListGroups(ctx context.Context, params *iam.ListGroupsInput, optFns ...func(*iam.Options)) (*iam.ListGroupsOutput, error) {
+	return &iam.ListGroupsOutput{
+		Groups: []types.Group{
+			{
+				Path:       sources.PtrString("/"),
+				GroupName:  sources.PtrString("power-users"),
+				GroupId:    sources.PtrString("AGPA3VLV2U27T6SSLJMDS"),
+				Arn:        sources.PtrString("arn:aws:iam::801795385023:group/power-users"),
+				CreateDate: sources.PtrTime(time.Now()),
+			},
+		},
+	}, nil
+}
+
+func (t *TestIAMClient) ListGroupPolicies(ctx context.Context, params *iam.ListGroupPoliciesInput, optFns ...func(*iam.Options)) (*iam.ListGroupPoliciesOutput, error) {
+	return &iam.ListGroupPoliciesOutput{
+		PolicyNames: []string{
+			"logs",
+		},
+	}, nil
+}
+
+func (t *TestIAMClient) GetGroupPolicy(ctx context.Context, params *iam.GetGroupPolicyInput, optFns ...func(*iam.Options)) (*iam.GetGroupPolicyOutput, error) {
+	return &iam.GetGroupPolicyOutput{
+		GroupName:  params.GroupName,
+		PolicyName: params.PolicyName,
+		PolicyDocument: sources.PtrString(`{
+			"Version": "2012-10-17",
+			"Statement": {
+				"Effect": "Allow",
+				"Action": ["logs:CreateLogStream", "logs:PutLogEvents"],
+				"Resource": "arn:aws:logs:eu-west-2:801795385023:log-group:/app:*"
+			}
+		}`),
+	}, nil
+}
+
+func TestGroupGetFunc(t *testing.T) {
+	group, err := groupGetFunc(context.Background(), &TestIAMClient{}, "foo", "power-users", &TestRateLimit)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if group.Group == nil {
+		t.Error("group is nil")
+	}
+
+	if len(group.EmbeddedPolicies) != 1 {
+		t.Errorf("expected 1 embedded policy, got %v", len(group.EmbeddedPolicies))
+	}
+}
+
+func TestGroupListFunc(t *testing.T) {
+	groups, err := groupListFunc(context.Background(), &TestIAMClient{}, "foo", &TestRateLimit)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(groups) != 1 {
+		t.Fatalf("expected 1 group, got %v", len(groups))
+	}
+
+	if len(groups[0].EmbeddedPolicies) != 1 {
+		t.Errorf("expected 1 embedded policy, got %v", len(groups[0].EmbeddedPolicies))
+	}
+}
+
 func TestGroupItemMapper(t *testing.T) {
-	zone := types.Group{
-		Path:       sources.PtrString("/"),
-		GroupName:  sources.PtrString("power-users"),
-		GroupId:    sources.PtrString("AGPA3VLV2U27T6SSLJMDS"),
-		Arn:        sources.PtrString("arn:aws:iam::801795385023:group/power-users"),
-		CreateDate: sources.PtrTime(time.Now()),
+	group, err := groupGetFunc(context.Background(), &TestIAMClient{}, "801795385023", "power-users", &TestRateLimit)
+
+	if err != nil {
+		t.Fatal(err)
 	}
 
-	item, err := groupItemMapper("foo", &zone)
+	item, err := groupItemMapper("801795385023", group)
 
 	if err != nil {
 		t.Error(err)
@@ -27,6 +109,16 @@ func TestGroupItemMapper(t *testing.T) {
 		t.Error(err)
 	}
 
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "logs-log-group",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:logs:eu-west-2:801795385023:log-group:/app",
+			ExpectedScope:  "801795385023.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
 }
 
 func TestNewGroupSource(t *testing.T) {
@@ -0,0 +1,193 @@
+package iam
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+// eksIssuerRegex Matches the OIDC issuers that EKS creates for each cluster
+// e.g. oidc.eks.eu-west-2.amazonaws.com/id/EXAMPLED539D4633E53DE1B71EXAMPLE
+var eksIssuerRegex = regexp.MustCompile(`^(?:https://)?oidc\.eks\.([a-z0-9-]+)\.amazonaws\.com/id/[A-Za-z0-9]+$`)
+
+// eksClusterQuery Returns a query for the EKS cluster that an OIDC issuer
+// belongs to, or nil if the issuer isn't an EKS cluster. The cluster source
+// can be searched by issuer URL
+func eksClusterQuery(issuer string, accountID string) *sdp.Query {
+	matches := eksIssuerRegex.FindStringSubmatch(issuer)
+
+	if matches == nil || accountID == "" {
+		return nil
+	}
+
+	if !strings.HasPrefix(issuer, "https://") {
+		issuer = "https://" + issuer
+	}
+
+	return &sdp.Query{
+		Type:   "eks-cluster",
+		Method: sdp.QueryMethod_SEARCH,
+		Query:  issuer,
+		Scope:  sources.FormatScope(accountID, matches[1]),
+	}
+}
+
+type OIDCProviderDetails struct {
+	Arn      string
+	Provider *iam.GetOpenIDConnectProviderOutput
+}
+
+// providerARN Builds the ARN of an OIDC or SAML provider from its name, which
+// is what is used as the query
+func providerARN(accountID string, providerType string, name string) string {
+	a := arn.ARN{
+		Partition: "aws",
+		Service:   "iam",
+		AccountID: accountID,
+		Resource:  providerType + "/" + name,
+	}
+
+	return a.String()
+}
+
+func oidcProviderGetFunc(ctx context.Context, client IAMClient, scope, query string, limit *sources.LimitBucket) (*OIDCProviderDetails, error) {
+	providerArn := providerARN(scope, "oidc-provider", query)
+
+	limit.Wait(ctx)
+
+	out, err := client.GetOpenIDConnectProvider(ctx, &iam.GetOpenIDConnectProviderInput{
+		OpenIDConnectProviderArn: &providerArn,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &OIDCProviderDetails{
+		Arn:      providerArn,
+		Provider: out,
+	}, nil
+}
+
+func oidcProviderListFunc(ctx context.Context, client IAMClient, scope string, limit *sources.LimitBucket) ([]*OIDCProviderDetails, error) {
+	limit.Wait(ctx)
+
+	out, err := client.ListOpenIDConnectProviders(ctx, &iam.ListOpenIDConnectProvidersInput{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	providers := make([]*OIDCProviderDetails, 0)
+
+	for _, entry := range out.OpenIDConnectProviderList {
+		if entry.Arn == nil {
+			continue
+		}
+
+		a, err := sources.ParseARN(*entry.Arn)
+
+		if err != nil {
+			continue
+		}
+
+		provider, err := oidcProviderGetFunc(ctx, client, scope, a.ResourceID(), limit)
+
+		if err != nil {
+			return nil, err
+		}
+
+		providers = append(providers, provider)
+	}
+
+	return providers, nil
+}
+
+func oidcProviderItemMapper(scope string, awsItem *OIDCProviderDetails) (*sdp.Item, error) {
+	attributes, err := sources.ToAttributesCase(awsItem.Provider, "resultMetadata", "tags")
+
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := sources.ParseARN(awsItem.Arn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = attributes.Set("arn", awsItem.Arn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = attributes.Set("name", a.ResourceID())
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := sdp.Item{
+		Type:            "iam-oidc-provider",
+		UniqueAttribute: "name",
+		Attributes:      attributes,
+		Scope:           scope,
+		Tags:            make(map[string]string),
+	}
+
+	for _, tag := range awsItem.Provider.Tags {
+		if tag.Key != nil && tag.Value != nil {
+			item.Tags[*tag.Key] = *tag.Value
+		}
+	}
+
+	if query := eksClusterQuery(a.ResourceID(), a.AccountID); query != nil {
+		// +overmind:link eks-cluster
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: query,
+			BlastPropagation: &sdp.BlastPropagation{
+				// Deleting the cluster will invalidate the issuer
+				In: true,
+				// Changing the provider will affect which pods in the cluster
+				// can assume roles
+				Out: true,
+			},
+		})
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type iam-oidc-provider
+// +overmind:descriptiveType IAM OIDC Provider
+// +overmind:get Get an OIDC provider by its URL without the scheme e.g. oidc.eks.eu-west-2.amazonaws.com/id/EXAMPLED539D4633E53DE1B71EXAMPLE
+// +overmind:list List all OIDC providers
+// +overmind:search Search for OIDC providers by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_iam_openid_connect_provider.arn
+// +overmind:terraform:method SEARCH
+
+func NewOIDCProviderSource(config aws.Config, accountID string, _ string, limit *sources.LimitBucket) *sources.GetListSource[*OIDCProviderDetails, IAMClient, *iam.Options] {
+	return &sources.GetListSource[*OIDCProviderDetails, IAMClient, *iam.Options]{
+		ItemType:      "iam-oidc-provider",
+		Client:        iam.NewFromConfig(config),
+		CacheDuration: 3 * time.Hour, // IAM has very low rate limits, we need to cache for a long time
+		AccountID:     accountID,
+		GetFunc: func(ctx context.Context, client IAMClient, scope, query string) (*OIDCProviderDetails, error) {
+			return oidcProviderGetFunc(ctx, client, scope, query, limit)
+		},
+		ListFunc: func(ctx context.Context, client IAMClient, scope string) ([]*OIDCProviderDetails, error) {
+			return oidcProviderListFunc(ctx, client, scope, limit)
+		},
+		ItemMapper: oidcProviderItemMapper,
+	}
+}
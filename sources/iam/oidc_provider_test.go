@@ -0,0 +1,103 @@
+package iam
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (t *TestIAMClient) GetOpenIDConnectProvider(ctx context.Context, params *iam.GetOpenIDConnectProviderInput, optFns ...func(*iam.Options)) (*iam.GetOpenIDConnectProviderOutput, error) {
+	return &iam.GetOpenIDConnectProviderOutput{
+		ClientIDList:   []string{"sts.amazonaws.com"},
+		CreateDate:     sources.PtrTime(time.Now()),
+		ThumbprintList: []string{"9e99a48a9960b14926bb7f3b02e22da2b0ab7280"},
+		Url:            sources.PtrString("oidc.eks.eu-west-2.amazonaws.com/id/EXAMPLED539D4633E53DE1B71EXAMPLE"),
+		Tags: []types.Tag{
+			{
+				Key:   sources.PtrString("alpha.eksctl.io/cluster-name"),
+				Value: sources.PtrString("dylan"),
+			},
+		},
+	}, nil
+}
+
+func (t *TestIAMClient) ListOpenIDConnectProviders(ctx context.Context, params *iam.ListOpenIDConnectProvidersInput, optFns ...func(*iam.Options)) (*iam.ListOpenIDConnectProvidersOutput, error) {
+	return &iam.ListOpenIDConnectProvidersOutput{
+		OpenIDConnectProviderList: []types.OpenIDConnectProviderListEntry{
+			{
+				Arn: sources.PtrString("arn:aws:iam::801795385023:oidc-provider/oidc.eks.eu-west-2.amazonaws.com/id/EXAMPLED539D4633E53DE1B71EXAMPLE"),
+			},
+		},
+	}, nil
+}
+
+func TestOIDCProviderGetFunc(t *testing.T) {
+	provider, err := oidcProviderGetFunc(context.Background(), &TestIAMClient{}, "801795385023", "oidc.eks.eu-west-2.amazonaws.com/id/EXAMPLED539D4633E53DE1B71EXAMPLE", &TestRateLimit)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if provider.Arn != "arn:aws:iam::801795385023:oidc-provider/oidc.eks.eu-west-2.amazonaws.com/id/EXAMPLED539D4633E53DE1B71EXAMPLE" {
+		t.Errorf("unexpected ARN %v", provider.Arn)
+	}
+
+	item, err := oidcProviderItemMapper("801795385023", provider)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.UniqueAttributeValue() != "oidc.eks.eu-west-2.amazonaws.com/id/EXAMPLED539D4633E53DE1B71EXAMPLE" {
+		t.Errorf("unexpected unique attribute value %v", item.UniqueAttributeValue())
+	}
+
+	if item.GetTags()["alpha.eksctl.io/cluster-name"] != "dylan" {
+		t.Errorf("expected tags to be set, got %v", item.GetTags())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "eks-cluster",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "https://oidc.eks.eu-west-2.amazonaws.com/id/EXAMPLED539D4633E53DE1B71EXAMPLE",
+			ExpectedScope:  "801795385023.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestOIDCProviderListFunc(t *testing.T) {
+	providers, err := oidcProviderListFunc(context.Background(), &TestIAMClient{}, "801795385023", &TestRateLimit)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(providers) != 1 {
+		t.Errorf("expected 1 provider, got %v", len(providers))
+	}
+}
+
+func TestNewOIDCProviderSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewOIDCProviderSource(config, account, region, &TestRateLimit)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 30 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 	"github.com/aws/aws-sdk-go-v2/service/iam/types"
 	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/aws-source/sources/iampolicy"
 	"github.com/overmindtech/sdp-go"
 	log "github.com/sirupsen/logrus"
 	"github.com/sourcegraph/conc/iter"
@@ -23,6 +24,10 @@ type PolicyDetails struct {
 	PolicyGroups []types.PolicyGroup
 	PolicyRoles  []types.PolicyRole
 	PolicyUsers  []types.PolicyUser
+	// The document of the default version of the policy
+	Document *iampolicy.Document
+	// Why the document couldn't be read, if it couldn't
+	DocumentError string
 }
 
 func policyGetFunc(ctx context.Context, client IAMClient, scope, query string, limit *sources.LimitBucket) (*PolicyDetails, error) {
@@ -57,6 +62,12 @@ func policyGetFunc(ctx context.Context, client IAMClient, scope, query string, l
 		if err != nil {
 			return nil, err
 		}
+
+		err = addPolicyDocument(ctx, client, &details, limit)
+
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return &details, nil
@@ -99,6 +110,42 @@ func addPolicyEntities(ctx context.Context, client IAMClient, details *PolicyDet
 	return nil
 }
 
+// addPolicyDocument Fetches and parses the default version of the policy. If
+// this fails the reason is recorded on the details and the span rather than
+// returned, since the rest of the policy is still useful
+func addPolicyDocument(ctx context.Context, client IAMClient, details *PolicyDetails, limit *sources.LimitBucket) error {
+	if details == nil {
+		return errors.New("details is nil")
+	}
+
+	if details.Policy == nil {
+		return errors.New("policy is nil")
+	}
+
+	if details.Policy.DefaultVersionId == nil {
+		// Nothing to fetch
+		return nil
+	}
+
+	limit.Wait(ctx)
+
+	out, err := client.GetPolicyVersion(ctx, &iam.GetPolicyVersionInput{
+		PolicyArn: details.Policy.Arn,
+		VersionId: details.Policy.DefaultVersionId,
+	})
+
+	if err == nil && out.PolicyVersion != nil && out.PolicyVersion.Document != nil {
+		details.Document, err = iampolicy.Parse(*out.PolicyVersion.Document)
+	}
+
+	if err != nil {
+		details.DocumentError = err.Error()
+		recordPolicyDocumentError(ctx, aws.ToString(details.Policy.PolicyName), err)
+	}
+
+	return nil
+}
+
 // PolicyListFunc Lists all attached policies. There is no way to list
 // unattached policies since I don't think it will be very valuable, there are
 // hundreds by default and if you aren't using them they aren't very interesting
@@ -150,6 +197,12 @@ func policyListFunc(ctx context.Context, client IAMClient, scope string, limit *
 
 		err := addPolicyEntities(ctx, client, &details, limit)
 
+		if err != nil {
+			return nil, err
+		}
+
+		err = addPolicyDocument(ctx, client, &details, limit)
+
 		return &details, err
 	})
 
@@ -161,7 +214,33 @@ func policyListFunc(ctx context.Context, client IAMClient, scope string, limit *
 }
 
 func policyItemMapper(scope string, awsItem *PolicyDetails) (*sdp.Item, error) {
-	attributes, err := sources.ToAttributesCase(awsItem.Policy)
+	var resourceLinks []*sdp.LinkedItemQuery
+	var wildcards []string
+	var err error
+
+	if awsItem.Document != nil {
+		resourceLinks, wildcards, err = policyResourceLinks(scope, awsItem.Document)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	enrichedPolicy := struct {
+		*types.Policy
+		Document *iampolicy.Document
+		// Why the document couldn't be read, if it couldn't
+		DocumentError string `json:",omitempty"`
+		// Resources in the policy that contain wildcards
+		WildcardResources []string
+	}{
+		Policy:            awsItem.Policy,
+		Document:          awsItem.Document,
+		DocumentError:     awsItem.DocumentError,
+		WildcardResources: wildcards,
+	}
+
+	attributes, err := sources.ToAttributesCase(enrichedPolicy)
 
 	if err != nil {
 		return nil, err
@@ -242,6 +321,29 @@ func policyItemMapper(scope string, awsItem *PolicyDetails) (*sdp.Item, error) {
 		})
 	}
 
+	// +overmind:link dynamodb-table
+	// +overmind:link ecr-repository
+	// +overmind:link efs-access-point
+	// +overmind:link efs-file-system
+	// +overmind:link elbv2-load-balancer
+	// +overmind:link elbv2-target-group
+	// +overmind:link events-event-bus
+	// +overmind:link events-rule
+	// +overmind:link firehose-delivery-stream
+	// +overmind:link iam-instance-profile
+	// +overmind:link kinesis-stream
+	// +overmind:link kms-key
+	// +overmind:link lambda-function
+	// +overmind:link logs-log-group
+	// +overmind:link s3-access-point
+	// +overmind:link s3-bucket
+	// +overmind:link s3-object-lambda-access-point
+	// +overmind:link sfn-activity
+	// +overmind:link sfn-state-machine
+	// +overmind:link sns-topic
+	// +overmind:link sqs-queue
+	item.LinkedItemQueries = append(item.LinkedItemQueries, resourceLinks...)
+
 	return &item, nil
 }
 
@@ -2,12 +2,14 @@ package iam
 
 import (
 	"context"
+	"net/url"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 	"github.com/aws/aws-sdk-go-v2/service/iam/types"
 	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/aws-source/sources/iampolicy"
 	"github.com/overmindtech/sdp-go"
 )
 
@@ -93,6 +95,31 @@ func (t *TestIAMClient) ListPolicyTags(ctx context.Context, params *iam.ListPoli
 	}, nil
 }
 
+func (t *TestIAMClient) GetPolicyVersion(ctx context.Context, params *iam.GetPolicyVersionInput, optFns ...func(*iam.Options)) (*iam.GetPolicyVersionOutput, error) {
+	return &iam.GetPolicyVersionOutput{
+		PolicyVersion: &types.PolicyVersion{
+			VersionId:        params.VersionId,
+			IsDefaultVersion: true,
+			CreateDate:       sources.PtrTime(time.Now()),
+			Document: sources.PtrString(url.QueryEscape(`{
+				"Version": "2012-10-17",
+				"Statement": [
+					{
+						"Effect": "Allow",
+						"Action": "sns:Publish",
+						"Resource": "arn:aws:sns:eu-west-2:801795385023:alerts"
+					},
+					{
+						"Effect": "Allow",
+						"Action": "kms:Decrypt",
+						"Resource": "arn:aws:kms:eu-west-2:801795385023:key/*"
+					}
+				]
+			}`)),
+		},
+	}, nil
+}
+
 func TestPolicyGetFunc(t *testing.T) {
 	policy, err := policyGetFunc(context.Background(), &TestIAMClient{}, "foo", "bar", &TestRateLimit)
 
@@ -115,6 +142,57 @@ func TestPolicyGetFunc(t *testing.T) {
 	if len(policy.PolicyUsers) != 1 {
 		t.Errorf("expected 1 User, got %v", len(policy.PolicyUsers))
 	}
+
+	if policy.Document == nil {
+		t.Fatal("document was nil")
+	}
+
+	if len(policy.Document.Statement) != 2 {
+		t.Errorf("expected 2 statements, got %v", len(policy.Document.Statement))
+	}
+}
+
+// testIAMBadDocumentClient A client that returns a policy version whose
+// document can't be parsed
+type testIAMBadDocumentClient struct {
+	TestIAMClient
+}
+
+func (t *testIAMBadDocumentClient) GetPolicyVersion(ctx context.Context, params *iam.GetPolicyVersionInput, optFns ...func(*iam.Options)) (*iam.GetPolicyVersionOutput, error) {
+	return &iam.GetPolicyVersionOutput{
+		PolicyVersion: &types.PolicyVersion{
+			VersionId: params.VersionId,
+			Document:  sources.PtrString("not a policy"),
+		},
+	}, nil
+}
+
+func TestPolicyGetFuncBadDocument(t *testing.T) {
+	policy, err := policyGetFunc(context.Background(), &testIAMBadDocumentClient{}, "foo", "bar", &TestRateLimit)
+
+	if err != nil {
+		t.Fatalf("expected the policy to be returned despite the bad document, got %v", err)
+	}
+
+	if policy.Document != nil {
+		t.Errorf("expected no document, got %v", policy.Document)
+	}
+
+	if policy.DocumentError == "" {
+		t.Error("expected the document error to be recorded")
+	}
+}
+
+func TestParseEmbeddedPolicyBadDocument(t *testing.T) {
+	policy := parseEmbeddedPolicy(context.Background(), "inline", sources.PtrString("not a policy"))
+
+	if policy.Name != "inline" {
+		t.Errorf("expected name inline, got %v", policy.Name)
+	}
+
+	if policy.Document != nil || policy.DocumentError == "" {
+		t.Errorf("expected the document error to be recorded, got %v", policy)
+	}
 }
 
 func TestPolicyListFunc(t *testing.T) {
@@ -127,6 +205,12 @@ func TestPolicyListFunc(t *testing.T) {
 	if len(policies) != 2 {
 		t.Errorf("expected 2 policies, got %v", len(policies))
 	}
+
+	for _, policy := range policies {
+		if policy.Document == nil {
+			t.Error("document was nil")
+		}
+	}
 }
 
 func TestPolicyListTagsFunc(t *testing.T) {
@@ -177,6 +261,21 @@ func TestPolicyItemMapper(t *testing.T) {
 				UserName: sources.PtrString("userName"),
 			},
 		},
+		Document: &iampolicy.Document{
+			Version: "2012-10-17",
+			Statement: iampolicy.Statements{
+				{
+					Effect:   iampolicy.EffectAllow,
+					Action:   iampolicy.Value{"sns:Publish"},
+					Resource: iampolicy.Value{"arn:aws:sns:eu-west-2:801795385023:alerts"},
+				},
+				{
+					Effect:   iampolicy.EffectAllow,
+					Action:   iampolicy.Value{"kms:Decrypt"},
+					Resource: iampolicy.Value{"arn:aws:kms:eu-west-2:801795385023:key/*"},
+				},
+			},
+		},
 	})
 
 	if err != nil {
@@ -206,10 +305,30 @@ func TestPolicyItemMapper(t *testing.T) {
 			ExpectedQuery:  "roleName",
 			ExpectedScope:  "foo",
 		},
+		{
+			ExpectedType:   "sns-topic",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:sns:eu-west-2:801795385023:alerts",
+			ExpectedScope:  "801795385023.eu-west-2",
+		},
 	}
 
 	tests.Execute(t, item)
 
+	if _, err := item.GetAttributes().Get("document"); err != nil {
+		t.Error(err)
+	}
+
+	wildcards, err := item.GetAttributes().Get("wildcardResources")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if wildcardList, ok := wildcards.([]interface{}); !ok || len(wildcardList) != 1 {
+		t.Errorf("expected 1 wildcard resource, got %v", wildcards)
+	}
+
 	if item.UniqueAttributeValue() != "service-role/AWSControlTowerAdminPolicy" {
 		t.Errorf("unexpected unique attribute value, got %s", item.UniqueAttributeValue())
 	}
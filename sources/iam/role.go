@@ -2,9 +2,8 @@ package iam
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
-	"net/url"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -13,6 +12,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/aws-source/sources/iampolicy"
 	"github.com/overmindtech/sdp-go"
 	"github.com/sourcegraph/conc/iter"
 )
@@ -70,11 +70,6 @@ func enrichRole(ctx context.Context, client IAMClient, roleDetails *RoleDetails,
 	return nil
 }
 
-type embeddedPolicy struct {
-	Name     string
-	Document map[string]interface{}
-}
-
 // getEmbeddedPolicies returns a list of inline policies embedded in the role
 func getEmbeddedPolicies(ctx context.Context, client IAMClient, roleName string, limit *sources.LimitBucket) ([]embeddedPolicy, error) {
 	policiesPaginator := iam.NewListRolePoliciesPaginator(client, &iam.ListRolePoliciesInput{
@@ -124,30 +119,11 @@ func getRolePolicyDetails(ctx context.Context, client IAMClient, roleName string
 		return nil, err
 	}
 
-	if policy == nil || policy.PolicyDocument == nil {
+	if policy == nil {
 		return nil, errors.New("policy document not found")
 	}
 
-	// URL Decode the policy document
-	unescaped, err := url.QueryUnescape(*policy.PolicyDocument)
-
-	if err != nil {
-		return nil, err
-	}
-
-	// Parse the policy into a map[string]interface{} from JSON
-	var policyDoc map[string]interface{}
-
-	err = json.Unmarshal([]byte(unescaped), &policyDoc)
-
-	if err != nil {
-		return nil, err
-	}
-
-	return &embeddedPolicy{
-		Name:     policyName,
-		Document: policyDoc,
-	}, nil
+	return parseEmbeddedPolicy(ctx, policyName, policy.PolicyDocument), nil
 }
 
 // getAttachedPolicies Gets the attached policies for a role, these are actual
@@ -221,15 +197,152 @@ func roleListFunc(ctx context.Context, client IAMClient, scope string, limit *so
 	return roles, nil
 }
 
+// trustedServices Returns the service principals that are allowed to assume
+// a role e.g. lambda.amazonaws.com
+func trustedServices(trustPolicy *iampolicy.Document) []string {
+	services := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, statement := range trustPolicy.Statement {
+		if statement.Effect != iampolicy.EffectAllow || statement.Principal == nil {
+			continue
+		}
+
+		for _, service := range statement.Principal.Service {
+			if !seen[service] {
+				seen[service] = true
+				services = append(services, service)
+			}
+		}
+	}
+
+	return services
+}
+
+// trustPolicyLinks Returns links to the principals that are allowed to assume
+// a role. This includes IAM roles and users (which can be in other accounts),
+// OIDC and SAML providers, and the EKS clusters that use IAM roles for service
+// accounts (IRSA)
+func trustPolicyLinks(trustPolicy *iampolicy.Document, accountID string) []*sdp.LinkedItemQuery {
+	links := make([]*sdp.LinkedItemQuery, 0)
+	seen := make(map[string]bool)
+
+	addLink := func(query *sdp.Query, blastPropagation *sdp.BlastPropagation) {
+		key := query.GetType() + query.GetScope() + query.GetQuery()
+
+		if seen[key] {
+			return
+		}
+
+		seen[key] = true
+		links = append(links, &sdp.LinkedItemQuery{
+			Query:            query,
+			BlastPropagation: blastPropagation,
+		})
+	}
+
+	for _, statement := range trustPolicy.Statement {
+		if statement.Effect != iampolicy.EffectAllow {
+			continue
+		}
+
+		for _, query := range iampolicy.PrincipalQueries(statement.Principal) {
+			switch query.GetType() {
+			case "iam-role", "iam-user":
+				addLink(query, &sdp.BlastPropagation{
+					// Changing the principal won't affect the role
+					In: false,
+					// Changing the role will affect what the principal can do
+					// once it has assumed it
+					Out: true,
+				})
+			case "iam-oidc-provider", "iam-saml-provider":
+				addLink(query, &sdp.BlastPropagation{
+					// If the provider is changed or deleted, federated users
+					// will no longer be able to assume the role
+					In: true,
+					// Changing the role won't affect the provider
+					Out: false,
+				})
+
+				// IRSA roles trust the OIDC provider of the cluster
+				if a, err := sources.ParseARN(query.GetQuery()); err == nil {
+					if clusterQuery := eksClusterQuery(a.ResourceID(), a.AccountID); clusterQuery != nil {
+						addLink(clusterQuery, &sdp.BlastPropagation{
+							// Deleting the cluster will invalidate the issuer
+							In: true,
+							// Changing the role will affect the pods that use
+							// it
+							Out: true,
+						})
+					}
+				}
+			}
+		}
+
+		// IRSA conditions are keyed by the issuer e.g.
+		// oidc.eks.eu-west-2.amazonaws.com/id/EXAMPLE:sub so we can also get
+		// the cluster from these
+		for _, conditions := range statement.Condition {
+			for key := range conditions {
+				issuer, _, found := strings.Cut(key, ":")
+
+				if !found {
+					continue
+				}
+
+				if clusterQuery := eksClusterQuery(issuer, accountID); clusterQuery != nil {
+					addLink(clusterQuery, &sdp.BlastPropagation{
+						// Deleting the cluster will invalidate the issuer
+						In: true,
+						// Changing the role will affect the pods that use it
+						Out: true,
+					})
+				}
+			}
+		}
+	}
+
+	return links
+}
+
 func roleItemMapper(scope string, awsItem *RoleDetails) (*sdp.Item, error) {
 	enrichedRole := struct {
 		*types.Role
 		EmbeddedPolicies []embeddedPolicy
+		// The decoded trust policy. This is left as the raw string if it
+		// can't be parsed
+		AssumeRolePolicyDocument interface{}
+		TrustedServices          []string
+		// Resources in the inline policies that contain wildcards
+		WildcardResources []string
 	}{
 		Role:             awsItem.Role,
 		EmbeddedPolicies: awsItem.EmbeddedPolicies,
 	}
 
+	var trustPolicy *iampolicy.Document
+
+	if awsItem.Role.AssumeRolePolicyDocument != nil {
+		var err error
+		trustPolicy, err = iampolicy.Parse(*awsItem.Role.AssumeRolePolicyDocument)
+
+		if err == nil {
+			enrichedRole.AssumeRolePolicyDocument = trustPolicy
+			enrichedRole.TrustedServices = trustedServices(trustPolicy)
+		} else {
+			enrichedRole.AssumeRolePolicyDocument = *awsItem.Role.AssumeRolePolicyDocument
+		}
+	}
+
+	resourceLinks, wildcards, err := policyResourceLinks(scope, embeddedPolicyDocuments(awsItem.EmbeddedPolicies)...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	enrichedRole.WildcardResources = wildcards
+
 	attributes, err := sources.ToAttributesCase(enrichedRole)
 
 	if err != nil {
@@ -265,6 +378,46 @@ func roleItemMapper(scope string, awsItem *RoleDetails) (*sdp.Item, error) {
 		}
 	}
 
+	// +overmind:link dynamodb-table
+	// +overmind:link ecr-repository
+	// +overmind:link efs-access-point
+	// +overmind:link efs-file-system
+	// +overmind:link elbv2-load-balancer
+	// +overmind:link elbv2-target-group
+	// +overmind:link events-event-bus
+	// +overmind:link events-rule
+	// +overmind:link firehose-delivery-stream
+	// +overmind:link iam-group
+	// +overmind:link iam-instance-profile
+	// +overmind:link iam-policy
+	// +overmind:link kinesis-stream
+	// +overmind:link kms-key
+	// +overmind:link lambda-function
+	// +overmind:link logs-log-group
+	// +overmind:link s3-access-point
+	// +overmind:link s3-bucket
+	// +overmind:link s3-object-lambda-access-point
+	// +overmind:link sfn-activity
+	// +overmind:link sfn-state-machine
+	// +overmind:link sns-topic
+	// +overmind:link sqs-queue
+	item.LinkedItemQueries = append(item.LinkedItemQueries, resourceLinks...)
+
+	if trustPolicy != nil {
+		accountID, _, err := sources.ParseScope(scope)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// +overmind:link iam-role
+		// +overmind:link iam-user
+		// +overmind:link iam-oidc-provider
+		// +overmind:link iam-saml-provider
+		// +overmind:link eks-cluster
+		item.LinkedItemQueries = append(item.LinkedItemQueries, trustPolicyLinks(trustPolicy, accountID)...)
+	}
+
 	return &item, nil
 }
 
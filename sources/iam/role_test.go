@@ -2,12 +2,14 @@ package iam
 
 import (
 	"context"
+	"net/url"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 	"github.com/aws/aws-sdk-go-v2/service/iam/types"
 	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/aws-source/sources/iampolicy"
 	"github.com/overmindtech/sdp-go"
 )
 
@@ -165,14 +167,19 @@ func TestRoleItemMapper(t *testing.T) {
 		EmbeddedPolicies: []embeddedPolicy{
 			{
 				Name: "foo",
-				Document: map[string]interface{}{
-					"Version": "2012-10-17",
-					"Statement": []map[string]interface{}{
+				Document: &iampolicy.Document{
+					Version: "2012-10-17",
+					Statement: iampolicy.Statements{
 						{
-							"Sid":      "VisualEditor0",
-							"Effect":   "Allow",
-							"Action":   "s3:ListAllMyBuckets",
-							"Resource": "*",
+							Sid:      "VisualEditor0",
+							Effect:   iampolicy.EffectAllow,
+							Action:   iampolicy.Value{"s3:ListAllMyBuckets"},
+							Resource: iampolicy.Value{"*"},
+						},
+						{
+							Effect:   iampolicy.EffectAllow,
+							Action:   iampolicy.Value{"sqs:SendMessage"},
+							Resource: iampolicy.Value{"arn:aws:sqs:eu-west-2:801795385023:queue"},
 						},
 					},
 				},
@@ -203,9 +210,137 @@ func TestRoleItemMapper(t *testing.T) {
 			ExpectedQuery:  "arn:aws:iam::aws:policy/AdministratorAccess",
 			ExpectedScope:  "aws",
 		},
+		{
+			ExpectedType:   "sqs-queue",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:sqs:eu-west-2:801795385023:queue",
+			ExpectedScope:  "801795385023.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+
+	wildcards, err := item.GetAttributes().Get("wildcardResources")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if wildcardList, ok := wildcards.([]interface{}); !ok || len(wildcardList) != 1 {
+		t.Errorf("expected 1 wildcard resource, got %v", wildcards)
+	}
+}
+
+func TestRoleItemMapperTrustPolicy(t *testing.T) {
+	trustPolicy := `{
+		"Version": "2012-10-17",
+		"Statement": [
+			{
+				"Effect": "Allow",
+				"Principal": {
+					"Service": ["lambda.amazonaws.com", "edgelambda.amazonaws.com"]
+				},
+				"Action": "sts:AssumeRole"
+			},
+			{
+				"Effect": "Allow",
+				"Principal": {
+					"AWS": ["arn:aws:iam::111122223333:role/deployer", "arn:aws:iam::111122223333:root"]
+				},
+				"Action": "sts:AssumeRole"
+			},
+			{
+				"Effect": "Allow",
+				"Principal": {
+					"Federated": "arn:aws:iam::801795385023:oidc-provider/oidc.eks.eu-west-2.amazonaws.com/id/EXAMPLED539D4633E53DE1B71EXAMPLE"
+				},
+				"Action": "sts:AssumeRoleWithWebIdentity",
+				"Condition": {
+					"StringEquals": {
+						"oidc.eks.eu-west-2.amazonaws.com/id/EXAMPLED539D4633E53DE1B71EXAMPLE:sub": "system:serviceaccount:default:app",
+						"oidc.eks.eu-west-2.amazonaws.com/id/EXAMPLED539D4633E53DE1B71EXAMPLE:aud": "sts.amazonaws.com"
+					}
+				}
+			},
+			{
+				"Effect": "Allow",
+				"Principal": {
+					"Federated": "arn:aws:iam::801795385023:saml-provider/okta"
+				},
+				"Action": "sts:AssumeRoleWithSAML"
+			}
+		]
+	}`
+
+	role := RoleDetails{
+		Role: &types.Role{
+			Path:                     sources.PtrString("/"),
+			RoleName:                 sources.PtrString("app"),
+			RoleId:                   sources.PtrString("AROA3VLV2U27YSTBFCGCJ"),
+			Arn:                      sources.PtrString("arn:aws:iam::801795385023:role/app"),
+			CreateDate:               sources.PtrTime(time.Now()),
+			AssumeRolePolicyDocument: sources.PtrString(url.QueryEscape(trustPolicy)),
+		},
+	}
+
+	item, err := roleItemMapper("801795385023", &role)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	services, err := item.GetAttributes().Get("trustedServices")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if servicesList, ok := services.([]interface{}); !ok || len(servicesList) != 2 {
+		t.Errorf("expected 2 trusted services, got %v", services)
+	}
+
+	if _, err := item.GetAttributes().Get("assumeRolePolicyDocument"); err != nil {
+		t.Error(err)
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "iam-role",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:iam::111122223333:role/deployer",
+			ExpectedScope:  "111122223333",
+		},
+		{
+			ExpectedType:   "iam-oidc-provider",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:iam::801795385023:oidc-provider/oidc.eks.eu-west-2.amazonaws.com/id/EXAMPLED539D4633E53DE1B71EXAMPLE",
+			ExpectedScope:  "801795385023",
+		},
+		{
+			ExpectedType:   "eks-cluster",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "https://oidc.eks.eu-west-2.amazonaws.com/id/EXAMPLED539D4633E53DE1B71EXAMPLE",
+			ExpectedScope:  "801795385023.eu-west-2",
+		},
+		{
+			ExpectedType:   "iam-saml-provider",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:iam::801795385023:saml-provider/okta",
+			ExpectedScope:  "801795385023",
+		},
 	}
 
 	tests.Execute(t, item)
+
+	// The cluster is referenced by both the principal and the conditions but
+	// should only be linked once
+	if len(item.GetLinkedItemQueries()) != len(tests) {
+		t.Errorf("expected %v linked item queries, got %v", len(tests), len(item.GetLinkedItemQueries()))
+	}
 }
 
 func TestNewRoleSource(t *testing.T) {
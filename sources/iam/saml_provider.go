@@ -0,0 +1,144 @@
+package iam
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+type SAMLProviderDetails struct {
+	Arn      string
+	Name     string
+	Provider *iam.GetSAMLProviderOutput
+}
+
+func samlProviderGetFunc(ctx context.Context, client IAMClient, scope, query string, limit *sources.LimitBucket) (*SAMLProviderDetails, error) {
+	providerArn := providerARN(scope, "saml-provider", query)
+
+	limit.Wait(ctx)
+
+	out, err := client.GetSAMLProvider(ctx, &iam.GetSAMLProviderInput{
+		SAMLProviderArn: &providerArn,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &SAMLProviderDetails{
+		Arn:      providerArn,
+		Name:     query,
+		Provider: out,
+	}, nil
+}
+
+func samlProviderListFunc(ctx context.Context, client IAMClient, scope string, limit *sources.LimitBucket) ([]*SAMLProviderDetails, error) {
+	limit.Wait(ctx)
+
+	out, err := client.ListSAMLProviders(ctx, &iam.ListSAMLProvidersInput{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	providers := make([]*SAMLProviderDetails, 0)
+
+	for _, entry := range out.SAMLProviderList {
+		if entry.Arn == nil {
+			continue
+		}
+
+		a, err := sources.ParseARN(*entry.Arn)
+
+		if err != nil {
+			continue
+		}
+
+		provider, err := samlProviderGetFunc(ctx, client, scope, a.ResourceID(), limit)
+
+		if err != nil {
+			return nil, err
+		}
+
+		providers = append(providers, provider)
+	}
+
+	return providers, nil
+}
+
+func samlProviderItemMapper(scope string, awsItem *SAMLProviderDetails) (*sdp.Item, error) {
+	// The metadata document is a large XML document that includes the
+	// signing certificates, so it isn't useful as an attribute
+	attributes, err := sources.ToAttributesCase(awsItem.Provider, "resultMetadata", "tags", "samlMetadataDocument")
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = attributes.Set("arn", awsItem.Arn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = attributes.Set("name", awsItem.Name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := sdp.Item{
+		Type:            "iam-saml-provider",
+		UniqueAttribute: "name",
+		Attributes:      attributes,
+		Scope:           scope,
+		Tags:            make(map[string]string),
+	}
+
+	for _, tag := range awsItem.Provider.Tags {
+		if tag.Key != nil && tag.Value != nil {
+			item.Tags[*tag.Key] = *tag.Value
+		}
+	}
+
+	// Once the metadata expires, federated users can no longer sign in
+	if awsItem.Provider.ValidUntil != nil {
+		if awsItem.Provider.ValidUntil.Before(time.Now()) {
+			item.Health = sdp.Health_HEALTH_ERROR.Enum()
+		} else {
+			item.Health = sdp.Health_HEALTH_OK.Enum()
+		}
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type iam-saml-provider
+// +overmind:descriptiveType IAM SAML Provider
+// +overmind:get Get a SAML provider by name
+// +overmind:list List all SAML providers
+// +overmind:search Search for SAML providers by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_iam_saml_provider.arn
+// +overmind:terraform:method SEARCH
+
+func NewSAMLProviderSource(config aws.Config, accountID string, _ string, limit *sources.LimitBucket) *sources.GetListSource[*SAMLProviderDetails, IAMClient, *iam.Options] {
+	return &sources.GetListSource[*SAMLProviderDetails, IAMClient, *iam.Options]{
+		ItemType:      "iam-saml-provider",
+		Client:        iam.NewFromConfig(config),
+		CacheDuration: 3 * time.Hour, // IAM has very low rate limits, we need to cache for a long time
+		AccountID:     accountID,
+		GetFunc: func(ctx context.Context, client IAMClient, scope, query string) (*SAMLProviderDetails, error) {
+			return samlProviderGetFunc(ctx, client, scope, query, limit)
+		},
+		ListFunc: func(ctx context.Context, client IAMClient, scope string) ([]*SAMLProviderDetails, error) {
+			return samlProviderListFunc(ctx, client, scope, limit)
+		},
+		ItemMapper: samlProviderItemMapper,
+	}
+}
@@ -0,0 +1,95 @@
+package iam
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (t *TestIAMClient) GetSAMLProvider(ctx context.Context, params *iam.GetSAMLProviderInput, optFns ...func(*iam.Options)) (*iam.GetSAMLProviderOutput, error) {
+	return &iam.GetSAMLProviderOutput{
+		CreateDate:           sources.PtrTime(time.Now()),
+		SAMLMetadataDocument: sources.PtrString("<EntityDescriptor></EntityDescriptor>"),
+		ValidUntil:           sources.PtrTime(time.Now().Add(24 * time.Hour)),
+		Tags: []types.Tag{
+			{
+				Key:   sources.PtrString("foo"),
+				Value: sources.PtrString("bar"),
+			},
+		},
+	}, nil
+}
+
+func (t *TestIAMClient) ListSAMLProviders(ctx context.Context, params *iam.ListSAMLProvidersInput, optFns ...func(*iam.Options)) (*iam.ListSAMLProvidersOutput, error) {
+	return &iam.ListSAMLProvidersOutput{
+		SAMLProviderList: []types.SAMLProviderListEntry{
+			{
+				Arn: sources.PtrString("arn:aws:iam::801795385023:saml-provider/okta"),
+			},
+		},
+	}, nil
+}
+
+func TestSAMLProviderGetFunc(t *testing.T) {
+	provider, err := samlProviderGetFunc(context.Background(), &TestIAMClient{}, "801795385023", "okta", &TestRateLimit)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := samlProviderItemMapper("801795385023", provider)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if arn, _ := item.GetAttributes().Get("arn"); arn != "arn:aws:iam::801795385023:saml-provider/okta" {
+		t.Errorf("unexpected ARN %v", arn)
+	}
+
+	if _, err := item.GetAttributes().Get("samlMetadataDocument"); err == nil {
+		t.Error("expected the metadata document to be excluded")
+	}
+
+	if item.GetHealth() != sdp.Health_HEALTH_OK {
+		t.Errorf("expected health to be OK, got %v", item.GetHealth())
+	}
+}
+
+func TestSAMLProviderListFunc(t *testing.T) {
+	providers, err := samlProviderListFunc(context.Background(), &TestIAMClient{}, "801795385023", &TestRateLimit)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(providers) != 1 {
+		t.Fatalf("expected 1 provider, got %v", len(providers))
+	}
+
+	if providers[0].Name != "okta" {
+		t.Errorf("expected provider okta, got %v", providers[0].Name)
+	}
+}
+
+func TestNewSAMLProviderSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewSAMLProviderSource(config, account, region, &TestRateLimit)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 30 * time.Second,
+	}
+
+	test.Run(t)
+}
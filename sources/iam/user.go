@@ -13,8 +13,9 @@ import (
 )
 
 type UserDetails struct {
-	User       *types.User
-	UserGroups []types.Group
+	User             *types.User
+	UserGroups       []types.Group
+	EmbeddedPolicies []embeddedPolicy
 }
 
 func userGetFunc(ctx context.Context, client IAMClient, scope, query string, limit *sources.LimitBucket) (*UserDetails, error) {
@@ -38,7 +39,7 @@ func userGetFunc(ctx context.Context, client IAMClient, scope, query string, lim
 	return &details, nil
 }
 
-// enrichUser Enriches the user with group and tag info
+// enrichUser Enriches the user with group and inline policy info
 func enrichUser(ctx context.Context, client IAMClient, userDetails *UserDetails, limit *sources.LimitBucket) error {
 	var err error
 
@@ -48,9 +49,50 @@ func enrichUser(ctx context.Context, client IAMClient, userDetails *UserDetails,
 		return err
 	}
 
+	userDetails.EmbeddedPolicies, err = getUserEmbeddedPolicies(ctx, client, userDetails.User.UserName, limit)
+
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// getUserEmbeddedPolicies Returns the inline policies embedded in a user
+func getUserEmbeddedPolicies(ctx context.Context, client IAMClient, userName *string, limit *sources.LimitBucket) ([]embeddedPolicy, error) {
+	policies := make([]embeddedPolicy, 0)
+
+	paginator := iam.NewListUserPoliciesPaginator(client, &iam.ListUserPoliciesInput{
+		UserName: userName,
+	})
+
+	for paginator.HasMorePages() {
+		limit.Wait(ctx) // Wait for rate limiting
+		out, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, policyName := range out.PolicyNames {
+			limit.Wait(ctx) // Wait for rate limiting
+			policy, err := client.GetUserPolicy(ctx, &iam.GetUserPolicyInput{
+				UserName:   userName,
+				PolicyName: &policyName,
+			})
+
+			if err != nil {
+				// Ignore these errors
+				continue
+			}
+
+			policies = append(policies, *parseEmbeddedPolicy(ctx, policyName, policy.PolicyDocument))
+		}
+	}
+
+	return policies, nil
+}
+
 // Gets all of the groups that a user is in
 func getUserGroups(ctx context.Context, client IAMClient, userName *string, limit *sources.LimitBucket) ([]types.Group, error) {
 	var out *iam.ListGroupsForUserOutput
@@ -110,7 +152,24 @@ func userListFunc(ctx context.Context, client IAMClient, scope string, limit *so
 }
 
 func userItemMapper(scope string, awsItem *UserDetails) (*sdp.Item, error) {
-	attributes, err := sources.ToAttributesCase(awsItem.User)
+	resourceLinks, wildcards, err := policyResourceLinks(scope, embeddedPolicyDocuments(awsItem.EmbeddedPolicies)...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	enrichedUser := struct {
+		*types.User
+		EmbeddedPolicies []embeddedPolicy
+		// Resources in the inline policies that contain wildcards
+		WildcardResources []string
+	}{
+		User:              awsItem.User,
+		EmbeddedPolicies:  awsItem.EmbeddedPolicies,
+		WildcardResources: wildcards,
+	}
+
+	attributes, err := sources.ToAttributesCase(enrichedUser)
 
 	if err != nil {
 		return nil, err
@@ -141,6 +200,31 @@ func userItemMapper(scope string, awsItem *UserDetails) (*sdp.Item, error) {
 		})
 	}
 
+	// +overmind:link dynamodb-table
+	// +overmind:link ecr-repository
+	// +overmind:link efs-access-point
+	// +overmind:link efs-file-system
+	// +overmind:link elbv2-load-balancer
+	// +overmind:link elbv2-target-group
+	// +overmind:link events-event-bus
+	// +overmind:link events-rule
+	// +overmind:link firehose-delivery-stream
+	// +overmind:link iam-instance-profile
+	// +overmind:link iam-policy
+	// +overmind:link iam-role
+	// +overmind:link kinesis-stream
+	// +overmind:link kms-key
+	// +overmind:link lambda-function
+	// +overmind:link logs-log-group
+	// +overmind:link s3-access-point
+	// +overmind:link s3-bucket
+	// +overmind:link s3-object-lambda-access-point
+	// +overmind:link sfn-activity
+	// +overmind:link sfn-state-machine
+	// +overmind:link sns-topic
+	// +overmind:link sqs-queue
+	item.LinkedItemQueries = append(item.LinkedItemQueries, resourceLinks...)
+
 	return &item, nil
 }
 
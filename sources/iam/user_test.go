@@ -110,6 +110,36 @@ func (t *TestIAMClient) ListUserTags(context.Context, *iam.ListUserTagsInput, ..
 	}, nil
 }
 
+func (t *TestIAMClient) ListUserPolicies(ctx context.Context, params *iam.ListUserPoliciesInput, optFns ...func(*iam.Options)) (*iam.ListUserPoliciesOutput, error) {
+	return &iam.ListUserPoliciesOutput{
+		PolicyNames: []string{
+			"data",
+		},
+	}, nil
+}
+
+func (t *TestIAMClient) GetUserPolicy(ctx context.Context, params *iam.GetUserPolicyInput, optFns ...func(*iam.Options)) (*iam.GetUserPolicyOutput, error) {
+	return &iam.GetUserPolicyOutput{
+		UserName:   params.UserName,
+		PolicyName: params.PolicyName,
+		PolicyDocument: sources.PtrString(`{
+			"Version": "2012-10-17",
+			"Statement": [
+				{
+					"Effect": "Allow",
+					"Action": "dynamodb:GetItem",
+					"Resource": "arn:aws:dynamodb:eu-west-2:801795385023:table/users/index/email"
+				},
+				{
+					"Effect": "Allow",
+					"Action": "s3:GetObject",
+					"Resource": ["arn:aws:s3:::exports/*", "arn:aws:s3:::logs-*"]
+				}
+			]
+		}`),
+	}, nil
+}
+
 func TestGetUserGroups(t *testing.T) {
 	groups, err := getUserGroups(context.Background(), &TestIAMClient{}, sources.PtrString("foo"), &TestRateLimit)
 
@@ -137,6 +167,10 @@ func TestUserGetFunc(t *testing.T) {
 		t.Errorf("expected 3 groups, got %v", len(user.UserGroups))
 
 	}
+
+	if len(user.EmbeddedPolicies) != 1 {
+		t.Errorf("expected 1 embedded policy, got %v", len(user.EmbeddedPolicies))
+	}
 }
 
 func TestUserListFunc(t *testing.T) {
@@ -193,7 +227,14 @@ func TestUserItemMapper(t *testing.T) {
 		},
 	}
 
-	item, err := userItemMapper("foo", &details)
+	var err error
+	details.EmbeddedPolicies, err = getUserEmbeddedPolicies(context.Background(), &TestIAMClient{}, details.User.UserName, &TestRateLimit)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := userItemMapper("801795385023.eu-west-2", &details)
 
 	if err != nil {
 		t.Error(err)
@@ -208,11 +249,33 @@ func TestUserItemMapper(t *testing.T) {
 			ExpectedType:   "iam-group",
 			ExpectedMethod: sdp.QueryMethod_GET,
 			ExpectedQuery:  "name",
-			ExpectedScope:  "foo",
+			ExpectedScope:  "801795385023.eu-west-2",
+		},
+		{
+			ExpectedType:   "dynamodb-table",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:dynamodb:eu-west-2:801795385023:table/users",
+			ExpectedScope:  "801795385023.eu-west-2",
+		},
+		{
+			ExpectedType:   "s3-bucket",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "exports",
+			ExpectedScope:  "801795385023",
 		},
 	}
 
 	tests.Execute(t, item)
+
+	wildcards, err := item.GetAttributes().Get("wildcardResources")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if wildcardList, ok := wildcards.([]interface{}); !ok || len(wildcardList) != 2 {
+		t.Errorf("expected 2 wildcard resources, got %v", wildcards)
+	}
 }
 
 func TestNewUserSource(t *testing.T) {
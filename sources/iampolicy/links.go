@@ -0,0 +1,333 @@
+package iampolicy
+
+import (
+	"strings"
+
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+// arnTypes Maps the service and resource type of an ARN to the item type that
+// it refers to. Resources that don't have a type in their ARN (like SNS
+// topics) use an empty resource type. All of these are searchable by ARN
+var arnTypes = map[string]map[string]string{
+	"dynamodb": {
+		"table": "dynamodb-table",
+	},
+	"ecr": {
+		"repository": "ecr-repository",
+	},
+	"elasticfilesystem": {
+		"access-point": "efs-access-point",
+		"file-system":  "efs-file-system",
+	},
+	"elasticloadbalancing": {
+		"loadbalancer": "elbv2-load-balancer",
+		"targetgroup":  "elbv2-target-group",
+	},
+	"events": {
+		"event-bus": "events-event-bus",
+		"rule":      "events-rule",
+	},
+	"firehose": {
+		"deliverystream": "firehose-delivery-stream",
+	},
+	"kinesis": {
+		"stream": "kinesis-stream",
+	},
+	"kms": {
+		"key": "kms-key",
+	},
+	"lambda": {
+		"function": "lambda-function",
+	},
+	"logs": {
+		"log-group": "logs-log-group",
+	},
+	"s3-object-lambda": {
+		"accesspoint": "s3-object-lambda-access-point",
+	},
+	"sns": {
+		"": "sns-topic",
+	},
+	"sqs": {
+		"": "sqs-queue",
+	},
+	"states": {
+		"activity":     "sfn-activity",
+		"stateMachine": "sfn-state-machine",
+	},
+}
+
+// iamTypes IAM resources are global, so their scope is just the account
+var iamTypes = map[string]string{
+	"group":            "iam-group",
+	"instance-profile": "iam-instance-profile",
+	"oidc-provider":    "iam-oidc-provider",
+	"policy":           "iam-policy",
+	"role":             "iam-role",
+	"saml-provider":    "iam-saml-provider",
+	"user":             "iam-user",
+}
+
+// IsWildcard Whether a value contains IAM wildcards, meaning that it can't be
+// resolved to a single item
+func IsWildcard(value string) bool {
+	return strings.ContainsAny(value, "*?")
+}
+
+// resourceType Returns the resource type of an ARN, or an empty string if the
+// ARN doesn't have one e.g. arn:aws:sns:eu-west-2:052392120703:topic
+func resourceType(a *sources.ARN) string {
+	if !strings.ContainsAny(a.Resource, "/:") {
+		return ""
+	}
+
+	return a.Type()
+}
+
+// ARNQuery Returns a query for the item that an ARN refers to, or nil if the
+// ARN contains wildcards or isn't a type that we know about. S3 bucket ARNs
+// don't contain an account ID, so the bucket is assumed to be in the supplied
+// account
+func ARNQuery(resourceARN string, accountID string) *sdp.Query {
+	a, err := sources.ParseARN(resourceARN)
+
+	if err != nil {
+		return nil
+	}
+
+	switch a.Service {
+	case "s3":
+		if strings.HasPrefix(a.Resource, "accesspoint/") {
+			return accessPointQuery(a)
+		}
+
+		// Bucket ARNs are in the format arn:aws:s3:::bucket/key so we only
+		// want the bucket name
+		if a.Region != "" || a.AccountID != "" || accountID == "" {
+			return nil
+		}
+
+		bucket, _, _ := strings.Cut(a.Resource, "/")
+
+		if bucket == "" || IsWildcard(bucket) {
+			return nil
+		}
+
+		return &sdp.Query{
+			Type:   "s3-bucket",
+			Method: sdp.QueryMethod_GET,
+			Query:  bucket,
+			Scope:  sources.FormatScope(accountID, ""),
+		}
+	case "iam":
+		itemType, ok := iamTypes[resourceType(a)]
+
+		if !ok || IsWildcard(resourceARN) {
+			return nil
+		}
+
+		return &sdp.Query{
+			Type:   itemType,
+			Method: sdp.QueryMethod_SEARCH,
+			Query:  resourceARN,
+			Scope:  sources.FormatScope(a.AccountID, ""),
+		}
+	case "sts":
+		// Assumed role sessions are in the format
+		// assumed-role/{roleName}/{sessionName}, we link to the role
+		sections := strings.Split(a.Resource, "/")
+
+		if len(sections) != 3 || sections[0] != "assumed-role" || IsWildcard(sections[1]) {
+			return nil
+		}
+
+		return &sdp.Query{
+			Type:   "iam-role",
+			Method: sdp.QueryMethod_GET,
+			Query:  sections[1],
+			Scope:  sources.FormatScope(a.AccountID, ""),
+		}
+	}
+
+	itemType, ok := arnTypes[a.Service][resourceType(a)]
+
+	if !ok {
+		return nil
+	}
+
+	switch itemType {
+	case "dynamodb-table":
+		// Indexes and streams are in the format table/{name}/index/{index}
+		// so we link to the table itself
+		sections := strings.SplitN(a.Resource, "/", 3)
+
+		if len(sections) < 2 {
+			return nil
+		}
+
+		a.Resource = sections[0] + "/" + sections[1]
+		resourceARN = a.String()
+	case "logs-log-group":
+		// Log group ARNs often end in :* to include the streams in the group
+		resourceARN = strings.TrimSuffix(resourceARN, ":*")
+	}
+
+	if IsWildcard(resourceARN) {
+		return nil
+	}
+
+	return &sdp.Query{
+		Type:   itemType,
+		Method: sdp.QueryMethod_SEARCH,
+		Query:  resourceARN,
+		Scope:  sources.FormatScope(a.AccountID, a.Region),
+	}
+}
+
+// accessPointQuery Returns a query for an S3 access point ARN. These are in the
+// format arn:aws:s3:{region}:{account}:accesspoint/{name}/object/{key}.
+// Multi-region access points don't have a region and are referenced by their
+// alias rather than their name, so these aren't linked
+func accessPointQuery(a *sources.ARN) *sdp.Query {
+	sections := strings.SplitN(a.Resource, "/", 3)
+
+	if a.Region == "" || len(sections) < 2 || sections[1] == "" || IsWildcard(sections[1]) {
+		return nil
+	}
+
+	a.Resource = sections[0] + "/" + sections[1]
+
+	return &sdp.Query{
+		Type:   "s3-access-point",
+		Method: sdp.QueryMethod_SEARCH,
+		Query:  a.String(),
+		Scope:  sources.FormatScope(a.AccountID, a.Region),
+	}
+}
+
+// ResourceQueries Returns queries for all of the concrete resources that the
+// Resource elements of a policy's Allow statements refer to. Deny statements
+// are skipped since they don't grant access to anything. Wildcards and unknown
+// resource types are also skipped, see WildcardResources for the former
+func (d *Document) ResourceQueries(accountID string) []*sdp.Query {
+	queries := make([]*sdp.Query, 0)
+	seen := make(map[string]bool)
+
+	for _, statement := range d.Statement {
+		if statement.Effect != EffectAllow {
+			continue
+		}
+
+		for _, resource := range statement.Resource {
+			query := ARNQuery(resource, accountID)
+
+			if query == nil {
+				continue
+			}
+
+			key := query.GetType() + query.GetScope() + query.GetQuery()
+
+			if seen[key] {
+				continue
+			}
+
+			seen[key] = true
+			queries = append(queries, query)
+		}
+	}
+
+	return queries
+}
+
+// WildcardResources Returns the resources referenced by a policy that contain
+// wildcards, and therefore can't be linked to a single item
+func (d *Document) WildcardResources() []string {
+	resources := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, statement := range d.Statement {
+		for _, resource := range statement.Resource {
+			if IsWildcard(resource) && !seen[resource] {
+				seen[resource] = true
+				resources = append(resources, resource)
+			}
+		}
+	}
+
+	return resources
+}
+
+// PrincipalQueries Returns queries for the IAM roles and users in the AWS
+// section of a principal, and the OIDC and SAML providers in the Federated
+// section. Account IDs, account root principals, wildcards and web identity
+// providers like accounts.google.com aren't linked since there is no item for
+// them
+func PrincipalQueries(principal *Principal) []*sdp.Query {
+	queries := make([]*sdp.Query, 0)
+
+	if principal == nil {
+		return queries
+	}
+
+	for _, aws := range principal.AWS {
+		query := ARNQuery(aws, "")
+
+		if query == nil {
+			continue
+		}
+
+		switch query.GetType() {
+		case "iam-role", "iam-user":
+			queries = append(queries, query)
+		}
+	}
+
+	for _, federated := range principal.Federated {
+		query := ARNQuery(federated, "")
+
+		if query == nil {
+			continue
+		}
+
+		switch query.GetType() {
+		case "iam-oidc-provider", "iam-saml-provider":
+			queries = append(queries, query)
+		}
+	}
+
+	return queries
+}
+
+// SourceARNs Returns the values of the aws:SourceArn condition key. This is
+// how AWS recommends restricting which resource a service principal is
+// acting on behalf of. Only positive operators are included, since values of
+// negated operators such as ArnNotEquals are the sources that are excluded
+func (s Statement) SourceARNs() []string {
+	return s.Condition.ValuesFor("aws:SourceArn", ArnEquals, ArnLike, StringEquals, StringLike)
+}
+
+// SourceAccounts Returns the values of the aws:SourceAccount condition key,
+// for positive operators only
+func (s Statement) SourceAccounts() []string {
+	return s.Condition.ValuesFor("aws:SourceAccount", StringEquals, StringLike)
+}
+
+// LinkedItemQueries Wraps queries in LinkedItemQuery with the given blast
+// propagation
+func LinkedItemQueries(queries []*sdp.Query, blastPropagation *sdp.BlastPropagation) []*sdp.LinkedItemQuery {
+	links := make([]*sdp.LinkedItemQuery, 0, len(queries))
+
+	for _, query := range queries {
+		links = append(links, &sdp.LinkedItemQuery{
+			Query: query,
+			BlastPropagation: &sdp.BlastPropagation{
+				In:  blastPropagation.GetIn(),
+				Out: blastPropagation.GetOut(),
+			},
+		})
+	}
+
+	return links
+}
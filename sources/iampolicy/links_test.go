@@ -0,0 +1,251 @@
+package iampolicy
+
+import (
+	"testing"
+
+	"github.com/overmindtech/sdp-go"
+)
+
+func TestARNQuery(t *testing.T) {
+	cases := []struct {
+		ARN      string
+		Expected *sdp.Query
+	}{
+		{
+			ARN: "arn:aws:s3:::example-bucket/some/key",
+			Expected: &sdp.Query{
+				Type:   "s3-bucket",
+				Method: sdp.QueryMethod_GET,
+				Query:  "example-bucket",
+				Scope:  "052392120703",
+			},
+		},
+		{
+			ARN: "arn:aws:dynamodb:eu-west-2:052392120703:table/orders/index/by-customer",
+			Expected: &sdp.Query{
+				Type:   "dynamodb-table",
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  "arn:aws:dynamodb:eu-west-2:052392120703:table/orders",
+				Scope:  "052392120703.eu-west-2",
+			},
+		},
+		{
+			ARN: "arn:aws:sns:eu-west-2:052392120703:alerts",
+			Expected: &sdp.Query{
+				Type:   "sns-topic",
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  "arn:aws:sns:eu-west-2:052392120703:alerts",
+				Scope:  "052392120703.eu-west-2",
+			},
+		},
+		{
+			ARN: "arn:aws:iam::111122223333:role/service-role/deployer",
+			Expected: &sdp.Query{
+				Type:   "iam-role",
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  "arn:aws:iam::111122223333:role/service-role/deployer",
+				Scope:  "111122223333",
+			},
+		},
+		{
+			ARN: "arn:aws:sts::111122223333:assumed-role/deployer/session",
+			Expected: &sdp.Query{
+				Type:   "iam-role",
+				Method: sdp.QueryMethod_GET,
+				Query:  "deployer",
+				Scope:  "111122223333",
+			},
+		},
+		{
+			ARN: "arn:aws:logs:eu-west-2:052392120703:log-group:/aws/lambda/example:*",
+			Expected: &sdp.Query{
+				Type:   "logs-log-group",
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  "arn:aws:logs:eu-west-2:052392120703:log-group:/aws/lambda/example",
+				Scope:  "052392120703.eu-west-2",
+			},
+		},
+		{
+			ARN: "arn:aws:s3:eu-west-2:052392120703:accesspoint/uploads/object/some/key",
+			Expected: &sdp.Query{
+				Type:   "s3-access-point",
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  "arn:aws:s3:eu-west-2:052392120703:accesspoint/uploads",
+				Scope:  "052392120703.eu-west-2",
+			},
+		},
+		{
+			ARN: "arn:aws:s3-object-lambda:eu-west-2:052392120703:accesspoint/redacted",
+			Expected: &sdp.Query{
+				Type:   "s3-object-lambda-access-point",
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  "arn:aws:s3-object-lambda:eu-west-2:052392120703:accesspoint/redacted",
+				Scope:  "052392120703.eu-west-2",
+			},
+		},
+		{
+			ARN: "arn:aws:s3::052392120703:accesspoint/mfzwi23gnjvgw.mrap",
+		},
+		{
+			ARN: "arn:aws:s3:::example-*",
+		},
+		{
+			ARN: "arn:aws:sqs:eu-west-2:052392120703:*",
+		},
+		{
+			ARN: "arn:aws:iam::052392120703:root",
+		},
+		{
+			ARN: "*",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.ARN, func(t *testing.T) {
+			query := ARNQuery(c.ARN, "052392120703")
+
+			if c.Expected == nil {
+				if query != nil {
+					t.Errorf("expected no query, got %v", query)
+				}
+
+				return
+			}
+
+			if query == nil {
+				t.Fatal("expected a query, got nil")
+			}
+
+			if query.GetType() != c.Expected.GetType() || query.GetMethod() != c.Expected.GetMethod() || query.GetQuery() != c.Expected.GetQuery() || query.GetScope() != c.Expected.GetScope() {
+				t.Errorf("expected %v, got %v", c.Expected, query)
+			}
+		})
+	}
+}
+
+func TestResourceQueries(t *testing.T) {
+	policy, err := Parse(`{
+		"Statement": [
+			{
+				"Effect": "Allow",
+				"Action": "s3:*",
+				"Resource": ["arn:aws:s3:::example-bucket", "arn:aws:s3:::example-bucket/*", "arn:aws:s3:::logs-*"]
+			},
+			{
+				"Effect": "Allow",
+				"Action": "sqs:SendMessage",
+				"Resource": "arn:aws:sqs:eu-west-2:052392120703:jobs"
+			},
+			{
+				"Effect": "Deny",
+				"Action": "sqs:DeleteQueue",
+				"Resource": "arn:aws:sqs:eu-west-2:052392120703:protected"
+			}
+		]
+	}`)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	queries := policy.ResourceQueries("052392120703")
+
+	// The bucket should only be linked once, and resources that are only
+	// denied shouldn't be linked
+	if len(queries) != 2 {
+		t.Fatalf("expected 2 queries, got %v", queries)
+	}
+
+	if queries[0].GetType() != "s3-bucket" || queries[1].GetType() != "sqs-queue" {
+		t.Errorf("unexpected queries %v", queries)
+	}
+
+	wildcards := policy.WildcardResources()
+
+	if len(wildcards) != 2 {
+		t.Errorf("expected 2 wildcard resources, got %v", wildcards)
+	}
+}
+
+func TestPrincipalQueries(t *testing.T) {
+	principal := Principal{
+		AWS: Value{
+			"arn:aws:iam::111122223333:role/deployer",
+			"arn:aws:iam::111122223333:user/alice",
+			"arn:aws:iam::111122223333:root",
+			"111122223333",
+			"*",
+		},
+		Federated: Value{
+			"arn:aws:iam::111122223333:oidc-provider/oidc.eks.eu-west-2.amazonaws.com/id/EXAMPLED539D4633E53DE1B71EXAMPLE",
+			"arn:aws:iam::111122223333:saml-provider/okta",
+			"cognito-identity.amazonaws.com",
+		},
+	}
+
+	queries := PrincipalQueries(&principal)
+
+	if len(queries) != 4 {
+		t.Fatalf("expected 4 queries, got %v", queries)
+	}
+
+	if queries[0].GetType() != "iam-role" || queries[1].GetType() != "iam-user" || queries[2].GetType() != "iam-oidc-provider" || queries[3].GetType() != "iam-saml-provider" {
+		t.Errorf("unexpected queries %v", queries)
+	}
+
+	if queries[0].GetScope() != "111122223333" {
+		t.Errorf("expected scope 111122223333, got %v", queries[0].GetScope())
+	}
+}
+
+func TestStatementSourceARNs(t *testing.T) {
+	policy, err := Parse(`{
+		"Version": "2012-10-17",
+		"Statement": [
+			{
+				"Effect": "Allow",
+				"Principal": {"Service": "sns.amazonaws.com"},
+				"Action": "sqs:SendMessage",
+				"Resource": "*",
+				"Condition": {
+					"ArnLike": {"aws:SourceArn": "arn:aws:sns:eu-west-2:111122223333:orders"},
+					"StringEquals": {"aws:SourceAccount": "111122223333"}
+				}
+			},
+			{
+				"Effect": "Deny",
+				"Principal": "*",
+				"Action": "sqs:SendMessage",
+				"Resource": "*",
+				"Condition": {
+					"ArnNotEquals": {"aws:SourceArn": "arn:aws:sns:eu-west-2:111122223333:orders"},
+					"StringNotEquals": {"aws:SourceAccount": "111122223333"}
+				}
+			}
+		]
+	}`)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	allow := policy.Statement[0]
+
+	if arns := allow.SourceARNs(); len(arns) != 1 || arns[0] != "arn:aws:sns:eu-west-2:111122223333:orders" {
+		t.Errorf("unexpected source ARNs %v", arns)
+	}
+
+	if accounts := allow.SourceAccounts(); len(accounts) != 1 || accounts[0] != "111122223333" {
+		t.Errorf("unexpected source accounts %v", accounts)
+	}
+
+	deny := policy.Statement[1]
+
+	if arns := deny.SourceARNs(); len(arns) != 0 {
+		t.Errorf("expected no source ARNs for negated operators, got %v", arns)
+	}
+
+	if accounts := deny.SourceAccounts(); len(accounts) != 0 {
+		t.Errorf("expected no source accounts for negated operators, got %v", accounts)
+	}
+}
@@ -0,0 +1,330 @@
+// Package iampolicy models the IAM policy grammar so that identity policies,
+// trust policies and resource policies (buckets, queues, topics, functions,
+// keys, repositories etc.) can all be parsed and linked in the same way. The
+// grammar is documented here:
+// https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_policies_grammar.html
+package iampolicy
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// Document An IAM policy document
+type Document struct {
+	Version   string     `json:",omitempty"`
+	Id        string     `json:",omitempty"`
+	Statement Statements `json:",omitempty"`
+}
+
+// Statements The statements in a policy. These can be either a single
+// statement object or a list of statements
+type Statements []Statement
+
+func (s *Statements) UnmarshalJSON(data []byte) error {
+	var single Statement
+
+	if err := json.Unmarshal(data, &single); err == nil {
+		*s = Statements{single}
+		return nil
+	}
+
+	var list []Statement
+
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+
+	*s = list
+
+	return nil
+}
+
+// Effect Whether a statement allows or denies access
+type Effect string
+
+const (
+	EffectAllow Effect = "Allow"
+	EffectDeny  Effect = "Deny"
+)
+
+// Statement A single statement in a policy
+type Statement struct {
+	Sid          string     `json:",omitempty"`
+	Effect       Effect     `json:",omitempty"`
+	Principal    *Principal `json:",omitempty"`
+	NotPrincipal *Principal `json:",omitempty"`
+	Action       Value      `json:",omitempty"`
+	NotAction    Value      `json:",omitempty"`
+	Resource     Value      `json:",omitempty"`
+	NotResource  Value      `json:",omitempty"`
+	Condition    Condition  `json:",omitempty"`
+}
+
+// Value A policy value that can be either a single string or a list of
+// strings, such as Action, Resource or the values of a condition. Numbers and
+// booleans are also allowed in conditions, so these are converted to strings
+type Value []string
+
+func (v *Value) UnmarshalJSON(data []byte) error {
+	var single interface{}
+
+	if err := json.Unmarshal(data, &single); err != nil {
+		return err
+	}
+
+	switch s := single.(type) {
+	case nil:
+		*v = nil
+	case []interface{}:
+		values := make(Value, 0, len(s))
+
+		for _, element := range s {
+			str, err := valueString(element)
+
+			if err != nil {
+				return err
+			}
+
+			values = append(values, str)
+		}
+
+		*v = values
+	default:
+		str, err := valueString(s)
+
+		if err != nil {
+			return err
+		}
+
+		*v = Value{str}
+	}
+
+	return nil
+}
+
+// valueString Converts a scalar JSON value to a string
+func valueString(i interface{}) (string, error) {
+	switch s := i.(type) {
+	case string:
+		return s, nil
+	case bool, float64:
+		b, err := json.Marshal(s)
+
+		return string(b), err
+	default:
+		return "", errors.New("policy values must be strings, numbers or booleans")
+	}
+}
+
+// Principal The principal that a statement applies to. This is either "*",
+// which means everyone, or a map of principal types to one or more values
+type Principal struct {
+	// All is set when the principal is "*"
+	All           bool  `json:"-"`
+	AWS           Value `json:",omitempty"`
+	Service       Value `json:",omitempty"`
+	Federated     Value `json:",omitempty"`
+	CanonicalUser Value `json:",omitempty"`
+}
+
+func (p *Principal) UnmarshalJSON(data []byte) error {
+	var wildcard string
+
+	if err := json.Unmarshal(data, &wildcard); err == nil {
+		if wildcard != "*" {
+			return errors.New(`principal must be "*" or an object`)
+		}
+
+		*p = Principal{All: true}
+
+		return nil
+	}
+
+	// Use a type without the custom unmarshaller to avoid recursion
+	type principal Principal
+
+	var parsed principal
+
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return err
+	}
+
+	*p = Principal(parsed)
+
+	return nil
+}
+
+func (p Principal) MarshalJSON() ([]byte, error) {
+	if p.All {
+		return json.Marshal("*")
+	}
+
+	type principal Principal
+
+	return json.Marshal(principal(p))
+}
+
+// IsEveryone Whether the principal matches everyone, either through "*" or
+// {"AWS": "*"}
+func (p *Principal) IsEveryone() bool {
+	if p == nil {
+		return false
+	}
+
+	if p.All {
+		return true
+	}
+
+	for _, aws := range p.AWS {
+		if aws == "*" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Condition The conditions of a statement. This is a map of condition
+// operators e.g. StringEquals or ForAnyValue:ArnLike to a map of condition
+// keys and their values
+type Condition map[string]map[string]Value
+
+// Values Returns all values for a given condition key across all operators.
+// Condition keys are case-insensitive
+func (c Condition) Values(key string) []string {
+	values := make([]string, 0)
+
+	for _, conditions := range c {
+		for k, v := range conditions {
+			if strings.EqualFold(k, key) {
+				values = append(values, v...)
+			}
+		}
+	}
+
+	return values
+}
+
+// ValuesFor Returns the values for a given condition key, but only for
+// operators that match one of the given base operators e.g. "StringEquals".
+// Set qualifiers and the IfExists suffix are ignored when matching
+func (c Condition) ValuesFor(key string, baseOperators ...string) []string {
+	values := make([]string, 0)
+
+	for operator, conditions := range c {
+		parsed := ParseOperator(operator)
+
+		matches := false
+		for _, base := range baseOperators {
+			if parsed.Base == base {
+				matches = true
+				break
+			}
+		}
+
+		if !matches {
+			continue
+		}
+
+		for k, v := range conditions {
+			if strings.EqualFold(k, key) {
+				values = append(values, v...)
+			}
+		}
+	}
+
+	return values
+}
+
+// Operator A parsed condition operator
+type Operator struct {
+	// The base operator e.g. StringEquals
+	Base string
+	// The set qualifier, either ForAllValues, ForAnyValue or empty
+	Qualifier string
+	// Whether the operator has the IfExists suffix
+	IfExists bool
+}
+
+// The condition operators that IAM supports
+// https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_policies_elements_condition_operators.html
+const (
+	StringEquals              = "StringEquals"
+	StringNotEquals           = "StringNotEquals"
+	StringEqualsIgnoreCase    = "StringEqualsIgnoreCase"
+	StringNotEqualsIgnoreCase = "StringNotEqualsIgnoreCase"
+	StringLike                = "StringLike"
+	StringNotLike             = "StringNotLike"
+	NumericEquals             = "NumericEquals"
+	NumericNotEquals          = "NumericNotEquals"
+	NumericLessThan           = "NumericLessThan"
+	NumericLessThanEquals     = "NumericLessThanEquals"
+	NumericGreaterThan        = "NumericGreaterThan"
+	NumericGreaterThanEquals  = "NumericGreaterThanEquals"
+	DateEquals                = "DateEquals"
+	DateNotEquals             = "DateNotEquals"
+	DateLessThan              = "DateLessThan"
+	DateLessThanEquals        = "DateLessThanEquals"
+	DateGreaterThan           = "DateGreaterThan"
+	DateGreaterThanEquals     = "DateGreaterThanEquals"
+	Bool                      = "Bool"
+	BinaryEquals              = "BinaryEquals"
+	IpAddress                 = "IpAddress"
+	NotIpAddress              = "NotIpAddress"
+	ArnEquals                 = "ArnEquals"
+	ArnNotEquals              = "ArnNotEquals"
+	ArnLike                   = "ArnLike"
+	ArnNotLike                = "ArnNotLike"
+	Null                      = "Null"
+
+	ForAllValues = "ForAllValues"
+	ForAnyValue  = "ForAnyValue"
+)
+
+// ParseOperator Splits a condition operator into its set qualifier, base
+// operator and IfExists suffix e.g. "ForAnyValue:StringLikeIfExists"
+func ParseOperator(operator string) Operator {
+	var parsed Operator
+
+	if qualifier, base, found := strings.Cut(operator, ":"); found {
+		parsed.Qualifier = qualifier
+		operator = base
+	}
+
+	// Null is the only operator that can't have IfExists
+	if operator != Null && strings.HasSuffix(operator, "IfExists") {
+		parsed.IfExists = true
+		operator = strings.TrimSuffix(operator, "IfExists")
+	}
+
+	parsed.Base = operator
+
+	return parsed
+}
+
+// Parse Parses a policy document. IAM returns documents URL-encoded, so these
+// are decoded first if required
+func Parse(document string) (*Document, error) {
+	document = strings.TrimSpace(document)
+
+	if !strings.HasPrefix(document, "{") {
+		unescaped, err := url.QueryUnescape(document)
+
+		if err != nil {
+			return nil, err
+		}
+
+		document = unescaped
+	}
+
+	var doc Document
+
+	if err := json.Unmarshal([]byte(document), &doc); err != nil {
+		return nil, err
+	}
+
+	return &doc, nil
+}
@@ -0,0 +1,168 @@
+package iampolicy
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+)
+
+var testPolicy = `{
+	"Version": "2012-10-17",
+	"Id": "example",
+	"Statement": [
+		{
+			"Sid": "AllowRead",
+			"Effect": "Allow",
+			"Principal": {
+				"AWS": ["arn:aws:iam::052392120703:role/reader", "111122223333"],
+				"Service": "lambda.amazonaws.com"
+			},
+			"Action": ["s3:GetObject", "s3:ListBucket"],
+			"Resource": "arn:aws:s3:::example-bucket/*",
+			"Condition": {
+				"ForAnyValue:StringLikeIfExists": {
+					"aws:PrincipalTag/team": ["a*", "b*"]
+				},
+				"NumericLessThan": {
+					"s3:max-keys": 10
+				},
+				"Bool": {
+					"aws:SecureTransport": true
+				}
+			}
+		},
+		{
+			"Effect": "Deny",
+			"Principal": "*",
+			"NotAction": "s3:GetObject",
+			"NotResource": ["arn:aws:s3:::example-bucket", "arn:aws:s3:::example-bucket/public/*"]
+		}
+	]
+}`
+
+func TestParse(t *testing.T) {
+	policy, err := Parse(testPolicy)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if policy.Version != "2012-10-17" {
+		t.Errorf("expected version 2012-10-17, got %v", policy.Version)
+	}
+
+	if len(policy.Statement) != 2 {
+		t.Fatalf("expected 2 statements, got %v", len(policy.Statement))
+	}
+
+	allow := policy.Statement[0]
+
+	if allow.Effect != EffectAllow {
+		t.Errorf("expected Allow, got %v", allow.Effect)
+	}
+
+	if len(allow.Principal.AWS) != 2 {
+		t.Errorf("expected 2 AWS principals, got %v", allow.Principal.AWS)
+	}
+
+	if len(allow.Principal.Service) != 1 || allow.Principal.Service[0] != "lambda.amazonaws.com" {
+		t.Errorf("expected service principal lambda.amazonaws.com, got %v", allow.Principal.Service)
+	}
+
+	if len(allow.Action) != 2 {
+		t.Errorf("expected 2 actions, got %v", allow.Action)
+	}
+
+	if len(allow.Resource) != 1 {
+		t.Errorf("expected 1 resource, got %v", allow.Resource)
+	}
+
+	if values := allow.Condition.Values("AWS:PrincipalTag/Team"); len(values) != 2 {
+		t.Errorf("expected 2 values for case-insensitive condition key, got %v", values)
+	}
+
+	if values := allow.Condition.ValuesFor("s3:max-keys", NumericLessThan); len(values) != 1 || values[0] != "10" {
+		t.Errorf("expected numeric condition value 10, got %v", values)
+	}
+
+	if values := allow.Condition.ValuesFor("aws:SecureTransport", Bool); len(values) != 1 || values[0] != "true" {
+		t.Errorf("expected bool condition value true, got %v", values)
+	}
+
+	if values := allow.Condition.ValuesFor("aws:PrincipalTag/team", StringLike); len(values) != 2 {
+		t.Errorf("expected qualified operator to match StringLike, got %v", values)
+	}
+
+	deny := policy.Statement[1]
+
+	if !deny.Principal.All || !deny.Principal.IsEveryone() {
+		t.Error("expected wildcard principal")
+	}
+
+	if len(deny.NotAction) != 1 || len(deny.NotResource) != 2 {
+		t.Errorf("expected NotAction and NotResource to be parsed, got %v and %v", deny.NotAction, deny.NotResource)
+	}
+}
+
+func TestParseSingleStatement(t *testing.T) {
+	policy, err := Parse(`{"Statement": {"Effect": "Allow", "Action": "*", "Resource": "*"}}`)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(policy.Statement) != 1 {
+		t.Fatalf("expected 1 statement, got %v", len(policy.Statement))
+	}
+
+	if policy.Statement[0].Principal != nil {
+		t.Error("expected no principal")
+	}
+}
+
+func TestParseURLEncoded(t *testing.T) {
+	policy, err := Parse(url.QueryEscape(testPolicy))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(policy.Statement) != 2 {
+		t.Errorf("expected 2 statements, got %v", len(policy.Statement))
+	}
+}
+
+func TestPrincipalRoundTrip(t *testing.T) {
+	policy, err := Parse(testPolicy)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(policy.Statement[1].Principal)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != `"*"` {
+		t.Errorf("expected wildcard principal to marshal to \"*\", got %v", string(b))
+	}
+}
+
+func TestParseOperator(t *testing.T) {
+	cases := map[string]Operator{
+		"StringEquals":                   {Base: StringEquals},
+		"ArnLikeIfExists":                {Base: ArnLike, IfExists: true},
+		"ForAllValues:StringNotEquals":   {Base: StringNotEquals, Qualifier: ForAllValues},
+		"ForAnyValue:StringLikeIfExists": {Base: StringLike, Qualifier: ForAnyValue, IfExists: true},
+		"Null":                           {Base: Null},
+		"DateGreaterThanEqualsIfExists":  {Base: DateGreaterThanEquals, IfExists: true},
+	}
+
+	for operator, expected := range cases {
+		if parsed := ParseOperator(operator); parsed != expected {
+			t.Errorf("%v: expected %+v, got %+v", operator, expected, parsed)
+		}
+	}
+}
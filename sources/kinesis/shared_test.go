@@ -0,0 +1,3 @@
+package kinesis
+
+type testKinesisClient struct{}
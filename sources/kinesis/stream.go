@@ -0,0 +1,208 @@
+package kinesis
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+// streamTags Gets all tags for a stream. Kinesis doesn't provide a paginator
+// for this so we need to handle the continuation ourselves
+func streamTags(ctx context.Context, client KinesisClient, streamName *string) (map[string]string, error) {
+	tags := make([]types.Tag, 0)
+	input := kinesis.ListTagsForStreamInput{
+		StreamName: streamName,
+	}
+
+	for {
+		out, err := client.ListTagsForStream(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, out.Tags...)
+
+		if out.HasMoreTags == nil || !*out.HasMoreTags || len(out.Tags) == 0 {
+			break
+		}
+
+		input.ExclusiveStartTagKey = out.Tags[len(out.Tags)-1].Key
+	}
+
+	return tagsToMap(tags), nil
+}
+
+func streamGetFunc(ctx context.Context, client KinesisClient, scope string, input *kinesis.DescribeStreamSummaryInput) (*sdp.Item, error) {
+	output, err := client.DescribeStreamSummary(ctx, input)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if output.StreamDescriptionSummary == nil {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: "stream description summary was nil",
+		}
+	}
+
+	stream := output.StreamDescriptionSummary
+
+	// Enrich the stream with the details of the consumers that are
+	// registered to it
+	consumers := make([]types.Consumer, 0)
+
+	if stream.StreamARN != nil {
+		paginator := kinesis.NewListStreamConsumersPaginator(client, &kinesis.ListStreamConsumersInput{
+			StreamARN: stream.StreamARN,
+		})
+
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+
+			if err != nil {
+				return nil, err
+			}
+
+			consumers = append(consumers, page.Consumers...)
+		}
+	}
+
+	enrichedStream := struct {
+		*types.StreamDescriptionSummary
+		Consumers []types.Consumer
+	}{
+		StreamDescriptionSummary: stream,
+		Consumers:                consumers,
+	}
+
+	attributes, err := sources.ToAttributesCase(enrichedStream)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := streamTags(ctx, client, stream.StreamName)
+
+	if err != nil {
+		tags = sources.HandleTagsError(ctx, err)
+	}
+
+	item := sdp.Item{
+		Type:            "kinesis-stream",
+		UniqueAttribute: "streamName",
+		Attributes:      attributes,
+		Scope:           scope,
+		Tags:            tags,
+	}
+
+	switch stream.StreamStatus {
+	case types.StreamStatusActive:
+		item.Health = sdp.Health_HEALTH_OK.Enum()
+	case types.StreamStatusCreating, types.StreamStatusUpdating:
+		item.Health = sdp.Health_HEALTH_PENDING.Enum()
+	case types.StreamStatusDeleting:
+		item.Health = sdp.Health_HEALTH_WARNING.Enum()
+	}
+
+	if stream.StreamARN != nil {
+		// +overmind:link lambda-event-source-mapping
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "lambda-event-source-mapping",
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  *stream.StreamARN,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Records in the stream are delivered to the function by the
+				// mapping, so these are tightly coupled
+				In:  true,
+				Out: true,
+			},
+		})
+	}
+
+	if stream.EncryptionType == types.EncryptionTypeKms && stream.KeyId != nil {
+		// This can be a key ARN, alias ARN, alias name or key ID
+		if a, err := sources.ParseARN(*stream.KeyId); err == nil {
+			// +overmind:link kms-key
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "kms-key",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *stream.KeyId,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the key will affect the stream
+					In: true,
+					// Changing the stream won't affect the key
+					Out: false,
+				},
+			})
+		} else {
+			// +overmind:link kms-key
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "kms-key",
+					Method: sdp.QueryMethod_GET,
+					Query:  *stream.KeyId,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the key will affect the stream
+					In: true,
+					// Changing the stream won't affect the key
+					Out: false,
+				},
+			})
+		}
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type kinesis-stream
+// +overmind:descriptiveType Kinesis Data Stream
+// +overmind:get Get a Kinesis stream by name
+// +overmind:list List all Kinesis streams
+// +overmind:search Search for Kinesis streams by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_kinesis_stream.name
+
+func NewStreamSource(config aws.Config, accountID string, region string) *sources.AlwaysGetSource[*kinesis.ListStreamsInput, *kinesis.ListStreamsOutput, *kinesis.DescribeStreamSummaryInput, *kinesis.DescribeStreamSummaryOutput, KinesisClient, *kinesis.Options] {
+	return &sources.AlwaysGetSource[*kinesis.ListStreamsInput, *kinesis.ListStreamsOutput, *kinesis.DescribeStreamSummaryInput, *kinesis.DescribeStreamSummaryOutput, KinesisClient, *kinesis.Options]{
+		ItemType:  "kinesis-stream",
+		Client:    kinesis.NewFromConfig(config),
+		AccountID: accountID,
+		Region:    region,
+		ListInput: &kinesis.ListStreamsInput{},
+		GetInputMapper: func(scope, query string) *kinesis.DescribeStreamSummaryInput {
+			return &kinesis.DescribeStreamSummaryInput{
+				StreamName: &query,
+			}
+		},
+		ListFuncPaginatorBuilder: func(client KinesisClient, input *kinesis.ListStreamsInput) sources.Paginator[*kinesis.ListStreamsOutput, *kinesis.Options] {
+			return kinesis.NewListStreamsPaginator(client, input)
+		},
+		ListFuncOutputMapper: func(output *kinesis.ListStreamsOutput, _ *kinesis.ListStreamsInput) ([]*kinesis.DescribeStreamSummaryInput, error) {
+			inputs := make([]*kinesis.DescribeStreamSummaryInput, len(output.StreamNames))
+
+			for i := range output.StreamNames {
+				inputs[i] = &kinesis.DescribeStreamSummaryInput{
+					StreamName: &output.StreamNames[i],
+				}
+			}
+
+			return inputs, nil
+		},
+		GetFunc: streamGetFunc,
+	}
+}
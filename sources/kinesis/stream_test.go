@@ -0,0 +1,132 @@
+package kinesis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testKinesisClient) DescribeStreamSummary(ctx context.Context, params *kinesis.DescribeStreamSummaryInput, optFns ...func(*kinesis.Options)) (*kinesis.DescribeStreamSummaryOutput, error) {
+	return &kinesis.DescribeStreamSummaryOutput{
+		StreamDescriptionSummary: &types.StreamDescriptionSummary{
+			StreamName:              params.StreamName,
+			StreamARN:               sources.PtrString("arn:aws:kinesis:us-east-1:123456789012:stream/orders"),
+			StreamStatus:            types.StreamStatusActive, // health
+			StreamCreationTimestamp: sources.PtrTime(time.Now()),
+			RetentionPeriodHours:    sources.PtrInt32(24),
+			OpenShardCount:          sources.PtrInt32(4),
+			ConsumerCount:           sources.PtrInt32(1),
+			EncryptionType:          types.EncryptionTypeKms,
+			KeyId:                   sources.PtrString("arn:aws:kms:us-east-1:123456789012:key/12345678-1234-1234-1234-123456789012"), // link
+			StreamModeDetails: &types.StreamModeDetails{
+				StreamMode: types.StreamModeProvisioned,
+			},
+			EnhancedMonitoring: []types.EnhancedMetrics{
+				{
+					ShardLevelMetrics: []types.MetricsName{
+						types.MetricsNameIncomingBytes,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func (c testKinesisClient) ListStreamConsumers(ctx context.Context, params *kinesis.ListStreamConsumersInput, optFns ...func(*kinesis.Options)) (*kinesis.ListStreamConsumersOutput, error) {
+	return &kinesis.ListStreamConsumersOutput{
+		Consumers: []types.Consumer{
+			{
+				ConsumerARN:               sources.PtrString("arn:aws:kinesis:us-east-1:123456789012:stream/orders/consumer/analytics:1700000000"),
+				ConsumerName:              sources.PtrString("analytics"),
+				ConsumerStatus:            types.ConsumerStatusActive,
+				ConsumerCreationTimestamp: sources.PtrTime(time.Now()),
+			},
+		},
+	}, nil
+}
+
+func (c testKinesisClient) ListStreams(ctx context.Context, params *kinesis.ListStreamsInput, optFns ...func(*kinesis.Options)) (*kinesis.ListStreamsOutput, error) {
+	return &kinesis.ListStreamsOutput{
+		StreamNames:    []string{"orders"},
+		HasMoreStreams: sources.PtrBool(false),
+	}, nil
+}
+
+func (c testKinesisClient) ListTagsForStream(ctx context.Context, params *kinesis.ListTagsForStreamInput, optFns ...func(*kinesis.Options)) (*kinesis.ListTagsForStreamOutput, error) {
+	return &kinesis.ListTagsForStreamOutput{
+		Tags: []types.Tag{
+			{
+				Key:   sources.PtrString("team"),
+				Value: sources.PtrString("orders"),
+			},
+		},
+		HasMoreTags: sources.PtrBool(false),
+	}, nil
+}
+
+func TestStreamGetFunc(t *testing.T) {
+	item, err := streamGetFunc(context.Background(), testKinesisClient{}, "123456789012.us-east-1", &kinesis.DescribeStreamSummaryInput{
+		StreamName: sources.PtrString("orders"),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := item.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if item.GetHealth() != sdp.Health_HEALTH_OK {
+		t.Errorf("expected health OK, got %v", item.GetHealth())
+	}
+
+	if item.GetTags()["team"] != "orders" {
+		t.Errorf("expected tag team=orders, got %v", item.GetTags())
+	}
+
+	consumers, err := item.GetAttributes().Get("consumers")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c, ok := consumers.([]interface{}); !ok || len(c) != 1 {
+		t.Errorf("expected 1 consumer, got %v", consumers)
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "lambda-event-source-mapping",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:kinesis:us-east-1:123456789012:stream/orders",
+			ExpectedScope:  "123456789012.us-east-1",
+		},
+		{
+			ExpectedType:   "kms-key",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:kms:us-east-1:123456789012:key/12345678-1234-1234-1234-123456789012",
+			ExpectedScope:  "123456789012.us-east-1",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestNewStreamSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewStreamSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
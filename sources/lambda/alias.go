@@ -0,0 +1,214 @@
+package lambda
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+type AliasDetails struct {
+	Alias *lambda.GetAliasOutput
+
+	// The provisioned concurrency config for the alias, if there is one
+	ProvisionedConcurrencyConfig *lambda.GetProvisionedConcurrencyConfigOutput
+}
+
+func aliasGetInputMapper(scope, query string) *lambda.GetAliasInput {
+	functionName, aliasName := parseQualifiedName(query)
+
+	if functionName == "" {
+		return nil
+	}
+
+	return &lambda.GetAliasInput{
+		FunctionName: &functionName,
+		Name:         &aliasName,
+	}
+}
+
+func aliasGetFunc(ctx context.Context, client LambdaClient, scope string, input *lambda.GetAliasInput) (*sdp.Item, error) {
+	if input == nil {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: "query must be in the format {functionName}:{aliasName}",
+		}
+	}
+
+	out, err := client.GetAlias(ctx, input)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if out.AliasArn == nil {
+		return nil, errors.New("alias has empty ARN")
+	}
+
+	// Function names in the input could be ARNs, so we take the name from the
+	// alias ARN instead
+	fullName := qualifiedNameFromARN(*out.AliasArn)
+	functionName, _ := parseQualifiedName(fullName)
+
+	alias := AliasDetails{
+		Alias: out,
+	}
+
+	pcc, err := client.GetProvisionedConcurrencyConfig(ctx, &lambda.GetProvisionedConcurrencyConfigInput{
+		FunctionName: &functionName,
+		Qualifier:    out.Name,
+	})
+
+	if err != nil {
+		var notFound *types.ProvisionedConcurrencyConfigNotFoundException
+
+		if !errors.As(err, &notFound) {
+			return nil, err
+		}
+	} else {
+		alias.ProvisionedConcurrencyConfig = pcc
+	}
+
+	attributes, err := sources.ToAttributesCase(alias, "resultMetadata")
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = attributes.Set("fullName", fullName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := sdp.Item{
+		Type:            "lambda-alias",
+		UniqueAttribute: "fullName",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	// +overmind:link lambda-function
+	item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+		Query: &sdp.Query{
+			Type:   "lambda-function",
+			Method: sdp.QueryMethod_GET,
+			Query:  functionName,
+			Scope:  scope,
+		},
+		BlastPropagation: &sdp.BlastPropagation{
+			// The alias is part of the function
+			In:  true,
+			Out: true,
+		},
+	})
+
+	versions := make([]string, 0)
+
+	if out.FunctionVersion != nil {
+		versions = append(versions, *out.FunctionVersion)
+	}
+
+	if out.RoutingConfig != nil {
+		for version := range out.RoutingConfig.AdditionalVersionWeights {
+			versions = append(versions, version)
+		}
+	}
+
+	for _, version := range versions {
+		// +overmind:link lambda-function-version
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "lambda-function-version",
+				Method: sdp.QueryMethod_GET,
+				Query:  functionName + ":" + version,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Changing the version will change what the alias runs
+				In: true,
+				// Changing the alias won't affect the version, but it will
+				// change how much traffic is sent to it
+				Out: true,
+			},
+		})
+	}
+
+	if alias.ProvisionedConcurrencyConfig != nil {
+		switch alias.ProvisionedConcurrencyConfig.Status {
+		case types.ProvisionedConcurrencyStatusEnumReady:
+			item.Health = sdp.Health_HEALTH_OK.Enum()
+		case types.ProvisionedConcurrencyStatusEnumInProgress:
+			item.Health = sdp.Health_HEALTH_PENDING.Enum()
+		case types.ProvisionedConcurrencyStatusEnumFailed:
+			item.Health = sdp.Health_HEALTH_ERROR.Enum()
+		}
+
+		// +overmind:link lambda-provisioned-concurrency-config
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "lambda-provisioned-concurrency-config",
+				Method: sdp.QueryMethod_GET,
+				Query:  fullName,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// The config determines how many warm instances serve the
+				// alias
+				In:  true,
+				Out: true,
+			},
+		})
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type lambda-alias
+// +overmind:descriptiveType Lambda Alias
+// +overmind:get Get an alias by full name ({functionName}:{aliasName})
+// +overmind:search Search for aliases by function name or ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_lambda_alias.arn
+// +overmind:terraform:method SEARCH
+
+func NewAliasSource(config aws.Config, accountID string, region string) *sources.AlwaysGetSource[*lambda.ListAliasesInput, *lambda.ListAliasesOutput, *lambda.GetAliasInput, *lambda.GetAliasOutput, LambdaClient, *lambda.Options] {
+	return &sources.AlwaysGetSource[*lambda.ListAliasesInput, *lambda.ListAliasesOutput, *lambda.GetAliasInput, *lambda.GetAliasOutput, LambdaClient, *lambda.Options]{
+		ItemType:  "lambda-alias",
+		Client:    lambda.NewFromConfig(config),
+		AccountID: accountID,
+		Region:    region,
+		// Aliases can only be listed per function
+		DisableList:    true,
+		ListInput:      &lambda.ListAliasesInput{},
+		GetInputMapper: aliasGetInputMapper,
+		GetFunc:        aliasGetFunc,
+		SearchInputMapper: func(scope, query string) (*lambda.ListAliasesInput, error) {
+			return &lambda.ListAliasesInput{
+				FunctionName: sources.PtrString(functionNameFromQuery(query)),
+			}, nil
+		},
+		ListFuncPaginatorBuilder: func(client LambdaClient, input *lambda.ListAliasesInput) sources.Paginator[*lambda.ListAliasesOutput, *lambda.Options] {
+			return lambda.NewListAliasesPaginator(client, input)
+		},
+		ListFuncOutputMapper: func(output *lambda.ListAliasesOutput, input *lambda.ListAliasesInput) ([]*lambda.GetAliasInput, error) {
+			inputs := make([]*lambda.GetAliasInput, 0, len(output.Aliases))
+
+			for _, alias := range output.Aliases {
+				if alias.Name != nil {
+					inputs = append(inputs, &lambda.GetAliasInput{
+						FunctionName: input.FunctionName,
+						Name:         alias.Name,
+					})
+				}
+			}
+
+			return inputs, nil
+		},
+	}
+}
@@ -0,0 +1,130 @@
+package lambda
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (t *TestLambdaClient) GetAlias(ctx context.Context, params *lambda.GetAliasInput, optFns ...func(*lambda.Options)) (*lambda.GetAliasOutput, error) {
+	return &lambda.GetAliasOutput{
+		AliasArn:        sources.PtrString("arn:aws:lambda:eu-west-2:052392120703:function:process-orders:" + *params.Name),
+		Name:            params.Name,
+		Description:     sources.PtrString("description"),
+		FunctionVersion: sources.PtrString("3"), // link
+		RevisionId:      sources.PtrString("b00dd2e6-eec3-48b0-abf1-f84406e00a3e"),
+		RoutingConfig: &types.AliasRoutingConfiguration{
+			AdditionalVersionWeights: map[string]float64{
+				"4": 0.1, // link
+			},
+		},
+	}, nil
+}
+
+func (t *TestLambdaClient) ListAliases(context.Context, *lambda.ListAliasesInput, ...func(*lambda.Options)) (*lambda.ListAliasesOutput, error) {
+	return &lambda.ListAliasesOutput{}, nil
+}
+
+func TestAliasGetInputMapper(t *testing.T) {
+	tests := []struct {
+		Query     string
+		ExpectNil bool
+	}{
+		{
+			Query:     "process-orders:live",
+			ExpectNil: false,
+		},
+		{
+			Query:     "process-orders",
+			ExpectNil: true,
+		},
+		{
+			Query:     ":live",
+			ExpectNil: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Query, func(t *testing.T) {
+			input := aliasGetInputMapper("foo", test.Query)
+
+			if input == nil && !test.ExpectNil {
+				t.Error("input was nil unexpectedly")
+			}
+
+			if input != nil && test.ExpectNil {
+				t.Error("input was non-nil when expected to be nil")
+			}
+		})
+	}
+}
+
+func TestAliasGetFunc(t *testing.T) {
+	item, err := aliasGetFunc(context.Background(), &TestLambdaClient{}, "foo", &lambda.GetAliasInput{
+		FunctionName: sources.PtrString("process-orders"),
+		Name:         sources.PtrString("live"),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.UniqueAttributeValue() != "process-orders:live" {
+		t.Errorf("expected unique attribute value to be process-orders:live, got %v", item.UniqueAttributeValue())
+	}
+
+	if item.GetHealth() != sdp.Health_HEALTH_OK {
+		t.Errorf("expected health to be OK, got %v", item.GetHealth())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "lambda-function",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "process-orders",
+			ExpectedScope:  "foo",
+		},
+		{
+			ExpectedType:   "lambda-function-version",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "process-orders:3",
+			ExpectedScope:  "foo",
+		},
+		{
+			ExpectedType:   "lambda-function-version",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "process-orders:4",
+			ExpectedScope:  "foo",
+		},
+		{
+			ExpectedType:   "lambda-provisioned-concurrency-config",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "process-orders:live",
+			ExpectedScope:  "foo",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestNewAliasSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewAliasSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
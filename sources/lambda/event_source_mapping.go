@@ -0,0 +1,237 @@
+package lambda
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+// eventSourceLink Returns a link to the queue, stream or table that an event
+// source mapping reads from. Sources that don't have an item type, such as MSK
+// clusters and Amazon MQ brokers, aren't linked
+func eventSourceLink(eventSourceARN string) *sdp.LinkedItemQuery {
+	a, err := sources.ParseARN(eventSourceARN)
+
+	if err != nil {
+		return nil
+	}
+
+	query := &sdp.Query{
+		Method: sdp.QueryMethod_SEARCH,
+		Query:  eventSourceARN,
+		Scope:  sources.FormatScope(a.AccountID, a.Region),
+	}
+
+	switch a.Service {
+	case "sqs":
+		query.Type = "sqs-queue"
+	case "kinesis":
+		query.Type = "kinesis-stream"
+	case "dynamodb":
+		// DynamoDB stream ARNs are in the format
+		// arn:aws:dynamodb:{region}:{account}:table/{table}/stream/{label} so
+		// we link to the table
+		sections := strings.Split(a.Resource, "/")
+
+		if len(sections) < 2 || sections[0] != "table" {
+			return nil
+		}
+
+		query.Type = "dynamodb-table"
+		query.Method = sdp.QueryMethod_GET
+		query.Query = sections[1]
+	default:
+		return nil
+	}
+
+	return &sdp.LinkedItemQuery{
+		Query: query,
+		BlastPropagation: &sdp.BlastPropagation{
+			// The source and the mapping make up a pipeline, changes to
+			// either will affect the other
+			In:  true,
+			Out: true,
+		},
+	}
+}
+
+// functionQualifierLink Returns a link to the version or alias that a
+// qualified function ARN invokes. Unqualified ARNs and $LATEST invoke the
+// function itself, so don't need an extra link
+func functionQualifierLink(functionARN string) *sdp.LinkedItemQuery {
+	a, err := sources.ParseARN(functionARN)
+
+	if err != nil {
+		return nil
+	}
+
+	name := qualifiedNameFromARN(functionARN)
+	_, qualifier := parseQualifiedName(name)
+
+	if qualifier == "" || qualifier == "$LATEST" {
+		return nil
+	}
+
+	query := &sdp.Query{
+		Method: sdp.QueryMethod_GET,
+		Query:  name,
+		Scope:  sources.FormatScope(a.AccountID, a.Region),
+	}
+
+	// Versions are always numeric, and alias names can't be
+	if _, err := strconv.ParseUint(qualifier, 10, 64); err == nil {
+		query.Type = "lambda-function-version"
+	} else {
+		query.Type = "lambda-alias"
+	}
+
+	return &sdp.LinkedItemQuery{
+		Query: query,
+		BlastPropagation: &sdp.BlastPropagation{
+			// Changing the version or alias will change what is invoked
+			In:  true,
+			Out: true,
+		},
+	}
+}
+
+func eventSourceMappingGetFunc(ctx context.Context, client LambdaClient, scope string, input *lambda.GetEventSourceMappingInput) (*sdp.Item, error) {
+	out, err := client.GetEventSourceMapping(ctx, input)
+
+	if err != nil {
+		return nil, err
+	}
+
+	attributes, err := sources.ToAttributesCase(out, "resultMetadata")
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := sdp.Item{
+		Type:            "lambda-event-source-mapping",
+		UniqueAttribute: "uUID",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	if out.LastProcessingResult != nil && strings.HasPrefix(*out.LastProcessingResult, "PROBLEM") {
+		item.Health = sdp.Health_HEALTH_ERROR.Enum()
+	} else if out.State != nil {
+		switch *out.State {
+		case "Enabled":
+			item.Health = sdp.Health_HEALTH_OK.Enum()
+		case "Creating", "Enabling", "Disabling", "Updating", "Deleting":
+			item.Health = sdp.Health_HEALTH_PENDING.Enum()
+		}
+	}
+
+	if out.FunctionArn != nil {
+		if a, err := sources.ParseARN(*out.FunctionArn); err == nil {
+			// +overmind:link lambda-function
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "lambda-function",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *out.FunctionArn,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// The mapping invokes the function, so they are tightly
+					// coupled
+					In:  true,
+					Out: true,
+				},
+			})
+
+			// The mapping may invoke a specific version or alias
+			//
+			// +overmind:link lambda-function-version
+			// +overmind:link lambda-alias
+			if link := functionQualifierLink(*out.FunctionArn); link != nil {
+				item.LinkedItemQueries = append(item.LinkedItemQueries, link)
+			}
+		}
+	}
+
+	if out.EventSourceArn != nil {
+		// +overmind:link sqs-queue
+		// +overmind:link kinesis-stream
+		// +overmind:link dynamodb-table
+		if link := eventSourceLink(*out.EventSourceArn); link != nil {
+			item.LinkedItemQueries = append(item.LinkedItemQueries, link)
+		}
+	}
+
+	if out.DestinationConfig != nil && out.DestinationConfig.OnFailure != nil && out.DestinationConfig.OnFailure.Destination != nil {
+		// Possible links from `GetEventLinkedItem()`
+		// +overmind:link sns-topic
+		// +overmind:link sqs-queue
+		if lir, err := GetEventLinkedItem(*out.DestinationConfig.OnFailure.Destination); err == nil {
+			item.LinkedItemQueries = append(item.LinkedItemQueries, lir)
+		}
+	}
+
+	return &item, nil
+}
+
+// eventSourceMappingSearchInputMapper Searches for event source mappings by
+// the name or ARN of the function, or by the ARN of the event source
+func eventSourceMappingSearchInputMapper(scope, query string) (*lambda.ListEventSourceMappingsInput, error) {
+	if a, err := sources.ParseARN(query); err == nil && a.Service != "lambda" {
+		return &lambda.ListEventSourceMappingsInput{
+			EventSourceArn: &query,
+		}, nil
+	}
+
+	return &lambda.ListEventSourceMappingsInput{
+		FunctionName: &query,
+	}, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type lambda-event-source-mapping
+// +overmind:descriptiveType Lambda Event Source Mapping
+// +overmind:get Get an event source mapping by UUID
+// +overmind:list List all event source mappings
+// +overmind:search Search for event source mappings by function name or ARN, or by the ARN of the event source
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_lambda_event_source_mapping.uuid
+
+func NewEventSourceMappingSource(config aws.Config, accountID string, region string) *sources.AlwaysGetSource[*lambda.ListEventSourceMappingsInput, *lambda.ListEventSourceMappingsOutput, *lambda.GetEventSourceMappingInput, *lambda.GetEventSourceMappingOutput, LambdaClient, *lambda.Options] {
+	return &sources.AlwaysGetSource[*lambda.ListEventSourceMappingsInput, *lambda.ListEventSourceMappingsOutput, *lambda.GetEventSourceMappingInput, *lambda.GetEventSourceMappingOutput, LambdaClient, *lambda.Options]{
+		ItemType:  "lambda-event-source-mapping",
+		Client:    lambda.NewFromConfig(config),
+		AccountID: accountID,
+		Region:    region,
+		ListInput: &lambda.ListEventSourceMappingsInput{},
+		GetFunc:   eventSourceMappingGetFunc,
+		GetInputMapper: func(scope, query string) *lambda.GetEventSourceMappingInput {
+			return &lambda.GetEventSourceMappingInput{
+				UUID: &query,
+			}
+		},
+		SearchInputMapper: eventSourceMappingSearchInputMapper,
+		ListFuncPaginatorBuilder: func(client LambdaClient, input *lambda.ListEventSourceMappingsInput) sources.Paginator[*lambda.ListEventSourceMappingsOutput, *lambda.Options] {
+			return lambda.NewListEventSourceMappingsPaginator(client, input)
+		},
+		ListFuncOutputMapper: func(output *lambda.ListEventSourceMappingsOutput, input *lambda.ListEventSourceMappingsInput) ([]*lambda.GetEventSourceMappingInput, error) {
+			inputs := make([]*lambda.GetEventSourceMappingInput, 0, len(output.EventSourceMappings))
+
+			for _, mapping := range output.EventSourceMappings {
+				if mapping.UUID != nil {
+					inputs = append(inputs, &lambda.GetEventSourceMappingInput{
+						UUID: mapping.UUID,
+					})
+				}
+			}
+
+			return inputs, nil
+		},
+	}
+}
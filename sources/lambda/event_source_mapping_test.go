@@ -0,0 +1,236 @@
+package lambda
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (t *TestLambdaClient) GetEventSourceMapping(ctx context.Context, params *lambda.GetEventSourceMappingInput, optFns ...func(*lambda.Options)) (*lambda.GetEventSourceMappingOutput, error) {
+	return &lambda.GetEventSourceMappingOutput{
+		UUID:                  params.UUID,
+		BatchSize:             sources.PtrInt32(10),
+		EventSourceArn:        sources.PtrString("arn:aws:sqs:eu-west-2:052392120703:orders"),                          // link
+		FunctionArn:           sources.PtrString("arn:aws:lambda:eu-west-2:052392120703:function:process-orders:live"), // link
+		LastModified:          sources.PtrTime(time.Now()),
+		LastProcessingResult:  sources.PtrString("OK"),
+		State:                 sources.PtrString("Enabled"),
+		StateTransitionReason: sources.PtrString("USER_INITIATED"),
+		DestinationConfig: &types.DestinationConfig{
+			OnFailure: &types.OnFailure{
+				Destination: sources.PtrString("arn:aws:sns:eu-west-2:052392120703:failures"), // link
+			},
+		},
+	}, nil
+}
+
+func (t *TestLambdaClient) ListEventSourceMappings(context.Context, *lambda.ListEventSourceMappingsInput, ...func(*lambda.Options)) (*lambda.ListEventSourceMappingsOutput, error) {
+	return &lambda.ListEventSourceMappingsOutput{}, nil
+}
+
+func TestEventSourceLink(t *testing.T) {
+	tests := []struct {
+		ARN           string
+		ExpectedType  string
+		ExpectedQuery string
+	}{
+		{
+			ARN:           "arn:aws:sqs:eu-west-2:052392120703:orders",
+			ExpectedType:  "sqs-queue",
+			ExpectedQuery: "arn:aws:sqs:eu-west-2:052392120703:orders",
+		},
+		{
+			ARN:           "arn:aws:kinesis:eu-west-2:052392120703:stream/clicks",
+			ExpectedType:  "kinesis-stream",
+			ExpectedQuery: "arn:aws:kinesis:eu-west-2:052392120703:stream/clicks",
+		},
+		{
+			ARN:           "arn:aws:dynamodb:eu-west-2:052392120703:table/orders/stream/2024-01-01T00:00:00.000",
+			ExpectedType:  "dynamodb-table",
+			ExpectedQuery: "orders",
+		},
+		{
+			// There is no MSK source
+			ARN:          "arn:aws:kafka:eu-west-2:052392120703:cluster/events/abcd1234-0123-4567-89ab-cdef01234567-1",
+			ExpectedType: "",
+		},
+		{
+			ARN:          "arn:aws:s3:::bucket",
+			ExpectedType: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.ARN, func(t *testing.T) {
+			link := eventSourceLink(test.ARN)
+
+			if test.ExpectedType == "" {
+				if link != nil {
+					t.Errorf("expected no link, got %v", link)
+				}
+
+				return
+			}
+
+			if link == nil {
+				t.Fatal("expected a link, got nil")
+			}
+
+			if link.GetQuery().GetType() != test.ExpectedType {
+				t.Errorf("expected type %v, got %v", test.ExpectedType, link.GetQuery().GetType())
+			}
+
+			if link.GetQuery().GetQuery() != test.ExpectedQuery {
+				t.Errorf("expected query %v, got %v", test.ExpectedQuery, link.GetQuery().GetQuery())
+			}
+		})
+	}
+}
+
+func TestFunctionQualifierLink(t *testing.T) {
+	tests := []struct {
+		ARN           string
+		ExpectedType  string
+		ExpectedQuery string
+	}{
+		{
+			ARN:           "arn:aws:lambda:eu-west-2:052392120703:function:process-orders:live",
+			ExpectedType:  "lambda-alias",
+			ExpectedQuery: "process-orders:live",
+		},
+		{
+			ARN:           "arn:aws:lambda:eu-west-2:052392120703:function:process-orders:12",
+			ExpectedType:  "lambda-function-version",
+			ExpectedQuery: "process-orders:12",
+		},
+		{
+			ARN:          "arn:aws:lambda:eu-west-2:052392120703:function:process-orders:$LATEST",
+			ExpectedType: "",
+		},
+		{
+			ARN:          "arn:aws:lambda:eu-west-2:052392120703:function:process-orders",
+			ExpectedType: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.ARN, func(t *testing.T) {
+			link := functionQualifierLink(test.ARN)
+
+			if test.ExpectedType == "" {
+				if link != nil {
+					t.Errorf("expected no link, got %v", link)
+				}
+
+				return
+			}
+
+			if link == nil {
+				t.Fatal("expected a link, got nil")
+			}
+
+			if link.GetQuery().GetType() != test.ExpectedType {
+				t.Errorf("expected type %v, got %v", test.ExpectedType, link.GetQuery().GetType())
+			}
+
+			if link.GetQuery().GetQuery() != test.ExpectedQuery {
+				t.Errorf("expected query %v, got %v", test.ExpectedQuery, link.GetQuery().GetQuery())
+			}
+
+			if link.GetQuery().GetScope() != "052392120703.eu-west-2" {
+				t.Errorf("expected scope 052392120703.eu-west-2, got %v", link.GetQuery().GetScope())
+			}
+		})
+	}
+}
+
+func TestEventSourceMappingGetFunc(t *testing.T) {
+	item, err := eventSourceMappingGetFunc(context.Background(), &TestLambdaClient{}, "foo", &lambda.GetEventSourceMappingInput{
+		UUID: sources.PtrString("14e0db71-abcd-4eb5-b481-3e1a4ef4bd87"),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.UniqueAttributeValue() != "14e0db71-abcd-4eb5-b481-3e1a4ef4bd87" {
+		t.Errorf("expected unique attribute value to be the UUID, got %v", item.UniqueAttributeValue())
+	}
+
+	if item.GetHealth() != sdp.Health_HEALTH_OK {
+		t.Errorf("expected health to be OK, got %v", item.GetHealth())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "lambda-function",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:lambda:eu-west-2:052392120703:function:process-orders:live",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "lambda-alias",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "process-orders:live",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "sqs-queue",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:sqs:eu-west-2:052392120703:orders",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "sns-topic",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:sns:eu-west-2:052392120703:failures",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestEventSourceMappingSearchInputMapper(t *testing.T) {
+	input, err := eventSourceMappingSearchInputMapper("foo", "arn:aws:sqs:eu-west-2:052392120703:orders")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if input.EventSourceArn == nil || input.FunctionName != nil {
+		t.Error("expected a queue ARN to search by event source")
+	}
+
+	input, err = eventSourceMappingSearchInputMapper("foo", "process-orders")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if input.FunctionName == nil || input.EventSourceArn != nil {
+		t.Error("expected a function name to search by function")
+	}
+}
+
+func TestNewEventSourceMappingSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewEventSourceMappingSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -2,7 +2,6 @@ package lambda
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"strings"
 
@@ -10,6 +9,8 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
 	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
 	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/aws-source/sources/ecr"
+	"github.com/overmindtech/aws-source/sources/iampolicy"
 	"github.com/overmindtech/sdp-go"
 )
 
@@ -19,7 +20,7 @@ type FunctionDetails struct {
 	Configuration      *types.FunctionConfiguration
 	UrlConfigs         []*types.FunctionUrlConfig
 	EventInvokeConfigs []*types.FunctionEventInvokeConfig
-	Policy             *PolicyDocument
+	Policy             *iampolicy.Document
 	Tags               map[string]string
 }
 
@@ -93,11 +94,10 @@ func functionGetFunc(ctx context.Context, client LambdaClient, scope string, inp
 
 	if err == nil && policyResponse != nil && policyResponse.Policy != nil {
 		// Try to parse the policy
-		policy := PolicyDocument{}
-		err := json.Unmarshal([]byte(*policyResponse.Policy), &policy)
+		policy, err := iampolicy.Parse(*policyResponse.Policy)
 
 		if err == nil {
-			linkedItemQueries = ExtractLinksFromPolicy(&policy)
+			linkedItemQueries = ExtractLinksFromPolicy(policy)
 		}
 	}
 
@@ -122,6 +122,51 @@ func functionGetFunc(ctx context.Context, client LambdaClient, scope string, inp
 		LinkedItemQueries: linkedItemQueries,
 	}
 
+	// +overmind:link lambda-event-source-mapping
+	item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+		Query: &sdp.Query{
+			Type:   "lambda-event-source-mapping",
+			Method: sdp.QueryMethod_SEARCH,
+			Query:  *out.Configuration.FunctionName,
+			Scope:  scope,
+		},
+		BlastPropagation: &sdp.BlastPropagation{
+			// The mappings invoke the function, so they are tightly coupled
+			In:  true,
+			Out: true,
+		},
+	})
+
+	// +overmind:link lambda-alias
+	item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+		Query: &sdp.Query{
+			Type:   "lambda-alias",
+			Method: sdp.QueryMethod_SEARCH,
+			Query:  *out.Configuration.FunctionName,
+			Scope:  scope,
+		},
+		BlastPropagation: &sdp.BlastPropagation{
+			// Aliases are part of the function
+			In:  true,
+			Out: true,
+		},
+	})
+
+	// +overmind:link lambda-function-version
+	item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+		Query: &sdp.Query{
+			Type:   "lambda-function-version",
+			Method: sdp.QueryMethod_SEARCH,
+			Query:  *out.Configuration.FunctionName,
+			Scope:  scope,
+		},
+		BlastPropagation: &sdp.BlastPropagation{
+			// Versions are part of the function, and are deleted with it
+			In:  true,
+			Out: true,
+		},
+	})
+
 	if function.Code != nil {
 		if function.Code.Location != nil {
 			// +overmind:link http
@@ -175,6 +220,21 @@ func functionGetFunc(ctx context.Context, client LambdaClient, scope string, inp
 				},
 			})
 		}
+
+		// The resolved URI includes the digest of the image that the function
+		// is actually running, so prefer that when linking to ECR
+		imageURI := function.Code.ResolvedImageUri
+
+		if imageURI == nil {
+			imageURI = function.Code.ImageUri
+		}
+
+		if imageURI != nil {
+			if link := ecr.ImageLink(*imageURI, nil); link != nil {
+				// +overmind:link ecr-image
+				item.LinkedItemQueries = append(item.LinkedItemQueries, link)
+			}
+		}
 	}
 
 	var a *sources.ARN
@@ -488,61 +548,82 @@ func functionGetFunc(ctx context.Context, client LambdaClient, scope string, inp
 	return &item, nil
 }
 
-func ExtractLinksFromPolicy(policy *PolicyDocument) []*sdp.LinkedItemQuery {
+func ExtractLinksFromPolicy(policy *iampolicy.Document) []*sdp.LinkedItemQuery {
 	links := make([]*sdp.LinkedItemQuery, 0)
 
 	for _, statement := range policy.Statement {
-		var queryType string
-		var scope string
-		method := sdp.QueryMethod_SEARCH
-
-		switch statement.Principal.Service {
-		case "sns.amazonaws.com":
-			queryType = "sns-topic"
-			method = sdp.QueryMethod_GET
-		case "elasticloadbalancing.amazonaws.com":
-			queryType = "elbv2-target-group"
-		case "vpc-lattice.amazonaws.com":
-			queryType = "vpc-lattice-target-group"
-		case "logs.amazonaws.com":
-			queryType = "logs-log-group"
-		case "events.amazonaws.com":
-			queryType = "events-rule"
-		case "s3.amazonaws.com":
-			// S3 is global and runs in an account scope so we need to extract
-			// that from the policy as the ARN doesn't contain the account that
-			// the bucket is in
-			queryType = "s3-bucket"
-			scope = sources.FormatScope(statement.Condition.StringEquals.AWSSourceAccount, "")
-		default:
+		if statement.Effect != iampolicy.EffectAllow || statement.Principal == nil {
 			continue
 		}
 
-		if scope == "" {
-			// If we don't have a scope set then extract it from the target ARN
-			parsedARN, err := sources.ParseARN(statement.Condition.ArnLike.AWSSourceArn)
-
-			if err != nil {
+		for _, service := range statement.Principal.Service {
+			var queryTypes []string
+			var scope string
+			method := sdp.QueryMethod_SEARCH
+
+			switch service {
+			case "sns.amazonaws.com":
+				queryTypes = []string{"sns-topic"}
+				method = sdp.QueryMethod_GET
+			case "elasticloadbalancing.amazonaws.com":
+				queryTypes = []string{"elbv2-target-group"}
+			case "vpc-lattice.amazonaws.com":
+				queryTypes = []string{"vpc-lattice-target-group"}
+			case "logs.amazonaws.com":
+				queryTypes = []string{"logs-log-group"}
+			case "events.amazonaws.com":
+				queryTypes = []string{"events-rule"}
+			case "apigateway.amazonaws.com":
+				// The execute-api ARN is the same format for REST APIs and
+				// HTTP or WebSocket APIs, so we can't tell which one this is
+				queryTypes = []string{"apigateway-rest-api", "apigatewayv2-api"}
+			case "s3.amazonaws.com":
+				// S3 is global and runs in an account scope so we need to
+				// extract that from the policy as the ARN doesn't contain the
+				// account that the bucket is in
+				queryTypes = []string{"s3-bucket"}
+
+				if accounts := statement.SourceAccounts(); len(accounts) > 0 {
+					scope = sources.FormatScope(accounts[0], "")
+				}
+			default:
 				continue
 			}
 
-			scope = sources.FormatScope(parsedARN.AccountID, parsedARN.Region)
-		}
+			for _, sourceARN := range statement.SourceARNs() {
+				sourceScope := scope
 
-		links = append(links, &sdp.LinkedItemQuery{
-			Query: &sdp.Query{
-				Type:   queryType,
-				Method: method,
-				Query:  statement.Condition.ArnLike.AWSSourceArn,
-				Scope:  scope,
-			},
-			BlastPropagation: &sdp.BlastPropagation{
-				// Changing a lambda shouldn't affect the upstream source
-				Out: false,
-				// Changing the source should affect the lambda
-				In: true,
-			},
-		})
+				if sourceScope == "" {
+					// If we don't have a scope set then extract it from the
+					// target ARN
+					parsedARN, err := sources.ParseARN(sourceARN)
+
+					if err != nil {
+						continue
+					}
+
+					sourceScope = sources.FormatScope(parsedARN.AccountID, parsedARN.Region)
+				}
+
+				for _, queryType := range queryTypes {
+					links = append(links, &sdp.LinkedItemQuery{
+						Query: &sdp.Query{
+							Type:   queryType,
+							Method: method,
+							Query:  sourceARN,
+							Scope:  sourceScope,
+						},
+						BlastPropagation: &sdp.BlastPropagation{
+							// Changing a lambda shouldn't affect the upstream
+							// source
+							Out: false,
+							// Changing the source should affect the lambda
+							In: true,
+						},
+					})
+				}
+			}
+		}
 	}
 
 	return links
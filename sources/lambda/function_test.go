@@ -96,8 +96,8 @@ var testFuncConfig = &types.FunctionConfiguration{
 var testFuncCode = &types.FunctionCodeLocation{
 	RepositoryType:   sources.PtrString("S3"),
 	Location:         sources.PtrString("https://awslambda-eu-west-2-tasks.s3.eu-west-2.amazonaws.com/snapshots/052392120703/aws-controltower-NotificationForwarder-bcea303b-7721-4cf0-b8db-7a0e6dca76dd"), // link
-	ImageUri:         sources.PtrString("https://foo"),                                                                                                                                                     // link
-	ResolvedImageUri: sources.PtrString("https://foo"),                                                                                                                                                     // link
+	ImageUri:         sources.PtrString("052392120703.dkr.ecr.eu-west-2.amazonaws.com/notification-forwarder:latest"),                                                                                      // link
+	ResolvedImageUri: sources.PtrString("052392120703.dkr.ecr.eu-west-2.amazonaws.com/notification-forwarder@sha256:8b1a9953c4611296a827abf8c47804d7e6c49c6b8f5c1a0e6e2d5b9b3f0c4a1d"),                     // link
 }
 
 func (t *TestLambdaClient) GetFunction(ctx context.Context, params *lambda.GetFunctionInput, optFns ...func(*lambda.Options)) (*lambda.GetFunctionOutput, error) {
@@ -181,6 +181,24 @@ func TestFunctionGetFunc(t *testing.T) {
 	}
 
 	tests := sources.QueryTests{
+		{
+			ExpectedType:   "lambda-event-source-mapping",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "aws-controltower-NotificationForwarder",
+			ExpectedScope:  "foo",
+		},
+		{
+			ExpectedType:   "lambda-alias",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "aws-controltower-NotificationForwarder",
+			ExpectedScope:  "foo",
+		},
+		{
+			ExpectedType:   "lambda-function-version",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "aws-controltower-NotificationForwarder",
+			ExpectedScope:  "foo",
+		},
 		{
 			ExpectedType:   "http",
 			ExpectedMethod: sdp.QueryMethod_GET,
@@ -190,15 +208,21 @@ func TestFunctionGetFunc(t *testing.T) {
 		{
 			ExpectedType:   "http",
 			ExpectedMethod: sdp.QueryMethod_GET,
-			ExpectedQuery:  "https://foo",
+			ExpectedQuery:  "052392120703.dkr.ecr.eu-west-2.amazonaws.com/notification-forwarder:latest",
 			ExpectedScope:  "global",
 		},
 		{
 			ExpectedType:   "http",
 			ExpectedMethod: sdp.QueryMethod_GET,
-			ExpectedQuery:  "https://foo",
+			ExpectedQuery:  "052392120703.dkr.ecr.eu-west-2.amazonaws.com/notification-forwarder@sha256:8b1a9953c4611296a827abf8c47804d7e6c49c6b8f5c1a0e6e2d5b9b3f0c4a1d",
 			ExpectedScope:  "global",
 		},
+		{
+			ExpectedType:   "ecr-image",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "notification-forwarder@sha256:8b1a9953c4611296a827abf8c47804d7e6c49c6b8f5c1a0e6e2d5b9b3f0c4a1d",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
 		{
 			ExpectedType:   "iam-role",
 			ExpectedMethod: sdp.QueryMethod_SEARCH,
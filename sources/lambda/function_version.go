@@ -0,0 +1,216 @@
+package lambda
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func functionVersionGetInputMapper(scope, query string) *lambda.GetFunctionConfigurationInput {
+	functionName, version := parseQualifiedName(query)
+
+	if functionName == "" {
+		return nil
+	}
+
+	return &lambda.GetFunctionConfigurationInput{
+		FunctionName: &functionName,
+		Qualifier:    &version,
+	}
+}
+
+func functionVersionGetFunc(ctx context.Context, client LambdaClient, scope string, input *lambda.GetFunctionConfigurationInput) (*sdp.Item, error) {
+	if input == nil {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: "query must be in the format {functionName}:{version}",
+		}
+	}
+
+	out, err := client.GetFunctionConfiguration(ctx, input)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if out.FunctionName == nil || out.Version == nil {
+		return nil, errors.New("function version has empty name or version")
+	}
+
+	attributes, err := sources.ToAttributesCase(out, "resultMetadata")
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = attributes.Set("fullName", *out.FunctionName+":"+*out.Version)
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := sdp.Item{
+		Type:            "lambda-function-version",
+		UniqueAttribute: "fullName",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	switch out.State {
+	case types.StatePending:
+		item.Health = sdp.Health_HEALTH_PENDING.Enum()
+	case types.StateActive:
+		item.Health = sdp.Health_HEALTH_OK.Enum()
+	case types.StateFailed:
+		item.Health = sdp.Health_HEALTH_ERROR.Enum()
+	}
+
+	// +overmind:link lambda-function
+	item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+		Query: &sdp.Query{
+			Type:   "lambda-function",
+			Method: sdp.QueryMethod_GET,
+			Query:  *out.FunctionName,
+			Scope:  scope,
+		},
+		BlastPropagation: &sdp.BlastPropagation{
+			// Versions are immutable snapshots of the function, so changing
+			// the function won't affect them
+			In: false,
+			// Changing the version won't affect the function
+			Out: false,
+		},
+	})
+
+	// +overmind:link lambda-alias
+	item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+		Query: &sdp.Query{
+			Type:   "lambda-alias",
+			Method: sdp.QueryMethod_SEARCH,
+			Query:  *out.FunctionName,
+			Scope:  scope,
+		},
+		BlastPropagation: &sdp.BlastPropagation{
+			// Aliases are how traffic reaches the version
+			In:  true,
+			Out: true,
+		},
+	})
+
+	var a *sources.ARN
+
+	if out.Role != nil {
+		if a, err = sources.ParseARN(*out.Role); err == nil {
+			// +overmind:link iam-role
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "iam-role",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *out.Role,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the role will affect the version
+					In: true,
+					// Changing the version won't affect the role
+					Out: false,
+				},
+			})
+		}
+	}
+
+	if out.KMSKeyArn != nil {
+		if a, err = sources.ParseARN(*out.KMSKeyArn); err == nil {
+			// +overmind:link kms-key
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "kms-key",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *out.KMSKeyArn,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the key will affect the version
+					In: true,
+					// Changing the version won't affect the key
+					Out: false,
+				},
+			})
+		}
+	}
+
+	for _, layer := range out.Layers {
+		if layer.Arn != nil {
+			if a, err = sources.ParseARN(*layer.Arn); err == nil {
+				// Strip the leading "layer:"
+				name := strings.TrimPrefix(a.Resource, "layer:")
+
+				// +overmind:link lambda-layer-version
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "lambda-layer-version",
+						Method: sdp.QueryMethod_GET,
+						Query:  name,
+						Scope:  sources.FormatScope(a.AccountID, a.Region),
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// These are tightly linked
+						In:  true,
+						Out: true,
+					},
+				})
+			}
+		}
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type lambda-function-version
+// +overmind:descriptiveType Lambda Function Version
+// +overmind:get Get a function version by full name ({functionName}:{version})
+// +overmind:search Search for function versions by function name or ARN
+// +overmind:group AWS
+
+func NewFunctionVersionSource(config aws.Config, accountID string, region string) *sources.AlwaysGetSource[*lambda.ListVersionsByFunctionInput, *lambda.ListVersionsByFunctionOutput, *lambda.GetFunctionConfigurationInput, *lambda.GetFunctionConfigurationOutput, LambdaClient, *lambda.Options] {
+	return &sources.AlwaysGetSource[*lambda.ListVersionsByFunctionInput, *lambda.ListVersionsByFunctionOutput, *lambda.GetFunctionConfigurationInput, *lambda.GetFunctionConfigurationOutput, LambdaClient, *lambda.Options]{
+		ItemType:  "lambda-function-version",
+		Client:    lambda.NewFromConfig(config),
+		AccountID: accountID,
+		Region:    region,
+		// Versions can only be listed per function
+		DisableList:    true,
+		ListInput:      &lambda.ListVersionsByFunctionInput{},
+		GetInputMapper: functionVersionGetInputMapper,
+		GetFunc:        functionVersionGetFunc,
+		SearchInputMapper: func(scope, query string) (*lambda.ListVersionsByFunctionInput, error) {
+			return &lambda.ListVersionsByFunctionInput{
+				FunctionName: sources.PtrString(functionNameFromQuery(query)),
+			}, nil
+		},
+		ListFuncPaginatorBuilder: func(client LambdaClient, input *lambda.ListVersionsByFunctionInput) sources.Paginator[*lambda.ListVersionsByFunctionOutput, *lambda.Options] {
+			return lambda.NewListVersionsByFunctionPaginator(client, input)
+		},
+		ListFuncOutputMapper: func(output *lambda.ListVersionsByFunctionOutput, input *lambda.ListVersionsByFunctionInput) ([]*lambda.GetFunctionConfigurationInput, error) {
+			inputs := make([]*lambda.GetFunctionConfigurationInput, 0, len(output.Versions))
+
+			for _, version := range output.Versions {
+				if version.FunctionName != nil && version.Version != nil {
+					inputs = append(inputs, &lambda.GetFunctionConfigurationInput{
+						FunctionName: version.FunctionName,
+						Qualifier:    version.Version,
+					})
+				}
+			}
+
+			return inputs, nil
+		},
+	}
+}
@@ -0,0 +1,97 @@
+package lambda
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (t *TestLambdaClient) GetFunctionConfiguration(ctx context.Context, params *lambda.GetFunctionConfigurationInput, optFns ...func(*lambda.Options)) (*lambda.GetFunctionConfigurationOutput, error) {
+	return &lambda.GetFunctionConfigurationOutput{
+		FunctionName: params.FunctionName,
+		FunctionArn:  sources.PtrString("arn:aws:lambda:eu-west-2:052392120703:function:" + *params.FunctionName + ":" + *params.Qualifier),
+		Version:      params.Qualifier,
+		Runtime:      types.RuntimePython39,
+		Role:         sources.PtrString("arn:aws:iam::052392120703:role/process-orders"), // link
+		KMSKeyArn:    sources.PtrString("arn:aws:kms:eu-west-2:052392120703:key/id"),     // link
+		Layers: []types.Layer{
+			{
+				Arn: sources.PtrString("arn:aws:lambda:eu-west-2:052392120703:layer:name:1"), // link
+			},
+		},
+		State: types.StateActive,
+	}, nil
+}
+
+func (t *TestLambdaClient) ListVersionsByFunction(context.Context, *lambda.ListVersionsByFunctionInput, ...func(*lambda.Options)) (*lambda.ListVersionsByFunctionOutput, error) {
+	return &lambda.ListVersionsByFunctionOutput{}, nil
+}
+
+func TestFunctionVersionGetFunc(t *testing.T) {
+	item, err := functionVersionGetFunc(context.Background(), &TestLambdaClient{}, "foo", functionVersionGetInputMapper("foo", "process-orders:3"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.UniqueAttributeValue() != "process-orders:3" {
+		t.Errorf("expected unique attribute value to be process-orders:3, got %v", item.UniqueAttributeValue())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "lambda-function",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "process-orders",
+			ExpectedScope:  "foo",
+		},
+		{
+			ExpectedType:   "lambda-alias",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "process-orders",
+			ExpectedScope:  "foo",
+		},
+		{
+			ExpectedType:   "iam-role",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:iam::052392120703:role/process-orders",
+			ExpectedScope:  "052392120703",
+		},
+		{
+			ExpectedType:   "kms-key",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:kms:eu-west-2:052392120703:key/id",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "lambda-layer-version",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "name:1",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestNewFunctionVersionSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewFunctionVersionSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
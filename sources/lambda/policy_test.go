@@ -0,0 +1,250 @@
+package lambda
+
+import (
+	"testing"
+
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/aws-source/sources/iampolicy"
+	"github.com/overmindtech/sdp-go"
+)
+
+var testPolicyJSON string = `{
+	"Version": "2012-10-17",
+	"Id": "default",
+	"Statement": [
+		{
+			"Sid": "lambda-191096b5-9db0-4ff2-87ce-d90c8869cb93",
+			"Effect": "Allow",
+			"Principal": {
+				"Service": "sns.amazonaws.com"
+			},
+			"Action": "lambda:InvokeFunction",
+			"Resource": "arn:aws:lambda:eu-west-2:540044833068:function:example_lambda_function",
+			"Condition": {
+				"ArnLike": {
+					"AWS:SourceArn": "arn:aws:sns:eu-west-2:540044833068:example-topic"
+				}
+			}
+		},
+		{
+			"Sid": "lambda-e881f390-21ed-4d5a-9e64-50ddb5562873",
+			"Effect": "Allow",
+			"Principal": {
+				"Service": "elasticloadbalancing.amazonaws.com"
+			},
+			"Action": "lambda:InvokeFunction",
+			"Resource": "arn:aws:lambda:eu-west-2:540044833068:function:test",
+			"Condition": {
+				"ArnLike": {
+					"AWS:SourceArn": "arn:aws:elasticloadbalancing:eu-west-2:540044833068:targetgroup/lambda-rvaaio9n3auuhnvvvjmp/6f23de9c63bd4653"
+				}
+			}
+		},
+		{
+			"Sid": "lambda-e137420e-640f-47bf-a37f-3f3c3134c110",
+			"Effect": "Allow",
+			"Principal": {
+				"Service": "vpc-lattice.amazonaws.com"
+			},
+			"Action": "lambda:InvokeFunction",
+			"Resource": "arn:aws:lambda:eu-west-2:540044833068:function:test",
+			"Condition": {
+				"ArnLike": {
+					"AWS:SourceArn": "arn:aws:vpc-lattice:eu-west-2:540044833068:targetgroup/tg-0510fc8a1fef35ef0"
+				}
+			}
+		},
+		{
+			"Sid": "lambda-945e8a2a-f5d2-4b32-869e-bca6227133b6",
+			"Effect": "Allow",
+			"Principal": {
+				"Service": "logs.amazonaws.com"
+			},
+			"Action": "lambda:InvokeFunction",
+			"Resource": "arn:aws:lambda:eu-west-2:540044833068:function:test",
+			"Condition": {
+				"StringEquals": {
+					"AWS:SourceAccount": "540044833068"
+				},
+				"ArnLike": {
+					"AWS:SourceArn": "arn:aws:logs:eu-west-2:540044833068:log-group:/aws/ecs/example:*"
+				}
+			}
+		},
+		{
+			"Sid": "lambda-1b87395a-6f9a-406d-bc4c-4366044c1a06",
+			"Effect": "Allow",
+			"Principal": {
+				"Service": "events.amazonaws.com"
+			},
+			"Action": "lambda:InvokeFunction",
+			"Resource": "arn:aws:lambda:eu-west-2:540044833068:function:test",
+			"Condition": {
+				"ArnLike": {
+					"AWS:SourceArn": "arn:aws:events:eu-west-2:540044833068:rule/test"
+				}
+			}
+		},
+		{
+			"Sid": "lambda-e0070e15-19c9-4e75-8705-075d618113a4",
+			"Effect": "Allow",
+			"Principal": {
+				"Service": "s3.amazonaws.com"
+			},
+			"Action": "lambda:InvokeFunction",
+			"Resource": "arn:aws:lambda:eu-west-2:540044833068:function:test",
+			"Condition": {
+				"StringEquals": {
+					"AWS:SourceAccount": "540044833068"
+				},
+				"ArnLike": {
+					"AWS:SourceArn": "arn:aws:s3:::second-example-profound-lamb"
+				}
+			}
+		}
+	]
+}`
+
+func TestExtractLinksFromPolicy(t *testing.T) {
+	policy, err := iampolicy.Parse(testPolicyJSON)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	links := ExtractLinksFromPolicy(policy)
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "sns-topic",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "arn:aws:sns:eu-west-2:540044833068:example-topic",
+			ExpectedScope:  "540044833068.eu-west-2",
+		},
+		{
+			ExpectedType:   "elbv2-target-group",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:elasticloadbalancing:eu-west-2:540044833068:targetgroup/lambda-rvaaio9n3auuhnvvvjmp/6f23de9c63bd4653",
+			ExpectedScope:  "540044833068.eu-west-2",
+		},
+		{
+			ExpectedType:   "vpc-lattice-target-group",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:vpc-lattice:eu-west-2:540044833068:targetgroup/tg-0510fc8a1fef35ef0",
+			ExpectedScope:  "540044833068.eu-west-2",
+		},
+		{
+			ExpectedType:   "logs-log-group",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:logs:eu-west-2:540044833068:log-group:/aws/ecs/example:*",
+			ExpectedScope:  "540044833068.eu-west-2",
+		},
+		{
+			ExpectedType:   "events-rule",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:events:eu-west-2:540044833068:rule/test",
+			ExpectedScope:  "540044833068.eu-west-2",
+		},
+		{
+			ExpectedType:   "s3-bucket",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:s3:::second-example-profound-lamb",
+			ExpectedScope:  "540044833068",
+		},
+	}
+
+	tests.Execute(t, &sdp.Item{LinkedItemQueries: links})
+}
+
+func TestExtractLinksFromPolicyAPIGateway(t *testing.T) {
+	policy := iampolicy.Document{
+		Statement: iampolicy.Statements{
+			{
+				Effect: iampolicy.EffectAllow,
+				Action: iampolicy.Value{"lambda:InvokeFunction"},
+				Principal: &iampolicy.Principal{
+					Service: iampolicy.Value{"apigateway.amazonaws.com"},
+				},
+				Condition: iampolicy.Condition{
+					iampolicy.ArnLike: {
+						"AWS:SourceArn": iampolicy.Value{"arn:aws:execute-api:eu-west-2:540044833068:a1b2c3d4e5/*/GET/orders"},
+					},
+				},
+			},
+		},
+	}
+
+	links := ExtractLinksFromPolicy(&policy)
+
+	// We can't tell whether this is a REST API or an HTTP API, so both should
+	// be linked
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "apigateway-rest-api",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:execute-api:eu-west-2:540044833068:a1b2c3d4e5/*/GET/orders",
+			ExpectedScope:  "540044833068.eu-west-2",
+		},
+		{
+			ExpectedType:   "apigatewayv2-api",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:execute-api:eu-west-2:540044833068:a1b2c3d4e5/*/GET/orders",
+			ExpectedScope:  "540044833068.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, &sdp.Item{LinkedItemQueries: links})
+}
+
+func TestExtractLinksFromPolicyNegatedCondition(t *testing.T) {
+	policy := iampolicy.Document{
+		Statement: iampolicy.Statements{
+			{
+				Effect: iampolicy.EffectDeny,
+				Action: iampolicy.Value{"lambda:InvokeFunction"},
+				Principal: &iampolicy.Principal{
+					Service: iampolicy.Value{"sns.amazonaws.com"},
+				},
+				Condition: iampolicy.Condition{
+					iampolicy.ArnNotEquals: {
+						"AWS:SourceArn": iampolicy.Value{"arn:aws:sns:eu-west-2:540044833068:example-topic"},
+					},
+				},
+			},
+		},
+	}
+
+	links := ExtractLinksFromPolicy(&policy)
+
+	// The topic in the condition is the one that is excluded, so it shouldn't
+	// be linked
+	if len(links) != 0 {
+		t.Errorf("expected no links, got %v", links)
+	}
+}
+
+func TestExtractLinksFromPolicyDeny(t *testing.T) {
+	policy := iampolicy.Document{
+		Statement: iampolicy.Statements{
+			{
+				Effect: iampolicy.EffectDeny,
+				Action: iampolicy.Value{"lambda:InvokeFunction"},
+				Principal: &iampolicy.Principal{
+					Service: iampolicy.Value{"events.amazonaws.com"},
+				},
+				Condition: iampolicy.Condition{
+					iampolicy.ArnLike: {
+						"AWS:SourceArn": iampolicy.Value{"arn:aws:events:eu-west-2:540044833068:rule/example-rule"},
+					},
+				},
+			},
+		},
+	}
+
+	links := ExtractLinksFromPolicy(&policy)
+
+	// The rule is denied access so it can't invoke the function
+	if len(links) != 0 {
+		t.Errorf("expected no links, got %v", links)
+	}
+}
@@ -0,0 +1,142 @@
+package lambda
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func provisionedConcurrencyConfigGetInputMapper(scope, query string) *lambda.GetProvisionedConcurrencyConfigInput {
+	functionName, qualifier := parseQualifiedName(query)
+
+	if functionName == "" {
+		return nil
+	}
+
+	return &lambda.GetProvisionedConcurrencyConfigInput{
+		FunctionName: &functionName,
+		Qualifier:    &qualifier,
+	}
+}
+
+func provisionedConcurrencyConfigGetFunc(ctx context.Context, client LambdaClient, scope string, input *lambda.GetProvisionedConcurrencyConfigInput) (*sdp.Item, error) {
+	if input == nil {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: "query must be in the format {functionName}:{qualifier}",
+		}
+	}
+
+	out, err := client.GetProvisionedConcurrencyConfig(ctx, input)
+
+	if err != nil {
+		return nil, err
+	}
+
+	functionName := functionNameFromQuery(*input.FunctionName)
+
+	attributes, err := sources.ToAttributesCase(out, "resultMetadata")
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = attributes.Set("fullName", functionName+":"+*input.Qualifier)
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := sdp.Item{
+		Type:            "lambda-provisioned-concurrency-config",
+		UniqueAttribute: "fullName",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	switch out.Status {
+	case types.ProvisionedConcurrencyStatusEnumReady:
+		item.Health = sdp.Health_HEALTH_OK.Enum()
+	case types.ProvisionedConcurrencyStatusEnumInProgress:
+		item.Health = sdp.Health_HEALTH_PENDING.Enum()
+	case types.ProvisionedConcurrencyStatusEnumFailed:
+		item.Health = sdp.Health_HEALTH_ERROR.Enum()
+	}
+
+	// The qualifier can be either an alias or a version, and we can't tell
+	// which from the name alone
+	//
+	// +overmind:link lambda-alias
+	// +overmind:link lambda-function-version
+	for _, queryType := range []string{"lambda-alias", "lambda-function-version"} {
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   queryType,
+				Method: sdp.QueryMethod_GET,
+				Query:  functionName + ":" + *input.Qualifier,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// The config determines how many warm instances serve the
+				// alias or version
+				In:  true,
+				Out: true,
+			},
+		})
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type lambda-provisioned-concurrency-config
+// +overmind:descriptiveType Lambda Provisioned Concurrency Config
+// +overmind:get Get a provisioned concurrency config by full name ({functionName}:{qualifier})
+// +overmind:search Search for provisioned concurrency configs by function name or ARN
+// +overmind:group AWS
+
+func NewProvisionedConcurrencyConfigSource(config aws.Config, accountID string, region string) *sources.AlwaysGetSource[*lambda.ListProvisionedConcurrencyConfigsInput, *lambda.ListProvisionedConcurrencyConfigsOutput, *lambda.GetProvisionedConcurrencyConfigInput, *lambda.GetProvisionedConcurrencyConfigOutput, LambdaClient, *lambda.Options] {
+	return &sources.AlwaysGetSource[*lambda.ListProvisionedConcurrencyConfigsInput, *lambda.ListProvisionedConcurrencyConfigsOutput, *lambda.GetProvisionedConcurrencyConfigInput, *lambda.GetProvisionedConcurrencyConfigOutput, LambdaClient, *lambda.Options]{
+		ItemType:  "lambda-provisioned-concurrency-config",
+		Client:    lambda.NewFromConfig(config),
+		AccountID: accountID,
+		Region:    region,
+		// Configs can only be listed per function
+		DisableList:    true,
+		ListInput:      &lambda.ListProvisionedConcurrencyConfigsInput{},
+		GetInputMapper: provisionedConcurrencyConfigGetInputMapper,
+		GetFunc:        provisionedConcurrencyConfigGetFunc,
+		SearchInputMapper: func(scope, query string) (*lambda.ListProvisionedConcurrencyConfigsInput, error) {
+			return &lambda.ListProvisionedConcurrencyConfigsInput{
+				FunctionName: sources.PtrString(functionNameFromQuery(query)),
+			}, nil
+		},
+		ListFuncPaginatorBuilder: func(client LambdaClient, input *lambda.ListProvisionedConcurrencyConfigsInput) sources.Paginator[*lambda.ListProvisionedConcurrencyConfigsOutput, *lambda.Options] {
+			return lambda.NewListProvisionedConcurrencyConfigsPaginator(client, input)
+		},
+		ListFuncOutputMapper: func(output *lambda.ListProvisionedConcurrencyConfigsOutput, input *lambda.ListProvisionedConcurrencyConfigsInput) ([]*lambda.GetProvisionedConcurrencyConfigInput, error) {
+			inputs := make([]*lambda.GetProvisionedConcurrencyConfigInput, 0, len(output.ProvisionedConcurrencyConfigs))
+
+			for _, config := range output.ProvisionedConcurrencyConfigs {
+				if config.FunctionArn == nil {
+					continue
+				}
+
+				// The function ARN includes the qualifier that the config
+				// applies to
+				if functionName, qualifier := parseQualifiedName(qualifiedNameFromARN(*config.FunctionArn)); functionName != "" {
+					inputs = append(inputs, &lambda.GetProvisionedConcurrencyConfigInput{
+						FunctionName: &functionName,
+						Qualifier:    &qualifier,
+					})
+				}
+			}
+
+			return inputs, nil
+		},
+	}
+}
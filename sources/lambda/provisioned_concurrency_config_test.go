@@ -0,0 +1,76 @@
+package lambda
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (t *TestLambdaClient) GetProvisionedConcurrencyConfig(ctx context.Context, params *lambda.GetProvisionedConcurrencyConfigInput, optFns ...func(*lambda.Options)) (*lambda.GetProvisionedConcurrencyConfigOutput, error) {
+	return &lambda.GetProvisionedConcurrencyConfigOutput{
+		AllocatedProvisionedConcurrentExecutions: sources.PtrInt32(5),
+		AvailableProvisionedConcurrentExecutions: sources.PtrInt32(5),
+		RequestedProvisionedConcurrentExecutions: sources.PtrInt32(5),
+		LastModified:                             sources.PtrString("2024-01-01T00:00:00.000+0000"),
+		Status:                                   types.ProvisionedConcurrencyStatusEnumReady,
+	}, nil
+}
+
+func (t *TestLambdaClient) ListProvisionedConcurrencyConfigs(context.Context, *lambda.ListProvisionedConcurrencyConfigsInput, ...func(*lambda.Options)) (*lambda.ListProvisionedConcurrencyConfigsOutput, error) {
+	return &lambda.ListProvisionedConcurrencyConfigsOutput{}, nil
+}
+
+func TestProvisionedConcurrencyConfigGetFunc(t *testing.T) {
+	item, err := provisionedConcurrencyConfigGetFunc(context.Background(), &TestLambdaClient{}, "foo", provisionedConcurrencyConfigGetInputMapper("foo", "process-orders:live"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.UniqueAttributeValue() != "process-orders:live" {
+		t.Errorf("expected unique attribute value to be process-orders:live, got %v", item.UniqueAttributeValue())
+	}
+
+	if item.GetHealth() != sdp.Health_HEALTH_OK {
+		t.Errorf("expected health to be OK, got %v", item.GetHealth())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "lambda-alias",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "process-orders:live",
+			ExpectedScope:  "foo",
+		},
+		{
+			ExpectedType:   "lambda-function-version",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "process-orders:live",
+			ExpectedScope:  "foo",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestNewProvisionedConcurrencyConfigSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewProvisionedConcurrencyConfigSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
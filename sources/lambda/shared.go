@@ -2,19 +2,81 @@ package lambda
 
 import (
 	"context"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/overmindtech/aws-source/sources"
 )
 
 // LambdaClient Represents the client we need to talk to Lambda, usually this is
 // *lambda.Client
 type LambdaClient interface {
+	GetAlias(ctx context.Context, params *lambda.GetAliasInput, optFns ...func(*lambda.Options)) (*lambda.GetAliasOutput, error)
+	GetEventSourceMapping(ctx context.Context, params *lambda.GetEventSourceMappingInput, optFns ...func(*lambda.Options)) (*lambda.GetEventSourceMappingOutput, error)
 	GetFunction(ctx context.Context, params *lambda.GetFunctionInput, optFns ...func(*lambda.Options)) (*lambda.GetFunctionOutput, error)
+	GetFunctionConfiguration(ctx context.Context, params *lambda.GetFunctionConfigurationInput, optFns ...func(*lambda.Options)) (*lambda.GetFunctionConfigurationOutput, error)
 	GetLayerVersion(ctx context.Context, params *lambda.GetLayerVersionInput, optFns ...func(*lambda.Options)) (*lambda.GetLayerVersionOutput, error)
 	GetPolicy(ctx context.Context, params *lambda.GetPolicyInput, optFns ...func(*lambda.Options)) (*lambda.GetPolicyOutput, error)
+	GetProvisionedConcurrencyConfig(ctx context.Context, params *lambda.GetProvisionedConcurrencyConfigInput, optFns ...func(*lambda.Options)) (*lambda.GetProvisionedConcurrencyConfigOutput, error)
 
+	lambda.ListAliasesAPIClient
+	lambda.ListEventSourceMappingsAPIClient
 	lambda.ListFunctionEventInvokeConfigsAPIClient
 	lambda.ListFunctionUrlConfigsAPIClient
 	lambda.ListFunctionsAPIClient
 	lambda.ListLayerVersionsAPIClient
+	lambda.ListProvisionedConcurrencyConfigsAPIClient
+	lambda.ListVersionsByFunctionAPIClient
+}
+
+// parseQualifiedName Splits a query in the format {functionName}:{qualifier}
+// into its parts, where the qualifier is a version or alias name. Returns empty
+// strings if the query isn't in this format
+func parseQualifiedName(query string) (functionName string, qualifier string) {
+	sections := strings.Split(query, ":")
+
+	if len(sections) != 2 || sections[0] == "" || sections[1] == "" {
+		return "", ""
+	}
+
+	return sections[0], sections[1]
+}
+
+// functionNameFromQuery Returns the name of a function from a query that is
+// either the name of a function or its ARN. If the ARN is qualified with a
+// version or alias, this is removed
+func functionNameFromQuery(query string) string {
+	a, err := sources.ParseARN(query)
+
+	if err != nil {
+		return query
+	}
+
+	// Function ARNs are in the format:
+	// arn:aws:lambda:{region}:{account}:function:{name}[:{qualifier}]
+	sections := strings.Split(a.Resource, ":")
+
+	if len(sections) < 2 || sections[0] != "function" {
+		return query
+	}
+
+	return sections[1]
+}
+
+// qualifiedNameFromARN Returns the {functionName}:{qualifier} name from a
+// qualified function ARN, such as the ARN of an alias or version
+func qualifiedNameFromARN(arn string) string {
+	a, err := sources.ParseARN(arn)
+
+	if err != nil {
+		return ""
+	}
+
+	sections := strings.Split(a.Resource, ":")
+
+	if len(sections) != 3 || sections[0] != "function" {
+		return ""
+	}
+
+	return sections[1] + ":" + sections[2]
 }
@@ -0,0 +1,214 @@
+package memorydb
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/memorydb"
+	"github.com/aws/aws-sdk-go-v2/service/memorydb/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func statusToHealth(status string) *sdp.Health {
+	switch status {
+	case "available", "snapshotting":
+		return sdp.Health_HEALTH_OK.Enum()
+	case "creating", "updating":
+		return sdp.Health_HEALTH_PENDING.Enum()
+	case "deleting":
+		return sdp.Health_HEALTH_WARNING.Enum()
+	case "create-failed":
+		return sdp.Health_HEALTH_ERROR.Enum()
+	}
+
+	return nil
+}
+
+func endpointLink(endpoint *types.Endpoint) *sdp.LinkedItemQuery {
+	if endpoint == nil || endpoint.Address == nil {
+		return nil
+	}
+
+	return &sdp.LinkedItemQuery{
+		Query: &sdp.Query{
+			Type:   "dns",
+			Method: sdp.QueryMethod_SEARCH,
+			Query:  *endpoint.Address,
+			Scope:  "global",
+		},
+		BlastPropagation: &sdp.BlastPropagation{
+			// DNS always links
+			In:  true,
+			Out: true,
+		},
+	}
+}
+
+func clusterOutputMapper(ctx context.Context, client memoryDBClient, scope string, _ *memorydb.DescribeClustersInput, output *memorydb.DescribeClustersOutput) ([]*sdp.Item, error) {
+	items := make([]*sdp.Item, 0)
+
+	for _, cluster := range output.Clusters {
+		var tags map[string]string
+
+		// Get tags
+		tagsOut, err := client.ListTags(ctx, &memorydb.ListTagsInput{
+			ResourceArn: cluster.ARN,
+		})
+
+		if err == nil {
+			tags = tagsToMap(tagsOut.TagList)
+		} else {
+			tags = sources.HandleTagsError(ctx, err)
+		}
+
+		attributes, err := sources.ToAttributesCase(cluster)
+
+		if err != nil {
+			return nil, err
+		}
+
+		item := sdp.Item{
+			Type:            "memorydb-cluster",
+			UniqueAttribute: "name",
+			Attributes:      attributes,
+			Scope:           scope,
+			Tags:            tags,
+		}
+
+		if cluster.Status != nil {
+			item.Health = statusToHealth(*cluster.Status)
+		}
+
+		// +overmind:link dns
+		if link := endpointLink(cluster.ClusterEndpoint); link != nil {
+			item.LinkedItemQueries = append(item.LinkedItemQueries, link)
+		}
+
+		for _, shard := range cluster.Shards {
+			for _, node := range shard.Nodes {
+				// +overmind:link dns
+				if link := endpointLink(node.Endpoint); link != nil {
+					item.LinkedItemQueries = append(item.LinkedItemQueries, link)
+				}
+			}
+		}
+
+		for _, sg := range cluster.SecurityGroups {
+			if sg.SecurityGroupId != nil {
+				// +overmind:link ec2-security-group
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "ec2-security-group",
+						Method: sdp.QueryMethod_GET,
+						Query:  *sg.SecurityGroupId,
+						Scope:  scope,
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Changing the security group can affect the cluster
+						In: true,
+						// The cluster won't affect the security group
+						Out: false,
+					},
+				})
+			}
+		}
+
+		if cluster.KmsKeyId != nil {
+			if a, err := sources.ParseARN(*cluster.KmsKeyId); err == nil {
+				// +overmind:link kms-key
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "kms-key",
+						Method: sdp.QueryMethod_SEARCH,
+						Query:  *cluster.KmsKeyId,
+						Scope:  sources.FormatScope(a.AccountID, a.Region),
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Changing the KMS key can affect the cluster
+						In: true,
+						// The cluster won't affect the KMS key
+						Out: false,
+					},
+				})
+			} else {
+				// +overmind:link kms-key
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "kms-key",
+						Method: sdp.QueryMethod_GET,
+						Query:  *cluster.KmsKeyId,
+						Scope:  scope,
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Changing the KMS key can affect the cluster
+						In: true,
+						// The cluster won't affect the KMS key
+						Out: false,
+					},
+				})
+			}
+		}
+
+		if cluster.SnsTopicArn != nil {
+			if a, err := sources.ParseARN(*cluster.SnsTopicArn); err == nil {
+				// +overmind:link sns-topic
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "sns-topic",
+						Method: sdp.QueryMethod_SEARCH,
+						Query:  *cluster.SnsTopicArn,
+						Scope:  sources.FormatScope(a.AccountID, a.Region),
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Changing the topic won't affect the cluster
+						In: false,
+						// The cluster sends notifications to the topic
+						Out: true,
+					},
+				})
+			}
+		}
+
+		items = append(items, &item)
+	}
+
+	return items, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type memorydb-cluster
+// +overmind:descriptiveType MemoryDB Cluster
+// +overmind:get Get a cluster by name
+// +overmind:list List all clusters
+// +overmind:search Search for clusters by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_memorydb_cluster.name
+
+func NewClusterSource(config aws.Config, accountID string) *sources.DescribeOnlySource[*memorydb.DescribeClustersInput, *memorydb.DescribeClustersOutput, memoryDBClient, *memorydb.Options] {
+	return &sources.DescribeOnlySource[*memorydb.DescribeClustersInput, *memorydb.DescribeClustersOutput, memoryDBClient, *memorydb.Options]{
+		ItemType:  "memorydb-cluster",
+		Config:    config,
+		AccountID: accountID,
+		Client:    memorydb.NewFromConfig(config),
+		PaginatorBuilder: func(client memoryDBClient, params *memorydb.DescribeClustersInput) sources.Paginator[*memorydb.DescribeClustersOutput, *memorydb.Options] {
+			return memorydb.NewDescribeClustersPaginator(client, params)
+		},
+		DescribeFunc: func(ctx context.Context, client memoryDBClient, input *memorydb.DescribeClustersInput) (*memorydb.DescribeClustersOutput, error) {
+			return client.DescribeClusters(ctx, input)
+		},
+		InputMapperGet: func(scope, query string) (*memorydb.DescribeClustersInput, error) {
+			return &memorydb.DescribeClustersInput{
+				ClusterName: &query,
+				// Without this the node endpoints aren't returned
+				ShowShardDetails: sources.PtrBool(true),
+			}, nil
+		},
+		InputMapperList: func(scope string) (*memorydb.DescribeClustersInput, error) {
+			return &memorydb.DescribeClustersInput{
+				ShowShardDetails: sources.PtrBool(true),
+			}, nil
+		},
+		OutputMapper: clusterOutputMapper,
+	}
+}
@@ -0,0 +1,151 @@
+package memorydb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/memorydb"
+	"github.com/aws/aws-sdk-go-v2/service/memorydb/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+type mockMemoryDBClient struct{}
+
+func (m mockMemoryDBClient) DescribeClusters(ctx context.Context, params *memorydb.DescribeClustersInput, optFns ...func(*memorydb.Options)) (*memorydb.DescribeClustersOutput, error) {
+	return nil, nil
+}
+
+func (m mockMemoryDBClient) ListTags(ctx context.Context, params *memorydb.ListTagsInput, optFns ...func(*memorydb.Options)) (*memorydb.ListTagsOutput, error) {
+	return &memorydb.ListTagsOutput{
+		TagList: []types.Tag{
+			{
+				Key:   sources.PtrString("key"),
+				Value: sources.PtrString("value"),
+			},
+		},
+	}, nil
+}
+
+func TestClusterOutputMapper(t *testing.T) {
+	output := memorydb.DescribeClustersOutput{
+		Clusters: []types.Cluster{
+			{
+				ARN:                sources.PtrString("arn:aws:memorydb:eu-west-2:052392120703:cluster/sessions"),
+				Name:               sources.PtrString("sessions"),
+				Status:             sources.PtrString("available"),
+				NodeType:           sources.PtrString("db.t4g.small"),
+				EngineVersion:      sources.PtrString("7.0"),
+				NumberOfShards:     sources.PtrInt32(1),
+				TLSEnabled:         sources.PtrBool(true),
+				ACLName:            sources.PtrString("open-access"),
+				ParameterGroupName: sources.PtrString("default.memorydb-redis7"),
+				SubnetGroupName:    sources.PtrString("sessions-subnets"),
+				ClusterEndpoint: &types.Endpoint{
+					Address: sources.PtrString("clustercfg.sessions.abc123.memorydb.eu-west-2.amazonaws.com"), // link
+					Port:    6379,
+				},
+				Shards: []types.Shard{
+					{
+						Name:   sources.PtrString("0001"),
+						Status: sources.PtrString("available"),
+						Nodes: []types.Node{
+							{
+								Name:             sources.PtrString("sessions-0001-001"),
+								AvailabilityZone: sources.PtrString("eu-west-2a"),
+								CreateTime:       sources.PtrTime(time.Now()),
+								Status:           sources.PtrString("available"),
+								Endpoint: &types.Endpoint{
+									Address: sources.PtrString("sessions-0001-001.sessions.abc123.memorydb.eu-west-2.amazonaws.com"), // link
+									Port:    6379,
+								},
+							},
+						},
+					},
+				},
+				SecurityGroups: []types.SecurityGroupMembership{
+					{
+						SecurityGroupId: sources.PtrString("sg-0b6a1c5e0e8d6c8f2"), // link
+						Status:          sources.PtrString("active"),
+					},
+				},
+				KmsKeyId:       sources.PtrString("arn:aws:kms:eu-west-2:052392120703:key/3f6b1c2d-8a0e-4b7c-9d21-5e6f7a8b9c0d"), // link
+				SnsTopicArn:    sources.PtrString("arn:aws:sns:eu-west-2:052392120703:memorydb-events"),                          // link
+				SnsTopicStatus: sources.PtrString("active"),
+			},
+		},
+	}
+
+	items, err := clusterOutputMapper(context.Background(), mockMemoryDBClient{}, "foo", nil, &output)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 1 {
+		t.Fatalf("got %v items, expected 1", len(items))
+	}
+
+	item := items[0]
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.Tags["key"] != "value" {
+		t.Errorf("expected key to be value, got %v", item.Tags["key"])
+	}
+
+	if item.GetHealth() != sdp.Health_HEALTH_OK {
+		t.Errorf("expected health to be OK, got %v", item.GetHealth())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "dns",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "clustercfg.sessions.abc123.memorydb.eu-west-2.amazonaws.com",
+			ExpectedScope:  "global",
+		},
+		{
+			ExpectedType:   "dns",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "sessions-0001-001.sessions.abc123.memorydb.eu-west-2.amazonaws.com",
+			ExpectedScope:  "global",
+		},
+		{
+			ExpectedType:   "ec2-security-group",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "sg-0b6a1c5e0e8d6c8f2",
+			ExpectedScope:  "foo",
+		},
+		{
+			ExpectedType:   "kms-key",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:kms:eu-west-2:052392120703:key/3f6b1c2d-8a0e-4b7c-9d21-5e6f7a8b9c0d",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "sns-topic",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:sns:eu-west-2:052392120703:memorydb-events",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestNewClusterSource(t *testing.T) {
+	config, account, _ := sources.GetAutoConfig(t)
+
+	source := NewClusterSource(config, account)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -2,7 +2,9 @@ package route53
 
 import (
 	"context"
-	"errors"
+	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/route53"
@@ -11,32 +13,476 @@ import (
 	"github.com/overmindtech/sdp-go"
 )
 
-func resourceRecordSetGetFunc(ctx context.Context, client *route53.Client, scope, query string) (*types.ResourceRecordSet, error) {
-	return nil, errors.New("get is not supported for route53-resource-record-set. Use search")
+var (
+	// S3 website endpoints e.g. s3-website.eu-west-2.amazonaws.com or
+	// s3-website-us-east-1.amazonaws.com
+	s3WebsiteDNSRegex = regexp.MustCompile(`^s3-website[.-]([a-z0-9-]+)\.amazonaws\.com$`)
+	// API Gateway regional custom domain names e.g.
+	// d-abcdef1234.execute-api.eu-west-2.amazonaws.com
+	apiGatewayDNSRegex = regexp.MustCompile(`^d-[a-z0-9]+\.execute-api\.([a-z0-9-]+)\.amazonaws\.com$`)
+)
+
+// ResourceRecordSetDetails A record set along with the ID of the hosted zone
+// that it is in, since record sets are only unique within a zone
+type ResourceRecordSetDetails struct {
+	HostedZoneId      string
+	ResourceRecordSet *types.ResourceRecordSet
+}
+
+// recordSetQuery The parts of the unique name of a record set, which is in the
+// format {hostedZoneId}|{name}|{type} with an optional |{setIdentifier} for
+// weighted, latency, failover etc. record sets that share a name and type
+type recordSetQuery struct {
+	HostedZoneId  string
+	Name          string
+	Type          types.RRType
+	SetIdentifier *string
+}
+
+func parseRecordSetQuery(query string) (*recordSetQuery, error) {
+	sections := strings.SplitN(query, "|", 4)
+
+	if len(sections) < 3 || sections[0] == "" || sections[1] == "" || sections[2] == "" {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: fmt.Sprintf("query must be in the format {hostedZoneId}|{name}|{type}[|{setIdentifier}], got %v", query),
+		}
+	}
+
+	q := recordSetQuery{
+		HostedZoneId: trimHostedZonePrefix(sections[0]),
+		Name:         sections[1],
+		Type:         types.RRType(strings.ToUpper(sections[2])),
+	}
+
+	if len(sections) == 4 {
+		q.SetIdentifier = &sections[3]
+	}
+
+	return &q, nil
+}
+
+// parseTerraformRecordID Parses the ID that Terraform uses for an
+// aws_route53_record, which is in the format {hostedZoneId}_{name}_{type} with
+// an optional _{setIdentifier}. Names can contain underscores, so the type is
+// found by looking for the first valid record type after the name
+func parseTerraformRecordID(id string) (*recordSetQuery, bool) {
+	hostedZoneID, rest, found := strings.Cut(id, "_")
+
+	if !found || hostedZoneID == "" || strings.Contains(hostedZoneID, ".") {
+		return nil, false
+	}
+
+	// Start at 1 since names such as _dmarc.example.com start with an
+	// underscore
+	for i := 1; i < len(rest); i++ {
+		if rest[i] != '_' {
+			continue
+		}
+
+		recordType, setIdentifier, hasSetIdentifier := strings.Cut(rest[i+1:], "_")
+
+		if !isRecordType(recordType) {
+			continue
+		}
+
+		q := recordSetQuery{
+			HostedZoneId: hostedZoneID,
+			Name:         rest[:i],
+			Type:         types.RRType(recordType),
+		}
+
+		if hasSetIdentifier {
+			q.SetIdentifier = &setIdentifier
+		}
+
+		return &q, true
+	}
+
+	return nil, false
+}
+
+// isRecordType Returns whether a string is one of the record types that Route
+// 53 supports
+func isRecordType(recordType string) bool {
+	for _, t := range types.RRType("").Values() {
+		if string(t) == recordType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// recordSetUniqueName Returns the unique name of a record set in the format
+// that parseRecordSetQuery accepts
+func recordSetUniqueName(hostedZoneID string, rrs *types.ResourceRecordSet) string {
+	name := fmt.Sprintf("%v|%v|%v", trimHostedZonePrefix(hostedZoneID), *rrs.Name, rrs.Type)
+
+	if rrs.SetIdentifier != nil {
+		name += "|" + *rrs.SetIdentifier
+	}
+
+	return name
+}
+
+// trimHostedZonePrefix Removes the /hostedzone/ prefix that the API includes
+// in hosted zone IDs
+func trimHostedZonePrefix(id string) string {
+	return strings.TrimPrefix(id, "/hostedzone/")
+}
+
+// sameRecordName Compares record names, ignoring case and the trailing dot
+// which Route 53 always returns but users often omit
+func sameRecordName(a, b string) bool {
+	return strings.EqualFold(strings.TrimSuffix(a, "."), strings.TrimSuffix(b, "."))
 }
 
-// ResourceRecordSetSearchFunc Search func that accepts a hosted zone ID as a
-// query
-func resourceRecordSetSearchFunc(ctx context.Context, client *route53.Client, scope, query string) ([]*types.ResourceRecordSet, error) {
+func resourceRecordSetGetFunc(ctx context.Context, client *route53.Client, scope, query string) (*ResourceRecordSetDetails, error) {
+	q, err := parseRecordSetQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return getResourceRecordSet(ctx, client, q)
+}
+
+// getResourceRecordSet Gets a single record set by its hosted zone, name, type
+// and set identifier
+func getResourceRecordSet(ctx context.Context, client *route53.Client, q *recordSetQuery) (*ResourceRecordSetDetails, error) {
+	// The API doesn't have a get, but listing starting at the record we want
+	// will return it first if it exists
 	out, err := client.ListResourceRecordSets(ctx, &route53.ListResourceRecordSetsInput{
-		HostedZoneId: &query,
+		HostedZoneId:          &q.HostedZoneId,
+		StartRecordName:       &q.Name,
+		StartRecordType:       q.Type,
+		StartRecordIdentifier: q.SetIdentifier,
+		MaxItems:              aws.Int32(1),
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	zones := make([]*types.ResourceRecordSet, len(out.ResourceRecordSets))
+	for i, rrs := range out.ResourceRecordSets {
+		if rrs.Name == nil || !sameRecordName(*rrs.Name, q.Name) || rrs.Type != q.Type {
+			continue
+		}
+
+		if aws.ToString(rrs.SetIdentifier) != aws.ToString(q.SetIdentifier) {
+			continue
+		}
+
+		return &ResourceRecordSetDetails{
+			HostedZoneId:      q.HostedZoneId,
+			ResourceRecordSet: &out.ResourceRecordSets[i],
+		}, nil
+	}
+
+	name := recordSetUniqueName(q.HostedZoneId, &types.ResourceRecordSet{
+		Name:          &q.Name,
+		Type:          q.Type,
+		SetIdentifier: q.SetIdentifier,
+	})
+
+	return nil, &sdp.QueryError{
+		ErrorType:   sdp.QueryError_NOTFOUND,
+		ErrorString: fmt.Sprintf("record set %v not found", name),
+	}
+}
+
+// listResourceRecordSets Lists all record sets in a given hosted zone
+func listResourceRecordSets(ctx context.Context, client *route53.Client, hostedZoneID string) ([]*ResourceRecordSetDetails, error) {
+	hostedZoneID = trimHostedZonePrefix(hostedZoneID)
+	recordSets := make([]*ResourceRecordSetDetails, 0)
+
+	input := route53.ListResourceRecordSetsInput{
+		HostedZoneId: &hostedZoneID,
+	}
+
+	for {
+		out, err := client.ListResourceRecordSets(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range out.ResourceRecordSets {
+			recordSets = append(recordSets, &ResourceRecordSetDetails{
+				HostedZoneId:      hostedZoneID,
+				ResourceRecordSet: &out.ResourceRecordSets[i],
+			})
+		}
+
+		if !out.IsTruncated {
+			break
+		}
+
+		input.StartRecordName = out.NextRecordName
+		input.StartRecordType = out.NextRecordType
+		input.StartRecordIdentifier = out.NextRecordIdentifier
+	}
+
+	return recordSets, nil
+}
+
+func resourceRecordSetListFunc(ctx context.Context, client *route53.Client, scope string) ([]*ResourceRecordSetDetails, error) {
+	recordSets := make([]*ResourceRecordSetDetails, 0)
 
-	for i, zone := range out.ResourceRecordSets {
-		zones[i] = &zone
+	paginator := route53.NewListHostedZonesPaginator(client, &route53.ListHostedZonesInput{})
+
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, zone := range out.HostedZones {
+			if zone.Id == nil {
+				continue
+			}
+
+			zoneRecordSets, err := listResourceRecordSets(ctx, client, *zone.Id)
+
+			if err != nil {
+				return nil, err
+			}
+
+			recordSets = append(recordSets, zoneRecordSets...)
+		}
 	}
 
-	return zones, nil
+	return recordSets, nil
 }
 
-func resourceRecordSetItemMapper(scope string, awsItem *types.ResourceRecordSet) (*sdp.Item, error) {
-	attributes, err := sources.ToAttributesCase(awsItem)
+// listResourceRecordSetsByName Finds all record sets with a given name, in any
+// hosted zone that the name could belong to
+func listResourceRecordSetsByName(ctx context.Context, client *route53.Client, name string) ([]*ResourceRecordSetDetails, error) {
+	recordSets := make([]*ResourceRecordSetDetails, 0)
+	fqdn := strings.ToLower(strings.TrimSuffix(name, ".")) + "."
+
+	paginator := route53.NewListHostedZonesPaginator(client, &route53.ListHostedZonesInput{})
+
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, zone := range out.HostedZones {
+			if zone.Id == nil || zone.Name == nil {
+				continue
+			}
+
+			zoneName := strings.ToLower(*zone.Name)
+
+			if fqdn != zoneName && !strings.HasSuffix(fqdn, "."+zoneName) {
+				continue
+			}
+
+			hostedZoneID := trimHostedZonePrefix(*zone.Id)
+
+			// Records are sorted by name, so listing from the name we want
+			// returns all of its records first
+			input := route53.ListResourceRecordSetsInput{
+				HostedZoneId:    &hostedZoneID,
+				StartRecordName: &fqdn,
+			}
+
+			for {
+				records, err := client.ListResourceRecordSets(ctx, &input)
+
+				if err != nil {
+					return nil, err
+				}
+
+				passedName := false
+
+				for i, rrs := range records.ResourceRecordSets {
+					if rrs.Name == nil || !sameRecordName(*rrs.Name, fqdn) {
+						passedName = true
+						break
+					}
+
+					recordSets = append(recordSets, &ResourceRecordSetDetails{
+						HostedZoneId:      hostedZoneID,
+						ResourceRecordSet: &records.ResourceRecordSets[i],
+					})
+				}
+
+				if passedName || !records.IsTruncated {
+					break
+				}
+
+				input.StartRecordName = records.NextRecordName
+				input.StartRecordType = records.NextRecordType
+				input.StartRecordIdentifier = records.NextRecordIdentifier
+			}
+		}
+	}
+
+	return recordSets, nil
+}
+
+// ResourceRecordSetSearchFunc Search func that accepts a hosted zone ID, the
+// unique name of a record set, the Terraform ID of a record set, or a record
+// name such as www.example.com
+func resourceRecordSetSearchFunc(ctx context.Context, client *route53.Client, scope, query string) ([]*ResourceRecordSetDetails, error) {
+	var q *recordSetQuery
+	var err error
+
+	if strings.Contains(query, "|") {
+		q, err = parseRecordSetQuery(query)
+
+		if err != nil {
+			return nil, err
+		}
+	} else if terraformQuery, ok := parseTerraformRecordID(query); ok {
+		q = terraformQuery
+	}
+
+	if q != nil {
+		recordSet, err := getResourceRecordSet(ctx, client, q)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return []*ResourceRecordSetDetails{recordSet}, nil
+	}
+
+	// Hosted zone IDs never contain dots, but record names always do
+	if strings.Contains(query, ".") {
+		return listResourceRecordSetsByName(ctx, client, query)
+	}
+
+	return listResourceRecordSets(ctx, client, query)
+}
+
+// recordValueQuery Returns a query for the value of a non-alias record, or nil
+// if the value isn't something that can be linked to
+func recordValueQuery(recordType types.RRType, value string) *sdp.Query {
+	var hostname string
+
+	switch recordType {
+	case types.RRTypeA, types.RRTypeAaaa:
+		return &sdp.Query{
+			Type:   "ip",
+			Method: sdp.QueryMethod_GET,
+			Query:  value,
+			Scope:  "global",
+		}
+	case types.RRTypeCname, types.RRTypeNs, types.RRTypePtr:
+		hostname = value
+	case types.RRTypeMx:
+		// In the format {priority} {hostname}
+		if fields := strings.Fields(value); len(fields) == 2 {
+			hostname = fields[1]
+		}
+	case types.RRTypeSrv:
+		// In the format {priority} {weight} {port} {hostname}
+		if fields := strings.Fields(value); len(fields) == 4 {
+			hostname = fields[3]
+		}
+	}
+
+	hostname = strings.TrimSuffix(hostname, ".")
+
+	if hostname == "" {
+		return nil
+	}
+
+	return &sdp.Query{
+		Type:   "dns",
+		Method: sdp.QueryMethod_SEARCH,
+		Query:  hostname,
+		Scope:  "global",
+	}
+}
+
+// aliasTargetQueries Returns queries for the AWS resources that an alias
+// points to, based on the format of the alias target's DNS name
+func aliasTargetQueries(recordName string, dnsName string, scope string) ([]*sdp.Query, error) {
+	accountID, _, err := sources.ParseScope(scope)
+
+	if err != nil {
+		return nil, err
+	}
+
+	queries := make([]*sdp.Query, 0)
+	dnsName = strings.ToLower(strings.TrimSuffix(dnsName, "."))
+	recordName = strings.TrimSuffix(recordName, ".")
+
+	if strings.HasSuffix(dnsName, ".cloudfront.net") {
+		queries = append(queries, &sdp.Query{
+			Type:   "cloudfront-distribution",
+			Method: sdp.QueryMethod_SEARCH,
+			Query:  dnsName,
+			Scope:  sources.FormatScope(accountID, ""), // CloudFront is global
+		})
+	}
+
+	queries = append(queries, sources.LoadBalancerQueries(dnsName, accountID)...)
+
+	if s3WebsiteDNSRegex.MatchString(dnsName) {
+		// Website endpoints only work when the bucket has the same name as
+		// the record
+		queries = append(queries, &sdp.Query{
+			Type:   "s3-bucket",
+			Method: sdp.QueryMethod_GET,
+			Query:  recordName,
+			Scope:  sources.FormatScope(accountID, ""), // S3 buckets are global
+		})
+	}
+
+	if matches := apiGatewayDNSRegex.FindStringSubmatch(dnsName); matches != nil {
+		// Custom domain names point at a regional domain name, and have the
+		// same name as the record. REST and HTTP API domain names share the
+		// same DNS format so we can't tell which this is
+		queries = append(queries, &sdp.Query{
+			Type:   "apigateway-domain-name",
+			Method: sdp.QueryMethod_GET,
+			Query:  recordName,
+			Scope:  sources.FormatScope(accountID, matches[1]),
+		}, &sdp.Query{
+			Type:   "apigatewayv2-domain-name",
+			Method: sdp.QueryMethod_GET,
+			Query:  recordName,
+			Scope:  sources.FormatScope(accountID, matches[1]),
+		})
+	}
+
+	return queries, nil
+}
+
+func resourceRecordSetItemMapper(scope string, awsItem *ResourceRecordSetDetails) (*sdp.Item, error) {
+	rrs := awsItem.ResourceRecordSet
+
+	enrichedRecordSet := struct {
+		*types.ResourceRecordSet
+		HostedZoneId string
+	}{
+		ResourceRecordSet: rrs,
+		HostedZoneId:      awsItem.HostedZoneId,
+	}
+
+	attributes, err := sources.ToAttributesCase(enrichedRecordSet)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if rrs.Name == nil {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_OTHER,
+			ErrorString: "record set has no name",
+		}
+	}
+
+	err = attributes.Set("uniqueName", recordSetUniqueName(awsItem.HostedZoneId, rrs))
 
 	if err != nil {
 		return nil, err
@@ -44,19 +490,55 @@ func resourceRecordSetItemMapper(scope string, awsItem *types.ResourceRecordSet)
 
 	item := sdp.Item{
 		Type:            "route53-resource-record-set",
-		UniqueAttribute: "name",
+		UniqueAttribute: "uniqueName",
 		Attributes:      attributes,
 		Scope:           scope,
 	}
 
-	if awsItem.AliasTarget != nil {
-		if awsItem.AliasTarget.DNSName != nil {
+	// +overmind:link route53-hosted-zone
+	item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+		Query: &sdp.Query{
+			Type:   "route53-hosted-zone",
+			Method: sdp.QueryMethod_GET,
+			// Hosted zones use the full ID, including the prefix
+			Query: "/hostedzone/" + awsItem.HostedZoneId,
+			Scope: scope,
+		},
+		BlastPropagation: &sdp.BlastPropagation{
+			// Changing the hosted zone can affect the resource record set
+			In: true,
+			// The resource record set won't affect the hosted zone
+			Out: false,
+		},
+	})
+
+	for _, record := range rrs.ResourceRecords {
+		if record.Value == nil {
+			continue
+		}
+
+		if q := recordValueQuery(rrs.Type, *record.Value); q != nil {
+			// +overmind:link ip
+			// +overmind:link dns
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: q,
+				BlastPropagation: &sdp.BlastPropagation{
+					// DNS and IPs are always linked
+					In:  true,
+					Out: true,
+				},
+			})
+		}
+	}
+
+	if rrs.AliasTarget != nil {
+		if rrs.AliasTarget.DNSName != nil {
 			// +overmind:link dns
 			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
 				Query: &sdp.Query{
 					Type:   "dns",
 					Method: sdp.QueryMethod_SEARCH,
-					Query:  *awsItem.AliasTarget.DNSName,
+					Query:  *rrs.AliasTarget.DNSName,
 					Scope:  "global",
 				},
 				BlastPropagation: &sdp.BlastPropagation{
@@ -65,6 +547,31 @@ func resourceRecordSetItemMapper(scope string, awsItem *types.ResourceRecordSet)
 					Out: true,
 				},
 			})
+
+			queries, err := aliasTargetQueries(*rrs.Name, *rrs.AliasTarget.DNSName, scope)
+
+			if err != nil {
+				return nil, err
+			}
+
+			for _, q := range queries {
+				// +overmind:link apigateway-domain-name
+				// +overmind:link apigatewayv2-domain-name
+				// +overmind:link cloudfront-distribution
+				// +overmind:link elb-load-balancer
+				// +overmind:link elbv2-load-balancer
+				// +overmind:link s3-bucket
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: q,
+					BlastPropagation: &sdp.BlastPropagation{
+						// Changing the target will change what the record
+						// resolves to
+						In: true,
+						// The record won't affect the target
+						Out: false,
+					},
+				})
+			}
 		}
 	}
 
@@ -74,22 +581,22 @@ func resourceRecordSetItemMapper(scope string, awsItem *types.ResourceRecordSet)
 //go:generate docgen ../../docs-data
 // +overmind:type route53-resource-record-set
 // +overmind:descriptiveType Route53 Record Set
-// +overmind:get Get a Route53 record Set by name
-// +overmind:list List all record sets
-// +overmind:search Search for a record set by ARN
+// +overmind:get Get a record set by {hostedZoneId}|{name}|{type}, with an optional |{setIdentifier} for record sets that use a routing policy
+// +overmind:list List all record sets in all hosted zones
+// +overmind:search Search for record sets by hosted zone ID, by record name (e.g. `www.example.com`), by {hostedZoneId}|{name}|{type}[|{setIdentifier}], or by Terraform ID ({hostedZoneId}_{name}_{type}[_{setIdentifier}])
 // +overmind:group AWS
-// +overmind:terraform:queryMap aws_route53_record.arn
+// +overmind:terraform:queryMap aws_route53_record.id
 // +overmind:terraform:method SEARCH
 
-func NewResourceRecordSetSource(config aws.Config, accountID string, region string) *sources.GetListSource[*types.ResourceRecordSet, *route53.Client, *route53.Options] {
-	return &sources.GetListSource[*types.ResourceRecordSet, *route53.Client, *route53.Options]{
-		ItemType:    "route53-resource-record-set",
-		Client:      route53.NewFromConfig(config),
-		DisableList: true,
-		AccountID:   accountID,
-		Region:      region,
-		GetFunc:     resourceRecordSetGetFunc,
-		ItemMapper:  resourceRecordSetItemMapper,
-		SearchFunc:  resourceRecordSetSearchFunc,
+func NewResourceRecordSetSource(config aws.Config, accountID string, region string) *sources.GetListSource[*ResourceRecordSetDetails, *route53.Client, *route53.Options] {
+	return &sources.GetListSource[*ResourceRecordSetDetails, *route53.Client, *route53.Options]{
+		ItemType:   "route53-resource-record-set",
+		Client:     route53.NewFromConfig(config),
+		AccountID:  accountID,
+		Region:     region,
+		GetFunc:    resourceRecordSetGetFunc,
+		ListFunc:   resourceRecordSetListFunc,
+		ItemMapper: resourceRecordSetItemMapper,
+		SearchFunc: resourceRecordSetSearchFunc,
 	}
 }
@@ -4,11 +4,118 @@ import (
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/route53/types"
 	"github.com/overmindtech/aws-source/sources"
 	"github.com/overmindtech/sdp-go"
 )
 
+func TestParseRecordSetQuery(t *testing.T) {
+	t.Run("without set identifier", func(t *testing.T) {
+		q, err := parseRecordSetQuery("/hostedzone/Z08416862SZP5DJXIDB29|www.overmind-demo.com.|a")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if q.HostedZoneId != "Z08416862SZP5DJXIDB29" {
+			t.Errorf("expected hosted zone ID Z08416862SZP5DJXIDB29, got %v", q.HostedZoneId)
+		}
+
+		if q.Name != "www.overmind-demo.com." {
+			t.Errorf("expected name www.overmind-demo.com., got %v", q.Name)
+		}
+
+		if q.Type != types.RRTypeA {
+			t.Errorf("expected type A, got %v", q.Type)
+		}
+
+		if q.SetIdentifier != nil {
+			t.Errorf("expected no set identifier, got %v", *q.SetIdentifier)
+		}
+	})
+
+	t.Run("with set identifier", func(t *testing.T) {
+		q, err := parseRecordSetQuery("Z08416862SZP5DJXIDB29|www.overmind-demo.com.|A|eu-west-2|primary")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Set identifiers can contain the separator
+		if q.SetIdentifier == nil || *q.SetIdentifier != "eu-west-2|primary" {
+			t.Errorf("expected set identifier eu-west-2|primary, got %v", q.SetIdentifier)
+		}
+	})
+
+	t.Run("bad query", func(t *testing.T) {
+		_, err := parseRecordSetQuery("Z08416862SZP5DJXIDB29|www.overmind-demo.com.")
+
+		if err == nil {
+			t.Error("expected error for query without a type")
+		}
+	})
+}
+
+func TestParseTerraformRecordID(t *testing.T) {
+	cases := []struct {
+		ID            string
+		Name          string
+		Type          types.RRType
+		SetIdentifier *string
+	}{
+		{
+			ID:   "Z08416862SZP5DJXIDB29_www.overmind-demo.com_A",
+			Name: "www.overmind-demo.com",
+			Type: types.RRTypeA,
+		},
+		{
+			ID:            "Z08416862SZP5DJXIDB29_www.overmind-demo.com_CNAME_eu_west_2",
+			Name:          "www.overmind-demo.com",
+			Type:          types.RRTypeCname,
+			SetIdentifier: sources.PtrString("eu_west_2"),
+		},
+		{
+			// Names can start with and contain underscores
+			ID:   "Z08416862SZP5DJXIDB29__acme_challenge.overmind-demo.com_TXT",
+			Name: "_acme_challenge.overmind-demo.com",
+			Type: types.RRTypeTxt,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.ID, func(t *testing.T) {
+			q, ok := parseTerraformRecordID(c.ID)
+
+			if !ok {
+				t.Fatal("expected ID to be parsed")
+			}
+
+			if q.HostedZoneId != "Z08416862SZP5DJXIDB29" {
+				t.Errorf("expected hosted zone ID Z08416862SZP5DJXIDB29, got %v", q.HostedZoneId)
+			}
+
+			if q.Name != c.Name {
+				t.Errorf("expected name %v, got %v", c.Name, q.Name)
+			}
+
+			if q.Type != c.Type {
+				t.Errorf("expected type %v, got %v", c.Type, q.Type)
+			}
+
+			if aws.ToString(q.SetIdentifier) != aws.ToString(c.SetIdentifier) {
+				t.Errorf("expected set identifier %v, got %v", aws.ToString(c.SetIdentifier), aws.ToString(q.SetIdentifier))
+			}
+		})
+	}
+
+	for _, query := range []string{"Z08416862SZP5DJXIDB29", "www.overmind-demo.com", "Z08416862SZP5DJXIDB29_www.overmind-demo.com"} {
+		if _, ok := parseTerraformRecordID(query); ok {
+			t.Errorf("expected %v not to be parsed as a Terraform ID", query)
+		}
+	}
+}
+
 func TestResourceRecordSetItemMapper(t *testing.T) {
 	recordSet := types.ResourceRecordSet{
 		Name: sources.PtrString("overmind-demo.com."),
@@ -16,16 +123,16 @@ func TestResourceRecordSetItemMapper(t *testing.T) {
 		TTL:  sources.PtrInt64(172800),
 		ResourceRecords: []types.ResourceRecord{
 			{
-				Value: sources.PtrString("ns-1673.awsdns-17.co.uk."),
+				Value: sources.PtrString("ns-1673.awsdns-17.co.uk."), // link
 			},
 			{
-				Value: sources.PtrString("ns-1505.awsdns-60.org."),
+				Value: sources.PtrString("ns-1505.awsdns-60.org."), // link
 			},
 			{
-				Value: sources.PtrString("ns-955.awsdns-55.net."),
+				Value: sources.PtrString("ns-955.awsdns-55.net."), // link
 			},
 			{
-				Value: sources.PtrString("ns-276.awsdns-34.com."),
+				Value: sources.PtrString("ns-276.awsdns-34.com."), // link
 			},
 		},
 		AliasTarget: &types.AliasTarget{
@@ -51,17 +158,44 @@ func TestResourceRecordSetItemMapper(t *testing.T) {
 		Weight:                  sources.PtrInt64(100),
 	}
 
-	item, err := resourceRecordSetItemMapper("foo", &recordSet)
+	scope := "123456789012.eu-west-2"
+
+	item, err := resourceRecordSetItemMapper(scope, &ResourceRecordSetDetails{
+		HostedZoneId:      "Z08416862SZP5DJXIDB29",
+		ResourceRecordSet: &recordSet,
+	})
 
 	if err != nil {
-		t.Error(err)
+		t.Fatal(err)
 	}
 
 	if err = item.Validate(); err != nil {
 		t.Error(err)
 	}
 
+	if item.UniqueAttributeValue() != "Z08416862SZP5DJXIDB29|overmind-demo.com.|NS|identifier" {
+		t.Errorf("unexpected unique attribute value %v", item.UniqueAttributeValue())
+	}
+
 	tests := sources.QueryTests{
+		{
+			ExpectedType:   "route53-hosted-zone",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "/hostedzone/Z08416862SZP5DJXIDB29",
+			ExpectedScope:  scope,
+		},
+		{
+			ExpectedType:   "dns",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "ns-1673.awsdns-17.co.uk",
+			ExpectedScope:  "global",
+		},
+		{
+			ExpectedType:   "dns",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "ns-276.awsdns-34.com",
+			ExpectedScope:  "global",
+		},
 		{
 			ExpectedType:   "dns",
 			ExpectedMethod: sdp.QueryMethod_SEARCH,
@@ -73,6 +207,184 @@ func TestResourceRecordSetItemMapper(t *testing.T) {
 	tests.Execute(t, item)
 }
 
+func TestRecordValueQuery(t *testing.T) {
+	cases := []struct {
+		Name          string
+		Type          types.RRType
+		Value         string
+		ExpectedType  string
+		ExpectedQuery string
+	}{
+		{
+			Name:          "A",
+			Type:          types.RRTypeA,
+			Value:         "192.0.2.44",
+			ExpectedType:  "ip",
+			ExpectedQuery: "192.0.2.44",
+		},
+		{
+			Name:          "AAAA",
+			Type:          types.RRTypeAaaa,
+			Value:         "2001:db8::1",
+			ExpectedType:  "ip",
+			ExpectedQuery: "2001:db8::1",
+		},
+		{
+			Name:          "CNAME",
+			Type:          types.RRTypeCname,
+			Value:         "www.example.com.",
+			ExpectedType:  "dns",
+			ExpectedQuery: "www.example.com",
+		},
+		{
+			Name:          "MX",
+			Type:          types.RRTypeMx,
+			Value:         "10 mail.example.com.",
+			ExpectedType:  "dns",
+			ExpectedQuery: "mail.example.com",
+		},
+		{
+			Name:          "SRV",
+			Type:          types.RRTypeSrv,
+			Value:         "1 10 5269 xmpp-server.example.com.",
+			ExpectedType:  "dns",
+			ExpectedQuery: "xmpp-server.example.com",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			q := recordValueQuery(c.Type, c.Value)
+
+			if q == nil {
+				t.Fatal("expected a query, got nil")
+			}
+
+			if q.Type != c.ExpectedType {
+				t.Errorf("expected type %v, got %v", c.ExpectedType, q.Type)
+			}
+
+			if q.Query != c.ExpectedQuery {
+				t.Errorf("expected query %v, got %v", c.ExpectedQuery, q.Query)
+			}
+		})
+	}
+
+	if q := recordValueQuery(types.RRTypeTxt, `"v=spf1 -all"`); q != nil {
+		t.Errorf("expected no query for TXT record, got %v", q)
+	}
+}
+
+func TestAliasTargetQueries(t *testing.T) {
+	scope := "123456789012.eu-west-2"
+
+	cases := []struct {
+		Name     string
+		DNSName  string
+		Expected []*sdp.Query
+	}{
+		{
+			Name:    "CloudFront",
+			DNSName: "d111111abcdef8.cloudfront.net.",
+			Expected: []*sdp.Query{
+				{
+					Type:   "cloudfront-distribution",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  "d111111abcdef8.cloudfront.net",
+					Scope:  "123456789012",
+				},
+			},
+		},
+		{
+			Name:    "Application load balancer",
+			DNSName: "dualstack.internal-my-alb-1234567890.eu-west-1.elb.amazonaws.com.",
+			Expected: []*sdp.Query{
+				{
+					Type:   "elbv2-load-balancer",
+					Method: sdp.QueryMethod_GET,
+					Query:  "my-alb",
+					Scope:  "123456789012.eu-west-1",
+				},
+				{
+					Type:   "elb-load-balancer",
+					Method: sdp.QueryMethod_GET,
+					Query:  "my-alb",
+					Scope:  "123456789012.eu-west-1",
+				},
+			},
+		},
+		{
+			Name:    "Network load balancer",
+			DNSName: "my-nlb-0123456789abcdef.elb.eu-west-2.amazonaws.com",
+			Expected: []*sdp.Query{
+				{
+					Type:   "elbv2-load-balancer",
+					Method: sdp.QueryMethod_GET,
+					Query:  "my-nlb",
+					Scope:  scope,
+				},
+			},
+		},
+		{
+			Name:    "S3 website",
+			DNSName: "s3-website.eu-west-2.amazonaws.com.",
+			Expected: []*sdp.Query{
+				{
+					Type:   "s3-bucket",
+					Method: sdp.QueryMethod_GET,
+					Query:  "www.overmind-demo.com",
+					Scope:  "123456789012",
+				},
+			},
+		},
+		{
+			Name:    "API Gateway",
+			DNSName: "d-abcdef1234.execute-api.eu-west-2.amazonaws.com.",
+			Expected: []*sdp.Query{
+				{
+					Type:   "apigateway-domain-name",
+					Method: sdp.QueryMethod_GET,
+					Query:  "www.overmind-demo.com",
+					Scope:  scope,
+				},
+				{
+					Type:   "apigatewayv2-domain-name",
+					Method: sdp.QueryMethod_GET,
+					Query:  "www.overmind-demo.com",
+					Scope:  scope,
+				},
+			},
+		},
+		{
+			Name:     "Something else",
+			DNSName:  "example.com.",
+			Expected: []*sdp.Query{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			queries, err := aliasTargetQueries("www.overmind-demo.com.", c.DNSName, scope)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(queries) != len(c.Expected) {
+				t.Fatalf("expected %v queries, got %v", len(c.Expected), len(queries))
+			}
+
+			for i, expected := range c.Expected {
+				q := queries[i]
+
+				if q.Type != expected.Type || q.Method != expected.Method || q.Query != expected.Query || q.Scope != expected.Scope {
+					t.Errorf("expected %v, got %v", expected, q)
+				}
+			}
+		})
+	}
+}
+
 func TestNewResourceRecordSetSource(t *testing.T) {
 	config, account, region := sources.GetAutoConfig(t)
 
@@ -81,7 +393,6 @@ func TestNewResourceRecordSetSource(t *testing.T) {
 	test := sources.E2ETest{
 		Source:  source,
 		Timeout: 10 * time.Second,
-		SkipGet: true,
 	}
 
 	test.Run(t)
@@ -0,0 +1,143 @@
+package s3
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3control"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func accessPointGetFunc(ctx context.Context, client S3ControlClient, accountID string, query string) (*s3control.GetAccessPointOutput, error) {
+	return client.GetAccessPoint(ctx, &s3control.GetAccessPointInput{
+		AccountId: &accountID,
+		Name:      &query,
+	})
+}
+
+// accessPointListFunc Lists all access points. The list output doesn't
+// include the creation date, public access block or endpoints, so each access
+// point is fetched individually
+func accessPointListFunc(ctx context.Context, client S3ControlClient, accountID string) ([]*s3control.GetAccessPointOutput, error) {
+	accessPoints := make([]*s3control.GetAccessPointOutput, 0)
+
+	paginator := s3control.NewListAccessPointsPaginator(client, &s3control.ListAccessPointsInput{
+		AccountId: &accountID,
+	})
+
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, accessPoint := range out.AccessPointList {
+			if accessPoint.Name == nil {
+				continue
+			}
+
+			details, err := accessPointGetFunc(ctx, client, accountID, *accessPoint.Name)
+
+			if err != nil {
+				return nil, err
+			}
+
+			accessPoints = append(accessPoints, details)
+		}
+	}
+
+	return accessPoints, nil
+}
+
+func accessPointItemMapper(scope string, awsItem *s3control.GetAccessPointOutput) (*sdp.Item, error) {
+	attributes, err := sources.ToAttributesCase(awsItem)
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := sdp.Item{
+		Type:            "s3-access-point",
+		UniqueAttribute: "name",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	if awsItem.Bucket != nil {
+		// The bucket can be owned by another account
+		bucketAccount, _, err := sources.ParseScope(scope)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if awsItem.BucketAccountId != nil {
+			bucketAccount = *awsItem.BucketAccountId
+		}
+
+		// +overmind:link s3-bucket
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "s3-bucket",
+				Method: sdp.QueryMethod_GET,
+				Query:  *awsItem.Bucket,
+				Scope:  sources.FormatScope(bucketAccount, ""),
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Changing the bucket will affect the access point
+				In: true,
+				// Changing the access point won't affect the bucket itself
+				Out: false,
+			},
+		})
+	}
+
+	if awsItem.VpcConfiguration != nil && awsItem.VpcConfiguration.VpcId != nil {
+		// +overmind:link ec2-vpc
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "ec2-vpc",
+				Method: sdp.QueryMethod_GET,
+				Query:  *awsItem.VpcConfiguration.VpcId,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Access points restricted to a VPC can only be used from
+				// that VPC, so deleting it will affect the access point
+				In: true,
+				// The access point won't affect the VPC
+				Out: false,
+			},
+		})
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type s3-access-point
+// +overmind:descriptiveType S3 Access Point
+// +overmind:get Get an S3 access point by name
+// +overmind:list List all S3 access points
+// +overmind:search Search for S3 access points by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_s3_access_point.arn
+// +overmind:terraform:method SEARCH
+
+func NewAccessPointSource(config aws.Config, accountID string, region string) *sources.GetListSource[*s3control.GetAccessPointOutput, S3ControlClient, *s3control.Options] {
+	return &sources.GetListSource[*s3control.GetAccessPointOutput, S3ControlClient, *s3control.Options]{
+		ItemType:  "s3-access-point",
+		Client:    s3control.NewFromConfig(config),
+		AccountID: accountID,
+		Region:    region,
+		GetFunc: func(ctx context.Context, client S3ControlClient, scope, query string) (*s3control.GetAccessPointOutput, error) {
+			return accessPointGetFunc(ctx, client, accountID, query)
+		},
+		ListFunc: func(ctx context.Context, client S3ControlClient, scope string) ([]*s3control.GetAccessPointOutput, error) {
+			return accessPointListFunc(ctx, client, accountID)
+		},
+		ItemMapper: accessPointItemMapper,
+	}
+}
@@ -0,0 +1,104 @@
+package s3
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3control"
+	"github.com/aws/aws-sdk-go-v2/service/s3control/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+type testS3ControlClient struct{}
+
+func (c testS3ControlClient) GetAccessPoint(ctx context.Context, params *s3control.GetAccessPointInput, optFns ...func(*s3control.Options)) (*s3control.GetAccessPointOutput, error) {
+	return &s3control.GetAccessPointOutput{
+		AccessPointArn:  sources.PtrString("arn:aws:s3:eu-west-2:052392120703:accesspoint/" + *params.Name),
+		Alias:           sources.PtrString("uploads-abcdefghijklmnopqrstuvwxyz123-s3alias"),
+		Bucket:          sources.PtrString("uploads"),
+		BucketAccountId: sources.PtrString("111122223333"),
+		CreationDate:    sources.PtrTime(time.Now()),
+		Name:            params.Name,
+		NetworkOrigin:   types.NetworkOriginVpc,
+		VpcConfiguration: &types.VpcConfiguration{
+			VpcId: sources.PtrString("vpc-0d7892e00e573e701"),
+		},
+	}, nil
+}
+
+func (c testS3ControlClient) ListAccessPoints(ctx context.Context, params *s3control.ListAccessPointsInput, optFns ...func(*s3control.Options)) (*s3control.ListAccessPointsOutput, error) {
+	return &s3control.ListAccessPointsOutput{
+		AccessPointList: []types.AccessPoint{
+			{
+				Name:   sources.PtrString("uploads"),
+				Bucket: sources.PtrString("uploads"),
+			},
+			{
+				Name:   sources.PtrString("reports"),
+				Bucket: sources.PtrString("uploads"),
+			},
+		},
+	}, nil
+}
+
+func TestAccessPointListFunc(t *testing.T) {
+	accessPoints, err := accessPointListFunc(context.Background(), testS3ControlClient{}, "052392120703")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(accessPoints) != 2 {
+		t.Errorf("expected 2 access points, got %v", len(accessPoints))
+	}
+}
+
+func TestAccessPointItemMapper(t *testing.T) {
+	accessPoint, err := accessPointGetFunc(context.Background(), testS3ControlClient{}, "052392120703", "uploads")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := accessPointItemMapper("052392120703.eu-west-2", accessPoint)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "s3-bucket",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "uploads",
+			ExpectedScope:  "111122223333",
+		},
+		{
+			ExpectedType:   "ec2-vpc",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "vpc-0d7892e00e573e701",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestNewAccessPointSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewAccessPointSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -0,0 +1,189 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/smithy-go"
+	"github.com/overmindtech/sdp-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/semaphore"
+)
+
+// DefaultMaxParallel The default maximum number of S3 requests that can be in
+// flight at once across all buckets
+const DefaultMaxParallel = 50
+
+// configStatus The outcome of a request for one of a bucket's configurations
+type configStatus string
+
+const (
+	// The request succeeded
+	configStatusConfigured configStatus = "configured"
+	// The bucket doesn't have this configuration. S3 returns an error rather
+	// than an empty response in this case
+	configStatusNotConfigured configStatus = "notConfigured"
+	// We don't have permission to read this configuration
+	configStatusAccessDenied configStatus = "accessDenied"
+	// Anything else, such as throttling or network errors
+	configStatusFailed configStatus = "failed"
+)
+
+// errNoConfigurations Returned when listing a type of configuration succeeds
+// but the bucket doesn't have any, so that it is recorded the same way as a Get
+// that fails because the configuration doesn't exist
+var errNoConfigurations = errors.New("bucket has no configurations of this type")
+
+// notConfiguredCodes The error codes that S3 returns when a bucket doesn't have
+// a given configuration
+var notConfiguredCodes = map[string]bool{
+	"NoSuchBucketPolicy":                             true,
+	"NoSuchConfiguration":                            true,
+	"NoSuchCORSConfiguration":                        true,
+	"NoSuchLifecycleConfiguration":                   true,
+	"NoSuchTagSet":                                   true,
+	"NoSuchWebsiteConfiguration":                     true,
+	"OwnershipControlsNotFoundError":                 true,
+	"ReplicationConfigurationNotFoundError":          true,
+	"ServerSideEncryptionConfigurationNotFoundError": true,
+}
+
+// accessDeniedCodes The error codes that S3 returns when we don't have
+// permission to read a configuration
+var accessDeniedCodes = map[string]bool{
+	"AccessDenied":      true,
+	"AllAccessDisabled": true,
+}
+
+// classifyConfigError Works out the outcome of a request for a bucket's
+// configuration from the error that it returned
+func classifyConfigError(err error) configStatus {
+	if err == nil {
+		return configStatusConfigured
+	}
+
+	if errors.Is(err, errNoConfigurations) {
+		return configStatusNotConfigured
+	}
+
+	var apiErr smithy.APIError
+
+	if errors.As(err, &apiErr) {
+		if notConfiguredCodes[apiErr.ErrorCode()] {
+			return configStatusNotConfigured
+		}
+
+		if accessDeniedCodes[apiErr.ErrorCode()] {
+			return configStatusAccessDenied
+		}
+	}
+
+	var responseErr *awshttp.ResponseError
+
+	if errors.As(err, &responseErr) && responseErr.HTTPStatusCode() == 403 {
+		return configStatusAccessDenied
+	}
+
+	return configStatusFailed
+}
+
+// bucketConfigResults Records the outcome of each of the requests for a
+// bucket's configuration. This is safe for concurrent use
+type bucketConfigResults struct {
+	mu       sync.Mutex
+	statuses map[string]configStatus
+	errors   map[string]error
+}
+
+func newBucketConfigResults() *bucketConfigResults {
+	return &bucketConfigResults{
+		statuses: make(map[string]configStatus),
+		errors:   make(map[string]error),
+	}
+}
+
+// record Records the result of the request for a named configuration e.g.
+// "Policy" for GetBucketPolicy
+func (r *bucketConfigResults) record(name string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.statuses[name] = classifyConfigError(err)
+
+	if err != nil {
+		r.errors[name] = err
+	}
+}
+
+// names Returns the names of the recorded configurations in a stable order
+func (r *bucketConfigResults) names() []string {
+	names := make([]string, 0, len(r.statuses))
+
+	for name := range r.statuses {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// setAttributes Adds an `errorGettingX` attribute for each configuration that
+// couldn't be fetched, containing the reason
+func (r *bucketConfigResults) setAttributes(attributes *sdp.ItemAttributes) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, name := range r.names() {
+		if status := r.statuses[name]; status != configStatusConfigured {
+			if err := attributes.Set("errorGetting"+name, string(status)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// addToSpan Records the outcome of each request on the span, along with an
+// event for each request that was denied or failed
+func (r *bucketConfigResults) addToSpan(span trace.Span) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, name := range r.names() {
+		status := r.statuses[name]
+
+		span.SetAttributes(attribute.String("ovm.aws.s3.config."+name, string(status)))
+
+		switch status {
+		case configStatusAccessDenied, configStatusFailed:
+			span.AddEvent("Error getting bucket configuration", trace.WithAttributes(
+				attribute.String("ovm.aws.s3.config.name", name),
+				attribute.String("ovm.aws.s3.config.status", string(status)),
+				attribute.String("error", r.errors[name].Error()),
+			))
+		}
+	}
+}
+
+// acquireRequest Waits until a request to S3 can be made without exceeding
+// the limit. A nil limit allows unlimited requests
+func acquireRequest(ctx context.Context, limit *semaphore.Weighted) error {
+	if limit == nil {
+		return nil
+	}
+
+	return limit.Acquire(ctx, 1)
+}
+
+// releaseRequest Releases a request that was acquired with acquireRequest
+func releaseRequest(limit *semaphore.Weighted) {
+	if limit != nil {
+		limit.Release(1)
+	}
+}
@@ -0,0 +1,169 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdpcache"
+	"golang.org/x/sync/semaphore"
+)
+
+func TestClassifyConfigError(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Err      error
+		Expected configStatus
+	}{
+		{
+			Name:     "no error",
+			Err:      nil,
+			Expected: configStatusConfigured,
+		},
+		{
+			Name:     "no bucket policy",
+			Err:      &smithy.GenericAPIError{Code: "NoSuchBucketPolicy"},
+			Expected: configStatusNotConfigured,
+		},
+		{
+			Name:     "no encryption",
+			Err:      &smithy.GenericAPIError{Code: "ServerSideEncryptionConfigurationNotFoundError"},
+			Expected: configStatusNotConfigured,
+		},
+		{
+			Name:     "no configurations listed",
+			Err:      errNoConfigurations,
+			Expected: configStatusNotConfigured,
+		},
+		{
+			Name:     "access denied",
+			Err:      &smithy.GenericAPIError{Code: "AccessDenied"},
+			Expected: configStatusAccessDenied,
+		},
+		{
+			Name: "forbidden response",
+			Err: &awshttp.ResponseError{
+				ResponseError: &smithyhttp.ResponseError{
+					Response: &smithyhttp.Response{
+						Response: &http.Response{
+							StatusCode: 403,
+						},
+					},
+					Err: errors.New("forbidden"),
+				},
+			},
+			Expected: configStatusAccessDenied,
+		},
+		{
+			Name:     "throttled",
+			Err:      &smithy.GenericAPIError{Code: "SlowDown"},
+			Expected: configStatusFailed,
+		},
+		{
+			Name:     "other error",
+			Err:      errors.New("connection reset"),
+			Expected: configStatusFailed,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if status := classifyConfigError(c.Err); status != c.Expected {
+				t.Errorf("expected %v, got %v", c.Expected, status)
+			}
+		})
+	}
+}
+
+// testS3ConfigErrorClient A client that returns the errors S3 uses when
+// configuration is missing, denied or fails
+type testS3ConfigErrorClient struct {
+	TestS3Client
+}
+
+func (t testS3ConfigErrorClient) GetBucketPolicy(ctx context.Context, params *s3.GetBucketPolicyInput, optFns ...func(*s3.Options)) (*s3.GetBucketPolicyOutput, error) {
+	return nil, &smithy.GenericAPIError{Code: "NoSuchBucketPolicy"}
+}
+
+func (t testS3ConfigErrorClient) GetBucketReplication(ctx context.Context, params *s3.GetBucketReplicationInput, optFns ...func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
+	return nil, &smithy.GenericAPIError{Code: "AccessDenied"}
+}
+
+func (t testS3ConfigErrorClient) GetBucketWebsite(ctx context.Context, params *s3.GetBucketWebsiteInput, optFns ...func(*s3.Options)) (*s3.GetBucketWebsiteOutput, error) {
+	return nil, errors.New("connection reset")
+}
+
+func (t testS3ConfigErrorClient) ListBucketMetricsConfigurations(ctx context.Context, params *s3.ListBucketMetricsConfigurationsInput, optFns ...func(*s3.Options)) (*s3.ListBucketMetricsConfigurationsOutput, error) {
+	return &s3.ListBucketMetricsConfigurationsOutput{}, nil
+}
+
+func (t testS3ConfigErrorClient) ListBucketInventoryConfigurations(ctx context.Context, params *s3.ListBucketInventoryConfigurationsInput, optFns ...func(*s3.Options)) (*s3.ListBucketInventoryConfigurationsOutput, error) {
+	if params.ContinuationToken == nil {
+		return &s3.ListBucketInventoryConfigurationsOutput{
+			InventoryConfigurationList: []types.InventoryConfiguration{
+				{Id: sources.PtrString("first")},
+			},
+			IsTruncated:           sources.PtrBool(true),
+			NextContinuationToken: sources.PtrString("next"),
+		}, nil
+	}
+
+	return &s3.ListBucketInventoryConfigurationsOutput{
+		InventoryConfigurationList: []types.InventoryConfiguration{
+			{Id: sources.PtrString("second")},
+		},
+		IsTruncated: sources.PtrBool(false),
+	}, nil
+}
+
+func TestS3GetImplConfigErrors(t *testing.T) {
+	item, err := getImpl(context.Background(), sdpcache.NewCache(), testS3ConfigErrorClient{}, nil, semaphore.NewWeighted(1), "foo", "bar", false)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"errorGettingPolicy":      "notConfigured",
+		"errorGettingReplication": "accessDenied",
+		"errorGettingWebsite":     "failed",
+
+		"errorGettingMetricsConfigurations": "notConfigured",
+	}
+
+	for attribute, status := range expected {
+		value, err := item.GetAttributes().Get(attribute)
+
+		if err != nil {
+			t.Errorf("expected attribute %v: %v", attribute, err)
+			continue
+		}
+
+		if value != status {
+			t.Errorf("expected %v to be %v, got %v", attribute, status, value)
+		}
+	}
+
+	// Configuration that was returned shouldn't be reported
+	if _, err := item.GetAttributes().Get("errorGettingAcl"); err == nil {
+		t.Error("expected no errorGettingAcl attribute")
+	}
+
+	// Every page of configurations should be included
+	inventory, err := item.GetAttributes().Get("inventoryConfigurations")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if configs, ok := inventory.([]interface{}); !ok || len(configs) != 2 {
+		t.Errorf("expected 2 inventory configurations, got %v", inventory)
+	}
+}
@@ -0,0 +1,119 @@
+package s3
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/aws-source/sources/iampolicy"
+	"github.com/overmindtech/sdp-go"
+)
+
+// bucketRegion Returns the region that a bucket is in based on its location
+// constraint. Buckets in us-east-1 have an empty location constraint, and EU
+// is a legacy alias for eu-west-1
+func bucketRegion(location types.BucketLocationConstraint) string {
+	switch location {
+	case "":
+		return "us-east-1"
+	case types.BucketLocationConstraintEu:
+		return "eu-west-1"
+	default:
+		return string(location)
+	}
+}
+
+// kmsKeyQuery Returns a query for a KMS key that is referenced by either its
+// ID or ARN. Keys that are referenced by alias aren't linked since we can't
+// resolve them to a key
+func kmsKeyQuery(keyID string, accountID string, region string) *sdp.Query {
+	if strings.HasPrefix(keyID, "arn:") {
+		return iampolicy.ARNQuery(keyID, accountID)
+	}
+
+	if keyID == "" || strings.HasPrefix(keyID, "alias/") {
+		return nil
+	}
+
+	return &sdp.Query{
+		Type:   "kms-key",
+		Method: sdp.QueryMethod_GET,
+		Query:  keyID,
+		Scope:  sources.FormatScope(accountID, region),
+	}
+}
+
+// bucketPolicyLinks Returns links to the IAM roles and users that a bucket
+// policy grants access to, and the VPC endpoints that access is restricted to
+// using the aws:SourceVpce condition key. Only Allow statements are used, and
+// only positive operators for the condition, since exclusions don't mean the
+// bucket can be reached through the endpoint. Policies that can't be parsed
+// are ignored
+func bucketPolicyLinks(policy string, accountID string, region string) []*sdp.LinkedItemQuery {
+	links := make([]*sdp.LinkedItemQuery, 0)
+
+	document, err := iampolicy.Parse(policy)
+
+	if err != nil {
+		return links
+	}
+
+	seen := make(map[string]bool)
+
+	for _, statement := range document.Statement {
+		if statement.Effect != iampolicy.EffectAllow {
+			continue
+		}
+
+		for _, query := range iampolicy.PrincipalQueries(statement.Principal) {
+			switch query.GetType() {
+			case "iam-role", "iam-user":
+			default:
+				continue
+			}
+
+			if seen[query.GetType()+query.GetQuery()] {
+				continue
+			}
+
+			seen[query.GetType()+query.GetQuery()] = true
+
+			links = append(links, &sdp.LinkedItemQuery{
+				Query: query,
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the principal won't affect the bucket
+					In: false,
+					// Changing the policy will affect what the principal can
+					// do to the bucket
+					Out: true,
+				},
+			})
+		}
+
+		for _, endpointID := range statement.Condition.ValuesFor("aws:SourceVpce", iampolicy.StringEquals, iampolicy.StringLike) {
+			if iampolicy.IsWildcard(endpointID) || seen[endpointID] {
+				continue
+			}
+
+			seen[endpointID] = true
+
+			links = append(links, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "ec2-vpc-endpoint",
+					Method: sdp.QueryMethod_GET,
+					Query:  endpointID,
+					Scope:  sources.FormatScope(accountID, region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Deleting the endpoint will stop the bucket being
+					// accessible from the VPC
+					In: true,
+					// The bucket won't affect the endpoint
+					Out: false,
+				},
+			})
+		}
+	}
+
+	return links
+}
@@ -0,0 +1,166 @@
+package s3
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3control"
+	"github.com/aws/aws-sdk-go-v2/service/s3control/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+// multiRegionControlRegion Requests for multi-region access points must be
+// sent to us-west-2, regardless of which regions the access point is in
+const multiRegionControlRegion = "us-west-2"
+
+// multiRegionAccessPointGetFunc Gets a multi-region access point by name. ARNs
+// for these contain the alias rather than the name e.g.
+// arn:aws:s3::123456789012:accesspoint/mfzwi23gnjvgw.mrap, so if an alias is
+// supplied then the access points are listed to find it
+func multiRegionAccessPointGetFunc(ctx context.Context, client S3ControlClient, accountID string, query string) (*types.MultiRegionAccessPointReport, error) {
+	if strings.HasSuffix(query, ".mrap") {
+		accessPoints, err := multiRegionAccessPointListFunc(ctx, client, accountID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, accessPoint := range accessPoints {
+			if accessPoint.Alias != nil && *accessPoint.Alias == query {
+				return accessPoint, nil
+			}
+		}
+
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: "no multi-region access point found with alias " + query,
+		}
+	}
+
+	out, err := client.GetMultiRegionAccessPoint(ctx, &s3control.GetMultiRegionAccessPointInput{
+		AccountId: &accountID,
+		Name:      &query,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if out.AccessPoint == nil {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: "access point was nil",
+		}
+	}
+
+	return out.AccessPoint, nil
+}
+
+func multiRegionAccessPointListFunc(ctx context.Context, client S3ControlClient, accountID string) ([]*types.MultiRegionAccessPointReport, error) {
+	accessPoints := make([]*types.MultiRegionAccessPointReport, 0)
+
+	paginator := s3control.NewListMultiRegionAccessPointsPaginator(client, &s3control.ListMultiRegionAccessPointsInput{
+		AccountId: &accountID,
+	})
+
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range out.AccessPoints {
+			accessPoints = append(accessPoints, &out.AccessPoints[i])
+		}
+	}
+
+	return accessPoints, nil
+}
+
+func multiRegionAccessPointItemMapper(scope string, awsItem *types.MultiRegionAccessPointReport) (*sdp.Item, error) {
+	attributes, err := sources.ToAttributesCase(awsItem)
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := sdp.Item{
+		Type:            "s3-multi-region-access-point",
+		UniqueAttribute: "name",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	switch awsItem.Status {
+	case types.MultiRegionAccessPointStatusReady:
+		item.Health = sdp.Health_HEALTH_OK.Enum()
+	case types.MultiRegionAccessPointStatusCreating, types.MultiRegionAccessPointStatusDeleting:
+		item.Health = sdp.Health_HEALTH_PENDING.Enum()
+	case types.MultiRegionAccessPointStatusPartiallyCreated, types.MultiRegionAccessPointStatusPartiallyDeleted:
+		item.Health = sdp.Health_HEALTH_WARNING.Enum()
+	case types.MultiRegionAccessPointStatusInconsistentAcrossRegions:
+		item.Health = sdp.Health_HEALTH_ERROR.Enum()
+	}
+
+	for _, region := range awsItem.Regions {
+		if region.Bucket == nil {
+			continue
+		}
+
+		// The buckets can be owned by other accounts
+		bucketAccount := scope
+
+		if region.BucketAccountId != nil {
+			bucketAccount = *region.BucketAccountId
+		}
+
+		// +overmind:link s3-bucket
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "s3-bucket",
+				Method: sdp.QueryMethod_GET,
+				Query:  *region.Bucket,
+				Scope:  sources.FormatScope(bucketAccount, ""),
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Changing the bucket will affect requests routed to it
+				In: true,
+				// Changing the access point won't affect the bucket itself
+				Out: false,
+			},
+		})
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type s3-multi-region-access-point
+// +overmind:descriptiveType S3 Multi-Region Access Point
+// +overmind:get Get a multi-region access point by name or alias
+// +overmind:list List all multi-region access points
+// +overmind:search Search for multi-region access points by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_s3control_multi_region_access_point.alias
+
+// NewMultiRegionAccessPointSource Multi-region access points are global, so
+// this source uses the account as its scope, the same as S3 buckets
+func NewMultiRegionAccessPointSource(config aws.Config, accountID string) *sources.GetListSource[*types.MultiRegionAccessPointReport, S3ControlClient, *s3control.Options] {
+	return &sources.GetListSource[*types.MultiRegionAccessPointReport, S3ControlClient, *s3control.Options]{
+		ItemType: "s3-multi-region-access-point",
+		Client: s3control.NewFromConfig(config, func(o *s3control.Options) {
+			o.Region = multiRegionControlRegion
+		}),
+		AccountID: accountID,
+		GetFunc: func(ctx context.Context, client S3ControlClient, scope, query string) (*types.MultiRegionAccessPointReport, error) {
+			return multiRegionAccessPointGetFunc(ctx, client, accountID, query)
+		},
+		ListFunc: func(ctx context.Context, client S3ControlClient, scope string) ([]*types.MultiRegionAccessPointReport, error) {
+			return multiRegionAccessPointListFunc(ctx, client, accountID)
+		},
+		ItemMapper: multiRegionAccessPointItemMapper,
+	}
+}
@@ -0,0 +1,128 @@
+package s3
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3control"
+	"github.com/aws/aws-sdk-go-v2/service/s3control/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+var testMultiRegionAccessPoint = types.MultiRegionAccessPointReport{
+	Alias:     sources.PtrString("mfzwi23gnjvgw.mrap"),
+	CreatedAt: sources.PtrTime(time.Now()),
+	Name:      sources.PtrString("global-assets"),
+	Status:    types.MultiRegionAccessPointStatusReady,
+	Regions: []types.RegionReport{
+		{
+			Bucket: sources.PtrString("assets-eu-west-2"),
+			Region: sources.PtrString("eu-west-2"),
+		},
+		{
+			Bucket:          sources.PtrString("assets-us-east-1"),
+			BucketAccountId: sources.PtrString("111122223333"),
+			Region:          sources.PtrString("us-east-1"),
+		},
+	},
+}
+
+func (c testS3ControlClient) GetMultiRegionAccessPoint(ctx context.Context, params *s3control.GetMultiRegionAccessPointInput, optFns ...func(*s3control.Options)) (*s3control.GetMultiRegionAccessPointOutput, error) {
+	accessPoint := testMultiRegionAccessPoint
+
+	return &s3control.GetMultiRegionAccessPointOutput{
+		AccessPoint: &accessPoint,
+	}, nil
+}
+
+func (c testS3ControlClient) ListMultiRegionAccessPoints(ctx context.Context, params *s3control.ListMultiRegionAccessPointsInput, optFns ...func(*s3control.Options)) (*s3control.ListMultiRegionAccessPointsOutput, error) {
+	return &s3control.ListMultiRegionAccessPointsOutput{
+		AccessPoints: []types.MultiRegionAccessPointReport{
+			testMultiRegionAccessPoint,
+		},
+	}, nil
+}
+
+func TestMultiRegionAccessPointGetFunc(t *testing.T) {
+	t.Run("by name", func(t *testing.T) {
+		accessPoint, err := multiRegionAccessPointGetFunc(context.Background(), testS3ControlClient{}, "052392120703", "global-assets")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if *accessPoint.Name != "global-assets" {
+			t.Errorf("expected name global-assets, got %v", *accessPoint.Name)
+		}
+	})
+
+	t.Run("by alias", func(t *testing.T) {
+		accessPoint, err := multiRegionAccessPointGetFunc(context.Background(), testS3ControlClient{}, "052392120703", "mfzwi23gnjvgw.mrap")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if *accessPoint.Name != "global-assets" {
+			t.Errorf("expected name global-assets, got %v", *accessPoint.Name)
+		}
+	})
+
+	t.Run("with an unknown alias", func(t *testing.T) {
+		_, err := multiRegionAccessPointGetFunc(context.Background(), testS3ControlClient{}, "052392120703", "unknown.mrap")
+
+		if err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
+func TestMultiRegionAccessPointItemMapper(t *testing.T) {
+	accessPoint := testMultiRegionAccessPoint
+
+	item, err := multiRegionAccessPointItemMapper("052392120703", &accessPoint)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if item.GetHealth() != sdp.Health_HEALTH_OK {
+		t.Errorf("expected health to be OK, got %v", item.GetHealth())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "s3-bucket",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "assets-eu-west-2",
+			ExpectedScope:  "052392120703",
+		},
+		{
+			ExpectedType:   "s3-bucket",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "assets-us-east-1",
+			ExpectedScope:  "111122223333",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestNewMultiRegionAccessPointSource(t *testing.T) {
+	config, account, _ := sources.GetAutoConfig(t)
+
+	source := NewMultiRegionAccessPointSource(config, account)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -0,0 +1,175 @@
+package s3
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3control"
+	"github.com/aws/aws-sdk-go-v2/service/s3control/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/aws-source/sources/iampolicy"
+	"github.com/overmindtech/sdp-go"
+)
+
+// ObjectLambdaAccessPointDetails An Object Lambda access point along with its
+// configuration, which is returned by a separate API
+type ObjectLambdaAccessPointDetails struct {
+	AccessPoint   *s3control.GetAccessPointForObjectLambdaOutput
+	Configuration *types.ObjectLambdaConfiguration
+}
+
+func objectLambdaAccessPointGetFunc(ctx context.Context, client S3ControlClient, accountID string, query string) (*ObjectLambdaAccessPointDetails, error) {
+	out, err := client.GetAccessPointForObjectLambda(ctx, &s3control.GetAccessPointForObjectLambdaInput{
+		AccountId: &accountID,
+		Name:      &query,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := client.GetAccessPointConfigurationForObjectLambda(ctx, &s3control.GetAccessPointConfigurationForObjectLambdaInput{
+		AccountId: &accountID,
+		Name:      &query,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &ObjectLambdaAccessPointDetails{
+		AccessPoint:   out,
+		Configuration: config.Configuration,
+	}, nil
+}
+
+func objectLambdaAccessPointListFunc(ctx context.Context, client S3ControlClient, accountID string) ([]*ObjectLambdaAccessPointDetails, error) {
+	accessPoints := make([]*ObjectLambdaAccessPointDetails, 0)
+
+	paginator := s3control.NewListAccessPointsForObjectLambdaPaginator(client, &s3control.ListAccessPointsForObjectLambdaInput{
+		AccountId: &accountID,
+	})
+
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, accessPoint := range out.ObjectLambdaAccessPointList {
+			if accessPoint.Name == nil {
+				continue
+			}
+
+			details, err := objectLambdaAccessPointGetFunc(ctx, client, accountID, *accessPoint.Name)
+
+			if err != nil {
+				return nil, err
+			}
+
+			accessPoints = append(accessPoints, details)
+		}
+	}
+
+	return accessPoints, nil
+}
+
+func objectLambdaAccessPointItemMapper(scope string, awsItem *ObjectLambdaAccessPointDetails) (*sdp.Item, error) {
+	enrichedAccessPoint := struct {
+		*s3control.GetAccessPointForObjectLambdaOutput
+		Configuration *types.ObjectLambdaConfiguration
+	}{
+		GetAccessPointForObjectLambdaOutput: awsItem.AccessPoint,
+		Configuration:                       awsItem.Configuration,
+	}
+
+	attributes, err := sources.ToAttributesCase(enrichedAccessPoint)
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := sdp.Item{
+		Type:            "s3-object-lambda-access-point",
+		UniqueAttribute: "name",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	if awsItem.Configuration == nil {
+		return &item, nil
+	}
+
+	if awsItem.Configuration.SupportingAccessPoint != nil {
+		if query := iampolicy.ARNQuery(*awsItem.Configuration.SupportingAccessPoint, ""); query != nil {
+			// +overmind:link s3-access-point
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: query,
+				BlastPropagation: &sdp.BlastPropagation{
+					// Objects are fetched through the supporting access point,
+					// so changes to it will affect this one
+					In: true,
+					// Changing this access point won't affect the supporting
+					// access point
+					Out: false,
+				},
+			})
+		}
+	}
+
+	for _, transformation := range awsItem.Configuration.TransformationConfigurations {
+		lambda, ok := transformation.ContentTransformation.(*types.ObjectLambdaContentTransformationMemberAwsLambda)
+
+		if !ok || lambda.Value.FunctionArn == nil {
+			continue
+		}
+
+		if a, err := sources.ParseARN(*lambda.Value.FunctionArn); err == nil {
+			// +overmind:link lambda-function
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "lambda-function",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *lambda.Value.FunctionArn,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// The function transforms every object that is returned,
+					// so changing it will affect the access point
+					In: true,
+					// The access point won't affect the function
+					Out: false,
+				},
+			})
+		}
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type s3-object-lambda-access-point
+// +overmind:descriptiveType S3 Object Lambda Access Point
+// +overmind:get Get an Object Lambda access point by name
+// +overmind:list List all Object Lambda access points
+// +overmind:search Search for Object Lambda access points by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_s3control_object_lambda_access_point.arn
+// +overmind:terraform:method SEARCH
+
+func NewObjectLambdaAccessPointSource(config aws.Config, accountID string, region string) *sources.GetListSource[*ObjectLambdaAccessPointDetails, S3ControlClient, *s3control.Options] {
+	return &sources.GetListSource[*ObjectLambdaAccessPointDetails, S3ControlClient, *s3control.Options]{
+		ItemType:  "s3-object-lambda-access-point",
+		Client:    s3control.NewFromConfig(config),
+		AccountID: accountID,
+		Region:    region,
+		GetFunc: func(ctx context.Context, client S3ControlClient, scope, query string) (*ObjectLambdaAccessPointDetails, error) {
+			return objectLambdaAccessPointGetFunc(ctx, client, accountID, query)
+		},
+		ListFunc: func(ctx context.Context, client S3ControlClient, scope string) ([]*ObjectLambdaAccessPointDetails, error) {
+			return objectLambdaAccessPointListFunc(ctx, client, accountID)
+		},
+		ItemMapper: objectLambdaAccessPointItemMapper,
+	}
+}
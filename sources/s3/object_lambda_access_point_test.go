@@ -0,0 +1,118 @@
+package s3
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3control"
+	"github.com/aws/aws-sdk-go-v2/service/s3control/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testS3ControlClient) GetAccessPointForObjectLambda(ctx context.Context, params *s3control.GetAccessPointForObjectLambdaInput, optFns ...func(*s3control.Options)) (*s3control.GetAccessPointForObjectLambdaOutput, error) {
+	return &s3control.GetAccessPointForObjectLambdaOutput{
+		Name:         params.Name,
+		CreationDate: sources.PtrTime(time.Now()),
+		Alias: &types.ObjectLambdaAccessPointAlias{
+			Value:  sources.PtrString("redacted-abcdefghijklmnopqrstuvwxyz--ol-s3"),
+			Status: types.ObjectLambdaAccessPointAliasStatusReady,
+		},
+	}, nil
+}
+
+func (c testS3ControlClient) GetAccessPointConfigurationForObjectLambda(ctx context.Context, params *s3control.GetAccessPointConfigurationForObjectLambdaInput, optFns ...func(*s3control.Options)) (*s3control.GetAccessPointConfigurationForObjectLambdaOutput, error) {
+	return &s3control.GetAccessPointConfigurationForObjectLambdaOutput{
+		Configuration: &types.ObjectLambdaConfiguration{
+			SupportingAccessPoint: sources.PtrString("arn:aws:s3:eu-west-2:052392120703:accesspoint/uploads"),
+			TransformationConfigurations: []types.ObjectLambdaTransformationConfiguration{
+				{
+					Actions: []types.ObjectLambdaTransformationConfigurationAction{
+						types.ObjectLambdaTransformationConfigurationActionGetObject,
+					},
+					ContentTransformation: &types.ObjectLambdaContentTransformationMemberAwsLambda{
+						Value: types.AwsLambdaTransformation{
+							FunctionArn: sources.PtrString("arn:aws:lambda:eu-west-2:052392120703:function:redact"),
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func (c testS3ControlClient) ListAccessPointsForObjectLambda(ctx context.Context, params *s3control.ListAccessPointsForObjectLambdaInput, optFns ...func(*s3control.Options)) (*s3control.ListAccessPointsForObjectLambdaOutput, error) {
+	return &s3control.ListAccessPointsForObjectLambdaOutput{
+		ObjectLambdaAccessPointList: []types.ObjectLambdaAccessPoint{
+			{
+				Name:                       sources.PtrString("redacted"),
+				ObjectLambdaAccessPointArn: sources.PtrString("arn:aws:s3-object-lambda:eu-west-2:052392120703:accesspoint/redacted"),
+			},
+		},
+	}, nil
+}
+
+func TestObjectLambdaAccessPointListFunc(t *testing.T) {
+	accessPoints, err := objectLambdaAccessPointListFunc(context.Background(), testS3ControlClient{}, "052392120703")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(accessPoints) != 1 {
+		t.Fatalf("expected 1 access point, got %v", len(accessPoints))
+	}
+
+	if accessPoints[0].Configuration == nil {
+		t.Error("expected configuration to be populated")
+	}
+}
+
+func TestObjectLambdaAccessPointItemMapper(t *testing.T) {
+	accessPoint, err := objectLambdaAccessPointGetFunc(context.Background(), testS3ControlClient{}, "052392120703", "redacted")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := objectLambdaAccessPointItemMapper("052392120703.eu-west-2", accessPoint)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = item.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "s3-access-point",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:s3:eu-west-2:052392120703:accesspoint/uploads",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+		{
+			ExpectedType:   "lambda-function",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:lambda:eu-west-2:052392120703:function:redact",
+			ExpectedScope:  "052392120703.eu-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestNewObjectLambdaAccessPointSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewObjectLambdaAccessPointSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
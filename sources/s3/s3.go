@@ -3,6 +3,8 @@ package s3
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
 	"sync"
 	"time"
 
@@ -11,17 +13,27 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/getsentry/sentry-go"
 	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/aws-source/sources/iampolicy"
 	"github.com/overmindtech/sdp-go"
 	"github.com/overmindtech/sdpcache"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/semaphore"
 )
 
 const CacheDuration = 10 * time.Minute
 
-// NewS3Source Creates a new S3 source
-func NewS3Source(config aws.Config, accountID string) *S3Source {
+// NewS3Source Creates a new S3 source. maxParallel is the maximum number of
+// requests to S3 that can be in flight at once across all buckets, if this is
+// zero then DefaultMaxParallel is used
+func NewS3Source(config aws.Config, accountID string, maxParallel int) *S3Source {
+	if maxParallel <= 0 {
+		maxParallel = DefaultMaxParallel
+	}
+
 	return &S3Source{
-		config:    config,
-		accountID: accountID,
+		config:       config,
+		accountID:    accountID,
+		requestLimit: semaphore.NewWeighted(int64(maxParallel)),
 	}
 }
 
@@ -29,7 +41,7 @@ func NewS3Source(config aws.Config, accountID string) *S3Source {
 // +overmind:descriptiveType S3 Bucket
 // +overmind:get Get an S3 bucket by name
 // +overmind:list List all S3 buckets
-// +overmind:search Search for S3 buckets by ARN
+// +overmind:search Search for S3 buckets by ARN, or by region (e.g. `eu-west-2` or `{account}.{region}`) to find all buckets in that region
 // +overmind:group AWS
 // +overmind:terraform:queryMap aws_s3_bucket_acl.bucket
 // +overmind:terraform:queryMap aws_s3_bucket_analytics_configuration.bucket
@@ -67,6 +79,15 @@ type S3Source struct {
 	clientCreated bool
 	clientMutex   sync.Mutex
 
+	// regionalClients Clients for each region that buckets have been found
+	// in, keyed by region. These are protected by clientMutex
+	regionalClients map[string]*s3.Client
+
+	// requestLimit Limits the number of requests to S3 that can be in flight
+	// at once. Each bucket requires around 20 requests so without this large
+	// accounts can easily be throttled
+	requestLimit *semaphore.Weighted
+
 	CacheDuration time.Duration   // How long to cache items for
 	cache         *sdpcache.Cache // The sdpcache of this source
 	cacheInitMu   sync.Mutex      // Mutex to ensure cache is only initialised once
@@ -102,6 +123,30 @@ func (s *S3Source) Client() *s3.Client {
 	return s.client
 }
 
+// RegionalClient Returns a client that sends requests to the given region.
+// Bucket configuration must be requested from the region that the bucket is
+// in, otherwise requests for buckets in opt-in regions will fail
+func (s *S3Source) RegionalClient(region string) S3Client {
+	s.clientMutex.Lock()
+	defer s.clientMutex.Unlock()
+
+	if client, ok := s.regionalClients[region]; ok {
+		return client
+	}
+
+	if s.regionalClients == nil {
+		s.regionalClients = make(map[string]*s3.Client)
+	}
+
+	client := s3.NewFromConfig(s.config, func(o *s3.Options) {
+		o.Region = region
+	})
+
+	s.regionalClients[region] = client
+
+	return client
+}
+
 // Type The type of items that this source is capable of finding
 func (s *S3Source) Type() string {
 	// +overmind:type s3-bucket
@@ -125,15 +170,11 @@ func (s *S3Source) Scopes() []string {
 type S3Client interface {
 	ListBuckets(ctx context.Context, params *s3.ListBucketsInput, optFns ...func(*s3.Options)) (*s3.ListBucketsOutput, error)
 	GetBucketAcl(ctx context.Context, params *s3.GetBucketAclInput, optFns ...func(*s3.Options)) (*s3.GetBucketAclOutput, error)
-	GetBucketAnalyticsConfiguration(ctx context.Context, params *s3.GetBucketAnalyticsConfigurationInput, optFns ...func(*s3.Options)) (*s3.GetBucketAnalyticsConfigurationOutput, error)
 	GetBucketCors(ctx context.Context, params *s3.GetBucketCorsInput, optFns ...func(*s3.Options)) (*s3.GetBucketCorsOutput, error)
 	GetBucketEncryption(ctx context.Context, params *s3.GetBucketEncryptionInput, optFns ...func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error)
-	GetBucketIntelligentTieringConfiguration(ctx context.Context, params *s3.GetBucketIntelligentTieringConfigurationInput, optFns ...func(*s3.Options)) (*s3.GetBucketIntelligentTieringConfigurationOutput, error)
-	GetBucketInventoryConfiguration(ctx context.Context, params *s3.GetBucketInventoryConfigurationInput, optFns ...func(*s3.Options)) (*s3.GetBucketInventoryConfigurationOutput, error)
 	GetBucketLifecycleConfiguration(ctx context.Context, params *s3.GetBucketLifecycleConfigurationInput, optFns ...func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error)
 	GetBucketLocation(ctx context.Context, params *s3.GetBucketLocationInput, optFns ...func(*s3.Options)) (*s3.GetBucketLocationOutput, error)
 	GetBucketLogging(ctx context.Context, params *s3.GetBucketLoggingInput, optFns ...func(*s3.Options)) (*s3.GetBucketLoggingOutput, error)
-	GetBucketMetricsConfiguration(ctx context.Context, params *s3.GetBucketMetricsConfigurationInput, optFns ...func(*s3.Options)) (*s3.GetBucketMetricsConfigurationOutput, error)
 	GetBucketNotificationConfiguration(ctx context.Context, params *s3.GetBucketNotificationConfigurationInput, optFns ...func(*s3.Options)) (*s3.GetBucketNotificationConfigurationOutput, error)
 	GetBucketOwnershipControls(ctx context.Context, params *s3.GetBucketOwnershipControlsInput, optFns ...func(*s3.Options)) (*s3.GetBucketOwnershipControlsOutput, error)
 	GetBucketPolicy(ctx context.Context, params *s3.GetBucketPolicyInput, optFns ...func(*s3.Options)) (*s3.GetBucketPolicyOutput, error)
@@ -143,6 +184,10 @@ type S3Client interface {
 	GetBucketTagging(ctx context.Context, params *s3.GetBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.GetBucketTaggingOutput, error)
 	GetBucketVersioning(ctx context.Context, params *s3.GetBucketVersioningInput, optFns ...func(*s3.Options)) (*s3.GetBucketVersioningOutput, error)
 	GetBucketWebsite(ctx context.Context, params *s3.GetBucketWebsiteInput, optFns ...func(*s3.Options)) (*s3.GetBucketWebsiteOutput, error)
+	ListBucketAnalyticsConfigurations(ctx context.Context, params *s3.ListBucketAnalyticsConfigurationsInput, optFns ...func(*s3.Options)) (*s3.ListBucketAnalyticsConfigurationsOutput, error)
+	ListBucketIntelligentTieringConfigurations(ctx context.Context, params *s3.ListBucketIntelligentTieringConfigurationsInput, optFns ...func(*s3.Options)) (*s3.ListBucketIntelligentTieringConfigurationsOutput, error)
+	ListBucketInventoryConfigurations(ctx context.Context, params *s3.ListBucketInventoryConfigurationsInput, optFns ...func(*s3.Options)) (*s3.ListBucketInventoryConfigurationsOutput, error)
+	ListBucketMetricsConfigurations(ctx context.Context, params *s3.ListBucketMetricsConfigurationsInput, optFns ...func(*s3.Options)) (*s3.ListBucketMetricsConfigurationsOutput, error)
 }
 
 // Bucket represents an actual s3 bucket, with all of the extra requests
@@ -151,16 +196,16 @@ type Bucket struct {
 	// ListBuckets
 	types.Bucket
 
+	// Region The region that the bucket is in. Unlike the location constraint
+	// this is always populated, and is never a legacy alias such as EU
+	Region string
+
 	s3.GetBucketAclOutput
-	s3.GetBucketAnalyticsConfigurationOutput
 	s3.GetBucketCorsOutput
 	s3.GetBucketEncryptionOutput
-	s3.GetBucketIntelligentTieringConfigurationOutput
-	s3.GetBucketInventoryConfigurationOutput
 	s3.GetBucketLifecycleConfigurationOutput
 	s3.GetBucketLocationOutput
 	s3.GetBucketLoggingOutput
-	s3.GetBucketMetricsConfigurationOutput
 	s3.GetBucketNotificationConfigurationOutput
 	s3.GetBucketOwnershipControlsOutput
 	s3.GetBucketPolicyOutput
@@ -169,6 +214,14 @@ type Bucket struct {
 	s3.GetBucketRequestPaymentOutput
 	s3.GetBucketVersioningOutput
 	s3.GetBucketWebsiteOutput
+
+	// A bucket can have many analytics, intelligent tiering, inventory and
+	// metrics configurations, each with its own ID, so these are listed rather
+	// than fetched individually
+	AnalyticsConfigurations          []types.AnalyticsConfiguration
+	IntelligentTieringConfigurations []types.IntelligentTieringConfiguration
+	InventoryConfigurations          []types.InventoryConfiguration
+	MetricsConfigurations            []types.MetricsConfiguration
 }
 
 // Get Get a single item with a given scope and query. The item returned
@@ -186,10 +239,14 @@ func (s *S3Source) Get(ctx context.Context, scope string, query string, ignoreCa
 	}
 
 	s.ensureCache()
-	return getImpl(ctx, s.cache, s.Client(), scope, query, ignoreCache)
+	return getImpl(ctx, s.cache, s.Client(), s.RegionalClient, s.requestLimit, scope, query, ignoreCache)
 }
 
-func getImpl(ctx context.Context, cache *sdpcache.Cache, client S3Client, scope string, query string, ignoreCache bool) (*sdp.Item, error) {
+// regionalClientFunc Returns a client that sends requests to the given region.
+// When this is nil the default client is used for all requests
+type regionalClientFunc func(region string) S3Client
+
+func getImpl(ctx context.Context, cache *sdpcache.Cache, client S3Client, regionalClient regionalClientFunc, limit *semaphore.Weighted, scope string, query string, ignoreCache bool) (*sdp.Item, error) {
 	cacheHit, ck, cachedItems, qErr := cache.Lookup(ctx, "aws-s3-source", sdp.QueryMethod_GET, scope, "s3-bucket", query, ignoreCache)
 	if qErr != nil {
 		return nil, qErr
@@ -208,20 +265,37 @@ func getImpl(ctx context.Context, cache *sdpcache.Cache, client S3Client, scope
 
 	bucketName := sources.PtrString(query)
 
+	if err = acquireRequest(ctx, limit); err != nil {
+		return nil, sdp.NewQueryError(err)
+	}
+
 	location, err = client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{
 		Bucket: bucketName,
 	})
 
+	releaseRequest(limit)
+
 	if err != nil {
 		err = sources.WrapAWSError(err)
 		cache.StoreError(err, CacheDuration, ck)
 		return nil, err
 	}
 
+	// The scope of a bucket is just the account ID, but the bucket's
+	// configuration needs to be requested from its own region, and VPC
+	// endpoints and KMS keys are regional so we need to know which region the
+	// bucket is in
+	region := bucketRegion(location.LocationConstraint)
+
+	if regionalClient != nil {
+		client = regionalClient(region)
+	}
+
 	bucket := Bucket{
 		Bucket: types.Bucket{
 			Name: bucketName,
 		},
+		Region:                  region,
 		GetBucketLocationOutput: *location,
 	}
 
@@ -230,156 +304,234 @@ func getImpl(ctx context.Context, cache *sdpcache.Cache, client S3Client, scope
 	// do about it
 	var tagging *s3.GetBucketTaggingOutput
 
-	wg.Add(1)
-	go func() {
-		defer sentry.Recover()
-		defer wg.Done()
-		if acl, err := client.GetBucketAcl(ctx, &s3.GetBucketAclInput{Bucket: bucketName}); err == nil {
+	results := newBucketConfigResults()
+
+	// fetch Runs a request for one of the bucket's configurations in the
+	// background, waiting for the limit and recording the result
+	fetch := func(name string, get func() error) {
+		wg.Add(1)
+		go func() {
+			defer sentry.Recover()
+			defer wg.Done()
+
+			if err := acquireRequest(ctx, limit); err != nil {
+				results.record(name, err)
+				return
+			}
+			defer releaseRequest(limit)
+
+			results.record(name, get())
+		}()
+	}
+
+	fetch("Acl", func() error {
+		acl, err := client.GetBucketAcl(ctx, &s3.GetBucketAclInput{Bucket: bucketName})
+		if err == nil {
 			bucket.GetBucketAclOutput = *acl
 		}
-	}()
-	wg.Add(1)
-	go func() {
-		defer sentry.Recover()
-		defer wg.Done()
-		if analyticsConfiguration, err := client.GetBucketAnalyticsConfiguration(ctx, &s3.GetBucketAnalyticsConfigurationInput{Bucket: bucketName}); err == nil {
-			bucket.GetBucketAnalyticsConfigurationOutput = *analyticsConfiguration
-		}
-	}()
-	wg.Add(1)
-	go func() {
-		defer sentry.Recover()
-		defer wg.Done()
-		if cors, err := client.GetBucketCors(ctx, &s3.GetBucketCorsInput{Bucket: bucketName}); err == nil {
+		return err
+	})
+	fetch("AnalyticsConfigurations", func() error {
+		input := &s3.ListBucketAnalyticsConfigurationsInput{Bucket: bucketName}
+
+		for {
+			out, err := client.ListBucketAnalyticsConfigurations(ctx, input)
+			if err != nil {
+				return err
+			}
+
+			bucket.AnalyticsConfigurations = append(bucket.AnalyticsConfigurations, out.AnalyticsConfigurationList...)
+
+			if out.IsTruncated == nil || !*out.IsTruncated || out.NextContinuationToken == nil {
+				break
+			}
+
+			input.ContinuationToken = out.NextContinuationToken
+		}
+
+		if len(bucket.AnalyticsConfigurations) == 0 {
+			return errNoConfigurations
+		}
+
+		return nil
+	})
+	fetch("Cors", func() error {
+		cors, err := client.GetBucketCors(ctx, &s3.GetBucketCorsInput{Bucket: bucketName})
+		if err == nil {
 			bucket.GetBucketCorsOutput = *cors
 		}
-	}()
-	wg.Add(1)
-	go func() {
-		defer sentry.Recover()
-		defer wg.Done()
-		if encryption, err := client.GetBucketEncryption(ctx, &s3.GetBucketEncryptionInput{Bucket: bucketName}); err == nil {
+		return err
+	})
+	fetch("Encryption", func() error {
+		encryption, err := client.GetBucketEncryption(ctx, &s3.GetBucketEncryptionInput{Bucket: bucketName})
+		if err == nil {
 			bucket.GetBucketEncryptionOutput = *encryption
 		}
-	}()
-	wg.Add(1)
-	go func() {
-		defer sentry.Recover()
-		defer wg.Done()
-		if intelligentTieringConfiguration, err := client.GetBucketIntelligentTieringConfiguration(ctx, &s3.GetBucketIntelligentTieringConfigurationInput{Bucket: bucketName}); err == nil {
-			bucket.GetBucketIntelligentTieringConfigurationOutput = *intelligentTieringConfiguration
-		}
-	}()
-	wg.Add(1)
-	go func() {
-		defer sentry.Recover()
-		defer wg.Done()
-		if inventoryConfiguration, err := client.GetBucketInventoryConfiguration(ctx, &s3.GetBucketInventoryConfigurationInput{Bucket: bucketName}); err == nil {
-			bucket.GetBucketInventoryConfigurationOutput = *inventoryConfiguration
-		}
-	}()
-	wg.Add(1)
-	go func() {
-		defer sentry.Recover()
-		defer wg.Done()
-		if lifecycleConfiguration, err := client.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{Bucket: bucketName}); err == nil {
+		return err
+	})
+	fetch("IntelligentTieringConfigurations", func() error {
+		input := &s3.ListBucketIntelligentTieringConfigurationsInput{Bucket: bucketName}
+
+		for {
+			out, err := client.ListBucketIntelligentTieringConfigurations(ctx, input)
+			if err != nil {
+				return err
+			}
+
+			bucket.IntelligentTieringConfigurations = append(bucket.IntelligentTieringConfigurations, out.IntelligentTieringConfigurationList...)
+
+			if out.IsTruncated == nil || !*out.IsTruncated || out.NextContinuationToken == nil {
+				break
+			}
+
+			input.ContinuationToken = out.NextContinuationToken
+		}
+
+		if len(bucket.IntelligentTieringConfigurations) == 0 {
+			return errNoConfigurations
+		}
+
+		return nil
+	})
+	fetch("InventoryConfigurations", func() error {
+		input := &s3.ListBucketInventoryConfigurationsInput{Bucket: bucketName}
+
+		for {
+			out, err := client.ListBucketInventoryConfigurations(ctx, input)
+			if err != nil {
+				return err
+			}
+
+			bucket.InventoryConfigurations = append(bucket.InventoryConfigurations, out.InventoryConfigurationList...)
+
+			if out.IsTruncated == nil || !*out.IsTruncated || out.NextContinuationToken == nil {
+				break
+			}
+
+			input.ContinuationToken = out.NextContinuationToken
+		}
+
+		if len(bucket.InventoryConfigurations) == 0 {
+			return errNoConfigurations
+		}
+
+		return nil
+	})
+	fetch("LifecycleConfiguration", func() error {
+		lifecycleConfiguration, err := client.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{Bucket: bucketName})
+		if err == nil {
 			bucket.GetBucketLifecycleConfigurationOutput = *lifecycleConfiguration
 		}
-	}()
-	wg.Add(1)
-	go func() {
-		defer sentry.Recover()
-		defer wg.Done()
-		if logging, err := client.GetBucketLogging(ctx, &s3.GetBucketLoggingInput{Bucket: bucketName}); err == nil {
+		return err
+	})
+	fetch("Logging", func() error {
+		logging, err := client.GetBucketLogging(ctx, &s3.GetBucketLoggingInput{Bucket: bucketName})
+		if err == nil {
 			bucket.GetBucketLoggingOutput = *logging
 		}
-	}()
-	wg.Add(1)
-	go func() {
-		defer sentry.Recover()
-		defer wg.Done()
-		if metricsConfiguration, err := client.GetBucketMetricsConfiguration(ctx, &s3.GetBucketMetricsConfigurationInput{Bucket: bucketName}); err == nil {
-			bucket.GetBucketMetricsConfigurationOutput = *metricsConfiguration
-		}
-	}()
-	wg.Add(1)
-	go func() {
-		defer sentry.Recover()
-		defer wg.Done()
-		if notificationConfiguration, err := client.GetBucketNotificationConfiguration(ctx, &s3.GetBucketNotificationConfigurationInput{Bucket: bucketName}); err == nil {
+		return err
+	})
+	fetch("MetricsConfigurations", func() error {
+		input := &s3.ListBucketMetricsConfigurationsInput{Bucket: bucketName}
+
+		for {
+			out, err := client.ListBucketMetricsConfigurations(ctx, input)
+			if err != nil {
+				return err
+			}
+
+			bucket.MetricsConfigurations = append(bucket.MetricsConfigurations, out.MetricsConfigurationList...)
+
+			if out.IsTruncated == nil || !*out.IsTruncated || out.NextContinuationToken == nil {
+				break
+			}
+
+			input.ContinuationToken = out.NextContinuationToken
+		}
+
+		if len(bucket.MetricsConfigurations) == 0 {
+			return errNoConfigurations
+		}
+
+		return nil
+	})
+	fetch("NotificationConfiguration", func() error {
+		notificationConfiguration, err := client.GetBucketNotificationConfiguration(ctx, &s3.GetBucketNotificationConfigurationInput{Bucket: bucketName})
+		if err == nil {
 			bucket.GetBucketNotificationConfigurationOutput = *notificationConfiguration
 		}
-	}()
-	wg.Add(1)
-	go func() {
-		defer sentry.Recover()
-		defer wg.Done()
-		if ownershipControls, err := client.GetBucketOwnershipControls(ctx, &s3.GetBucketOwnershipControlsInput{Bucket: bucketName}); err == nil {
+		return err
+	})
+	fetch("OwnershipControls", func() error {
+		ownershipControls, err := client.GetBucketOwnershipControls(ctx, &s3.GetBucketOwnershipControlsInput{Bucket: bucketName})
+		if err == nil {
 			bucket.GetBucketOwnershipControlsOutput = *ownershipControls
 		}
-	}()
-	wg.Add(1)
-	go func() {
-		defer sentry.Recover()
-		defer wg.Done()
-		if policy, err := client.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{Bucket: bucketName}); err == nil {
+		return err
+	})
+	fetch("Policy", func() error {
+		policy, err := client.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{Bucket: bucketName})
+		if err == nil {
 			bucket.GetBucketPolicyOutput = *policy
 		}
-	}()
-	wg.Add(1)
-	go func() {
-		defer sentry.Recover()
-		defer wg.Done()
-		if policyStatus, err := client.GetBucketPolicyStatus(ctx, &s3.GetBucketPolicyStatusInput{Bucket: bucketName}); err == nil {
+		return err
+	})
+	fetch("PolicyStatus", func() error {
+		policyStatus, err := client.GetBucketPolicyStatus(ctx, &s3.GetBucketPolicyStatusInput{Bucket: bucketName})
+		if err == nil {
 			bucket.GetBucketPolicyStatusOutput = *policyStatus
 		}
-	}()
-	wg.Add(1)
-	go func() {
-		defer sentry.Recover()
-		defer wg.Done()
-		if replication, err := client.GetBucketReplication(ctx, &s3.GetBucketReplicationInput{Bucket: bucketName}); err == nil {
+		return err
+	})
+	fetch("Replication", func() error {
+		replication, err := client.GetBucketReplication(ctx, &s3.GetBucketReplicationInput{Bucket: bucketName})
+		if err == nil {
 			bucket.GetBucketReplicationOutput = *replication
 		}
-	}()
-	wg.Add(1)
-	go func() {
-		defer sentry.Recover()
-		defer wg.Done()
-		if requestPayment, err := client.GetBucketRequestPayment(ctx, &s3.GetBucketRequestPaymentInput{Bucket: bucketName}); err == nil {
+		return err
+	})
+	fetch("RequestPayment", func() error {
+		requestPayment, err := client.GetBucketRequestPayment(ctx, &s3.GetBucketRequestPaymentInput{Bucket: bucketName})
+		if err == nil {
 			bucket.GetBucketRequestPaymentOutput = *requestPayment
 		}
-	}()
-	wg.Add(1)
-	go func() {
-		defer sentry.Recover()
-		defer wg.Done()
-		if out, err := client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{Bucket: bucketName}); err == nil {
+		return err
+	})
+	fetch("Tagging", func() error {
+		out, err := client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{Bucket: bucketName})
+		if err == nil {
 			tagging = out
 		}
-	}()
-	wg.Add(1)
-	go func() {
-		defer sentry.Recover()
-		defer wg.Done()
-		if versioning, err := client.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{Bucket: bucketName}); err == nil {
+		return err
+	})
+	fetch("Versioning", func() error {
+		versioning, err := client.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{Bucket: bucketName})
+		if err == nil {
 			bucket.GetBucketVersioningOutput = *versioning
 		}
-	}()
-	wg.Add(1)
-	go func() {
-		defer sentry.Recover()
-		defer wg.Done()
-		if website, err := client.GetBucketWebsite(ctx, &s3.GetBucketWebsiteInput{Bucket: bucketName}); err == nil {
+		return err
+	})
+	fetch("Website", func() error {
+		website, err := client.GetBucketWebsite(ctx, &s3.GetBucketWebsiteInput{Bucket: bucketName})
+		if err == nil {
 			bucket.GetBucketWebsiteOutput = *website
 		}
-	}()
+		return err
+	})
 
 	// Wait for all requests to complete
 	wg.Wait()
 
+	results.addToSpan(trace.SpanFromContext(ctx))
+
 	attributes, err := sources.ToAttributesCase(bucket)
 
+	if err == nil {
+		// Record why any of the configuration couldn't be read, so that
+		// missing data can be told apart from data that doesn't exist
+		err = results.setAttributes(attributes)
+	}
+
 	if err != nil {
 		err = &sdp.QueryError{
 			ErrorType:   sdp.QueryError_OTHER,
@@ -521,17 +673,17 @@ func getImpl(ctx context.Context, cache *sdpcache.Cache, client S3Client, scope
 		}
 	}
 
-	if bucket.InventoryConfiguration != nil {
-		if bucket.InventoryConfiguration.Destination != nil {
-			if bucket.InventoryConfiguration.Destination.S3BucketDestination != nil {
-				if bucket.InventoryConfiguration.Destination.S3BucketDestination.Bucket != nil {
-					if a, err = sources.ParseARN(*bucket.InventoryConfiguration.Destination.S3BucketDestination.Bucket); err == nil {
+	for _, inventory := range bucket.InventoryConfigurations {
+		if inventory.Destination != nil {
+			if inventory.Destination.S3BucketDestination != nil {
+				if inventory.Destination.S3BucketDestination.Bucket != nil {
+					if a, err = sources.ParseARN(*inventory.Destination.S3BucketDestination.Bucket); err == nil {
 						// +overmind:link s3-bucket
 						item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
 							Query: &sdp.Query{
 								Type:   "s3-bucket",
 								Method: sdp.QueryMethod_SEARCH,
-								Query:  *bucket.InventoryConfiguration.Destination.S3BucketDestination.Bucket,
+								Query:  *inventory.Destination.S3BucketDestination.Bucket,
 								Scope:  sources.FormatScope(a.AccountID, a.Region),
 							},
 							BlastPropagation: &sdp.BlastPropagation{
@@ -548,19 +700,19 @@ func getImpl(ctx context.Context, cache *sdpcache.Cache, client S3Client, scope
 
 	// Dear god there has to be a better way to do this? Should we just let it
 	// panic and then deal with it?
-	if bucket.AnalyticsConfiguration != nil {
-		if bucket.AnalyticsConfiguration.StorageClassAnalysis != nil {
-			if bucket.AnalyticsConfiguration.StorageClassAnalysis.DataExport != nil {
-				if bucket.AnalyticsConfiguration.StorageClassAnalysis.DataExport.Destination != nil {
-					if bucket.AnalyticsConfiguration.StorageClassAnalysis.DataExport.Destination.S3BucketDestination != nil {
-						if bucket.AnalyticsConfiguration.StorageClassAnalysis.DataExport.Destination.S3BucketDestination.Bucket != nil {
-							if a, err = sources.ParseARN(*bucket.AnalyticsConfiguration.StorageClassAnalysis.DataExport.Destination.S3BucketDestination.Bucket); err == nil {
+	for _, analytics := range bucket.AnalyticsConfigurations {
+		if analytics.StorageClassAnalysis != nil {
+			if analytics.StorageClassAnalysis.DataExport != nil {
+				if analytics.StorageClassAnalysis.DataExport.Destination != nil {
+					if analytics.StorageClassAnalysis.DataExport.Destination.S3BucketDestination != nil {
+						if analytics.StorageClassAnalysis.DataExport.Destination.S3BucketDestination.Bucket != nil {
+							if a, err = sources.ParseARN(*analytics.StorageClassAnalysis.DataExport.Destination.S3BucketDestination.Bucket); err == nil {
 								// +overmind:link s3-bucket
 								item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
 									Query: &sdp.Query{
 										Type:   "s3-bucket",
 										Method: sdp.QueryMethod_SEARCH,
-										Query:  *bucket.AnalyticsConfiguration.StorageClassAnalysis.DataExport.Destination.S3BucketDestination.Bucket,
+										Query:  *analytics.StorageClassAnalysis.DataExport.Destination.S3BucketDestination.Bucket,
 										Scope:  sources.FormatScope(a.AccountID, a.Region),
 									},
 									BlastPropagation: &sdp.BlastPropagation{
@@ -577,6 +729,110 @@ func getImpl(ctx context.Context, cache *sdpcache.Cache, client S3Client, scope
 		}
 	}
 
+	// The scope of a bucket is just the account ID, which is the account that
+	// the resources that the bucket refers to are in unless they say otherwise
+	accountID, _, err := sources.ParseScope(scope)
+
+	if err != nil {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOSCOPE,
+			ErrorString: err.Error(),
+			Scope:       scope,
+		}
+	}
+
+	if bucket.Policy != nil {
+		// +overmind:link iam-role
+		// +overmind:link iam-user
+		// +overmind:link ec2-vpc-endpoint
+		item.LinkedItemQueries = append(item.LinkedItemQueries, bucketPolicyLinks(*bucket.Policy, accountID, region)...)
+	}
+
+	if bucket.ServerSideEncryptionConfiguration != nil {
+		for _, rule := range bucket.ServerSideEncryptionConfiguration.Rules {
+			if rule.ApplyServerSideEncryptionByDefault == nil || rule.ApplyServerSideEncryptionByDefault.KMSMasterKeyID == nil {
+				continue
+			}
+
+			if query := kmsKeyQuery(*rule.ApplyServerSideEncryptionByDefault.KMSMasterKeyID, accountID, region); query != nil {
+				// +overmind:link kms-key
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: query,
+					BlastPropagation: &sdp.BlastPropagation{
+						// Changing the key will affect the bucket
+						In: true,
+						// Changing the bucket won't affect the key
+						Out: false,
+					},
+				})
+			}
+		}
+	}
+
+	if bucket.ReplicationConfiguration != nil {
+		if bucket.ReplicationConfiguration.Role != nil {
+			if query := iampolicy.ARNQuery(*bucket.ReplicationConfiguration.Role, accountID); query != nil {
+				// +overmind:link iam-role
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: query,
+					BlastPropagation: &sdp.BlastPropagation{
+						// Replication will fail if the role's permissions
+						// change
+						In: true,
+						// The bucket won't affect the role
+						Out: false,
+					},
+				})
+			}
+		}
+
+		for _, rule := range bucket.ReplicationConfiguration.Rules {
+			if rule.Destination == nil {
+				continue
+			}
+
+			// The destination can be in another account
+			destinationAccount := accountID
+
+			if rule.Destination.Account != nil {
+				destinationAccount = *rule.Destination.Account
+			}
+
+			if rule.Destination.Bucket != nil {
+				if query := iampolicy.ARNQuery(*rule.Destination.Bucket, destinationAccount); query != nil {
+					// +overmind:link s3-bucket
+					item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+						Query: query,
+						BlastPropagation: &sdp.BlastPropagation{
+							// Tightly coupled
+							In:  true,
+							Out: true,
+						},
+					})
+				}
+			}
+
+			if rule.Destination.EncryptionConfiguration != nil && rule.Destination.EncryptionConfiguration.ReplicaKmsKeyID != nil {
+				// Replica keys must be in the same region as the destination
+				// bucket, which we don't know, so only ARNs are linked
+				if keyARN := *rule.Destination.EncryptionConfiguration.ReplicaKmsKeyID; strings.HasPrefix(keyARN, "arn:") {
+					if query := kmsKeyQuery(keyARN, destinationAccount, ""); query != nil {
+						// +overmind:link kms-key
+						item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+							Query: query,
+							BlastPropagation: &sdp.BlastPropagation{
+								// Changing the key will affect replication
+								In: true,
+								// Changing the bucket won't affect the key
+								Out: false,
+							},
+						})
+					}
+				}
+			}
+		}
+	}
+
 	cache.StoreItem(&item, CacheDuration, ck)
 
 	return &item, nil
@@ -593,10 +849,10 @@ func (s *S3Source) List(ctx context.Context, scope string, ignoreCache bool) ([]
 	}
 
 	s.ensureCache()
-	return listImpl(ctx, s.cache, s.Client(), scope, ignoreCache)
+	return listImpl(ctx, s.cache, s.Client(), s.RegionalClient, s.requestLimit, scope, ignoreCache)
 }
 
-func listImpl(ctx context.Context, cache *sdpcache.Cache, client S3Client, scope string, ignoreCache bool) ([]*sdp.Item, error) {
+func listImpl(ctx context.Context, cache *sdpcache.Cache, client S3Client, regionalClient regionalClientFunc, limit *semaphore.Weighted, scope string, ignoreCache bool) ([]*sdp.Item, error) {
 	cacheHit, ck, cachedItems, qErr := cache.Lookup(ctx, "aws-s3-source", sdp.QueryMethod_LIST, scope, "s3-bucket", "", ignoreCache)
 	if qErr != nil {
 		return nil, qErr
@@ -620,7 +876,7 @@ func listImpl(ctx context.Context, cache *sdpcache.Cache, client S3Client, scope
 	}
 
 	for _, bucket := range buckets.Buckets {
-		item, err := getImpl(ctx, cache, client, scope, *bucket.Name, ignoreCache)
+		item, err := getImpl(ctx, cache, client, regionalClient, limit, scope, *bucket.Name, ignoreCache)
 
 		if err != nil {
 			continue
@@ -646,10 +902,14 @@ func (s *S3Source) Search(ctx context.Context, scope string, query string, ignor
 	}
 
 	s.ensureCache()
-	return searchImpl(ctx, s.cache, s.Client(), scope, query, ignoreCache)
+	return searchImpl(ctx, s.cache, s.Client(), s.RegionalClient, s.requestLimit, scope, query, ignoreCache)
 }
 
-func searchImpl(ctx context.Context, cache *sdpcache.Cache, client S3Client, scope string, query string, ignoreCache bool) ([]*sdp.Item, error) {
+func searchImpl(ctx context.Context, cache *sdpcache.Cache, client S3Client, regionalClient regionalClientFunc, limit *semaphore.Weighted, scope string, query string, ignoreCache bool) ([]*sdp.Item, error) {
+	if region, ok := searchRegion(scope, query); ok {
+		return searchRegionImpl(ctx, cache, client, regionalClient, limit, scope, region, ignoreCache)
+	}
+
 	// Parse the ARN
 	a, err := sources.ParseARN(query)
 
@@ -666,7 +926,7 @@ func searchImpl(ctx context.Context, cache *sdpcache.Cache, client S3Client, sco
 	}
 
 	// If the ARN was parsed we can just ask Get for the item
-	item, err := getImpl(ctx, cache, client, scope, a.ResourceID(), ignoreCache)
+	item, err := getImpl(ctx, cache, client, regionalClient, limit, scope, a.ResourceID(), ignoreCache)
 	if err != nil {
 		return nil, err
 	}
@@ -674,6 +934,83 @@ func searchImpl(ctx context.Context, cache *sdpcache.Cache, client S3Client, sco
 	return []*sdp.Item{item}, nil
 }
 
+// regionRegex Matches AWS region names such as eu-west-2 or us-gov-west-1
+var regionRegex = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-[0-9]+$`)
+
+// searchRegion Works out whether a search query is for all buckets in a
+// region. The query can either be a region, or a regional scope for the
+// source's account e.g. `{account}.{region}`
+func searchRegion(scope string, query string) (string, bool) {
+	region := query
+
+	if account, r, found := strings.Cut(query, "."); found {
+		if account != scope {
+			return "", false
+		}
+
+		region = r
+	}
+
+	return region, regionRegex.MatchString(region)
+}
+
+// searchRegionImpl Returns all buckets in a given region. Only the location is
+// requested for buckets in other regions, so this is much cheaper than
+// listing all buckets and filtering them
+func searchRegionImpl(ctx context.Context, cache *sdpcache.Cache, client S3Client, regionalClient regionalClientFunc, limit *semaphore.Weighted, scope string, region string, ignoreCache bool) ([]*sdp.Item, error) {
+	cacheHit, ck, cachedItems, qErr := cache.Lookup(ctx, "aws-s3-source", sdp.QueryMethod_SEARCH, scope, "s3-bucket", region, ignoreCache)
+	if qErr != nil {
+		return nil, qErr
+	}
+	if cacheHit {
+		return cachedItems, nil
+	}
+
+	buckets, err := client.ListBuckets(ctx, &s3.ListBucketsInput{})
+
+	if err != nil {
+		err = sdp.NewQueryError(err)
+		cache.StoreError(err, CacheDuration, ck)
+		return nil, err
+	}
+
+	items := make([]*sdp.Item, 0)
+
+	for _, bucket := range buckets.Buckets {
+		if bucket.Name == nil {
+			continue
+		}
+
+		if err = acquireRequest(ctx, limit); err != nil {
+			return nil, sdp.NewQueryError(err)
+		}
+
+		location, err := client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{
+			Bucket: bucket.Name,
+		})
+
+		releaseRequest(limit)
+
+		if err != nil || bucketRegion(location.LocationConstraint) != region {
+			continue
+		}
+
+		item, err := getImpl(ctx, cache, client, regionalClient, limit, scope, *bucket.Name, ignoreCache)
+
+		if err != nil {
+			continue
+		}
+
+		items = append(items, item)
+	}
+
+	for _, item := range items {
+		cache.StoreItem(item, CacheDuration, ck)
+	}
+
+	return items, nil
+}
+
 // Weight Returns the priority weighting of items returned by this source.
 // This is used to resolve conflicts where two sources of the same type
 // return an item for a GET request. In this instance only one item can be
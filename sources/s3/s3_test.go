@@ -16,7 +16,7 @@ import (
 func TestS3SearchImpl(t *testing.T) {
 	cache := sdpcache.NewCache()
 	t.Run("with a good ARN", func(t *testing.T) {
-		items, err := searchImpl(context.Background(), cache, TestS3Client{}, "account-id.region", "arn:partition:service:region:account-id:resource-type:resource-id", false)
+		items, err := searchImpl(context.Background(), cache, TestS3Client{}, nil, nil, "account-id.region", "arn:partition:service:region:account-id:resource-type:resource-id", false)
 
 		if err != nil {
 			t.Error(err)
@@ -27,7 +27,7 @@ func TestS3SearchImpl(t *testing.T) {
 	})
 
 	t.Run("with a bad ARN", func(t *testing.T) {
-		_, err := searchImpl(context.Background(), cache, TestS3Client{}, "account-id.region", "foo", false)
+		_, err := searchImpl(context.Background(), cache, TestS3Client{}, nil, nil, "account-id.region", "foo", false)
 
 		if err == nil {
 			t.Error("expected error")
@@ -43,7 +43,7 @@ func TestS3SearchImpl(t *testing.T) {
 	})
 
 	t.Run("with an ARN in another scope", func(t *testing.T) {
-		_, err := searchImpl(context.Background(), cache, TestS3Client{}, "account-id.region", "arn:partition:service:region:account-id-2:resource-type:resource-id", false)
+		_, err := searchImpl(context.Background(), cache, TestS3Client{}, nil, nil, "account-id.region", "arn:partition:service:region:account-id-2:resource-type:resource-id", false)
 
 		if err == nil {
 			t.Error("expected error")
@@ -59,9 +59,59 @@ func TestS3SearchImpl(t *testing.T) {
 	})
 }
 
+func TestS3SearchImplRegion(t *testing.T) {
+	cases := map[string]int{
+		"af-south-1":     1,
+		"eu-west-2":      0,
+		"foo.af-south-1": 1,
+		"foo.eu-west-2":  0,
+	}
+
+	for query, expected := range cases {
+		t.Run(query, func(t *testing.T) {
+			items, err := searchImpl(context.Background(), sdpcache.NewCache(), TestS3Client{}, nil, nil, "foo", query, false)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(items) != expected {
+				t.Errorf("expected %v items, got %v", expected, len(items))
+			}
+		})
+	}
+}
+
+func TestSearchRegion(t *testing.T) {
+	cases := []struct {
+		Query    string
+		Region   string
+		IsRegion bool
+	}{
+		{Query: "eu-west-2", Region: "eu-west-2", IsRegion: true},
+		{Query: "us-gov-west-1", Region: "us-gov-west-1", IsRegion: true},
+		{Query: "052392120703.eu-west-2", Region: "eu-west-2", IsRegion: true},
+		{Query: "111122223333.eu-west-2", IsRegion: false},
+		{Query: "arn:aws:s3:::bucket", IsRegion: false},
+		{Query: "foo", IsRegion: false},
+	}
+
+	for _, c := range cases {
+		region, ok := searchRegion("052392120703", c.Query)
+
+		if ok != c.IsRegion {
+			t.Errorf("expected %q to be a region search: %v, got %v", c.Query, c.IsRegion, ok)
+		}
+
+		if ok && region != c.Region {
+			t.Errorf("expected region %v for %q, got %v", c.Region, c.Query, region)
+		}
+	}
+}
+
 func TestS3ListImpl(t *testing.T) {
 	cache := sdpcache.NewCache()
-	items, err := listImpl(context.Background(), cache, TestS3Client{}, "foo", false)
+	items, err := listImpl(context.Background(), cache, TestS3Client{}, nil, nil, "foo", false)
 
 	if err != nil {
 		t.Error(err)
@@ -73,7 +123,7 @@ func TestS3ListImpl(t *testing.T) {
 
 func TestS3GetImpl(t *testing.T) {
 	cache := sdpcache.NewCache()
-	item, err := getImpl(context.Background(), cache, TestS3Client{}, "foo", "bar", false)
+	item, err := getImpl(context.Background(), cache, TestS3Client{}, nil, nil, "foo", "bar", false)
 
 	if err != nil {
 		t.Fatal(err)
@@ -122,14 +172,102 @@ func TestS3GetImpl(t *testing.T) {
 			ExpectedQuery:  "arn:partition:service:region:account-id:resource-type:resource-id",
 			ExpectedScope:  "account-id.region",
 		},
+		{
+			ExpectedType:   "iam-role",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:iam::052392120703:role/reader",
+			ExpectedScope:  "052392120703",
+		},
+		{
+			ExpectedType:   "ec2-vpc-endpoint",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "vpce-1a2b3c4d",
+			ExpectedScope:  "foo.af-south-1",
+		},
+		{
+			ExpectedType:   "kms-key",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "1234abcd-12ab-34cd-56ef-1234567890ab",
+			ExpectedScope:  "foo.af-south-1",
+		},
+		{
+			ExpectedType:   "iam-role",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:iam::052392120703:role/replication",
+			ExpectedScope:  "052392120703",
+		},
+		{
+			ExpectedType:   "s3-bucket",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "replica",
+			ExpectedScope:  "111122223333",
+		},
+		{
+			ExpectedType:   "kms-key",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:kms:eu-west-1:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+			ExpectedScope:  "111122223333.eu-west-1",
+		},
 	}
 
 	tests.Execute(t, item)
+
+	// Endpoints that are excluded, and anything in a Deny statement, don't
+	// give access to the bucket
+	for _, link := range item.GetLinkedItemQueries() {
+		switch link.GetQuery().GetQuery() {
+		case "vpce-0000ffff", "vpce-99998888", "arn:aws:iam::052392120703:role/blocked":
+			t.Errorf("unexpected link %v", link.GetQuery())
+		}
+	}
+}
+
+func TestS3GetImplRegionalClient(t *testing.T) {
+	var requested []string
+
+	regionalClient := func(region string) S3Client {
+		requested = append(requested, region)
+		return TestS3Client{}
+	}
+
+	item, err := getImpl(context.Background(), sdpcache.NewCache(), TestS3Client{}, regionalClient, nil, "foo", "bar", false)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(requested) != 1 || requested[0] != "af-south-1" {
+		t.Errorf("expected a client for af-south-1, got %v", requested)
+	}
+
+	region, err := item.GetAttributes().Get("region")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if region != "af-south-1" {
+		t.Errorf("expected region to be af-south-1, got %v", region)
+	}
+}
+
+func TestBucketRegion(t *testing.T) {
+	cases := map[types.BucketLocationConstraint]string{
+		"":                                    "us-east-1",
+		types.BucketLocationConstraintEu:      "eu-west-1",
+		types.BucketLocationConstraintEuWest2: "eu-west-2",
+	}
+
+	for location, expected := range cases {
+		if region := bucketRegion(location); region != expected {
+			t.Errorf("expected %v for location %q, got %v", expected, location, region)
+		}
+	}
 }
 
 func TestS3SourceCaching(t *testing.T) {
 	cache := sdpcache.NewCache()
-	first, err := getImpl(context.Background(), cache, TestS3Client{}, "foo", "bar", false)
+	first, err := getImpl(context.Background(), cache, TestS3Client{}, nil, nil, "foo", "bar", false)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -137,7 +275,7 @@ func TestS3SourceCaching(t *testing.T) {
 		t.Fatal("expected first item")
 	}
 
-	second, err := getImpl(context.Background(), cache, TestS3FailClient{}, "foo", "bar", false)
+	second, err := getImpl(context.Background(), cache, TestS3FailClient{}, nil, nil, "foo", "bar", false)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -145,7 +283,7 @@ func TestS3SourceCaching(t *testing.T) {
 		t.Fatal("expected second item")
 	}
 
-	third, err := getImpl(context.Background(), cache, TestS3Client{}, "foo", "bar", true)
+	third, err := getImpl(context.Background(), cache, TestS3Client{}, nil, nil, "foo", "bar", true)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -195,21 +333,23 @@ func (t TestS3Client) GetBucketAcl(ctx context.Context, params *s3.GetBucketAclI
 	}, nil
 }
 
-func (t TestS3Client) GetBucketAnalyticsConfiguration(ctx context.Context, params *s3.GetBucketAnalyticsConfigurationInput, optFns ...func(*s3.Options)) (*s3.GetBucketAnalyticsConfigurationOutput, error) {
-	return &s3.GetBucketAnalyticsConfigurationOutput{
-		AnalyticsConfiguration: &types.AnalyticsConfiguration{
-			Id: sources.PtrString("id"),
-			StorageClassAnalysis: &types.StorageClassAnalysis{
-				DataExport: &types.StorageClassAnalysisDataExport{
-					Destination: &types.AnalyticsExportDestination{
-						S3BucketDestination: &types.AnalyticsS3BucketDestination{
-							Bucket:          sources.PtrString("arn:partition:service:region:account-id:resource-type:resource-id"),
-							Format:          types.AnalyticsS3ExportFileFormatCsv,
-							BucketAccountId: sources.PtrString("id"),
-							Prefix:          sources.PtrString("pre"),
+func (t TestS3Client) ListBucketAnalyticsConfigurations(ctx context.Context, params *s3.ListBucketAnalyticsConfigurationsInput, optFns ...func(*s3.Options)) (*s3.ListBucketAnalyticsConfigurationsOutput, error) {
+	return &s3.ListBucketAnalyticsConfigurationsOutput{
+		AnalyticsConfigurationList: []types.AnalyticsConfiguration{
+			{
+				Id: sources.PtrString("id"),
+				StorageClassAnalysis: &types.StorageClassAnalysis{
+					DataExport: &types.StorageClassAnalysisDataExport{
+						Destination: &types.AnalyticsExportDestination{
+							S3BucketDestination: &types.AnalyticsS3BucketDestination{
+								Bucket:          sources.PtrString("arn:partition:service:region:account-id:resource-type:resource-id"),
+								Format:          types.AnalyticsS3ExportFileFormatCsv,
+								BucketAccountId: sources.PtrString("id"),
+								Prefix:          sources.PtrString("pre"),
+							},
 						},
+						OutputSchemaVersion: types.StorageClassAnalysisSchemaVersionV1,
 					},
-					OutputSchemaVersion: types.StorageClassAnalysisSchemaVersionV1,
 				},
 			},
 		},
@@ -246,7 +386,7 @@ func (t TestS3Client) GetBucketEncryption(ctx context.Context, params *s3.GetBuc
 				{
 					ApplyServerSideEncryptionByDefault: &types.ServerSideEncryptionByDefault{
 						SSEAlgorithm:   types.ServerSideEncryptionAes256,
-						KMSMasterKeyID: sources.PtrString("id"),
+						KMSMasterKeyID: sources.PtrString("1234abcd-12ab-34cd-56ef-1234567890ab"),
 					},
 					BucketKeyEnabled: sources.PtrBool(true),
 				},
@@ -255,43 +395,47 @@ func (t TestS3Client) GetBucketEncryption(ctx context.Context, params *s3.GetBuc
 	}, nil
 }
 
-func (t TestS3Client) GetBucketIntelligentTieringConfiguration(ctx context.Context, params *s3.GetBucketIntelligentTieringConfigurationInput, optFns ...func(*s3.Options)) (*s3.GetBucketIntelligentTieringConfigurationOutput, error) {
-	return &s3.GetBucketIntelligentTieringConfigurationOutput{
-		IntelligentTieringConfiguration: &types.IntelligentTieringConfiguration{
-			Id:     sources.PtrString("id"),
-			Status: types.IntelligentTieringStatusEnabled,
-			Tierings: []types.Tiering{
-				{
-					AccessTier: types.IntelligentTieringAccessTierDeepArchiveAccess,
-					Days:       sources.PtrInt32(100),
+func (t TestS3Client) ListBucketIntelligentTieringConfigurations(ctx context.Context, params *s3.ListBucketIntelligentTieringConfigurationsInput, optFns ...func(*s3.Options)) (*s3.ListBucketIntelligentTieringConfigurationsOutput, error) {
+	return &s3.ListBucketIntelligentTieringConfigurationsOutput{
+		IntelligentTieringConfigurationList: []types.IntelligentTieringConfiguration{
+			{
+				Id:     sources.PtrString("id"),
+				Status: types.IntelligentTieringStatusEnabled,
+				Tierings: []types.Tiering{
+					{
+						AccessTier: types.IntelligentTieringAccessTierDeepArchiveAccess,
+						Days:       sources.PtrInt32(100),
+					},
 				},
+				Filter: &types.IntelligentTieringFilter{},
 			},
-			Filter: &types.IntelligentTieringFilter{},
 		},
 	}, nil
 }
 
-func (t TestS3Client) GetBucketInventoryConfiguration(ctx context.Context, params *s3.GetBucketInventoryConfigurationInput, optFns ...func(*s3.Options)) (*s3.GetBucketInventoryConfigurationOutput, error) {
-	return &s3.GetBucketInventoryConfigurationOutput{
-		InventoryConfiguration: &types.InventoryConfiguration{
-			Destination: &types.InventoryDestination{
-				S3BucketDestination: &types.InventoryS3BucketDestination{
-					Bucket:    sources.PtrString("arn:partition:service:region:account-id:resource-type:resource-id"),
-					Format:    types.InventoryFormatCsv,
-					AccountId: sources.PtrString("id"),
-					Encryption: &types.InventoryEncryption{
-						SSEKMS: &types.SSEKMS{
-							KeyId: sources.PtrString("key"),
+func (t TestS3Client) ListBucketInventoryConfigurations(ctx context.Context, params *s3.ListBucketInventoryConfigurationsInput, optFns ...func(*s3.Options)) (*s3.ListBucketInventoryConfigurationsOutput, error) {
+	return &s3.ListBucketInventoryConfigurationsOutput{
+		InventoryConfigurationList: []types.InventoryConfiguration{
+			{
+				Destination: &types.InventoryDestination{
+					S3BucketDestination: &types.InventoryS3BucketDestination{
+						Bucket:    sources.PtrString("arn:partition:service:region:account-id:resource-type:resource-id"),
+						Format:    types.InventoryFormatCsv,
+						AccountId: sources.PtrString("id"),
+						Encryption: &types.InventoryEncryption{
+							SSEKMS: &types.SSEKMS{
+								KeyId: sources.PtrString("key"),
+							},
 						},
+						Prefix: sources.PtrString("pre"),
 					},
-					Prefix: sources.PtrString("pre"),
 				},
-			},
-			Id:                     sources.PtrString("id"),
-			IncludedObjectVersions: types.InventoryIncludedObjectVersionsAll,
-			IsEnabled:              sources.PtrBool(true),
-			Schedule: &types.InventorySchedule{
-				Frequency: types.InventoryFrequencyDaily,
+				Id:                     sources.PtrString("id"),
+				IncludedObjectVersions: types.InventoryIncludedObjectVersionsAll,
+				IsEnabled:              sources.PtrBool(true),
+				Schedule: &types.InventorySchedule{
+					Frequency: types.InventoryFrequencyDaily,
+				},
 			},
 		},
 	}, nil
@@ -358,10 +502,12 @@ func (t TestS3Client) GetBucketLogging(ctx context.Context, params *s3.GetBucket
 	}, nil
 }
 
-func (t TestS3Client) GetBucketMetricsConfiguration(ctx context.Context, params *s3.GetBucketMetricsConfigurationInput, optFns ...func(*s3.Options)) (*s3.GetBucketMetricsConfigurationOutput, error) {
-	return &s3.GetBucketMetricsConfigurationOutput{
-		MetricsConfiguration: &types.MetricsConfiguration{
-			Id: sources.PtrString("id"),
+func (t TestS3Client) ListBucketMetricsConfigurations(ctx context.Context, params *s3.ListBucketMetricsConfigurationsInput, optFns ...func(*s3.Options)) (*s3.ListBucketMetricsConfigurationsOutput, error) {
+	return &s3.ListBucketMetricsConfigurationsOutput{
+		MetricsConfigurationList: []types.MetricsConfiguration{
+			{
+				Id: sources.PtrString("id"),
+			},
 		},
 	}, nil
 }
@@ -427,7 +573,40 @@ func (t TestS3Client) GetBucketOwnershipControls(ctx context.Context, params *s3
 
 func (t TestS3Client) GetBucketPolicy(ctx context.Context, params *s3.GetBucketPolicyInput, optFns ...func(*s3.Options)) (*s3.GetBucketPolicyOutput, error) {
 	return &s3.GetBucketPolicyOutput{
-		Policy: sources.PtrString("policy"),
+		Policy: sources.PtrString(`{
+			"Version": "2012-10-17",
+			"Statement": [
+				{
+					"Effect": "Allow",
+					"Principal": {
+						"AWS": ["arn:aws:iam::052392120703:role/reader", "arn:aws:iam::052392120703:root"]
+					},
+					"Action": "s3:GetObject",
+					"Resource": "arn:aws:s3:::bucket/*",
+					"Condition": {
+						"StringEquals": {
+							"aws:SourceVpce": "vpce-1a2b3c4d"
+						},
+						"StringNotEquals": {
+							"aws:SourceVpce": "vpce-0000ffff"
+						}
+					}
+				},
+				{
+					"Effect": "Deny",
+					"Principal": {
+						"AWS": "arn:aws:iam::052392120703:role/blocked"
+					},
+					"Action": "s3:*",
+					"Resource": "arn:aws:s3:::bucket/*",
+					"Condition": {
+						"StringEquals": {
+							"aws:SourceVpce": "vpce-99998888"
+						}
+					}
+				}
+			]
+		}`),
 	}, nil
 }
 
@@ -442,17 +621,17 @@ func (t TestS3Client) GetBucketPolicyStatus(ctx context.Context, params *s3.GetB
 func (t TestS3Client) GetBucketReplication(ctx context.Context, params *s3.GetBucketReplicationInput, optFns ...func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
 	return &s3.GetBucketReplicationOutput{
 		ReplicationConfiguration: &types.ReplicationConfiguration{
-			Role: sources.PtrString("role"),
+			Role: sources.PtrString("arn:aws:iam::052392120703:role/replication"),
 			Rules: []types.ReplicationRule{
 				{
 					Destination: &types.Destination{
-						Bucket: sources.PtrString("bucket"),
+						Bucket: sources.PtrString("arn:aws:s3:::replica"),
 						AccessControlTranslation: &types.AccessControlTranslation{
 							Owner: types.OwnerOverrideDestination,
 						},
-						Account: sources.PtrString("account"),
+						Account: sources.PtrString("111122223333"),
 						EncryptionConfiguration: &types.EncryptionConfiguration{
-							ReplicaKmsKeyID: sources.PtrString("keyId"),
+							ReplicaKmsKeyID: sources.PtrString("arn:aws:kms:eu-west-1:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab"),
 						},
 						Metrics: &types.Metrics{
 							Status: types.MetricsStatusEnabled,
@@ -528,8 +707,8 @@ func (t TestS3FailClient) ListBuckets(ctx context.Context, params *s3.ListBucket
 func (t TestS3FailClient) GetBucketAcl(ctx context.Context, params *s3.GetBucketAclInput, optFns ...func(*s3.Options)) (*s3.GetBucketAclOutput, error) {
 	return nil, errors.New("failed to get bucket ACL")
 }
-func (t TestS3FailClient) GetBucketAnalyticsConfiguration(ctx context.Context, params *s3.GetBucketAnalyticsConfigurationInput, optFns ...func(*s3.Options)) (*s3.GetBucketAnalyticsConfigurationOutput, error) {
-	return nil, errors.New("failed to get bucket ACL")
+func (t TestS3FailClient) ListBucketAnalyticsConfigurations(ctx context.Context, params *s3.ListBucketAnalyticsConfigurationsInput, optFns ...func(*s3.Options)) (*s3.ListBucketAnalyticsConfigurationsOutput, error) {
+	return nil, errors.New("failed to list bucket analytics configurations")
 }
 
 func (t TestS3FailClient) GetBucketCors(ctx context.Context, params *s3.GetBucketCorsInput, optFns ...func(*s3.Options)) (*s3.GetBucketCorsOutput, error) {
@@ -540,12 +719,12 @@ func (t TestS3FailClient) GetBucketEncryption(ctx context.Context, params *s3.Ge
 	return nil, errors.New("failed to get bucket CORS")
 }
 
-func (t TestS3FailClient) GetBucketIntelligentTieringConfiguration(ctx context.Context, params *s3.GetBucketIntelligentTieringConfigurationInput, optFns ...func(*s3.Options)) (*s3.GetBucketIntelligentTieringConfigurationOutput, error) {
-	return nil, errors.New("failed to get bucket CORS")
+func (t TestS3FailClient) ListBucketIntelligentTieringConfigurations(ctx context.Context, params *s3.ListBucketIntelligentTieringConfigurationsInput, optFns ...func(*s3.Options)) (*s3.ListBucketIntelligentTieringConfigurationsOutput, error) {
+	return nil, errors.New("failed to list bucket intelligent tiering configurations")
 }
 
-func (t TestS3FailClient) GetBucketInventoryConfiguration(ctx context.Context, params *s3.GetBucketInventoryConfigurationInput, optFns ...func(*s3.Options)) (*s3.GetBucketInventoryConfigurationOutput, error) {
-	return nil, errors.New("failed to get bucket CORS")
+func (t TestS3FailClient) ListBucketInventoryConfigurations(ctx context.Context, params *s3.ListBucketInventoryConfigurationsInput, optFns ...func(*s3.Options)) (*s3.ListBucketInventoryConfigurationsOutput, error) {
+	return nil, errors.New("failed to list bucket inventory configurations")
 }
 
 func (t TestS3FailClient) GetBucketLifecycleConfiguration(ctx context.Context, params *s3.GetBucketLifecycleConfigurationInput, optFns ...func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
@@ -560,8 +739,8 @@ func (t TestS3FailClient) GetBucketLogging(ctx context.Context, params *s3.GetBu
 	return nil, errors.New("failed to get bucket logging")
 }
 
-func (t TestS3FailClient) GetBucketMetricsConfiguration(ctx context.Context, params *s3.GetBucketMetricsConfigurationInput, optFns ...func(*s3.Options)) (*s3.GetBucketMetricsConfigurationOutput, error) {
-	return nil, errors.New("failed to get bucket logging")
+func (t TestS3FailClient) ListBucketMetricsConfigurations(ctx context.Context, params *s3.ListBucketMetricsConfigurationsInput, optFns ...func(*s3.Options)) (*s3.ListBucketMetricsConfigurationsOutput, error) {
+	return nil, errors.New("failed to list bucket metrics configurations")
 }
 
 func (t TestS3FailClient) GetBucketNotificationConfiguration(ctx context.Context, params *s3.GetBucketNotificationConfigurationInput, optFns ...func(*s3.Options)) (*s3.GetBucketNotificationConfigurationOutput, error) {
@@ -663,7 +842,7 @@ func (t TestS3FailClient) PutObject(ctx context.Context, params *s3.PutObjectInp
 func TestNewS3Source(t *testing.T) {
 	config, account, _ := sources.GetAutoConfig(t)
 
-	source := NewS3Source(config, account)
+	source := NewS3Source(config, account, DefaultMaxParallel)
 
 	test := sources.E2ETest{
 		Source:  source,
@@ -0,0 +1,286 @@
+package servicediscovery
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/servicediscovery"
+	"github.com/aws/aws-sdk-go-v2/service/servicediscovery/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+// InstanceDetails An instance along with the ID of the service that it is
+// registered with, since instance IDs are only unique within a service
+type InstanceDetails struct {
+	ServiceId *string
+	Instance  *types.Instance
+}
+
+func instanceGetFunc(ctx context.Context, client ServiceDiscoveryClient, scope, query string) (*InstanceDetails, error) {
+	// We are using a custom id of {serviceId}/{instanceId} e.g.
+	// srv-e4anhexw6fjyc5ok/i-0123456789abcdef0
+	sections := strings.SplitN(query, "/", 2)
+
+	if len(sections) != 2 {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: fmt.Sprintf("query must be in the format {serviceId}/{instanceId}, got %v", query),
+		}
+	}
+
+	out, err := client.GetInstance(ctx, &servicediscovery.GetInstanceInput{
+		ServiceId:  &sections[0],
+		InstanceId: &sections[1],
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if out.Instance == nil {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: "instance was nil",
+		}
+	}
+
+	return &InstanceDetails{
+		ServiceId: &sections[0],
+		Instance:  out.Instance,
+	}, nil
+}
+
+// listInstances Lists all instances registered with a given service
+func listInstances(ctx context.Context, client ServiceDiscoveryClient, serviceID string) ([]*InstanceDetails, error) {
+	instances := make([]*InstanceDetails, 0)
+
+	paginator := servicediscovery.NewListInstancesPaginator(client, &servicediscovery.ListInstancesInput{
+		ServiceId: &serviceID,
+	})
+
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, summary := range out.Instances {
+			instances = append(instances, &InstanceDetails{
+				ServiceId: &serviceID,
+				Instance: &types.Instance{
+					Id:         summary.Id,
+					Attributes: summary.Attributes,
+				},
+			})
+		}
+	}
+
+	return instances, nil
+}
+
+func instanceListFunc(ctx context.Context, client ServiceDiscoveryClient, scope string) ([]*InstanceDetails, error) {
+	instances := make([]*InstanceDetails, 0)
+
+	paginator := servicediscovery.NewListServicesPaginator(client, &servicediscovery.ListServicesInput{})
+
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, service := range out.Services {
+			if service.Id == nil {
+				continue
+			}
+
+			serviceInstances, err := listInstances(ctx, client, *service.Id)
+
+			if err != nil {
+				return nil, err
+			}
+
+			instances = append(instances, serviceInstances...)
+		}
+	}
+
+	return instances, nil
+}
+
+// instanceSearchFunc Searches for instances by the ID or ARN of the service
+// that they are registered with
+func instanceSearchFunc(ctx context.Context, client ServiceDiscoveryClient, scope, query string) ([]*InstanceDetails, error) {
+	serviceID := query
+
+	if a, err := sources.ParseARN(query); err == nil {
+		serviceID = a.ResourceID()
+	}
+
+	return listInstances(ctx, client, serviceID)
+}
+
+func instanceItemMapper(scope string, awsItem *InstanceDetails) (*sdp.Item, error) {
+	attributes, err := sources.ToAttributesCase(awsItem)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if awsItem.ServiceId == nil || awsItem.Instance == nil || awsItem.Instance.Id == nil {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_OTHER,
+			ErrorString: "instance is missing its service ID or instance ID",
+		}
+	}
+
+	attributes.Set("uniqueName", fmt.Sprintf("%v/%v", *awsItem.ServiceId, *awsItem.Instance.Id))
+
+	item := sdp.Item{
+		Type:            "servicediscovery-instance",
+		UniqueAttribute: "uniqueName",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	// +overmind:link servicediscovery-service
+	item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+		Query: &sdp.Query{
+			Type:   "servicediscovery-service",
+			Method: sdp.QueryMethod_GET,
+			Query:  *awsItem.ServiceId,
+			Scope:  scope,
+		},
+		BlastPropagation: &sdp.BlastPropagation{
+			// The service resolves to its instances, so they are tightly
+			// coupled
+			In:  true,
+			Out: true,
+		},
+	})
+
+	attrs := awsItem.Instance.Attributes
+
+	for _, key := range []string{"AWS_INSTANCE_IPV4", "AWS_INSTANCE_IPV6"} {
+		if ip, ok := attrs[key]; ok && ip != "" {
+			// +overmind:link ip
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "ip",
+					Method: sdp.QueryMethod_GET,
+					Query:  ip,
+					Scope:  "global",
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// IPs are always linked
+					In:  true,
+					Out: true,
+				},
+			})
+		}
+	}
+
+	for _, key := range []string{"AWS_INSTANCE_CNAME", "AWS_ALIAS_DNS_NAME"} {
+		if name, ok := attrs[key]; ok && name != "" {
+			// +overmind:link dns
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "dns",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  name,
+					Scope:  "global",
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// DNS is always linked
+					In:  true,
+					Out: true,
+				},
+			})
+		}
+	}
+
+	if instanceID, ok := attrs["AWS_EC2_INSTANCE_ID"]; ok && instanceID != "" {
+		// +overmind:link ec2-instance
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "ec2-instance",
+				Method: sdp.QueryMethod_GET,
+				Query:  instanceID,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Changing the EC2 instance will change what the service
+				// discovery instance resolves to
+				In: true,
+				// Deregistering won't affect the EC2 instance itself
+				Out: false,
+			},
+		})
+	}
+
+	if clusterName, ok := attrs["ECS_CLUSTER_NAME"]; ok && clusterName != "" {
+		// Instances registered by ECS use the task ID as the instance ID
+		// +overmind:link ecs-task
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "ecs-task",
+				Method: sdp.QueryMethod_GET,
+				Query:  fmt.Sprintf("%v/%v", clusterName, *awsItem.Instance.Id),
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// The instance represents the task, so changes to the task
+				// will affect it
+				In: true,
+				// Changing the instance won't affect the task
+				Out: false,
+			},
+		})
+
+		if serviceName, ok := attrs["ECS_SERVICE_NAME"]; ok && serviceName != "" {
+			// +overmind:link ecs-service
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "ecs-service",
+					Method: sdp.QueryMethod_GET,
+					Query:  fmt.Sprintf("%v/%v", clusterName, serviceName),
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// The ECS service manages the registration of this
+					// instance
+					In: true,
+					// Changing the instance won't affect the ECS service
+					Out: false,
+				},
+			})
+		}
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type servicediscovery-instance
+// +overmind:descriptiveType Cloud Map Instance
+// +overmind:get Get an instance by {serviceId}/{instanceId}
+// +overmind:list List all instances
+// +overmind:search Search for instances by service ID or ARN
+// +overmind:group AWS
+
+func NewInstanceSource(config aws.Config, accountID string, region string) *sources.GetListSource[*InstanceDetails, ServiceDiscoveryClient, *servicediscovery.Options] {
+	return &sources.GetListSource[*InstanceDetails, ServiceDiscoveryClient, *servicediscovery.Options]{
+		ItemType:   "servicediscovery-instance",
+		Client:     servicediscovery.NewFromConfig(config),
+		AccountID:  accountID,
+		Region:     region,
+		GetFunc:    instanceGetFunc,
+		ListFunc:   instanceListFunc,
+		SearchFunc: instanceSearchFunc,
+		ItemMapper: instanceItemMapper,
+	}
+}
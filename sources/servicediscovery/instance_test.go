@@ -0,0 +1,165 @@
+package servicediscovery
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/servicediscovery"
+	"github.com/aws/aws-sdk-go-v2/service/servicediscovery/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testServiceDiscoveryClient) GetInstance(ctx context.Context, params *servicediscovery.GetInstanceInput, optFns ...func(*servicediscovery.Options)) (*servicediscovery.GetInstanceOutput, error) {
+	return &servicediscovery.GetInstanceOutput{
+		Instance: &types.Instance{
+			Id: params.InstanceId,
+			Attributes: map[string]string{
+				"AWS_INSTANCE_IPV4":          "10.0.1.25", // link
+				"AWS_INSTANCE_PORT":          "8080",
+				"AVAILABILITY_ZONE":          "us-east-1a",
+				"ECS_CLUSTER_NAME":           "production", // link
+				"ECS_SERVICE_NAME":           "api",        // link
+				"ECS_TASK_DEFINITION_FAMILY": "api",
+			},
+		},
+	}, nil
+}
+
+func (c testServiceDiscoveryClient) ListInstances(ctx context.Context, params *servicediscovery.ListInstancesInput, optFns ...func(*servicediscovery.Options)) (*servicediscovery.ListInstancesOutput, error) {
+	return &servicediscovery.ListInstancesOutput{
+		Instances: []types.InstanceSummary{
+			{
+				Id: sources.PtrString("i-0123456789abcdef0"),
+				Attributes: map[string]string{
+					"AWS_EC2_INSTANCE_ID": "i-0123456789abcdef0",
+					"AWS_INSTANCE_IPV4":   "10.0.2.10",
+				},
+			},
+		},
+	}, nil
+}
+
+func TestInstanceGetFunc(t *testing.T) {
+	scope := "123456789012.us-east-1"
+
+	instance, err := instanceGetFunc(context.Background(), testServiceDiscoveryClient{}, scope, "srv-e4anhexw6fjyc5ok/2ffd7ed376c841bcb0e6795ddb6e72e2")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := instanceItemMapper(scope, instance)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := item.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if item.UniqueAttributeValue() != "srv-e4anhexw6fjyc5ok/2ffd7ed376c841bcb0e6795ddb6e72e2" {
+		t.Errorf("unexpected unique attribute value %v", item.UniqueAttributeValue())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "servicediscovery-service",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "srv-e4anhexw6fjyc5ok",
+			ExpectedScope:  scope,
+		},
+		{
+			ExpectedType:   "ip",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "10.0.1.25",
+			ExpectedScope:  "global",
+		},
+		{
+			ExpectedType:   "ecs-task",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "production/2ffd7ed376c841bcb0e6795ddb6e72e2",
+			ExpectedScope:  scope,
+		},
+		{
+			ExpectedType:   "ecs-service",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "production/api",
+			ExpectedScope:  scope,
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestInstanceGetFuncBadQuery(t *testing.T) {
+	_, err := instanceGetFunc(context.Background(), testServiceDiscoveryClient{}, "123456789012.us-east-1", "srv-e4anhexw6fjyc5ok")
+
+	if err == nil {
+		t.Error("expected error for query without an instance ID")
+	}
+}
+
+func TestInstanceSearchFunc(t *testing.T) {
+	scope := "123456789012.us-east-1"
+
+	instances, err := instanceSearchFunc(context.Background(), testServiceDiscoveryClient{}, scope, "arn:aws:servicediscovery:us-east-1:123456789012:service/srv-e4anhexw6fjyc5ok")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(instances) != 1 {
+		t.Fatalf("expected 1 instance, got %v", len(instances))
+	}
+
+	item, err := instanceItemMapper(scope, instances[0])
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "ec2-instance",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "i-0123456789abcdef0",
+			ExpectedScope:  scope,
+		},
+		{
+			ExpectedType:   "servicediscovery-service",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "srv-e4anhexw6fjyc5ok",
+			ExpectedScope:  scope,
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestInstanceListFunc(t *testing.T) {
+	instances, err := instanceListFunc(context.Background(), testServiceDiscoveryClient{}, "123456789012.us-east-1")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(instances) != 1 {
+		t.Fatalf("expected 1 instance, got %v", len(instances))
+	}
+}
+
+func TestNewInstanceSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewInstanceSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -0,0 +1,143 @@
+package servicediscovery
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/servicediscovery"
+	"github.com/aws/aws-sdk-go-v2/service/servicediscovery/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func namespaceGetFunc(ctx context.Context, client ServiceDiscoveryClient, scope, query string) (*types.Namespace, error) {
+	out, err := client.GetNamespace(ctx, &servicediscovery.GetNamespaceInput{
+		Id: &query,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if out.Namespace == nil {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: "namespace was nil",
+		}
+	}
+
+	return out.Namespace, nil
+}
+
+func namespaceListFunc(ctx context.Context, client ServiceDiscoveryClient, scope string) ([]*types.Namespace, error) {
+	namespaces := make([]*types.Namespace, 0)
+
+	paginator := servicediscovery.NewListNamespacesPaginator(client, &servicediscovery.ListNamespacesInput{})
+
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// The summary has everything that we need apart from the creator
+		// request ID, so there's no need to get each namespace
+		for _, summary := range out.Namespaces {
+			namespaces = append(namespaces, &types.Namespace{
+				Arn:          summary.Arn,
+				CreateDate:   summary.CreateDate,
+				Description:  summary.Description,
+				Id:           summary.Id,
+				Name:         summary.Name,
+				Properties:   summary.Properties,
+				ServiceCount: summary.ServiceCount,
+				Type:         summary.Type,
+			})
+		}
+	}
+
+	return namespaces, nil
+}
+
+func namespaceListTagsFunc(ctx context.Context, namespace *types.Namespace, client ServiceDiscoveryClient) (map[string]string, error) {
+	return tagsByResourceARN(ctx, client, namespace.Arn), nil
+}
+
+func namespaceItemMapper(scope string, awsItem *types.Namespace) (*sdp.Item, error) {
+	attributes, err := sources.ToAttributesCase(awsItem)
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := sdp.Item{
+		Type:            "servicediscovery-namespace",
+		UniqueAttribute: "id",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	if awsItem.Properties != nil && awsItem.Properties.DnsProperties != nil && awsItem.Properties.DnsProperties.HostedZoneId != nil {
+		// +overmind:link route53-hosted-zone
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "route53-hosted-zone",
+				Method: sdp.QueryMethod_GET,
+				Query:  *awsItem.Properties.DnsProperties.HostedZoneId,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Cloud Map manages the records in the hosted zone, so they
+				// are tightly coupled
+				In:  true,
+				Out: true,
+			},
+		})
+	}
+
+	if awsItem.Id != nil {
+		// +overmind:link servicediscovery-service
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "servicediscovery-service",
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  *awsItem.Id,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Changing the services won't affect the namespace
+				In: false,
+				// Changing the namespace affects how every service in it is
+				// discovered
+				Out: true,
+			},
+		})
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type servicediscovery-namespace
+// +overmind:descriptiveType Cloud Map Namespace
+// +overmind:get Get a namespace by ID
+// +overmind:list List all namespaces
+// +overmind:search Search for namespaces by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_service_discovery_http_namespace.id
+// +overmind:terraform:queryMap aws_service_discovery_private_dns_namespace.id
+// +overmind:terraform:queryMap aws_service_discovery_public_dns_namespace.id
+
+func NewNamespaceSource(config aws.Config, accountID string, region string) *sources.GetListSource[*types.Namespace, ServiceDiscoveryClient, *servicediscovery.Options] {
+	return &sources.GetListSource[*types.Namespace, ServiceDiscoveryClient, *servicediscovery.Options]{
+		ItemType:     "servicediscovery-namespace",
+		Client:       servicediscovery.NewFromConfig(config),
+		AccountID:    accountID,
+		Region:       region,
+		GetFunc:      namespaceGetFunc,
+		ListFunc:     namespaceListFunc,
+		ListTagsFunc: namespaceListTagsFunc,
+		ItemMapper:   namespaceItemMapper,
+	}
+}
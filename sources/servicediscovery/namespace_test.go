@@ -0,0 +1,109 @@
+package servicediscovery
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/servicediscovery"
+	"github.com/aws/aws-sdk-go-v2/service/servicediscovery/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testServiceDiscoveryClient) GetNamespace(ctx context.Context, params *servicediscovery.GetNamespaceInput, optFns ...func(*servicediscovery.Options)) (*servicediscovery.GetNamespaceOutput, error) {
+	return &servicediscovery.GetNamespaceOutput{
+		Namespace: &types.Namespace{
+			Arn:        sources.PtrString("arn:aws:servicediscovery:us-east-1:123456789012:namespace/" + *params.Id),
+			CreateDate: sources.PtrTime(time.Now()),
+			Id:         params.Id,
+			Name:       sources.PtrString("internal.example.com"),
+			Properties: &types.NamespaceProperties{
+				DnsProperties: &types.DnsProperties{
+					HostedZoneId: sources.PtrString("Z0123456789ABCDEFGHIJ"), // link
+				},
+			},
+			ServiceCount: sources.PtrInt32(1),
+			Type:         types.NamespaceTypeDnsPrivate,
+		},
+	}, nil
+}
+
+func (c testServiceDiscoveryClient) ListNamespaces(ctx context.Context, params *servicediscovery.ListNamespacesInput, optFns ...func(*servicediscovery.Options)) (*servicediscovery.ListNamespacesOutput, error) {
+	return &servicediscovery.ListNamespacesOutput{
+		Namespaces: []types.NamespaceSummary{
+			{
+				Arn:  sources.PtrString("arn:aws:servicediscovery:us-east-1:123456789012:namespace/ns-e4anhexw6fjyc5ok"),
+				Id:   sources.PtrString("ns-e4anhexw6fjyc5ok"),
+				Name: sources.PtrString("http.example.com"),
+				Type: types.NamespaceTypeHttp,
+			},
+		},
+	}, nil
+}
+
+func TestNamespaceGetFunc(t *testing.T) {
+	scope := "123456789012.us-east-1"
+
+	namespace, err := namespaceGetFunc(context.Background(), testServiceDiscoveryClient{}, scope, "ns-e4anhexw6fjyc5ok")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := namespaceItemMapper(scope, namespace)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := item.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "route53-hosted-zone",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "Z0123456789ABCDEFGHIJ",
+			ExpectedScope:  scope,
+		},
+		{
+			ExpectedType:   "servicediscovery-service",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "ns-e4anhexw6fjyc5ok",
+			ExpectedScope:  scope,
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestNamespaceListFunc(t *testing.T) {
+	namespaces, err := namespaceListFunc(context.Background(), testServiceDiscoveryClient{}, "123456789012.us-east-1")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(namespaces) != 1 {
+		t.Fatalf("expected 1 namespace, got %v", len(namespaces))
+	}
+
+	if *namespaces[0].Id != "ns-e4anhexw6fjyc5ok" {
+		t.Errorf("expected namespace ns-e4anhexw6fjyc5ok, got %v", *namespaces[0].Id)
+	}
+}
+
+func TestNewNamespaceSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewNamespaceSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
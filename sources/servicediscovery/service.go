@@ -0,0 +1,172 @@
+package servicediscovery
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/servicediscovery"
+	"github.com/aws/aws-sdk-go-v2/service/servicediscovery/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func serviceGetFunc(ctx context.Context, client ServiceDiscoveryClient, scope, query string) (*types.Service, error) {
+	out, err := client.GetService(ctx, &servicediscovery.GetServiceInput{
+		Id: &query,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if out.Service == nil {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: "service was nil",
+		}
+	}
+
+	return out.Service, nil
+}
+
+// listServices Lists services and then gets each of them, since the summaries
+// don't include the namespace for HTTP services
+func listServices(ctx context.Context, client ServiceDiscoveryClient, input *servicediscovery.ListServicesInput) ([]*types.Service, error) {
+	services := make([]*types.Service, 0)
+
+	paginator := servicediscovery.NewListServicesPaginator(client, input)
+
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, summary := range out.Services {
+			if summary.Id == nil {
+				continue
+			}
+
+			service, err := serviceGetFunc(ctx, client, "", *summary.Id)
+
+			if err != nil {
+				return nil, err
+			}
+
+			services = append(services, service)
+		}
+	}
+
+	return services, nil
+}
+
+func serviceListFunc(ctx context.Context, client ServiceDiscoveryClient, scope string) ([]*types.Service, error) {
+	return listServices(ctx, client, &servicediscovery.ListServicesInput{})
+}
+
+// serviceSearchFunc Searches for services by ARN, or by the ID of the namespace
+// that they are in
+func serviceSearchFunc(ctx context.Context, client ServiceDiscoveryClient, scope, query string) ([]*types.Service, error) {
+	if a, err := sources.ParseARN(query); err == nil {
+		service, err := serviceGetFunc(ctx, client, scope, a.ResourceID())
+
+		if err != nil {
+			return nil, err
+		}
+
+		return []*types.Service{service}, nil
+	}
+
+	return listServices(ctx, client, &servicediscovery.ListServicesInput{
+		Filters: []types.ServiceFilter{
+			{
+				Name:      types.ServiceFilterNameNamespaceId,
+				Values:    []string{query},
+				Condition: types.FilterConditionEq,
+			},
+		},
+	})
+}
+
+func serviceListTagsFunc(ctx context.Context, service *types.Service, client ServiceDiscoveryClient) (map[string]string, error) {
+	return tagsByResourceARN(ctx, client, service.Arn), nil
+}
+
+func serviceItemMapper(scope string, awsItem *types.Service) (*sdp.Item, error) {
+	attributes, err := sources.ToAttributesCase(awsItem)
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := sdp.Item{
+		Type:            "servicediscovery-service",
+		UniqueAttribute: "id",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	if awsItem.NamespaceId != nil {
+		// +overmind:link servicediscovery-namespace
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "servicediscovery-namespace",
+				Method: sdp.QueryMethod_GET,
+				Query:  *awsItem.NamespaceId,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Changing the namespace will affect how the service is
+				// discovered
+				In: true,
+				// Changing the service won't affect the namespace
+				Out: false,
+			},
+		})
+	}
+
+	if awsItem.Id != nil {
+		// +overmind:link servicediscovery-instance
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "servicediscovery-instance",
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  *awsItem.Id,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// The instances are what the service resolves to, so they
+				// are tightly coupled
+				In:  true,
+				Out: true,
+			},
+		})
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type servicediscovery-service
+// +overmind:descriptiveType Cloud Map Service
+// +overmind:get Get a service by ID
+// +overmind:list List all services
+// +overmind:search Search for services by ARN, or by namespace ID
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_service_discovery_service.arn
+// +overmind:terraform:method SEARCH
+
+func NewServiceSource(config aws.Config, accountID string, region string) *sources.GetListSource[*types.Service, ServiceDiscoveryClient, *servicediscovery.Options] {
+	return &sources.GetListSource[*types.Service, ServiceDiscoveryClient, *servicediscovery.Options]{
+		ItemType:     "servicediscovery-service",
+		Client:       servicediscovery.NewFromConfig(config),
+		AccountID:    accountID,
+		Region:       region,
+		GetFunc:      serviceGetFunc,
+		ListFunc:     serviceListFunc,
+		SearchFunc:   serviceSearchFunc,
+		ListTagsFunc: serviceListTagsFunc,
+		ItemMapper:   serviceItemMapper,
+	}
+}
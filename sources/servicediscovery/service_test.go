@@ -0,0 +1,134 @@
+package servicediscovery
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/servicediscovery"
+	"github.com/aws/aws-sdk-go-v2/service/servicediscovery/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testServiceDiscoveryClient) GetService(ctx context.Context, params *servicediscovery.GetServiceInput, optFns ...func(*servicediscovery.Options)) (*servicediscovery.GetServiceOutput, error) {
+	return &servicediscovery.GetServiceOutput{
+		Service: &types.Service{
+			Arn:           sources.PtrString("arn:aws:servicediscovery:us-east-1:123456789012:service/" + *params.Id),
+			CreateDate:    sources.PtrTime(time.Now()),
+			Id:            params.Id,
+			InstanceCount: sources.PtrInt32(1),
+			Name:          sources.PtrString("api"),
+			NamespaceId:   sources.PtrString("ns-e4anhexw6fjyc5ok"), // link
+			DnsConfig: &types.DnsConfig{
+				DnsRecords: []types.DnsRecord{
+					{
+						TTL:  sources.PtrInt64(60),
+						Type: types.RecordTypeA,
+					},
+				},
+				RoutingPolicy: types.RoutingPolicyMultivalue,
+			},
+			Type: types.ServiceTypeDnsHttp,
+		},
+	}, nil
+}
+
+func (c testServiceDiscoveryClient) ListServices(ctx context.Context, params *servicediscovery.ListServicesInput, optFns ...func(*servicediscovery.Options)) (*servicediscovery.ListServicesOutput, error) {
+	return &servicediscovery.ListServicesOutput{
+		Services: []types.ServiceSummary{
+			{
+				Arn:  sources.PtrString("arn:aws:servicediscovery:us-east-1:123456789012:service/srv-e4anhexw6fjyc5ok"),
+				Id:   sources.PtrString("srv-e4anhexw6fjyc5ok"),
+				Name: sources.PtrString("api"),
+			},
+		},
+	}, nil
+}
+
+func TestServiceGetFunc(t *testing.T) {
+	scope := "123456789012.us-east-1"
+
+	service, err := serviceGetFunc(context.Background(), testServiceDiscoveryClient{}, scope, "srv-e4anhexw6fjyc5ok")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := serviceItemMapper(scope, service)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := item.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "servicediscovery-namespace",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "ns-e4anhexw6fjyc5ok",
+			ExpectedScope:  scope,
+		},
+		{
+			ExpectedType:   "servicediscovery-instance",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "srv-e4anhexw6fjyc5ok",
+			ExpectedScope:  scope,
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestServiceSearchFunc(t *testing.T) {
+	scope := "123456789012.us-east-1"
+
+	t.Run("by ARN", func(t *testing.T) {
+		services, err := serviceSearchFunc(context.Background(), testServiceDiscoveryClient{}, scope, "arn:aws:servicediscovery:us-east-1:123456789012:service/srv-e4anhexw6fjyc5ok")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(services) != 1 {
+			t.Fatalf("expected 1 service, got %v", len(services))
+		}
+
+		if *services[0].Id != "srv-e4anhexw6fjyc5ok" {
+			t.Errorf("expected service srv-e4anhexw6fjyc5ok, got %v", *services[0].Id)
+		}
+	})
+
+	t.Run("by namespace", func(t *testing.T) {
+		services, err := serviceSearchFunc(context.Background(), testServiceDiscoveryClient{}, scope, "ns-e4anhexw6fjyc5ok")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(services) != 1 {
+			t.Fatalf("expected 1 service, got %v", len(services))
+		}
+
+		// The namespace is only available once the service has been fetched
+		if services[0].NamespaceId == nil {
+			t.Error("expected service to have been fetched")
+		}
+	})
+}
+
+func TestNewServiceSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewServiceSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -0,0 +1,44 @@
+package servicediscovery
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/servicediscovery"
+	"github.com/overmindtech/aws-source/sources"
+)
+
+// ServiceDiscoveryClient Represents the client we need to talk to Cloud Map,
+// usually this is *servicediscovery.Client
+type ServiceDiscoveryClient interface {
+	GetInstance(ctx context.Context, params *servicediscovery.GetInstanceInput, optFns ...func(*servicediscovery.Options)) (*servicediscovery.GetInstanceOutput, error)
+	GetNamespace(ctx context.Context, params *servicediscovery.GetNamespaceInput, optFns ...func(*servicediscovery.Options)) (*servicediscovery.GetNamespaceOutput, error)
+	GetService(ctx context.Context, params *servicediscovery.GetServiceInput, optFns ...func(*servicediscovery.Options)) (*servicediscovery.GetServiceOutput, error)
+	ListInstances(ctx context.Context, params *servicediscovery.ListInstancesInput, optFns ...func(*servicediscovery.Options)) (*servicediscovery.ListInstancesOutput, error)
+	ListNamespaces(ctx context.Context, params *servicediscovery.ListNamespacesInput, optFns ...func(*servicediscovery.Options)) (*servicediscovery.ListNamespacesOutput, error)
+	ListServices(ctx context.Context, params *servicediscovery.ListServicesInput, optFns ...func(*servicediscovery.Options)) (*servicediscovery.ListServicesOutput, error)
+	ListTagsForResource(ctx context.Context, params *servicediscovery.ListTagsForResourceInput, optFns ...func(*servicediscovery.Options)) (*servicediscovery.ListTagsForResourceOutput, error)
+}
+
+func tagsByResourceARN(ctx context.Context, client ServiceDiscoveryClient, resourceARN *string) map[string]string {
+	if resourceARN == nil {
+		return nil
+	}
+
+	out, err := client.ListTagsForResource(ctx, &servicediscovery.ListTagsForResourceInput{
+		ResourceARN: resourceARN,
+	})
+
+	if err != nil {
+		return sources.HandleTagsError(ctx, err)
+	}
+
+	tags := make(map[string]string)
+
+	for _, tag := range out.Tags {
+		if tag.Key != nil && tag.Value != nil {
+			tags[*tag.Key] = *tag.Value
+		}
+	}
+
+	return tags
+}
@@ -0,0 +1,96 @@
+package sfn
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func activityGetFunc(ctx context.Context, client SFNClient, scope, query string) (*sfn.DescribeActivityOutput, error) {
+	return client.DescribeActivity(ctx, &sfn.DescribeActivityInput{
+		ActivityArn: &query,
+	})
+}
+
+func activityListFunc(ctx context.Context, client SFNClient, scope string) ([]*sfn.DescribeActivityOutput, error) {
+	activities := make([]*sfn.DescribeActivityOutput, 0)
+
+	paginator := sfn.NewListActivitiesPaginator(client, &sfn.ListActivitiesInput{})
+
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// The list output has everything that describe does, so there's no
+		// need to describe each one
+		for _, activity := range out.Activities {
+			activities = append(activities, &sfn.DescribeActivityOutput{
+				ActivityArn:  activity.ActivityArn,
+				CreationDate: activity.CreationDate,
+				Name:         activity.Name,
+			})
+		}
+	}
+
+	return activities, nil
+}
+
+func activitySearchFunc(ctx context.Context, client SFNClient, scope, query string) ([]*sfn.DescribeActivityOutput, error) {
+	activity, err := activityGetFunc(ctx, client, scope, query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []*sfn.DescribeActivityOutput{activity}, nil
+}
+
+func activityListTagsFunc(ctx context.Context, activity *sfn.DescribeActivityOutput, client SFNClient) (map[string]string, error) {
+	return tagsByResourceARN(ctx, client, activity.ActivityArn), nil
+}
+
+func activityItemMapper(scope string, awsItem *sfn.DescribeActivityOutput) (*sdp.Item, error) {
+	attributes, err := sources.ToAttributesCase(awsItem, "resultMetadata")
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := sdp.Item{
+		Type:            "sfn-activity",
+		UniqueAttribute: "activityArn",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type sfn-activity
+// +overmind:descriptiveType Step Functions Activity
+// +overmind:get Get an activity by ARN
+// +overmind:list List all activities
+// +overmind:search Search for an activity by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_sfn_activity.id
+
+func NewActivitySource(config aws.Config, accountID string, region string) *sources.GetListSource[*sfn.DescribeActivityOutput, SFNClient, *sfn.Options] {
+	return &sources.GetListSource[*sfn.DescribeActivityOutput, SFNClient, *sfn.Options]{
+		ItemType:     "sfn-activity",
+		Client:       sfn.NewFromConfig(config),
+		AccountID:    accountID,
+		Region:       region,
+		GetFunc:      activityGetFunc,
+		ListFunc:     activityListFunc,
+		SearchFunc:   activitySearchFunc,
+		ListTagsFunc: activityListTagsFunc,
+		ItemMapper:   activityItemMapper,
+	}
+}
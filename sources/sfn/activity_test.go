@@ -0,0 +1,78 @@
+package sfn
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	"github.com/aws/aws-sdk-go-v2/service/sfn/types"
+	"github.com/overmindtech/aws-source/sources"
+)
+
+func (c testSFNClient) DescribeActivity(ctx context.Context, params *sfn.DescribeActivityInput, optFns ...func(*sfn.Options)) (*sfn.DescribeActivityOutput, error) {
+	return &sfn.DescribeActivityOutput{
+		ActivityArn:  params.ActivityArn,
+		CreationDate: sources.PtrTime(time.Now()),
+		Name:         sources.PtrString("approve-order"),
+	}, nil
+}
+
+func (c testSFNClient) ListActivities(ctx context.Context, params *sfn.ListActivitiesInput, optFns ...func(*sfn.Options)) (*sfn.ListActivitiesOutput, error) {
+	return &sfn.ListActivitiesOutput{
+		Activities: []types.ActivityListItem{
+			{
+				ActivityArn:  sources.PtrString("arn:aws:states:us-east-1:123456789012:activity:approve-order"),
+				CreationDate: sources.PtrTime(time.Now()),
+				Name:         sources.PtrString("approve-order"),
+			},
+		},
+	}, nil
+}
+
+func TestActivityGetFunc(t *testing.T) {
+	activity, err := activityGetFunc(context.Background(), testSFNClient{}, "123456789012.us-east-1", "arn:aws:states:us-east-1:123456789012:activity:approve-order")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := activityItemMapper("123456789012.us-east-1", activity)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := item.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestActivityListFunc(t *testing.T) {
+	activities, err := activityListFunc(context.Background(), testSFNClient{}, "123456789012.us-east-1")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(activities) != 1 {
+		t.Fatalf("expected 1 activity, got %v", len(activities))
+	}
+
+	if *activities[0].Name != "approve-order" {
+		t.Errorf("expected activity approve-order, got %v", *activities[0].Name)
+	}
+}
+
+func TestNewActivitySource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewActivitySource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -0,0 +1,291 @@
+package sfn
+
+import (
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+// aslDefinition The parts of an Amazon States Language definition that we
+// need in order to find the resources that a state machine uses. States can
+// contain nested definitions in Parallel branches and Map iterators
+type aslDefinition struct {
+	States map[string]aslState `json:"States"`
+}
+
+type aslState struct {
+	Type       string                 `json:"Type"`
+	Resource   string                 `json:"Resource"`
+	Parameters map[string]interface{} `json:"Parameters"`
+
+	// Parallel states
+	Branches []aslDefinition `json:"Branches"`
+
+	// Map states. Iterator is the older name for ItemProcessor
+	Iterator      *aslDefinition `json:"Iterator"`
+	ItemProcessor *aslDefinition `json:"ItemProcessor"`
+}
+
+// parseDefinition Parses an ASL definition
+func parseDefinition(definition string) (*aslDefinition, error) {
+	var d aslDefinition
+
+	err := json.Unmarshal([]byte(definition), &d)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// taskStates Returns all Task states in the definition, including those in
+// nested Parallel and Map states. States are returned in name order so that
+// the resulting links are stable
+func (d *aslDefinition) taskStates() []aslState {
+	tasks := make([]aslState, 0)
+
+	if d == nil {
+		return tasks
+	}
+
+	names := make([]string, 0, len(d.States))
+
+	for name := range d.States {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		state := d.States[name]
+
+		switch state.Type {
+		case "Task":
+			tasks = append(tasks, state)
+		case "Parallel":
+			for i := range state.Branches {
+				tasks = append(tasks, state.Branches[i].taskStates()...)
+			}
+		case "Map":
+			tasks = append(tasks, state.Iterator.taskStates()...)
+			tasks = append(tasks, state.ItemProcessor.taskStates()...)
+		}
+	}
+
+	return tasks
+}
+
+// stringParameter Returns a parameter that has a literal string value.
+// Parameters whose names end in ".$" are JSONPath expressions that are only
+// resolved at runtime so they can't be linked
+func (s aslState) stringParameter(name string) (string, bool) {
+	if s.Parameters == nil {
+		return "", false
+	}
+
+	value, ok := s.Parameters[name].(string)
+
+	if !ok || value == "" {
+		return "", false
+	}
+
+	return value, true
+}
+
+// taskBlastPropagation The state machine invokes the resources in its tasks,
+// and changes to those resources can break the workflow, so these links go
+// both ways
+func taskBlastPropagation() *sdp.BlastPropagation {
+	return &sdp.BlastPropagation{
+		In:  true,
+		Out: true,
+	}
+}
+
+// arnOrNameLink Links to a resource that can be referenced by either its ARN
+// or its name. Names are assumed to be in the same scope as the state machine
+func arnOrNameLink(queryType string, value string, scope string) *sdp.LinkedItemQuery {
+	if a, err := sources.ParseARN(value); err == nil {
+		return &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   queryType,
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  value,
+				Scope:  sources.FormatScope(a.AccountID, a.Region),
+			},
+			BlastPropagation: taskBlastPropagation(),
+		}
+	}
+
+	return &sdp.LinkedItemQuery{
+		Query: &sdp.Query{
+			Type:   queryType,
+			Method: sdp.QueryMethod_GET,
+			Query:  value,
+			Scope:  scope,
+		},
+		BlastPropagation: taskBlastPropagation(),
+	}
+}
+
+// sqsQueueLink Links to an SQS queue by URL. Queue URLs are in the format
+// https://sqs.{region}.amazonaws.com/{account}/{name}, so the scope can be
+// worked out from the URL
+func sqsQueueLink(queueURL string, scope string) *sdp.LinkedItemQuery {
+	queueScope := scope
+
+	if u, err := url.Parse(queueURL); err == nil {
+		path := strings.Split(strings.Trim(u.Path, "/"), "/")
+		host := strings.Split(u.Hostname(), ".")
+
+		if len(path) == 2 && len(host) > 2 && host[0] == "sqs" {
+			queueScope = sources.FormatScope(path[0], host[1])
+		}
+	}
+
+	return &sdp.LinkedItemQuery{
+		Query: &sdp.Query{
+			Type:   "sqs-queue",
+			Method: sdp.QueryMethod_GET,
+			Query:  queueURL,
+			Scope:  queueScope,
+		},
+		BlastPropagation: taskBlastPropagation(),
+	}
+}
+
+// integrationLinks Returns links for a task that uses an optimised service
+// integration e.g. arn:aws:states:::sqs:sendMessage. The resources for these
+// are in the task's parameters rather than the resource ARN
+func integrationLinks(integration string, state aslState, scope string) []*sdp.LinkedItemQuery {
+	links := make([]*sdp.LinkedItemQuery, 0)
+
+	// Remove the integration pattern e.g. ".sync" or ".waitForTaskToken"
+	integration, _, _ = strings.Cut(integration, ".")
+	service, _, _ := strings.Cut(integration, ":")
+
+	switch service {
+	case "lambda":
+		if name, ok := state.stringParameter("FunctionName"); ok {
+			if _, err := sources.ParseARN(name); err != nil {
+				// This could be a partial ARN ({account}:function:{name}) or
+				// include a qualifier, neither of which can be used for a GET
+				if _, after, found := strings.Cut(name, "function:"); found {
+					name = after
+				}
+
+				name, _, _ = strings.Cut(name, ":")
+			}
+
+			links = append(links, arnOrNameLink("lambda-function", name, scope))
+		}
+	case "sqs":
+		if queueURL, ok := state.stringParameter("QueueUrl"); ok {
+			links = append(links, sqsQueueLink(queueURL, scope))
+		}
+	case "sns":
+		if topicARN, ok := state.stringParameter("TopicArn"); ok {
+			links = append(links, arnOrNameLink("sns-topic", topicARN, scope))
+		}
+	case "ecs":
+		if cluster, ok := state.stringParameter("Cluster"); ok {
+			links = append(links, arnOrNameLink("ecs-cluster", cluster, scope))
+		}
+
+		// Task definitions can only be fetched with a revision, if this is
+		// just the family then we can't tell which revision will be used
+		if taskDefinition, ok := state.stringParameter("TaskDefinition"); ok && strings.Contains(taskDefinition, ":") {
+			links = append(links, arnOrNameLink("ecs-task-definition", taskDefinition, scope))
+		}
+	case "dynamodb":
+		if tableName, ok := state.stringParameter("TableName"); ok {
+			links = append(links, arnOrNameLink("dynamodb-table", tableName, scope))
+		}
+	case "states":
+		if stateMachineARN, ok := state.stringParameter("StateMachineArn"); ok {
+			if a, err := sources.ParseARN(stateMachineARN); err == nil {
+				links = append(links, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "sfn-state-machine",
+						Method: sdp.QueryMethod_SEARCH,
+						Query:  stateMachineARN,
+						Scope:  sources.FormatScope(a.AccountID, a.Region),
+					},
+					BlastPropagation: taskBlastPropagation(),
+				})
+			}
+		}
+	}
+
+	return links
+}
+
+// taskLinks Returns the links for a single Task state
+func taskLinks(state aslState, scope string) []*sdp.LinkedItemQuery {
+	a, err := sources.ParseARN(state.Resource)
+
+	if err != nil {
+		return nil
+	}
+
+	switch a.Service {
+	case "lambda":
+		// Lambda functions can be invoked directly by ARN
+		return []*sdp.LinkedItemQuery{
+			{
+				Query: &sdp.Query{
+					Type:   "lambda-function",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  state.Resource,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: taskBlastPropagation(),
+			},
+		}
+	case "states":
+		if a.AccountID == "" {
+			// Service integrations have no region or account
+			return integrationLinks(a.Resource, state, scope)
+		}
+
+		if a.Type() == "activity" {
+			return []*sdp.LinkedItemQuery{
+				{
+					Query: &sdp.Query{
+						Type:   "sfn-activity",
+						Method: sdp.QueryMethod_GET,
+						Query:  state.Resource,
+						Scope:  sources.FormatScope(a.AccountID, a.Region),
+					},
+					BlastPropagation: taskBlastPropagation(),
+				},
+			}
+		}
+	}
+
+	return nil
+}
+
+// definitionLinks Parses an ASL definition and returns links to the resources
+// used by each of its Task states. Definitions that can't be parsed have no
+// links
+func definitionLinks(definition string, scope string) []*sdp.LinkedItemQuery {
+	links := make([]*sdp.LinkedItemQuery, 0)
+
+	d, err := parseDefinition(definition)
+
+	if err != nil {
+		return links
+	}
+
+	for _, state := range d.taskStates() {
+		links = append(links, taskLinks(state, scope)...)
+	}
+
+	return links
+}
@@ -0,0 +1,42 @@
+package sfn
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	"github.com/overmindtech/aws-source/sources"
+)
+
+// SFNClient Represents the client we need to talk to Step Functions, usually
+// this is *sfn.Client
+type SFNClient interface {
+	DescribeActivity(ctx context.Context, params *sfn.DescribeActivityInput, optFns ...func(*sfn.Options)) (*sfn.DescribeActivityOutput, error)
+	DescribeStateMachine(ctx context.Context, params *sfn.DescribeStateMachineInput, optFns ...func(*sfn.Options)) (*sfn.DescribeStateMachineOutput, error)
+	ListActivities(ctx context.Context, params *sfn.ListActivitiesInput, optFns ...func(*sfn.Options)) (*sfn.ListActivitiesOutput, error)
+	ListStateMachines(ctx context.Context, params *sfn.ListStateMachinesInput, optFns ...func(*sfn.Options)) (*sfn.ListStateMachinesOutput, error)
+	ListTagsForResource(ctx context.Context, params *sfn.ListTagsForResourceInput, optFns ...func(*sfn.Options)) (*sfn.ListTagsForResourceOutput, error)
+}
+
+func tagsByResourceARN(ctx context.Context, client SFNClient, resourceARN *string) map[string]string {
+	if resourceARN == nil {
+		return nil
+	}
+
+	out, err := client.ListTagsForResource(ctx, &sfn.ListTagsForResourceInput{
+		ResourceArn: resourceARN,
+	})
+
+	if err != nil {
+		return sources.HandleTagsError(ctx, err)
+	}
+
+	tags := make(map[string]string)
+
+	for _, tag := range out.Tags {
+		if tag.Key != nil && tag.Value != nil {
+			tags[*tag.Key] = *tag.Value
+		}
+	}
+
+	return tags
+}
@@ -0,0 +1,172 @@
+package sfn
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	"github.com/aws/aws-sdk-go-v2/service/sfn/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func stateMachineGetFunc(ctx context.Context, client SFNClient, scope, query string) (*sfn.DescribeStateMachineOutput, error) {
+	return client.DescribeStateMachine(ctx, &sfn.DescribeStateMachineInput{
+		StateMachineArn: &query,
+	})
+}
+
+// stateMachineListFunc Lists state machines and then describes each of them,
+// since the list output doesn't include the definition
+func stateMachineListFunc(ctx context.Context, client SFNClient, scope string) ([]*sfn.DescribeStateMachineOutput, error) {
+	stateMachines := make([]*sfn.DescribeStateMachineOutput, 0)
+
+	paginator := sfn.NewListStateMachinesPaginator(client, &sfn.ListStateMachinesInput{})
+
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, stateMachine := range out.StateMachines {
+			if stateMachine.StateMachineArn == nil {
+				continue
+			}
+
+			describeOut, err := stateMachineGetFunc(ctx, client, scope, *stateMachine.StateMachineArn)
+
+			if err != nil {
+				return nil, err
+			}
+
+			stateMachines = append(stateMachines, describeOut)
+		}
+	}
+
+	return stateMachines, nil
+}
+
+// stateMachineSearchFunc Searches for a state machine by ARN. This is the same
+// as a GET, but allows links from other resources that only know the ARN to
+// use the same method as the rest of AWS
+func stateMachineSearchFunc(ctx context.Context, client SFNClient, scope, query string) ([]*sfn.DescribeStateMachineOutput, error) {
+	stateMachine, err := stateMachineGetFunc(ctx, client, scope, query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []*sfn.DescribeStateMachineOutput{stateMachine}, nil
+}
+
+func stateMachineListTagsFunc(ctx context.Context, stateMachine *sfn.DescribeStateMachineOutput, client SFNClient) (map[string]string, error) {
+	return tagsByResourceARN(ctx, client, stateMachine.StateMachineArn), nil
+}
+
+func stateMachineItemMapper(scope string, awsItem *sfn.DescribeStateMachineOutput) (*sdp.Item, error) {
+	attributes, err := sources.ToAttributesCase(awsItem, "resultMetadata")
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := sdp.Item{
+		Type:            "sfn-state-machine",
+		UniqueAttribute: "stateMachineArn",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	switch awsItem.Status {
+	case types.StateMachineStatusActive:
+		item.Health = sdp.Health_HEALTH_OK.Enum()
+	case types.StateMachineStatusDeleting:
+		item.Health = sdp.Health_HEALTH_WARNING.Enum()
+	}
+
+	if awsItem.RoleArn != nil {
+		if a, err := sources.ParseARN(*awsItem.RoleArn); err == nil {
+			// +overmind:link iam-role
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "iam-role",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  *awsItem.RoleArn,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the role will affect what the state machine
+					// can do
+					In: true,
+					// Changing the state machine won't affect the role
+					Out: false,
+				},
+			})
+		}
+	}
+
+	if awsItem.LoggingConfiguration != nil {
+		for _, destination := range awsItem.LoggingConfiguration.Destinations {
+			if destination.CloudWatchLogsLogGroup == nil || destination.CloudWatchLogsLogGroup.LogGroupArn == nil {
+				continue
+			}
+
+			if a, err := sources.ParseARN(*destination.CloudWatchLogsLogGroup.LogGroupArn); err == nil {
+				// +overmind:link logs-log-group
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "logs-log-group",
+						Method: sdp.QueryMethod_SEARCH,
+						Query:  *destination.CloudWatchLogsLogGroup.LogGroupArn,
+						Scope:  sources.FormatScope(a.AccountID, a.Region),
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// Changing the log group won't affect the state machine
+						In: false,
+						// Execution history is sent to the log group
+						Out: true,
+					},
+				})
+			}
+		}
+	}
+
+	if awsItem.Definition != nil {
+		// +overmind:link lambda-function
+		// +overmind:link sqs-queue
+		// +overmind:link sns-topic
+		// +overmind:link ecs-cluster
+		// +overmind:link ecs-task-definition
+		// +overmind:link dynamodb-table
+		// +overmind:link sfn-state-machine
+		// +overmind:link sfn-activity
+		item.LinkedItemQueries = append(item.LinkedItemQueries, definitionLinks(*awsItem.Definition, scope)...)
+	}
+
+	return &item, nil
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type sfn-state-machine
+// +overmind:descriptiveType Step Functions State Machine
+// +overmind:get Get a state machine by ARN
+// +overmind:list List all state machines
+// +overmind:search Search for a state machine by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_sfn_state_machine.arn
+
+func NewStateMachineSource(config aws.Config, accountID string, region string) *sources.GetListSource[*sfn.DescribeStateMachineOutput, SFNClient, *sfn.Options] {
+	return &sources.GetListSource[*sfn.DescribeStateMachineOutput, SFNClient, *sfn.Options]{
+		ItemType:     "sfn-state-machine",
+		Client:       sfn.NewFromConfig(config),
+		AccountID:    accountID,
+		Region:       region,
+		GetFunc:      stateMachineGetFunc,
+		ListFunc:     stateMachineListFunc,
+		SearchFunc:   stateMachineSearchFunc,
+		ListTagsFunc: stateMachineListTagsFunc,
+		ItemMapper:   stateMachineItemMapper,
+	}
+}
@@ -0,0 +1,286 @@
+package sfn
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	"github.com/aws/aws-sdk-go-v2/service/sfn/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+const testDefinition = `{
+  "Comment": "Processes an order",
+  "StartAt": "Validate",
+  "States": {
+    "Validate": {
+      "Type": "Task",
+      "Resource": "arn:aws:lambda:us-east-1:123456789012:function:validate-order",
+      "Next": "Fulfil"
+    },
+    "Fulfil": {
+      "Type": "Parallel",
+      "Branches": [
+        {
+          "StartAt": "Reserve",
+          "States": {
+            "Reserve": {
+              "Type": "Task",
+              "Resource": "arn:aws:states:::dynamodb:putItem",
+              "Parameters": {
+                "TableName": "reservations",
+                "Item": {
+                  "OrderId": {
+                    "S.$": "$.orderId"
+                  }
+                }
+              },
+              "End": true
+            }
+          }
+        },
+        {
+          "StartAt": "Ship",
+          "States": {
+            "Ship": {
+              "Type": "Task",
+              "Resource": "arn:aws:states:::ecs:runTask.sync",
+              "Parameters": {
+                "Cluster": "arn:aws:ecs:us-east-1:123456789012:cluster/fulfilment",
+                "TaskDefinition": "shipping:3",
+                "LaunchType": "FARGATE"
+              },
+              "End": true
+            }
+          }
+        }
+      ],
+      "Next": "NotifyItems"
+    },
+    "NotifyItems": {
+      "Type": "Map",
+      "ItemsPath": "$.items",
+      "ItemProcessor": {
+        "StartAt": "Notify",
+        "States": {
+          "Notify": {
+            "Type": "Task",
+            "Resource": "arn:aws:states:::lambda:invoke",
+            "Parameters": {
+              "FunctionName": "notify-customer:live",
+              "Payload.$": "$"
+            },
+            "End": true
+          }
+        }
+      },
+      "Next": "Queue"
+    },
+    "Queue": {
+      "Type": "Task",
+      "Resource": "arn:aws:states:::sqs:sendMessage",
+      "Parameters": {
+        "QueueUrl": "https://sqs.us-east-1.amazonaws.com/123456789012/invoices",
+        "MessageBody.$": "$"
+      },
+      "Next": "DynamicQueue"
+    },
+    "DynamicQueue": {
+      "Type": "Task",
+      "Resource": "arn:aws:states:::sqs:sendMessage",
+      "Parameters": {
+        "QueueUrl.$": "$.queueUrl",
+        "MessageBody.$": "$"
+      },
+      "Next": "Approve"
+    },
+    "Approve": {
+      "Type": "Task",
+      "Resource": "arn:aws:states:us-east-1:123456789012:activity:approve-order",
+      "Next": "Archive"
+    },
+    "Archive": {
+      "Type": "Task",
+      "Resource": "arn:aws:states:::states:startExecution.sync:2",
+      "Parameters": {
+        "StateMachineArn": "arn:aws:states:us-east-1:123456789012:stateMachine:archive-order",
+        "Input.$": "$"
+      },
+      "Next": "Done"
+    },
+    "Done": {
+      "Type": "Succeed"
+    }
+  }
+}`
+
+func (c testSFNClient) DescribeStateMachine(ctx context.Context, params *sfn.DescribeStateMachineInput, optFns ...func(*sfn.Options)) (*sfn.DescribeStateMachineOutput, error) {
+	return &sfn.DescribeStateMachineOutput{
+		CreationDate:    sources.PtrTime(time.Now()),
+		Definition:      sources.PtrString(testDefinition),
+		Name:            sources.PtrString("process-order"),
+		RoleArn:         sources.PtrString("arn:aws:iam::123456789012:role/process-order"), // link
+		StateMachineArn: params.StateMachineArn,
+		Type:            types.StateMachineTypeStandard,
+		Status:          types.StateMachineStatusActive, // health
+		LoggingConfiguration: &types.LoggingConfiguration{
+			Level: types.LogLevelError,
+			Destinations: []types.LogDestination{
+				{
+					CloudWatchLogsLogGroup: &types.CloudWatchLogsLogGroup{
+						LogGroupArn: sources.PtrString("arn:aws:logs:us-east-1:123456789012:log-group:/aws/states/process-order:*"), // link
+					},
+				},
+			},
+		},
+		TracingConfiguration: &types.TracingConfiguration{
+			Enabled: true,
+		},
+	}, nil
+}
+
+func (c testSFNClient) ListStateMachines(ctx context.Context, params *sfn.ListStateMachinesInput, optFns ...func(*sfn.Options)) (*sfn.ListStateMachinesOutput, error) {
+	return &sfn.ListStateMachinesOutput{
+		StateMachines: []types.StateMachineListItem{
+			{
+				CreationDate:    sources.PtrTime(time.Now()),
+				Name:            sources.PtrString("process-order"),
+				StateMachineArn: sources.PtrString("arn:aws:states:us-east-1:123456789012:stateMachine:process-order"),
+				Type:            types.StateMachineTypeStandard,
+			},
+		},
+	}, nil
+}
+
+func TestStateMachineGetFunc(t *testing.T) {
+	scope := "123456789012.us-east-1"
+
+	stateMachine, err := stateMachineGetFunc(context.Background(), testSFNClient{}, scope, "arn:aws:states:us-east-1:123456789012:stateMachine:process-order")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := stateMachineItemMapper(scope, stateMachine)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := item.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if item.GetHealth() != sdp.Health_HEALTH_OK {
+		t.Errorf("expected health OK, got %v", item.GetHealth())
+	}
+
+	// The dynamic queue should not be linked
+	if len(item.GetLinkedItemQueries()) != 10 {
+		t.Errorf("expected 10 links, got %v", len(item.GetLinkedItemQueries()))
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "iam-role",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:iam::123456789012:role/process-order",
+			ExpectedScope:  "123456789012",
+		},
+		{
+			ExpectedType:   "logs-log-group",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:logs:us-east-1:123456789012:log-group:/aws/states/process-order:*",
+			ExpectedScope:  scope,
+		},
+		{
+			ExpectedType:   "lambda-function",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:lambda:us-east-1:123456789012:function:validate-order",
+			ExpectedScope:  scope,
+		},
+		{
+			ExpectedType:   "dynamodb-table",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "reservations",
+			ExpectedScope:  scope,
+		},
+		{
+			ExpectedType:   "ecs-cluster",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:ecs:us-east-1:123456789012:cluster/fulfilment",
+			ExpectedScope:  scope,
+		},
+		{
+			ExpectedType:   "ecs-task-definition",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "shipping:3",
+			ExpectedScope:  scope,
+		},
+		{
+			ExpectedType:   "lambda-function",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "notify-customer",
+			ExpectedScope:  scope,
+		},
+		{
+			ExpectedType:   "sqs-queue",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "https://sqs.us-east-1.amazonaws.com/123456789012/invoices",
+			ExpectedScope:  scope,
+		},
+		{
+			ExpectedType:   "sfn-activity",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "arn:aws:states:us-east-1:123456789012:activity:approve-order",
+			ExpectedScope:  scope,
+		},
+		{
+			ExpectedType:   "sfn-state-machine",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:states:us-east-1:123456789012:stateMachine:archive-order",
+			ExpectedScope:  scope,
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestStateMachineListFunc(t *testing.T) {
+	stateMachines, err := stateMachineListFunc(context.Background(), testSFNClient{}, "123456789012.us-east-1")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stateMachines) != 1 {
+		t.Fatalf("expected 1 state machine, got %v", len(stateMachines))
+	}
+
+	if stateMachines[0].Definition == nil {
+		t.Error("expected state machine to be described")
+	}
+}
+
+func TestDefinitionLinksInvalid(t *testing.T) {
+	links := definitionLinks("not json", "123456789012.us-east-1")
+
+	if len(links) != 0 {
+		t.Errorf("expected no links, got %v", len(links))
+	}
+}
+
+func TestNewStateMachineSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewStateMachineSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
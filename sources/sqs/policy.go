@@ -0,0 +1,96 @@
+package sqs
+
+import (
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/aws-source/sources/iampolicy"
+	"github.com/overmindtech/sdp-go"
+)
+
+// redrivePolicy The contents of the RedrivePolicy attribute, which sets the
+// dead-letter queue for a queue
+type redrivePolicy struct {
+	DeadLetterTargetArn string `json:"deadLetterTargetArn"`
+}
+
+// redriveAllowPolicy The contents of the RedriveAllowPolicy attribute, which
+// controls which source queues can use this queue as a dead-letter queue
+type redriveAllowPolicy struct {
+	RedrivePermission string   `json:"redrivePermission"`
+	SourceQueueArns   []string `json:"sourceQueueArns"`
+}
+
+// policySourceLinks Parses a queue's access policy and returns links to the
+// SNS topics, S3 buckets and EventBridge rules that are allowed to send
+// messages to it. These are identified using the aws:SourceArn condition key,
+// which is how AWS recommends granting these services access
+func policySourceLinks(policy string, queueScope string) []*sdp.LinkedItemQuery {
+	doc, err := iampolicy.Parse(policy)
+
+	if err != nil {
+		return nil
+	}
+
+	links := make([]*sdp.LinkedItemQuery, 0)
+
+	for _, statement := range doc.Statement {
+		if statement.Effect != iampolicy.EffectAllow {
+			continue
+		}
+
+		for _, sourceARN := range statement.SourceARNs() {
+			if iampolicy.IsWildcard(sourceARN) {
+				// Wildcards can't be resolved to a specific item
+				continue
+			}
+
+			a, err := sources.ParseARN(sourceARN)
+
+			if err != nil {
+				continue
+			}
+
+			var queryType string
+			scope := sources.FormatScope(a.AccountID, a.Region)
+
+			switch a.Service {
+			case "sns":
+				queryType = "sns-topic"
+			case "events":
+				queryType = "events-rule"
+			case "s3":
+				queryType = "s3-bucket"
+
+				// Bucket ARNs don't contain the account, so this has to come
+				// from the aws:SourceAccount condition, or we assume that it's
+				// the same account as the queue
+				if accounts := statement.SourceAccounts(); len(accounts) > 0 {
+					scope = sources.FormatScope(accounts[0], "")
+				} else if accountID, _, err := sources.ParseScope(queueScope); err == nil {
+					scope = sources.FormatScope(accountID, "")
+				} else {
+					continue
+				}
+			default:
+				continue
+			}
+
+			links = append(links, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   queryType,
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  sourceARN,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changes to the source will affect the messages that
+					// arrive in the queue
+					In: true,
+					// Changing the queue won't affect the source
+					Out: false,
+				},
+			})
+		}
+	}
+
+	return links
+}
@@ -2,6 +2,9 @@ package sqs
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
@@ -12,6 +15,7 @@ import (
 
 type client interface {
 	GetQueueAttributes(ctx context.Context, params *sqs.GetQueueAttributesInput, optFns ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error)
+	ListDeadLetterSourceQueues(ctx context.Context, params *sqs.ListDeadLetterSourceQueuesInput, optFns ...func(*sqs.Options)) (*sqs.ListDeadLetterSourceQueuesOutput, error)
 	ListQueueTags(ctx context.Context, params *sqs.ListQueueTagsInput, optFns ...func(*sqs.Options)) (*sqs.ListQueueTagsOutput, error)
 	ListQueues(context.Context, *sqs.ListQueuesInput, ...func(*sqs.Options)) (*sqs.ListQueuesOutput, error)
 }
@@ -47,13 +51,165 @@ func getFunc(ctx context.Context, client client, scope string, input *sqs.GetQue
 		}
 	}
 
-	return &sdp.Item{
+	item := sdp.Item{
 		Type:            "sqs-queue",
 		UniqueAttribute: "queueURL",
 		Attributes:      attributes,
 		Scope:           scope,
 		Tags:            resourceTags,
-	}, nil
+	}
+
+	if queueARN, ok := output.Attributes["QueueArn"]; ok {
+		// +overmind:link lambda-event-source-mapping
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "lambda-event-source-mapping",
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  queueARN,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Messages in the queue are delivered to the function by the
+				// mapping, so these are tightly coupled
+				In:  true,
+				Out: true,
+			},
+		})
+	}
+
+	if redrive, ok := output.Attributes["RedrivePolicy"]; ok {
+		var policy redrivePolicy
+
+		if err := json.Unmarshal([]byte(redrive), &policy); err == nil {
+			if a, err := sources.ParseARN(policy.DeadLetterTargetArn); err == nil {
+				// +overmind:link sqs-queue
+				item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+					Query: &sdp.Query{
+						Type:   "sqs-queue",
+						Method: sdp.QueryMethod_SEARCH,
+						Query:  policy.DeadLetterTargetArn,
+						Scope:  sources.FormatScope(a.AccountID, a.Region),
+					},
+					BlastPropagation: &sdp.BlastPropagation{
+						// If the dead-letter queue is deleted, messages that
+						// fail processing will be lost
+						In: true,
+						// Failed messages are sent to the dead-letter queue
+						Out: true,
+					},
+				})
+			}
+		}
+	}
+
+	if redriveAllow, ok := output.Attributes["RedriveAllowPolicy"]; ok {
+		var policy redriveAllowPolicy
+
+		if err := json.Unmarshal([]byte(redriveAllow), &policy); err == nil && policy.RedrivePermission == "byQueue" {
+			for _, sourceARN := range policy.SourceQueueArns {
+				if a, err := sources.ParseARN(sourceARN); err == nil {
+					// +overmind:link sqs-queue
+					item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+						Query: &sdp.Query{
+							Type:   "sqs-queue",
+							Method: sdp.QueryMethod_SEARCH,
+							Query:  sourceARN,
+							Scope:  sources.FormatScope(a.AccountID, a.Region),
+						},
+						BlastPropagation: &sdp.BlastPropagation{
+							// Failed messages from the source queue end up here
+							In: true,
+							// Changing this queue affects where the source
+							// queue's failed messages go
+							Out: true,
+						},
+					})
+				}
+			}
+		}
+	}
+
+	// Queues that use this one as their dead-letter queue. These are always
+	// in the same account and region
+	sourceQueues := sqs.NewListDeadLetterSourceQueuesPaginator(client, &sqs.ListDeadLetterSourceQueuesInput{
+		QueueUrl: input.QueueUrl,
+	})
+
+	for sourceQueues.HasMorePages() {
+		out, err := sourceQueues.NextPage(ctx)
+
+		if err != nil {
+			// This is best-effort, the queue is still valid without it
+			break
+		}
+
+		for _, url := range out.QueueUrls {
+			// +overmind:link sqs-queue
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "sqs-queue",
+					Method: sdp.QueryMethod_GET,
+					Query:  url,
+					Scope:  scope,
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Failed messages from the source queue end up here
+					In: true,
+					// Changing this queue affects where the source queue's
+					// failed messages go
+					Out: true,
+				},
+			})
+		}
+	}
+
+	// The key can also be referenced by an alias, either as alias/{name} or
+	// as an alias ARN. These can't be resolved to a key so aren't linked
+	if keyID, ok := output.Attributes["KmsMasterKeyId"]; ok && keyID != "" && !strings.HasPrefix(keyID, "alias/") && !strings.Contains(keyID, ":alias/") {
+		// +overmind:link kms-key
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "kms-key",
+				Method: sdp.QueryMethod_GET,
+				Query:  keyID,
+				Scope:  scope,
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Changing the key will affect the queue
+				In: true,
+				// Changing the queue won't affect the key
+				Out: false,
+			},
+		})
+	}
+
+	if policy, ok := output.Attributes["Policy"]; ok {
+		// +overmind:link sns-topic
+		// +overmind:link s3-bucket
+		// +overmind:link events-rule
+		item.LinkedItemQueries = append(item.LinkedItemQueries, policySourceLinks(policy, scope)...)
+	}
+
+	return &item, nil
+}
+
+// queueURL Returns the URL of a queue from its ARN. The API only accepts URLs,
+// but other queues and services refer to queues by ARN
+func queueURL(queueARN string) (string, error) {
+	a, err := sources.ParseARN(queueARN)
+
+	if err != nil {
+		return "", err
+	}
+
+	if a.Service != "sqs" || a.Resource == "" {
+		return "", &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: fmt.Sprintf("%v is not an SQS queue ARN", queueARN),
+		}
+	}
+
+	return fmt.Sprintf("https://sqs.%v.amazonaws.com/%v/%v", a.Region, a.AccountID, a.Resource), nil
 }
 
 //go:generate docgen ../../docs-data
@@ -79,6 +235,18 @@ func NewQueueSource(config aws.Config, accountID string, region string) *sources
 				AttributeNames: []types.QueueAttributeName{"All"},
 			}
 		},
+		SearchGetInputMapper: func(scope, query string) (*sqs.GetQueueAttributesInput, error) {
+			url, err := queueURL(query)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return &sqs.GetQueueAttributesInput{
+				QueueUrl:       &url,
+				AttributeNames: []types.QueueAttributeName{"All"},
+			}, nil
+		},
 		ListFuncPaginatorBuilder: func(client client, input *sqs.ListQueuesInput) sources.Paginator[*sqs.ListQueuesOutput, *sqs.Options] {
 			return sqs.NewListQueuesPaginator(client, input)
 		},
@@ -86,7 +254,8 @@ func NewQueueSource(config aws.Config, accountID string, region string) *sources
 			var inputs []*sqs.GetQueueAttributesInput
 			for _, url := range output.QueueUrls {
 				inputs = append(inputs, &sqs.GetQueueAttributesInput{
-					QueueUrl: &url,
+					QueueUrl:       &url,
+					AttributeNames: []types.QueueAttributeName{"All"},
 				})
 			}
 			return inputs, nil
@@ -2,11 +2,14 @@ package sqs
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
 )
 
 type testClient struct{}
@@ -26,6 +29,47 @@ func (t testClient) GetQueueAttributes(ctx context.Context, params *sqs.GetQueue
 			"ReceiveMessageWaitTimeSeconds":         "0",
 			"VisibilityTimeout":                     "30",
 			"RedrivePolicy":                         "{\"deadLetterTargetArn\":\"arn:aws:sqs:us-east-1:80398EXAMPLE:MyDeadLetterQueue\",\"maxReceiveCount\":1000}",
+			"RedriveAllowPolicy":                    "{\"redrivePermission\":\"byQueue\",\"sourceQueueArns\":[\"arn:aws:sqs:us-west-2:123456789012:MySourceQueue\"]}",
+			"KmsMasterKeyId":                        "alias/aws/sqs",
+			"Policy":                                testQueuePolicy,
+		},
+	}, nil
+}
+
+const testQueuePolicy = `{
+	"Version": "2012-10-17",
+	"Statement": [
+		{
+			"Effect": "Allow",
+			"Principal": {"Service": "sns.amazonaws.com"},
+			"Action": "sqs:SendMessage",
+			"Resource": "arn:aws:sqs:us-west-2:123456789012:MyQueue",
+			"Condition": {"ArnEquals": {"aws:SourceArn": "arn:aws:sns:us-west-2:123456789012:MyTopic"}}
+		},
+		{
+			"Effect": "Allow",
+			"Principal": {"Service": "s3.amazonaws.com"},
+			"Action": "sqs:SendMessage",
+			"Resource": "arn:aws:sqs:us-west-2:123456789012:MyQueue",
+			"Condition": {
+				"ArnLike": {"aws:SourceArn": "arn:aws:s3:::my-bucket"},
+				"StringEquals": {"aws:SourceAccount": "123456789012"}
+			}
+		},
+		{
+			"Effect": "Allow",
+			"Principal": {"Service": "events.amazonaws.com"},
+			"Action": "sqs:SendMessage",
+			"Resource": "arn:aws:sqs:us-west-2:123456789012:MyQueue",
+			"Condition": {"ArnEquals": {"aws:SourceArn": ["arn:aws:events:us-west-2:123456789012:rule/MyRule", "arn:aws:events:us-west-2:123456789012:rule/*"]}}
+		}
+	]
+}`
+
+func (t testClient) ListDeadLetterSourceQueues(ctx context.Context, params *sqs.ListDeadLetterSourceQueuesInput, optFns ...func(*sqs.Options)) (*sqs.ListDeadLetterSourceQueuesOutput, error) {
+	return &sqs.ListDeadLetterSourceQueuesOutput{
+		QueueUrls: []string{
+			"https://sqs.us-west-2.amazonaws.com/123456789012/MySourceQueue",
 		},
 	}, nil
 }
@@ -62,6 +106,144 @@ func TestGetFunc(t *testing.T) {
 	if err = item.Validate(); err != nil {
 		t.Error(err)
 	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "lambda-event-source-mapping",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:sqs:us-west-2:123456789012:MyQueue",
+			ExpectedScope:  "scope",
+		},
+		{
+			ExpectedType:   "sqs-queue",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:sqs:us-east-1:80398EXAMPLE:MyDeadLetterQueue",
+			ExpectedScope:  "80398EXAMPLE.us-east-1",
+		},
+		{
+			ExpectedType:   "sqs-queue",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:sqs:us-west-2:123456789012:MySourceQueue",
+			ExpectedScope:  "123456789012.us-west-2",
+		},
+		{
+			ExpectedType:   "sqs-queue",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "https://sqs.us-west-2.amazonaws.com/123456789012/MySourceQueue",
+			ExpectedScope:  "scope",
+		},
+		{
+			ExpectedType:   "sns-topic",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:sns:us-west-2:123456789012:MyTopic",
+			ExpectedScope:  "123456789012.us-west-2",
+		},
+		{
+			ExpectedType:   "s3-bucket",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:s3:::my-bucket",
+			ExpectedScope:  "123456789012",
+		},
+		{
+			ExpectedType:   "events-rule",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:events:us-west-2:123456789012:rule/MyRule",
+			ExpectedScope:  "123456789012.us-west-2",
+		},
+	}
+
+	tests.Execute(t, item)
+
+	// The wildcard rule ARN and the KMS key alias can't be linked
+	if len(item.GetLinkedItemQueries()) != len(tests) {
+		t.Errorf("expected %v linked item queries, got %v", len(tests), len(item.GetLinkedItemQueries()))
+	}
+}
+
+func TestGetFuncKMSKey(t *testing.T) {
+	ctx := context.Background()
+
+	item, err := getFunc(ctx, testKeyClient{keyID: "arn:aws:kms:us-west-2:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"}, "scope", &sqs.GetQueueAttributesInput{
+		QueueUrl: sources.PtrString("https://sqs.us-west-2.amazonaws.com/123456789012/MyQueue"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "kms-key",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "arn:aws:kms:us-west-2:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+			ExpectedScope:  "scope",
+		},
+	}
+
+	tests.Execute(t, item)
+
+	// Alias ARNs can't be resolved to a key either
+	item, err = getFunc(ctx, testKeyClient{keyID: "arn:aws:kms:us-west-2:123456789012:alias/my-key"}, "scope", &sqs.GetQueueAttributesInput{
+		QueueUrl: sources.PtrString("https://sqs.us-west-2.amazonaws.com/123456789012/MyQueue"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, link := range item.GetLinkedItemQueries() {
+		if link.GetQuery().GetType() == "kms-key" {
+			t.Errorf("expected no kms-key link, got %v", link.GetQuery().GetQuery())
+		}
+	}
+}
+
+// testKeyClient Returns a queue that is only encrypted with the given key
+type testKeyClient struct {
+	testClient
+
+	keyID string
+}
+
+func (t testKeyClient) GetQueueAttributes(ctx context.Context, params *sqs.GetQueueAttributesInput, optFns ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error) {
+	return &sqs.GetQueueAttributesOutput{
+		Attributes: map[string]string{
+			"QueueArn":       "arn:aws:sqs:us-west-2:123456789012:MyQueue",
+			"KmsMasterKeyId": t.keyID,
+		},
+	}, nil
+}
+
+func TestQueueSourceSearch(t *testing.T) {
+	src := NewQueueSource(aws.Config{}, "123456789012", "us-west-2")
+
+	// Override the client
+	src.Client = testURLClient{}
+
+	items, err := src.Search(context.Background(), "123456789012.us-west-2", "arn:aws:sqs:us-west-2:123456789012:MyQueue", false)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 1 {
+		t.Fatalf("expected 1 item, got %v", len(items))
+	}
+
+	if url, _ := items[0].GetAttributes().Get("queueURL"); url != "https://sqs.us-west-2.amazonaws.com/123456789012/MyQueue" {
+		t.Errorf("expected queue URL to be https://sqs.us-west-2.amazonaws.com/123456789012/MyQueue, got %v", url)
+	}
+}
+
+// testURLClient Only returns the queue if it is requested by its URL
+type testURLClient struct {
+	testClient
+}
+
+func (t testURLClient) GetQueueAttributes(ctx context.Context, params *sqs.GetQueueAttributesInput, optFns ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error) {
+	if *params.QueueUrl != "https://sqs.us-west-2.amazonaws.com/123456789012/MyQueue" {
+		return nil, errors.New("invalid queue URL")
+	}
+
+	return t.testClient.GetQueueAttributes(ctx, params, optFns...)
 }
 
 func TestNewQueueSource(t *testing.T) {
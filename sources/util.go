@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -31,12 +32,18 @@ func FormatScope(accountID, region string) string {
 	return fmt.Sprintf("%v.%v", accountID, region)
 }
 
-// ParseScope Parses a scope and returns the account id and region
+// ParseScope Parses a scope and returns the account id and region. Scopes of
+// global services such as IAM don't have a region, in which case the region is
+// empty
 func ParseScope(scope string) (string, string, error) {
 	sections := strings.Split(scope, ".")
 
-	if len(sections) != 2 {
-		return "", "", fmt.Errorf("could not split scope '%v' into 2 sections", scope)
+	if len(sections) > 2 || sections[0] == "" {
+		return "", "", fmt.Errorf("could not split scope '%v' into an account and region", scope)
+	}
+
+	if len(sections) == 1 {
+		return sections[0], "", nil
 	}
 
 	return sections[0], sections[1], nil
@@ -96,6 +103,51 @@ func ParseARN(arnString string) (*ARN, error) {
 	}, nil
 }
 
+var (
+	// Application and classic load balancers e.g.
+	// dualstack.internal-my-alb-1234567890.eu-west-2.elb.amazonaws.com
+	elbDNSRegex = regexp.MustCompile(`^(?:dualstack\.)?(?:internal-)?(.+)-[0-9]+\.([a-z0-9-]+)\.elb\.amazonaws\.com$`)
+	// Network load balancers e.g. my-nlb-0123456789abcdef.elb.eu-west-2.amazonaws.com
+	nlbDNSRegex = regexp.MustCompile(`^(?:dualstack\.)?(?:internal-)?(.+)-[0-9a-f]+\.elb\.([a-z0-9-]+)\.amazonaws\.com$`)
+)
+
+// LoadBalancerQueries Returns queries for the load balancer that a DNS name
+// belongs to, or nil if it isn't a load balancer DNS name. Application and
+// classic load balancers share the same DNS format so both are returned
+func LoadBalancerQueries(dnsName string, accountID string) []*sdp.Query {
+	dnsName = strings.ToLower(strings.TrimSuffix(dnsName, "."))
+
+	if matches := elbDNSRegex.FindStringSubmatch(dnsName); matches != nil {
+		return []*sdp.Query{
+			{
+				Type:   "elbv2-load-balancer",
+				Method: sdp.QueryMethod_GET,
+				Query:  matches[1],
+				Scope:  FormatScope(accountID, matches[2]),
+			},
+			{
+				Type:   "elb-load-balancer",
+				Method: sdp.QueryMethod_GET,
+				Query:  matches[1],
+				Scope:  FormatScope(accountID, matches[2]),
+			},
+		}
+	}
+
+	if matches := nlbDNSRegex.FindStringSubmatch(dnsName); matches != nil {
+		return []*sdp.Query{
+			{
+				Type:   "elbv2-load-balancer",
+				Method: sdp.QueryMethod_GET,
+				Query:  matches[1],
+				Scope:  FormatScope(accountID, matches[2]),
+			},
+		}
+	}
+
+	return nil
+}
+
 // WrapAWSError Wraps an AWS error in the appropriate SDP error
 func WrapAWSError(err error) error {
 	var responseErr *awshttp.ResponseError
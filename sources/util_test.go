@@ -133,3 +133,79 @@ func TestParseARN(t *testing.T) {
 		}
 	})
 }
+
+func TestLoadBalancerQueries(t *testing.T) {
+	t.Run("application or classic load balancer", func(t *testing.T) {
+		queries := LoadBalancerQueries("dualstack.internal-my-alb-1234567890.eu-west-2.elb.amazonaws.com.", "123456789012")
+
+		if len(queries) != 2 {
+			t.Fatalf("expected 2 queries, got %v", len(queries))
+		}
+
+		for i, expectedType := range []string{"elbv2-load-balancer", "elb-load-balancer"} {
+			if queries[i].GetType() != expectedType {
+				t.Errorf("expected type %v, got %v", expectedType, queries[i].GetType())
+			}
+
+			if queries[i].GetQuery() != "my-alb" {
+				t.Errorf("expected query my-alb, got %v", queries[i].GetQuery())
+			}
+
+			if queries[i].GetScope() != "123456789012.eu-west-2" {
+				t.Errorf("expected scope 123456789012.eu-west-2, got %v", queries[i].GetScope())
+			}
+		}
+	})
+
+	t.Run("network load balancer", func(t *testing.T) {
+		queries := LoadBalancerQueries("my-nlb-0123456789abcdef.elb.eu-west-2.amazonaws.com", "123456789012")
+
+		if len(queries) != 1 {
+			t.Fatalf("expected 1 query, got %v", len(queries))
+		}
+
+		if queries[0].GetType() != "elbv2-load-balancer" || queries[0].GetQuery() != "my-nlb" || queries[0].GetScope() != "123456789012.eu-west-2" {
+			t.Errorf("unexpected query %v", queries[0])
+		}
+	})
+
+	t.Run("something else", func(t *testing.T) {
+		if queries := LoadBalancerQueries("d111111abcdef8.cloudfront.net", "123456789012"); queries != nil {
+			t.Errorf("expected no queries, got %v", queries)
+		}
+	})
+}
+
+func TestParseScope(t *testing.T) {
+	t.Run("regional", func(t *testing.T) {
+		accountID, region, err := ParseScope("123456789012.eu-west-2")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if accountID != "123456789012" || region != "eu-west-2" {
+			t.Errorf("expected 123456789012 and eu-west-2, got %v and %v", accountID, region)
+		}
+	})
+
+	t.Run("global", func(t *testing.T) {
+		accountID, region, err := ParseScope("123456789012")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if accountID != "123456789012" || region != "" {
+			t.Errorf("expected 123456789012 and no region, got %v and %v", accountID, region)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, scope := range []string{"", "123456789012.eu-west-2.extra"} {
+			if _, _, err := ParseScope(scope); err == nil {
+				t.Errorf("expected error parsing %q", scope)
+			}
+		}
+	})
+}
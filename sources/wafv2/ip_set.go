@@ -0,0 +1,187 @@
+package wafv2
+
+import (
+	"context"
+	"net"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/wafv2"
+	"github.com/aws/aws-sdk-go-v2/service/wafv2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func ipSetGetFunc(ctx context.Context, client WAFv2Client, wafScope types.Scope, query string) (*types.IPSet, error) {
+	name, id, err := parseQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := client.GetIPSet(ctx, &wafv2.GetIPSetInput{
+		Name:  &name,
+		Id:    &id,
+		Scope: wafScope,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if out.IPSet == nil {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: "IP set was nil",
+		}
+	}
+
+	return out.IPSet, nil
+}
+
+func ipSetListFunc(ctx context.Context, client WAFv2Client, wafScope types.Scope) ([]*types.IPSet, error) {
+	ipSets := make([]*types.IPSet, 0)
+	input := wafv2.ListIPSetsInput{
+		Scope: wafScope,
+	}
+
+	for {
+		out, err := client.ListIPSets(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, summary := range out.IPSets {
+			if summary.Name == nil || summary.Id == nil {
+				continue
+			}
+
+			ipSet, err := ipSetGetFunc(ctx, client, wafScope, *summary.Name+"/"+*summary.Id)
+
+			if err != nil {
+				return nil, err
+			}
+
+			ipSets = append(ipSets, ipSet)
+		}
+
+		if out.NextMarker == nil || len(out.IPSets) == 0 {
+			break
+		}
+
+		input.NextMarker = out.NextMarker
+	}
+
+	return ipSets, nil
+}
+
+func ipSetItemMapper(scope string, awsItem *types.IPSet) (*sdp.Item, error) {
+	attributes, err := sources.ToAttributesCase(awsItem)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if awsItem.Name != nil && awsItem.Id != nil {
+		err = attributes.Set("uniqueName", *awsItem.Name+"/"+*awsItem.Id)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	item := sdp.Item{
+		Type:            "wafv2-ip-set",
+		UniqueAttribute: "uniqueName",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	for _, address := range awsItem.Addresses {
+		// Only link to addresses that are single IPs, not whole ranges
+		ip, ipNet, err := net.ParseCIDR(address)
+
+		if err != nil {
+			continue
+		}
+
+		if ones, bits := ipNet.Mask.Size(); ones != bits {
+			continue
+		}
+
+		// +overmind:link ip
+		item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+			Query: &sdp.Query{
+				Type:   "ip",
+				Method: sdp.QueryMethod_GET,
+				Query:  ip.String(),
+				Scope:  "global",
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// IPs are always linked
+				In:  true,
+				Out: true,
+			},
+		})
+	}
+
+	return &item, nil
+}
+
+func ipSetListTagsFunc(ctx context.Context, ipSet *types.IPSet, client WAFv2Client) (map[string]string, error) {
+	return tagsByResourceARN(ctx, client, ipSet.ARN), nil
+}
+
+// newIPSetSource Creates an IP set source for the given WAF scope
+func newIPSetSource(config aws.Config, accountID string, region string, wafScope types.Scope) *sources.GetListSource[*types.IPSet, WAFv2Client, *wafv2.Options] {
+	return &sources.GetListSource[*types.IPSet, WAFv2Client, *wafv2.Options]{
+		ItemType:  "wafv2-ip-set",
+		Client:    newClient(config, wafScope),
+		AccountID: accountID,
+		Region:    region,
+		GetFunc: func(ctx context.Context, client WAFv2Client, scope, query string) (*types.IPSet, error) {
+			return ipSetGetFunc(ctx, client, wafScope, query)
+		},
+		ListFunc: func(ctx context.Context, client WAFv2Client, scope string) ([]*types.IPSet, error) {
+			return ipSetListFunc(ctx, client, wafScope)
+		},
+		SearchFunc: func(ctx context.Context, client WAFv2Client, scope, query string) ([]*types.IPSet, error) {
+			getQuery, err := queryFromARN(query, "ipset")
+
+			if err != nil {
+				return nil, err
+			}
+
+			ipSet, err := ipSetGetFunc(ctx, client, wafScope, getQuery)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return []*types.IPSet{ipSet}, nil
+		},
+		ListTagsFunc: ipSetListTagsFunc,
+		ItemMapper:   ipSetItemMapper,
+	}
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type wafv2-ip-set
+// +overmind:descriptiveType WAF IP Set
+// +overmind:get Get an IP set by {name}/{id}
+// +overmind:list List all IP sets
+// +overmind:search Search for IP sets by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_wafv2_ip_set.arn
+// +overmind:terraform:method SEARCH
+
+// NewIPSetSource Creates a source for IP sets with the REGIONAL scope
+func NewIPSetSource(config aws.Config, accountID string, region string) *sources.GetListSource[*types.IPSet, WAFv2Client, *wafv2.Options] {
+	return newIPSetSource(config, accountID, region, types.ScopeRegional)
+}
+
+// NewCloudFrontIPSetSource Creates a source for IP sets with the CLOUDFRONT
+// scope. This is global so it should only be registered once
+func NewCloudFrontIPSetSource(config aws.Config, accountID string) *sources.GetListSource[*types.IPSet, WAFv2Client, *wafv2.Options] {
+	return newIPSetSource(config, accountID, "", types.ScopeCloudfront)
+}
@@ -0,0 +1,97 @@
+package wafv2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/wafv2"
+	"github.com/aws/aws-sdk-go-v2/service/wafv2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testWAFv2Client) GetIPSet(ctx context.Context, params *wafv2.GetIPSetInput, optFns ...func(*wafv2.Options)) (*wafv2.GetIPSetOutput, error) {
+	return &wafv2.GetIPSetOutput{
+		LockToken: sources.PtrString("token"),
+		IPSet: &types.IPSet{
+			ARN:              sources.PtrString("arn:aws:wafv2:eu-west-1:123456789012:regional/ipset/" + *params.Name + "/" + *params.Id),
+			Id:               params.Id,
+			Name:             params.Name,
+			IPAddressVersion: types.IPAddressVersionIpv4,
+			Addresses: []string{
+				"192.0.2.44/32", // link
+				"198.51.100.0/24",
+			},
+		},
+	}, nil
+}
+
+func (c testWAFv2Client) ListIPSets(ctx context.Context, params *wafv2.ListIPSetsInput, optFns ...func(*wafv2.Options)) (*wafv2.ListIPSetsOutput, error) {
+	return &wafv2.ListIPSetsOutput{
+		IPSets: []types.IPSetSummary{
+			{
+				Name: sources.PtrString("blocked"),
+				Id:   sources.PtrString("1111"),
+			},
+		},
+	}, nil
+}
+
+func TestIPSetGetFunc(t *testing.T) {
+	ipSet, err := ipSetGetFunc(context.Background(), testWAFv2Client{}, types.ScopeRegional, "blocked/1111")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := ipSetItemMapper("123456789012.eu-west-1", ipSet)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := item.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(item.GetLinkedItemQueries()) != 1 {
+		t.Errorf("expected 1 linked item query, got %v", len(item.GetLinkedItemQueries()))
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "ip",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "192.0.2.44",
+			ExpectedScope:  "global",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestIPSetListFunc(t *testing.T) {
+	ipSets, err := ipSetListFunc(context.Background(), testWAFv2Client{}, types.ScopeRegional)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ipSets) != 1 {
+		t.Errorf("expected 1 IP set, got %v", len(ipSets))
+	}
+}
+
+func TestNewIPSetSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewIPSetSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
@@ -0,0 +1,161 @@
+package wafv2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/wafv2"
+	"github.com/aws/aws-sdk-go-v2/service/wafv2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func regexPatternSetGetFunc(ctx context.Context, client WAFv2Client, wafScope types.Scope, query string) (*types.RegexPatternSet, error) {
+	name, id, err := parseQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := client.GetRegexPatternSet(ctx, &wafv2.GetRegexPatternSetInput{
+		Name:  &name,
+		Id:    &id,
+		Scope: wafScope,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if out.RegexPatternSet == nil {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: "regex pattern set was nil",
+		}
+	}
+
+	return out.RegexPatternSet, nil
+}
+
+func regexPatternSetListFunc(ctx context.Context, client WAFv2Client, wafScope types.Scope) ([]*types.RegexPatternSet, error) {
+	sets := make([]*types.RegexPatternSet, 0)
+	input := wafv2.ListRegexPatternSetsInput{
+		Scope: wafScope,
+	}
+
+	for {
+		out, err := client.ListRegexPatternSets(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, summary := range out.RegexPatternSets {
+			if summary.Name == nil || summary.Id == nil {
+				continue
+			}
+
+			set, err := regexPatternSetGetFunc(ctx, client, wafScope, *summary.Name+"/"+*summary.Id)
+
+			if err != nil {
+				return nil, err
+			}
+
+			sets = append(sets, set)
+		}
+
+		if out.NextMarker == nil || len(out.RegexPatternSets) == 0 {
+			break
+		}
+
+		input.NextMarker = out.NextMarker
+	}
+
+	return sets, nil
+}
+
+func regexPatternSetItemMapper(scope string, awsItem *types.RegexPatternSet) (*sdp.Item, error) {
+	attributes, err := sources.ToAttributesCase(awsItem)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if awsItem.Name != nil && awsItem.Id != nil {
+		err = attributes.Set("uniqueName", *awsItem.Name+"/"+*awsItem.Id)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	item := sdp.Item{
+		Type:            "wafv2-regex-pattern-set",
+		UniqueAttribute: "uniqueName",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	return &item, nil
+}
+
+func regexPatternSetListTagsFunc(ctx context.Context, set *types.RegexPatternSet, client WAFv2Client) (map[string]string, error) {
+	return tagsByResourceARN(ctx, client, set.ARN), nil
+}
+
+// newRegexPatternSetSource Creates a regex pattern set source for the given
+// WAF scope
+func newRegexPatternSetSource(config aws.Config, accountID string, region string, wafScope types.Scope) *sources.GetListSource[*types.RegexPatternSet, WAFv2Client, *wafv2.Options] {
+	return &sources.GetListSource[*types.RegexPatternSet, WAFv2Client, *wafv2.Options]{
+		ItemType:  "wafv2-regex-pattern-set",
+		Client:    newClient(config, wafScope),
+		AccountID: accountID,
+		Region:    region,
+		GetFunc: func(ctx context.Context, client WAFv2Client, scope, query string) (*types.RegexPatternSet, error) {
+			return regexPatternSetGetFunc(ctx, client, wafScope, query)
+		},
+		ListFunc: func(ctx context.Context, client WAFv2Client, scope string) ([]*types.RegexPatternSet, error) {
+			return regexPatternSetListFunc(ctx, client, wafScope)
+		},
+		SearchFunc: func(ctx context.Context, client WAFv2Client, scope, query string) ([]*types.RegexPatternSet, error) {
+			getQuery, err := queryFromARN(query, "regexpatternset")
+
+			if err != nil {
+				return nil, err
+			}
+
+			set, err := regexPatternSetGetFunc(ctx, client, wafScope, getQuery)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return []*types.RegexPatternSet{set}, nil
+		},
+		ListTagsFunc: regexPatternSetListTagsFunc,
+		ItemMapper:   regexPatternSetItemMapper,
+	}
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type wafv2-regex-pattern-set
+// +overmind:descriptiveType WAF Regex Pattern Set
+// +overmind:get Get a regex pattern set by {name}/{id}
+// +overmind:list List all regex pattern sets
+// +overmind:search Search for regex pattern sets by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_wafv2_regex_pattern_set.arn
+// +overmind:terraform:method SEARCH
+
+// NewRegexPatternSetSource Creates a source for regex pattern sets with the
+// REGIONAL scope
+func NewRegexPatternSetSource(config aws.Config, accountID string, region string) *sources.GetListSource[*types.RegexPatternSet, WAFv2Client, *wafv2.Options] {
+	return newRegexPatternSetSource(config, accountID, region, types.ScopeRegional)
+}
+
+// NewCloudFrontRegexPatternSetSource Creates a source for regex pattern sets
+// with the CLOUDFRONT scope. This is global so it should only be registered
+// once
+func NewCloudFrontRegexPatternSetSource(config aws.Config, accountID string) *sources.GetListSource[*types.RegexPatternSet, WAFv2Client, *wafv2.Options] {
+	return newRegexPatternSetSource(config, accountID, "", types.ScopeCloudfront)
+}
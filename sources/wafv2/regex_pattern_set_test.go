@@ -0,0 +1,85 @@
+package wafv2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/wafv2"
+	"github.com/aws/aws-sdk-go-v2/service/wafv2/types"
+	"github.com/overmindtech/aws-source/sources"
+)
+
+func (c testWAFv2Client) GetRegexPatternSet(ctx context.Context, params *wafv2.GetRegexPatternSetInput, optFns ...func(*wafv2.Options)) (*wafv2.GetRegexPatternSetOutput, error) {
+	return &wafv2.GetRegexPatternSetOutput{
+		LockToken: sources.PtrString("token"),
+		RegexPatternSet: &types.RegexPatternSet{
+			ARN:  sources.PtrString("arn:aws:wafv2:eu-west-1:123456789012:regional/regexpatternset/" + *params.Name + "/" + *params.Id),
+			Id:   params.Id,
+			Name: params.Name,
+			RegularExpressionList: []types.Regex{
+				{
+					RegexString: sources.PtrString("(?i)bot"),
+				},
+			},
+		},
+	}, nil
+}
+
+func (c testWAFv2Client) ListRegexPatternSets(ctx context.Context, params *wafv2.ListRegexPatternSetsInput, optFns ...func(*wafv2.Options)) (*wafv2.ListRegexPatternSetsOutput, error) {
+	return &wafv2.ListRegexPatternSetsOutput{
+		RegexPatternSets: []types.RegexPatternSetSummary{
+			{
+				Name: sources.PtrString("bots"),
+				Id:   sources.PtrString("3333"),
+			},
+		},
+	}, nil
+}
+
+func TestRegexPatternSetGetFunc(t *testing.T) {
+	set, err := regexPatternSetGetFunc(context.Background(), testWAFv2Client{}, types.ScopeRegional, "bots/3333")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := regexPatternSetItemMapper("123456789012.eu-west-1", set)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := item.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if item.UniqueAttributeValue() != "bots/3333" {
+		t.Errorf("expected unique attribute value bots/3333, got %v", item.UniqueAttributeValue())
+	}
+}
+
+func TestRegexPatternSetListFunc(t *testing.T) {
+	sets, err := regexPatternSetListFunc(context.Background(), testWAFv2Client{}, types.ScopeRegional)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sets) != 1 {
+		t.Errorf("expected 1 regex pattern set, got %v", len(sets))
+	}
+}
+
+func TestNewRegexPatternSetSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewRegexPatternSetSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
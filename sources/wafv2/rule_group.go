@@ -0,0 +1,162 @@
+package wafv2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/wafv2"
+	"github.com/aws/aws-sdk-go-v2/service/wafv2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func ruleGroupGetFunc(ctx context.Context, client WAFv2Client, wafScope types.Scope, query string) (*types.RuleGroup, error) {
+	name, id, err := parseQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := client.GetRuleGroup(ctx, &wafv2.GetRuleGroupInput{
+		Name:  &name,
+		Id:    &id,
+		Scope: wafScope,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if out.RuleGroup == nil {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: "rule group was nil",
+		}
+	}
+
+	return out.RuleGroup, nil
+}
+
+func ruleGroupListFunc(ctx context.Context, client WAFv2Client, wafScope types.Scope) ([]*types.RuleGroup, error) {
+	ruleGroups := make([]*types.RuleGroup, 0)
+	input := wafv2.ListRuleGroupsInput{
+		Scope: wafScope,
+	}
+
+	for {
+		out, err := client.ListRuleGroups(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, summary := range out.RuleGroups {
+			if summary.Name == nil || summary.Id == nil {
+				continue
+			}
+
+			ruleGroup, err := ruleGroupGetFunc(ctx, client, wafScope, *summary.Name+"/"+*summary.Id)
+
+			if err != nil {
+				return nil, err
+			}
+
+			ruleGroups = append(ruleGroups, ruleGroup)
+		}
+
+		if out.NextMarker == nil || len(out.RuleGroups) == 0 {
+			break
+		}
+
+		input.NextMarker = out.NextMarker
+	}
+
+	return ruleGroups, nil
+}
+
+func ruleGroupItemMapper(scope string, awsItem *types.RuleGroup) (*sdp.Item, error) {
+	attributes, err := sources.ToAttributesCase(awsItem)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if awsItem.Name != nil && awsItem.Id != nil {
+		err = attributes.Set("uniqueName", *awsItem.Name+"/"+*awsItem.Id)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	item := sdp.Item{
+		Type:            "wafv2-rule-group",
+		UniqueAttribute: "uniqueName",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	// +overmind:link wafv2-ip-set
+	// +overmind:link wafv2-regex-pattern-set
+	item.LinkedItemQueries = append(item.LinkedItemQueries, rulesLinks(awsItem.Rules)...)
+
+	return &item, nil
+}
+
+func ruleGroupListTagsFunc(ctx context.Context, ruleGroup *types.RuleGroup, client WAFv2Client) (map[string]string, error) {
+	return tagsByResourceARN(ctx, client, ruleGroup.ARN), nil
+}
+
+// newRuleGroupSource Creates a rule group source for the given WAF scope
+func newRuleGroupSource(config aws.Config, accountID string, region string, wafScope types.Scope) *sources.GetListSource[*types.RuleGroup, WAFv2Client, *wafv2.Options] {
+	return &sources.GetListSource[*types.RuleGroup, WAFv2Client, *wafv2.Options]{
+		ItemType:  "wafv2-rule-group",
+		Client:    newClient(config, wafScope),
+		AccountID: accountID,
+		Region:    region,
+		GetFunc: func(ctx context.Context, client WAFv2Client, scope, query string) (*types.RuleGroup, error) {
+			return ruleGroupGetFunc(ctx, client, wafScope, query)
+		},
+		ListFunc: func(ctx context.Context, client WAFv2Client, scope string) ([]*types.RuleGroup, error) {
+			return ruleGroupListFunc(ctx, client, wafScope)
+		},
+		SearchFunc: func(ctx context.Context, client WAFv2Client, scope, query string) ([]*types.RuleGroup, error) {
+			getQuery, err := queryFromARN(query, "rulegroup")
+
+			if err != nil {
+				return nil, err
+			}
+
+			ruleGroup, err := ruleGroupGetFunc(ctx, client, wafScope, getQuery)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return []*types.RuleGroup{ruleGroup}, nil
+		},
+		ListTagsFunc: ruleGroupListTagsFunc,
+		ItemMapper:   ruleGroupItemMapper,
+	}
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type wafv2-rule-group
+// +overmind:descriptiveType WAF Rule Group
+// +overmind:get Get a rule group by {name}/{id}
+// +overmind:list List all rule groups
+// +overmind:search Search for rule groups by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_wafv2_rule_group.arn
+// +overmind:terraform:method SEARCH
+
+// NewRuleGroupSource Creates a source for rule groups with the REGIONAL scope
+func NewRuleGroupSource(config aws.Config, accountID string, region string) *sources.GetListSource[*types.RuleGroup, WAFv2Client, *wafv2.Options] {
+	return newRuleGroupSource(config, accountID, region, types.ScopeRegional)
+}
+
+// NewCloudFrontRuleGroupSource Creates a source for rule groups with the
+// CLOUDFRONT scope. This is global so it should only be registered once
+func NewCloudFrontRuleGroupSource(config aws.Config, accountID string) *sources.GetListSource[*types.RuleGroup, WAFv2Client, *wafv2.Options] {
+	return newRuleGroupSource(config, accountID, "", types.ScopeCloudfront)
+}
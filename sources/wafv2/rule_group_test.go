@@ -0,0 +1,114 @@
+package wafv2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/wafv2"
+	"github.com/aws/aws-sdk-go-v2/service/wafv2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testWAFv2Client) GetRuleGroup(ctx context.Context, params *wafv2.GetRuleGroupInput, optFns ...func(*wafv2.Options)) (*wafv2.GetRuleGroupOutput, error) {
+	return &wafv2.GetRuleGroupOutput{
+		LockToken: sources.PtrString("token"),
+		RuleGroup: &types.RuleGroup{
+			ARN:      sources.PtrString("arn:aws:wafv2:eu-west-1:123456789012:regional/rulegroup/" + *params.Name + "/" + *params.Id),
+			Id:       params.Id,
+			Name:     params.Name,
+			Capacity: sources.PtrInt64(10),
+			Rules: []types.Rule{
+				{
+					Name:     sources.PtrString("rate-limit-bots"),
+					Priority: 0,
+					Action: &types.RuleAction{
+						Block: &types.BlockAction{},
+					},
+					Statement: &types.Statement{
+						RateBasedStatement: &types.RateBasedStatement{
+							AggregateKeyType: types.RateBasedStatementAggregateKeyTypeIp,
+							Limit:            sources.PtrInt64(1000),
+							ScopeDownStatement: &types.Statement{
+								RegexPatternSetReferenceStatement: &types.RegexPatternSetReferenceStatement{
+									ARN: sources.PtrString("arn:aws:wafv2:eu-west-1:123456789012:regional/regexpatternset/bots/3333"), // link
+								},
+							},
+						},
+					},
+				},
+			},
+			VisibilityConfig: &types.VisibilityConfig{
+				CloudWatchMetricsEnabled: true,
+				MetricName:               params.Name,
+				SampledRequestsEnabled:   true,
+			},
+		},
+	}, nil
+}
+
+func (c testWAFv2Client) ListRuleGroups(ctx context.Context, params *wafv2.ListRuleGroupsInput, optFns ...func(*wafv2.Options)) (*wafv2.ListRuleGroupsOutput, error) {
+	return &wafv2.ListRuleGroupsOutput{
+		RuleGroups: []types.RuleGroupSummary{
+			{
+				Name: sources.PtrString("shared"),
+				Id:   sources.PtrString("2222"),
+			},
+		},
+	}, nil
+}
+
+func TestRuleGroupGetFunc(t *testing.T) {
+	ruleGroup, err := ruleGroupGetFunc(context.Background(), testWAFv2Client{}, types.ScopeRegional, "shared/2222")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := ruleGroupItemMapper("123456789012.eu-west-1", ruleGroup)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := item.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "wafv2-regex-pattern-set",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:wafv2:eu-west-1:123456789012:regional/regexpatternset/bots/3333",
+			ExpectedScope:  "123456789012.eu-west-1",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestRuleGroupListFunc(t *testing.T) {
+	ruleGroups, err := ruleGroupListFunc(context.Background(), testWAFv2Client{}, types.ScopeRegional)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ruleGroups) != 1 {
+		t.Errorf("expected 1 rule group, got %v", len(ruleGroups))
+	}
+}
+
+func TestNewRuleGroupSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewRuleGroupSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
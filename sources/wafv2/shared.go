@@ -0,0 +1,216 @@
+package wafv2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
+	"github.com/aws/aws-sdk-go-v2/service/wafv2"
+	"github.com/aws/aws-sdk-go-v2/service/wafv2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+// WAF resources with the CLOUDFRONT scope can only be managed from this region
+const cloudFrontRegion = "us-east-1"
+
+// WAFv2Client Represents the client we need to talk to WAF v2, usually this is
+// *wafv2.Client
+type WAFv2Client interface {
+	GetIPSet(ctx context.Context, params *wafv2.GetIPSetInput, optFns ...func(*wafv2.Options)) (*wafv2.GetIPSetOutput, error)
+	GetRegexPatternSet(ctx context.Context, params *wafv2.GetRegexPatternSetInput, optFns ...func(*wafv2.Options)) (*wafv2.GetRegexPatternSetOutput, error)
+	GetRuleGroup(ctx context.Context, params *wafv2.GetRuleGroupInput, optFns ...func(*wafv2.Options)) (*wafv2.GetRuleGroupOutput, error)
+	GetWebACL(ctx context.Context, params *wafv2.GetWebACLInput, optFns ...func(*wafv2.Options)) (*wafv2.GetWebACLOutput, error)
+	ListIPSets(ctx context.Context, params *wafv2.ListIPSetsInput, optFns ...func(*wafv2.Options)) (*wafv2.ListIPSetsOutput, error)
+	ListRegexPatternSets(ctx context.Context, params *wafv2.ListRegexPatternSetsInput, optFns ...func(*wafv2.Options)) (*wafv2.ListRegexPatternSetsOutput, error)
+	ListResourcesForWebACL(ctx context.Context, params *wafv2.ListResourcesForWebACLInput, optFns ...func(*wafv2.Options)) (*wafv2.ListResourcesForWebACLOutput, error)
+	ListRuleGroups(ctx context.Context, params *wafv2.ListRuleGroupsInput, optFns ...func(*wafv2.Options)) (*wafv2.ListRuleGroupsOutput, error)
+	ListTagsForResource(ctx context.Context, params *wafv2.ListTagsForResourceInput, optFns ...func(*wafv2.Options)) (*wafv2.ListTagsForResourceOutput, error)
+	ListWebACLs(ctx context.Context, params *wafv2.ListWebACLsInput, optFns ...func(*wafv2.Options)) (*wafv2.ListWebACLsOutput, error)
+}
+
+// CloudFrontClient The parts of the CloudFront API that we need in order to
+// find the distributions that a web ACL is associated with. WAF doesn't track
+// these associations itself for the CLOUDFRONT scope
+type CloudFrontClient interface {
+	ListDistributionsByWebACLId(ctx context.Context, params *cloudfront.ListDistributionsByWebACLIdInput, optFns ...func(*cloudfront.Options)) (*cloudfront.ListDistributionsByWebACLIdOutput, error)
+}
+
+// newClient Creates a WAF v2 client for the given WAF scope. Resources with the
+// CLOUDFRONT scope must always be requested from us-east-1
+func newClient(config aws.Config, wafScope types.Scope) *wafv2.Client {
+	return wafv2.NewFromConfig(config, func(o *wafv2.Options) {
+		if wafScope == types.ScopeCloudfront {
+			o.Region = cloudFrontRegion
+		}
+	})
+}
+
+// parseQuery Parses a query in the format {name}/{id}. WAF requires both the
+// name and the ID in order to get a resource
+func parseQuery(query string) (name string, id string, err error) {
+	i := strings.LastIndex(query, "/")
+
+	if i <= 0 || i == len(query)-1 {
+		return "", "", &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: fmt.Sprintf("query %v must be in the format {name}/{id}", query),
+		}
+	}
+
+	return query[:i], query[i+1:], nil
+}
+
+// queryFromARN Converts a WAF ARN into a {name}/{id} query. WAF ARNs are in
+// the format:
+// arn:aws:wafv2:{region}:{account}:{global|regional}/{type}/{name}/{id}
+func queryFromARN(arn string, resourceType string) (string, error) {
+	a, err := sources.ParseARN(arn)
+
+	if err != nil {
+		return "", err
+	}
+
+	sections := strings.Split(a.Resource, "/")
+
+	if len(sections) != 4 || sections[1] != resourceType {
+		return "", &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: fmt.Sprintf("ARN %v is not a WAF %v", arn, resourceType),
+		}
+	}
+
+	return sections[2] + "/" + sections[3], nil
+}
+
+// scopeFromARN Returns the Overmind scope for a WAF ARN. Resources with the
+// CLOUDFRONT scope are global, so they are discovered in the account scope
+// along with the rest of the CloudFront resources even though their ARNs
+// include us-east-1
+func scopeFromARN(a *sources.ARN) string {
+	if a.Type() == "global" {
+		return sources.FormatScope(a.AccountID, "")
+	}
+
+	return sources.FormatScope(a.AccountID, a.Region)
+}
+
+func tagsByResourceARN(ctx context.Context, client WAFv2Client, resourceARN *string) map[string]string {
+	if resourceARN == nil {
+		return nil
+	}
+
+	out, err := client.ListTagsForResource(ctx, &wafv2.ListTagsForResourceInput{
+		ResourceARN: resourceARN,
+	})
+
+	if err != nil {
+		return sources.HandleTagsError(ctx, err)
+	}
+
+	tags := make(map[string]string)
+
+	if out.TagInfoForResource != nil {
+		for _, tag := range out.TagInfoForResource.TagList {
+			if tag.Key != nil && tag.Value != nil {
+				tags[*tag.Key] = *tag.Value
+			}
+		}
+	}
+
+	return tags
+}
+
+// referenceLink Returns a link to a WAF resource that is referenced by ARN
+// from a rule statement
+func referenceLink(queryType string, arn *string) []*sdp.LinkedItemQuery {
+	if arn == nil {
+		return nil
+	}
+
+	a, err := sources.ParseARN(*arn)
+
+	if err != nil {
+		return nil
+	}
+
+	return []*sdp.LinkedItemQuery{
+		{
+			Query: &sdp.Query{
+				Type:   queryType,
+				Method: sdp.QueryMethod_SEARCH,
+				Query:  *arn,
+				Scope:  scopeFromARN(a),
+			},
+			BlastPropagation: &sdp.BlastPropagation{
+				// Changing the referenced resource will change how requests
+				// are filtered
+				In: true,
+				// Changing the statement won't affect the referenced resource
+				Out: false,
+			},
+		},
+	}
+}
+
+// statementLinks Returns links to the rule groups, IP sets and regex pattern
+// sets that a statement references. Statements can be nested so this is
+// recursive
+func statementLinks(statement *types.Statement) []*sdp.LinkedItemQuery {
+	links := make([]*sdp.LinkedItemQuery, 0)
+
+	if statement == nil {
+		return links
+	}
+
+	if statement.RuleGroupReferenceStatement != nil {
+		links = append(links, referenceLink("wafv2-rule-group", statement.RuleGroupReferenceStatement.ARN)...)
+	}
+
+	if statement.IPSetReferenceStatement != nil {
+		links = append(links, referenceLink("wafv2-ip-set", statement.IPSetReferenceStatement.ARN)...)
+	}
+
+	if statement.RegexPatternSetReferenceStatement != nil {
+		links = append(links, referenceLink("wafv2-regex-pattern-set", statement.RegexPatternSetReferenceStatement.ARN)...)
+	}
+
+	if statement.AndStatement != nil {
+		for i := range statement.AndStatement.Statements {
+			links = append(links, statementLinks(&statement.AndStatement.Statements[i])...)
+		}
+	}
+
+	if statement.OrStatement != nil {
+		for i := range statement.OrStatement.Statements {
+			links = append(links, statementLinks(&statement.OrStatement.Statements[i])...)
+		}
+	}
+
+	if statement.NotStatement != nil {
+		links = append(links, statementLinks(statement.NotStatement.Statement)...)
+	}
+
+	if statement.RateBasedStatement != nil {
+		links = append(links, statementLinks(statement.RateBasedStatement.ScopeDownStatement)...)
+	}
+
+	if statement.ManagedRuleGroupStatement != nil {
+		links = append(links, statementLinks(statement.ManagedRuleGroupStatement.ScopeDownStatement)...)
+	}
+
+	return links
+}
+
+// rulesLinks Returns the links for all statements in a set of rules
+func rulesLinks(rules []types.Rule) []*sdp.LinkedItemQuery {
+	links := make([]*sdp.LinkedItemQuery, 0)
+
+	for _, rule := range rules {
+		links = append(links, statementLinks(rule.Statement)...)
+	}
+
+	return links
+}
@@ -0,0 +1,125 @@
+package wafv2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
+	cfTypes "github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
+	"github.com/aws/aws-sdk-go-v2/service/wafv2"
+	"github.com/aws/aws-sdk-go-v2/service/wafv2/types"
+	"github.com/overmindtech/aws-source/sources"
+)
+
+type testWAFv2Client struct{}
+
+func (c testWAFv2Client) ListTagsForResource(ctx context.Context, params *wafv2.ListTagsForResourceInput, optFns ...func(*wafv2.Options)) (*wafv2.ListTagsForResourceOutput, error) {
+	return &wafv2.ListTagsForResourceOutput{
+		TagInfoForResource: &types.TagInfoForResource{
+			ResourceARN: params.ResourceARN,
+			TagList: []types.Tag{
+				{
+					Key:   sources.PtrString("foo"),
+					Value: sources.PtrString("bar"),
+				},
+			},
+		},
+	}, nil
+}
+
+type testCloudFrontClient struct{}
+
+func (c testCloudFrontClient) ListDistributionsByWebACLId(ctx context.Context, params *cloudfront.ListDistributionsByWebACLIdInput, optFns ...func(*cloudfront.Options)) (*cloudfront.ListDistributionsByWebACLIdOutput, error) {
+	return &cloudfront.ListDistributionsByWebACLIdOutput{
+		DistributionList: &cfTypes.DistributionList{
+			IsTruncated: sources.PtrBool(false),
+			Items: []cfTypes.DistributionSummary{
+				{
+					ARN: sources.PtrString("arn:aws:cloudfront::123456789012:distribution/E2QWRUHAPOMQZL"),
+					Id:  sources.PtrString("E2QWRUHAPOMQZL"),
+				},
+			},
+		},
+	}, nil
+}
+
+func TestParseQuery(t *testing.T) {
+	name, id, err := parseQuery("my-acl/a1b2c3d4-5678-90ab-cdef-EXAMPLE11111")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if name != "my-acl" {
+		t.Errorf("expected name my-acl, got %v", name)
+	}
+
+	if id != "a1b2c3d4-5678-90ab-cdef-EXAMPLE11111" {
+		t.Errorf("expected id a1b2c3d4-5678-90ab-cdef-EXAMPLE11111, got %v", id)
+	}
+
+	for _, query := range []string{"my-acl", "/id", "my-acl/"} {
+		if _, _, err := parseQuery(query); err == nil {
+			t.Errorf("expected error for query %v", query)
+		}
+	}
+}
+
+func TestQueryFromARN(t *testing.T) {
+	query, err := queryFromARN("arn:aws:wafv2:us-east-1:123456789012:global/ipset/blocked/a1b2c3d4", "ipset")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if query != "blocked/a1b2c3d4" {
+		t.Errorf("expected query blocked/a1b2c3d4, got %v", query)
+	}
+
+	_, err = queryFromARN("arn:aws:wafv2:us-east-1:123456789012:global/ipset/blocked/a1b2c3d4", "webacl")
+
+	if err == nil {
+		t.Error("expected error for ARN of the wrong type")
+	}
+}
+
+func TestStatementLinks(t *testing.T) {
+	statement := types.Statement{
+		AndStatement: &types.AndStatement{
+			Statements: []types.Statement{
+				{
+					IPSetReferenceStatement: &types.IPSetReferenceStatement{
+						ARN: sources.PtrString("arn:aws:wafv2:eu-west-1:123456789012:regional/ipset/allowed/1111"),
+					},
+				},
+				{
+					NotStatement: &types.NotStatement{
+						Statement: &types.Statement{
+							RegexPatternSetReferenceStatement: &types.RegexPatternSetReferenceStatement{
+								ARN: sources.PtrString("arn:aws:wafv2:eu-west-1:123456789012:regional/regexpatternset/bots/2222"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	links := statementLinks(&statement)
+
+	if len(links) != 2 {
+		t.Fatalf("expected 2 links, got %v", len(links))
+	}
+
+	if links[0].GetQuery().GetType() != "wafv2-ip-set" {
+		t.Errorf("expected wafv2-ip-set link, got %v", links[0].GetQuery().GetType())
+	}
+
+	if links[1].GetQuery().GetType() != "wafv2-regex-pattern-set" {
+		t.Errorf("expected wafv2-regex-pattern-set link, got %v", links[1].GetQuery().GetType())
+	}
+
+	if links[1].GetQuery().GetScope() != "123456789012.eu-west-1" {
+		t.Errorf("expected scope 123456789012.eu-west-1, got %v", links[1].GetQuery().GetScope())
+	}
+}
@@ -0,0 +1,333 @@
+package wafv2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
+	"github.com/aws/aws-sdk-go-v2/service/wafv2"
+	"github.com/aws/aws-sdk-go-v2/service/wafv2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+type WebACLDetails struct {
+	WebACL *types.WebACL
+
+	// The ARNs of the resources that the web ACL is associated with
+	AssociatedResources []string
+}
+
+// The types of regional resources that we look up associations for
+var webACLResourceTypes = []types.ResourceType{
+	types.ResourceTypeApplicationLoadBalancer,
+	types.ResourceTypeApiGateway,
+}
+
+// associatedDistributions Returns the ARNs of the CloudFront distributions
+// that use a given web ACL
+func associatedDistributions(ctx context.Context, client CloudFrontClient, webACLARN *string) ([]string, error) {
+	arns := make([]string, 0)
+	input := cloudfront.ListDistributionsByWebACLIdInput{
+		WebACLId: webACLARN,
+	}
+
+	for {
+		out, err := client.ListDistributionsByWebACLId(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if out.DistributionList == nil {
+			break
+		}
+
+		for _, distribution := range out.DistributionList.Items {
+			if distribution.ARN != nil {
+				arns = append(arns, *distribution.ARN)
+			}
+		}
+
+		if out.DistributionList.IsTruncated == nil || !*out.DistributionList.IsTruncated {
+			break
+		}
+
+		input.Marker = out.DistributionList.NextMarker
+	}
+
+	return arns, nil
+}
+
+// webACLGetFunc Gets a web ACL and the resources that it is associated with.
+// Regional associations are tracked by WAF, but CloudFront associations are
+// only tracked by CloudFront so we need to ask it instead
+func webACLGetFunc(ctx context.Context, client WAFv2Client, cfClient CloudFrontClient, wafScope types.Scope, query string) (*WebACLDetails, error) {
+	name, id, err := parseQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := client.GetWebACL(ctx, &wafv2.GetWebACLInput{
+		Name:  &name,
+		Id:    &id,
+		Scope: wafScope,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if out.WebACL == nil {
+		return nil, &sdp.QueryError{
+			ErrorType:   sdp.QueryError_NOTFOUND,
+			ErrorString: "web ACL was nil",
+		}
+	}
+
+	details := WebACLDetails{
+		WebACL:              out.WebACL,
+		AssociatedResources: make([]string, 0),
+	}
+
+	if wafScope == types.ScopeCloudfront {
+		if cfClient != nil {
+			details.AssociatedResources, err = associatedDistributions(ctx, cfClient, out.WebACL.ARN)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		for _, resourceType := range webACLResourceTypes {
+			resources, err := client.ListResourcesForWebACL(ctx, &wafv2.ListResourcesForWebACLInput{
+				WebACLArn:    out.WebACL.ARN,
+				ResourceType: resourceType,
+			})
+
+			if err != nil {
+				return nil, err
+			}
+
+			details.AssociatedResources = append(details.AssociatedResources, resources.ResourceArns...)
+		}
+	}
+
+	return &details, nil
+}
+
+func webACLListFunc(ctx context.Context, client WAFv2Client, cfClient CloudFrontClient, wafScope types.Scope) ([]*WebACLDetails, error) {
+	acls := make([]*WebACLDetails, 0)
+	input := wafv2.ListWebACLsInput{
+		Scope: wafScope,
+	}
+
+	for {
+		out, err := client.ListWebACLs(ctx, &input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, summary := range out.WebACLs {
+			if summary.Name == nil || summary.Id == nil {
+				continue
+			}
+
+			acl, err := webACLGetFunc(ctx, client, cfClient, wafScope, *summary.Name+"/"+*summary.Id)
+
+			if err != nil {
+				return nil, err
+			}
+
+			acls = append(acls, acl)
+		}
+
+		if out.NextMarker == nil || len(out.WebACLs) == 0 {
+			break
+		}
+
+		input.NextMarker = out.NextMarker
+	}
+
+	return acls, nil
+}
+
+func webACLItemMapper(scope string, awsItem *WebACLDetails) (*sdp.Item, error) {
+	enrichedACL := struct {
+		*types.WebACL
+		AssociatedResources []string
+	}{
+		WebACL:              awsItem.WebACL,
+		AssociatedResources: awsItem.AssociatedResources,
+	}
+
+	attributes, err := sources.ToAttributesCase(enrichedACL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if awsItem.WebACL.Name != nil && awsItem.WebACL.Id != nil {
+		err = attributes.Set("uniqueName", *awsItem.WebACL.Name+"/"+*awsItem.WebACL.Id)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	item := sdp.Item{
+		Type:            "wafv2-web-acl",
+		UniqueAttribute: "uniqueName",
+		Attributes:      attributes,
+		Scope:           scope,
+	}
+
+	// +overmind:link wafv2-rule-group
+	// +overmind:link wafv2-ip-set
+	// +overmind:link wafv2-regex-pattern-set
+	item.LinkedItemQueries = append(item.LinkedItemQueries, rulesLinks(awsItem.WebACL.Rules)...)
+
+	// Rule groups can also be added by Firewall Manager
+	fmsRuleGroups := make([]types.FirewallManagerRuleGroup, 0)
+	fmsRuleGroups = append(fmsRuleGroups, awsItem.WebACL.PreProcessFirewallManagerRuleGroups...)
+	fmsRuleGroups = append(fmsRuleGroups, awsItem.WebACL.PostProcessFirewallManagerRuleGroups...)
+
+	for _, ruleGroup := range fmsRuleGroups {
+		if ruleGroup.FirewallManagerStatement != nil {
+			if ref := ruleGroup.FirewallManagerStatement.RuleGroupReferenceStatement; ref != nil {
+				// +overmind:link wafv2-rule-group
+				item.LinkedItemQueries = append(item.LinkedItemQueries, referenceLink("wafv2-rule-group", ref.ARN)...)
+			}
+		}
+	}
+
+	for _, resourceARN := range awsItem.AssociatedResources {
+		a, err := sources.ParseARN(resourceARN)
+
+		if err != nil {
+			continue
+		}
+
+		switch a.Service {
+		case "elasticloadbalancing":
+			// +overmind:link elbv2-load-balancer
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "elbv2-load-balancer",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  resourceARN,
+					Scope:  sources.FormatScope(a.AccountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the ACL will affect the traffic that reaches
+					// the load balancer
+					Out: true,
+					// Changing the load balancer won't affect the ACL
+					In: false,
+				},
+			})
+		case "apigateway":
+			// API Gateway ARNs don't include the account, so we need to
+			// take this from the scope
+			accountID, _, _ := sources.ParseScope(scope)
+
+			// +overmind:link apigateway-stage
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "apigateway-stage",
+					Method: sdp.QueryMethod_SEARCH,
+					Query:  resourceARN,
+					Scope:  sources.FormatScope(accountID, a.Region),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the ACL will affect the traffic that reaches
+					// the stage
+					Out: true,
+					// Changing the stage won't affect the ACL
+					In: false,
+				},
+			})
+		case "cloudfront":
+			// +overmind:link cloudfront-distribution
+			item.LinkedItemQueries = append(item.LinkedItemQueries, &sdp.LinkedItemQuery{
+				Query: &sdp.Query{
+					Type:   "cloudfront-distribution",
+					Method: sdp.QueryMethod_GET,
+					Query:  a.ResourceID(),
+					Scope:  sources.FormatScope(a.AccountID, ""),
+				},
+				BlastPropagation: &sdp.BlastPropagation{
+					// Changing the ACL will affect the traffic that reaches
+					// the distribution
+					Out: true,
+					// Changing the distribution won't affect the ACL
+					In: false,
+				},
+			})
+		}
+	}
+
+	return &item, nil
+}
+
+func webACLListTagsFunc(ctx context.Context, acl *WebACLDetails, client WAFv2Client) (map[string]string, error) {
+	return tagsByResourceARN(ctx, client, acl.WebACL.ARN), nil
+}
+
+// newWebACLSource Creates a web ACL source for the given WAF scope
+func newWebACLSource(config aws.Config, accountID string, region string, wafScope types.Scope, cfClient CloudFrontClient) *sources.GetListSource[*WebACLDetails, WAFv2Client, *wafv2.Options] {
+	return &sources.GetListSource[*WebACLDetails, WAFv2Client, *wafv2.Options]{
+		ItemType:  "wafv2-web-acl",
+		Client:    newClient(config, wafScope),
+		AccountID: accountID,
+		Region:    region,
+		GetFunc: func(ctx context.Context, client WAFv2Client, scope, query string) (*WebACLDetails, error) {
+			return webACLGetFunc(ctx, client, cfClient, wafScope, query)
+		},
+		ListFunc: func(ctx context.Context, client WAFv2Client, scope string) ([]*WebACLDetails, error) {
+			return webACLListFunc(ctx, client, cfClient, wafScope)
+		},
+		SearchFunc: func(ctx context.Context, client WAFv2Client, scope, query string) ([]*WebACLDetails, error) {
+			getQuery, err := queryFromARN(query, "webacl")
+
+			if err != nil {
+				return nil, err
+			}
+
+			acl, err := webACLGetFunc(ctx, client, cfClient, wafScope, getQuery)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return []*WebACLDetails{acl}, nil
+		},
+		ListTagsFunc: webACLListTagsFunc,
+		ItemMapper:   webACLItemMapper,
+	}
+}
+
+//go:generate docgen ../../docs-data
+// +overmind:type wafv2-web-acl
+// +overmind:descriptiveType WAF Web ACL
+// +overmind:get Get a web ACL by {name}/{id}
+// +overmind:list List all web ACLs
+// +overmind:search Search for web ACLs by ARN
+// +overmind:group AWS
+// +overmind:terraform:queryMap aws_wafv2_web_acl.arn
+// +overmind:terraform:method SEARCH
+
+// NewWebACLSource Creates a source for web ACLs with the REGIONAL scope, these
+// protect load balancers and API Gateway stages in a given region
+func NewWebACLSource(config aws.Config, accountID string, region string) *sources.GetListSource[*WebACLDetails, WAFv2Client, *wafv2.Options] {
+	return newWebACLSource(config, accountID, region, types.ScopeRegional, nil)
+}
+
+// NewCloudFrontWebACLSource Creates a source for web ACLs with the CLOUDFRONT
+// scope. Like the other CloudFront sources this is global, so it should only
+// be registered once
+func NewCloudFrontWebACLSource(config aws.Config, accountID string) *sources.GetListSource[*WebACLDetails, WAFv2Client, *wafv2.Options] {
+	return newWebACLSource(config, accountID, "", types.ScopeCloudfront, cloudfront.NewFromConfig(config))
+}
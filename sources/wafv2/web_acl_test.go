@@ -0,0 +1,229 @@
+package wafv2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/wafv2"
+	"github.com/aws/aws-sdk-go-v2/service/wafv2/types"
+	"github.com/overmindtech/aws-source/sources"
+	"github.com/overmindtech/sdp-go"
+)
+
+func (c testWAFv2Client) GetWebACL(ctx context.Context, params *wafv2.GetWebACLInput, optFns ...func(*wafv2.Options)) (*wafv2.GetWebACLOutput, error) {
+	scope := "regional"
+	region := "eu-west-1"
+
+	if params.Scope == types.ScopeCloudfront {
+		scope = "global"
+		region = "us-east-1"
+	}
+
+	return &wafv2.GetWebACLOutput{
+		LockToken: sources.PtrString("token"),
+		WebACL: &types.WebACL{
+			ARN:  sources.PtrString("arn:aws:wafv2:" + region + ":123456789012:" + scope + "/webacl/" + *params.Name + "/" + *params.Id),
+			Id:   params.Id,
+			Name: params.Name,
+			DefaultAction: &types.DefaultAction{
+				Allow: &types.AllowAction{},
+			},
+			Capacity: 50,
+			Rules: []types.Rule{
+				{
+					Name:     sources.PtrString("block-ips"),
+					Priority: 0,
+					Statement: &types.Statement{
+						IPSetReferenceStatement: &types.IPSetReferenceStatement{
+							ARN: sources.PtrString("arn:aws:wafv2:" + region + ":123456789012:" + scope + "/ipset/blocked/1111"), // link
+						},
+					},
+					VisibilityConfig: &types.VisibilityConfig{
+						CloudWatchMetricsEnabled: true,
+						MetricName:               sources.PtrString("block-ips"),
+						SampledRequestsEnabled:   true,
+					},
+				},
+				{
+					Name:     sources.PtrString("shared-rules"),
+					Priority: 1,
+					Statement: &types.Statement{
+						RuleGroupReferenceStatement: &types.RuleGroupReferenceStatement{
+							ARN: sources.PtrString("arn:aws:wafv2:" + region + ":123456789012:" + scope + "/rulegroup/shared/2222"), // link
+						},
+					},
+				},
+			},
+			VisibilityConfig: &types.VisibilityConfig{
+				CloudWatchMetricsEnabled: true,
+				MetricName:               params.Name,
+				SampledRequestsEnabled:   true,
+			},
+		},
+	}, nil
+}
+
+func (c testWAFv2Client) ListWebACLs(ctx context.Context, params *wafv2.ListWebACLsInput, optFns ...func(*wafv2.Options)) (*wafv2.ListWebACLsOutput, error) {
+	return &wafv2.ListWebACLsOutput{
+		WebACLs: []types.WebACLSummary{
+			{
+				Name: sources.PtrString("my-acl"),
+				Id:   sources.PtrString("a1b2c3d4"),
+			},
+		},
+	}, nil
+}
+
+func (c testWAFv2Client) ListResourcesForWebACL(ctx context.Context, params *wafv2.ListResourcesForWebACLInput, optFns ...func(*wafv2.Options)) (*wafv2.ListResourcesForWebACLOutput, error) {
+	switch params.ResourceType {
+	case types.ResourceTypeApplicationLoadBalancer:
+		return &wafv2.ListResourcesForWebACLOutput{
+			ResourceArns: []string{
+				"arn:aws:elasticloadbalancing:eu-west-1:123456789012:loadbalancer/app/my-alb/50dc6c495c0c9188", // link
+			},
+		}, nil
+	case types.ResourceTypeApiGateway:
+		return &wafv2.ListResourcesForWebACLOutput{
+			ResourceArns: []string{
+				"arn:aws:apigateway:eu-west-1::/restapis/a1b2c3/stages/prod", // link
+			},
+		}, nil
+	default:
+		return &wafv2.ListResourcesForWebACLOutput{}, nil
+	}
+}
+
+func TestWebACLGetFunc(t *testing.T) {
+	acl, err := webACLGetFunc(context.Background(), testWAFv2Client{}, nil, types.ScopeRegional, "my-acl/a1b2c3d4")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := webACLItemMapper("123456789012.eu-west-1", acl)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := item.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if item.UniqueAttributeValue() != "my-acl/a1b2c3d4" {
+		t.Errorf("expected unique attribute value my-acl/a1b2c3d4, got %v", item.UniqueAttributeValue())
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "wafv2-ip-set",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:wafv2:eu-west-1:123456789012:regional/ipset/blocked/1111",
+			ExpectedScope:  "123456789012.eu-west-1",
+		},
+		{
+			ExpectedType:   "wafv2-rule-group",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:wafv2:eu-west-1:123456789012:regional/rulegroup/shared/2222",
+			ExpectedScope:  "123456789012.eu-west-1",
+		},
+		{
+			ExpectedType:   "elbv2-load-balancer",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:elasticloadbalancing:eu-west-1:123456789012:loadbalancer/app/my-alb/50dc6c495c0c9188",
+			ExpectedScope:  "123456789012.eu-west-1",
+		},
+		{
+			ExpectedType:   "apigateway-stage",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:apigateway:eu-west-1::/restapis/a1b2c3/stages/prod",
+			ExpectedScope:  "123456789012.eu-west-1",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestCloudFrontWebACLGetFunc(t *testing.T) {
+	acl, err := webACLGetFunc(context.Background(), testWAFv2Client{}, testCloudFrontClient{}, types.ScopeCloudfront, "my-acl/a1b2c3d4")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := webACLItemMapper("123456789012", acl)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := item.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := sources.QueryTests{
+		{
+			ExpectedType:   "wafv2-ip-set",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:wafv2:us-east-1:123456789012:global/ipset/blocked/1111",
+			ExpectedScope:  "123456789012",
+		},
+		{
+			ExpectedType:   "wafv2-rule-group",
+			ExpectedMethod: sdp.QueryMethod_SEARCH,
+			ExpectedQuery:  "arn:aws:wafv2:us-east-1:123456789012:global/rulegroup/shared/2222",
+			ExpectedScope:  "123456789012",
+		},
+		{
+			ExpectedType:   "cloudfront-distribution",
+			ExpectedMethod: sdp.QueryMethod_GET,
+			ExpectedQuery:  "E2QWRUHAPOMQZL",
+			ExpectedScope:  "123456789012",
+		},
+	}
+
+	tests.Execute(t, item)
+}
+
+func TestWebACLListFunc(t *testing.T) {
+	acls, err := webACLListFunc(context.Background(), testWAFv2Client{}, nil, types.ScopeRegional)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(acls) != 1 {
+		t.Fatalf("expected 1 web ACL, got %v", len(acls))
+	}
+
+	if len(acls[0].AssociatedResources) != 2 {
+		t.Errorf("expected 2 associated resources, got %v", len(acls[0].AssociatedResources))
+	}
+}
+
+func TestNewWebACLSource(t *testing.T) {
+	config, account, region := sources.GetAutoConfig(t)
+
+	source := NewWebACLSource(config, account, region)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}
+
+func TestNewCloudFrontWebACLSource(t *testing.T) {
+	config, account, _ := sources.GetAutoConfig(t)
+
+	source := NewCloudFrontWebACLSource(config, account)
+
+	test := sources.E2ETest{
+		Source:  source,
+		Timeout: 10 * time.Second,
+	}
+
+	test.Run(t)
+}